@@ -0,0 +1,56 @@
+package main
+
+import (
+	"context"
+	"os/user"
+	"shop-api-go/internal/adapter/auth"
+	"shop-api-go/internal/adapter/config"
+	"shop-api-go/internal/adapter/handler/api"
+	"shop-api-go/internal/adapter/logger"
+	"shop-api-go/internal/adapter/storage/postgres"
+	"shop-api-go/internal/core/domain"
+	"shop-api-go/internal/core/service"
+
+	"github.com/gin-gonic/gin/binding"
+	"github.com/google/uuid"
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+)
+
+// populate creates the targets with the fx modules of the API, so commands apply the same business rules.
+// Only the dependencies of the targets are created and no lifecycle hooks are run.
+// The configuration is read from env variables and the CONFIG_FILE.
+func populate(targets ...any) error {
+	app := fx.New(
+		fx.Supply(&config.Source{}),
+		config.Module,
+		logger.Module,
+		postgres.Module,
+		auth.Module,
+		service.Module,
+		fx.Invoke(func(logger *zap.Logger) {
+			zap.ReplaceGlobals(logger)
+		}),
+		fx.Populate(targets...),
+		fx.NopLogger,
+	)
+	return app.Err()
+}
+
+// operatorContext returns a copy of ctx whose audit events name the admin CLI
+// and the OS user running it as user agent.
+func operatorContext(ctx context.Context) context.Context {
+	userAgent := "admin-cli"
+	if current, err := user.Current(); err == nil {
+		userAgent += " (" + current.Username + ")"
+	}
+	return domain.ContextWithRequestMetadata(ctx, domain.NewRequestMetadata("", userAgent, uuid.NewString()))
+}
+
+// validate checks a request struct with the binding rules of the REST API.
+func validate(obj any) error {
+	if err := api.RegisterValidations(); err != nil {
+		return err
+	}
+	return binding.Validator.ValidateStruct(obj)
+}
@@ -0,0 +1,52 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"shop-api-go/internal/adapter/config"
+	"text/tabwriter"
+)
+
+// configUsage describes the subcommands of config.
+const configUsage = `usage: admin config <subcommand> [-config file] [-set KEY=VALUE]...
+
+Subcommands:
+  validate  reports every invalid value of the configuration
+  print     prints the effective configuration and the layer of every key, with secrets redacted`
+
+// configCommand runs a subcommand of the configuration.
+func configCommand(_ context.Context, args []string) error {
+	if len(args) == 0 || (args[0] != "validate" && args[0] != "print") {
+		return errors.New(configUsage)
+	}
+
+	source, err := config.ParseFlags("config "+args[0], args[1:])
+	if err != nil {
+		return err
+	}
+	container, err := config.New(source)
+	if err != nil {
+		// Every invalid value is printed on its own line.
+		var joined interface{ Unwrap() []error }
+		if errors.As(err, &joined) {
+			for _, e := range joined.Unwrap() {
+				fmt.Fprintln(os.Stderr, e)
+			}
+			return errors.New("invalid config, see the errors above")
+		}
+		return err
+	}
+
+	if args[0] == "validate" {
+		fmt.Println("config is valid")
+		return nil
+	}
+
+	writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	for _, setting := range container.Settings() {
+		fmt.Fprintf(writer, "%s=%s\t# %s\n", setting.Key, setting.Value, setting.Layer)
+	}
+	return writer.Flush()
+}
@@ -0,0 +1,117 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"shop-api-go/internal/core/domain"
+	"shop-api-go/internal/core/port"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+	"golang.org/x/term"
+)
+
+// envPrefix is the prefix of the env variables setting flags, e.g. ADMIN_USERNAME sets --username.
+const envPrefix = "ADMIN_"
+
+// flagEnv returns the name of the env variable setting the flag.
+func flagEnv(name string) string {
+	return envPrefix + strings.ToUpper(strings.ReplaceAll(name, "-", "_"))
+}
+
+// stringFlag defines a string flag that defaults to its env variable if it is set.
+func stringFlag(flags *flag.FlagSet, name, fallback, usage string) *string {
+	if value, ok := os.LookupEnv(flagEnv(name)); ok {
+		fallback = value
+	}
+	return flags.String(name, fallback, fmt.Sprintf("%s (env %s)", usage, flagEnv(name)))
+}
+
+// intFlag defines an int flag that defaults to its env variable if it is a valid int.
+func intFlag(flags *flag.FlagSet, name string, fallback int, usage string) *int {
+	if value, err := strconv.Atoi(os.Getenv(flagEnv(name))); err == nil {
+		fallback = value
+	}
+	return flags.Int(name, fallback, fmt.Sprintf("%s (env %s)", usage, flagEnv(name)))
+}
+
+// boolFlag defines a bool flag that defaults to its env variable if it is a valid bool.
+func boolFlag(flags *flag.FlagSet, name string, fallback bool, usage string) *bool {
+	if value, err := strconv.ParseBool(os.Getenv(flagEnv(name))); err == nil {
+		fallback = value
+	}
+	return flags.Bool(name, fallback, fmt.Sprintf("%s (env %s)", usage, flagEnv(name)))
+}
+
+// parseFlags parses the flags of a command and checks that the required ones are set.
+func parseFlags(flags *flag.FlagSet, args []string, required ...string) error {
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() > 0 {
+		return fmt.Errorf("unexpected arguments: %s", strings.Join(flags.Args(), " "))
+	}
+	for _, name := range required {
+		if flags.Lookup(name).Value.String() == "" {
+			return fmt.Errorf("missing flag -%s or env %s", name, flagEnv(name))
+		}
+	}
+	return nil
+}
+
+// readPassword returns the password of the ADMIN_PASSWORD env variable, the first line of stdin
+// if fromStdin is set, or prompts for it twice without echoing it.
+func readPassword(fromStdin bool) (string, error) {
+	if password, ok := os.LookupEnv(flagEnv("password")); ok {
+		return password, nil
+	}
+
+	if fromStdin {
+		line, err := bufio.NewReader(os.Stdin).ReadString('\n')
+		if err != nil && !errors.Is(err, io.EOF) {
+			return "", fmt.Errorf("reading password: %w", err)
+		}
+		return strings.TrimRight(line, "\r\n"), nil
+	}
+
+	fd := int(os.Stdin.Fd())
+	if !term.IsTerminal(fd) {
+		return "", fmt.Errorf("stdin is not a terminal, use -password-stdin or %s", flagEnv("password"))
+	}
+
+	fmt.Fprint(os.Stderr, "Password: ")
+	password, err := term.ReadPassword(fd)
+	fmt.Fprintln(os.Stderr)
+	if err != nil {
+		return "", fmt.Errorf("reading password: %w", err)
+	}
+
+	fmt.Fprint(os.Stderr, "Repeat password: ")
+	repeated, err := term.ReadPassword(fd)
+	fmt.Fprintln(os.Stderr)
+	if err != nil {
+		return "", fmt.Errorf("reading password: %w", err)
+	}
+
+	if string(password) != string(repeated) {
+		return "", errors.New("passwords do not match")
+	}
+	return string(password), nil
+}
+
+// findUser finds a user by id, email or username.
+func findUser(ctx context.Context, userRepository port.UserRepository, user string) (*domain.User, error) {
+	if id, err := uuid.Parse(user); err == nil {
+		return userRepository.GetUserById(ctx, id)
+	}
+	if strings.Contains(user, "@") {
+		return userRepository.GetUserByEmail(ctx, user)
+	}
+	return userRepository.GetUserByUsername(ctx, user)
+}
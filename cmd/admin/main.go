@@ -0,0 +1,68 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"slices"
+
+	_ "github.com/lib/pq"
+)
+
+// command is a subcommand of the admin CLI.
+type command struct {
+	name        string
+	description string
+	run         func(ctx context.Context, args []string) error
+}
+
+// commands contains every subcommand of the admin CLI.
+var commands = []command{
+	{name: "create-user", description: "creates a user with any role", run: createUser},
+	{name: "reset-password", description: "sets a new password of a user and revokes their sessions", run: resetPassword},
+	{name: "set-role", description: "changes the role of a user and revokes their sessions", run: setRole},
+	{name: "revoke-sessions", description: "revokes every token of a user", run: revokeSessions},
+	{name: "list-users", description: "lists the users matching filters", run: listUsers},
+	{name: "migrate", description: "applies or reverts database migrations", run: migrate},
+	{name: "seed", description: "generates demo data and loads fixtures", run: seedCommand},
+	{name: "config", description: "validates the configuration", run: configCommand},
+}
+
+// usage prints the commands of the admin CLI.
+func usage() {
+	fmt.Fprint(os.Stderr, "Usage: admin <command> [flags]\n\nCommands:\n")
+	for _, c := range commands {
+		fmt.Fprintf(os.Stderr, "  %-16s %s\n", c.name, c.description)
+	}
+	fmt.Fprintf(
+		os.Stderr,
+		"\nRun admin <command> -h for its flags. Every flag can also be set with %s<FLAG>, e.g. %s.\n",
+		envPrefix,
+		flagEnv("username"),
+	)
+}
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+		os.Exit(2)
+	}
+
+	i := slices.IndexFunc(commands, func(c command) bool {
+		return c.name == os.Args[1]
+	})
+	if i == -1 {
+		usage()
+		os.Exit(2)
+	}
+
+	err := commands[i].run(context.Background(), os.Args[2:])
+	if errors.Is(err, flag.ErrHelp) {
+		return
+	} else if err != nil {
+		log.Fatalf("error running %s: %v", os.Args[1], err)
+	}
+}
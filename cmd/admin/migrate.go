@@ -0,0 +1,105 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"shop-api-go/internal/adapter/storage/postgres"
+	"strconv"
+)
+
+// migrateUsage describes the subcommands of migrate.
+const migrateUsage = `usage: admin migrate <subcommand> [argument]
+
+Subcommands:
+  up               applies all pending migrations
+  down [steps]     reverts the last steps migrations, 1 by default
+  to <version>     applies or reverts migrations until the version, 0 reverts all
+  status           prints the current version and the pending migrations
+  force <version>  sets the version without running migrations, after fixing a dirty database`
+
+// parseUint parses the argument of a migrate subcommand, fallback is used if it is missing and not nil.
+func parseUint(args []string, fallback *uint) (uint, error) {
+	if len(args) == 0 {
+		if fallback == nil {
+			return 0, fmt.Errorf("missing argument\n\n%s", migrateUsage)
+		}
+		return *fallback, nil
+	}
+	if len(args) > 1 {
+		return 0, fmt.Errorf("too many arguments\n\n%s", migrateUsage)
+	}
+
+	value, err := strconv.ParseUint(args[0], 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid argument: %s", args[0])
+	}
+	return uint(value), nil
+}
+
+// printMigrationStatus prints the current version and marks every applied migration.
+func printMigrationStatus(status *postgres.MigrationStatus) {
+	state := "clean"
+	if status.Dirty {
+		state = "dirty"
+	}
+	fmt.Printf("version: %d (%s)\n", status.Version, state)
+
+	for _, migration := range status.Migrations {
+		mark := " "
+		if migration.Version <= status.Version {
+			mark = "x"
+		}
+		fmt.Printf("[%s] %06d %s\n", mark, migration.Version, migration.Name)
+	}
+}
+
+// migrate runs a subcommand of the embedded migrations.
+func migrate(ctx context.Context, args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("missing subcommand\n\n%s", migrateUsage)
+	}
+
+	var db *sql.DB
+	if err := populate(&db); err != nil {
+		return err
+	}
+	migrator, err := postgres.NewMigrator(db)
+	if err != nil {
+		return err
+	}
+
+	subcommand, args := args[0], args[1:]
+	switch subcommand {
+	case "up":
+		return migrator.Up(ctx)
+	case "down":
+		defaultSteps := uint(1)
+		steps, err := parseUint(args, &defaultSteps)
+		if err != nil {
+			return err
+		}
+		return migrator.Down(ctx, int(steps))
+	case "to":
+		version, err := parseUint(args, nil)
+		if err != nil {
+			return err
+		}
+		return migrator.To(ctx, version)
+	case "force":
+		version, err := parseUint(args, nil)
+		if err != nil {
+			return err
+		}
+		return migrator.Force(ctx, version)
+	case "status":
+		status, err := migrator.Status(ctx)
+		if err != nil {
+			return err
+		}
+		printMigrationStatus(status)
+		return nil
+	default:
+		return fmt.Errorf("unknown subcommand: %s\n\n%s", subcommand, migrateUsage)
+	}
+}
@@ -0,0 +1,144 @@
+package main
+
+import (
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"flag"
+	"fmt"
+	"os"
+	"shop-api-go/internal/adapter/auth/password"
+	"shop-api-go/internal/adapter/config"
+	"shop-api-go/internal/adapter/handler/http/request"
+	"shop-api-go/internal/adapter/storage/postgres"
+	"shop-api-go/internal/adapter/storage/seed"
+	"shop-api-go/internal/core/port"
+	"strings"
+	"text/tabwriter"
+)
+
+// seedCommand generates demo data and loads fixtures into the database. Rows that exist already
+// are skipped, so running it again with the same flags inserts nothing.
+//
+// Note: Production databases are only seeded with -force. Generated users get a random password
+// that is printed, unless -user-password is set.
+func seedCommand(ctx context.Context, args []string) error {
+	flags := flag.NewFlagSet("seed", flag.ContinueOnError)
+	seedFlag := intFlag(flags, "seed", 1, "seed of the generated prices, ratings, counts and subcategories")
+	sections := intFlag(flags, "sections", 5, "number of generated category sections")
+	categories := intFlag(flags, "categories", 4, "number of generated categories per section")
+	subcategories := intFlag(flags, "subcategories", 3, "number of generated subcategories per category")
+	products := intFlag(flags, "products", 200, "number of generated products")
+	usersPerRole := intFlag(flags, "users-per-role", 5, "number of generated users of every role")
+	userPassword := stringFlag(flags, "user-password", "", "password of the generated users, random if empty")
+	generate := boolFlag(flags, "generate", true, "generate data, disable it to only load fixtures")
+	fixtures := stringFlag(flags, "fixtures", "", "comma separated YAML or JSON fixture files")
+	dryRun := boolFlag(flags, "dry-run", false, "only validate the data")
+	force := boolFlag(flags, "force", false, "seed even if ENVIRONMENT is production")
+	if err := parseFlags(flags, args); err != nil {
+		return err
+	}
+	if *seedFlag < 0 || *sections < 0 || *categories < 0 || *subcategories < 0 || *products < 0 || *usersPerRole < 0 {
+		return fmt.Errorf("seed and numbers of rows must be >= 0")
+	}
+
+	// The configuration is read without populate, so dry runs do not need a database.
+	container, err := config.New(&config.Source{})
+	if err != nil {
+		return err
+	}
+	if container.App.Environment == config.Production && !*force && !*dryRun {
+		return fmt.Errorf("refusing to seed a production database, use -force")
+	}
+
+	generatedPassword := ""
+	if *generate && *usersPerRole > 0 && *userPassword == "" {
+		generatedPassword = randomPassword()
+		*userPassword = generatedPassword
+	}
+
+	dataset := &seed.Dataset{}
+	if *generate {
+		dataset = seed.Generate(&seed.Options{
+			Seed:                     uint64(*seedFlag),
+			Sections:                 *sections,
+			CategoriesPerSection:     *categories,
+			SubcategoriesPerCategory: *subcategories,
+			Products:                 *products,
+			UsersPerRole:             *usersPerRole,
+			Password:                 *userPassword,
+		})
+	}
+	if *fixtures != "" {
+		for _, path := range strings.Split(*fixtures, ",") {
+			fixture, err := seed.LoadFixture(strings.TrimSpace(path))
+			if err != nil {
+				return err
+			}
+			dataset.Append(fixture)
+		}
+	}
+
+	// Users are inserted without the user service, so they are checked with the rules of a user import.
+	passwordPolicy := password.NewPolicy(container.Password)
+	for _, user := range dataset.Users {
+		err := validate(&request.ImportUser{
+			Email:    user.Email,
+			Username: user.Username,
+			Password: user.Password,
+			Role:     user.Role,
+		})
+		if err == nil {
+			err = passwordPolicy.Validate(user.Password, user.Username, user.Email)
+		}
+		if err != nil {
+			return fmt.Errorf("user %s: %w", user.Username, err)
+		}
+	}
+	if *dryRun {
+		fmt.Printf(
+			"%d sections, %d categories, %d subcategories, %d products and %d users are valid\n",
+			len(dataset.Sections),
+			len(dataset.Categories),
+			len(dataset.Subcategories),
+			len(dataset.Products),
+			len(dataset.Users),
+		)
+		return nil
+	}
+
+	var (
+		db     *sql.DB
+		hasher port.PasswordHasher
+	)
+	if err := populate(&db, &hasher); err != nil {
+		return err
+	}
+	if err := dataset.HashPasswords(hasher); err != nil {
+		return err
+	}
+
+	results, err := postgres.NewSeeder(db).Seed(ctx, dataset)
+	if err != nil {
+		return err
+	}
+
+	writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(writer, "TABLE\tROWS\tINSERTED")
+	for _, result := range results {
+		fmt.Fprintf(writer, "%s\t%d\t%d\n", result.Table, result.Rows, result.Inserted)
+	}
+	if err = writer.Flush(); err != nil {
+		return err
+	}
+	if generatedPassword != "" {
+		fmt.Printf("generated users have the password %s\n", generatedPassword)
+	}
+	return nil
+}
+
+// randomPassword returns a random password for the generated users. rand.Text only has upper case
+// letters and digits, so a lower case part and the separators satisfy the password rules.
+func randomPassword() string {
+	return rand.Text()[:12] + "-" + strings.ToLower(rand.Text()[:12]) + "-7"
+}
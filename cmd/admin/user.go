@@ -0,0 +1,213 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"shop-api-go/internal/adapter/handler/http/request"
+	"shop-api-go/internal/core/domain"
+	"shop-api-go/internal/core/port"
+	"text/tabwriter"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// createUser creates a user with any role, e.g. the first admin.
+func createUser(ctx context.Context, args []string) error {
+	flags := flag.NewFlagSet("create-user", flag.ContinueOnError)
+	username := stringFlag(flags, "username", "", "username of the user")
+	email := stringFlag(flags, "email", "", "email of the user")
+	role := stringFlag(flags, "role", string(domain.Client), "role of the user")
+	passwordStdin := boolFlag(flags, "password-stdin", false, "read the password from the first line of stdin")
+	if err := parseFlags(flags, args, "username", "email", "role"); err != nil {
+		return err
+	}
+
+	password, err := readPassword(*passwordStdin)
+	if err != nil {
+		return err
+	}
+	err = validate(&request.CreateUser{
+		Email:    *email,
+		Username: *username,
+		Password: password,
+		Role:     domain.UserRole(*role),
+	})
+	if err != nil {
+		return err
+	}
+
+	var adminService port.AdminService
+	if err = populate(&adminService); err != nil {
+		return err
+	}
+
+	user := domain.NewUser(uuid.Nil, *username, *email, password, domain.UserRole(*role), time.Time{}, time.Time{})
+	if err = adminService.CreateUser(operatorContext(ctx), domain.NewOperatorToken(), user); err != nil {
+		return err
+	}
+	fmt.Printf("created %s %s with id %s\n", user.Role, user.Username, user.Id)
+	return nil
+}
+
+// resetPassword sets a new password of a user, which also revokes their sessions.
+func resetPassword(ctx context.Context, args []string) error {
+	flags := flag.NewFlagSet("reset-password", flag.ContinueOnError)
+	userFlag := stringFlag(flags, "user", "", "id, email or username of the user")
+	passwordStdin := boolFlag(flags, "password-stdin", false, "read the password from the first line of stdin")
+	if err := parseFlags(flags, args, "user"); err != nil {
+		return err
+	}
+
+	password, err := readPassword(*passwordStdin)
+	if err != nil {
+		return err
+	}
+	if err = validate(&request.UpdateUser{Password: &password}); err != nil {
+		return err
+	}
+
+	return updateUser(ctx, *userFlag, func(update *domain.UserUpdate) {
+		update.Password = &password
+	})
+}
+
+// setRole changes the role of a user, which also revokes their sessions.
+func setRole(ctx context.Context, args []string) error {
+	flags := flag.NewFlagSet("set-role", flag.ContinueOnError)
+	userFlag := stringFlag(flags, "user", "", "id, email or username of the user")
+	role := stringFlag(flags, "role", "", "new role of the user")
+	if err := parseFlags(flags, args, "user", "role"); err != nil {
+		return err
+	}
+
+	newRole := domain.UserRole(*role)
+	if err := validate(&request.UpdateUser{Role: &newRole}); err != nil {
+		return err
+	}
+
+	return updateUser(ctx, *userFlag, func(update *domain.UserUpdate) {
+		update.Role = &newRole
+	})
+}
+
+// updateUser finds the user and updates the fields set by fn through the admin service.
+func updateUser(ctx context.Context, userFlag string, fn func(update *domain.UserUpdate)) error {
+	var (
+		adminService   port.AdminService
+		userRepository port.UserRepository
+	)
+	if err := populate(&adminService, &userRepository); err != nil {
+		return err
+	}
+
+	ctx = operatorContext(ctx)
+	user, err := findUser(ctx, userRepository, userFlag)
+	if err != nil {
+		return err
+	}
+
+	update := &domain.UserUpdate{Id: user.Id}
+	fn(update)
+	if err = adminService.UpdateUser(ctx, domain.NewOperatorToken(), update); err != nil {
+		return err
+	}
+	fmt.Printf("updated %s and revoked their sessions\n", user.Username)
+	return nil
+}
+
+// revokeSessions revokes every token of a user.
+func revokeSessions(ctx context.Context, args []string) error {
+	flags := flag.NewFlagSet("revoke-sessions", flag.ContinueOnError)
+	userFlag := stringFlag(flags, "user", "", "id, email or username of the user")
+	if err := parseFlags(flags, args, "user"); err != nil {
+		return err
+	}
+
+	var (
+		adminService   port.AdminService
+		userRepository port.UserRepository
+	)
+	if err := populate(&adminService, &userRepository); err != nil {
+		return err
+	}
+
+	ctx = operatorContext(ctx)
+	user, err := findUser(ctx, userRepository, *userFlag)
+	if err != nil {
+		return err
+	}
+	if err = adminService.RevokeUserSessions(ctx, domain.NewOperatorToken(), user.Id); err != nil {
+		return err
+	}
+	fmt.Printf("revoked the sessions of %s\n", user.Username)
+	return nil
+}
+
+// listUsers prints a page of the users matching the filters as a table.
+func listUsers(ctx context.Context, args []string) error {
+	flags := flag.NewFlagSet("list-users", flag.ContinueOnError)
+	username := stringFlag(flags, "username", "", "similar username")
+	email := stringFlag(flags, "email", "", "similar email")
+	role := stringFlag(flags, "role", "", "role of the users")
+	status := stringFlag(flags, "status", "", "status of the users")
+	sortBy := stringFlag(flags, "sort", string(domain.UserSortCreatedAt), "field to sort by")
+	descending := boolFlag(flags, "desc", false, "sort in descending order")
+	page := intFlag(flags, "page", 1, "page to list")
+	limit := intFlag(flags, "limit", 50, "users per page")
+	if err := parseFlags(flags, args); err != nil {
+		return err
+	}
+	if *page <= 0 || *limit <= 0 {
+		return fmt.Errorf("page and limit must be > 0")
+	}
+
+	var filter domain.UserFilter
+	if *username != "" {
+		filter.Username = username
+	}
+	if *email != "" {
+		filter.Email = email
+	}
+	if *role != "" {
+		userRole := domain.UserRole(*role)
+		filter.Role = &userRole
+	}
+	if *status != "" {
+		userStatus := domain.UserStatus(*status)
+		filter.Status = &userStatus
+	}
+
+	var adminService port.AdminService
+	if err := populate(&adminService); err != nil {
+		return err
+	}
+
+	get := domain.NewGetUsers(&filter, domain.UserSortField(*sortBy), *descending, page, nil, limit, true)
+	result, err := adminService.GetUsers(operatorContext(ctx), domain.NewOperatorToken(), get)
+	if err != nil {
+		return err
+	}
+
+	writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(writer, "ID\tUSERNAME\tEMAIL\tROLE\tSTATUS\tCREATED")
+	for _, user := range result.Users {
+		fmt.Fprintf(
+			writer,
+			"%s\t%s\t%s\t%s\t%s\t%s\n",
+			user.Id,
+			user.Username,
+			user.Email,
+			user.Role,
+			user.Status,
+			user.CreatedAt.Format(time.RFC3339),
+		)
+	}
+	if err = writer.Flush(); err != nil {
+		return err
+	}
+	fmt.Printf("page %d, %d of %d users\n", *page, len(result.Users), *result.Total)
+	return nil
+}
@@ -1,11 +1,21 @@
 package main
 
 import (
+	"errors"
+	"flag"
+	"log"
+	"os"
+	"shop-api-go/internal/adapter/archive"
 	"shop-api-go/internal/adapter/auth"
 	"shop-api-go/internal/adapter/config"
+	"shop-api-go/internal/adapter/handler/graphql"
+	"shop-api-go/internal/adapter/handler/grpc"
 	"shop-api-go/internal/adapter/handler/http"
 	"shop-api-go/internal/adapter/logger"
+	"shop-api-go/internal/adapter/publisher"
+	"shop-api-go/internal/adapter/settings"
 	"shop-api-go/internal/adapter/storage/postgres"
+	"shop-api-go/internal/adapter/webhook"
 	"shop-api-go/internal/core/service"
 	"shop-api-go/internal/core/task"
 
@@ -20,13 +30,27 @@ import (
 // @BasePath        /api/v1
 // @schemes         http
 func main() {
+	source, err := config.ParseFlags(os.Args[0], os.Args[1:])
+	if errors.Is(err, flag.ErrHelp) {
+		return
+	} else if err != nil {
+		log.Fatalf("error parsing flags: %v", err)
+	}
+
 	fx.New(
+		fx.Supply(source),
 		config.Module,
 		logger.Module,
 		postgres.Module,
 		auth.Module,
+		archive.Module,
+		webhook.Module,
+		publisher.Module,
+		settings.Module,
 		service.Module,
 		task.Module,
+		graphql.Module,
 		http.Module,
+		grpc.Module,
 	).Run()
 }
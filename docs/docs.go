@@ -15,21 +15,89 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/admin/users": {
+        "/admin/api-keys/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Revokes an API key or personal access token. Users can revoke their own keys, revoking keys of other users requires the api_keys:write permission.",
+                "tags": [
+                    "API keys"
+                ],
+                "summary": "Revoke API key",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer access token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "API key ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "API key revoked successfully",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid API key id",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Missing or invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Insufficient permissions or invalid token type(expected access token)",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "API key not found or already revoked",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/audit": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Retrieves user information with optional filters and pagination. Requires admin privileges and a valid JWT token in the Authorization header.",
+                "description": "Retrieves audit events, newest first, with optional filters and pagination. Requires the audit:read permission and a valid JWT token in the Authorization header.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "Admin"
                 ],
-                "summary": "Users information",
+                "summary": "Audit log",
                 "parameters": [
                     {
                         "type": "string",
@@ -40,52 +108,59 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "Filter by user ID (UUID)",
-                        "name": "id",
+                        "description": "Filter by actor user ID (UUID)",
+                        "name": "actorId",
                         "in": "query"
                     },
                     {
                         "type": "string",
-                        "description": "Filter by username",
-                        "name": "username",
+                        "description": "Filter by action (e.g. 'auth.login_failed')",
+                        "name": "action",
                         "in": "query"
                     },
                     {
                         "type": "string",
-                        "description": "Filter by email address",
-                        "name": "email",
+                        "description": "Filter by target type (e.g. 'user', 'role')",
+                        "name": "targetType",
                         "in": "query"
                     },
                     {
                         "type": "string",
-                        "description": "Filter by user role (e.g. 'admin', 'user')",
-                        "name": "role",
+                        "description": "Filter by target ID",
+                        "name": "targetId",
                         "in": "query"
                     },
                     {
-                        "type": "integer",
-                        "description": "Page number for pagination (min=1)",
-                        "name": "page",
+                        "type": "string",
+                        "description": "Only events created at or after this RFC 3339 time",
+                        "name": "from",
                         "in": "query"
                     },
                     {
                         "type": "string",
-                        "description": "Base64-encoded timestamp cursor for pagination",
-                        "name": "cursor",
+                        "description": "Only events created before this RFC 3339 time",
+                        "name": "to",
                         "in": "query"
                     },
                     {
                         "type": "integer",
-                        "description": "Maximum number of users to return (min=1)",
-                        "name": "limit",
+                        "description": "Page number for pagination (min=1)",
+                        "name": "page",
                         "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum number of events to return (min=1, max=100)",
+                        "name": "limit",
+                        "in": "query",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "List of users",
+                        "description": "List of audit events",
                         "schema": {
-                            "$ref": "#/definitions/response.FetchingUsersResponse"
+                            "$ref": "#/definitions/response.AuditEventsResponse"
                         }
                     },
                     "400": {
@@ -115,18 +190,21 @@ const docTemplate = `{
                 }
             }
         },
-        "/admin/users/update/{id}": {
-            "patch": {
+        "/admin/data-requests/{id}": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Allows an admin to update a user's information, including username, email, password, and role. Requires a valid admin JWT token.",
+                "description": "Retrieves the status of a data export or erasure request. Requires the users:privacy permission and a valid JWT token in the Authorization header.",
+                "produces": [
+                    "application/json"
+                ],
                 "tags": [
                     "Admin"
                 ],
-                "summary": "Update user by admin",
+                "summary": "Data request status",
                 "parameters": [
                     {
                         "type": "string",
@@ -137,30 +215,21 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "User ID (UUID) to update",
+                        "description": "Data request ID (UUID)",
                         "name": "id",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "description": "Fields to update for the user",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/request.UpdateUser"
-                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "User updated successfully",
+                        "description": "Data request",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/response.DataRequestResponse"
                         }
                     },
                     "400": {
-                        "description": "Invalid request payload or parameters",
+                        "description": "Invalid data request id",
                         "schema": {
                             "$ref": "#/definitions/response.ErrorResponse"
                         }
@@ -178,7 +247,7 @@ const docTemplate = `{
                         }
                     },
                     "404": {
-                        "description": "User not found",
+                        "description": "Data request not found",
                         "schema": {
                             "$ref": "#/definitions/response.ErrorResponse"
                         }
@@ -192,45 +261,76 @@ const docTemplate = `{
                 }
             }
         },
-        "/auth/login": {
-            "post": {
-                "description": "Authenticates a user using their username and password, returning a new pair of access and refresh tokens upon successful login.",
-                "consumes": [
-                    "application/json"
+        "/admin/data-requests/{id}/archive": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
                 ],
+                "description": "Downloads the ZIP archive of a completed data export, with a JSON file per kind of data. Archives are deleted 7 days after completion and when the user is erased. Every download is recorded in the audit log. Requires the users:privacy permission and a valid JWT token in the Authorization header.",
                 "produces": [
-                    "application/json"
+                    "application/zip"
                 ],
                 "tags": [
-                    "Auth"
+                    "Admin"
                 ],
-                "summary": "Authenticate user",
+                "summary": "Download user data export",
                 "parameters": [
                     {
-                        "description": "Login credentials (username and password)",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/request.LoginRequest"
-                        }
+                        "type": "string",
+                        "description": "Bearer access token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Data request ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Login successful — returns new access and refresh tokens",
+                        "description": "ZIP archive",
                         "schema": {
-                            "$ref": "#/definitions/response.TokensResponse"
+                            "type": "file"
                         }
                     },
                     "400": {
-                        "description": "Invalid request payload or missing fields",
+                        "description": "Invalid data request id",
                         "schema": {
                             "$ref": "#/definitions/response.ErrorResponse"
                         }
                     },
                     "401": {
-                        "description": "Invalid credentials",
+                        "description": "Unauthorized – invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden – insufficient permissions or invalid token type(expected access token)",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Data request not found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Data request is not a completed export",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "410": {
+                        "description": "Archive expired or erased",
                         "schema": {
                             "$ref": "#/definitions/response.ErrorResponse"
                         }
@@ -244,25 +344,25 @@ const docTemplate = `{
                 }
             }
         },
-        "/auth/refresh-session": {
-            "post": {
+        "/admin/mfa/roles": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Refreshes the user's authentication session using a valid **refresh token** provided in the Authorization header. Returns a new access/refresh token pair.",
+                "description": "Retrieves whether MFA is required for each user role. Requires admin privileges and a valid JWT token in the Authorization header.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Auth"
+                    "Admin"
                 ],
-                "summary": "Refresh access token",
+                "summary": "MFA requirements",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Bearer refresh token (format: Bearer \u003ctoken\u003e)",
+                        "description": "Bearer access token",
                         "name": "Authorization",
                         "in": "header",
                         "required": true
@@ -270,19 +370,19 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "Session refreshed successfully — returns new access and refresh tokens",
+                        "description": "MFA requirement of every role",
                         "schema": {
-                            "$ref": "#/definitions/response.TokensResponse"
+                            "$ref": "#/definitions/response.RoleMFARequirementsResponse"
                         }
                     },
                     "401": {
-                        "description": "Missing, malformed, or invalid token",
+                        "description": "Missing or invalid JWT token",
                         "schema": {
                             "$ref": "#/definitions/response.ErrorResponse"
                         }
                     },
                     "403": {
-                        "description": "Invalid token type (expected refresh token)",
+                        "description": "Insufficient permissions or invalid token type(expected access token)",
                         "schema": {
                             "$ref": "#/definitions/response.ErrorResponse"
                         }
@@ -296,47 +396,67 @@ const docTemplate = `{
                 }
             }
         },
-        "/users/me/update": {
-            "patch": {
+        "/admin/mfa/roles/{role}": {
+            "put": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Updates a user's account. Requires current username and password for authentication. Optional fields include new username, new email, and new password.",
+                "description": "Sets whether users with a specific role must use MFA to log in. Requires admin privileges and a valid JWT token in the Authorization header.",
                 "consumes": [
                     "application/json"
                 ],
-                "produces": [
-                    "application/json"
-                ],
                 "tags": [
-                    "Users"
+                    "Admin"
                 ],
-                "summary": "Update user account",
+                "summary": "Set MFA requirement",
                 "parameters": [
                     {
-                        "description": "Update account payload",
+                        "type": "string",
+                        "description": "Bearer access token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "User role (e.g. 'admin', 'client')",
+                        "name": "role",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "MFA requirement",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/request.UpdateAccountRequest"
+                            "$ref": "#/definitions/request.SetMFARequirementRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Account updated successfully"
+                        "description": "MFA requirement updated successfully",
+                        "schema": {
+                            "type": "string"
+                        }
                     },
                     "400": {
-                        "description": "Invalid request payload or missing fields",
+                        "description": "Invalid request payload or parameters",
                         "schema": {
                             "$ref": "#/definitions/response.ErrorResponse"
                         }
                     },
                     "401": {
-                        "description": "Unauthorized - invalid credentials",
+                        "description": "Unauthorized – invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden – insufficient permissions or invalid token type(expected access token)",
                         "schema": {
                             "$ref": "#/definitions/response.ErrorResponse"
                         }
@@ -350,42 +470,45 @@ const docTemplate = `{
                 }
             }
         },
-        "/users/register": {
-            "post": {
-                "description": "Registers a new user in the system using email, username, and password. Returns HTTP 201 on success.",
-                "consumes": [
-                    "application/json"
+        "/admin/permissions": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
                 ],
+                "description": "Retrieves all permissions that can be granted to roles. Requires the roles:read permission and a valid JWT token in the Authorization header.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Users"
+                    "Admin"
                 ],
-                "summary": "Register a new user",
+                "summary": "Permissions",
                 "parameters": [
                     {
-                        "description": "Registration details",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/request.RegisterRequest"
-                        }
+                        "type": "string",
+                        "description": "Bearer access token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "User created successfully"
+                    "200": {
+                        "description": "List of permissions",
+                        "schema": {
+                            "$ref": "#/definitions/response.PermissionsResponse"
+                        }
                     },
-                    "400": {
-                        "description": "Invalid request payload or missing fields",
+                    "401": {
+                        "description": "Missing or invalid JWT token",
                         "schema": {
                             "$ref": "#/definitions/response.ErrorResponse"
                         }
                     },
-                    "409": {
-                        "description": "Email or username already exists",
+                    "403": {
+                        "description": "Insufficient permissions or invalid token type(expected access token)",
                         "schema": {
                             "$ref": "#/definitions/response.ErrorResponse"
                         }
@@ -398,111 +521,4391 @@ const docTemplate = `{
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "domain.UserRole": {
-            "type": "string",
-            "enum": [
-                "admin",
-                "client",
+        },
+        "/admin/roles": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieves all roles with the permissions granted to them. Requires the roles:read permission and a valid JWT token in the Authorization header.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Roles",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer access token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "List of roles",
+                        "schema": {
+                            "$ref": "#/definitions/response.RolesResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Missing or invalid JWT token",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Insufficient permissions or invalid token type(expected access token)",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Creates a custom role with the given permissions. Requires the roles:write permission and a valid JWT token in the Authorization header.",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Create role",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer access token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Role to create",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.CreateRoleRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Role created successfully",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request payload or unknown permission",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized – invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden – insufficient permissions, invalid token type(expected access token) or role with permissions the caller lacks",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Role already exists",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/roles/{role}": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Updates the description of a custom role and replaces its permissions. Requires the roles:write permission and a valid JWT token in the Authorization header.",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Update role",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer access token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Role name (e.g. 'warehouse')",
+                        "name": "role",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Fields to update",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.UpdateRoleRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Role updated successfully",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request payload or unknown permission",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized – invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden – insufficient permissions, invalid token type(expected access token) or role with permissions the caller lacks",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Role not found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Role is built-in or update would revoke roles:write from the caller's own role",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Deletes a custom role that is not assigned to any user. Requires the roles:write permission and a valid JWT token in the Authorization header.",
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Delete role",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer access token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Role name (e.g. 'support')",
+                        "name": "role",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Role deleted successfully",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid role name",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized – invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden – insufficient permissions, invalid token type(expected access token) or role with permissions the caller lacks",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Role not found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Role is built-in or assigned to users",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/settings": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieves the settings that can be changed without a restart: the log level, rate limits, token expire times and feature flags. Requires the settings:read permission and a valid JWT token in the Authorization header.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Runtime settings",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer access token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Current runtime settings",
+                        "schema": {
+                            "$ref": "#/definitions/response.RuntimeSettingsResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Missing or invalid JWT token",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Insufficient permissions or invalid token type(expected access token)",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Changes the log level, rate limits, token expire times or feature flags without a restart. The change is validated, audited, written to the runtime settings file and applied right away, token expire times apply to tokens issued afterwards. Requires the settings:write permission and a valid JWT token in the Authorization header.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Update runtime settings",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer access token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Settings to change",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.UpdateRuntimeSettingsRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Updated runtime settings",
+                        "schema": {
+                            "$ref": "#/definitions/response.RuntimeSettingsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request payload, invalid settings or no fields to update",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized – invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden – insufficient permissions or invalid token type(expected access token)",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/users": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieves users matching all given filters, sorted by any field, with page or cursor pagination. Username and email are fuzzy matched. Without page, the response contains a cursor for the next page unless there are no more users; the cursor is only valid for the same sort and order. Requires admin privileges and a valid JWT token in the Authorization header.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Users information",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer access token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by user ID (UUID)",
+                        "name": "id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by similar username",
+                        "name": "username",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by similar email address",
+                        "name": "email",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by user role (e.g. 'admin', 'client')",
+                        "name": "role",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by status ('active' or 'suspended')",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only users created at or after this RFC 3339 time",
+                        "name": "createdFrom",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only users created before this RFC 3339 time",
+                        "name": "createdTo",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only users updated at or after this RFC 3339 time",
+                        "name": "updatedFrom",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only users updated before this RFC 3339 time",
+                        "name": "updatedTo",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sort field: id, username, email, role, status, created_at (default) or updated_at",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sort order: asc (default) or desc",
+                        "name": "order",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page number for offset pagination (min=1), cannot be combined with cursor",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor returned by the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum number of users to return (min=1, max=100), required unless filtering by id",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Include the total number of matching users",
+                        "name": "total",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "List of users",
+                        "schema": {
+                            "$ref": "#/definitions/response.FetchingUsersResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid query parameters",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Missing or invalid JWT token",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Insufficient permissions or invalid token type(expected access token)",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Creates a user with a specific role. The password is screened against the password policy. Requires admin privileges and a valid JWT token in the Authorization header.",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Create user",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer access token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "User details",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.CreateUser"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "User created successfully",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request payload or weak password",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized – invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden – insufficient permissions or invalid token type(expected access token)",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Role not found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Email or username already exists",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/users/export": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Streams all users matching the filters as CSV or NDJSON, ordered by creation time. Passwords are never exported. Requires admin privileges and a valid JWT token in the Authorization header.",
+                "produces": [
+                    "text/csv",
+                    "application/x-ndjson"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Export users",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer access token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by user ID (UUID)",
+                        "name": "id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by similar username",
+                        "name": "username",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by similar email address",
+                        "name": "email",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by user role (e.g. 'admin', 'client')",
+                        "name": "role",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by status ('active' or 'suspended')",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only users created at or after this RFC 3339 time",
+                        "name": "createdFrom",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only users created before this RFC 3339 time",
+                        "name": "createdTo",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only users updated at or after this RFC 3339 time",
+                        "name": "updatedFrom",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only users updated before this RFC 3339 time",
+                        "name": "updatedTo",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Export format, 'csv' (default) or 'ndjson'",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Exported users",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid query parameters",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Missing or invalid JWT token",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Insufficient permissions or invalid token type(expected access token)",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/users/import": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Creates users from a CSV file with an email,username,password,role header or an NDJSON file with a user object per line. Every user is validated with the same rules as creating a single user and either all users are imported in one transaction or none. With dryRun the file is only validated. Requires admin privileges and a valid JWT token in the Authorization header.",
+                "consumes": [
+                    "text/csv",
+                    "application/x-ndjson"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Import users",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer access token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Only validate the file",
+                        "name": "dryRun",
+                        "in": "query"
+                    },
+                    {
+                        "description": "CSV or NDJSON users",
+                        "name": "file",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Users are valid (dry run)",
+                        "schema": {
+                            "$ref": "#/definitions/response.ImportUsersResponse"
+                        }
+                    },
+                    "201": {
+                        "description": "Users imported successfully",
+                        "schema": {
+                            "$ref": "#/definitions/response.ImportUsersResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid users with the rejected lines",
+                        "schema": {
+                            "$ref": "#/definitions/response.ImportErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized – invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden – insufficient permissions or invalid token type(expected access token)",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "413": {
+                        "description": "Import file contains too many users",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "415": {
+                        "description": "Unsupported content type",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/users/update/{id}": {
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Allows an admin to update a user's information, including username, email, password, and role. Requires a valid admin JWT token.",
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Update user by admin",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer access token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "User ID (UUID) to update",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Fields to update for the user",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.UpdateUser"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "User updated successfully",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request payload or parameters",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized – invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden – insufficient permissions or invalid token type(expected access token)",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/users/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Soft-deletes a user and revokes his tokens. The user can be restored later. Requires admin privileges and a valid JWT token in the Authorization header.",
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Delete user",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer access token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "User ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "User deleted successfully",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid user id",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized – invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden – insufficient permissions, invalid token type(expected access token) or user with permissions the admin lacks",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Cannot target your own account",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/users/{id}/api-keys": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieves the API keys and personal access tokens of a user without their secrets. Requires the api_keys:read permission and a valid JWT token in the Authorization header.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "User API keys",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer access token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "User ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "List of API keys",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIKeysResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid user id",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Missing or invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Insufficient permissions or invalid token type(expected access token)",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Creates a scoped API key for an integration acting as the user. The secret is returned only once. Requires the api_keys:write permission and a valid JWT access token in the Authorization header.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Create service API key",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer access token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "User ID (UUID) the key acts as",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "API key to create",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.CreateAPIKeyRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created API key with its secret",
+                        "schema": {
+                            "$ref": "#/definitions/response.CreatedAPIKeyResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request payload, expiry or unknown scope",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Missing or invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Insufficient permissions or invalid token type(expected JWT access token)",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/users/{id}/data-export": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Queues the export of all data stored about a user: profile, sessions, MFA settings, linked identities, API keys and audit entries. The archive is compiled in the background, poll the returned request until it is completed. Requires the users:privacy permission and a valid JWT token in the Authorization header.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Request user data export",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer access token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "User ID (UUID) to export",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Export queued",
+                        "schema": {
+                            "$ref": "#/definitions/response.DataRequestResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid user id",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized – invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden – insufficient permissions or invalid token type(expected access token)",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "User data has been erased",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/users/{id}/erasure": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Queues the erasure of the personal data of a user. Username, email and password are anonymised, sessions, MFA, linked identities, API keys and export archives are deleted, and the user is soft-deleted for good. The user record is kept so that records referencing it stay intact, the audit log is append-only and kept. Requires the users:privacy permission and a valid JWT token in the Authorization header.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Request user data erasure",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer access token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "User ID (UUID) to erase",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Erasure queued",
+                        "schema": {
+                            "$ref": "#/definitions/response.DataRequestResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid user id",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized – invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden – insufficient permissions or invalid token type(expected access token)",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "User data already erased or own account",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/users/{id}/impersonate": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Issues a short-lived access token for a user that names the admin as actor. The token cannot be refreshed, cannot change users, roles or API keys, and every request made with it is recorded in the audit log. Only users whose role grants a part of the admin's permissions can be impersonated. Requires admin privileges and a valid JWT token in the Authorization header.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Impersonate user",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer access token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "User ID (UUID) to impersonate",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Impersonation token issued",
+                        "schema": {
+                            "$ref": "#/definitions/response.ImpersonationTokenResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid user id",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized – invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden – insufficient permissions, invalid token type(expected access token), impersonation token, suspended user or user with a role not below the admin's",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/users/{id}/reactivate": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Reactivates a suspended user. Requires admin privileges and a valid JWT token in the Authorization header.",
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Reactivate user",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer access token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "User ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "User reactivated successfully",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid user id",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized – invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden – insufficient permissions, invalid token type(expected access token) or user with permissions the admin lacks",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Cannot target your own account",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/users/{id}/restore": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Restores a soft-deleted user. Requires admin privileges and a valid JWT token in the Authorization header.",
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Restore user",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer access token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "User ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "User restored successfully",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid user id",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized – invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden – insufficient permissions, invalid token type(expected access token) or user with permissions the admin lacks",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "User is not deleted",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/users/{id}/suspend": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Suspends a user so he can no longer log in or refresh his session. Issued access tokens stay valid until they expire. Requires admin privileges and a valid JWT token in the Authorization header.",
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Suspend user",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer access token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "User ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "User suspended successfully",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid user id",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized – invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden – insufficient permissions, invalid token type(expected access token) or user with permissions the admin lacks",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Cannot target your own account",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/users/{id}/unlock": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Clears the failed login attempts of a user so he can log in again immediately. Requires admin privileges and a valid JWT token in the Authorization header.",
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Unlock user",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer access token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "User ID (UUID) to unlock",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "User unlocked successfully",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid user id",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized – invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden – insufficient permissions, invalid token type(expected access token) or user with permissions the admin lacks",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/webhook-deliveries/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieves a webhook delivery with the payload of its event and the history of its attempts. Requires the webhooks:read permission and a valid JWT token in the Authorization header.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Webhook delivery",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer access token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Webhook delivery ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Webhook delivery",
+                        "schema": {
+                            "$ref": "#/definitions/response.WebhookDeliveryResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid webhook delivery id",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized – invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden – insufficient permissions or invalid token type(expected access token)",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Webhook delivery not found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/webhook-deliveries/{id}/replay": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Queues a webhook delivery to be sent again right away with a fresh set of attempts, e.g. a dead delivery after the partner fixed their endpoint. Requires the webhooks:write permission and a valid JWT token in the Authorization header.",
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Replay webhook delivery",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer access token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Webhook delivery ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Webhook delivery queued",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid webhook delivery id",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized – invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden – insufficient permissions or invalid token type(expected access token)",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Webhook delivery not found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/webhooks": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieves all webhook endpoints without their secrets. Requires the webhooks:read permission and a valid JWT token in the Authorization header.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Webhook endpoints",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer access token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "List of webhook endpoints",
+                        "schema": {
+                            "$ref": "#/definitions/response.WebhookEndpointsResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Missing or invalid JWT token",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Insufficient permissions or invalid token type(expected access token)",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Creates a webhook endpoint receiving the given event types, or every event if none is given. The response contains the secret used to sign the deliveries, it is only shown once. Requires the webhooks:write permission and a valid JWT token in the Authorization header.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Create webhook endpoint",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer access token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Webhook endpoint to create",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.CreateWebhookEndpointRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created webhook endpoint with its secret",
+                        "schema": {
+                            "$ref": "#/definitions/response.WebhookSecretResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request payload, unknown event type or URL without https or with a private address",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized – invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden – insufficient permissions or invalid token type(expected access token)",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/webhooks/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieves a webhook endpoint without its secret. Requires the webhooks:read permission and a valid JWT token in the Authorization header.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Webhook endpoint",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer access token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Webhook endpoint ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Webhook endpoint",
+                        "schema": {
+                            "$ref": "#/definitions/response.WebhookEndpointResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid webhook endpoint id",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized – invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden – insufficient permissions or invalid token type(expected access token)",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Webhook endpoint not found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Deletes a webhook endpoint with its deliveries. Requires the webhooks:write permission and a valid JWT token in the Authorization header.",
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Delete webhook endpoint",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer access token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Webhook endpoint ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Webhook endpoint deleted successfully",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid webhook endpoint id",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized – invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden – insufficient permissions or invalid token type(expected access token)",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Webhook endpoint not found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Updates the url, description, event types or enabled flag of a webhook endpoint. Deliveries of a disabled endpoint are kept and sent once it is enabled again. Requires the webhooks:write permission and a valid JWT token in the Authorization header.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Update webhook endpoint",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer access token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Webhook endpoint ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Fields to update",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.UpdateWebhookEndpointRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Updated webhook endpoint",
+                        "schema": {
+                            "$ref": "#/definitions/response.WebhookEndpointResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request payload, unknown event type, URL without https or with a private address or no fields to update",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized – invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden – insufficient permissions or invalid token type(expected access token)",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Webhook endpoint not found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/webhooks/{id}/deliveries": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieves the deliveries of a webhook endpoint, newest first, with an optional status filter and pagination. Requires the webhooks:read permission and a valid JWT token in the Authorization header.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Webhook deliveries",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer access token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Webhook endpoint ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by status ('pending', 'succeeded' or 'dead')",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page number for pagination (min=1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum number of deliveries to return (min=1, max=100)",
+                        "name": "limit",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "List of webhook deliveries",
+                        "schema": {
+                            "$ref": "#/definitions/response.WebhookDeliveriesResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid webhook endpoint id or query parameters",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized – invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden – insufficient permissions or invalid token type(expected access token)",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Webhook endpoint not found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/webhooks/{id}/secret": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Replaces the secret used to sign the deliveries of a webhook endpoint, deliveries are signed with the new secret right away. The response contains the new secret, it is only shown once. Requires the webhooks:write permission and a valid JWT token in the Authorization header.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Rotate webhook secret",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer access token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Webhook endpoint ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Webhook endpoint with its new secret",
+                        "schema": {
+                            "$ref": "#/definitions/response.WebhookSecretResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid webhook endpoint id",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized – invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden – insufficient permissions or invalid token type(expected access token)",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Webhook endpoint not found",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/login": {
+            "post": {
+                "description": "Authenticates a user using their username and password, returning a new pair of access and refresh tokens upon successful login. If the user has MFA enabled, a short-lived MFA token is returned instead and must be exchanged at /auth/mfa/verify.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "Authenticate user",
+                "parameters": [
+                    {
+                        "description": "Login credentials (username and password)",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.LoginRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Login successful — returns new access and refresh tokens",
+                        "schema": {
+                            "$ref": "#/definitions/response.TokensResponse"
+                        }
+                    },
+                    "202": {
+                        "description": "Credentials valid — MFA verification required",
+                        "schema": {
+                            "$ref": "#/definitions/response.MFAChallengeResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request payload or missing fields",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Invalid credentials",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "The user's role requires MFA but it is not enabled",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Too many failed login attempts",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/mfa/verify": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Finishes a login of a user with MFA enabled. Exchanges the **MFA token** returned by /auth/login together with a TOTP or recovery code for a new access/refresh token pair.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "Verify MFA code",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer MFA token (format: Bearer \u003ctoken\u003e)",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "TOTP or recovery code",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.VerifyMFARequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "MFA verified — returns new access and refresh tokens",
+                        "schema": {
+                            "$ref": "#/definitions/response.TokensResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request payload or missing fields",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Missing, malformed, or invalid token or code",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Invalid token type (expected MFA token)",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Too many failed verification attempts",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/oauth/{provider}/callback": {
+            "get": {
+                "description": "Finishes a login started at /auth/oauth/{provider}/login or a link started at /auth/oauth/{provider}/link. A linked identity logs in its user. An unlinked identity is linked to the user who started the link, to an existing user without permissions and MFA with the same verified email, or a new client user is created. Returns a new pair of access and refresh tokens, or an MFA token if the user has MFA enabled.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "Finish login with an identity provider",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Identity provider name",
+                        "name": "provider",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "State returned by the identity provider",
+                        "name": "state",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Authorization code returned by the identity provider",
+                        "name": "code",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Login successful — returns new access and refresh tokens",
+                        "schema": {
+                            "$ref": "#/definitions/response.TokensResponse"
+                        }
+                    },
+                    "202": {
+                        "description": "Identity verified — MFA verification required",
+                        "schema": {
+                            "$ref": "#/definitions/response.MFAChallengeResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Missing parameters or invalid, expired or reused state",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "The identity provider did not authenticate the user",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "The identity has no verified email or the user's role requires MFA but it is not enabled",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Identity provider not configured",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "A privileged user or one with MFA enabled has the email, the identity is linked to another user or no free username could be generated",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/oauth/{provider}/link": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Starts linking an identity of a configured identity provider to the logged in user and returns the url of its consent page. The link is finished at /auth/oauth/{provider}/callback. Users with permissions or MFA enabled can only link identities this way.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "Link an identity provider",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer access token (format: Bearer \u003ctoken\u003e)",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Identity provider name",
+                        "name": "provider",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Url of the identity provider",
+                        "schema": {
+                            "$ref": "#/definitions/response.OAuthLinkResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Missing, malformed, or invalid access token",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Invalid token type (expected access token of the user)",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Identity provider not configured",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/oauth/{provider}/login": {
+            "get": {
+                "description": "Starts an OAuth 2.0 / OpenID Connect login with a configured identity provider (e.g. google, github) and redirects the user to its consent page. The login is protected with state, nonce and PKCE.",
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "Start login with an identity provider",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Identity provider name",
+                        "name": "provider",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "302": {
+                        "description": "Redirect to the identity provider"
+                    },
+                    "404": {
+                        "description": "Identity provider not configured",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/refresh-session": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Refreshes the user's authentication session using a valid **refresh token** provided in the Authorization header. Returns a new access/refresh token pair.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "Refresh access token",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer refresh token (format: Bearer \u003ctoken\u003e)",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Session refreshed successfully — returns new access and refresh tokens",
+                        "schema": {
+                            "$ref": "#/definitions/response.TokensResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Missing, malformed, or invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Invalid token type (expected refresh token)",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/me/api-keys": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieves the API keys and personal access tokens of the authenticated user without their secrets.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "API keys"
+                ],
+                "summary": "Own API keys",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer access token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "List of API keys",
+                        "schema": {
+                            "$ref": "#/definitions/response.APIKeysResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Missing or invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Invalid token type(expected access token)",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Creates a scoped personal access token for the authenticated user. The secret is returned only once. Requires a valid JWT access token in the Authorization header.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "API keys"
+                ],
+                "summary": "Create personal access token",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer access token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Personal access token to create",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.CreateAPIKeyRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created personal access token with its secret",
+                        "schema": {
+                            "$ref": "#/definitions/response.CreatedAPIKeyResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request payload, expiry or unknown scope",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Missing or invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Invalid token type(expected JWT access token)",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/me/api-keys/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Revokes an API key or personal access token. Users can revoke their own keys, revoking keys of other users requires the api_keys:write permission.",
+                "tags": [
+                    "API keys"
+                ],
+                "summary": "Revoke API key",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer access token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "API key ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "API key revoked successfully",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid API key id",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Missing or invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Insufficient permissions or invalid token type(expected access token)",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "API key not found or already revoked",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/me/mfa/confirm": {
+            "post": {
+                "description": "Enables MFA after validating a TOTP code generated from the enrolled secret. Returns one-time recovery codes that are shown only once.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Confirm MFA enrolment",
+                "parameters": [
+                    {
+                        "description": "User credentials and TOTP code",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.MFACodeRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "MFA enabled — returns recovery codes",
+                        "schema": {
+                            "$ref": "#/definitions/response.RecoveryCodesResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request payload or MFA not enrolled",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Invalid credentials or code",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "MFA is already enabled",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/me/mfa/disable": {
+            "post": {
+                "description": "Disables MFA after validating a TOTP code and deletes all recovery codes.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Disable MFA",
+                "parameters": [
+                    {
+                        "description": "User credentials and TOTP code",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.MFACodeRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "MFA disabled successfully"
+                    },
+                    "400": {
+                        "description": "Invalid request payload or MFA not enrolled",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Invalid credentials or code",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/me/mfa/enroll": {
+            "post": {
+                "description": "Generates a new TOTP secret for the user and returns it together with an otpauth:// provisioning URI that can be rendered as a QR code. MFA is not enabled until the enrolment is confirmed.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Start MFA enrolment",
+                "parameters": [
+                    {
+                        "description": "User credentials",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.MFAEnrollRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "TOTP secret and provisioning URI",
+                        "schema": {
+                            "$ref": "#/definitions/response.MFAEnrollmentResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request payload or missing fields",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Invalid credentials",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "MFA is already enabled",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/me/update": {
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Updates a user's account. Requires current username and password for authentication. Optional fields include new username, new email, and new password.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Update user account",
+                "parameters": [
+                    {
+                        "description": "Update account payload",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.UpdateAccountRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Account updated successfully"
+                    },
+                    "400": {
+                        "description": "Invalid request payload or missing fields",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - invalid credentials",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/register": {
+            "post": {
+                "description": "Registers a new user in the system using email, username, and password. Returns HTTP 201 on success.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Register a new user",
+                "parameters": [
+                    {
+                        "description": "Registration details",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.RegisterRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "User created successfully"
+                    },
+                    "400": {
+                        "description": "Invalid request payload or missing fields",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Email or username already exists",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "domain.APIKeyType": {
+            "type": "string",
+            "enum": [
+                "api_key",
+                "personal_access_token"
+            ],
+            "x-enum-varnames": [
+                "ServiceAPIKey",
+                "PersonalAccessToken"
+            ]
+        },
+        "domain.AuditAction": {
+            "type": "string",
+            "enum": [
+                "auth.login_succeeded",
+                "auth.login_failed",
+                "auth.mfa_failed",
+                "auth.session_refreshed",
+                "auth.impersonation_started",
+                "auth.impersonated_request",
+                "user.registered",
+                "user.created",
+                "user.imported",
+                "user.updated",
+                "user.suspended",
+                "user.reactivated",
+                "user.deleted",
+                "user.restored",
+                "user.unlocked",
+                "user.erased",
+                "user.data_export_requested",
+                "user.data_export_downloaded",
+                "user.erasure_requested",
+                "user.tokens_revoked",
+                "user.mfa_enabled",
+                "user.mfa_disabled",
+                "api_key.created",
+                "api_key.revoked",
+                "role.created",
+                "role.updated",
+                "role.deleted",
+                "role.mfa_requirement_updated",
+                "webhook.created",
+                "webhook.updated",
+                "webhook.deleted",
+                "webhook.secret_rotated",
+                "webhook.delivery_replayed",
+                "settings.updated",
+                "settings.reloaded"
+            ],
+            "x-enum-varnames": [
+                "AuditLoginSucceeded",
+                "AuditLoginFailed",
+                "AuditMFAFailed",
+                "AuditSessionRefreshed",
+                "AuditImpersonationStarted",
+                "AuditImpersonatedRequest",
+                "AuditUserRegistered",
+                "AuditUserCreated",
+                "AuditUserImported",
+                "AuditUserUpdated",
+                "AuditUserSuspended",
+                "AuditUserReactivated",
+                "AuditUserDeleted",
+                "AuditUserRestored",
+                "AuditUserUnlocked",
+                "AuditUserErased",
+                "AuditDataExportRequested",
+                "AuditDataExportDownloaded",
+                "AuditErasureRequested",
+                "AuditTokensRevoked",
+                "AuditMFAEnabled",
+                "AuditMFADisabled",
+                "AuditAPIKeyCreated",
+                "AuditAPIKeyRevoked",
+                "AuditRoleCreated",
+                "AuditRoleUpdated",
+                "AuditRoleDeleted",
+                "AuditMFARequirementUpdated",
+                "AuditWebhookCreated",
+                "AuditWebhookUpdated",
+                "AuditWebhookDeleted",
+                "AuditWebhookSecretRotated",
+                "AuditWebhookReplayed",
+                "AuditSettingsUpdated",
+                "AuditSettingsReloaded"
+            ]
+        },
+        "domain.AuditChange": {
+            "type": "object",
+            "properties": {
+                "after": {},
+                "before": {}
+            }
+        },
+        "domain.AuditTargetType": {
+            "type": "string",
+            "enum": [
+                "user",
+                "username",
+                "api_key",
+                "role",
+                "webhook",
+                "settings"
+            ],
+            "x-enum-varnames": [
+                "AuditTargetUser",
+                "AuditTargetUsername",
+                "AuditTargetAPIKey",
+                "AuditTargetRole",
+                "AuditTargetWebhook",
+                "AuditTargetSettings"
+            ]
+        },
+        "domain.DataRequestKind": {
+            "type": "string",
+            "enum": [
+                "export",
+                "erasure"
+            ],
+            "x-enum-varnames": [
+                "DataExport",
+                "DataErasure"
+            ]
+        },
+        "domain.DataRequestStatus": {
+            "type": "string",
+            "enum": [
+                "pending",
+                "running",
+                "completed",
+                "failed"
+            ],
+            "x-enum-varnames": [
+                "DataRequestPending",
+                "DataRequestRunning",
+                "DataRequestCompleted",
+                "DataRequestFailed"
+            ]
+        },
+        "domain.EventType": {
+            "type": "string",
+            "enum": [
+                "user.registered",
+                "user.created",
+                "user.role_changed"
+            ],
+            "x-enum-varnames": [
+                "EventUserRegistered",
+                "EventUserCreated",
+                "EventUserRoleChanged"
+            ]
+        },
+        "domain.LogLevel": {
+            "type": "string",
+            "enum": [
+                "debug",
+                "info",
+                "warn",
+                "error"
+            ],
+            "x-enum-varnames": [
+                "LogDebug",
+                "LogInfo",
+                "LogWarn",
+                "LogError"
+            ]
+        },
+        "domain.Permission": {
+            "type": "string",
+            "enum": [
+                "users:read",
+                "users:write",
+                "roles:read",
+                "roles:write",
+                "products:write",
+                "orders:read",
+                "orders:ship",
+                "api_keys:read",
+                "api_keys:write",
+                "audit:read",
+                "webhooks:read",
+                "webhooks:write",
+                "settings:read",
+                "settings:write",
+                "users:impersonate",
+                "users:privacy"
+            ],
+            "x-enum-varnames": [
+                "UsersRead",
+                "UsersWrite",
+                "RolesRead",
+                "RolesWrite",
+                "ProductsWrite",
+                "OrdersRead",
+                "OrdersShip",
+                "APIKeysRead",
+                "APIKeysWrite",
+                "AuditRead",
+                "WebhooksRead",
+                "WebhooksWrite",
+                "SettingsRead",
+                "SettingsWrite",
+                "UsersImpersonate",
+                "UsersPrivacy"
+            ]
+        },
+        "domain.UserRole": {
+            "type": "string",
+            "enum": [
+                "admin",
+                "client",
                 "delivery",
                 "warehouse"
             ],
-            "x-enum-varnames": [
-                "Admin",
-                "Client",
-                "Delivery",
-                "Warehouse"
-            ]
+            "x-enum-varnames": [
+                "Admin",
+                "Client",
+                "Delivery",
+                "Warehouse"
+            ]
+        },
+        "domain.UserStatus": {
+            "type": "string",
+            "enum": [
+                "active",
+                "suspended"
+            ],
+            "x-enum-varnames": [
+                "UserActive",
+                "UserSuspended"
+            ]
+        },
+        "domain.WebhookDeliveryStatus": {
+            "type": "string",
+            "enum": [
+                "pending",
+                "succeeded",
+                "dead"
+            ],
+            "x-enum-varnames": [
+                "WebhookDeliveryPending",
+                "WebhookDeliverySucceeded",
+                "WebhookDeliveryDead"
+            ]
+        },
+        "request.CreateAPIKeyRequest": {
+            "type": "object",
+            "required": [
+                "name",
+                "scopes"
+            ],
+            "properties": {
+                "expiresIn": {
+                    "type": "string",
+                    "example": "720h"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Warehouse scanner"
+                },
+                "scopes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.Permission"
+                    },
+                    "example": [
+                        "orders:read"
+                    ]
+                }
+            }
+        },
+        "request.CreateRoleRequest": {
+            "type": "object",
+            "required": [
+                "name",
+                "permissions"
+            ],
+            "properties": {
+                "description": {
+                    "type": "string",
+                    "example": "Customer support staff"
+                },
+                "name": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domain.UserRole"
+                        }
+                    ],
+                    "example": "support"
+                },
+                "permissions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.Permission"
+                    },
+                    "example": [
+                        "users:read"
+                    ]
+                }
+            }
+        },
+        "request.CreateUser": {
+            "type": "object",
+            "required": [
+                "email",
+                "password",
+                "role",
+                "username"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string",
+                    "example": "newUser@email.com"
+                },
+                "password": {
+                    "type": "string",
+                    "example": "NewSecret_123"
+                },
+                "role": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domain.UserRole"
+                        }
+                    ],
+                    "example": "warehouse"
+                },
+                "username": {
+                    "type": "string",
+                    "example": "newUser123"
+                }
+            }
+        },
+        "request.CreateWebhookEndpointRequest": {
+            "type": "object",
+            "required": [
+                "url"
+            ],
+            "properties": {
+                "description": {
+                    "type": "string",
+                    "example": "ERP integration"
+                },
+                "eventTypes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.EventType"
+                    },
+                    "example": [
+                        "user.registered"
+                    ]
+                },
+                "url": {
+                    "type": "string",
+                    "maxLength": 2048,
+                    "example": "https://partner.example.com/webhooks"
+                }
+            }
+        },
+        "request.LoginRequest": {
+            "type": "object",
+            "properties": {
+                "password": {
+                    "type": "string",
+                    "example": "Secret_password123"
+                },
+                "username": {
+                    "type": "string",
+                    "example": "MyUsername"
+                }
+            }
+        },
+        "request.MFACodeRequest": {
+            "type": "object",
+            "required": [
+                "code",
+                "password",
+                "username"
+            ],
+            "properties": {
+                "code": {
+                    "type": "string",
+                    "example": "123456"
+                },
+                "password": {
+                    "type": "string",
+                    "example": "MyPassword_123"
+                },
+                "username": {
+                    "type": "string",
+                    "example": "MyUsername"
+                }
+            }
+        },
+        "request.MFAEnrollRequest": {
+            "type": "object",
+            "required": [
+                "password",
+                "username"
+            ],
+            "properties": {
+                "password": {
+                    "type": "string",
+                    "example": "MyPassword_123"
+                },
+                "username": {
+                    "type": "string",
+                    "example": "MyUsername"
+                }
+            }
+        },
+        "request.RateLimitPolicyRequest": {
+            "type": "object",
+            "required": [
+                "default"
+            ],
+            "properties": {
+                "default": {
+                    "type": "string",
+                    "example": "20/1m"
+                },
+                "roles": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "request.RegisterRequest": {
+            "type": "object",
+            "required": [
+                "email",
+                "password",
+                "username"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string",
+                    "example": "newUser@email.com"
+                },
+                "password": {
+                    "type": "string",
+                    "example": "NewSecret_123"
+                },
+                "username": {
+                    "type": "string",
+                    "example": "newUser123"
+                }
+            }
+        },
+        "request.SetMFARequirementRequest": {
+            "type": "object",
+            "required": [
+                "required"
+            ],
+            "properties": {
+                "required": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "request.UpdateAccountRequest": {
+            "type": "object",
+            "required": [
+                "password",
+                "username"
+            ],
+            "properties": {
+                "newEmail": {
+                    "type": "string",
+                    "example": "newEmail@emai.com"
+                },
+                "newPassword": {
+                    "type": "string",
+                    "example": "NewSecret_123"
+                },
+                "newUsername": {
+                    "type": "string",
+                    "example": "newUsername123"
+                },
+                "password": {
+                    "type": "string",
+                    "example": "MyPassword_123"
+                },
+                "username": {
+                    "type": "string",
+                    "example": "MyUsername"
+                }
+            }
+        },
+        "request.UpdateRoleRequest": {
+            "type": "object",
+            "required": [
+                "permissions"
+            ],
+            "properties": {
+                "description": {
+                    "type": "string",
+                    "example": "Customer support staff"
+                },
+                "permissions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.Permission"
+                    },
+                    "example": [
+                        "users:read"
+                    ]
+                }
+            }
+        },
+        "request.UpdateRuntimeSettingsRequest": {
+            "type": "object",
+            "properties": {
+                "accessTokenExpireTime": {
+                    "type": "string",
+                    "example": "30m"
+                },
+                "features": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "boolean"
+                    }
+                },
+                "impersonationTokenExpireTime": {
+                    "type": "string",
+                    "example": "15m"
+                },
+                "logLevel": {
+                    "enum": [
+                        "debug",
+                        "info",
+                        "warn",
+                        "error"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domain.LogLevel"
+                        }
+                    ],
+                    "example": "debug"
+                },
+                "mfaTokenExpireTime": {
+                    "type": "string",
+                    "example": "5m"
+                },
+                "rateLimitEnabled": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "rateLimits": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/request.RateLimitPolicyRequest"
+                    }
+                },
+                "refreshTokenExpireTime": {
+                    "type": "string",
+                    "example": "24h"
+                }
+            }
+        },
+        "request.UpdateUser": {
+            "type": "object",
+            "properties": {
+                "email": {
+                    "type": "string"
+                },
+                "password": {
+                    "type": "string"
+                },
+                "role": {
+                    "$ref": "#/definitions/domain.UserRole"
+                },
+                "username": {
+                    "type": "string"
+                }
+            }
+        },
+        "request.UpdateWebhookEndpointRequest": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string",
+                    "example": "ERP integration"
+                },
+                "enabled": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "eventTypes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.EventType"
+                    },
+                    "example": [
+                        "user.registered"
+                    ]
+                },
+                "url": {
+                    "type": "string",
+                    "maxLength": 2048,
+                    "example": "https://partner.example.com/webhooks"
+                }
+            }
+        },
+        "request.VerifyMFARequest": {
+            "type": "object",
+            "required": [
+                "code"
+            ],
+            "properties": {
+                "code": {
+                    "type": "string",
+                    "example": "123456"
+                }
+            }
+        },
+        "response.APIKeysResponse": {
+            "type": "object",
+            "properties": {
+                "keys": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.apiKey"
+                    }
+                }
+            }
+        },
+        "response.AuditEventsResponse": {
+            "type": "object",
+            "properties": {
+                "events": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.auditEvent"
+                    }
+                }
+            }
+        },
+        "response.CreatedAPIKeyResponse": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "string",
+                    "example": "2025-10-15T12:37:42.664482Z"
+                },
+                "expiresAt": {
+                    "type": "string",
+                    "example": "2026-01-13T12:37:42.664482Z"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "1bd70616-480b-47b9-91f5-292b4f4a45b1"
+                },
+                "lastUsedAt": {
+                    "type": "string",
+                    "example": "2025-10-15T12:40:19.555827Z"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Warehouse scanner"
+                },
+                "prefix": {
+                    "type": "string",
+                    "example": "sk_abcdefghijkl"
+                },
+                "revokedAt": {
+                    "type": "string"
+                },
+                "scopes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.Permission"
+                    },
+                    "example": [
+                        "orders:read"
+                    ]
+                },
+                "secret": {
+                    "type": "string",
+                    "example": "sk_abcdefghijkl_mzxw6ytboi4dsnrvgq3tsmjvgy"
+                },
+                "type": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domain.APIKeyType"
+                        }
+                    ],
+                    "example": "api_key"
+                }
+            }
+        },
+        "response.DataRequestResponse": {
+            "type": "object",
+            "properties": {
+                "archiveAvailable": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "archiveExpiresAt": {
+                    "type": "string",
+                    "example": "2025-10-22T12:37:42.664482Z"
+                },
+                "completedAt": {
+                    "type": "string",
+                    "example": "2025-10-15T12:37:43.102934Z"
+                },
+                "createdAt": {
+                    "type": "string",
+                    "example": "2025-10-15T12:37:42.664482Z"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "6f1c2a8e-3b4d-4e5f-8a9b-0c1d2e3f4a5b"
+                },
+                "kind": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domain.DataRequestKind"
+                        }
+                    ],
+                    "example": "export"
+                },
+                "requestedBy": {
+                    "type": "string",
+                    "example": "9a8b7c6d-5e4f-4a3b-2c1d-0e9f8a7b6c5d"
+                },
+                "status": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domain.DataRequestStatus"
+                        }
+                    ],
+                    "example": "completed"
+                },
+                "updatedAt": {
+                    "type": "string",
+                    "example": "2025-10-15T12:37:43.102934Z"
+                },
+                "userId": {
+                    "type": "string",
+                    "example": "1bd70616-480b-47b9-91f5-292b4f4a45b1"
+                }
+            }
+        },
+        "response.ErrorResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string",
+                    "example": "INTERNAL_SERVER_ERROR"
+                },
+                "messages": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "Server cannot process the request."
+                    ]
+                }
+            }
+        },
+        "response.FetchingUsersResponse": {
+            "type": "object",
+            "properties": {
+                "cursor": {
+                    "type": "string",
+                    "example": "eyJzb3J0QnkiOiJjcmVhdGVkX2F0IiwiZGVzYyI6ZmFsc2UsInZhbHVlIjoiMjAyNS0xMC0xNVQxMjo0MDoxOS41NTU4MjdaIiwiaWQiOiIxYmQ3MDYxNi00ODBiLTQ3YjktOTFmNS0yOTJiNGY0YTQ1YjEifQ=="
+                },
+                "total": {
+                    "type": "integer",
+                    "example": 42
+                },
+                "users": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.user"
+                    }
+                }
+            }
+        },
+        "response.ImpersonationTokenResponse": {
+            "type": "object",
+            "properties": {
+                "accessToken": {
+                    "type": "string",
+                    "example": "eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."
+                },
+                "expiresAt": {
+                    "type": "string",
+                    "example": "2025-01-01T12:15:00Z"
+                }
+            }
+        },
+        "response.ImportErrorResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string",
+                    "example": "INVALID_IMPORT"
+                },
+                "messages": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "Import file contains invalid users."
+                    ]
+                },
+                "rows": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.importRowError"
+                    }
+                }
+            }
+        },
+        "response.ImportUsersResponse": {
+            "type": "object",
+            "properties": {
+                "dryRun": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "users": {
+                    "type": "integer",
+                    "example": 25
+                }
+            }
+        },
+        "response.MFAChallengeResponse": {
+            "type": "object",
+            "properties": {
+                "mfaToken": {
+                    "type": "string",
+                    "example": "eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."
+                }
+            }
+        },
+        "response.MFAEnrollmentResponse": {
+            "type": "object",
+            "properties": {
+                "provisioningUri": {
+                    "type": "string",
+                    "example": "otpauth://totp/shop-api:MyUsername?algorithm=SHA1\u0026digits=6\u0026issuer=shop-api\u0026period=30\u0026secret=JBSWY3DPEHPK3PXPJBSWY3DPEHPK3PXP"
+                },
+                "secret": {
+                    "type": "string",
+                    "example": "JBSWY3DPEHPK3PXPJBSWY3DPEHPK3PXP"
+                }
+            }
+        },
+        "response.OAuthLinkResponse": {
+            "type": "object",
+            "properties": {
+                "url": {
+                    "type": "string",
+                    "example": "https://accounts.google.com/o/oauth2/v2/auth?client_id=..."
+                }
+            }
+        },
+        "response.PermissionsResponse": {
+            "type": "object",
+            "properties": {
+                "permissions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.permission"
+                    }
+                }
+            }
+        },
+        "response.RecoveryCodesResponse": {
+            "type": "object",
+            "properties": {
+                "recoveryCodes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "abcde-fghij"
+                    ]
+                }
+            }
+        },
+        "response.RoleMFARequirementsResponse": {
+            "type": "object",
+            "properties": {
+                "requirements": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.roleMFARequirement"
+                    }
+                }
+            }
+        },
+        "response.RolesResponse": {
+            "type": "object",
+            "properties": {
+                "roles": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.role"
+                    }
+                }
+            }
+        },
+        "response.RuntimeSettingsResponse": {
+            "type": "object",
+            "properties": {
+                "accessTokenExpireTime": {
+                    "type": "string",
+                    "example": "30m0s"
+                },
+                "features": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "boolean"
+                    }
+                },
+                "impersonationTokenExpireTime": {
+                    "type": "string",
+                    "example": "15m0s"
+                },
+                "logLevel": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domain.LogLevel"
+                        }
+                    ],
+                    "example": "info"
+                },
+                "mfaTokenExpireTime": {
+                    "type": "string",
+                    "example": "5m0s"
+                },
+                "rateLimitEnabled": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "rateLimits": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/response.rateLimitPolicy"
+                    }
+                },
+                "refreshTokenExpireTime": {
+                    "type": "string",
+                    "example": "24h0m0s"
+                }
+            }
+        },
+        "response.TokensResponse": {
+            "type": "object",
+            "properties": {
+                "accessToken": {
+                    "type": "string",
+                    "example": "eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."
+                },
+                "refreshToken": {
+                    "type": "string",
+                    "example": "eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."
+                }
+            }
+        },
+        "response.WebhookDeliveriesResponse": {
+            "type": "object",
+            "properties": {
+                "deliveries": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.webhookDelivery"
+                    }
+                }
+            }
+        },
+        "response.WebhookDeliveryResponse": {
+            "type": "object",
+            "properties": {
+                "attempts": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "createdAt": {
+                    "type": "string",
+                    "example": "2025-10-15T12:37:42.664482Z"
+                },
+                "deliveredAt": {
+                    "type": "string",
+                    "example": "2025-10-15T12:38:42.664482Z"
+                },
+                "endpointId": {
+                    "type": "string",
+                    "example": "3c2b1a09-8f7e-4d6c-9b5a-4e3f2a1b0c9d"
+                },
+                "eventId": {
+                    "type": "string",
+                    "example": "6f1c2a8e-3b4d-4e5f-8a9b-0c1d2e3f4a5b"
+                },
+                "eventType": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domain.EventType"
+                        }
+                    ],
+                    "example": "user.registered"
+                },
+                "history": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.webhookAttempt"
+                    }
+                },
+                "id": {
+                    "type": "string",
+                    "example": "9d8c7b6a-5f4e-4d3c-8b2a-1f0e9d8c7b6a"
+                },
+                "lastError": {
+                    "type": "string",
+                    "example": "unexpected response status 503"
+                },
+                "nextAttemptAt": {
+                    "type": "string",
+                    "example": "2025-10-15T12:39:42.664482Z"
+                },
+                "payload": {
+                    "type": "object",
+                    "additionalProperties": {}
+                },
+                "status": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domain.WebhookDeliveryStatus"
+                        }
+                    ],
+                    "example": "pending"
+                },
+                "updatedAt": {
+                    "type": "string",
+                    "example": "2025-10-15T12:38:42.664482Z"
+                }
+            }
         },
-        "request.LoginRequest": {
+        "response.WebhookEndpointResponse": {
             "type": "object",
             "properties": {
-                "password": {
+                "createdAt": {
                     "type": "string",
-                    "example": "Secret_password123"
+                    "example": "2025-10-15T12:37:42.664482Z"
                 },
-                "username": {
+                "description": {
                     "type": "string",
-                    "example": "MyUsername"
+                    "example": "ERP integration"
+                },
+                "enabled": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "eventTypes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.EventType"
+                    },
+                    "example": [
+                        "user.registered"
+                    ]
+                },
+                "id": {
+                    "type": "string",
+                    "example": "3c2b1a09-8f7e-4d6c-9b5a-4e3f2a1b0c9d"
+                },
+                "updatedAt": {
+                    "type": "string",
+                    "example": "2025-10-15T12:37:42.664482Z"
+                },
+                "url": {
+                    "type": "string",
+                    "example": "https://partner.example.com/webhooks"
                 }
             }
         },
-        "request.RegisterRequest": {
+        "response.WebhookEndpointsResponse": {
             "type": "object",
-            "required": [
-                "email",
-                "password",
-                "username"
-            ],
             "properties": {
-                "email": {
+                "endpoints": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.WebhookEndpointResponse"
+                    }
+                }
+            }
+        },
+        "response.WebhookSecretResponse": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
                     "type": "string",
-                    "example": "newUser@email.com"
+                    "example": "2025-10-15T12:37:42.664482Z"
                 },
-                "password": {
+                "description": {
                     "type": "string",
-                    "example": "NewSecret_123"
+                    "example": "ERP integration"
                 },
-                "username": {
+                "enabled": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "eventTypes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.EventType"
+                    },
+                    "example": [
+                        "user.registered"
+                    ]
+                },
+                "id": {
                     "type": "string",
-                    "example": "newUser123"
+                    "example": "3c2b1a09-8f7e-4d6c-9b5a-4e3f2a1b0c9d"
+                },
+                "secret": {
+                    "type": "string",
+                    "example": "whsec_k3v9x2m7q4w8e1r5t6y0u2i3o4"
+                },
+                "updatedAt": {
+                    "type": "string",
+                    "example": "2025-10-15T12:37:42.664482Z"
+                },
+                "url": {
+                    "type": "string",
+                    "example": "https://partner.example.com/webhooks"
                 }
             }
         },
-        "request.UpdateAccountRequest": {
+        "response.apiKey": {
             "type": "object",
-            "required": [
-                "password",
-                "username"
-            ],
             "properties": {
-                "newEmail": {
+                "createdAt": {
                     "type": "string",
-                    "example": "newEmail@emai.com"
+                    "example": "2025-10-15T12:37:42.664482Z"
                 },
-                "newPassword": {
+                "expiresAt": {
                     "type": "string",
-                    "example": "NewSecret_123"
+                    "example": "2026-01-13T12:37:42.664482Z"
                 },
-                "newUsername": {
+                "id": {
                     "type": "string",
-                    "example": "newUsername123"
+                    "example": "1bd70616-480b-47b9-91f5-292b4f4a45b1"
                 },
-                "password": {
+                "lastUsedAt": {
                     "type": "string",
-                    "example": "MyPassword_123"
+                    "example": "2025-10-15T12:40:19.555827Z"
                 },
-                "username": {
+                "name": {
                     "type": "string",
-                    "example": "MyUsername"
+                    "example": "Warehouse scanner"
+                },
+                "prefix": {
+                    "type": "string",
+                    "example": "sk_abcdefghijkl"
+                },
+                "revokedAt": {
+                    "type": "string"
+                },
+                "scopes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.Permission"
+                    },
+                    "example": [
+                        "orders:read"
+                    ]
+                },
+                "type": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domain.APIKeyType"
+                        }
+                    ],
+                    "example": "api_key"
                 }
             }
         },
-        "request.UpdateUser": {
+        "response.auditEvent": {
             "type": "object",
             "properties": {
-                "email": {
-                    "type": "string"
+                "action": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domain.AuditAction"
+                        }
+                    ],
+                    "example": "user.updated"
                 },
-                "password": {
-                    "type": "string"
+                "actorId": {
+                    "type": "string",
+                    "example": "6f1c2a8e-0d4b-4f7e-9a51-3c8b7e2d9f10"
                 },
-                "role": {
-                    "$ref": "#/definitions/domain.UserRole"
+                "changes": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/domain.AuditChange"
+                    }
                 },
-                "username": {
-                    "type": "string"
+                "clientIp": {
+                    "type": "string",
+                    "example": "203.0.113.7"
+                },
+                "createdAt": {
+                    "type": "string",
+                    "example": "2025-10-15T12:37:42.664482Z"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "1bd70616-480b-47b9-91f5-292b4f4a45b1"
+                },
+                "requestId": {
+                    "type": "string",
+                    "example": "0b5e4c1a-7f2d-4e8b-9c3a-1d6f8e2b4a70"
+                },
+                "targetId": {
+                    "type": "string",
+                    "example": "1bd70616-480b-47b9-91f5-292b4f4a45b1"
+                },
+                "targetType": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domain.AuditTargetType"
+                        }
+                    ],
+                    "example": "user"
+                },
+                "userAgent": {
+                    "type": "string",
+                    "example": "curl/8.5.0"
                 }
             }
         },
-        "response.ErrorResponse": {
+        "response.importRowError": {
             "type": "object",
             "properties": {
-                "code": {
-                    "type": "string",
-                    "example": "INTERNAL_SERVER_ERROR"
+                "line": {
+                    "type": "integer",
+                    "example": 2
                 },
                 "messages": {
                     "type": "array",
@@ -510,36 +4913,87 @@ const docTemplate = `{
                         "type": "string"
                     },
                     "example": [
-                        "Server cannot process the request."
+                        "Username is already in use."
                     ]
                 }
             }
         },
-        "response.FetchingUsersResponse": {
+        "response.permission": {
             "type": "object",
             "properties": {
-                "cursor": {
+                "description": {
                     "type": "string",
-                    "example": "MjAyNS0xMC0xNVQxMjo0MDoxOS41NTU4Mjda"
+                    "example": "Read user accounts"
                 },
-                "users": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/response.user"
-                    }
+                "name": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domain.Permission"
+                        }
+                    ],
+                    "example": "users:read"
                 }
             }
         },
-        "response.TokensResponse": {
+        "response.rateLimitPolicy": {
             "type": "object",
             "properties": {
-                "accessToken": {
+                "default": {
                     "type": "string",
-                    "example": "eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."
+                    "example": "20/1m0s"
                 },
-                "refreshToken": {
+                "roles": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "response.role": {
+            "type": "object",
+            "properties": {
+                "builtIn": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "description": {
                     "type": "string",
-                    "example": "eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."
+                    "example": "Warehouse staff"
+                },
+                "name": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domain.UserRole"
+                        }
+                    ],
+                    "example": "warehouse"
+                },
+                "permissions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.Permission"
+                    },
+                    "example": [
+                        "products:write"
+                    ]
+                }
+            }
+        },
+        "response.roleMFARequirement": {
+            "type": "object",
+            "properties": {
+                "required": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "role": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domain.UserRole"
+                        }
+                    ],
+                    "example": "admin"
                 }
             }
         },
@@ -566,6 +5020,14 @@ const docTemplate = `{
                     ],
                     "example": "client"
                 },
+                "status": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domain.UserStatus"
+                        }
+                    ],
+                    "example": "active"
+                },
                 "updatedAt": {
                     "type": "string",
                     "example": "2025-10-15T12:37:42.664482Z"
@@ -575,6 +5037,84 @@ const docTemplate = `{
                     "example": "Viktor123"
                 }
             }
+        },
+        "response.webhookAttempt": {
+            "type": "object",
+            "properties": {
+                "attemptedAt": {
+                    "type": "string",
+                    "example": "2025-10-15T12:38:42.664482Z"
+                },
+                "durationMs": {
+                    "type": "integer",
+                    "example": 184
+                },
+                "error": {
+                    "type": "string",
+                    "example": "context deadline exceeded"
+                },
+                "responseStatus": {
+                    "type": "integer",
+                    "example": 503
+                }
+            }
+        },
+        "response.webhookDelivery": {
+            "type": "object",
+            "properties": {
+                "attempts": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "createdAt": {
+                    "type": "string",
+                    "example": "2025-10-15T12:37:42.664482Z"
+                },
+                "deliveredAt": {
+                    "type": "string",
+                    "example": "2025-10-15T12:38:42.664482Z"
+                },
+                "endpointId": {
+                    "type": "string",
+                    "example": "3c2b1a09-8f7e-4d6c-9b5a-4e3f2a1b0c9d"
+                },
+                "eventId": {
+                    "type": "string",
+                    "example": "6f1c2a8e-3b4d-4e5f-8a9b-0c1d2e3f4a5b"
+                },
+                "eventType": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domain.EventType"
+                        }
+                    ],
+                    "example": "user.registered"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "9d8c7b6a-5f4e-4d3c-8b2a-1f0e9d8c7b6a"
+                },
+                "lastError": {
+                    "type": "string",
+                    "example": "unexpected response status 503"
+                },
+                "nextAttemptAt": {
+                    "type": "string",
+                    "example": "2025-10-15T12:39:42.664482Z"
+                },
+                "status": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/domain.WebhookDeliveryStatus"
+                        }
+                    ],
+                    "example": "pending"
+                },
+                "updatedAt": {
+                    "type": "string",
+                    "example": "2025-10-15T12:38:42.664482Z"
+                }
+            }
         }
     }
 }`
@@ -0,0 +1,17 @@
+package archive
+
+import (
+	"shop-api-go/internal/core/port"
+
+	"go.uber.org/fx"
+)
+
+var Module = fx.Module(
+	"Archive",
+	fx.Provide(
+		fx.Annotate(
+			NewZipArchiver,
+			fx.As(new(port.UserDataArchiver)),
+		),
+	),
+)
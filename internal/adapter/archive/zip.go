@@ -0,0 +1,170 @@
+package archive
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"shop-api-go/internal/core/domain"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// profile represents the account of the user in an archive.
+type profile struct {
+	Id        uuid.UUID         `json:"id"`
+	Username  string            `json:"username"`
+	Email     string            `json:"email"`
+	Role      domain.UserRole   `json:"role"`
+	Status    domain.UserStatus `json:"status"`
+	CreatedAt time.Time         `json:"createdAt"`
+	UpdatedAt time.Time         `json:"updatedAt"`
+	DeletedAt *time.Time        `json:"deletedAt"`
+}
+
+// session represents an issued refresh token in an archive.
+type session struct {
+	Id        uuid.UUID        `json:"id"`
+	Type      domain.TokenType `json:"type"`
+	ExpiresAt time.Time        `json:"expiresAt"`
+}
+
+// mfa represents the two-factor authentication settings in an archive.
+type mfa struct {
+	Enabled bool `json:"enabled"`
+}
+
+// identity represents a linked external identity in an archive.
+type identity struct {
+	Provider  string    `json:"provider"`
+	Subject   string    `json:"subject"`
+	Email     string    `json:"email"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// apiKey represents an API key or personal access token in an archive.
+type apiKey struct {
+	Id         uuid.UUID           `json:"id"`
+	Name       string              `json:"name"`
+	Type       domain.APIKeyType   `json:"type"`
+	Prefix     string              `json:"prefix"`
+	Scopes     []domain.Permission `json:"scopes"`
+	ExpiresAt  time.Time           `json:"expiresAt"`
+	LastUsedAt *time.Time          `json:"lastUsedAt"`
+	RevokedAt  *time.Time          `json:"revokedAt"`
+	CreatedAt  time.Time           `json:"createdAt"`
+}
+
+// auditEvent represents an audit event performed by or on the user in an archive.
+type auditEvent struct {
+	Id         uuid.UUID                     `json:"id"`
+	ActorId    *uuid.UUID                    `json:"actorId"`
+	Action     domain.AuditAction            `json:"action"`
+	TargetType domain.AuditTargetType        `json:"targetType"`
+	TargetId   string                        `json:"targetId"`
+	Changes    map[string]domain.AuditChange `json:"changes"`
+	ClientIP   string                        `json:"clientIp"`
+	UserAgent  string                        `json:"userAgent"`
+	CreatedAt  time.Time                     `json:"createdAt"`
+}
+
+// ZipArchiver implements port.UserDataArchiver and compiles user data into a ZIP
+// archive with a JSON file per kind of data.
+type ZipArchiver struct{}
+
+// NewZipArchiver creates a new ZipArchiver instance.
+func NewZipArchiver() *ZipArchiver {
+	return &ZipArchiver{}
+}
+
+func (a *ZipArchiver) Archive(data *domain.UserData) ([]byte, error) {
+	user := &data.User
+	sessions := make([]session, 0, len(data.Sessions))
+	for _, t := range data.Sessions {
+		sessions = append(sessions, session{Id: t.Id, Type: t.TokenType, ExpiresAt: t.ExpiresAt})
+	}
+	identities := make([]identity, 0, len(data.Identities))
+	for _, i := range data.Identities {
+		identities = append(identities, identity{Provider: i.Provider, Subject: i.Subject, Email: i.Email, CreatedAt: i.CreatedAt})
+	}
+	apiKeys := make([]apiKey, 0, len(data.APIKeys))
+	for _, k := range data.APIKeys {
+		apiKeys = append(apiKeys, apiKey{
+			Id:         k.Id,
+			Name:       k.Name,
+			Type:       k.KeyType,
+			Prefix:     k.Prefix,
+			Scopes:     k.Scopes,
+			ExpiresAt:  k.ExpiresAt,
+			LastUsedAt: k.LastUsedAt,
+			RevokedAt:  k.RevokedAt,
+			CreatedAt:  k.CreatedAt,
+		})
+	}
+	auditEvents := make([]auditEvent, 0, len(data.AuditEvents))
+	for _, e := range data.AuditEvents {
+		auditEvents = append(auditEvents, auditEvent{
+			Id:         e.Id,
+			ActorId:    e.ActorId,
+			Action:     e.Action,
+			TargetType: e.TargetType,
+			TargetId:   e.TargetId,
+			Changes:    e.Changes,
+			ClientIP:   e.ClientIP,
+			UserAgent:  e.UserAgent,
+			CreatedAt:  e.CreatedAt,
+		})
+	}
+
+	files := []struct {
+		name  string
+		value any
+	}{
+		{"profile.json", profile{
+			Id:        user.Id,
+			Username:  user.Username,
+			Email:     user.Email,
+			Role:      user.Role,
+			Status:    user.Status,
+			CreatedAt: user.CreatedAt,
+			UpdatedAt: user.UpdatedAt,
+			DeletedAt: user.DeletedAt,
+		}},
+		{"sessions.json", sessions},
+		{"mfa.json", mfa{Enabled: data.MFAEnabled}},
+		{"identities.json", identities},
+		{"api_keys.json", apiKeys},
+		{"audit_events.json", auditEvents},
+	}
+
+	var buf bytes.Buffer
+	w := zip.NewWriter(&buf)
+	for _, f := range files {
+		fw, err := w.Create(f.name)
+		if err != nil {
+			return nil, archiveError(user.Id, f.name, err)
+		}
+		encoder := json.NewEncoder(fw)
+		encoder.SetIndent("", "  ")
+		if err = encoder.Encode(f.value); err != nil {
+			return nil, archiveError(user.Id, f.name, err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		return nil, archiveError(user.Id, "", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// archiveError logs a failure to write a file of the archive and returns domain.ErrInternal.
+func archiveError(userId uuid.UUID, file string, err error) error {
+	zap.L().
+		Error(
+			"writing user data archive failed",
+			zap.String("userId", userId.String()),
+			zap.String("file", file),
+			zap.Error(err),
+		)
+	return domain.ErrInternal
+}
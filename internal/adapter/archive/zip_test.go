@@ -0,0 +1,60 @@
+package archive
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"io"
+	"shop-api-go/internal/core/domain"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestZipArchiver_Archive(t *testing.T) {
+	data := &domain.UserData{
+		User: domain.User{
+			Id:       uuid.New(),
+			Username: "Username",
+			Email:    "user@example.com",
+			Password: "hash",
+			Role:     domain.Client,
+			Status:   domain.UserActive,
+		},
+		MFAEnabled: true,
+		APIKeys: []domain.APIKey{
+			{Id: uuid.New(), Name: "CLI", KeyType: domain.PersonalAccessToken, Prefix: "pat_abcdefghijkl"},
+		},
+	}
+
+	archive, err := NewZipArchiver().Archive(data)
+	require.NoError(t, err)
+
+	r, err := zip.NewReader(bytes.NewReader(archive), int64(len(archive)))
+	require.NoError(t, err)
+
+	files := make(map[string][]byte)
+	for _, f := range r.File {
+		rc, err := f.Open()
+		require.NoError(t, err)
+		content, err := io.ReadAll(rc)
+		require.NoError(t, err)
+		require.NoError(t, rc.Close())
+		files[f.Name] = content
+	}
+	require.Len(t, files, 6)
+
+	var p map[string]any
+	require.NoError(t, json.Unmarshal(files["profile.json"], &p))
+	require.Equal(t, "user@example.com", p["email"])
+	require.NotContains(t, p, "password")
+
+	var keys []map[string]any
+	require.NoError(t, json.Unmarshal(files["api_keys.json"], &keys))
+	require.Len(t, keys, 1)
+	require.NotContains(t, keys[0], "secretHash")
+
+	require.JSONEq(t, `{"enabled": true}`, string(files["mfa.json"]))
+	require.JSONEq(t, `[]`, string(files["sessions.json"]))
+}
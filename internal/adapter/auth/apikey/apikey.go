@@ -0,0 +1,64 @@
+package apikey
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"shop-api-go/internal/core/domain"
+	"strings"
+)
+
+const (
+	// serviceKeyPrefix is the type prefix of service API keys.
+	serviceKeyPrefix = "sk"
+	// personalTokenPrefix is the type prefix of personal access tokens.
+	personalTokenPrefix = "pat"
+	// idLength is the length of the random part of the identification prefix.
+	idLength = 12
+)
+
+// APIKeyGenerator implements port.APIKeyGenerator and provides keys in the
+// <type>_<id>_<secret> format, where <type>_<id> is the identification prefix.
+//
+// Note: Keys have 130 bits of entropy, so a single SHA-256 is used instead of a slow password hash.
+type APIKeyGenerator struct{}
+
+// NewAPIKeyGenerator creates a new APIKeyGenerator instance.
+func NewAPIKeyGenerator() *APIKeyGenerator {
+	return &APIKeyGenerator{}
+}
+
+func (g *APIKeyGenerator) Generate(keyType domain.APIKeyType) (*domain.APIKeySecret, error) {
+	typePrefix := serviceKeyPrefix
+	if keyType == domain.PersonalAccessToken {
+		typePrefix = personalTokenPrefix
+	}
+
+	prefix := typePrefix + "_" + strings.ToLower(rand.Text()[:idLength])
+	secret := prefix + "_" + strings.ToLower(rand.Text())
+	return domain.NewAPIKeySecret(prefix, secret), nil
+}
+
+func (g *APIKeyGenerator) Prefix(secret string) (string, bool) {
+	parts := strings.Split(secret, "_")
+	if len(parts) != 3 {
+		return "", false
+	}
+	if parts[0] != serviceKeyPrefix && parts[0] != personalTokenPrefix {
+		return "", false
+	}
+	if len(parts[1]) != idLength || parts[2] == "" {
+		return "", false
+	}
+	return parts[0] + "_" + parts[1], true
+}
+
+func (g *APIKeyGenerator) Hash(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(sum[:])
+}
+
+func (g *APIKeyGenerator) Compare(secret, hash string) bool {
+	return subtle.ConstantTimeCompare([]byte(g.Hash(secret)), []byte(hash)) == 1
+}
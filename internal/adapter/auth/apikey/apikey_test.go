@@ -0,0 +1,55 @@
+package apikey
+
+import (
+	"shop-api-go/internal/core/domain"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAPIKeyGenerator_Generate(t *testing.T) {
+	generator := NewAPIKeyGenerator()
+
+	for keyType, typePrefix := range map[domain.APIKeyType]string{
+		domain.ServiceAPIKey:       "sk_",
+		domain.PersonalAccessToken: "pat_",
+	} {
+		t.Run(string(keyType), func(t *testing.T) {
+			key, err := generator.Generate(keyType)
+			require.NoError(t, err)
+			require.True(t, strings.HasPrefix(key.Prefix, typePrefix))
+			require.True(t, strings.HasPrefix(key.Secret, key.Prefix+"_"))
+
+			prefix, ok := generator.Prefix(key.Secret)
+			require.True(t, ok)
+			require.Equal(t, key.Prefix, prefix)
+
+			hash := generator.Hash(key.Secret)
+			require.True(t, generator.Compare(key.Secret, hash))
+			require.False(t, generator.Compare(key.Secret+"x", hash))
+		})
+	}
+}
+
+func TestAPIKeyGenerator_Prefix(t *testing.T) {
+	tests := []struct {
+		secret string
+		ok     bool
+	}{
+		{"pat_abcdefghijkl_secret", true},
+		{"sk_abcdefghijkl_secret", true},
+		{"xx_abcdefghijkl_secret", false},
+		{"pat_short_secret", false},
+		{"pat_abcdefghijkl_", false},
+		{"eyJhbGciOiJIUzI1NiJ9.eyJzdWIiOiIxIn0.sig", false},
+	}
+
+	generator := NewAPIKeyGenerator()
+	for _, test := range tests {
+		t.Run(test.secret, func(t *testing.T) {
+			_, ok := generator.Prefix(test.secret)
+			require.Equal(t, test.ok, ok)
+		})
+	}
+}
@@ -1,8 +1,11 @@
 package auth
 
 import (
-	"shop-api-go/internal/adapter/auth/bcrypt"
+	"shop-api-go/internal/adapter/auth/apikey"
 	"shop-api-go/internal/adapter/auth/jwt"
+	"shop-api-go/internal/adapter/auth/oauth"
+	"shop-api-go/internal/adapter/auth/password"
+	"shop-api-go/internal/adapter/auth/totp"
 	"shop-api-go/internal/core/port"
 
 	"go.uber.org/fx"
@@ -12,14 +15,39 @@ var Module = fx.Module(
 	"Auth",
 	fx.Provide(
 		fx.Annotate(
-			bcrypt.NewPasswordHasher,
+			password.NewPasswordHasher,
 			fx.As(new(port.PasswordHasher)),
 		),
 	),
+	fx.Provide(
+		fx.Annotate(
+			password.NewPolicy,
+			fx.As(new(port.PasswordPolicy)),
+		),
+	),
 	fx.Provide(
 		fx.Annotate(
 			jwt.NewTokenGenerator,
+			fx.As(fx.Self()),
 			fx.As(new(port.TokenGenerator)),
 		),
 	),
+	fx.Provide(
+		fx.Annotate(
+			totp.NewMFAProvider,
+			fx.As(new(port.MFAProvider)),
+		),
+	),
+	fx.Provide(
+		fx.Annotate(
+			oauth.NewIdentityProvider,
+			fx.As(new(port.IdentityProvider)),
+		),
+	),
+	fx.Provide(
+		fx.Annotate(
+			apikey.NewAPIKeyGenerator,
+			fx.As(new(port.APIKeyGenerator)),
+		),
+	),
 )
@@ -3,6 +3,7 @@ package jwt
 import (
 	"shop-api-go/internal/adapter/config"
 	"shop-api-go/internal/core/domain"
+	"sync/atomic"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
@@ -14,28 +15,53 @@ import (
 type claims struct {
 	UserRole  domain.UserRole  `json:"userRole"`
 	TokenType domain.TokenType `json:"tokenType"`
+	Actor     *actorClaim      `json:"act,omitempty"`
 	jwt.RegisteredClaims
 }
 
+// actorClaim represents the actor claim (RFC 8693) naming the admin acting as the subject.
+type actorClaim struct {
+	Subject string `json:"sub"`
+}
+
 // TokenGenerator implements port.TokenGenerator and provides JWT generation.
+// It implements port.RuntimeSettingsWatcher to update the expire times of the tokens.
 type TokenGenerator struct {
-	config *config.JWTConfig
+	config atomic.Pointer[config.JWTConfig]
 }
 
 // NewTokenGenerator creates a new TokenGenerator instance.
 func NewTokenGenerator(config *config.JWTConfig) *TokenGenerator {
-	return &TokenGenerator{config: config}
+	t := &TokenGenerator{}
+	t.config.Store(config)
+	return t
+}
+
+// ApplySettings swaps the config for a copy with the expire times of the settings.
+func (t *TokenGenerator) ApplySettings(settings *domain.RuntimeSettings) {
+	next := *t.config.Load()
+	next.AccessTokenExpireTime = settings.AccessTokenExpireTime
+	next.RefreshTokenExpireTime = settings.RefreshTokenExpireTime
+	next.MFATokenExpireTime = settings.MFATokenExpireTime
+	next.ImpersonationTokenExpireTime = settings.ImpersonationTokenExpireTime
+	t.config.Store(&next)
 }
 
 func (t *TokenGenerator) SignToken(token *domain.Token) (string, error) {
 	now := time.Now()
+	jwtConfig := t.config.Load()
 	var exp time.Duration
 
 	switch token.TokenType {
 	case domain.AccessToken:
-		exp = t.config.AccessTokenExpireTime
+		exp = jwtConfig.AccessTokenExpireTime
+		if token.IsImpersonated() {
+			exp = jwtConfig.ImpersonationTokenExpireTime
+		}
 	case domain.RefreshToken:
-		exp = t.config.RefreshTokenExpireTime
+		exp = jwtConfig.RefreshTokenExpireTime
+	case domain.MFAToken:
+		exp = jwtConfig.MFATokenExpireTime
 	default:
 		zap.L().Error(
 			"invalid token type",
@@ -49,17 +75,27 @@ func (t *TokenGenerator) SignToken(token *domain.Token) (string, error) {
 		UserRole:  token.UserRole,
 		TokenType: token.TokenType,
 		RegisteredClaims: jwt.RegisteredClaims{
-			Audience:  []string{t.config.Audience},
+			Audience:  []string{jwtConfig.Audience},
 			ExpiresAt: jwt.NewNumericDate(now.Add(exp)),
 			ID:        token.Id.String(),
 			IssuedAt:  jwt.NewNumericDate(now),
-			Issuer:    t.config.Issuer,
+			Issuer:    jwtConfig.Issuer,
 			NotBefore: jwt.NewNumericDate(now),
 			Subject:   token.UserId.String(),
 		},
 	}
+	if token.IsImpersonated() {
+		if token.TokenType != domain.AccessToken {
+			zap.L().Error(
+				"impersonation token is not an access token",
+				zap.String("token_type", string(token.TokenType)),
+			)
+			return "", domain.ErrInternal
+		}
+		jwtClaims.Actor = &actorClaim{Subject: token.ActorId.String()}
+	}
 
-	signedToken, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwtClaims).SignedString(t.config.Secret)
+	signedToken, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwtClaims).SignedString(jwtConfig.Secret)
 	if err != nil {
 		zap.L().Error(
 			"error signing token",
@@ -75,7 +111,7 @@ func (t *TokenGenerator) ParseToken(token string) (*domain.Token, error) {
 		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
 			return nil, domain.ErrInvalidToken
 		}
-		return t.config.Secret, nil
+		return t.config.Load().Secret, nil
 	})
 
 	if err != nil {
@@ -96,5 +132,13 @@ func (t *TokenGenerator) ParseToken(token string) (*domain.Token, error) {
 		return nil, domain.ErrInvalidToken
 	}
 
-	return domain.NewToken(tokenId, userId, jwtClaims.UserRole, jwtClaims.TokenType, jwtClaims.ExpiresAt.Time), nil
+	parsed := domain.NewToken(tokenId, userId, jwtClaims.UserRole, jwtClaims.TokenType, jwtClaims.ExpiresAt.Time)
+	if jwtClaims.Actor != nil {
+		actorId, err := uuid.Parse(jwtClaims.Actor.Subject)
+		if err != nil || jwtClaims.TokenType != domain.AccessToken {
+			return nil, domain.ErrInvalidToken
+		}
+		parsed.ActorId = &actorId
+	}
+	return parsed, nil
 }
@@ -0,0 +1,141 @@
+package oauth
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"shop-api-go/internal/adapter/config"
+	"shop-api-go/internal/core/domain"
+	"strconv"
+
+	"go.uber.org/zap"
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/github"
+)
+
+// githubAPIURL is the base url of the GitHub REST API.
+const githubAPIURL = "https://api.github.com"
+
+// githubProvider implements provider using GitHub OAuth apps.
+//
+// Note: GitHub does not support OpenID Connect for users, so the identity
+// is fetched from the REST API and the nonce is not used.
+type githubProvider struct {
+	name   string
+	oauth2 *oauth2.Config
+	apiURL string
+}
+
+// newGitHubProvider creates a new githubProvider instance.
+func newGitHubProvider(name string, config *config.OAuthProviderConfig) *githubProvider {
+	return &githubProvider{
+		name: name,
+		oauth2: &oauth2.Config{
+			ClientID:     config.ClientId,
+			ClientSecret: config.ClientSecret,
+			Endpoint:     github.Endpoint,
+			RedirectURL:  config.RedirectURL,
+			Scopes:       config.Scopes,
+		},
+		apiURL: githubAPIURL,
+	}
+}
+
+func (p *githubProvider) authCodeURL(_ context.Context, state, _, verifier string) (string, error) {
+	return p.oauth2.AuthCodeURL(state, oauth2.S256ChallengeOption(verifier)), nil
+}
+
+func (p *githubProvider) exchange(ctx context.Context, code, verifier, _ string) (*domain.ExternalIdentity, error) {
+	token, err := p.oauth2.Exchange(ctx, code, oauth2.VerifierOption(verifier))
+	if err != nil {
+		zap.L().Warn(
+			"exchanging authorization code failed",
+			zap.String("provider", p.name),
+			zap.Error(err),
+		)
+		return nil, domain.ErrExternalAuthFailed
+	}
+	client := p.oauth2.Client(ctx, token)
+
+	var user struct {
+		Id    int64  `json:"id"`
+		Login string `json:"login"`
+	}
+	if err = p.get(ctx, client, "/user", &user); err != nil {
+		return nil, err
+	}
+
+	var emails []struct {
+		Email    string `json:"email"`
+		Primary  bool   `json:"primary"`
+		Verified bool   `json:"verified"`
+	}
+	if err = p.get(ctx, client, "/user/emails", &emails); err != nil {
+		return nil, err
+	}
+
+	identity := domain.NewExternalIdentity(p.name, strconv.FormatInt(user.Id, 10), "", false, user.Login)
+	for _, email := range emails {
+		if email.Primary {
+			identity.Email = email.Email
+			identity.EmailVerified = email.Verified
+		}
+	}
+	return identity, nil
+}
+
+// get fetches a resource of the GitHub API and decodes it into the value.
+func (p *githubProvider) get(ctx context.Context, client *http.Client, path string, value any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.apiURL+path, nil)
+	if err != nil {
+		zap.L().Error(
+			"creating github request failed",
+			zap.String("path", path),
+			zap.Error(err),
+		)
+		return domain.ErrInternal
+	}
+	req.Header.Set("Accept", "application/vnd.github+json")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		zap.L().Warn(
+			"fetching github resource failed",
+			zap.String("provider", p.name),
+			zap.String("path", path),
+			zap.Error(err),
+		)
+		return domain.ErrExternalAuthFailed
+	}
+
+	defer func() {
+		closeErr := resp.Body.Close()
+		if closeErr != nil {
+			zap.L().Error(
+				"error closing response body",
+				zap.Error(closeErr),
+			)
+		}
+	}()
+
+	if resp.StatusCode != http.StatusOK {
+		zap.L().Warn(
+			"fetching github resource failed",
+			zap.String("provider", p.name),
+			zap.String("path", path),
+			zap.Int("status", resp.StatusCode),
+		)
+		return domain.ErrExternalAuthFailed
+	}
+
+	if err = json.NewDecoder(resp.Body).Decode(value); err != nil {
+		zap.L().Warn(
+			"decoding github resource failed",
+			zap.String("provider", p.name),
+			zap.String("path", path),
+			zap.Error(err),
+		)
+		return domain.ErrExternalAuthFailed
+	}
+	return nil
+}
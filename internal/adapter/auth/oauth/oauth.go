@@ -0,0 +1,84 @@
+package oauth
+
+import (
+	"context"
+	"crypto/rand"
+	"net/http"
+	"shop-api-go/internal/adapter/config"
+	"shop-api-go/internal/core/domain"
+	"time"
+
+	"github.com/coreos/go-oidc/v3/oidc"
+	"golang.org/x/oauth2"
+)
+
+// requestTimeout is the timeout of requests sent to identity providers.
+const requestTimeout = 10 * time.Second
+
+// provider is implemented by every supported identity provider protocol.
+type provider interface {
+	// authCodeURL returns the url of the provider's consent page.
+	authCodeURL(ctx context.Context, state, nonce, verifier string) (string, error)
+	// exchange exchanges the authorization code and returns the authenticated identity.
+	exchange(ctx context.Context, code, verifier, nonce string) (*domain.ExternalIdentity, error)
+}
+
+// IdentityProvider implements port.IdentityProvider and provides login
+// with the identity providers from config.OAuthConfig.
+type IdentityProvider struct {
+	config     *config.OAuthConfig
+	providers  map[string]provider
+	httpClient *http.Client
+	now        func() time.Time
+}
+
+// NewIdentityProvider creates a new IdentityProvider instance.
+func NewIdentityProvider(config *config.OAuthConfig) *IdentityProvider {
+	p := &IdentityProvider{
+		config:     config,
+		providers:  make(map[string]provider),
+		httpClient: &http.Client{Timeout: requestTimeout},
+		now:        time.Now,
+	}
+	for name, providerConfig := range config.Providers {
+		p.providers[name] = newProvider(name, providerConfig)
+	}
+	return p
+}
+
+func (p *IdentityProvider) Authorize(ctx context.Context, name string) (*domain.OAuthAuthorization, error) {
+	provider, ok := p.providers[name]
+	if !ok {
+		return nil, domain.ErrIdentityProviderNotFound
+	}
+
+	state := domain.NewOAuthState(
+		rand.Text(),
+		name,
+		rand.Text(),
+		oauth2.GenerateVerifier(),
+		p.now().Add(p.config.StateExpireTime),
+	)
+
+	url, err := provider.authCodeURL(oidc.ClientContext(ctx, p.httpClient), state.State, state.Nonce, state.CodeVerifier)
+	if err != nil {
+		return nil, err
+	}
+	return domain.NewOAuthAuthorization(state, url), nil
+}
+
+func (p *IdentityProvider) Exchange(ctx context.Context, state *domain.OAuthState, code string) (*domain.ExternalIdentity, error) {
+	provider, ok := p.providers[state.Provider]
+	if !ok {
+		return nil, domain.ErrIdentityProviderNotFound
+	}
+	return provider.exchange(oidc.ClientContext(ctx, p.httpClient), code, state.CodeVerifier, state.Nonce)
+}
+
+// newProvider creates the provider of the configured type.
+func newProvider(name string, providerConfig *config.OAuthProviderConfig) provider {
+	if providerConfig.Type == config.GitHubProvider {
+		return newGitHubProvider(name, providerConfig)
+	}
+	return newOIDCProvider(name, providerConfig)
+}
@@ -0,0 +1,140 @@
+package oauth
+
+import (
+	"context"
+	"crypto/subtle"
+	"shop-api-go/internal/adapter/config"
+	"shop-api-go/internal/core/domain"
+	"sync"
+
+	"github.com/coreos/go-oidc/v3/oidc"
+	"go.uber.org/zap"
+	"golang.org/x/oauth2"
+)
+
+// oidcProvider implements provider using OpenID Connect discovery and ID tokens.
+type oidcProvider struct {
+	name   string
+	config *config.OAuthProviderConfig
+
+	mu       sync.Mutex
+	provider *oidc.Provider
+}
+
+// newOIDCProvider creates a new oidcProvider instance.
+//
+// Note: The discovery document is fetched on first use so that an unavailable
+// issuer does not prevent the application from starting.
+func newOIDCProvider(name string, config *config.OAuthProviderConfig) *oidcProvider {
+	return &oidcProvider{
+		name:   name,
+		config: config,
+	}
+}
+
+func (p *oidcProvider) authCodeURL(ctx context.Context, state, nonce, verifier string) (string, error) {
+	provider, err := p.discover(ctx)
+	if err != nil {
+		return "", err
+	}
+	return p.oauth2Config(provider).AuthCodeURL(state, oidc.Nonce(nonce), oauth2.S256ChallengeOption(verifier)), nil
+}
+
+func (p *oidcProvider) exchange(ctx context.Context, code, verifier, nonce string) (*domain.ExternalIdentity, error) {
+	provider, err := p.discover(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	token, err := p.oauth2Config(provider).Exchange(ctx, code, oauth2.VerifierOption(verifier))
+	if err != nil {
+		zap.L().Warn(
+			"exchanging authorization code failed",
+			zap.String("provider", p.name),
+			zap.Error(err),
+		)
+		return nil, domain.ErrExternalAuthFailed
+	}
+
+	rawIDToken, ok := token.Extra("id_token").(string)
+	if !ok {
+		zap.L().Warn(
+			"token response has no id token",
+			zap.String("provider", p.name),
+		)
+		return nil, domain.ErrExternalAuthFailed
+	}
+
+	idToken, err := provider.Verifier(&oidc.Config{ClientID: p.config.ClientId}).Verify(ctx, rawIDToken)
+	if err != nil {
+		zap.L().Warn(
+			"verifying id token failed",
+			zap.String("provider", p.name),
+			zap.Error(err),
+		)
+		return nil, domain.ErrExternalAuthFailed
+	}
+	if subtle.ConstantTimeCompare([]byte(idToken.Nonce), []byte(nonce)) != 1 {
+		zap.L().Warn(
+			"id token nonce mismatch",
+			zap.String("provider", p.name),
+		)
+		return nil, domain.ErrExternalAuthFailed
+	}
+
+	var claims struct {
+		Email             string `json:"email"`
+		EmailVerified     bool   `json:"email_verified"`
+		PreferredUsername string `json:"preferred_username"`
+	}
+	if err = idToken.Claims(&claims); err != nil {
+		zap.L().Warn(
+			"parsing id token claims failed",
+			zap.String("provider", p.name),
+			zap.Error(err),
+		)
+		return nil, domain.ErrExternalAuthFailed
+	}
+
+	return domain.NewExternalIdentity(
+		p.name,
+		idToken.Subject,
+		claims.Email,
+		claims.EmailVerified,
+		claims.PreferredUsername,
+	), nil
+}
+
+// discover fetches the discovery document of the issuer once and caches it.
+func (p *oidcProvider) discover(ctx context.Context) (*oidc.Provider, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.provider != nil {
+		return p.provider, nil
+	}
+
+	provider, err := oidc.NewProvider(ctx, p.config.Issuer)
+	if err != nil {
+		zap.L().Error(
+			"fetching oidc discovery document failed",
+			zap.String("provider", p.name),
+			zap.String("issuer", p.config.Issuer),
+			zap.Error(err),
+		)
+		return nil, domain.ErrInternal
+	}
+	p.provider = provider
+	return provider, nil
+}
+
+// oauth2Config returns the OAuth 2.0 client configuration of the provider.
+func (p *oidcProvider) oauth2Config(provider *oidc.Provider) *oauth2.Config {
+	return &oauth2.Config{
+		ClientID:     p.config.ClientId,
+		ClientSecret: p.config.ClientSecret,
+		Endpoint:     provider.Endpoint(),
+		RedirectURL:  p.config.RedirectURL,
+		Scopes:       p.config.Scopes,
+	}
+}
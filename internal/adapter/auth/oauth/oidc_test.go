@@ -0,0 +1,203 @@
+package oauth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"shop-api-go/internal/adapter/config"
+	"shop-api-go/internal/core/domain"
+	"testing"
+	"time"
+
+	"github.com/go-jose/go-jose/v4"
+	"github.com/go-jose/go-jose/v4/jwt"
+	"github.com/stretchr/testify/require"
+)
+
+// mockIssuer is a minimal OpenID Connect issuer accepting a single authorization code.
+type mockIssuer struct {
+	server    *httptest.Server
+	key       *rsa.PrivateKey
+	code      string
+	challenge string
+	nonce     string
+}
+
+// newMockIssuer starts a new mockIssuer.
+func newMockIssuer(t *testing.T) *mockIssuer {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	issuer := &mockIssuer{key: key, code: "code"}
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, map[string]any{
+			"issuer":                                issuer.server.URL,
+			"authorization_endpoint":                issuer.server.URL + "/authorize",
+			"token_endpoint":                        issuer.server.URL + "/token",
+			"jwks_uri":                              issuer.server.URL + "/jwks",
+			"id_token_signing_alg_values_supported": []string{"RS256"},
+		})
+	})
+	mux.HandleFunc("GET /jwks", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, jose.JSONWebKeySet{Keys: []jose.JSONWebKey{{
+			Key:       &key.PublicKey,
+			KeyID:     "key",
+			Algorithm: string(jose.RS256),
+			Use:       "sig",
+		}}})
+	})
+	mux.HandleFunc("POST /token", func(w http.ResponseWriter, r *http.Request) {
+		verifier := sha256.Sum256([]byte(r.PostFormValue("code_verifier")))
+		if r.PostFormValue("code") != issuer.code ||
+			base64.RawURLEncoding.EncodeToString(verifier[:]) != issuer.challenge {
+			w.WriteHeader(http.StatusBadRequest)
+			writeJSON(w, map[string]string{"error": "invalid_grant"})
+			return
+		}
+
+		idToken, err := issuer.idToken()
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		writeJSON(w, map[string]any{
+			"access_token": "access",
+			"token_type":   "Bearer",
+			"expires_in":   3600,
+			"id_token":     idToken,
+		})
+	})
+
+	issuer.server = httptest.NewServer(mux)
+	t.Cleanup(issuer.server.Close)
+	return issuer
+}
+
+// idToken signs an ID token for the pending login.
+func (i *mockIssuer) idToken() (string, error) {
+	signer, err := jose.NewSigner(
+		jose.SigningKey{Algorithm: jose.RS256, Key: i.key},
+		(&jose.SignerOptions{}).WithHeader("kid", "key"),
+	)
+	if err != nil {
+		return "", err
+	}
+
+	now := time.Now()
+	return jwt.Signed(signer).Claims(map[string]any{
+		"iss":                i.server.URL,
+		"sub":                "subject",
+		"aud":                "client",
+		"iat":                now.Unix(),
+		"exp":                now.Add(time.Minute).Unix(),
+		"nonce":              i.nonce,
+		"email":              "user@example.com",
+		"email_verified":     true,
+		"preferred_username": "external.user",
+	}).Serialize()
+}
+
+// authorize simulates the user consenting on the authorization endpoint.
+func (i *mockIssuer) authorize(t *testing.T, authURL string) {
+	parsed, err := url.Parse(authURL)
+	require.NoError(t, err)
+
+	query := parsed.Query()
+	require.Equal(t, i.server.URL+"/authorize", parsed.Scheme+"://"+parsed.Host+parsed.Path)
+	require.Equal(t, "client", query.Get("client_id"))
+	require.Equal(t, "http://localhost:8080/callback", query.Get("redirect_uri"))
+	require.Equal(t, "S256", query.Get("code_challenge_method"))
+	require.NotEmpty(t, query.Get("state"))
+
+	i.challenge = query.Get("code_challenge")
+	i.nonce = query.Get("nonce")
+}
+
+func writeJSON(w http.ResponseWriter, value any) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(value)
+}
+
+func newTestIdentityProvider(issuer *mockIssuer) *IdentityProvider {
+	return NewIdentityProvider(&config.OAuthConfig{
+		StateExpireTime: 10 * time.Minute,
+		Providers: map[string]*config.OAuthProviderConfig{
+			"mock": {
+				Type:        config.OIDCProvider,
+				Issuer:      issuer.server.URL,
+				ClientId:    "client",
+				RedirectURL: "http://localhost:8080/callback",
+				Scopes:      []string{"openid", "email", "profile"},
+			},
+		},
+	})
+}
+
+func TestIdentityProvider_OIDC(t *testing.T) {
+	tests := []struct {
+		name             string
+		tamper           func(issuer *mockIssuer, state *domain.OAuthState)
+		expectedIdentity *domain.ExternalIdentity
+		expectedError    error
+	}{
+		{
+			name:   "success",
+			tamper: func(*mockIssuer, *domain.OAuthState) {},
+			expectedIdentity: &domain.ExternalIdentity{
+				Provider:          "mock",
+				Subject:           "subject",
+				Email:             "user@example.com",
+				EmailVerified:     true,
+				PreferredUsername: "external.user",
+			},
+		}, {
+			name: "wrong code verifier",
+			tamper: func(_ *mockIssuer, state *domain.OAuthState) {
+				state.CodeVerifier = "wrong"
+			},
+			expectedError: domain.ErrExternalAuthFailed,
+		}, {
+			name: "wrong nonce",
+			tamper: func(issuer *mockIssuer, _ *domain.OAuthState) {
+				issuer.nonce = "wrong"
+			},
+			expectedError: domain.ErrExternalAuthFailed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			issuer := newMockIssuer(t)
+			provider := newTestIdentityProvider(issuer)
+
+			authorization, err := provider.Authorize(context.Background(), "mock")
+			require.NoError(t, err)
+			require.Equal(t, "mock", authorization.State.Provider)
+			issuer.authorize(t, authorization.URL)
+
+			tt.tamper(issuer, authorization.State)
+			identity, err := provider.Exchange(context.Background(), authorization.State, "code")
+
+			if tt.expectedError != nil {
+				require.ErrorIs(t, err, tt.expectedError)
+			} else {
+				require.NoError(t, err)
+			}
+			require.Equal(t, tt.expectedIdentity, identity)
+		})
+	}
+}
+
+func TestIdentityProvider_UnknownProvider(t *testing.T) {
+	provider := newTestIdentityProvider(newMockIssuer(t))
+
+	_, err := provider.Authorize(context.Background(), "unknown")
+	require.ErrorIs(t, err, domain.ErrIdentityProviderNotFound)
+}
@@ -0,0 +1,124 @@
+package password
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"fmt"
+	"shop-api-go/internal/core/domain"
+	"strings"
+
+	"go.uber.org/zap"
+	"golang.org/x/crypto/argon2"
+)
+
+const (
+	// argon2idPrefix is the prefix of hashes in the PHC string format produced by argon2idAlgorithm.
+	argon2idPrefix     = "$argon2id$"
+	argon2idSaltLength = 16
+	argon2idKeyLength  = 32
+)
+
+// argon2idParams are the cost parameters of an Argon2id hash.
+type argon2idParams struct {
+	memory      uint32
+	iterations  uint32
+	parallelism uint8
+}
+
+// argon2idAlgorithm implements algorithm with Argon2id, hashes are encoded in the PHC string format:
+// $argon2id$v=19$m=<memory>,t=<iterations>,p=<parallelism>$<salt>$<key>.
+type argon2idAlgorithm struct {
+	params argon2idParams
+}
+
+// newArgon2id creates a new argon2idAlgorithm instance.
+func newArgon2id(memory, iterations uint32, parallelism uint8) *argon2idAlgorithm {
+	return &argon2idAlgorithm{
+		params: argon2idParams{
+			memory:      memory,
+			iterations:  iterations,
+			parallelism: parallelism,
+		},
+	}
+}
+
+func (a *argon2idAlgorithm) hash(password string) (string, error) {
+	salt := make([]byte, argon2idSaltLength)
+	if _, err := rand.Read(salt); err != nil {
+		zap.L().Error(
+			"argon2id salt generation failed",
+			zap.Error(err),
+		)
+		return "", domain.ErrInternal
+	}
+
+	key := argon2.IDKey([]byte(password), salt, a.params.iterations, a.params.memory, a.params.parallelism, argon2idKeyLength)
+	return fmt.Sprintf(
+		"%sv=%d$m=%d,t=%d,p=%d$%s$%s",
+		argon2idPrefix,
+		argon2.Version,
+		a.params.memory,
+		a.params.iterations,
+		a.params.parallelism,
+		base64.RawStdEncoding.EncodeToString(salt),
+		base64.RawStdEncoding.EncodeToString(key),
+	), nil
+}
+
+func (a *argon2idAlgorithm) compare(password, hash string) error {
+	params, salt, key, err := decodeArgon2id(hash)
+	if err != nil {
+		zap.L().Error(
+			"invalid argon2id hash",
+			zap.Error(err),
+		)
+		return domain.ErrInternal
+	}
+
+	computed := argon2.IDKey([]byte(password), salt, params.iterations, params.memory, params.parallelism, uint32(len(key)))
+	if subtle.ConstantTimeCompare(computed, key) != 1 {
+		return domain.ErrWrongCredentials
+	}
+	return nil
+}
+
+func (a *argon2idAlgorithm) outdated(hash string) bool {
+	params, _, _, err := decodeArgon2id(hash)
+	return err != nil || params != a.params
+}
+
+func (a *argon2idAlgorithm) recognizes(hash string) bool {
+	return strings.HasPrefix(hash, argon2idPrefix)
+}
+
+// decodeArgon2id parses the parameters, salt and key of an encoded Argon2id hash.
+func decodeArgon2id(hash string) (argon2idParams, []byte, []byte, error) {
+	parts := strings.Split(hash, "$")
+	if len(parts) != 6 {
+		return argon2idParams{}, nil, nil, fmt.Errorf("expected 6 parts, got %d", len(parts))
+	}
+
+	var version int
+	if _, err := fmt.Sscanf(parts[2], "v=%d", &version); err != nil {
+		return argon2idParams{}, nil, nil, fmt.Errorf("parsing version: %w", err)
+	}
+	if version != argon2.Version {
+		return argon2idParams{}, nil, nil, fmt.Errorf("unsupported version: %d", version)
+	}
+
+	var params argon2idParams
+	if _, err := fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &params.memory, &params.iterations, &params.parallelism); err != nil {
+		return argon2idParams{}, nil, nil, fmt.Errorf("parsing parameters: %w", err)
+	}
+
+	salt, err := base64.RawStdEncoding.DecodeString(parts[4])
+	if err != nil {
+		return argon2idParams{}, nil, nil, fmt.Errorf("decoding salt: %w", err)
+	}
+	key, err := base64.RawStdEncoding.DecodeString(parts[5])
+	if err != nil {
+		return argon2idParams{}, nil, nil, fmt.Errorf("decoding key: %w", err)
+	}
+	return params, salt, key, nil
+}
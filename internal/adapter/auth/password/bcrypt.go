@@ -0,0 +1,47 @@
+package password
+
+import (
+	"shop-api-go/internal/core/domain"
+	"strings"
+
+	"go.uber.org/zap"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// bcryptAlgorithm implements algorithm with bcrypt.
+type bcryptAlgorithm struct {
+	cost int
+}
+
+// newBcrypt creates a new bcryptAlgorithm instance.
+func newBcrypt(cost int) *bcryptAlgorithm {
+	return &bcryptAlgorithm{cost: cost}
+}
+
+func (b *bcryptAlgorithm) hash(password string) (string, error) {
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), b.cost)
+	if err != nil {
+		zap.L().Error(
+			"bcrypt hash failed",
+			zap.Error(err),
+		)
+		return "", domain.ErrInternal
+	}
+	return string(hash), nil
+}
+
+func (b *bcryptAlgorithm) compare(password, hash string) error {
+	if err := bcrypt.CompareHashAndPassword([]byte(hash), []byte(password)); err != nil {
+		return domain.ErrWrongCredentials
+	}
+	return nil
+}
+
+func (b *bcryptAlgorithm) outdated(hash string) bool {
+	cost, err := bcrypt.Cost([]byte(hash))
+	return err != nil || cost != b.cost
+}
+
+func (b *bcryptAlgorithm) recognizes(hash string) bool {
+	return strings.HasPrefix(hash, "$2a$") || strings.HasPrefix(hash, "$2b$") || strings.HasPrefix(hash, "$2y$")
+}
@@ -0,0 +1,61 @@
+package password
+
+import (
+	"shop-api-go/internal/adapter/config"
+	"shop-api-go/internal/core/domain"
+
+	"go.uber.org/zap"
+)
+
+// algorithm is an interface for a password hashing algorithm producing self-describing hashes.
+type algorithm interface {
+	// hash returns the encoded hash of the password.
+	hash(password string) (string, error)
+	// compare validates that the password and the encoded hash match.
+	compare(password, hash string) error
+	// outdated checks whether the encoded hash uses other parameters than the configured ones.
+	outdated(hash string) bool
+	// recognizes checks whether the encoded hash was produced by the algorithm.
+	recognizes(hash string) bool
+}
+
+// PasswordHasher implements port.PasswordHasher and provides password hashing with
+// the configured algorithm, while still accepting hashes of the other supported algorithms.
+type PasswordHasher struct {
+	current    algorithm
+	algorithms []algorithm
+}
+
+// NewPasswordHasher creates a new PasswordHasher instance.
+func NewPasswordHasher(passwordConfig *config.PasswordConfig) *PasswordHasher {
+	argon2id := newArgon2id(passwordConfig.Argon2Memory, passwordConfig.Argon2Iterations, passwordConfig.Argon2Parallelism)
+	bcrypt := newBcrypt(passwordConfig.BcryptCost)
+
+	current := algorithm(argon2id)
+	if passwordConfig.Algorithm == config.Bcrypt {
+		current = bcrypt
+	}
+	return &PasswordHasher{
+		current:    current,
+		algorithms: []algorithm{argon2id, bcrypt},
+	}
+}
+
+func (p *PasswordHasher) Hash(password string) (string, error) {
+	return p.current.hash(password)
+}
+
+func (p *PasswordHasher) Compare(password, hash string) error {
+	for _, a := range p.algorithms {
+		if a.recognizes(hash) {
+			return a.compare(password, hash)
+		}
+	}
+
+	zap.L().Error("unknown password hash format")
+	return domain.ErrInternal
+}
+
+func (p *PasswordHasher) NeedsRehash(hash string) bool {
+	return !p.current.recognizes(hash) || p.current.outdated(hash)
+}
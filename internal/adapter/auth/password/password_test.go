@@ -0,0 +1,64 @@
+package password
+
+import (
+	"shop-api-go/internal/adapter/config"
+	"shop-api-go/internal/core/domain"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// newTestConfig creates a PasswordConfig with cheap parameters.
+func newTestConfig(algorithm config.PasswordAlgorithm) *config.PasswordConfig {
+	return &config.PasswordConfig{
+		Algorithm:         algorithm,
+		BcryptCost:        4,
+		Argon2Memory:      64,
+		Argon2Iterations:  1,
+		Argon2Parallelism: 1,
+	}
+}
+
+func TestPasswordHasher_HashAndCompare(t *testing.T) {
+	for algorithm, prefix := range map[config.PasswordAlgorithm]string{
+		config.Argon2id: "$argon2id$v=19$m=64,t=1,p=1$",
+		config.Bcrypt:   "$2a$04$",
+	} {
+		t.Run(string(algorithm), func(t *testing.T) {
+			hasher := NewPasswordHasher(newTestConfig(algorithm))
+
+			hash, err := hasher.Hash("password")
+			require.NoError(t, err)
+			require.True(t, strings.HasPrefix(hash, prefix))
+
+			require.NoError(t, hasher.Compare("password", hash))
+			require.ErrorIs(t, hasher.Compare("wrong", hash), domain.ErrWrongCredentials)
+			require.False(t, hasher.NeedsRehash(hash))
+		})
+	}
+}
+
+func TestPasswordHasher_NeedsRehash(t *testing.T) {
+	bcryptHash, err := NewPasswordHasher(newTestConfig(config.Bcrypt)).Hash("password")
+	require.NoError(t, err)
+	argon2idHash, err := NewPasswordHasher(newTestConfig(config.Argon2id)).Hash("password")
+	require.NoError(t, err)
+
+	stronger := newTestConfig(config.Argon2id)
+	stronger.Argon2Iterations = 2
+	hasher := NewPasswordHasher(stronger)
+
+	// Hashes of other algorithms and outdated parameters are still accepted, but must be rehashed.
+	require.NoError(t, hasher.Compare("password", bcryptHash))
+	require.True(t, hasher.NeedsRehash(bcryptHash))
+	require.NoError(t, hasher.Compare("password", argon2idHash))
+	require.True(t, hasher.NeedsRehash(argon2idHash))
+}
+
+func TestPasswordHasher_CompareInvalidHash(t *testing.T) {
+	hasher := NewPasswordHasher(newTestConfig(config.Argon2id))
+
+	require.ErrorIs(t, hasher.Compare("password", "plain"), domain.ErrInternal)
+	require.ErrorIs(t, hasher.Compare("password", "$argon2id$v=19$m=64"), domain.ErrInternal)
+}
@@ -0,0 +1,156 @@
+package password
+
+import (
+	"bufio"
+	"crypto/sha1"
+	_ "embed"
+	"encoding/hex"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"shop-api-go/internal/adapter/config"
+	"shop-api-go/internal/core/domain"
+	"strings"
+	"unicode"
+
+	"go.uber.org/zap"
+)
+
+// commonPasswordList is a bundled list of the most common passwords, one lowercase password per line.
+//
+//go:embed common_passwords.txt
+var commonPasswordList string
+
+// breachedPrefixLength is the length of the SHA-1 hash prefix used to name the breached hash files.
+const breachedPrefixLength = 5
+
+// leetReplacer replaces common character substitutions with the letters they stand for.
+var leetReplacer = strings.NewReplacer("@", "a", "4", "a", "3", "e", "1", "i", "0", "o", "$", "s", "5", "s", "7", "t")
+
+// Policy implements port.PasswordPolicy and screens passwords against a list of common passwords,
+// a local breached password corpus and the user's own identifiers.
+type Policy struct {
+	common            map[string]struct{}
+	breachedHashesDir string
+}
+
+// NewPolicy creates a new Policy instance.
+//
+// Note: The breached password check is disabled if no breached hashes directory is configured.
+func NewPolicy(passwordConfig *config.PasswordConfig) *Policy {
+	common := make(map[string]struct{})
+	for _, line := range strings.Split(commonPasswordList, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			common[line] = struct{}{}
+		}
+	}
+
+	return &Policy{
+		common:            common,
+		breachedHashesDir: passwordConfig.BreachedHashesDir,
+	}
+}
+
+func (p *Policy) Validate(password, username, email string) error {
+	violations := make([]domain.PasswordViolation, 0)
+
+	if p.isCommon(password) {
+		violations = append(violations, domain.PasswordCommon)
+	}
+
+	breached, err := p.isBreached(password)
+	if err != nil {
+		return err
+	}
+	if breached {
+		violations = append(violations, domain.PasswordBreached)
+	}
+
+	lower := strings.ToLower(password)
+	if username != "" && strings.Contains(lower, strings.ToLower(username)) {
+		violations = append(violations, domain.PasswordContainsUsername)
+	}
+	if containsEmail(lower, strings.ToLower(email)) {
+		violations = append(violations, domain.PasswordContainsEmail)
+	}
+
+	if len(violations) > 0 {
+		return domain.NewPasswordPolicyError(violations)
+	}
+	return nil
+}
+
+// isCommon checks whether the password, or its letters without the usual decorations
+// such as a trailing number or symbol and character substitutions, is a common password.
+func (p *Policy) isCommon(password string) bool {
+	lower := strings.ToLower(password)
+	if _, ok := p.common[lower]; ok {
+		return true
+	}
+
+	core := strings.TrimFunc(lower, func(r rune) bool {
+		return unicode.IsDigit(r) || unicode.IsPunct(r) || unicode.IsSymbol(r)
+	})
+	_, ok := p.common[leetReplacer.Replace(core)]
+	return ok
+}
+
+// isBreached checks whether the SHA-1 hash of the password is in the breached hashes directory.
+//
+// The directory uses the k-anonymity range format: each file is named after the first five
+// hexadecimal characters of the hashes, e.g. 5BAA6.txt, and contains SUFFIX:COUNT lines.
+func (p *Policy) isBreached(password string) (bool, error) {
+	if p.breachedHashesDir == "" {
+		return false, nil
+	}
+
+	sum := sha1.Sum([]byte(password))
+	hash := strings.ToUpper(hex.EncodeToString(sum[:]))
+	prefix, suffix := hash[:breachedPrefixLength], hash[breachedPrefixLength:]
+
+	file, err := os.Open(filepath.Join(p.breachedHashesDir, prefix+".txt"))
+	if errors.Is(err, fs.ErrNotExist) {
+		return false, nil
+	} else if err != nil {
+		zap.L().Error(
+			"error opening breached hashes file",
+			zap.String("prefix", prefix),
+			zap.Error(err),
+		)
+		return false, domain.ErrInternal
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		lineSuffix, _, _ := strings.Cut(strings.TrimSpace(scanner.Text()), ":")
+		if strings.EqualFold(lineSuffix, suffix) {
+			return true, nil
+		}
+	}
+	if err = scanner.Err(); err != nil {
+		zap.L().Error(
+			"error reading breached hashes file",
+			zap.String("prefix", prefix),
+			zap.Error(err),
+		)
+		return false, domain.ErrInternal
+	}
+	return false, nil
+}
+
+// containsEmail checks whether the lowercase password contains the email or its local part.
+//
+// Note: Local parts shorter than 3 characters are ignored to avoid rejecting unrelated passwords.
+func containsEmail(password, email string) bool {
+	if email == "" {
+		return false
+	}
+	if strings.Contains(password, email) {
+		return true
+	}
+
+	local, _, _ := strings.Cut(email, "@")
+	return len(local) >= 3 && strings.Contains(password, local)
+}
@@ -0,0 +1,58 @@
+package password
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"shop-api-go/internal/adapter/config"
+	"shop-api-go/internal/core/domain"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPolicy_Validate(t *testing.T) {
+	// Write a breached hashes file in the k-anonymity range format for one password.
+	dir := t.TempDir()
+	sum := sha1.Sum([]byte("Breached_Pass1"))
+	hash := strings.ToUpper(hex.EncodeToString(sum[:]))
+	content := "0000000000000000000000000000000000A:3\n" + hash[5:] + ":42\n"
+	require.NoError(t, os.WriteFile(filepath.Join(dir, hash[:5]+".txt"), []byte(content), 0o600))
+
+	policy := NewPolicy(&config.PasswordConfig{BreachedHashesDir: dir})
+
+	tests := []struct {
+		name       string
+		password   string
+		violations []domain.PasswordViolation
+	}{
+		{"valid", "Tr4vel-Kettle!", nil},
+		{"common", "Password1!", []domain.PasswordViolation{domain.PasswordCommon}},
+		{"common with substitutions", "P@ssw0rd!", []domain.PasswordViolation{domain.PasswordCommon}},
+		{"breached", "Breached_Pass1", []domain.PasswordViolation{domain.PasswordBreached}},
+		{"contains username", "Xviktor123x!", []domain.PasswordViolation{domain.PasswordContainsUsername}},
+		{"contains email", "My-Viktor.S-1", []domain.PasswordViolation{domain.PasswordContainsEmail}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := policy.Validate(tt.password, "Viktor123", "viktor.s@email.com")
+			if tt.violations == nil {
+				require.NoError(t, err)
+				return
+			}
+
+			require.ErrorIs(t, err, domain.ErrWeakPassword)
+			var policyErr *domain.PasswordPolicyError
+			require.ErrorAs(t, err, &policyErr)
+			require.Equal(t, tt.violations, policyErr.Violations)
+		})
+	}
+}
+
+func TestPolicy_ValidateWithoutBreachedHashes(t *testing.T) {
+	policy := NewPolicy(&config.PasswordConfig{})
+	require.NoError(t, policy.Validate("Breached_Pass1", "username", "user@email.com"))
+}
@@ -0,0 +1,150 @@
+package totp
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base32"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"shop-api-go/internal/adapter/config"
+	"shop-api-go/internal/core/domain"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+const (
+	// secretSize is the size of the generated secrets in bytes.
+	secretSize = 20
+	// digits is the number of digits of a code.
+	digits = 6
+	// period is the duration of a single time step.
+	period = 30 * time.Second
+	// skew is the number of time steps before and after the current one that are accepted.
+	skew = 1
+	// recoveryCodeAlphabet is the alphabet used for recovery codes, it has exactly 32 characters.
+	recoveryCodeAlphabet = "abcdefghijklmnopqrstuvwxyz234567"
+)
+
+// encoding is the base32 encoding used for secrets.
+var encoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// MFAProvider implements port.MFAProvider and provides TOTP (RFC 6238) codes.
+type MFAProvider struct {
+	config *config.MFAConfig
+	now    func() time.Time
+}
+
+// NewMFAProvider creates a new MFAProvider instance.
+func NewMFAProvider(config *config.MFAConfig) *MFAProvider {
+	return &MFAProvider{
+		config: config,
+		now:    time.Now,
+	}
+}
+
+func (p *MFAProvider) GenerateSecret() (string, error) {
+	secret := make([]byte, secretSize)
+	if _, err := rand.Read(secret); err != nil {
+		zap.L().Error(
+			"generating totp secret failed",
+			zap.Error(err),
+		)
+		return "", domain.ErrInternal
+	}
+	return encoding.EncodeToString(secret), nil
+}
+
+func (p *MFAProvider) ProvisioningURI(accountName, secret string) string {
+	query := url.Values{}
+	query.Set("secret", secret)
+	query.Set("issuer", p.config.Issuer)
+	query.Set("algorithm", "SHA1")
+	query.Set("digits", fmt.Sprint(digits))
+	query.Set("period", fmt.Sprint(int(period.Seconds())))
+
+	uri := url.URL{
+		Scheme:   "otpauth",
+		Host:     "totp",
+		Path:     "/" + p.config.Issuer + ":" + accountName,
+		RawQuery: query.Encode(),
+	}
+	return uri.String()
+}
+
+func (p *MFAProvider) ValidateCode(code, secret string) (int64, bool) {
+	code = strings.TrimSpace(code)
+	if len(code) != digits {
+		return 0, false
+	}
+
+	key, err := encoding.DecodeString(strings.ToUpper(secret))
+	if err != nil {
+		return 0, false
+	}
+
+	current := p.now().Unix() / int64(period.Seconds())
+	for step := current - skew; step <= current+skew; step++ {
+		if subtle.ConstantTimeCompare([]byte(generateCode(key, step)), []byte(code)) == 1 {
+			return step, true
+		}
+	}
+	return 0, false
+}
+
+func (p *MFAProvider) GenerateRecoveryCodes(count int) ([]string, error) {
+	codes := make([]string, 0, count)
+	for range count {
+		raw := make([]byte, 10)
+		if _, err := rand.Read(raw); err != nil {
+			zap.L().Error(
+				"generating recovery code failed",
+				zap.Error(err),
+			)
+			return nil, domain.ErrInternal
+		}
+
+		var builder strings.Builder
+		for i, b := range raw {
+			if i == len(raw)/2 {
+				builder.WriteByte('-')
+			}
+			builder.WriteByte(recoveryCodeAlphabet[b&31])
+		}
+		codes = append(codes, builder.String())
+	}
+	return codes, nil
+}
+
+// HashRecoveryCode computes the HMAC-SHA256 of the normalized code. Recovery codes have 50 bits of entropy,
+// so a keyed hash is enough and lets the code be looked up without a slow password hash per stored code.
+func (p *MFAProvider) HashRecoveryCode(code string) string {
+	mac := hmac.New(sha256.New, p.config.RecoveryCodeSecret)
+	mac.Write([]byte(strings.ToLower(strings.TrimSpace(code))))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// generateCode computes the HOTP (RFC 4226) value of the key for a specific counter.
+func generateCode(key []byte, counter int64) string {
+	message := make([]byte, 8)
+	binary.BigEndian.PutUint64(message, uint64(counter))
+
+	mac := hmac.New(sha1.New, key)
+	mac.Write(message)
+	sum := mac.Sum(nil)
+
+	offset := sum[len(sum)-1] & 0x0f
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+
+	mod := uint32(1)
+	for range digits {
+		mod *= 10
+	}
+	return fmt.Sprintf("%0*d", digits, value%mod)
+}
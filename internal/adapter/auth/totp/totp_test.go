@@ -0,0 +1,64 @@
+package totp
+
+import (
+	"shop-api-go/internal/adapter/config"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_generateCode(t *testing.T) {
+	// Test vectors from RFC 6238 appendix B truncated to 6 digits.
+	key := []byte("12345678901234567890")
+	tests := []struct {
+		unix int64
+		code string
+	}{
+		{59, "287082"},
+		{1111111109, "081804"},
+		{1111111111, "050471"},
+		{1234567890, "005924"},
+		{2000000000, "279037"},
+		{20000000000, "353130"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.code, func(t *testing.T) {
+			require.Equal(t, test.code, generateCode(key, test.unix/30))
+		})
+	}
+}
+
+func TestMFAProvider_ValidateCode(t *testing.T) {
+	secret := encoding.EncodeToString([]byte("12345678901234567890"))
+	provider := NewMFAProvider(&config.MFAConfig{Issuer: "shop"})
+	provider.now = func() time.Time {
+		return time.Unix(1111111111, 0)
+	}
+
+	step, ok := provider.ValidateCode("050471", secret)
+	require.True(t, ok)
+	require.Equal(t, int64(1111111111/30), step)
+
+	_, ok = provider.ValidateCode("081804", secret)
+	require.True(t, ok)
+
+	_, ok = provider.ValidateCode("287082", secret)
+	require.False(t, ok)
+
+	_, ok = provider.ValidateCode("12345", secret)
+	require.False(t, ok)
+}
+
+func TestMFAProvider_HashRecoveryCode(t *testing.T) {
+	provider := NewMFAProvider(&config.MFAConfig{Issuer: "shop", RecoveryCodeSecret: []byte("key")})
+
+	hash := provider.HashRecoveryCode("abcde-fghij")
+	require.Len(t, hash, 64)
+	require.Equal(t, hash, provider.HashRecoveryCode(" ABCDE-FGHIJ "))
+	require.NotEqual(t, hash, provider.HashRecoveryCode("abcde-fghik"))
+
+	other := NewMFAProvider(&config.MFAConfig{Issuer: "shop", RecoveryCodeSecret: []byte("other-key")})
+	require.NotEqual(t, hash, other.HashRecoveryCode("abcde-fghij"))
+}
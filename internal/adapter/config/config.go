@@ -1,58 +1,43 @@
 package config
 
 import (
-	"fmt"
 	"log"
-	"os"
-	"strconv"
+	"net"
+	"shop-api-go/internal/core/domain"
+	"strings"
 	"time"
 
 	"github.com/joho/godotenv"
 )
 
-// getEnv is helper function used to get env variable, if the variable doesn't exist fallback is returned.
-func getEnv(key, fallback string) string {
-	if value, ok := os.LookupEnv(key); ok {
-		return value
-	}
-	return fallback
-}
-
-// getEnvInt is helper function used to get integer env variable,
-// if the variable doesn't exist or is invalid integer fallback is returned.
-func getEnvInt(key string, fallback int) int {
-	if value, ok := os.LookupEnv(key); ok {
-		if i, err := strconv.Atoi(value); err == nil {
-			return i
-		}
-	}
-	return fallback
-}
-
-// getEnvDuration is a helper function used to get time.Duration env variable,
-// if the variable doesn't exist or is invalid time.Duration fallback is returned.
-func getEnvDuration(key string, fallback time.Duration) time.Duration {
-	if value, ok := os.LookupEnv(key); ok {
-		if i, err := time.ParseDuration(value); err == nil {
-			return i
-		}
-	}
-	return fallback
-}
-
 type (
 	// Environment is an enum for different app environments.
 	Environment string
 	// Container contains all environment variables.
 	Container struct {
-		App      *AppConfig
-		Database *DBConfig
-		JWT      *JWTConfig
+		App       *AppConfig
+		Database  *DBConfig
+		JWT       *JWTConfig
+		MFA       *MFAConfig
+		Login     *LoginConfig
+		RateLimit *RateLimitConfig
+		OAuth     *OAuthConfig
+		APIKey    *APIKeyConfig
+		Password  *PasswordConfig
+		GraphQL   *GraphQLConfig
+		GRPC      *GRPCConfig
+		Outbox    *OutboxConfig
+		Webhook   *WebhookConfig
+		Runtime   *RuntimeConfig
+
+		settings []Setting
 	}
 	// AppConfig contains all environment variable for the application.
 	AppConfig struct {
-		Environment Environment
-		Port        string
+		Environment    Environment
+		Port           string
+		LogLevel       domain.LogLevel
+		TrustedProxies []string
 	}
 
 	// DBConfig contains all environment variables for the database.
@@ -60,6 +45,7 @@ type (
 		Url                string
 		MaxIdleConnections int
 		MaxOpenConnections int
+		AutoMigrate        bool
 	}
 
 	// JWTConfig contains all environment variables for the JWTConfig tokens.
@@ -69,7 +55,115 @@ type (
 		Audience               string
 		RefreshTokenExpireTime time.Duration
 		AccessTokenExpireTime  time.Duration
+		MFATokenExpireTime     time.Duration
+
+		ImpersonationTokenExpireTime time.Duration
+	}
+
+	// MFAConfig contains all environment variables for multi-factor authentication.
+	MFAConfig struct {
+		Issuer             string
+		RecoveryCodeSecret []byte
+	}
+
+	// LoginConfig contains all environment variables for login throttling.
+	LoginConfig struct {
+		AttemptStore    Store
+		MaxUserFailures int
+		MaxIPFailures   int
+		LockoutDuration time.Duration
+		BaseBackoff     time.Duration
+		MaxBackoff      time.Duration
+		AttemptWindow   time.Duration
+	}
+
+	// RateLimitConfig contains all environment variables for HTTP rate limiting.
+	RateLimitConfig struct {
+		Enabled bool
+		Store   Store
+		Groups  map[RateLimitGroup]domain.RateLimitPolicy
+	}
+
+	// OAuthConfig contains all environment variables for login with external identity providers.
+	OAuthConfig struct {
+		StateExpireTime time.Duration
+		Providers       map[string]*OAuthProviderConfig
+	}
+
+	// APIKeyConfig contains all environment variables for API keys and personal access tokens.
+	APIKeyConfig struct {
+		DefaultExpireTime time.Duration
+		MaxExpireTime     time.Duration
+	}
+
+	// PasswordConfig contains all environment variables for password hashing.
+	PasswordConfig struct {
+		Algorithm         PasswordAlgorithm
+		BcryptCost        int
+		Argon2Memory      uint32
+		Argon2Iterations  uint32
+		Argon2Parallelism uint8
+		BreachedHashesDir string
+	}
+
+	// GraphQLConfig contains all environment variables for the GraphQL API.
+	GraphQLConfig struct {
+		MaxDepth      int
+		MaxComplexity int
 	}
+
+	// GRPCConfig contains all environment variables for the gRPC server.
+	GRPCConfig struct {
+		Port string
+	}
+
+	// OutboxConfig contains all environment variables for relaying the events of the outbox.
+	OutboxConfig struct {
+		RelayInterval time.Duration
+		BatchSize     int
+		BaseBackoff   time.Duration
+		MaxBackoff    time.Duration
+		Retention     time.Duration
+	}
+
+	// WebhookConfig contains all environment variables for delivering webhooks.
+	WebhookConfig struct {
+		Workers              int
+		PollInterval         time.Duration
+		BatchSize            int
+		Timeout              time.Duration
+		MaxAttempts          int
+		BaseBackoff          time.Duration
+		MaxBackoff           time.Duration
+		RequireHTTPS         bool
+		AllowPrivateNetworks bool
+	}
+
+	// RuntimeConfig contains all environment variables for the settings that can be changed without a restart.
+	RuntimeConfig struct {
+		Store        Store
+		SettingsFile string
+		PollInterval time.Duration
+	}
+
+	// OAuthProviderConfig contains all environment variables for a single identity provider.
+	OAuthProviderConfig struct {
+		Type         OAuthProviderType
+		Issuer       string
+		ClientId     string
+		ClientSecret string
+		RedirectURL  string
+		Scopes       []string
+	}
+
+	// Store is an enum for different storage backends.
+	Store string
+	// RateLimitGroup is an enum for route groups with their own rate limits.
+	RateLimitGroup string
+	// OAuthProviderType is an enum for supported identity provider protocols.
+	OAuthProviderType string
+	// PasswordAlgorithm is an enum for supported password hashing algorithms.
+	PasswordAlgorithm string
 )
 
 const (
@@ -77,61 +171,405 @@ const (
 	Development Environment = "development"
 )
 
-// New creates a new Container instance.
-func New() (*Container, error) {
+const (
+	PostgresStore Store = "postgres"
+	MemoryStore   Store = "memory"
+	FileStore     Store = "file"
+)
+
+const (
+	UsersRateLimitGroup RateLimitGroup = "users"
+	AuthRateLimitGroup  RateLimitGroup = "auth"
+	AdminRateLimitGroup RateLimitGroup = "admin"
+)
+
+const (
+	OIDCProvider   OAuthProviderType = "oidc"
+	GitHubProvider OAuthProviderType = "github"
+)
+
+const (
+	Argon2id PasswordAlgorithm = "argon2id"
+	Bcrypt   PasswordAlgorithm = "bcrypt"
+)
+
+// defaultJWTSecret is the JWT secret used if none is set, it is refused in Production.
+const defaultJWTSecret = "secret"
+
+// defaultRecoveryCodeSecret is the key of the recovery code hashes used if none is set, it is refused in Production.
+const defaultRecoveryCodeSecret = "recovery-secret"
+
+// New creates a new Container instance. Every key is read from the first layer setting it:
+// the flags of the source, env variables, the config file and the defaults.
+// All invalid values are reported at once.
+func New(source *Source) (*Container, error) {
 	err := godotenv.Load()
 	if err != nil {
 		log.Printf("Error loading .env file: %v", err)
 	}
+	l := newLoader(source)
 
-	environment := Environment(getEnv("ENVIRONMENT", string(Development)))
+	environment := Environment(l.get("ENVIRONMENT", string(Development)))
 	if environment != Production && environment != Development {
-		return nil, fmt.Errorf("unknown environment: %s", environment)
+		l.errorf("unknown environment: %s", environment)
 	}
+	port := l.get("PORT", "8080")
 
-	maxOpenConnections := getEnvInt("DATABASE_MAX_OPEN_CONNECTIONS", 10)
+	var trustedProxies []string
+	for _, proxy := range strings.Split(l.get("TRUSTED_PROXIES", ""), ",") {
+		proxy = strings.TrimSpace(proxy)
+		if proxy == "" {
+			continue
+		}
+		if _, _, err := net.ParseCIDR(proxy); err != nil && net.ParseIP(proxy) == nil {
+			l.errorf("trusted proxy must be an IP or CIDR: %s", proxy)
+			continue
+		}
+		trustedProxies = append(trustedProxies, proxy)
+	}
+
+	defaultLogLevel := domain.LogInfo
+	if environment == Development {
+		defaultLogLevel = domain.LogDebug
+	}
+	logLevel := domain.LogLevel(l.get("LOG_LEVEL", string(defaultLogLevel)))
+	if !logLevel.IsValid() {
+		l.errorf("unknown log level: %s", logLevel)
+	}
+
+	databaseUrl, _ := l.value("DATABASE_URL", "", redactURL)
+
+	maxOpenConnections := l.getInt("DATABASE_MAX_OPEN_CONNECTIONS", 10)
 	if maxOpenConnections <= 0 {
-		return nil, fmt.Errorf("database max open connections must be > 0: %d", maxOpenConnections)
+		l.errorf("database max open connections must be > 0: %d", maxOpenConnections)
 	}
-	maxIdleConnections := getEnvInt("DATABASE_MAX_IDLE_CONNECTIONS", 10)
+	maxIdleConnections := l.getInt("DATABASE_MAX_IDLE_CONNECTIONS", 10)
 	if maxIdleConnections <= 0 {
-		return nil, fmt.Errorf("database max idle connections must be > 0: %d", maxIdleConnections)
+		l.errorf("database max idle connections must be > 0: %d", maxIdleConnections)
 	}
+	autoMigrate := l.getBool("DATABASE_AUTO_MIGRATE", false)
 
-	secret := getEnv("JWT_SECRET", "secret")
+	secret := l.getSecret("JWT_SECRET", defaultJWTSecret)
 	if secret == "" {
-		return nil, fmt.Errorf("jwt secret not be empty")
+		l.errorf("jwt secret must not be empty")
+	}
+	if secret == defaultJWTSecret {
+		if environment == Production {
+			l.errorf("jwt secret must be set in production")
+		} else {
+			log.Println("WARNING: JWT secret not set, using fallback")
+		}
 	}
-	if secret == "secret" {
-		log.Println("WARNING: JWT secret not set, using fallback")
+	jwtIssuer := l.get("JWT_ISSUER", "my-app")
+	jwtAudience := l.get("JWT_AUDIENCE", "my-app-users")
+
+	refreshTokenExpireTime := l.getDuration("JWT_REFRESH_TOKEN_EXPIRE_TIME", 24*time.Hour)
+	if refreshTokenExpireTime <= 0 || refreshTokenExpireTime > domain.MaxRefreshTokenExpireTime {
+		l.errorf("jwt refresh token expire time must be > 0 and <= %s: %s", domain.MaxRefreshTokenExpireTime, refreshTokenExpireTime)
 	}
 
-	refreshTokenExpireTime := getEnvDuration("JWT_REFRESH_TOKEN_EXPIRE_TIME", 24*time.Hour)
-	if refreshTokenExpireTime <= 0 {
-		return nil, fmt.Errorf("jwt refresh token expire time must be > 0: %d", refreshTokenExpireTime)
+	accessTokenExpireTime := l.getDuration("JWT_ACCESS_TOKEN_EXPIRE_TIME", 30*time.Minute)
+	if accessTokenExpireTime <= 0 || accessTokenExpireTime > domain.MaxAccessTokenExpireTime {
+		l.errorf("jwt access token expire time must be > 0 and <= %s: %s", domain.MaxAccessTokenExpireTime, accessTokenExpireTime)
+	}
+
+	mfaTokenExpireTime := l.getDuration("JWT_MFA_TOKEN_EXPIRE_TIME", 5*time.Minute)
+	if mfaTokenExpireTime <= 0 || mfaTokenExpireTime > domain.MaxMFATokenExpireTime {
+		l.errorf("jwt mfa token expire time must be > 0 and <= %s: %s", domain.MaxMFATokenExpireTime, mfaTokenExpireTime)
+	}
+
+	impersonationTokenExpireTime := l.getDuration("JWT_IMPERSONATION_TOKEN_EXPIRE_TIME", 15*time.Minute)
+	if impersonationTokenExpireTime <= 0 || impersonationTokenExpireTime > domain.MaxImpersonationTokenExpireTime {
+		l.errorf("jwt impersonation token expire time must be > 0 and <= %s: %s", domain.MaxImpersonationTokenExpireTime, impersonationTokenExpireTime)
+	}
+
+	mfaIssuer := l.get("MFA_ISSUER", "shop-api")
+	recoveryCodeSecret := l.getSecret("MFA_RECOVERY_CODE_SECRET", defaultRecoveryCodeSecret)
+	if recoveryCodeSecret == "" {
+		l.errorf("mfa recovery code secret must not be empty")
+	}
+	if recoveryCodeSecret == defaultRecoveryCodeSecret {
+		if environment == Production {
+			l.errorf("mfa recovery code secret must be set in production")
+		} else {
+			log.Println("WARNING: MFA recovery code secret not set, using fallback")
+		}
 	}
 
-	accessTokenExpireTime := getEnvDuration("JWT_ACCESS_TOKEN_EXPIRE_TIME", 30*time.Minute)
-	if accessTokenExpireTime <= 0 {
-		return nil, fmt.Errorf("jwt access token expire time must be > 0: %d", accessTokenExpireTime)
+	attemptStore := Store(l.get("LOGIN_ATTEMPT_STORE", string(PostgresStore)))
+	if attemptStore != PostgresStore && attemptStore != MemoryStore {
+		l.errorf("unknown login attempt store: %s", attemptStore)
+	}
+
+	maxUserFailures := l.getInt("LOGIN_MAX_USER_FAILURES", 5)
+	if maxUserFailures <= 0 {
+		l.errorf("login max user failures must be > 0: %d", maxUserFailures)
+	}
+	maxIPFailures := l.getInt("LOGIN_MAX_IP_FAILURES", 50)
+	if maxIPFailures <= 0 {
+		l.errorf("login max ip failures must be > 0: %d", maxIPFailures)
+	}
+
+	lockoutDuration := l.getDuration("LOGIN_LOCKOUT_DURATION", 15*time.Minute)
+	if lockoutDuration <= 0 {
+		l.errorf("login lockout duration must be > 0: %s", lockoutDuration)
+	}
+
+	baseBackoff := l.getDuration("LOGIN_BACKOFF_BASE", time.Second)
+	if baseBackoff < 0 {
+		l.errorf("login backoff base must be >= 0: %s", baseBackoff)
+	}
+	maxBackoff := l.getDuration("LOGIN_BACKOFF_MAX", 30*time.Second)
+	if maxBackoff < baseBackoff {
+		l.errorf("login backoff max must be >= login backoff base: %s", maxBackoff)
+	}
+
+	attemptWindow := l.getDuration("LOGIN_ATTEMPT_WINDOW", time.Hour)
+	if attemptWindow <= 0 {
+		l.errorf("login attempt window must be > 0: %s", attemptWindow)
+	}
+
+	rateLimitEnabled := l.getBool("RATE_LIMIT_ENABLED", true)
+	rateLimitStore := Store(l.get("RATE_LIMIT_STORE", string(MemoryStore)))
+	if rateLimitStore != PostgresStore && rateLimitStore != MemoryStore {
+		l.errorf("unknown rate limit store: %s", rateLimitStore)
+	}
+
+	rateLimitGroups := make(map[RateLimitGroup]domain.RateLimitPolicy)
+	for _, group := range []struct {
+		group    RateLimitGroup
+		fallback string
+	}{
+		{UsersRateLimitGroup, "20/1m"},
+		{AuthRateLimitGroup, "10/1m"},
+		{AdminRateLimitGroup, "300/1m"},
+	} {
+		rateLimitGroups[group.group] = l.rateLimitPolicy(group.group, group.fallback)
+	}
+
+	oauthStateExpireTime := l.getDuration("OAUTH_STATE_EXPIRE_TIME", 10*time.Minute)
+	if oauthStateExpireTime <= 0 {
+		l.errorf("oauth state expire time must be > 0: %s", oauthStateExpireTime)
+	}
+
+	oauthProviders := make(map[string]*OAuthProviderConfig)
+	for _, name := range strings.Split(l.get("OAUTH_PROVIDERS", ""), ",") {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" {
+			continue
+		}
+
+		if provider := l.oauthProvider(name); provider != nil {
+			oauthProviders[name] = provider
+		}
+	}
+
+	apiKeyMaxExpireTime := l.getDuration("API_KEY_MAX_EXPIRE_TIME", 365*24*time.Hour)
+	if apiKeyMaxExpireTime <= 0 {
+		l.errorf("api key max expire time must be > 0: %s", apiKeyMaxExpireTime)
+	}
+	apiKeyExpireTime := l.getDuration("API_KEY_EXPIRE_TIME", 90*24*time.Hour)
+	if apiKeyExpireTime <= 0 || apiKeyExpireTime > apiKeyMaxExpireTime {
+		l.errorf("api key expire time must be > 0 and <= api key max expire time: %s", apiKeyExpireTime)
+	}
+
+	passwordAlgorithm := PasswordAlgorithm(l.get("PASSWORD_HASH_ALGORITHM", string(Argon2id)))
+	if passwordAlgorithm != Argon2id && passwordAlgorithm != Bcrypt {
+		l.errorf("unknown password hash algorithm: %s", passwordAlgorithm)
+	}
+
+	bcryptCost := l.getInt("PASSWORD_BCRYPT_COST", 12)
+	if bcryptCost < 4 || bcryptCost > 31 {
+		l.errorf("password bcrypt cost must be between 4 and 31: %d", bcryptCost)
+	}
+
+	argon2Parallelism := l.getInt("PASSWORD_ARGON2_PARALLELISM", 2)
+	if argon2Parallelism < 1 || argon2Parallelism > 255 {
+		l.errorf("password argon2 parallelism must be between 1 and 255: %d", argon2Parallelism)
+	}
+	argon2Memory := l.getInt("PASSWORD_ARGON2_MEMORY", 64*1024)
+	if argon2Memory < 8*argon2Parallelism || argon2Memory > 4*1024*1024 {
+		l.errorf("password argon2 memory must be between 8*parallelism and 4194304 KiB: %d", argon2Memory)
+	}
+	argon2Iterations := l.getInt("PASSWORD_ARGON2_ITERATIONS", 3)
+	if argon2Iterations < 1 || argon2Iterations > 100 {
+		l.errorf("password argon2 iterations must be between 1 and 100: %d", argon2Iterations)
+	}
+	breachedHashesDir := l.get("PASSWORD_BREACHED_HASHES_DIR", "")
+
+	graphQLMaxDepth := l.getInt("GRAPHQL_MAX_DEPTH", 8)
+	if graphQLMaxDepth <= 0 {
+		l.errorf("graphql max depth must be > 0: %d", graphQLMaxDepth)
+	}
+	graphQLMaxComplexity := l.getInt("GRAPHQL_MAX_COMPLEXITY", 5000)
+	if graphQLMaxComplexity <= 0 {
+		l.errorf("graphql max complexity must be > 0: %d", graphQLMaxComplexity)
+	}
+
+	grpcPort := l.get("GRPC_PORT", ":9090")
+
+	outboxRelayInterval := l.getDuration("OUTBOX_RELAY_INTERVAL", 5*time.Second)
+	if outboxRelayInterval <= 0 {
+		l.errorf("outbox relay interval must be > 0: %s", outboxRelayInterval)
+	}
+	outboxBatchSize := l.getInt("OUTBOX_BATCH_SIZE", 100)
+	if outboxBatchSize <= 0 {
+		l.errorf("outbox batch size must be > 0: %d", outboxBatchSize)
+	}
+	outboxBaseBackoff := l.getDuration("OUTBOX_BACKOFF_BASE", time.Second)
+	if outboxBaseBackoff <= 0 {
+		l.errorf("outbox backoff base must be > 0: %s", outboxBaseBackoff)
+	}
+	outboxMaxBackoff := l.getDuration("OUTBOX_BACKOFF_MAX", 10*time.Minute)
+	if outboxMaxBackoff < outboxBaseBackoff {
+		l.errorf("outbox backoff max must be >= outbox backoff base: %s", outboxMaxBackoff)
+	}
+	outboxRetention := l.getDuration("OUTBOX_RETENTION", 7*24*time.Hour)
+	if outboxRetention <= 0 {
+		l.errorf("outbox retention must be > 0: %s", outboxRetention)
+	}
+
+	webhookWorkers := l.getInt("WEBHOOK_WORKERS", 4)
+	if webhookWorkers <= 0 {
+		l.errorf("webhook workers must be > 0: %d", webhookWorkers)
+	}
+	webhookPollInterval := l.getDuration("WEBHOOK_POLL_INTERVAL", 5*time.Second)
+	if webhookPollInterval <= 0 {
+		l.errorf("webhook poll interval must be > 0: %s", webhookPollInterval)
+	}
+	webhookBatchSize := l.getInt("WEBHOOK_BATCH_SIZE", 50)
+	if webhookBatchSize <= 0 {
+		l.errorf("webhook batch size must be > 0: %d", webhookBatchSize)
+	}
+	webhookTimeout := l.getDuration("WEBHOOK_TIMEOUT", 10*time.Second)
+	if webhookTimeout <= 0 || webhookTimeout >= domain.WebhookDeliveryTimeout {
+		l.errorf("webhook timeout must be > 0 and < %s: %s", domain.WebhookDeliveryTimeout, webhookTimeout)
+	}
+	webhookMaxAttempts := l.getInt("WEBHOOK_MAX_ATTEMPTS", 10)
+	if webhookMaxAttempts <= 0 {
+		l.errorf("webhook max attempts must be > 0: %d", webhookMaxAttempts)
+	}
+	webhookBaseBackoff := l.getDuration("WEBHOOK_BACKOFF_BASE", 30*time.Second)
+	if webhookBaseBackoff <= 0 {
+		l.errorf("webhook backoff base must be > 0: %s", webhookBaseBackoff)
+	}
+	webhookMaxBackoff := l.getDuration("WEBHOOK_BACKOFF_MAX", 6*time.Hour)
+	if webhookMaxBackoff < webhookBaseBackoff {
+		l.errorf("webhook backoff max must be >= webhook backoff base: %s", webhookMaxBackoff)
+	}
+	// Endpoints are entered by admins, so they must not reach the network of the server unless it is allowed.
+	webhookAllowPrivateNetworks := l.getBool("WEBHOOK_ALLOW_PRIVATE_NETWORKS", false)
+
+	// Changes of the settings have to survive restarts and reach every replica, so there is no memory store.
+	runtimeSettingsStore := Store(l.get("RUNTIME_SETTINGS_STORE", string(PostgresStore)))
+	if runtimeSettingsStore != PostgresStore && runtimeSettingsStore != FileStore {
+		l.errorf("unknown runtime settings store: %s", runtimeSettingsStore)
+	}
+	runtimeSettingsFile := l.get("RUNTIME_SETTINGS_FILE", "")
+	if runtimeSettingsStore == FileStore && runtimeSettingsFile == "" {
+		l.errorf("runtime settings file must be set for the file store")
+	}
+	runtimePollInterval := l.getDuration("RUNTIME_SETTINGS_POLL_INTERVAL", 5*time.Second)
+	if runtimePollInterval <= 0 {
+		l.errorf("runtime settings poll interval must be > 0: %s", runtimePollInterval)
+	}
+
+	if err = l.err(); err != nil {
+		return nil, err
 	}
 
 	return &Container{
 		App: &AppConfig{
-			Environment: environment,
-			Port:        getEnv("PORT", "8080"),
+			Environment:    environment,
+			Port:           port,
+			LogLevel:       logLevel,
+			TrustedProxies: trustedProxies,
 		},
 		Database: &DBConfig{
-			Url:                getEnv("DATABASE_URL", ""),
+			Url:                databaseUrl,
 			MaxOpenConnections: maxOpenConnections,
 			MaxIdleConnections: maxIdleConnections,
+			AutoMigrate:        autoMigrate,
 		},
 		JWT: &JWTConfig{
 			Secret:                 []byte(secret),
-			Issuer:                 getEnv("JWT_ISSUER", "my-app"),
-			Audience:               getEnv("JWT_AUDIENCE", "my-app-users"),
+			Issuer:                 jwtIssuer,
+			Audience:               jwtAudience,
 			RefreshTokenExpireTime: refreshTokenExpireTime,
 			AccessTokenExpireTime:  accessTokenExpireTime,
+			MFATokenExpireTime:     mfaTokenExpireTime,
+
+			ImpersonationTokenExpireTime: impersonationTokenExpireTime,
+		},
+		MFA: &MFAConfig{
+			Issuer:             mfaIssuer,
+			RecoveryCodeSecret: []byte(recoveryCodeSecret),
+		},
+		Login: &LoginConfig{
+			AttemptStore:    attemptStore,
+			MaxUserFailures: maxUserFailures,
+			MaxIPFailures:   maxIPFailures,
+			LockoutDuration: lockoutDuration,
+			BaseBackoff:     baseBackoff,
+			MaxBackoff:      maxBackoff,
+			AttemptWindow:   attemptWindow,
+		},
+		RateLimit: &RateLimitConfig{
+			Enabled: rateLimitEnabled,
+			Store:   rateLimitStore,
+			Groups:  rateLimitGroups,
+		},
+		OAuth: &OAuthConfig{
+			StateExpireTime: oauthStateExpireTime,
+			Providers:       oauthProviders,
+		},
+		APIKey: &APIKeyConfig{
+			DefaultExpireTime: apiKeyExpireTime,
+			MaxExpireTime:     apiKeyMaxExpireTime,
 		},
+		Password: &PasswordConfig{
+			Algorithm:         passwordAlgorithm,
+			BcryptCost:        bcryptCost,
+			Argon2Memory:      uint32(argon2Memory),
+			Argon2Iterations:  uint32(argon2Iterations),
+			Argon2Parallelism: uint8(argon2Parallelism),
+			BreachedHashesDir: breachedHashesDir,
+		},
+		GraphQL: &GraphQLConfig{
+			MaxDepth:      graphQLMaxDepth,
+			MaxComplexity: graphQLMaxComplexity,
+		},
+		GRPC: &GRPCConfig{
+			Port: grpcPort,
+		},
+		Outbox: &OutboxConfig{
+			RelayInterval: outboxRelayInterval,
+			BatchSize:     outboxBatchSize,
+			BaseBackoff:   outboxBaseBackoff,
+			MaxBackoff:    outboxMaxBackoff,
+			Retention:     outboxRetention,
+		},
+		Webhook: &WebhookConfig{
+			Workers:              webhookWorkers,
+			PollInterval:         webhookPollInterval,
+			BatchSize:            webhookBatchSize,
+			Timeout:              webhookTimeout,
+			MaxAttempts:          webhookMaxAttempts,
+			BaseBackoff:          webhookBaseBackoff,
+			MaxBackoff:           webhookMaxBackoff,
+			RequireHTTPS:         environment != Development,
+			AllowPrivateNetworks: webhookAllowPrivateNetworks,
+		},
+		Runtime: &RuntimeConfig{
+			Store:        runtimeSettingsStore,
+			SettingsFile: runtimeSettingsFile,
+			PollInterval: runtimePollInterval,
+		},
+		settings: l.settings,
 	}, nil
 }
+
+// Settings returns the effective value and layer of every key in the order they are read, secrets are redacted.
+func (c *Container) Settings() []Setting {
+	return c.settings
+}
@@ -1,6 +1,10 @@
 package config
 
-import "go.uber.org/fx"
+import (
+	"shop-api-go/internal/core/domain"
+
+	"go.uber.org/fx"
+)
 
 var Module = fx.Module(
 	"config",
@@ -14,4 +18,99 @@ var Module = fx.Module(
 	fx.Provide(func(config *Container) *JWTConfig {
 		return config.JWT
 	}),
+	fx.Provide(func(config *Container) *MFAConfig {
+		return config.MFA
+	}),
+	fx.Provide(func(config *Container) *LoginConfig {
+		return config.Login
+	}),
+	fx.Provide(func(config *Container) *RateLimitConfig {
+		return config.RateLimit
+	}),
+	fx.Provide(func(config *Container) *OAuthConfig {
+		return config.OAuth
+	}),
+	fx.Provide(func(config *Container) *APIKeyConfig {
+		return config.APIKey
+	}),
+	fx.Provide(func(config *Container) *PasswordConfig {
+		return config.Password
+	}),
+	fx.Provide(func(config *Container) *GraphQLConfig {
+		return config.GraphQL
+	}),
+	fx.Provide(func(config *Container) *GRPCConfig {
+		return config.GRPC
+	}),
+	fx.Provide(func(config *Container) *OutboxConfig {
+		return config.Outbox
+	}),
+	fx.Provide(func(config *Container) *WebhookConfig {
+		return config.Webhook
+	}),
+	fx.Provide(func(config *Container) *RuntimeConfig {
+		return config.Runtime
+	}),
+	fx.Provide(func(config *LoginConfig) *domain.LoginThrottle {
+		return &domain.LoginThrottle{
+			User: domain.LoginThrottlePolicy{
+				MaxFailures:     config.MaxUserFailures,
+				LockoutDuration: config.LockoutDuration,
+				BaseBackoff:     config.BaseBackoff,
+				MaxBackoff:      config.MaxBackoff,
+			},
+			IP: domain.LoginThrottlePolicy{
+				MaxFailures:     config.MaxIPFailures,
+				LockoutDuration: config.LockoutDuration,
+			},
+			Window: config.AttemptWindow,
+		}
+	}),
+	fx.Provide(func(config *APIKeyConfig) *domain.APIKeyPolicy {
+		return &domain.APIKeyPolicy{
+			DefaultExpireTime: config.DefaultExpireTime,
+			MaxExpireTime:     config.MaxExpireTime,
+		}
+	}),
+	fx.Provide(func(config *OutboxConfig) *domain.OutboxPolicy {
+		return &domain.OutboxPolicy{
+			RelayInterval: config.RelayInterval,
+			BatchSize:     config.BatchSize,
+			BaseBackoff:   config.BaseBackoff,
+			MaxBackoff:    config.MaxBackoff,
+			Retention:     config.Retention,
+		}
+	}),
+	fx.Provide(func(config *WebhookConfig) *domain.WebhookPolicy {
+		return &domain.WebhookPolicy{
+			Workers:              config.Workers,
+			PollInterval:         config.PollInterval,
+			BatchSize:            config.BatchSize,
+			MaxAttempts:          config.MaxAttempts,
+			BaseBackoff:          config.BaseBackoff,
+			MaxBackoff:           config.MaxBackoff,
+			RequireHTTPS:         config.RequireHTTPS,
+			AllowPrivateNetworks: config.AllowPrivateNetworks,
+		}
+	}),
+	fx.Provide(func(appConfig *AppConfig, jwtConfig *JWTConfig, rateLimitConfig *RateLimitConfig) *domain.RuntimeSettings {
+		rateLimits := make(map[string]domain.RateLimitPolicy, len(rateLimitConfig.Groups))
+		for group, policy := range rateLimitConfig.Groups {
+			rateLimits[string(group)] = policy
+		}
+		return &domain.RuntimeSettings{
+			LogLevel:                     appConfig.LogLevel,
+			RateLimitEnabled:             rateLimitConfig.Enabled,
+			RateLimits:                   rateLimits,
+			AccessTokenExpireTime:        jwtConfig.AccessTokenExpireTime,
+			RefreshTokenExpireTime:       jwtConfig.RefreshTokenExpireTime,
+			MFATokenExpireTime:           jwtConfig.MFATokenExpireTime,
+			ImpersonationTokenExpireTime: jwtConfig.ImpersonationTokenExpireTime,
+		}
+	}),
+	fx.Provide(func(config *RuntimeConfig) *domain.RuntimeSettingsPolicy {
+		return &domain.RuntimeSettingsPolicy{
+			PollInterval: config.PollInterval,
+		}
+	}),
 )
@@ -0,0 +1,408 @@
+package config
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"shop-api-go/internal/core/domain"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pelletier/go-toml/v2"
+	"gopkg.in/yaml.v3"
+)
+
+// Layers of the configuration, a key is read from the first layer that sets it.
+const (
+	FlagLayer    = "flag"
+	EnvLayer     = "env"
+	FileLayer    = "file"
+	DefaultLayer = "default"
+)
+
+// fileSuffix is the suffix of keys whose value is read from a file, e.g. JWT_SECRET_FILE=/run/secrets/jwt
+// sets JWT_SECRET to the content of the file, as done by Docker secrets.
+const fileSuffix = "_FILE"
+
+// Source sets where the configuration is read from besides env variables.
+type Source struct {
+	// File is the path of a YAML (.yaml, .yml) or TOML (.toml) config file. CONFIG_FILE is used if it is empty.
+	File string
+	// Overrides contains keys set by CLI flags, they take precedence over every other layer.
+	Overrides map[string]string
+}
+
+// ParseFlags reads the source of the configuration from CLI flags: -config sets the config file
+// and -set KEY=VALUE sets a key, e.g. -set JWT_ACCESS_TOKEN_EXPIRE_TIME=15m.
+func ParseFlags(name string, args []string) (*Source, error) {
+	source := &Source{Overrides: make(map[string]string)}
+
+	flags := flag.NewFlagSet(name, flag.ContinueOnError)
+	flags.StringVar(&source.File, "config", "", "path of a YAML or TOML config file (env CONFIG_FILE)")
+	flags.Func("set", "sets a config key, e.g. -set JWT_ACCESS_TOKEN_EXPIRE_TIME=15m (repeatable)", func(flag string) error {
+		key, value, ok := strings.Cut(flag, "=")
+		if !ok || key == "" {
+			return fmt.Errorf("must have KEY=VALUE format: %s", flag)
+		}
+		source.Overrides[normalizeKey(key)] = value
+		return nil
+	})
+	if err := flags.Parse(args); err != nil {
+		return nil, err
+	}
+	if flags.NArg() > 0 {
+		return nil, fmt.Errorf("unexpected arguments: %s", strings.Join(flags.Args(), " "))
+	}
+	return source, nil
+}
+
+// Setting is an effective configuration value and the layer it was read from.
+type Setting struct {
+	Key string
+	// Value is the value of the key, secrets are redacted.
+	Value string
+	Layer string
+}
+
+// loader reads the keys of the configuration from its layers. It collects every invalid value,
+// so all of them are reported at once.
+type loader struct {
+	overrides map[string]string
+	file      map[string]string
+	known     map[string]bool
+	settings  []Setting
+	errs      []error
+}
+
+// newLoader creates a new loader instance with the layers of the source.
+func newLoader(source *Source) *loader {
+	l := &loader{
+		overrides: make(map[string]string),
+		file:      make(map[string]string),
+		known:     make(map[string]bool),
+	}
+	if source == nil {
+		source = &Source{}
+	}
+	for key, value := range source.Overrides {
+		l.overrides[normalizeKey(key)] = value
+	}
+
+	path := source.File
+	if path == "" {
+		path = os.Getenv("CONFIG_FILE")
+	}
+	if path != "" {
+		file, err := readFile(path)
+		if err != nil {
+			l.errorf("config file: %w", err)
+		} else {
+			l.file = file
+		}
+	}
+	return l
+}
+
+// errorf records an invalid configuration.
+func (l *loader) errorf(format string, args ...any) {
+	l.errs = append(l.errs, fmt.Errorf(format, args...))
+}
+
+// err returns all recorded errors, unknown keys of the file and the flags included.
+func (l *loader) err() error {
+	for _, layer := range []struct {
+		name   string
+		values map[string]string
+	}{{FlagLayer, l.overrides}, {FileLayer, l.file}} {
+		keys := make([]string, 0, len(layer.values))
+		for key := range layer.values {
+			if !l.known[key] {
+				keys = append(keys, key)
+			}
+		}
+		slices.Sort(keys)
+		for _, key := range keys {
+			l.errorf("unknown %s key: %s", layer.name, key)
+		}
+	}
+	return errors.Join(l.errs...)
+}
+
+// lookup returns the value of the key and its layer. In every layer the key can also be set by a file
+// with the key suffixed by _FILE, whose content without the trailing newline is used.
+func (l *loader) lookup(key string) (string, string, bool) {
+	l.known[key] = true
+	l.known[key+fileSuffix] = true
+
+	layers := []struct {
+		name   string
+		lookup func(key string) (string, bool)
+	}{
+		{FlagLayer, mapLookup(l.overrides)},
+		{EnvLayer, os.LookupEnv},
+		{FileLayer, mapLookup(l.file)},
+	}
+	for _, layer := range layers {
+		value, ok := layer.lookup(key)
+		path, fromFile := layer.lookup(key + fileSuffix)
+		if ok && fromFile {
+			l.errorf("%s and %s are both set by %s", key, key+fileSuffix, layer.name)
+		}
+		if ok {
+			return value, layer.name, true
+		}
+		if fromFile {
+			content, err := os.ReadFile(path)
+			if err != nil {
+				l.errorf("%s: %w", key+fileSuffix, err)
+				return "", layer.name, false
+			}
+			return strings.TrimRight(string(content), "\r\n"), layer.name, true
+		}
+	}
+	return "", DefaultLayer, false
+}
+
+// value returns the value of the key or fallback if no layer sets it. The value is recorded
+// as a setting, redact replaces secrets in the recorded value if it is not nil.
+func (l *loader) value(key, fallback string, redact func(string) string) (string, bool) {
+	value, layer, ok := l.lookup(key)
+	if !ok {
+		value = fallback
+	}
+
+	printed := value
+	if redact != nil {
+		printed = redact(value)
+	}
+	l.settings = append(l.settings, Setting{Key: key, Value: printed, Layer: layer})
+	return value, ok
+}
+
+// get returns the value of the key or fallback if no layer sets it.
+func (l *loader) get(key, fallback string) string {
+	value, _ := l.value(key, fallback, nil)
+	return value
+}
+
+// getSecret returns the value of the key or fallback if no layer sets it, the value is redacted in the settings.
+func (l *loader) getSecret(key, fallback string) string {
+	value, _ := l.value(key, fallback, redactSecret)
+	return value
+}
+
+// getInt returns the integer value of the key or fallback if no layer sets it.
+func (l *loader) getInt(key string, fallback int) int {
+	value, ok := l.value(key, strconv.Itoa(fallback), nil)
+	if !ok {
+		return fallback
+	}
+	i, err := strconv.Atoi(value)
+	if err != nil {
+		l.errorf("%s must be an integer: %q", key, value)
+		return fallback
+	}
+	return i
+}
+
+// getDuration returns the time.Duration value of the key or fallback if no layer sets it.
+func (l *loader) getDuration(key string, fallback time.Duration) time.Duration {
+	value, ok := l.value(key, fallback.String(), nil)
+	if !ok {
+		return fallback
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		l.errorf("%s must be a duration, e.g. 30s or 1h: %q", key, value)
+		return fallback
+	}
+	return d
+}
+
+// getBool returns the boolean value of the key or fallback if no layer sets it.
+func (l *loader) getBool(key string, fallback bool) bool {
+	value, ok := l.value(key, strconv.FormatBool(fallback), nil)
+	if !ok {
+		return fallback
+	}
+	b, err := strconv.ParseBool(value)
+	if err != nil {
+		l.errorf("%s must be a boolean: %q", key, value)
+		return fallback
+	}
+	return b
+}
+
+// rateLimitPolicy returns the rate limit policy of a route group.
+// The default limit is read from RATE_LIMIT_<GROUP> and role limits from RATE_LIMIT_<GROUP>_<ROLE>.
+func (l *loader) rateLimitPolicy(group RateLimitGroup, fallback string) domain.RateLimitPolicy {
+	key := "RATE_LIMIT_" + strings.ToUpper(string(group))
+
+	limit, err := domain.ParseRateLimit(l.get(key, fallback))
+	if err != nil {
+		l.errorf("%s: %w", key, err)
+	}
+
+	policy := domain.RateLimitPolicy{
+		Default: limit,
+		Roles:   make(map[domain.UserRole]domain.RateLimit),
+	}
+	for _, role := range []domain.UserRole{domain.Admin, domain.Client, domain.Delivery, domain.Warehouse} {
+		roleKey := key + "_" + strings.ToUpper(string(role))
+		value, layer, ok := l.lookup(roleKey)
+		if !ok {
+			continue
+		}
+		l.settings = append(l.settings, Setting{Key: roleKey, Value: value, Layer: layer})
+
+		roleLimit, err := domain.ParseRateLimit(value)
+		if err != nil {
+			l.errorf("%s: %w", roleKey, err)
+			continue
+		}
+		policy.Roles[role] = roleLimit
+	}
+	return policy
+}
+
+// oauthProvider returns the configuration of an identity provider, nil if it is invalid.
+// The keys are read from OAUTH_<NAME>_*.
+func (l *loader) oauthProvider(name string) *OAuthProviderConfig {
+	prefix := "OAUTH_" + strings.ToUpper(name) + "_"
+
+	providerType := OIDCProvider
+	issuer := ""
+	scopes := "openid,email,profile"
+	switch name {
+	case "google":
+		issuer = "https://accounts.google.com"
+	case "github":
+		providerType = GitHubProvider
+		scopes = "read:user,user:email"
+	}
+
+	provider := &OAuthProviderConfig{
+		Type:         OAuthProviderType(l.get(prefix+"TYPE", string(providerType))),
+		Issuer:       l.get(prefix+"ISSUER", issuer),
+		ClientId:     l.get(prefix+"CLIENT_ID", ""),
+		ClientSecret: l.getSecret(prefix+"CLIENT_SECRET", ""),
+		RedirectURL:  l.get(prefix+"REDIRECT_URL", ""),
+		Scopes:       strings.Split(l.get(prefix+"SCOPES", scopes), ","),
+	}
+	valid := true
+	if provider.Type != OIDCProvider && provider.Type != GitHubProvider {
+		l.errorf("unknown oauth provider type of %s: %s", name, provider.Type)
+		valid = false
+	}
+	if provider.Type == OIDCProvider && provider.Issuer == "" {
+		l.errorf("oauth provider %s must have an issuer", name)
+		valid = false
+	}
+	if provider.ClientId == "" {
+		l.errorf("oauth provider %s must have a client id", name)
+		valid = false
+	}
+	if provider.RedirectURL == "" {
+		l.errorf("oauth provider %s must have a redirect url", name)
+		valid = false
+	}
+	if !valid {
+		return nil
+	}
+	return provider
+}
+
+// mapLookup returns a lookup function of the values.
+func mapLookup(values map[string]string) func(key string) (string, bool) {
+	return func(key string) (string, bool) {
+		value, ok := values[key]
+		return value, ok
+	}
+}
+
+// normalizeKey converts a key of a file or flag to the name of its env variable,
+// e.g. jwt.access-token-expire-time to JWT_ACCESS_TOKEN_EXPIRE_TIME.
+func normalizeKey(key string) string {
+	return strings.ToUpper(strings.NewReplacer(".", "_", "-", "_").Replace(key))
+}
+
+// redactSecret replaces a set secret.
+func redactSecret(value string) string {
+	if value == "" {
+		return ""
+	}
+	return domain.AuditRedacted
+}
+
+// redactURL replaces the password of a URL, e.g. the database URL.
+func redactURL(value string) string {
+	u, err := url.Parse(value)
+	if err != nil {
+		return redactSecret(value)
+	}
+	return u.Redacted()
+}
+
+// readFile reads the keys of a YAML or TOML config file. Nested keys are joined with underscores and lists
+// with commas, e.g. jwt: {secret: x} sets JWT_SECRET and oauth: {providers: [google, github]} OAUTH_PROVIDERS.
+func readFile(path string) (map[string]string, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var values map[string]any
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(content, &values)
+	case ".toml":
+		err = toml.Unmarshal(content, &values)
+	default:
+		return nil, fmt.Errorf("unsupported config file %s, use .yaml, .yml or .toml", path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", path, err)
+	}
+
+	keys := make(map[string]string)
+	if err = flatten(keys, "", values); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return keys, nil
+}
+
+// flatten adds the values to keys with their nested keys joined by underscores.
+func flatten(keys map[string]string, prefix string, values map[string]any) error {
+	for name, value := range values {
+		key := normalizeKey(name)
+		if prefix != "" {
+			key = prefix + "_" + key
+		}
+
+		switch v := value.(type) {
+		case map[string]any:
+			if err := flatten(keys, key, v); err != nil {
+				return err
+			}
+		case []any:
+			items := make([]string, 0, len(v))
+			for _, item := range v {
+				if _, ok := item.(map[string]any); ok {
+					return fmt.Errorf("%s must be a list of values", key)
+				}
+				items = append(items, fmt.Sprint(item))
+			}
+			keys[key] = strings.Join(items, ",")
+		case nil:
+			keys[key] = ""
+		default:
+			keys[key] = fmt.Sprint(v)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,6 @@
+// Package api contains the conventions shared by the HTTP and the gRPC API: the request id header,
+// the validation tags of the request structs and the encoding of user cursors.
+package api
+
+// RequestIdHeader is the header used to pass the request id, gRPC metadata uses its lower case form.
+const RequestIdHeader = "X-Request-Id"
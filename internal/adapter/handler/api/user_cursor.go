@@ -0,0 +1,55 @@
+package api
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"shop-api-go/internal/core/domain"
+
+	"github.com/google/uuid"
+)
+
+// userCursor is the encoded form of domain.UserCursor.
+type userCursor struct {
+	SortBy     domain.UserSortField `json:"sortBy"`
+	Descending bool                 `json:"desc"`
+	Value      string               `json:"value"`
+	Id         uuid.UUID            `json:"id"`
+}
+
+// EncodeUserCursor encodes a cursor as an opaque base64 string, nil cursors are not encoded.
+func EncodeUserCursor(cursor *domain.UserCursor) *string {
+	if cursor == nil {
+		return nil
+	}
+
+	encoded, err := json.Marshal(userCursor{
+		SortBy:     cursor.SortBy,
+		Descending: cursor.Descending,
+		Value:      cursor.Value,
+		Id:         cursor.Id,
+	})
+	if err != nil {
+		return nil
+	}
+	result := base64.URLEncoding.EncodeToString(encoded)
+	return &result
+}
+
+// DecodeUserCursor decodes a cursor encoded by EncodeUserCursor.
+func DecodeUserCursor(cursor string) (*domain.UserCursor, error) {
+	decoded, err := base64.URLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, domain.ErrInvalidCursor
+	}
+
+	var c userCursor
+	if err = json.Unmarshal(decoded, &c); err != nil {
+		return nil, domain.ErrInvalidCursor
+	}
+	return &domain.UserCursor{
+		SortBy:     c.SortBy,
+		Descending: c.Descending,
+		Value:      c.Value,
+		Id:         c.Id,
+	}, nil
+}
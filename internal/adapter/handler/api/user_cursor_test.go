@@ -0,0 +1,37 @@
+package api
+
+import (
+	"errors"
+	"shop-api-go/internal/core/domain"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestUserCursor(t *testing.T) {
+	cursor := &domain.UserCursor{
+		SortBy:     domain.UserSortUsername,
+		Descending: true,
+		Value:      "user|with,separators",
+		Id:         uuid.New(),
+	}
+
+	encoded := EncodeUserCursor(cursor)
+	if encoded == nil {
+		t.Fatal("Expected encoded cursor, got nil")
+	}
+	decoded, err := DecodeUserCursor(*encoded)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if *decoded != *cursor {
+		t.Errorf("Expected %v, got %v", cursor, decoded)
+	}
+
+	if EncodeUserCursor(nil) != nil {
+		t.Error("Expected nil cursor to not be encoded")
+	}
+	if _, err = DecodeUserCursor("not a cursor"); !errors.Is(err, domain.ErrInvalidCursor) {
+		t.Errorf("Expected %v, got %v", domain.ErrInvalidCursor, err)
+	}
+}
@@ -1,13 +1,38 @@
-package http
+package api
 
 import (
+	"regexp"
 	"shop-api-go/internal/core/domain"
+	"slices"
 	"strconv"
 	"unicode"
 
+	"github.com/gin-gonic/gin/binding"
 	"github.com/go-playground/validator/v10"
 )
 
+// RegisterValidations registers the custom validation tags used by the request structs
+// with the validator of gin's binding package.
+func RegisterValidations() error {
+	v, ok := binding.Validator.Engine().(*validator.Validate)
+	if !ok {
+		return nil
+	}
+	if err := v.RegisterValidation("password", validatePassword); err != nil {
+		return err
+	}
+	if err := v.RegisterValidation("min_bytes", validateMinBytesLength); err != nil {
+		return err
+	}
+	if err := v.RegisterValidation("max_bytes", validateMaxBytesLength); err != nil {
+		return err
+	}
+	if err := v.RegisterValidation("event_type", validateEventType); err != nil {
+		return err
+	}
+	return v.RegisterValidation("user_role", validateUserRole)
+}
+
 // validateMinBytesLength is a function that implement validator.FieldLevel interface
 // and varifies that length of the word is not less than the provided length.
 func validateMinBytesLength(fl validator.FieldLevel) bool {
@@ -86,16 +111,19 @@ func validatePassword(fl validator.FieldLevel) bool {
 	return isValidPassword(password)
 }
 
-// mapUserRoles is a map used to map valid user roles.
-var mapUserRoles = map[domain.UserRole]bool{
-	domain.Admin:     true,
-	domain.Client:    true,
-	domain.Delivery:  true,
-	domain.Warehouse: true,
-}
+// userRoleRegex matches the names of built-in and custom user roles.
+var userRoleRegex = regexp.MustCompile(`^[a-z][a-z0-9_-]{1,63}$`)
 
 // validateUserRole is a function that implement validator.FieldLevel interface
-// and validates that a user role is valid.
+// and validates that a user role has a valid name.
+//
+// Note: Whether the role exists is checked by the database since roles can be created at runtime.
 func validateUserRole(fl validator.FieldLevel) bool {
-	return mapUserRoles[domain.UserRole(fl.Field().String())]
+	return userRoleRegex.MatchString(fl.Field().String())
+}
+
+// validateEventType is a function that implement validator.FieldLevel interface
+// and validates that an event type is published.
+func validateEventType(fl validator.FieldLevel) bool {
+	return slices.Contains(domain.EventTypes, domain.EventType(fl.Field().String()))
 }
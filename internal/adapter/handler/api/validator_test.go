@@ -0,0 +1,79 @@
+package api
+
+import (
+	"shop-api-go/internal/adapter/handler/http/request"
+	"testing"
+
+	"github.com/gin-gonic/gin/binding"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_isValidPassword(t *testing.T) {
+	tests := []struct {
+		password string
+		valid    bool
+	}{
+		{"Password1!", true},
+		{"short1!", false},
+		{"noupper1!", false},
+		{"NOLOWER1!", false},
+		{"NoNumber!", false},
+		{"NoSpecial1", false},
+		{"White space1!", false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.password, func(t *testing.T) {
+			if isValidPassword(test.password) != test.valid {
+				t.Errorf("Expected %v, got %v", test.valid, isValidPassword(test.password))
+			}
+		})
+	}
+}
+
+func Test_userRoleRegex(t *testing.T) {
+	tests := []struct {
+		role  string
+		valid bool
+	}{
+		{"admin", true},
+		{"support_agent", true},
+		{"tier-2", true},
+		{"a", false},
+		{"Admin", false},
+		{"1admin", false},
+		{"admin role", false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.role, func(t *testing.T) {
+			if userRoleRegex.MatchString(test.role) != test.valid {
+				t.Errorf("Expected %v, got %v", test.valid, userRoleRegex.MatchString(test.role))
+			}
+		})
+	}
+}
+
+func Test_rateLimitRolesBinding(t *testing.T) {
+	require.NoError(t, RegisterValidations())
+	settings := func(role string) *request.UpdateRuntimeSettingsRequest {
+		return &request.UpdateRuntimeSettingsRequest{
+			RateLimits: map[string]request.RateLimitPolicyRequest{
+				"users": {Default: "20/1m", Roles: map[string]string{role: "100/1m"}},
+			},
+		}
+	}
+
+	require.NoError(t, binding.Validator.ValidateStruct(settings("support_agent")))
+	require.Error(t, binding.Validator.ValidateStruct(settings("Admin")))
+}
+
+func Fuzz_isValidPassword(f *testing.F) {
+	f.Add("")
+	f.Add("password")
+	f.Add("Password!")
+
+	f.Fuzz(func(t *testing.T, password string) {
+		_ = isValidPassword(password)
+	})
+}
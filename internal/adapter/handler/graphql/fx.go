@@ -0,0 +1,8 @@
+package graphql
+
+import "go.uber.org/fx"
+
+var Module = fx.Module(
+	"GraphQL",
+	fx.Provide(NewHandler),
+)
@@ -0,0 +1,125 @@
+package graphql
+
+import (
+	"net/http"
+	"shop-api-go/internal/adapter/config"
+	"shop-api-go/internal/adapter/handler/http/response"
+	"shop-api-go/internal/core/domain"
+	"shop-api-go/internal/core/port"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/gqlerrors"
+	"github.com/graphql-go/graphql/language/parser"
+)
+
+// Handler represent HTTP handler for GraphQL requests.
+type Handler struct {
+	schema         graphql.Schema
+	graphQLConfig  *config.GraphQLConfig
+	productService port.ProductService
+}
+
+// NewHandler creates a new Handler instance.
+func NewHandler(
+	graphQLConfig *config.GraphQLConfig,
+	userService port.UserService,
+	productService port.ProductService,
+) (*Handler, error) {
+	schema, err := newSchema(userService, productService)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Handler{
+		schema:         schema,
+		graphQLConfig:  graphQLConfig,
+		productService: productService,
+	}, nil
+}
+
+// requestBody represents the body of a GraphQL request.
+type requestBody struct {
+	Query         string         `json:"query" binding:"required"`
+	Variables     map[string]any `json:"variables"`
+	OperationName string         `json:"operationName"`
+}
+
+// Serve executes a GraphQL query with the access token of the Authorization header.
+//
+// Note: Queries that cannot be parsed, are invalid or exceed the depth and complexity limits
+// are rejected with 400, errors of resolvers are returned with 200 next to the resolved data.
+func (h *Handler) Serve(c *gin.Context) {
+	token, ok := c.Get("token")
+	if !ok {
+		response.HandleError(c, domain.ErrInternal)
+		return
+	}
+	domainToken, ok := token.(*domain.Token)
+	if !ok {
+		response.HandleError(c, domain.ErrInternal)
+		return
+	}
+	if domainToken.TokenType != domain.AccessToken {
+		response.HandleError(c, domain.ErrInvalidTokenType)
+		return
+	}
+	body := requestBody{}
+	if err := c.ShouldBindJSON(&body); err != nil {
+		response.HandleBindingError(c, err)
+		return
+	}
+
+	document, err := parser.Parse(parser.ParseParams{Source: body.Query})
+	if err != nil {
+		c.JSON(http.StatusBadRequest, &graphql.Result{Errors: gqlerrors.FormatErrors(err)})
+		return
+	}
+	validation := graphql.ValidateDocument(&h.schema, document, nil)
+	if !validation.IsValid {
+		c.JSON(http.StatusBadRequest, &graphql.Result{Errors: validation.Errors})
+		return
+	}
+	if err = checkLimits(
+		&h.schema,
+		document,
+		body.OperationName,
+		body.Variables,
+		h.graphQLConfig.MaxDepth,
+		h.graphQLConfig.MaxComplexity,
+	); err != nil {
+		c.JSON(http.StatusBadRequest, &graphql.Result{Errors: gqlerrors.FormatErrors(gqlerrors.NewLocatedError(newResolverError(err), nil))})
+		return
+	}
+
+	result := graphql.Execute(graphql.ExecuteParams{
+		Schema:        h.schema,
+		AST:           document,
+		OperationName: body.OperationName,
+		Args:          body.Variables,
+		Context: withRequest(c, &request{
+			token:   domainToken,
+			loaders: newLoaders(h.productService),
+		}),
+	})
+	c.JSON(http.StatusOK, result)
+}
+
+// resolverError is a domain error reported with the code and messages of its REST error response.
+type resolverError struct {
+	response response.ErrorResponse
+}
+
+// newResolverError creates a new resolverError instance.
+func newResolverError(err error) error {
+	return &resolverError{response: response.NewErrorResponse(err)}
+}
+
+func (e *resolverError) Error() string {
+	return strings.Join(e.response.Messages, " ")
+}
+
+func (e *resolverError) Extensions() map[string]any {
+	return map[string]any{"code": e.response.Code}
+}
@@ -0,0 +1,157 @@
+package graphql
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"shop-api-go/internal/adapter/config"
+	"shop-api-go/internal/core/domain"
+	"shop-api-go/internal/core/port/mock"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+// serve executes the query with an access token of userId and returns the status code and decoded body.
+func serve(t *testing.T, handler *Handler, userId uuid.UUID, query string, variables map[string]any) (int, map[string]any) {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.POST("/api/graphql", func(c *gin.Context) {
+		c.Set("token", &domain.Token{Id: uuid.New(), UserId: userId, TokenType: domain.AccessToken, UserRole: domain.Client})
+	}, handler.Serve)
+
+	body, err := json.Marshal(map[string]any{"query": query, "variables": variables})
+	require.NoError(t, err)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/api/graphql", bytes.NewReader(body)))
+
+	var result map[string]any
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &result))
+	return w.Code, result
+}
+
+func TestHandler_Serve_Batching(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	userService := mock.NewMockUserService(ctrl)
+	productService := mock.NewMockProductService(ctrl)
+	handler, err := NewHandler(&config.GraphQLConfig{MaxDepth: 8, MaxComplexity: 5000}, userService, productService)
+	require.NoError(t, err)
+
+	categoryId := uuid.New()
+	products := []domain.Product{
+		{Id: uuid.New(), Name: "Trail running shoes", Price: decimal.RequireFromString("89.99")},
+		{Id: uuid.New(), Name: "Road running shoes", Price: decimal.RequireFromString("79.50")},
+		{Id: uuid.New(), Name: "Running socks pack", Price: decimal.RequireFromString("12.00")},
+	}
+	productSubcategories := map[uuid.UUID][]domain.Subcategory{
+		products[0].Id: {{Id: uuid.New(), Name: "Shoes", CategoryID: categoryId}},
+		products[1].Id: {{Id: uuid.New(), Name: "Shoes", CategoryID: categoryId}},
+	}
+
+	productService.
+		EXPECT().
+		GetProducts(gomock.Any(), gomock.Eq(domain.NewProductFilter(nil, 1, 3))).
+		Return(products, nil)
+	productService.
+		EXPECT().
+		GetProductSubcategories(gomock.Any(), gomock.Len(3)).
+		Return(productSubcategories, nil).
+		Times(1)
+	productService.
+		EXPECT().
+		GetCategoriesByIds(gomock.Any(), gomock.Eq([]uuid.UUID{categoryId})).
+		Return(map[uuid.UUID]domain.Category{categoryId: {Id: categoryId, Name: "Running"}}, nil).
+		Times(1)
+
+	status, result := serve(t, handler, uuid.New(), `query($limit: Int!) {
+		products(limit: $limit) { id price subcategories { name category { name section { name } } } }
+	}`, map[string]any{"limit": 3})
+	require.Equal(t, http.StatusOK, status)
+	require.NotContains(t, result, "errors")
+
+	data := result["data"].(map[string]any)["products"].([]any)
+	require.Len(t, data, 3)
+	first := data[0].(map[string]any)
+	require.Equal(t, products[0].Id.String(), first["id"])
+	require.Equal(t, "89.99", first["price"])
+	subcategory := first["subcategories"].([]any)[0].(map[string]any)
+	require.Equal(t, "Running", subcategory["category"].(map[string]any)["name"])
+	require.Nil(t, subcategory["category"].(map[string]any)["section"])
+	require.Empty(t, data[2].(map[string]any)["subcategories"])
+}
+
+func TestHandler_Serve_Errors(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	userService := mock.NewMockUserService(ctrl)
+	productService := mock.NewMockProductService(ctrl)
+	handler, err := NewHandler(&config.GraphQLConfig{MaxDepth: 4, MaxComplexity: 500}, userService, productService)
+	require.NoError(t, err)
+	userId := uuid.New()
+
+	t.Run("resolver error carries code", func(t *testing.T) {
+		userService.
+			EXPECT().
+			GetAccount(gomock.Any(), gomock.Cond(func(token *domain.Token) bool { return token.UserId == userId })).
+			Return(nil, domain.ErrUserNotFound)
+
+		status, result := serve(t, handler, userId, `{ me { id username } }`, nil)
+		require.Equal(t, http.StatusOK, status)
+		errs := result["errors"].([]any)
+		require.Len(t, errs, 1)
+		require.Equal(t, "USER_NOT_FOUND", errs[0].(map[string]any)["extensions"].(map[string]any)["code"])
+	})
+
+	t.Run("too deep", func(t *testing.T) {
+		status, result := serve(t, handler, userId, `{
+			categorySections { categories { subcategories { category { name } } } }
+		}`, nil)
+		require.Equal(t, http.StatusBadRequest, status)
+		require.Equal(t, "QUERY_TOO_DEEP", result["errors"].([]any)[0].(map[string]any)["extensions"].(map[string]any)["code"])
+	})
+
+	t.Run("too complex", func(t *testing.T) {
+		status, result := serve(t, handler, userId, `{ products(limit: 100) { id name price rating imageUrl } }`, nil)
+		require.Equal(t, http.StatusBadRequest, status)
+		require.Equal(t, "QUERY_TOO_COMPLEX", result["errors"].([]any)[0].(map[string]any)["extensions"].(map[string]any)["code"])
+	})
+
+	t.Run("invalid query", func(t *testing.T) {
+		status, result := serve(t, handler, userId, `{ products { password } }`, nil)
+		require.Equal(t, http.StatusBadRequest, status)
+		require.NotEmpty(t, result["errors"])
+	})
+}
+
+func Test_loader(t *testing.T) {
+	calls := 0
+	l := newLoader(func(ctx context.Context, keys []uuid.UUID) (map[uuid.UUID]string, error) {
+		calls++
+		values := make(map[uuid.UUID]string, len(keys))
+		for _, key := range keys {
+			values[key] = key.String()
+		}
+		return values, nil
+	})
+
+	first, second := uuid.New(), uuid.New()
+	thunks := []func() (any, error){
+		l.Load(context.Background(), first),
+		l.Load(context.Background(), second),
+		l.Load(context.Background(), first),
+	}
+	for _, thunk := range thunks {
+		_, err := thunk()
+		require.NoError(t, err)
+	}
+	value, err := l.Load(context.Background(), second)()
+	require.NoError(t, err)
+	require.Equal(t, second.String(), value)
+	require.Equal(t, 1, calls)
+}
@@ -0,0 +1,175 @@
+package graphql
+
+import (
+	"math"
+	"shop-api-go/internal/core/domain"
+	"strconv"
+	"strings"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/language/ast"
+)
+
+// defaultListSize is the size assumed for list fields without a limit argument.
+const defaultListSize = 10
+
+// maxCost caps the cost of a single field so deeply nested lists cannot overflow.
+const maxCost = math.MaxInt32
+
+// limits measures the depth and complexity of the operation of a validated document.
+type limits struct {
+	schema    *graphql.Schema
+	fragments map[string]*ast.FragmentDefinition
+	variables map[string]any
+}
+
+// checkLimits rejects the operation of a validated document if it nests fields deeper than maxDepth
+// or has a complexity above maxComplexity.
+//
+// Note: Every field costs 1 and list fields multiply the cost of their selection by their limit argument
+// or defaultListSize. Introspection fields are answered from the schema and are not counted.
+func checkLimits(
+	schema *graphql.Schema,
+	document *ast.Document,
+	operationName string,
+	variables map[string]any,
+	maxDepth, maxComplexity int,
+) error {
+	l := &limits{
+		schema:    schema,
+		fragments: make(map[string]*ast.FragmentDefinition),
+		variables: variables,
+	}
+	var operations []*ast.OperationDefinition
+	for _, definition := range document.Definitions {
+		switch d := definition.(type) {
+		case *ast.OperationDefinition:
+			if operationName == "" || (d.Name != nil && d.Name.Value == operationName) {
+				operations = append(operations, d)
+			}
+		case *ast.FragmentDefinition:
+			l.fragments[d.Name.Value] = d
+		}
+	}
+	// The executor reports a missing or ambiguous operation.
+	if len(operations) != 1 {
+		return nil
+	}
+
+	depth, complexity := l.measure(l.rootType(operations[0]), operations[0].SelectionSet)
+	if depth > maxDepth {
+		return domain.ErrQueryTooDeep
+	}
+	if complexity > maxComplexity {
+		return domain.ErrQueryTooComplex
+	}
+	return nil
+}
+
+// rootType returns the object type of the operation's root fields.
+func (l *limits) rootType(operation *ast.OperationDefinition) *graphql.Object {
+	switch operation.Operation {
+	case ast.OperationTypeMutation:
+		return l.schema.MutationType()
+	case ast.OperationTypeSubscription:
+		return l.schema.SubscriptionType()
+	default:
+		return l.schema.QueryType()
+	}
+}
+
+// measure returns the depth and complexity of a selection set on an object type,
+// object is nil when the type is unknown.
+func (l *limits) measure(object *graphql.Object, set *ast.SelectionSet) (depth, complexity int) {
+	if set == nil {
+		return 0, 0
+	}
+
+	for _, selection := range set.Selections {
+		var d, c int
+		switch s := selection.(type) {
+		case *ast.Field:
+			if strings.HasPrefix(s.Name.Value, "__") {
+				continue
+			}
+			var child *graphql.Object
+			isList := false
+			if object != nil {
+				if field, ok := object.Fields()[s.Name.Value]; ok {
+					child, isList = unwrapType(field.Type)
+				}
+			}
+			d, c = l.measure(child, s.SelectionSet)
+			d++
+			c = min(c+1, maxCost)
+			if isList {
+				c = min(c*l.listSize(s), maxCost)
+			}
+		case *ast.InlineFragment:
+			d, c = l.measure(l.conditionType(s.TypeCondition, object), s.SelectionSet)
+		case *ast.FragmentSpread:
+			if fragment, ok := l.fragments[s.Name.Value]; ok {
+				d, c = l.measure(l.conditionType(fragment.TypeCondition, object), fragment.SelectionSet)
+			}
+		}
+		depth = max(depth, d)
+		complexity = min(complexity+c, maxCost)
+	}
+	return depth, complexity
+}
+
+// conditionType returns the object type of a fragment's type condition, or object if there is none.
+func (l *limits) conditionType(condition *ast.Named, object *graphql.Object) *graphql.Object {
+	if condition == nil {
+		return object
+	}
+	t, _ := l.schema.Type(condition.Name.Value).(*graphql.Object)
+	return t
+}
+
+// listSize returns the limit argument of a list field, or defaultListSize if it has none.
+func (l *limits) listSize(field *ast.Field) int {
+	for _, argument := range field.Arguments {
+		if argument.Name.Value != "limit" {
+			continue
+		}
+
+		switch v := argument.Value.(type) {
+		case *ast.IntValue:
+			if size, err := strconv.Atoi(v.Value); err == nil && size > 0 {
+				return size
+			}
+		case *ast.Variable:
+			switch size := l.variables[v.Name.Value].(type) {
+			case float64:
+				if size > 0 {
+					return int(min(size, maxCost))
+				}
+			case int:
+				if size > 0 {
+					return size
+				}
+			}
+		}
+	}
+	return defaultListSize
+}
+
+// unwrapType returns the object type of a field without its non-null and list wrappers,
+// nil for scalars, and whether the field is a list.
+func unwrapType(t graphql.Type) (*graphql.Object, bool) {
+	isList := false
+	for {
+		switch wrapper := t.(type) {
+		case *graphql.NonNull:
+			t = wrapper.OfType
+		case *graphql.List:
+			isList = true
+			t = wrapper.OfType
+		case *graphql.Object:
+			return wrapper, isList
+		default:
+			return nil, isList
+		}
+	}
+}
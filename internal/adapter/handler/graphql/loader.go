@@ -0,0 +1,158 @@
+package graphql
+
+import (
+	"context"
+	"shop-api-go/internal/adapter/handler/http/response"
+	"shop-api-go/internal/core/domain"
+	"shop-api-go/internal/core/port"
+	"sync"
+
+	"github.com/google/uuid"
+)
+
+// loader batches the keys loaded while resolving a level of a query into a single fetch.
+//
+// Note: graphql-go resolves the thunks returned by resolvers only after every field of the level
+// returned its thunk, so the keys of all siblings are collected before the first thunk fetches them.
+// A loader lives for a single request and caches what it fetched.
+type loader[V any] struct {
+	fetch   func(ctx context.Context, keys []uuid.UUID) (map[uuid.UUID]V, error)
+	mu      sync.Mutex
+	pending []uuid.UUID
+	values  map[uuid.UUID]V
+	errs    map[uuid.UUID]error
+}
+
+// newLoader creates a new loader instance.
+func newLoader[V any](fetch func(ctx context.Context, keys []uuid.UUID) (map[uuid.UUID]V, error)) *loader[V] {
+	return &loader[V]{
+		fetch:  fetch,
+		values: make(map[uuid.UUID]V),
+		errs:   make(map[uuid.UUID]error),
+	}
+}
+
+// Load queues the key and returns a thunk resolving to its value, or nil if nothing was found for it.
+func (l *loader[V]) Load(ctx context.Context, key uuid.UUID) func() (any, error) {
+	l.mu.Lock()
+	_, fetched := l.values[key]
+	_, failed := l.errs[key]
+	if !fetched && !failed && !l.isPending(key) {
+		l.pending = append(l.pending, key)
+	}
+	l.mu.Unlock()
+
+	return func() (any, error) {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+
+		if len(l.pending) > 0 {
+			keys := l.pending
+			l.pending = nil
+			values, err := l.fetch(ctx, keys)
+			for _, k := range keys {
+				if err != nil {
+					l.errs[k] = err
+				} else if value, ok := values[k]; ok {
+					l.values[k] = value
+				}
+			}
+		}
+
+		if err, ok := l.errs[key]; ok {
+			return nil, newResolverError(err)
+		}
+		if value, ok := l.values[key]; ok {
+			return value, nil
+		}
+		return nil, nil
+	}
+}
+
+// isPending reports whether the key is queued for the next fetch.
+func (l *loader[V]) isPending(key uuid.UUID) bool {
+	for _, k := range l.pending {
+		if k == key {
+			return true
+		}
+	}
+	return false
+}
+
+// loaders contains the loaders of a single request.
+type loaders struct {
+	productSubcategories  *loader[[]response.SubcategoryResponse]
+	categorySubcategories *loader[[]response.SubcategoryResponse]
+	sectionCategories     *loader[[]response.CategoryResponse]
+	categories            *loader[response.CategoryResponse]
+	categorySections      *loader[response.CategorySectionResponse]
+}
+
+// newLoaders creates the loaders for a request fetching from the product service.
+func newLoaders(productService port.ProductService) *loaders {
+	return &loaders{
+		productSubcategories: newLoader(func(ctx context.Context, keys []uuid.UUID) (map[uuid.UUID][]response.SubcategoryResponse, error) {
+			subcategories, err := productService.GetProductSubcategories(ctx, keys)
+			return convertLists(keys, subcategories, response.NewSubcategoryResponse), err
+		}),
+		categorySubcategories: newLoader(func(ctx context.Context, keys []uuid.UUID) (map[uuid.UUID][]response.SubcategoryResponse, error) {
+			subcategories, err := productService.GetCategorySubcategories(ctx, keys)
+			return convertLists(keys, subcategories, response.NewSubcategoryResponse), err
+		}),
+		sectionCategories: newLoader(func(ctx context.Context, keys []uuid.UUID) (map[uuid.UUID][]response.CategoryResponse, error) {
+			categories, err := productService.GetSectionCategories(ctx, keys)
+			return convertLists(keys, categories, response.NewCategoryResponse), err
+		}),
+		categories: newLoader(func(ctx context.Context, keys []uuid.UUID) (map[uuid.UUID]response.CategoryResponse, error) {
+			categories, err := productService.GetCategoriesByIds(ctx, keys)
+			return convertValues(categories, response.NewCategoryResponse), err
+		}),
+		categorySections: newLoader(func(ctx context.Context, keys []uuid.UUID) (map[uuid.UUID]response.CategorySectionResponse, error) {
+			sections, err := productService.GetCategorySectionsByIds(ctx, keys)
+			return convertValues(sections, response.NewCategorySectionResponse), err
+		}),
+	}
+}
+
+// convertValues converts the fetched entities into their responses.
+func convertValues[T, R any](values map[uuid.UUID]T, convert func(*T) R) map[uuid.UUID]R {
+	result := make(map[uuid.UUID]R, len(values))
+	for key, value := range values {
+		result[key] = convert(&value)
+	}
+	return result
+}
+
+// convertLists converts the fetched lists of entities into lists of their responses,
+// keys without entities get an empty list.
+func convertLists[T, R any](keys []uuid.UUID, values map[uuid.UUID][]T, convert func(*T) R) map[uuid.UUID][]R {
+	result := make(map[uuid.UUID][]R, len(keys))
+	for _, key := range keys {
+		list := make([]R, 0, len(values[key]))
+		for _, value := range values[key] {
+			list = append(list, convert(&value))
+		}
+		result[key] = list
+	}
+	return result
+}
+
+// requestKey is the context key of the request state.
+type requestKey struct{}
+
+// request contains the state of a single GraphQL request passed to the resolvers.
+type request struct {
+	token   *domain.Token
+	loaders *loaders
+}
+
+// withRequest returns a copy of ctx carrying the request state.
+func withRequest(ctx context.Context, r *request) context.Context {
+	return context.WithValue(ctx, requestKey{}, r)
+}
+
+// requestFrom returns the request state of ctx.
+func requestFrom(ctx context.Context) *request {
+	r, _ := ctx.Value(requestKey{}).(*request)
+	return r
+}
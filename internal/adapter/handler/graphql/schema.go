@@ -0,0 +1,205 @@
+package graphql
+
+import (
+	"shop-api-go/internal/adapter/handler/http/response"
+	"shop-api-go/internal/core/domain"
+	"shop-api-go/internal/core/port"
+
+	"github.com/google/uuid"
+	"github.com/graphql-go/graphql"
+)
+
+// newSchema creates the GraphQL schema resolved by the services.
+//
+// Note: Types are resolved from the REST response DTOs, their fields match the json names of the DTOs.
+// Related entities are loaded through the loaders of the request to avoid a query per parent.
+func newSchema(userService port.UserService, productService port.ProductService) (graphql.Schema, error) {
+	userType := graphql.NewObject(graphql.ObjectConfig{
+		Name:        "User",
+		Description: "Account of a user.",
+		Fields: graphql.Fields{
+			"id":        &graphql.Field{Type: graphql.NewNonNull(graphql.ID)},
+			"username":  &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"email":     &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"role":      &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"status":    &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"createdAt": &graphql.Field{Type: graphql.NewNonNull(graphql.DateTime)},
+			"updatedAt": &graphql.Field{Type: graphql.NewNonNull(graphql.DateTime)},
+		},
+	})
+
+	var categoryType *graphql.Object
+	categorySectionType := graphql.NewObject(graphql.ObjectConfig{
+		Name:        "CategorySection",
+		Description: "Top level group of categories.",
+		Fields: graphql.FieldsThunk(func() graphql.Fields {
+			return graphql.Fields{
+				"id":   &graphql.Field{Type: graphql.NewNonNull(graphql.ID)},
+				"name": &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+				"categories": &graphql.Field{
+					Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(categoryType))),
+					Resolve: func(p graphql.ResolveParams) (any, error) {
+						section := p.Source.(response.CategorySectionResponse)
+						return requestFrom(p.Context).loaders.sectionCategories.Load(p.Context, section.Id), nil
+					},
+				},
+			}
+		}),
+	})
+
+	subcategoryType := graphql.NewObject(graphql.ObjectConfig{
+		Name:        "Subcategory",
+		Description: "Category products are listed in.",
+		Fields: graphql.FieldsThunk(func() graphql.Fields {
+			return graphql.Fields{
+				"id":         &graphql.Field{Type: graphql.NewNonNull(graphql.ID)},
+				"name":       &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+				"categoryId": &graphql.Field{Type: graphql.ID},
+				"category": &graphql.Field{
+					Type: categoryType,
+					Resolve: func(p graphql.ResolveParams) (any, error) {
+						subcategory := p.Source.(response.SubcategoryResponse)
+						if subcategory.CategoryId == nil {
+							return nil, nil
+						}
+						return requestFrom(p.Context).loaders.categories.Load(p.Context, *subcategory.CategoryId), nil
+					},
+				},
+			}
+		}),
+	})
+
+	categoryType = graphql.NewObject(graphql.ObjectConfig{
+		Name:        "Category",
+		Description: "Group of subcategories within a section.",
+		Fields: graphql.Fields{
+			"id":        &graphql.Field{Type: graphql.NewNonNull(graphql.ID)},
+			"name":      &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"sectionId": &graphql.Field{Type: graphql.ID},
+			"section": &graphql.Field{
+				Type: categorySectionType,
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					category := p.Source.(response.CategoryResponse)
+					if category.SectionId == nil {
+						return nil, nil
+					}
+					return requestFrom(p.Context).loaders.categorySections.Load(p.Context, *category.SectionId), nil
+				},
+			},
+			"subcategories": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(subcategoryType))),
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					category := p.Source.(response.CategoryResponse)
+					return requestFrom(p.Context).loaders.categorySubcategories.Load(p.Context, category.Id), nil
+				},
+			},
+		},
+	})
+
+	productType := graphql.NewObject(graphql.ObjectConfig{
+		Name:        "Product",
+		Description: "Product of the catalog.",
+		Fields: graphql.Fields{
+			"id":          &graphql.Field{Type: graphql.NewNonNull(graphql.ID)},
+			"name":        &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"description": &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"price":       &graphql.Field{Type: graphql.NewNonNull(graphql.String), Description: "Decimal price, e.g. \"89.99\"."},
+			"rating":      &graphql.Field{Type: graphql.NewNonNull(graphql.String), Description: "Decimal rating from 0 to 5."},
+			"count":       &graphql.Field{Type: graphql.NewNonNull(graphql.Int)},
+			"imageUrl":    &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"createdAt":   &graphql.Field{Type: graphql.NewNonNull(graphql.DateTime)},
+			"updatedAt":   &graphql.Field{Type: graphql.NewNonNull(graphql.DateTime)},
+			"subcategories": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(subcategoryType))),
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					product := p.Source.(response.ProductResponse)
+					return requestFrom(p.Context).loaders.productSubcategories.Load(p.Context, product.Id), nil
+				},
+			},
+		},
+	})
+
+	queryType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Query",
+		Fields: graphql.Fields{
+			"me": &graphql.Field{
+				Type:        graphql.NewNonNull(userType),
+				Description: "Account of the authenticated user.",
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					user, err := userService.GetAccount(p.Context, requestFrom(p.Context).token)
+					if err != nil {
+						return nil, newResolverError(err)
+					}
+					return response.NewAccountResponse(user), nil
+				},
+			},
+			"product": &graphql.Field{
+				Type: productType,
+				Args: graphql.FieldConfigArgument{
+					"id": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.ID)},
+				},
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					id, err := uuid.Parse(p.Args["id"].(string))
+					if err != nil {
+						return nil, newResolverError(domain.ErrInvalidUUID)
+					}
+
+					product, err := productService.GetProduct(p.Context, id)
+					if err != nil {
+						return nil, newResolverError(err)
+					}
+					return response.NewProductResponse(product), nil
+				},
+			},
+			"products": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(productType))),
+				Description: "Products from newest to oldest, optionally in a subcategory.",
+				Args: graphql.FieldConfigArgument{
+					"subcategoryId": &graphql.ArgumentConfig{Type: graphql.ID},
+					"page":          &graphql.ArgumentConfig{Type: graphql.Int, DefaultValue: 1},
+					"limit":         &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.Int)},
+				},
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					var subcategoryId *uuid.UUID
+					if arg, ok := p.Args["subcategoryId"].(string); ok {
+						id, err := uuid.Parse(arg)
+						if err != nil {
+							return nil, newResolverError(domain.ErrInvalidUUID)
+						}
+						subcategoryId = &id
+					}
+					page, _ := p.Args["page"].(int)
+
+					products, err := productService.GetProducts(
+						p.Context,
+						domain.NewProductFilter(subcategoryId, page, p.Args["limit"].(int)),
+					)
+					if err != nil {
+						return nil, newResolverError(err)
+					}
+					result := make([]response.ProductResponse, 0, len(products))
+					for _, product := range products {
+						result = append(result, response.NewProductResponse(&product))
+					}
+					return result, nil
+				},
+			},
+			"categorySections": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(categorySectionType))),
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					sections, err := productService.GetCategorySections(p.Context)
+					if err != nil {
+						return nil, newResolverError(err)
+					}
+					result := make([]response.CategorySectionResponse, 0, len(sections))
+					for _, section := range sections {
+						result = append(result, response.NewCategorySectionResponse(&section))
+					}
+					return result, nil
+				},
+			},
+		},
+	})
+
+	return graphql.NewSchema(graphql.SchemaConfig{Query: queryType})
+}
@@ -0,0 +1,317 @@
+package grpc
+
+import (
+	"context"
+	"shop-api-go/internal/adapter/handler/api"
+	"shop-api-go/internal/adapter/handler/http/request"
+	"shop-api-go/internal/core/domain"
+	"shop-api-go/internal/core/port"
+	shopv1 "shop-api-go/proto/shop/v1"
+	"time"
+
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// AdminServer implements shopv1.AdminServiceServer and handles admin-related calls.
+type AdminServer struct {
+	shopv1.UnimplementedAdminServiceServer
+	adminService port.AdminService
+}
+
+// NewAdminServer creates a new AdminServer instance.
+func NewAdminServer(adminService port.AdminService) *AdminServer {
+	return &AdminServer{adminService: adminService}
+}
+
+func (s *AdminServer) GetUsers(ctx context.Context, req *shopv1.GetUsersRequest) (*shopv1.GetUsersResponse, error) {
+	token, err := tokenFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	query := request.GetUserQuery{
+		UserFilterQuery: newUserFilterQuery(req.GetFilter()),
+		Sort:            domain.UserSortField(req.GetSort()),
+		Order:           req.GetOrder(),
+		Page:            intPointer(req.Page),
+		Cursor:          req.Cursor,
+		Limit:           intPointer(req.Limit),
+		Total:           req.GetTotal(),
+	}
+	if query.Sort == "" {
+		query.Sort = domain.UserSortCreatedAt
+	}
+	if query.Order == "" {
+		query.Order = "asc"
+	}
+	if err = validate(&query); err != nil {
+		return nil, err
+	}
+
+	var id *uuid.UUID
+	if query.Id != nil {
+		parsedId, err := uuid.Parse(*query.Id)
+		if err != nil {
+			return nil, domain.ErrInvalidUUID
+		}
+		id = &parsedId
+	}
+	filter := domain.NewUserFilter(
+		id,
+		query.Username,
+		query.Email,
+		query.Role,
+		query.Status,
+		query.CreatedFrom,
+		query.CreatedTo,
+		query.UpdatedFrom,
+		query.UpdatedTo,
+	)
+
+	var cursor *domain.UserCursor
+	if query.Cursor != nil && *query.Cursor != "" {
+		cursor, err = api.DecodeUserCursor(*query.Cursor)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	result, err := s.adminService.GetUsers(
+		ctx,
+		token,
+		domain.NewGetUsers(
+			filter, query.Sort, query.Order == "desc", query.Page, cursor, query.Limit, query.Total,
+		),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	users := make([]*shopv1.User, 0, len(result.Users))
+	for _, user := range result.Users {
+		users = append(users, newUser(&user))
+	}
+	res := &shopv1.GetUsersResponse{
+		Users:  users,
+		Cursor: api.EncodeUserCursor(result.Cursor),
+	}
+	if result.Total != nil {
+		total := int64(*result.Total)
+		res.Total = &total
+	}
+	return res, nil
+}
+
+func (s *AdminServer) CreateUser(ctx context.Context, req *shopv1.CreateUserRequest) (*emptypb.Empty, error) {
+	token, err := tokenFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	create := request.CreateUser{
+		Email:    req.GetEmail(),
+		Username: req.GetUsername(),
+		Password: req.GetPassword(),
+		Role:     domain.UserRole(req.GetRole()),
+	}
+	if err = validate(&create); err != nil {
+		return nil, err
+	}
+
+	if err = s.adminService.CreateUser(ctx, token, &domain.User{
+		Email:    create.Email,
+		Username: create.Username,
+		Password: create.Password,
+		Role:     create.Role,
+	}); err != nil {
+		return nil, err
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (s *AdminServer) UpdateUser(ctx context.Context, req *shopv1.UpdateUserRequest) (*emptypb.Empty, error) {
+	token, err := tokenFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := uuid.Parse(req.GetId())
+	if err != nil {
+		return nil, domain.ErrInvalidUUID
+	}
+
+	update := request.UpdateUser{
+		Username: req.Username,
+		Email:    req.Email,
+		Password: req.Password,
+	}
+	if req.Role != nil {
+		role := domain.UserRole(*req.Role)
+		update.Role = &role
+	}
+	if err = validate(&update); err != nil {
+		return nil, err
+	}
+
+	if err = s.adminService.UpdateUser(
+		ctx,
+		token,
+		domain.NewUserUpdate(id, update.Username, update.Email, update.Password, update.Role),
+	); err != nil {
+		return nil, err
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (s *AdminServer) SuspendUser(ctx context.Context, req *shopv1.UserIdRequest) (*emptypb.Empty, error) {
+	return s.withUserId(ctx, req, s.adminService.SuspendUser)
+}
+
+func (s *AdminServer) ReactivateUser(ctx context.Context, req *shopv1.UserIdRequest) (*emptypb.Empty, error) {
+	return s.withUserId(ctx, req, s.adminService.ReactivateUser)
+}
+
+func (s *AdminServer) DeleteUser(ctx context.Context, req *shopv1.UserIdRequest) (*emptypb.Empty, error) {
+	return s.withUserId(ctx, req, s.adminService.DeleteUser)
+}
+
+func (s *AdminServer) RestoreUser(ctx context.Context, req *shopv1.UserIdRequest) (*emptypb.Empty, error) {
+	return s.withUserId(ctx, req, s.adminService.RestoreUser)
+}
+
+func (s *AdminServer) UnlockUser(ctx context.Context, req *shopv1.UserIdRequest) (*emptypb.Empty, error) {
+	return s.withUserId(ctx, req, s.adminService.UnlockUser)
+}
+
+func (s *AdminServer) ImpersonateUser(ctx context.Context, req *shopv1.UserIdRequest) (*shopv1.ImpersonationToken, error) {
+	token, err := tokenFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := uuid.Parse(req.GetId())
+	if err != nil {
+		return nil, domain.ErrInvalidUUID
+	}
+
+	impersonationToken, err := s.adminService.ImpersonateUser(ctx, token, id)
+	if err != nil {
+		return nil, err
+	}
+	return &shopv1.ImpersonationToken{
+		AccessToken: impersonationToken.AccessToken,
+		ExpiresAt:   timestamppb.New(impersonationToken.ExpiresAt),
+	}, nil
+}
+
+func (s *AdminServer) GetMFARequirements(ctx context.Context, _ *emptypb.Empty) (*shopv1.GetMFARequirementsResponse, error) {
+	token, err := tokenFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	requirements, err := s.adminService.GetMFARequirements(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &shopv1.GetMFARequirementsResponse{
+		Requirements: make([]*shopv1.RoleMFARequirement, 0, len(requirements)),
+	}
+	for _, requirement := range requirements {
+		res.Requirements = append(res.Requirements, &shopv1.RoleMFARequirement{
+			Role:     string(requirement.Role),
+			Required: requirement.Required,
+		})
+	}
+	return res, nil
+}
+
+func (s *AdminServer) SetMFARequirement(ctx context.Context, req *shopv1.RoleMFARequirement) (*emptypb.Empty, error) {
+	token, err := tokenFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	uri := request.RoleUri{Role: domain.UserRole(req.GetRole())}
+	if err = validate(&uri); err != nil {
+		return nil, err
+	}
+
+	if err = s.adminService.SetMFARequirement(
+		ctx,
+		token,
+		domain.NewRoleMFARequirement(uri.Role, req.GetRequired()),
+	); err != nil {
+		return nil, err
+	}
+	return &emptypb.Empty{}, nil
+}
+
+// withUserId parses the id of the request and calls fn with it.
+func (s *AdminServer) withUserId(
+	ctx context.Context,
+	req *shopv1.UserIdRequest,
+	fn func(ctx context.Context, token *domain.Token, id uuid.UUID) error,
+) (*emptypb.Empty, error) {
+	token, err := tokenFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := uuid.Parse(req.GetId())
+	if err != nil {
+		return nil, domain.ErrInvalidUUID
+	}
+
+	if err = fn(ctx, token, id); err != nil {
+		return nil, err
+	}
+	return &emptypb.Empty{}, nil
+}
+
+// newUserFilterQuery converts a shopv1.UserFilter into the query validated by the REST API.
+func newUserFilterQuery(filter *shopv1.UserFilter) request.UserFilterQuery {
+	if filter == nil {
+		return request.UserFilterQuery{}
+	}
+
+	query := request.UserFilterQuery{
+		Id:          filter.Id,
+		Username:    filter.Username,
+		Email:       filter.Email,
+		CreatedFrom: timePointer(filter.GetCreatedFrom()),
+		CreatedTo:   timePointer(filter.GetCreatedTo()),
+		UpdatedFrom: timePointer(filter.GetUpdatedFrom()),
+		UpdatedTo:   timePointer(filter.GetUpdatedTo()),
+	}
+	if filter.Role != nil {
+		role := domain.UserRole(*filter.Role)
+		query.Role = &role
+	}
+	if filter.Status != nil {
+		status := domain.UserStatus(*filter.Status)
+		query.Status = &status
+	}
+	return query
+}
+
+// intPointer converts an optional int32 field.
+func intPointer(v *int32) *int {
+	if v == nil {
+		return nil
+	}
+	i := int(*v)
+	return &i
+}
+
+// timePointer converts an optional timestamp field.
+func timePointer(t *timestamppb.Timestamp) *time.Time {
+	if t == nil {
+		return nil
+	}
+	v := t.AsTime()
+	return &v
+}
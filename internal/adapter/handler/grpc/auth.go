@@ -0,0 +1,72 @@
+package grpc
+
+import (
+	"context"
+	"shop-api-go/internal/adapter/handler/http/request"
+	"shop-api-go/internal/core/domain"
+	"shop-api-go/internal/core/port"
+	shopv1 "shop-api-go/proto/shop/v1"
+)
+
+// AuthServer implements shopv1.AuthServiceServer and handles authentication-related calls.
+type AuthServer struct {
+	shopv1.UnimplementedAuthServiceServer
+	authService port.AuthService
+}
+
+// NewAuthServer creates a new AuthServer instance.
+func NewAuthServer(authService port.AuthService) *AuthServer {
+	return &AuthServer{authService: authService}
+}
+
+func (s *AuthServer) Login(ctx context.Context, req *shopv1.LoginRequest) (*shopv1.LoginResponse, error) {
+	result, err := s.authService.Login(ctx, &domain.User{
+		Username: req.GetUsername(),
+		Password: req.GetPassword(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if result.MFAToken != nil {
+		return &shopv1.LoginResponse{Result: &shopv1.LoginResponse_MfaToken{MfaToken: *result.MFAToken}}, nil
+	}
+	return &shopv1.LoginResponse{Result: &shopv1.LoginResponse_Tokens{Tokens: newTokens(result.TokenGroup)}}, nil
+}
+
+func (s *AuthServer) VerifyMFA(ctx context.Context, req *shopv1.VerifyMFARequest) (*shopv1.Tokens, error) {
+	token, err := tokenFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err = validate(&request.VerifyMFARequest{Code: req.GetCode()}); err != nil {
+		return nil, err
+	}
+
+	tokenGroup, err := s.authService.VerifyMFA(ctx, token, req.GetCode())
+	if err != nil {
+		return nil, err
+	}
+	return newTokens(tokenGroup), nil
+}
+
+func (s *AuthServer) RefreshSession(ctx context.Context, _ *shopv1.RefreshSessionRequest) (*shopv1.Tokens, error) {
+	token, err := tokenFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	tokenGroup, err := s.authService.RefreshSession(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+	return newTokens(tokenGroup), nil
+}
+
+// newTokens creates a shopv1.Tokens message.
+func newTokens(group *domain.TokenGroup) *shopv1.Tokens {
+	return &shopv1.Tokens{
+		AccessToken:  group.AccessToken,
+		RefreshToken: group.RefreshToken,
+	}
+}
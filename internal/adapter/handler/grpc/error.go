@@ -0,0 +1,73 @@
+package grpc
+
+import (
+	"errors"
+	"net/http"
+	"shop-api-go/internal/adapter/handler/http/response"
+	"shop-api-go/internal/core/domain"
+	"strings"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// errorDomain is the domain of the google.rpc.ErrorInfo attached to status errors.
+const errorDomain = "shop-api"
+
+// statusCodes maps the HTTP status codes of the REST error responses to gRPC codes.
+var statusCodes = map[int]codes.Code{
+	http.StatusBadRequest:            codes.InvalidArgument,
+	http.StatusUnauthorized:          codes.Unauthenticated,
+	http.StatusForbidden:             codes.PermissionDenied,
+	http.StatusNotFound:              codes.NotFound,
+	http.StatusConflict:              codes.FailedPrecondition,
+	http.StatusGone:                  codes.NotFound,
+	http.StatusRequestEntityTooLarge: codes.InvalidArgument,
+	http.StatusUnsupportedMediaType:  codes.InvalidArgument,
+	http.StatusTooManyRequests:       codes.ResourceExhausted,
+	http.StatusInternalServerError:   codes.Internal,
+}
+
+// alreadyExists contains the conflicts reported as codes.AlreadyExists instead of codes.FailedPrecondition.
+var alreadyExists = []error{
+	domain.ErrEmailAlreadyInUse,
+	domain.ErrUsernameAlreadyInUse,
+	domain.ErrRoleAlreadyExists,
+	domain.ErrMFAAlreadyEnabled,
+}
+
+// toStatus converts an error returned by a service into a status error with the code and messages
+// of its REST error response, the REST error code is attached as the reason of a google.rpc.ErrorInfo.
+//
+// Note: Status errors are returned unchanged.
+func toStatus(err error) error {
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+	return newStatus(response.NewErrorResponse(err), err)
+}
+
+// invalidArgument converts a failed request validation into a status error.
+func invalidArgument(err error) error {
+	return newStatus(response.NewBindingErrorResponse(err), err)
+}
+
+// newStatus creates the status error of an error response.
+func newStatus(res response.ErrorResponse, err error) error {
+	code, ok := statusCodes[res.StatusCode()]
+	if !ok {
+		code = codes.Unknown
+	}
+	for _, target := range alreadyExists {
+		if errors.Is(err, target) {
+			code = codes.AlreadyExists
+		}
+	}
+
+	st := status.New(code, strings.Join(res.Messages, " "))
+	if detailed, detailsErr := st.WithDetails(&errdetails.ErrorInfo{Reason: res.Code, Domain: errorDomain}); detailsErr == nil {
+		st = detailed
+	}
+	return st.Err()
+}
@@ -0,0 +1,26 @@
+package grpc
+
+import (
+	"context"
+
+	"go.uber.org/fx"
+)
+
+var Module = fx.Module(
+	"gRPC",
+	fx.Provide(NewAuthServer),
+	fx.Provide(NewUserServer),
+	fx.Provide(NewAdminServer),
+	fx.Provide(NewProductServer),
+	fx.Provide(NewServer),
+	fx.Invoke(func(lc fx.Lifecycle, server *Server) {
+		lc.Append(fx.Hook{
+			OnStart: func(context.Context) error {
+				return server.Start()
+			},
+			OnStop: func(ctx context.Context) error {
+				return server.Shutdown(ctx)
+			},
+		})
+	}),
+)
@@ -0,0 +1,288 @@
+package grpc
+
+import (
+	"context"
+	"math"
+	"net"
+	"shop-api-go/internal/adapter/config"
+	"shop-api-go/internal/adapter/handler/api"
+	"shop-api-go/internal/core/domain"
+	"shop-api-go/internal/core/port"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// requestIdKey is the metadata key used to pass the request id.
+var requestIdKey = strings.ToLower(api.RequestIdHeader)
+
+// RequestMetadataInterceptor is an interceptor used to attach domain.RequestMetadata to the call context
+// and log incoming calls.
+//
+// Note: A new request id is generated if the client did not send a valid one.
+func RequestMetadataInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		start := time.Now()
+		md, _ := metadata.FromIncomingContext(ctx)
+		requestId := firstValue(md, requestIdKey)
+		if _, err := uuid.Parse(requestId); err != nil {
+			requestId = uuid.NewString()
+		}
+		_ = grpc.SetHeader(ctx, metadata.Pairs(requestIdKey, requestId))
+
+		clientIP := ""
+		if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+			clientIP = p.Addr.String()
+			if host, _, err := net.SplitHostPort(clientIP); err == nil {
+				clientIP = host
+			}
+		}
+		userAgent := firstValue(md, "user-agent")
+
+		resp, err := handler(
+			domain.ContextWithRequestMetadata(ctx, domain.NewRequestMetadata(clientIP, userAgent, requestId)),
+			req,
+		)
+
+		zap.L().Debug(
+			"incoming call",
+			zap.String("ip", clientIP),
+			zap.String("method", info.FullMethod),
+			zap.String("code", status.Code(err).String()),
+			zap.String("user-agent", userAgent),
+			zap.String("request-id", requestId),
+			zap.Duration("latency", time.Since(start)),
+		)
+		return resp, err
+	}
+}
+
+// AuthInterceptor is an interceptor used to authenticate calls of the shop services with the JWT or API key
+// sent as "Bearer <token>" in the authorization metadata, like middleware.AuthMiddleware.
+// Calls made with an impersonation token are recorded in the audit log with their status code.
+//
+// Note: Methods in public are called without a token and services of other packages, e.g. health,
+// are not authenticated. The token is read by handlers with tokenFromContext.
+func AuthInterceptor(
+	generator port.TokenGenerator,
+	apiKeyService port.APIKeyService,
+	auditService port.AuditService,
+	public map[string]bool,
+) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if public[info.FullMethod] || !strings.HasPrefix(info.FullMethod, "/shop.v1.") {
+			return handler(ctx, req)
+		}
+
+		md, _ := metadata.FromIncomingContext(ctx)
+		header := firstValue(md, "authorization")
+		if !strings.HasPrefix(header, "Bearer ") {
+			return nil, toStatus(domain.ErrInvalidToken)
+		}
+
+		tokenString := strings.TrimPrefix(header, "Bearer ")
+
+		// JWTs always consist of three dot separated parts, API keys never contain a dot.
+		var token *domain.Token
+		var err error
+		if strings.Count(tokenString, ".") == 2 {
+			token, err = generator.ParseToken(tokenString)
+			if err != nil {
+				err = domain.ErrInvalidToken
+			}
+		} else {
+			token, err = apiKeyService.Authenticate(ctx, tokenString)
+		}
+		if err != nil {
+			return nil, toStatus(err)
+		}
+
+		resp, err := handler(contextWithToken(ctx, token), req)
+		if token.IsImpersonated() {
+			if auditErr := auditService.RecordImpersonatedRequest(
+				ctx, token, "GRPC", info.FullMethod, int(status.Code(err)),
+			); auditErr != nil {
+				zap.L().Error(
+					"error recording impersonated request",
+					zap.String("actor_id", token.ActorId.String()),
+					zap.String("user_id", token.UserId.String()),
+					zap.Error(auditErr),
+				)
+			}
+		}
+		return resp, err
+	}
+}
+
+// ErrorInterceptor is an interceptor used to convert the errors returned by handlers into status errors.
+func ErrorInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		resp, err := handler(ctx, req)
+		if err != nil {
+			return nil, toStatus(err)
+		}
+		return resp, nil
+	}
+}
+
+// FeatureInterceptor is an interceptor used to reject calls of methods whose feature is switched off
+// in the runtime settings, the error is converted by ErrorInterceptor.
+func FeatureInterceptor(settingsService port.RuntimeSettingsService, features map[string]domain.Feature) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if feature, ok := features[info.FullMethod]; ok && !settingsService.Current().FeatureEnabled(feature) {
+			return nil, domain.ErrFeatureDisabled
+		}
+		return handler(ctx, req)
+	}
+}
+
+// RateLimitInterceptor is an interceptor used to limit the amount of calls sent to the shop services
+// per client IP, like middleware.RateLimit does for anonymous routes. The rate limit group of a call is looked up
+// by its service name in groups, the error is converted by ErrorInterceptor.
+//
+// Note: It runs before AuthInterceptor, so calls with invalid tokens are counted as well. Calls of public methods
+// are limited with the default limit of their group, other calls with the highest limit of any role since their
+// role is not known yet; UserRateLimitInterceptor limits them per user afterward. Calls are allowed if the store fails.
+func RateLimitInterceptor(
+	store port.RateLimitStore,
+	settingsService port.RuntimeSettingsService,
+	groups map[string]config.RateLimitGroup,
+	public map[string]bool,
+) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		group, policy, ok := rateLimitPolicy(settingsService, groups, info.FullMethod)
+		if !ok {
+			return handler(ctx, req)
+		}
+
+		// Only public calls are not limited again, so only they report the state of the bucket.
+		bucketKey := group + ":ip:" + domain.RequestMetadataFromContext(ctx).ClientIP
+		if public[info.FullMethod] {
+			err := takeRateLimit(ctx, store, bucketKey, policy.Default, true)
+			if err != nil {
+				return nil, err
+			}
+			return handler(ctx, req)
+		}
+		if err := takeRateLimit(ctx, store, bucketKey, policy.Highest(), false); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// UserRateLimitInterceptor is an interceptor used to limit the amount of authenticated calls sent to the shop
+// services per token subject using the limit of the token role, like middleware.RateLimit does for authenticated
+// routes. It runs after AuthInterceptor and the error is converted by ErrorInterceptor.
+//
+// Note: Calls are allowed if the store fails.
+func UserRateLimitInterceptor(
+	store port.RateLimitStore,
+	settingsService port.RuntimeSettingsService,
+	groups map[string]config.RateLimitGroup,
+) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		token, err := tokenFromContext(ctx)
+		if err != nil {
+			return handler(ctx, req)
+		}
+		group, policy, ok := rateLimitPolicy(settingsService, groups, info.FullMethod)
+		if !ok {
+			return handler(ctx, req)
+		}
+
+		bucketKey := group + ":user:" + token.UserId.String()
+		if err = takeRateLimit(ctx, store, bucketKey, policy.ForRole(token.UserRole), true); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// rateLimitPolicy returns the rate limit group of a method and its current policy,
+// false is returned if the method is not limited.
+func rateLimitPolicy(
+	settingsService port.RuntimeSettingsService,
+	groups map[string]config.RateLimitGroup,
+	fullMethod string,
+) (string, domain.RateLimitPolicy, bool) {
+	service, _, _ := strings.Cut(strings.TrimPrefix(fullMethod, "/"), "/")
+	group, ok := groups[service]
+	if !ok {
+		return "", domain.RateLimitPolicy{}, false
+	}
+
+	settings := settingsService.Current()
+	policy, ok := settings.RateLimitPolicy(string(group))
+	if !settings.RateLimitEnabled || !ok {
+		return "", domain.RateLimitPolicy{}, false
+	}
+	return string(group), policy, true
+}
+
+// takeRateLimit takes a token from the bucket of the key and returns domain.ErrRateLimitExceeded if it is empty.
+// The state of the bucket is sent as header metadata if report is set or the call is rejected.
+func takeRateLimit(ctx context.Context, store port.RateLimitStore, bucketKey string, limit domain.RateLimit, report bool) error {
+	result, err := store.Take(ctx, bucketKey, limit)
+	if err != nil {
+		zap.L().Warn(
+			"rate limit store failed, allowing call",
+			zap.String("key", bucketKey),
+			zap.Error(err),
+		)
+		return nil
+	}
+
+	header := metadata.Pairs(
+		"ratelimit-limit", strconv.Itoa(result.Limit),
+		"ratelimit-remaining", strconv.Itoa(result.Remaining),
+		"ratelimit-reset", seconds(result.ResetAfter),
+	)
+	if !result.Allowed {
+		header.Set("retry-after", seconds(result.RetryAfter))
+		_ = grpc.SetHeader(ctx, header)
+		return domain.ErrRateLimitExceeded
+	}
+	if report {
+		_ = grpc.SetHeader(ctx, header)
+	}
+	return nil
+}
+
+// tokenKey is the context key of the authenticated token.
+type tokenKey struct{}
+
+// contextWithToken returns a copy of ctx that carries the token.
+func contextWithToken(ctx context.Context, token *domain.Token) context.Context {
+	return context.WithValue(ctx, tokenKey{}, token)
+}
+
+// tokenFromContext returns the token stored by AuthInterceptor, domain.ErrInternal is returned
+// if the method is not authenticated.
+func tokenFromContext(ctx context.Context) (*domain.Token, error) {
+	token, ok := ctx.Value(tokenKey{}).(*domain.Token)
+	if !ok {
+		return nil, domain.ErrInternal
+	}
+	return token, nil
+}
+
+// firstValue returns the first value of a metadata key, or an empty string if it is not set.
+func firstValue(md metadata.MD, key string) string {
+	if values := md.Get(key); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
+
+// seconds formats a duration as a whole number of seconds rounded up.
+func seconds(d time.Duration) string {
+	return strconv.Itoa(int(math.Ceil(d.Seconds())))
+}
@@ -0,0 +1,157 @@
+package grpc
+
+import (
+	"context"
+	"shop-api-go/internal/core/domain"
+	"shop-api-go/internal/core/port"
+	shopv1 "shop-api-go/proto/shop/v1"
+
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// ProductServer implements shopv1.ProductServiceServer and handles catalog-related calls.
+//
+// Note: Related entities are fetched with the batch methods of the service, a response
+// costs a fixed number of queries regardless of how many products or sections it contains.
+type ProductServer struct {
+	shopv1.UnimplementedProductServiceServer
+	productService port.ProductService
+}
+
+// NewProductServer creates a new ProductServer instance.
+func NewProductServer(productService port.ProductService) *ProductServer {
+	return &ProductServer{productService: productService}
+}
+
+func (s *ProductServer) GetProducts(ctx context.Context, req *shopv1.GetProductsRequest) (*shopv1.GetProductsResponse, error) {
+	var subcategoryId *uuid.UUID
+	if req.SubcategoryId != nil {
+		id, err := uuid.Parse(*req.SubcategoryId)
+		if err != nil {
+			return nil, domain.ErrInvalidUUID
+		}
+		subcategoryId = &id
+	}
+
+	products, err := s.productService.GetProducts(
+		ctx,
+		domain.NewProductFilter(subcategoryId, int(req.GetPage()), int(req.GetLimit())),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]uuid.UUID, 0, len(products))
+	for _, product := range products {
+		ids = append(ids, product.Id)
+	}
+	subcategories, err := s.productService.GetProductSubcategories(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &shopv1.GetProductsResponse{Products: make([]*shopv1.Product, 0, len(products))}
+	for _, product := range products {
+		product.Subcategories = subcategories[product.Id]
+		res.Products = append(res.Products, newProduct(&product))
+	}
+	return res, nil
+}
+
+func (s *ProductServer) GetProduct(ctx context.Context, req *shopv1.GetProductRequest) (*shopv1.Product, error) {
+	id, err := uuid.Parse(req.GetId())
+	if err != nil {
+		return nil, domain.ErrInvalidUUID
+	}
+
+	product, err := s.productService.GetProduct(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	subcategories, err := s.productService.GetProductSubcategories(ctx, []uuid.UUID{id})
+	if err != nil {
+		return nil, err
+	}
+	product.Subcategories = subcategories[id]
+	return newProduct(product), nil
+}
+
+func (s *ProductServer) GetCategorySections(ctx context.Context, _ *emptypb.Empty) (*shopv1.GetCategorySectionsResponse, error) {
+	sections, err := s.productService.GetCategorySections(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	sectionIds := make([]uuid.UUID, 0, len(sections))
+	for _, section := range sections {
+		sectionIds = append(sectionIds, section.Id)
+	}
+	categories, err := s.productService.GetSectionCategories(ctx, sectionIds)
+	if err != nil {
+		return nil, err
+	}
+
+	var categoryIds []uuid.UUID
+	for _, sectionCategories := range categories {
+		for _, category := range sectionCategories {
+			categoryIds = append(categoryIds, category.Id)
+		}
+	}
+	subcategories, err := s.productService.GetCategorySubcategories(ctx, categoryIds)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &shopv1.GetCategorySectionsResponse{Sections: make([]*shopv1.CategorySection, 0, len(sections))}
+	for _, section := range sections {
+		message := &shopv1.CategorySection{
+			Id:         section.Id.String(),
+			Name:       section.Name,
+			Categories: make([]*shopv1.Category, 0, len(categories[section.Id])),
+		}
+		for _, category := range categories[section.Id] {
+			message.Categories = append(message.Categories, &shopv1.Category{
+				Id:            category.Id.String(),
+				Name:          category.Name,
+				Subcategories: newSubcategories(subcategories[category.Id]),
+			})
+		}
+		res.Sections = append(res.Sections, message)
+	}
+	return res, nil
+}
+
+// newProduct creates a shopv1.Product message.
+func newProduct(p *domain.Product) *shopv1.Product {
+	return &shopv1.Product{
+		Id:            p.Id.String(),
+		Name:          p.Name,
+		Description:   p.Description,
+		Price:         p.Price.String(),
+		Rating:        p.Rating.String(),
+		Count:         int32(p.Count),
+		ImageUrl:      p.ImageUrl,
+		Subcategories: newSubcategories(p.Subcategories),
+		CreatedAt:     timestamppb.New(p.CreatedAt),
+		UpdatedAt:     timestamppb.New(p.UpdatedAt),
+	}
+}
+
+// newSubcategories creates shopv1.Subcategory messages, subcategories without a category have no category id.
+func newSubcategories(subcategories []domain.Subcategory) []*shopv1.Subcategory {
+	result := make([]*shopv1.Subcategory, 0, len(subcategories))
+	for _, subcategory := range subcategories {
+		message := &shopv1.Subcategory{
+			Id:   subcategory.Id.String(),
+			Name: subcategory.Name,
+		}
+		if subcategory.CategoryID != uuid.Nil {
+			categoryId := subcategory.CategoryID.String()
+			message.CategoryId = &categoryId
+		}
+		result = append(result, message)
+	}
+	return result
+}
@@ -0,0 +1,131 @@
+package grpc
+
+import (
+	"context"
+	"net"
+	"shop-api-go/internal/adapter/config"
+	"shop-api-go/internal/adapter/handler/api"
+	"shop-api-go/internal/core/domain"
+	"shop-api-go/internal/core/port"
+	shopv1 "shop-api-go/proto/shop/v1"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
+)
+
+// publicMethods are the methods called without a token.
+var publicMethods = map[string]bool{
+	shopv1.AuthService_Login_FullMethodName:         true,
+	shopv1.UserService_Register_FullMethodName:      true,
+	shopv1.UserService_UpdateAccount_FullMethodName: true,
+}
+
+// featureMethods are the methods that can be switched off in the runtime settings.
+var featureMethods = map[string]domain.Feature{
+	shopv1.UserService_Register_FullMethodName: domain.FeatureRegistration,
+}
+
+// rateLimitGroups are the rate limit groups of the services, matching the route groups of the REST API.
+var rateLimitGroups = map[string]config.RateLimitGroup{
+	shopv1.AuthService_ServiceDesc.ServiceName:    config.AuthRateLimitGroup,
+	shopv1.UserService_ServiceDesc.ServiceName:    config.UsersRateLimitGroup,
+	shopv1.AdminService_ServiceDesc.ServiceName:   config.AdminRateLimitGroup,
+	shopv1.ProductService_ServiceDesc.ServiceName: config.UsersRateLimitGroup,
+}
+
+// Server serves the shop services over gRPC.
+type Server struct {
+	port   string
+	server *grpc.Server
+	health *health.Server
+}
+
+// NewServer creates a new Server instance with the services, health and reflection registered.
+func NewServer(
+	grpcConfig *config.GRPCConfig,
+	tokenGenerator port.TokenGenerator,
+	apiKeyService port.APIKeyService,
+	auditService port.AuditService,
+	runtimeSettingsService port.RuntimeSettingsService,
+	rateLimitStore port.RateLimitStore,
+	authServer *AuthServer,
+	userServer *UserServer,
+	adminServer *AdminServer,
+	productServer *ProductServer,
+) (*Server, error) {
+	// Requests are validated with the binding rules of the REST API.
+	if err := api.RegisterValidations(); err != nil {
+		return nil, err
+	}
+
+	server := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(
+			RequestMetadataInterceptor(),
+			ErrorInterceptor(),
+			// Calls are counted per client IP before authentication, so tokens cannot be guessed without limit.
+			RateLimitInterceptor(rateLimitStore, runtimeSettingsService, rateLimitGroups, publicMethods),
+			AuthInterceptor(tokenGenerator, apiKeyService, auditService, publicMethods),
+			UserRateLimitInterceptor(rateLimitStore, runtimeSettingsService, rateLimitGroups),
+			FeatureInterceptor(runtimeSettingsService, featureMethods),
+		),
+	)
+	shopv1.RegisterAuthServiceServer(server, authServer)
+	shopv1.RegisterUserServiceServer(server, userServer)
+	shopv1.RegisterAdminServiceServer(server, adminServer)
+	shopv1.RegisterProductServiceServer(server, productServer)
+
+	healthServer := health.NewServer()
+	for name := range server.GetServiceInfo() {
+		healthServer.SetServingStatus(name, healthpb.HealthCheckResponse_SERVING)
+	}
+	healthpb.RegisterHealthServer(server, healthServer)
+	reflection.Register(server)
+
+	return &Server{
+		port:   grpcConfig.Port,
+		server: server,
+		health: healthServer,
+	}, nil
+}
+
+// Start listens on the configured port and serves calls in the background.
+func (s *Server) Start() error {
+	listener, err := net.Listen("tcp", s.port)
+	if err != nil {
+		return err
+	}
+	return s.Serve(listener)
+}
+
+// Serve serves calls accepted by the listener in the background.
+func (s *Server) Serve(listener net.Listener) error {
+	go func() {
+		if err := s.server.Serve(listener); err != nil {
+			zap.L().Error("gRPC server stopped", zap.Error(err))
+		}
+	}()
+	return nil
+}
+
+// Shutdown marks all services as not serving and waits for pending calls,
+// they are cancelled if ctx is done first.
+func (s *Server) Shutdown(ctx context.Context) error {
+	s.health.Shutdown()
+
+	stopped := make(chan struct{})
+	go func() {
+		s.server.GracefulStop()
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+		return nil
+	case <-ctx.Done():
+		s.server.Stop()
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,323 @@
+package grpc
+
+import (
+	"context"
+	"net"
+	"shop-api-go/internal/adapter/config"
+	"shop-api-go/internal/adapter/storage/memory"
+	"shop-api-go/internal/core/domain"
+	"shop-api-go/internal/core/port/mock"
+	shopv1 "shop-api-go/proto/shop/v1"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// testServer contains the mocked dependencies of a Server served over an in-memory connection.
+type testServer struct {
+	tokenGenerator *mock.MockTokenGenerator
+	authService    *mock.MockAuthService
+	userService    *mock.MockUserService
+	adminService   *mock.MockAdminService
+	settings       *domain.RuntimeSettings
+	conn           *grpc.ClientConn
+}
+
+// newTestServer starts a Server with mocked services and returns a client connection to it.
+func newTestServer(t *testing.T) *testServer {
+	t.Helper()
+	ctrl := gomock.NewController(t)
+	s := &testServer{
+		tokenGenerator: mock.NewMockTokenGenerator(ctrl),
+		authService:    mock.NewMockAuthService(ctrl),
+		userService:    mock.NewMockUserService(ctrl),
+		adminService:   mock.NewMockAdminService(ctrl),
+		settings:       &domain.RuntimeSettings{},
+	}
+	runtimeSettingsService := mock.NewMockRuntimeSettingsService(ctrl)
+	runtimeSettingsService.
+		EXPECT().
+		Current().
+		DoAndReturn(func() *domain.RuntimeSettings { return s.settings }).
+		AnyTimes()
+
+	server, err := NewServer(
+		&config.GRPCConfig{},
+		s.tokenGenerator,
+		mock.NewMockAPIKeyService(ctrl),
+		mock.NewMockAuditService(ctrl),
+		runtimeSettingsService,
+		memory.NewRateLimitStore(),
+		NewAuthServer(s.authService),
+		NewUserServer(s.userService),
+		NewAdminServer(s.adminService),
+		NewProductServer(mock.NewMockProductService(ctrl)),
+	)
+	require.NoError(t, err)
+
+	listener := bufconn.Listen(1024 * 1024)
+	require.NoError(t, server.Serve(listener))
+	t.Cleanup(func() { require.NoError(t, server.Shutdown(context.Background())) })
+
+	s.conn, err = grpc.NewClient(
+		"passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = s.conn.Close() })
+	return s
+}
+
+// withToken returns a copy of ctx sending the token in the authorization metadata.
+func withToken(ctx context.Context, token string) context.Context {
+	return metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+token)
+}
+
+// requireStatus checks the code and the reason of the error details of a status error.
+func requireStatus(t *testing.T, err error, code codes.Code, reason string) {
+	t.Helper()
+	st, ok := status.FromError(err)
+	require.True(t, ok)
+	require.Equal(t, code, st.Code())
+	require.Len(t, st.Details(), 1)
+	require.Equal(t, reason, st.Details()[0].(*errdetails.ErrorInfo).GetReason())
+}
+
+func TestServer_Auth(t *testing.T) {
+	s := newTestServer(t)
+	client := shopv1.NewUserServiceClient(s.conn)
+	ctx := context.Background()
+
+	t.Run("missing token", func(t *testing.T) {
+		_, err := client.GetAccount(ctx, &shopv1.GetAccountRequest{})
+		requireStatus(t, err, codes.Unauthenticated, "INVALID_TOKEN")
+	})
+
+	t.Run("invalid token", func(t *testing.T) {
+		s.tokenGenerator.EXPECT().ParseToken("a.b.c").Return(nil, domain.ErrInvalidToken)
+
+		_, err := client.GetAccount(withToken(ctx, "a.b.c"), &shopv1.GetAccountRequest{})
+		requireStatus(t, err, codes.Unauthenticated, "INVALID_TOKEN")
+	})
+
+	t.Run("valid token", func(t *testing.T) {
+		token := &domain.Token{Id: uuid.New(), UserId: uuid.New(), TokenType: domain.AccessToken, UserRole: domain.Client}
+		s.tokenGenerator.EXPECT().ParseToken("a.b.c").Return(token, nil)
+		s.userService.
+			EXPECT().
+			GetAccount(gomock.Any(), gomock.Eq(token)).
+			Return(&domain.User{Id: token.UserId, Username: "username123", Role: domain.Client}, nil)
+
+		user, err := client.GetAccount(withToken(ctx, "a.b.c"), &shopv1.GetAccountRequest{})
+		require.NoError(t, err)
+		require.Equal(t, token.UserId.String(), user.GetId())
+		require.Equal(t, "username123", user.GetUsername())
+	})
+
+	t.Run("public method", func(t *testing.T) {
+		s.authService.
+			EXPECT().
+			Login(gomock.Any(), gomock.Any()).
+			Return(&domain.LoginResult{TokenGroup: &domain.TokenGroup{AccessToken: "access", RefreshToken: "refresh"}}, nil)
+
+		res, err := shopv1.NewAuthServiceClient(s.conn).Login(ctx, &shopv1.LoginRequest{Username: "username123", Password: "password"})
+		require.NoError(t, err)
+		require.Equal(t, "access", res.GetTokens().GetAccessToken())
+	})
+}
+
+func TestServer_Errors(t *testing.T) {
+	s := newTestServer(t)
+	client := shopv1.NewAdminServiceClient(s.conn)
+	token := &domain.Token{Id: uuid.New(), UserId: uuid.New(), TokenType: domain.AccessToken, UserRole: domain.Admin}
+	s.tokenGenerator.EXPECT().ParseToken("a.b.c").Return(token, nil).AnyTimes()
+	ctx := withToken(context.Background(), "a.b.c")
+
+	tests := []struct {
+		name      string
+		mockSetup func()
+		call      func() error
+		code      codes.Code
+		reason    string
+	}{
+		{
+			name: "not found",
+			mockSetup: func() {
+				s.adminService.EXPECT().SuspendUser(gomock.Any(), gomock.Eq(token), gomock.Any()).Return(domain.ErrUserNotFound)
+			},
+			call: func() error {
+				_, err := client.SuspendUser(ctx, &shopv1.UserIdRequest{Id: uuid.NewString()})
+				return err
+			},
+			code:   codes.NotFound,
+			reason: "USER_NOT_FOUND",
+		},
+		{
+			name: "forbidden",
+			mockSetup: func() {
+				s.adminService.EXPECT().UnlockUser(gomock.Any(), gomock.Eq(token), gomock.Any()).Return(domain.ErrInvalidTokenRole)
+			},
+			call: func() error {
+				_, err := client.UnlockUser(ctx, &shopv1.UserIdRequest{Id: uuid.NewString()})
+				return err
+			},
+			code:   codes.PermissionDenied,
+			reason: "INVALID_TOKEN_ROLE",
+		},
+		{
+			name: "already exists",
+			mockSetup: func() {
+				s.adminService.EXPECT().CreateUser(gomock.Any(), gomock.Eq(token), gomock.Any()).Return(domain.ErrEmailAlreadyInUse)
+			},
+			call: func() error {
+				_, err := client.CreateUser(ctx, &shopv1.CreateUserRequest{
+					Email:    "newUser@email.com",
+					Username: "newUser123",
+					Password: "NewSecret_123",
+					Role:     string(domain.Client),
+				})
+				return err
+			},
+			code:   codes.AlreadyExists,
+			reason: "EMAIL_ALREADY_IN_USE",
+		},
+		{
+			name:      "invalid uuid",
+			mockSetup: func() {},
+			call: func() error {
+				_, err := client.DeleteUser(ctx, &shopv1.UserIdRequest{Id: "not-a-uuid"})
+				return err
+			},
+			code:   codes.InvalidArgument,
+			reason: "INVALID_UUID",
+		},
+		{
+			name:      "invalid entity",
+			mockSetup: func() {},
+			call: func() error {
+				_, err := client.CreateUser(ctx, &shopv1.CreateUserRequest{Email: "invalid"})
+				return err
+			},
+			code:   codes.InvalidArgument,
+			reason: "INVALID_ENTITY",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+			requireStatus(t, tt.call(), tt.code, tt.reason)
+		})
+	}
+}
+
+func TestServer_FeatureDisabled(t *testing.T) {
+	s := newTestServer(t)
+	s.settings = &domain.RuntimeSettings{Features: map[domain.Feature]bool{domain.FeatureRegistration: false}}
+
+	_, err := shopv1.NewUserServiceClient(s.conn).Register(context.Background(), &shopv1.RegisterRequest{})
+	requireStatus(t, err, codes.PermissionDenied, "FEATURE_DISABLED")
+}
+
+func TestServer_RateLimit(t *testing.T) {
+	// The highest limit of the policy is the limit per client IP of non-public calls before authentication.
+	policy := domain.RateLimitPolicy{
+		Default: domain.NewRateLimit(1, time.Minute),
+		Roles: map[domain.UserRole]domain.RateLimit{
+			domain.Admin:    domain.NewRateLimit(2, time.Minute),
+			domain.Delivery: domain.NewRateLimit(5, time.Minute),
+		},
+	}
+	newServer := func(t *testing.T) *testServer {
+		s := newTestServer(t)
+		s.settings = &domain.RuntimeSettings{
+			RateLimitEnabled: true,
+			RateLimits: map[string]domain.RateLimitPolicy{
+				string(config.UsersRateLimitGroup): policy,
+				string(config.AuthRateLimitGroup):  policy,
+			},
+		}
+		return s
+	}
+	ctx := context.Background()
+
+	t.Run("anonymous calls are limited per client IP", func(t *testing.T) {
+		s := newServer(t)
+		s.authService.EXPECT().Login(gomock.Any(), gomock.Any()).Return(nil, domain.ErrWrongCredentials)
+		client := shopv1.NewAuthServiceClient(s.conn)
+		login := &shopv1.LoginRequest{Username: "username123", Password: "password"}
+
+		_, err := client.Login(ctx, login)
+		requireStatus(t, err, codes.Unauthenticated, "WRONG_CREDENTIALS")
+
+		var header metadata.MD
+		_, err = client.Login(ctx, login, grpc.Header(&header))
+		requireStatus(t, err, codes.ResourceExhausted, "RATE_LIMIT_EXCEEDED")
+		require.Equal(t, []string{"1"}, header.Get("ratelimit-limit"))
+		require.Equal(t, []string{"0"}, header.Get("ratelimit-remaining"))
+		require.NotEmpty(t, header.Get("retry-after"))
+	})
+
+	t.Run("invalid tokens are limited per client IP before authentication", func(t *testing.T) {
+		s := newServer(t)
+		s.tokenGenerator.EXPECT().ParseToken("guessed.access.token").Return(nil, domain.ErrInvalidToken).Times(5)
+		client := shopv1.NewUserServiceClient(s.conn)
+
+		for range 5 {
+			_, err := client.GetAccount(withToken(ctx, "guessed.access.token"), &shopv1.GetAccountRequest{})
+			requireStatus(t, err, codes.Unauthenticated, "INVALID_TOKEN")
+		}
+		_, err := client.GetAccount(withToken(ctx, "guessed.access.token"), &shopv1.GetAccountRequest{})
+		requireStatus(t, err, codes.ResourceExhausted, "RATE_LIMIT_EXCEEDED")
+	})
+
+	t.Run("authenticated calls are limited per token subject", func(t *testing.T) {
+		s := newServer(t)
+		admin := &domain.Token{Id: uuid.New(), UserId: uuid.New(), TokenType: domain.AccessToken, UserRole: domain.Admin}
+		user := &domain.Token{Id: uuid.New(), UserId: uuid.New(), TokenType: domain.AccessToken, UserRole: domain.Client}
+		s.tokenGenerator.EXPECT().ParseToken("admin.access.token").Return(admin, nil).Times(3)
+		s.tokenGenerator.EXPECT().ParseToken("client.access.token").Return(user, nil).Times(2)
+		s.userService.
+			EXPECT().
+			GetAccount(gomock.Any(), gomock.AssignableToTypeOf(&domain.Token{})).
+			Return(&domain.User{Id: uuid.New(), Role: domain.Client}, nil).
+			Times(3)
+		client := shopv1.NewUserServiceClient(s.conn)
+		getAccount := func(token string) error {
+			_, err := client.GetAccount(withToken(ctx, token), &shopv1.GetAccountRequest{})
+			return err
+		}
+
+		// Both users share the IP, the admin role has a limit of 2 calls and the client role of 1.
+		require.NoError(t, getAccount("admin.access.token"))
+		require.NoError(t, getAccount("admin.access.token"))
+		requireStatus(t, getAccount("admin.access.token"), codes.ResourceExhausted, "RATE_LIMIT_EXCEEDED")
+		require.NoError(t, getAccount("client.access.token"))
+		requireStatus(t, getAccount("client.access.token"), codes.ResourceExhausted, "RATE_LIMIT_EXCEEDED")
+	})
+}
+
+func TestServer_Health(t *testing.T) {
+	s := newTestServer(t)
+	client := healthpb.NewHealthClient(s.conn)
+
+	for _, service := range []string{"", shopv1.AdminService_ServiceDesc.ServiceName} {
+		res, err := client.Check(context.Background(), &healthpb.HealthCheckRequest{Service: service})
+		require.NoError(t, err)
+		require.Equal(t, healthpb.HealthCheckResponse_SERVING, res.GetStatus())
+	}
+}
@@ -0,0 +1,106 @@
+package grpc
+
+import (
+	"context"
+	"shop-api-go/internal/adapter/handler/http/request"
+	"shop-api-go/internal/core/domain"
+	"shop-api-go/internal/core/port"
+	shopv1 "shop-api-go/proto/shop/v1"
+
+	"github.com/gin-gonic/gin/binding"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// UserServer implements shopv1.UserServiceServer and handles user-related calls.
+type UserServer struct {
+	shopv1.UnimplementedUserServiceServer
+	userService port.UserService
+}
+
+// NewUserServer creates a new UserServer instance.
+func NewUserServer(userService port.UserService) *UserServer {
+	return &UserServer{userService: userService}
+}
+
+func (s *UserServer) Register(ctx context.Context, req *shopv1.RegisterRequest) (*emptypb.Empty, error) {
+	register := request.RegisterRequest{
+		Email:    req.GetEmail(),
+		Username: req.GetUsername(),
+		Password: req.GetPassword(),
+	}
+	if err := validate(&register); err != nil {
+		return nil, err
+	}
+
+	if err := s.userService.Register(ctx, &domain.User{
+		Email:    register.Email,
+		Username: register.Username,
+		Password: register.Password,
+	}); err != nil {
+		return nil, err
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (s *UserServer) UpdateAccount(ctx context.Context, req *shopv1.UpdateAccountRequest) (*emptypb.Empty, error) {
+	update := request.UpdateAccountRequest{
+		Username:    req.GetUsername(),
+		Password:    req.GetPassword(),
+		NewUsername: req.NewUsername,
+		NewEmail:    req.NewEmail,
+		NewPassword: req.NewPassword,
+	}
+	if err := validate(&update); err != nil {
+		return nil, err
+	}
+
+	if err := s.userService.UpdateAccount(
+		ctx,
+		domain.NewUpdateAccount(
+			update.Username,
+			update.Password,
+			update.NewUsername,
+			update.NewEmail,
+			update.NewPassword,
+		),
+	); err != nil {
+		return nil, err
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (s *UserServer) GetAccount(ctx context.Context, _ *shopv1.GetAccountRequest) (*shopv1.User, error) {
+	token, err := tokenFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := s.userService.GetAccount(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+	return newUser(user), nil
+}
+
+// newUser creates a shopv1.User message.
+func newUser(u *domain.User) *shopv1.User {
+	return &shopv1.User{
+		Id:        u.Id.String(),
+		Username:  u.Username,
+		Email:     u.Email,
+		Role:      string(u.Role),
+		Status:    string(u.Status),
+		CreatedAt: timestamppb.New(u.CreatedAt),
+		UpdatedAt: timestamppb.New(u.UpdatedAt),
+	}
+}
+
+// validate checks a request struct with the binding rules of the REST API
+// and converts a failed validation into a status error.
+func validate(obj any) error {
+	if err := binding.Validator.ValidateStruct(obj); err != nil {
+		return invalidArgument(err)
+	}
+	return nil
+}
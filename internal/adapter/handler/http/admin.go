@@ -1,13 +1,12 @@
 package http
 
 import (
-	"encoding/base64"
 	"net/http"
+	"shop-api-go/internal/adapter/handler/api"
 	"shop-api-go/internal/adapter/handler/http/request"
 	"shop-api-go/internal/adapter/handler/http/response"
 	"shop-api-go/internal/core/domain"
 	"shop-api-go/internal/core/port"
-	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
@@ -27,17 +26,25 @@ func NewAdminHandler(userService port.AdminService) *AdminHandler {
 
 // GetUsers godoc
 // @Summary      Users information
-// @Description  Retrieves user information with optional filters and pagination. Requires admin privileges and a valid JWT token in the Authorization header.
+// @Description  Retrieves users matching all given filters, sorted by any field, with page or cursor pagination. Username and email are fuzzy matched. Without page, the response contains a cursor for the next page unless there are no more users; the cursor is only valid for the same sort and order. Requires admin privileges and a valid JWT token in the Authorization header.
 // @Tags         Admin
 // @Security     BearerAuth
 // @Param        Authorization  header    string  true   "Bearer access token"
 // @Param        id             query     string  false  "Filter by user ID (UUID)"
-// @Param        username       query     string  false  "Filter by username"
-// @Param        email          query     string  false  "Filter by email address"
-// @Param        role           query     string  false  "Filter by user role (e.g. 'admin', 'user')"
-// @Param        page           query     int     false  "Page number for pagination (min=1)"
-// @Param        cursor         query     string  false  "Base64-encoded timestamp cursor for pagination"
-// @Param        limit          query     int     false  "Maximum number of users to return (min=1)"
+// @Param        username       query     string  false  "Filter by similar username"
+// @Param        email          query     string  false  "Filter by similar email address"
+// @Param        role           query     string  false  "Filter by user role (e.g. 'admin', 'client')"
+// @Param        status         query     string  false  "Filter by status ('active' or 'suspended')"
+// @Param        createdFrom    query     string  false  "Only users created at or after this RFC 3339 time"
+// @Param        createdTo      query     string  false  "Only users created before this RFC 3339 time"
+// @Param        updatedFrom    query     string  false  "Only users updated at or after this RFC 3339 time"
+// @Param        updatedTo      query     string  false  "Only users updated before this RFC 3339 time"
+// @Param        sort           query     string  false  "Sort field: id, username, email, role, status, created_at (default) or updated_at"
+// @Param        order          query     string  false  "Sort order: asc (default) or desc"
+// @Param        page           query     int     false  "Page number for offset pagination (min=1), cannot be combined with cursor"
+// @Param        cursor         query     string  false  "Cursor returned by the previous page"
+// @Param        limit          query     int     false  "Maximum number of users to return (min=1, max=100), required unless filtering by id"
+// @Param        total          query     bool    false  "Include the total number of matching users"
 // @Produce      json
 // @Success      200  {object}  response.FetchingUsersResponse "List of users"
 // @Failure      400  {object}  response.ErrorResponse "Invalid query parameters"
@@ -62,32 +69,18 @@ func (h *AdminHandler) GetUsers(c *gin.Context) {
 		return
 	}
 
-	var id *uuid.UUID
-	if query.Id != nil {
-		parsedId, err := uuid.Parse(*query.Id)
-		if err != nil {
-			response.HandleError(c, domain.ErrInvalidUUID)
-			return
-		}
-		id = &parsedId
+	filter, err := newUserFilter(&query.UserFilterQuery)
+	if err != nil {
+		response.HandleError(c, err)
+		return
 	}
 
-	var after *time.Time
-	if query.Cursor != nil {
-		if *query.Cursor == "" {
-			after = &time.Time{}
-		} else {
-			decoded, decodeErr := base64.URLEncoding.DecodeString(*query.Cursor)
-			if decodeErr != nil {
-				response.HandleError(c, domain.ErrInvalidCursor)
-				return
-			}
-			parsedTime, decodeErr := time.Parse(time.RFC3339Nano, string(decoded))
-			if decodeErr != nil {
-				response.HandleError(c, domain.ErrInvalidCursor)
-				return
-			}
-			after = &parsedTime
+	var cursor *domain.UserCursor
+	if query.Cursor != nil && *query.Cursor != "" {
+		cursor, err = api.DecodeUserCursor(*query.Cursor)
+		if err != nil {
+			response.HandleError(c, err)
+			return
 		}
 	}
 
@@ -95,7 +88,7 @@ func (h *AdminHandler) GetUsers(c *gin.Context) {
 		c,
 		domainToken,
 		domain.NewGetUsers(
-			id, query.Username, query.Email, query.Role, query.Page, after, query.Limit,
+			filter, query.Sort, query.Order == "desc", query.Page, cursor, query.Limit, query.Total,
 		),
 	)
 	if err != nil {
@@ -103,7 +96,193 @@ func (h *AdminHandler) GetUsers(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusOK, response.NewFetchingUsersResponse(result))
+	c.JSON(http.StatusOK, response.NewFetchingUsersResponse(result, api.EncodeUserCursor(result.Cursor)))
+}
+
+// newUserFilter creates a domain.UserFilter from the query parameters.
+func newUserFilter(query *request.UserFilterQuery) (*domain.UserFilter, error) {
+	var id *uuid.UUID
+	if query.Id != nil {
+		parsedId, err := uuid.Parse(*query.Id)
+		if err != nil {
+			return nil, domain.ErrInvalidUUID
+		}
+		id = &parsedId
+	}
+
+	return domain.NewUserFilter(
+		id,
+		query.Username,
+		query.Email,
+		query.Role,
+		query.Status,
+		query.CreatedFrom,
+		query.CreatedTo,
+		query.UpdatedFrom,
+		query.UpdatedTo,
+	), nil
+}
+
+// CreateUser godoc
+// @Summary      Create user
+// @Description  Creates a user with a specific role. The password is screened against the password policy. Requires admin privileges and a valid JWT token in the Authorization header.
+// @Tags         Admin
+// @Security     BearerAuth
+// @Accept       json
+// @Param        Authorization  header    string              true   "Bearer access token"
+// @Param        request        body      request.CreateUser  true   "User details"
+// @Success      201            {string}  string              "User created successfully"
+// @Failure      400            {object}  response.ErrorResponse "Invalid request payload or weak password"
+// @Failure      401            {object}  response.ErrorResponse "Unauthorized – invalid token"
+// @Failure      403            {object}  response.ErrorResponse "Forbidden – insufficient permissions or invalid token type(expected access token)"
+// @Failure      404            {object}  response.ErrorResponse "Role not found"
+// @Failure      409            {object}  response.ErrorResponse "Email or username already exists"
+// @Failure      500            {object}  response.ErrorResponse "Internal server error"
+// @Router       /admin/users [post]
+func (h *AdminHandler) CreateUser(c *gin.Context) {
+	token, ok := c.Get("token")
+	if !ok {
+		response.HandleError(c, domain.ErrInternal)
+		return
+	}
+	domainToken, ok := token.(*domain.Token)
+	if !ok {
+		response.HandleError(c, domain.ErrInternal)
+		return
+	}
+
+	var req request.CreateUser
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.HandleBindingError(c, err)
+		return
+	}
+
+	if err := h.adminService.CreateUser(c, domainToken, &domain.User{
+		Email:    req.Email,
+		Username: req.Username,
+		Password: req.Password,
+		Role:     req.Role,
+	}); err != nil {
+		response.HandleError(c, err)
+		return
+	}
+
+	c.Status(http.StatusCreated)
+}
+
+// ImportUsers godoc
+// @Summary      Import users
+// @Description  Creates users from a CSV file with an email,username,password,role header or an NDJSON file with a user object per line. Every user is validated with the same rules as creating a single user and either all users are imported in one transaction or none. With dryRun the file is only validated. Requires admin privileges and a valid JWT token in the Authorization header.
+// @Tags         Admin
+// @Security     BearerAuth
+// @Accept       text/csv
+// @Accept       application/x-ndjson
+// @Produce      json
+// @Param        Authorization  header    string  true   "Bearer access token"
+// @Param        dryRun         query     bool    false  "Only validate the file"
+// @Param        file           body      string  true   "CSV or NDJSON users"
+// @Success      200            {object}  response.ImportUsersResponse "Users are valid (dry run)"
+// @Success      201            {object}  response.ImportUsersResponse "Users imported successfully"
+// @Failure      400            {object}  response.ImportErrorResponse "Invalid users with the rejected lines"
+// @Failure      401            {object}  response.ErrorResponse "Unauthorized – invalid token"
+// @Failure      403            {object}  response.ErrorResponse "Forbidden – insufficient permissions or invalid token type(expected access token)"
+// @Failure      413            {object}  response.ErrorResponse "Import file contains too many users"
+// @Failure      415            {object}  response.ErrorResponse "Unsupported content type"
+// @Failure      500            {object}  response.ErrorResponse "Internal server error"
+// @Router       /admin/users/import [post]
+func (h *AdminHandler) ImportUsers(c *gin.Context) {
+	token, ok := c.Get("token")
+	if !ok {
+		response.HandleError(c, domain.ErrInternal)
+		return
+	}
+	domainToken, ok := token.(*domain.Token)
+	if !ok {
+		response.HandleError(c, domain.ErrInternal)
+		return
+	}
+
+	var query request.ImportUsersQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		response.HandleBindingError(c, err)
+		return
+	}
+
+	rows, err := parseUserImport(c.ContentType(), http.MaxBytesReader(c.Writer, c.Request.Body, maxUserImportBytes))
+	if err != nil {
+		response.HandleError(c, err)
+		return
+	}
+
+	imported, err := h.adminService.ImportUsers(c, domainToken, rows, query.DryRun)
+	if err != nil {
+		response.HandleError(c, err)
+		return
+	}
+
+	status := http.StatusCreated
+	if query.DryRun {
+		status = http.StatusOK
+	}
+	c.JSON(status, response.NewImportUsersResponse(imported, query.DryRun))
+}
+
+// ExportUsers godoc
+// @Summary      Export users
+// @Description  Streams all users matching the filters as CSV or NDJSON, ordered by creation time. Passwords are never exported. Requires admin privileges and a valid JWT token in the Authorization header.
+// @Tags         Admin
+// @Security     BearerAuth
+// @Produce      text/csv
+// @Produce      application/x-ndjson
+// @Param        Authorization  header    string  true   "Bearer access token"
+// @Param        id             query     string  false  "Filter by user ID (UUID)"
+// @Param        username       query     string  false  "Filter by similar username"
+// @Param        email          query     string  false  "Filter by similar email address"
+// @Param        role           query     string  false  "Filter by user role (e.g. 'admin', 'client')"
+// @Param        status         query     string  false  "Filter by status ('active' or 'suspended')"
+// @Param        createdFrom    query     string  false  "Only users created at or after this RFC 3339 time"
+// @Param        createdTo      query     string  false  "Only users created before this RFC 3339 time"
+// @Param        updatedFrom    query     string  false  "Only users updated at or after this RFC 3339 time"
+// @Param        updatedTo      query     string  false  "Only users updated before this RFC 3339 time"
+// @Param        format         query     string  false  "Export format, 'csv' (default) or 'ndjson'"
+// @Success      200  {string}  string  "Exported users"
+// @Failure      400  {object}  response.ErrorResponse "Invalid query parameters"
+// @Failure      401  {object}  response.ErrorResponse "Missing or invalid JWT token"
+// @Failure      403  {object}  response.ErrorResponse "Insufficient permissions or invalid token type(expected access token)"
+// @Failure      500  {object}  response.ErrorResponse "Internal server error"
+// @Router       /admin/users/export [get]
+func (h *AdminHandler) ExportUsers(c *gin.Context) {
+	token, ok := c.Get("token")
+	if !ok {
+		response.HandleError(c, domain.ErrInternal)
+		return
+	}
+	domainToken, ok := token.(*domain.Token)
+	if !ok {
+		response.HandleError(c, domain.ErrInternal)
+		return
+	}
+
+	var query request.ExportUsersQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		response.HandleBindingError(c, err)
+		return
+	}
+
+	filter, err := newUserFilter(&query.UserFilterQuery)
+	if err != nil {
+		response.HandleError(c, err)
+		return
+	}
+
+	writer := newUserExportWriter(c, query.Format)
+	err = h.adminService.ExportUsers(c, domainToken, filter, writer.write)
+	if err == nil {
+		err = writer.close()
+	}
+	if err != nil {
+		writer.fail(err)
+	}
 }
 
 // UpdateUser godoc
@@ -158,3 +337,333 @@ func (h *AdminHandler) UpdateUser(c *gin.Context) {
 
 	c.Status(http.StatusOK)
 }
+
+// SuspendUser godoc
+// @Summary      Suspend user
+// @Description  Suspends a user so he can no longer log in or refresh his session. Issued access tokens stay valid until they expire. Requires admin privileges and a valid JWT token in the Authorization header.
+// @Tags         Admin
+// @Security     BearerAuth
+// @Param        Authorization  header    string  true   "Bearer access token"
+// @Param        id             path      string  true   "User ID (UUID)"
+// @Success      200            {string}  string  "User suspended successfully"
+// @Failure      400            {object}  response.ErrorResponse "Invalid user id"
+// @Failure      401            {object}  response.ErrorResponse "Unauthorized – invalid token"
+// @Failure      403            {object}  response.ErrorResponse "Forbidden – insufficient permissions, invalid token type(expected access token) or user with permissions the admin lacks"
+// @Failure      404            {object}  response.ErrorResponse "User not found"
+// @Failure      409            {object}  response.ErrorResponse "Cannot target your own account"
+// @Failure      500            {object}  response.ErrorResponse "Internal server error"
+// @Router       /admin/users/{id}/suspend [post]
+func (h *AdminHandler) SuspendUser(c *gin.Context) {
+	token, ok := c.Get("token")
+	if !ok {
+		response.HandleError(c, domain.ErrInternal)
+		return
+	}
+	domainToken, ok := token.(*domain.Token)
+	if !ok {
+		response.HandleError(c, domain.ErrInternal)
+		return
+	}
+
+	parsedId, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.HandleError(c, domain.ErrInvalidUUID)
+		return
+	}
+
+	if err = h.adminService.SuspendUser(c, domainToken, parsedId); err != nil {
+		response.HandleError(c, err)
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
+
+// ReactivateUser godoc
+// @Summary      Reactivate user
+// @Description  Reactivates a suspended user. Requires admin privileges and a valid JWT token in the Authorization header.
+// @Tags         Admin
+// @Security     BearerAuth
+// @Param        Authorization  header    string  true   "Bearer access token"
+// @Param        id             path      string  true   "User ID (UUID)"
+// @Success      200            {string}  string  "User reactivated successfully"
+// @Failure      400            {object}  response.ErrorResponse "Invalid user id"
+// @Failure      401            {object}  response.ErrorResponse "Unauthorized – invalid token"
+// @Failure      403            {object}  response.ErrorResponse "Forbidden – insufficient permissions, invalid token type(expected access token) or user with permissions the admin lacks"
+// @Failure      404            {object}  response.ErrorResponse "User not found"
+// @Failure      409            {object}  response.ErrorResponse "Cannot target your own account"
+// @Failure      500            {object}  response.ErrorResponse "Internal server error"
+// @Router       /admin/users/{id}/reactivate [post]
+func (h *AdminHandler) ReactivateUser(c *gin.Context) {
+	token, ok := c.Get("token")
+	if !ok {
+		response.HandleError(c, domain.ErrInternal)
+		return
+	}
+	domainToken, ok := token.(*domain.Token)
+	if !ok {
+		response.HandleError(c, domain.ErrInternal)
+		return
+	}
+
+	parsedId, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.HandleError(c, domain.ErrInvalidUUID)
+		return
+	}
+
+	if err = h.adminService.ReactivateUser(c, domainToken, parsedId); err != nil {
+		response.HandleError(c, err)
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
+
+// DeleteUser godoc
+// @Summary      Delete user
+// @Description  Soft-deletes a user and revokes his tokens. The user can be restored later. Requires admin privileges and a valid JWT token in the Authorization header.
+// @Tags         Admin
+// @Security     BearerAuth
+// @Param        Authorization  header    string  true   "Bearer access token"
+// @Param        id             path      string  true   "User ID (UUID)"
+// @Success      200            {string}  string  "User deleted successfully"
+// @Failure      400            {object}  response.ErrorResponse "Invalid user id"
+// @Failure      401            {object}  response.ErrorResponse "Unauthorized – invalid token"
+// @Failure      403            {object}  response.ErrorResponse "Forbidden – insufficient permissions, invalid token type(expected access token) or user with permissions the admin lacks"
+// @Failure      404            {object}  response.ErrorResponse "User not found"
+// @Failure      409            {object}  response.ErrorResponse "Cannot target your own account"
+// @Failure      500            {object}  response.ErrorResponse "Internal server error"
+// @Router       /admin/users/{id} [delete]
+func (h *AdminHandler) DeleteUser(c *gin.Context) {
+	token, ok := c.Get("token")
+	if !ok {
+		response.HandleError(c, domain.ErrInternal)
+		return
+	}
+	domainToken, ok := token.(*domain.Token)
+	if !ok {
+		response.HandleError(c, domain.ErrInternal)
+		return
+	}
+
+	parsedId, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.HandleError(c, domain.ErrInvalidUUID)
+		return
+	}
+
+	if err = h.adminService.DeleteUser(c, domainToken, parsedId); err != nil {
+		response.HandleError(c, err)
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
+
+// RestoreUser godoc
+// @Summary      Restore user
+// @Description  Restores a soft-deleted user. Requires admin privileges and a valid JWT token in the Authorization header.
+// @Tags         Admin
+// @Security     BearerAuth
+// @Param        Authorization  header    string  true   "Bearer access token"
+// @Param        id             path      string  true   "User ID (UUID)"
+// @Success      200            {string}  string  "User restored successfully"
+// @Failure      400            {object}  response.ErrorResponse "Invalid user id"
+// @Failure      401            {object}  response.ErrorResponse "Unauthorized – invalid token"
+// @Failure      403            {object}  response.ErrorResponse "Forbidden – insufficient permissions, invalid token type(expected access token) or user with permissions the admin lacks"
+// @Failure      404            {object}  response.ErrorResponse "User not found"
+// @Failure      409            {object}  response.ErrorResponse "User is not deleted"
+// @Failure      500            {object}  response.ErrorResponse "Internal server error"
+// @Router       /admin/users/{id}/restore [post]
+func (h *AdminHandler) RestoreUser(c *gin.Context) {
+	token, ok := c.Get("token")
+	if !ok {
+		response.HandleError(c, domain.ErrInternal)
+		return
+	}
+	domainToken, ok := token.(*domain.Token)
+	if !ok {
+		response.HandleError(c, domain.ErrInternal)
+		return
+	}
+
+	parsedId, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.HandleError(c, domain.ErrInvalidUUID)
+		return
+	}
+
+	if err = h.adminService.RestoreUser(c, domainToken, parsedId); err != nil {
+		response.HandleError(c, err)
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
+
+// UnlockUser godoc
+// @Summary      Unlock user
+// @Description  Clears the failed login attempts of a user so he can log in again immediately. Requires admin privileges and a valid JWT token in the Authorization header.
+// @Tags         Admin
+// @Security     BearerAuth
+// @Param        Authorization  header    string  true   "Bearer access token"
+// @Param        id             path      string  true   "User ID (UUID) to unlock"
+// @Success      200            {string}  string  "User unlocked successfully"
+// @Failure      400            {object}  response.ErrorResponse "Invalid user id"
+// @Failure      401            {object}  response.ErrorResponse "Unauthorized – invalid token"
+// @Failure      403            {object}  response.ErrorResponse "Forbidden – insufficient permissions, invalid token type(expected access token) or user with permissions the admin lacks"
+// @Failure      404            {object}  response.ErrorResponse "User not found"
+// @Failure      500            {object}  response.ErrorResponse "Internal server error"
+// @Router       /admin/users/{id}/unlock [post]
+func (h *AdminHandler) UnlockUser(c *gin.Context) {
+	token, ok := c.Get("token")
+	if !ok {
+		response.HandleError(c, domain.ErrInternal)
+		return
+	}
+	domainToken, ok := token.(*domain.Token)
+	if !ok {
+		response.HandleError(c, domain.ErrInternal)
+		return
+	}
+
+	parsedId, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.HandleError(c, domain.ErrInvalidUUID)
+		return
+	}
+
+	if err = h.adminService.UnlockUser(c, domainToken, parsedId); err != nil {
+		response.HandleError(c, err)
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
+
+// ImpersonateUser godoc
+// @Summary      Impersonate user
+// @Description  Issues a short-lived access token for a user that names the admin as actor. The token cannot be refreshed, cannot change users, roles or API keys, and every request made with it is recorded in the audit log. Only users whose role grants a part of the admin's permissions can be impersonated. Requires admin privileges and a valid JWT token in the Authorization header.
+// @Tags         Admin
+// @Security     BearerAuth
+// @Param        Authorization  header    string  true   "Bearer access token"
+// @Param        id             path      string  true   "User ID (UUID) to impersonate"
+// @Produce      json
+// @Success      201            {object}  response.ImpersonationTokenResponse "Impersonation token issued"
+// @Failure      400            {object}  response.ErrorResponse "Invalid user id"
+// @Failure      401            {object}  response.ErrorResponse "Unauthorized – invalid token"
+// @Failure      403            {object}  response.ErrorResponse "Forbidden – insufficient permissions, invalid token type(expected access token), impersonation token, suspended user or user with a role not below the admin's"
+// @Failure      404            {object}  response.ErrorResponse "User not found"
+// @Failure      500            {object}  response.ErrorResponse "Internal server error"
+// @Router       /admin/users/{id}/impersonate [post]
+func (h *AdminHandler) ImpersonateUser(c *gin.Context) {
+	token, ok := c.Get("token")
+	if !ok {
+		response.HandleError(c, domain.ErrInternal)
+		return
+	}
+	domainToken, ok := token.(*domain.Token)
+	if !ok {
+		response.HandleError(c, domain.ErrInternal)
+		return
+	}
+
+	parsedId, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.HandleError(c, domain.ErrInvalidUUID)
+		return
+	}
+
+	impersonationToken, err := h.adminService.ImpersonateUser(c, domainToken, parsedId)
+	if err != nil {
+		response.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, response.NewImpersonationTokenResponse(impersonationToken))
+}
+
+// GetMFARequirements godoc
+// @Summary      MFA requirements
+// @Description  Retrieves whether MFA is required for each user role. Requires admin privileges and a valid JWT token in the Authorization header.
+// @Tags         Admin
+// @Security     BearerAuth
+// @Param        Authorization  header    string  true   "Bearer access token"
+// @Produce      json
+// @Success      200  {object}  response.RoleMFARequirementsResponse "MFA requirement of every role"
+// @Failure      401  {object}  response.ErrorResponse "Missing or invalid JWT token"
+// @Failure      403  {object}  response.ErrorResponse "Insufficient permissions or invalid token type(expected access token)"
+// @Failure      500  {object}  response.ErrorResponse "Internal server error"
+// @Router       /admin/mfa/roles [get]
+func (h *AdminHandler) GetMFARequirements(c *gin.Context) {
+	token, ok := c.Get("token")
+	if !ok {
+		response.HandleError(c, domain.ErrInternal)
+		return
+	}
+	domainToken, ok := token.(*domain.Token)
+	if !ok {
+		response.HandleError(c, domain.ErrInternal)
+		return
+	}
+
+	requirements, err := h.adminService.GetMFARequirements(c, domainToken)
+	if err != nil {
+		response.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response.NewRoleMFARequirementsResponse(requirements))
+}
+
+// SetMFARequirement godoc
+// @Summary      Set MFA requirement
+// @Description  Sets whether users with a specific role must use MFA to log in. Requires admin privileges and a valid JWT token in the Authorization header.
+// @Tags         Admin
+// @Security     BearerAuth
+// @Accept       json
+// @Param        Authorization  header    string                            true   "Bearer access token"
+// @Param        role           path      string                            true   "User role (e.g. 'admin', 'client')"
+// @Param        request        body      request.SetMFARequirementRequest  true   "MFA requirement"
+// @Success      200            {string}  string                            "MFA requirement updated successfully"
+// @Failure      400            {object}  response.ErrorResponse "Invalid request payload or parameters"
+// @Failure      401            {object}  response.ErrorResponse "Unauthorized – invalid token"
+// @Failure      403            {object}  response.ErrorResponse "Forbidden – insufficient permissions or invalid token type(expected access token)"
+// @Failure      500            {object}  response.ErrorResponse "Internal server error"
+// @Router       /admin/mfa/roles/{role} [put]
+func (h *AdminHandler) SetMFARequirement(c *gin.Context) {
+	token, ok := c.Get("token")
+	if !ok {
+		response.HandleError(c, domain.ErrInternal)
+		return
+	}
+	domainToken, ok := token.(*domain.Token)
+	if !ok {
+		response.HandleError(c, domain.ErrInternal)
+		return
+	}
+
+	var uri request.RoleUri
+	if err := c.ShouldBindUri(&uri); err != nil {
+		response.HandleBindingError(c, err)
+		return
+	}
+
+	var req request.SetMFARequirementRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.HandleBindingError(c, err)
+		return
+	}
+
+	if err := h.adminService.SetMFARequirement(
+		c,
+		domainToken,
+		domain.NewRoleMFARequirement(uri.Role, *req.Required),
+	); err != nil {
+		response.HandleError(c, err)
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
@@ -0,0 +1,268 @@
+package http
+
+import (
+	"net/http"
+	"shop-api-go/internal/adapter/handler/http/request"
+	"shop-api-go/internal/adapter/handler/http/response"
+	"shop-api-go/internal/core/domain"
+	"shop-api-go/internal/core/port"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// APIKeyHandler represent HTTP handler for API key-related requests.
+type APIKeyHandler struct {
+	apiKeyService port.APIKeyService
+}
+
+// NewAPIKeyHandler creates a new APIKeyHandler instance.
+func NewAPIKeyHandler(apiKeyService port.APIKeyService) *APIKeyHandler {
+	return &APIKeyHandler{
+		apiKeyService: apiKeyService,
+	}
+}
+
+// CreatePersonalAccessToken godoc
+// @Summary      Create personal access token
+// @Description  Creates a scoped personal access token for the authenticated user. The secret is returned only once. Requires a valid JWT access token in the Authorization header.
+// @Tags         API keys
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        Authorization  header    string                       true  "Bearer access token"
+// @Param        request        body      request.CreateAPIKeyRequest  true  "Personal access token to create"
+// @Success      201            {object}  response.CreatedAPIKeyResponse "Created personal access token with its secret"
+// @Failure      400            {object}  response.ErrorResponse "Invalid request payload, expiry or unknown scope"
+// @Failure      401            {object}  response.ErrorResponse "Missing or invalid token"
+// @Failure      403            {object}  response.ErrorResponse "Invalid token type(expected JWT access token)"
+// @Failure      500            {object}  response.ErrorResponse "Internal server error"
+// @Router       /users/me/api-keys [post]
+func (h *APIKeyHandler) CreatePersonalAccessToken(c *gin.Context) {
+	token, ok := c.Get("token")
+	if !ok {
+		response.HandleError(c, domain.ErrInternal)
+		return
+	}
+	domainToken, ok := token.(*domain.Token)
+	if !ok {
+		response.HandleError(c, domain.ErrInternal)
+		return
+	}
+
+	var req request.CreateAPIKeyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.HandleBindingError(c, err)
+		return
+	}
+	expiresIn, err := parseExpiresIn(req.ExpiresIn)
+	if err != nil {
+		response.HandleError(c, err)
+		return
+	}
+
+	created, err := h.apiKeyService.CreatePersonalAccessToken(
+		c,
+		domainToken,
+		domain.NewCreateAPIKey(domainToken.UserId, req.Name, req.Scopes, expiresIn),
+	)
+	if err != nil {
+		response.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, response.NewCreatedAPIKeyResponse(created))
+}
+
+// GetPersonalAccessTokens godoc
+// @Summary      Own API keys
+// @Description  Retrieves the API keys and personal access tokens of the authenticated user without their secrets.
+// @Tags         API keys
+// @Security     BearerAuth
+// @Produce      json
+// @Param        Authorization  header    string  true  "Bearer access token"
+// @Success      200  {object}  response.APIKeysResponse "List of API keys"
+// @Failure      401  {object}  response.ErrorResponse "Missing or invalid token"
+// @Failure      403  {object}  response.ErrorResponse "Invalid token type(expected access token)"
+// @Failure      500  {object}  response.ErrorResponse "Internal server error"
+// @Router       /users/me/api-keys [get]
+func (h *APIKeyHandler) GetPersonalAccessTokens(c *gin.Context) {
+	token, ok := c.Get("token")
+	if !ok {
+		response.HandleError(c, domain.ErrInternal)
+		return
+	}
+	domainToken, ok := token.(*domain.Token)
+	if !ok {
+		response.HandleError(c, domain.ErrInternal)
+		return
+	}
+
+	keys, err := h.apiKeyService.GetAPIKeys(c, domainToken, domainToken.UserId)
+	if err != nil {
+		response.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response.NewAPIKeysResponse(keys))
+}
+
+// CreateServiceAPIKey godoc
+// @Summary      Create service API key
+// @Description  Creates a scoped API key for an integration acting as the user. The secret is returned only once. Requires the api_keys:write permission and a valid JWT access token in the Authorization header.
+// @Tags         Admin
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        Authorization  header    string                       true  "Bearer access token"
+// @Param        id             path      string                       true  "User ID (UUID) the key acts as"
+// @Param        request        body      request.CreateAPIKeyRequest  true  "API key to create"
+// @Success      201            {object}  response.CreatedAPIKeyResponse "Created API key with its secret"
+// @Failure      400            {object}  response.ErrorResponse "Invalid request payload, expiry or unknown scope"
+// @Failure      401            {object}  response.ErrorResponse "Missing or invalid token"
+// @Failure      403            {object}  response.ErrorResponse "Insufficient permissions or invalid token type(expected JWT access token)"
+// @Failure      404            {object}  response.ErrorResponse "User not found"
+// @Failure      500            {object}  response.ErrorResponse "Internal server error"
+// @Router       /admin/users/{id}/api-keys [post]
+func (h *APIKeyHandler) CreateServiceAPIKey(c *gin.Context) {
+	token, ok := c.Get("token")
+	if !ok {
+		response.HandleError(c, domain.ErrInternal)
+		return
+	}
+	domainToken, ok := token.(*domain.Token)
+	if !ok {
+		response.HandleError(c, domain.ErrInternal)
+		return
+	}
+
+	userId, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.HandleError(c, domain.ErrInvalidUUID)
+		return
+	}
+
+	var req request.CreateAPIKeyRequest
+	if err = c.ShouldBindJSON(&req); err != nil {
+		response.HandleBindingError(c, err)
+		return
+	}
+	expiresIn, err := parseExpiresIn(req.ExpiresIn)
+	if err != nil {
+		response.HandleError(c, err)
+		return
+	}
+
+	created, err := h.apiKeyService.CreateServiceAPIKey(
+		c,
+		domainToken,
+		domain.NewCreateAPIKey(userId, req.Name, req.Scopes, expiresIn),
+	)
+	if err != nil {
+		response.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, response.NewCreatedAPIKeyResponse(created))
+}
+
+// GetUserAPIKeys godoc
+// @Summary      User API keys
+// @Description  Retrieves the API keys and personal access tokens of a user without their secrets. Requires the api_keys:read permission and a valid JWT token in the Authorization header.
+// @Tags         Admin
+// @Security     BearerAuth
+// @Produce      json
+// @Param        Authorization  header    string  true  "Bearer access token"
+// @Param        id             path      string  true  "User ID (UUID)"
+// @Success      200  {object}  response.APIKeysResponse "List of API keys"
+// @Failure      400  {object}  response.ErrorResponse "Invalid user id"
+// @Failure      401  {object}  response.ErrorResponse "Missing or invalid token"
+// @Failure      403  {object}  response.ErrorResponse "Insufficient permissions or invalid token type(expected access token)"
+// @Failure      500  {object}  response.ErrorResponse "Internal server error"
+// @Router       /admin/users/{id}/api-keys [get]
+func (h *APIKeyHandler) GetUserAPIKeys(c *gin.Context) {
+	token, ok := c.Get("token")
+	if !ok {
+		response.HandleError(c, domain.ErrInternal)
+		return
+	}
+	domainToken, ok := token.(*domain.Token)
+	if !ok {
+		response.HandleError(c, domain.ErrInternal)
+		return
+	}
+
+	userId, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.HandleError(c, domain.ErrInvalidUUID)
+		return
+	}
+
+	keys, err := h.apiKeyService.GetAPIKeys(c, domainToken, userId)
+	if err != nil {
+		response.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response.NewAPIKeysResponse(keys))
+}
+
+// RevokeAPIKey godoc
+// @Summary      Revoke API key
+// @Description  Revokes an API key or personal access token. Users can revoke their own keys, revoking keys of other users requires the api_keys:write permission.
+// @Tags         API keys
+// @Security     BearerAuth
+// @Param        Authorization  header    string  true  "Bearer access token"
+// @Param        id             path      string  true  "API key ID (UUID)"
+// @Success      204            {string}  string  "API key revoked successfully"
+// @Failure      400            {object}  response.ErrorResponse "Invalid API key id"
+// @Failure      401            {object}  response.ErrorResponse "Missing or invalid token"
+// @Failure      403            {object}  response.ErrorResponse "Insufficient permissions or invalid token type(expected access token)"
+// @Failure      404            {object}  response.ErrorResponse "API key not found or already revoked"
+// @Failure      500            {object}  response.ErrorResponse "Internal server error"
+// @Router       /users/me/api-keys/{id} [delete]
+// @Router       /admin/api-keys/{id} [delete]
+func (h *APIKeyHandler) RevokeAPIKey(c *gin.Context) {
+	token, ok := c.Get("token")
+	if !ok {
+		response.HandleError(c, domain.ErrInternal)
+		return
+	}
+	domainToken, ok := token.(*domain.Token)
+	if !ok {
+		response.HandleError(c, domain.ErrInternal)
+		return
+	}
+
+	var uri request.APIKeyUri
+	if err := c.ShouldBindUri(&uri); err != nil {
+		response.HandleBindingError(c, err)
+		return
+	}
+	id, err := uuid.Parse(uri.Id)
+	if err != nil {
+		response.HandleError(c, domain.ErrInvalidUUID)
+		return
+	}
+
+	if err = h.apiKeyService.RevokeAPIKey(c, domainToken, id); err != nil {
+		response.HandleError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// parseExpiresIn parses the optional expiry duration of an API key request.
+func parseExpiresIn(value *string) (*time.Duration, error) {
+	if value == nil {
+		return nil, nil
+	}
+
+	expiresIn, err := time.ParseDuration(*value)
+	if err != nil {
+		return nil, domain.ErrInvalidAPIKeyExpiry
+	}
+	return &expiresIn, nil
+}
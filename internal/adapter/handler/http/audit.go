@@ -0,0 +1,87 @@
+package http
+
+import (
+	"net/http"
+	"shop-api-go/internal/adapter/handler/http/request"
+	"shop-api-go/internal/adapter/handler/http/response"
+	"shop-api-go/internal/core/domain"
+	"shop-api-go/internal/core/port"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// AuditHandler represent HTTP handler for audit-related requests.
+type AuditHandler struct {
+	auditService port.AuditService
+}
+
+// NewAuditHandler creates a new AuditHandler instance.
+func NewAuditHandler(auditService port.AuditService) *AuditHandler {
+	return &AuditHandler{
+		auditService: auditService,
+	}
+}
+
+// GetAuditEvents godoc
+// @Summary      Audit log
+// @Description  Retrieves audit events, newest first, with optional filters and pagination. Requires the audit:read permission and a valid JWT token in the Authorization header.
+// @Tags         Admin
+// @Security     BearerAuth
+// @Param        Authorization  header    string  true   "Bearer access token"
+// @Param        actorId        query     string  false  "Filter by actor user ID (UUID)"
+// @Param        action         query     string  false  "Filter by action (e.g. 'auth.login_failed')"
+// @Param        targetType     query     string  false  "Filter by target type (e.g. 'user', 'role')"
+// @Param        targetId       query     string  false  "Filter by target ID"
+// @Param        from           query     string  false  "Only events created at or after this RFC 3339 time"
+// @Param        to             query     string  false  "Only events created before this RFC 3339 time"
+// @Param        page           query     int     false  "Page number for pagination (min=1)"
+// @Param        limit          query     int     true   "Maximum number of events to return (min=1, max=100)"
+// @Produce      json
+// @Success      200  {object}  response.AuditEventsResponse "List of audit events"
+// @Failure      400  {object}  response.ErrorResponse "Invalid query parameters"
+// @Failure      401  {object}  response.ErrorResponse "Missing or invalid JWT token"
+// @Failure      403  {object}  response.ErrorResponse "Insufficient permissions or invalid token type(expected access token)"
+// @Failure      500  {object}  response.ErrorResponse "Internal server error"
+// @Router       /admin/audit [get]
+func (h *AuditHandler) GetAuditEvents(c *gin.Context) {
+	token, ok := c.Get("token")
+	if !ok {
+		response.HandleError(c, domain.ErrInternal)
+		return
+	}
+	domainToken, ok := token.(*domain.Token)
+	if !ok {
+		response.HandleError(c, domain.ErrInternal)
+		return
+	}
+	query := request.GetAuditEventsQuery{}
+	if err := c.ShouldBindQuery(&query); err != nil {
+		response.HandleBindingError(c, err)
+		return
+	}
+
+	var actorId *uuid.UUID
+	if query.ActorId != nil {
+		parsedId, err := uuid.Parse(*query.ActorId)
+		if err != nil {
+			response.HandleError(c, domain.ErrInvalidUUID)
+			return
+		}
+		actorId = &parsedId
+	}
+
+	events, err := h.auditService.GetAuditEvents(
+		c,
+		domainToken,
+		domain.NewAuditFilter(
+			actorId, query.Action, query.TargetType, query.TargetId, query.From, query.To, query.Page, query.Limit,
+		),
+	)
+	if err != nil {
+		response.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response.NewAuditEventsResponse(events))
+}
@@ -22,14 +22,17 @@ func NewAuthHandler(authService port.AuthService) *AuthHandler {
 
 // Login godoc
 // @Summary      Authenticate user
-// @Description  Authenticates a user using their username and password, returning a new pair of access and refresh tokens upon successful login.
+// @Description  Authenticates a user using their username and password, returning a new pair of access and refresh tokens upon successful login. If the user has MFA enabled, a short-lived MFA token is returned instead and must be exchanged at /auth/mfa/verify.
 // @Tags         Auth
 // @Accept       json
 // @Produce      json
 // @Param        request  body      request.LoginRequest  true  "Login credentials (username and password)"
-// @Success      200      {object}  response.TokensResponse   "Login successful — returns new access and refresh tokens"
+// @Success      200      {object}  response.TokensResponse        "Login successful — returns new access and refresh tokens"
+// @Success      202      {object}  response.MFAChallengeResponse  "Credentials valid — MFA verification required"
 // @Failure      400      {object}  response.ErrorResponse    "Invalid request payload or missing fields"
 // @Failure      401      {object}  response.ErrorResponse    "Invalid credentials"
+// @Failure      403      {object}  response.ErrorResponse    "The user's role requires MFA but it is not enabled"
+// @Failure      429      {object}  response.ErrorResponse    "Too many failed login attempts"
 // @Failure      500      {object}  response.ErrorResponse    "Internal server error"
 // @Router       /auth/login [post]
 func (h *AuthHandler) Login(c *gin.Context) {
@@ -39,7 +42,7 @@ func (h *AuthHandler) Login(c *gin.Context) {
 		return
 	}
 
-	tokenGroup, err := h.authService.Login(c, &domain.User{
+	result, err := h.authService.Login(c, &domain.User{
 		Username: req.Username,
 		Password: req.Password,
 	})
@@ -48,9 +51,165 @@ func (h *AuthHandler) Login(c *gin.Context) {
 		return
 	}
 
+	if result.MFAToken != nil {
+		c.JSON(http.StatusAccepted, response.NewMFAChallengeResponse(*result.MFAToken))
+		return
+	}
+	c.JSON(http.StatusOK, response.NewTokensResponse(result.TokenGroup))
+}
+
+// VerifyMFA godoc
+// @Summary      Verify MFA code
+// @Description  Finishes a login of a user with MFA enabled. Exchanges the **MFA token** returned by /auth/login together with a TOTP or recovery code for a new access/refresh token pair.
+// @Tags         Auth
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        Authorization  header    string                    true  "Bearer MFA token (format: Bearer <token>)"
+// @Param        request        body      request.VerifyMFARequest  true  "TOTP or recovery code"
+// @Success      200  {object}  response.TokensResponse   "MFA verified — returns new access and refresh tokens"
+// @Failure      400  {object}  response.ErrorResponse    "Invalid request payload or missing fields"
+// @Failure      401  {object}  response.ErrorResponse    "Missing, malformed, or invalid token or code"
+// @Failure      403  {object}  response.ErrorResponse    "Invalid token type (expected MFA token)"
+// @Failure      429  {object}  response.ErrorResponse    "Too many failed verification attempts"
+// @Failure      500  {object}  response.ErrorResponse    "Internal server error"
+// @Router       /auth/mfa/verify [post]
+func (h *AuthHandler) VerifyMFA(c *gin.Context) {
+	token, ok := c.Get("token")
+	if !ok {
+		response.HandleError(c, domain.ErrInternal)
+		return
+	}
+	domainToken, ok := token.(*domain.Token)
+	if !ok {
+		response.HandleError(c, domain.ErrInternal)
+		return
+	}
+
+	var req request.VerifyMFARequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.HandleBindingError(c, err)
+		return
+	}
+
+	tokenGroup, err := h.authService.VerifyMFA(c, domainToken, req.Code)
+	if err != nil {
+		response.HandleError(c, err)
+		return
+	}
+
 	c.JSON(http.StatusOK, response.NewTokensResponse(tokenGroup))
 }
 
+// OAuthLogin godoc
+// @Summary      Start login with an identity provider
+// @Description  Starts an OAuth 2.0 / OpenID Connect login with a configured identity provider (e.g. google, github) and redirects the user to its consent page. The login is protected with state, nonce and PKCE.
+// @Tags         Auth
+// @Param        provider  path  string  true  "Identity provider name"
+// @Success      302  "Redirect to the identity provider"
+// @Failure      404  {object}  response.ErrorResponse    "Identity provider not configured"
+// @Failure      500  {object}  response.ErrorResponse    "Internal server error"
+// @Router       /auth/oauth/{provider}/login [get]
+func (h *AuthHandler) OAuthLogin(c *gin.Context) {
+	var uri request.OAuthProviderUri
+	if err := c.ShouldBindUri(&uri); err != nil {
+		response.HandleBindingError(c, err)
+		return
+	}
+
+	url, err := h.authService.StartOAuthLogin(c, uri.Provider)
+	if err != nil {
+		response.HandleError(c, err)
+		return
+	}
+
+	c.Redirect(http.StatusFound, url)
+}
+
+// OAuthLink godoc
+// @Summary      Link an identity provider
+// @Description  Starts linking an identity of a configured identity provider to the logged in user and returns the url of its consent page. The link is finished at /auth/oauth/{provider}/callback. Users with permissions or MFA enabled can only link identities this way.
+// @Tags         Auth
+// @Security     BearerAuth
+// @Produce      json
+// @Param        Authorization  header  string  true  "Bearer access token (format: Bearer <token>)"
+// @Param        provider       path    string  true  "Identity provider name"
+// @Success      200  {object}  response.OAuthLinkResponse  "Url of the identity provider"
+// @Failure      401  {object}  response.ErrorResponse    "Missing, malformed, or invalid access token"
+// @Failure      403  {object}  response.ErrorResponse    "Invalid token type (expected access token of the user)"
+// @Failure      404  {object}  response.ErrorResponse    "Identity provider not configured"
+// @Failure      500  {object}  response.ErrorResponse    "Internal server error"
+// @Router       /auth/oauth/{provider}/link [post]
+func (h *AuthHandler) OAuthLink(c *gin.Context) {
+	token, ok := c.Get("token")
+	if !ok {
+		response.HandleError(c, domain.ErrInternal)
+		return
+	}
+	domainToken, ok := token.(*domain.Token)
+	if !ok {
+		response.HandleError(c, domain.ErrInternal)
+		return
+	}
+
+	var uri request.OAuthProviderUri
+	if err := c.ShouldBindUri(&uri); err != nil {
+		response.HandleBindingError(c, err)
+		return
+	}
+
+	url, err := h.authService.StartOAuthLink(c, domainToken, uri.Provider)
+	if err != nil {
+		response.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response.NewOAuthLinkResponse(url))
+}
+
+// OAuthCallback godoc
+// @Summary      Finish login with an identity provider
+// @Description  Finishes a login started at /auth/oauth/{provider}/login or a link started at /auth/oauth/{provider}/link. A linked identity logs in its user. An unlinked identity is linked to the user who started the link, to an existing user without permissions and MFA with the same verified email, or a new client user is created. Returns a new pair of access and refresh tokens, or an MFA token if the user has MFA enabled.
+// @Tags         Auth
+// @Produce      json
+// @Param        provider  path      string  true  "Identity provider name"
+// @Param        state     query     string  true  "State returned by the identity provider"
+// @Param        code      query     string  true  "Authorization code returned by the identity provider"
+// @Success      200  {object}  response.TokensResponse        "Login successful — returns new access and refresh tokens"
+// @Success      202  {object}  response.MFAChallengeResponse  "Identity verified — MFA verification required"
+// @Failure      400  {object}  response.ErrorResponse    "Missing parameters or invalid, expired or reused state"
+// @Failure      401  {object}  response.ErrorResponse    "The identity provider did not authenticate the user"
+// @Failure      403  {object}  response.ErrorResponse    "The identity has no verified email or the user's role requires MFA but it is not enabled"
+// @Failure      404  {object}  response.ErrorResponse    "Identity provider not configured"
+// @Failure      409  {object}  response.ErrorResponse    "A privileged user or one with MFA enabled has the email, the identity is linked to another user or no free username could be generated"
+// @Failure      500  {object}  response.ErrorResponse    "Internal server error"
+// @Router       /auth/oauth/{provider}/callback [get]
+func (h *AuthHandler) OAuthCallback(c *gin.Context) {
+	var uri request.OAuthProviderUri
+	if err := c.ShouldBindUri(&uri); err != nil {
+		response.HandleBindingError(c, err)
+		return
+	}
+
+	var req request.OAuthCallbackRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		response.HandleBindingError(c, err)
+		return
+	}
+
+	result, err := h.authService.FinishOAuthLogin(c, uri.Provider, req.State, req.Code)
+	if err != nil {
+		response.HandleError(c, err)
+		return
+	}
+
+	if result.MFAToken != nil {
+		c.JSON(http.StatusAccepted, response.NewMFAChallengeResponse(*result.MFAToken))
+		return
+	}
+	c.JSON(http.StatusOK, response.NewTokensResponse(result.TokenGroup))
+}
+
 // RefreshSession godoc
 // @Summary      Refresh access token
 // @Description  Refreshes the user's authentication session using a valid **refresh token** provided in the Authorization header. Returns a new access/refresh token pair.
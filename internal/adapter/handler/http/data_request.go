@@ -0,0 +1,183 @@
+package http
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"shop-api-go/internal/adapter/handler/http/response"
+	"shop-api-go/internal/core/domain"
+	"shop-api-go/internal/core/port"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// DataRequestHandler represent HTTP handler for data subject request-related requests.
+type DataRequestHandler struct {
+	dataRequestService port.DataRequestService
+}
+
+// NewDataRequestHandler creates a new DataRequestHandler instance.
+func NewDataRequestHandler(dataRequestService port.DataRequestService) *DataRequestHandler {
+	return &DataRequestHandler{
+		dataRequestService: dataRequestService,
+	}
+}
+
+// RequestDataExport godoc
+// @Summary      Request user data export
+// @Description  Queues the export of all data stored about a user: profile, sessions, MFA settings, linked identities, API keys and audit entries. The archive is compiled in the background, poll the returned request until it is completed. Requires the users:privacy permission and a valid JWT token in the Authorization header.
+// @Tags         Admin
+// @Security     BearerAuth
+// @Param        Authorization  header    string  true   "Bearer access token"
+// @Param        id             path      string  true   "User ID (UUID) to export"
+// @Produce      json
+// @Success      202            {object}  response.DataRequestResponse "Export queued"
+// @Failure      400            {object}  response.ErrorResponse "Invalid user id"
+// @Failure      401            {object}  response.ErrorResponse "Unauthorized – invalid token"
+// @Failure      403            {object}  response.ErrorResponse "Forbidden – insufficient permissions or invalid token type(expected access token)"
+// @Failure      404            {object}  response.ErrorResponse "User not found"
+// @Failure      409            {object}  response.ErrorResponse "User data has been erased"
+// @Failure      500            {object}  response.ErrorResponse "Internal server error"
+// @Router       /admin/users/{id}/data-export [post]
+func (h *DataRequestHandler) RequestDataExport(c *gin.Context) {
+	h.addDataRequest(c, h.dataRequestService.RequestDataExport)
+}
+
+// RequestErasure godoc
+// @Summary      Request user data erasure
+// @Description  Queues the erasure of the personal data of a user. Username, email and password are anonymised, sessions, MFA, linked identities, API keys and export archives are deleted, and the user is soft-deleted for good. The user record is kept so that records referencing it stay intact, the audit log is append-only and kept. Requires the users:privacy permission and a valid JWT token in the Authorization header.
+// @Tags         Admin
+// @Security     BearerAuth
+// @Param        Authorization  header    string  true   "Bearer access token"
+// @Param        id             path      string  true   "User ID (UUID) to erase"
+// @Produce      json
+// @Success      202            {object}  response.DataRequestResponse "Erasure queued"
+// @Failure      400            {object}  response.ErrorResponse "Invalid user id"
+// @Failure      401            {object}  response.ErrorResponse "Unauthorized – invalid token"
+// @Failure      403            {object}  response.ErrorResponse "Forbidden – insufficient permissions or invalid token type(expected access token)"
+// @Failure      404            {object}  response.ErrorResponse "User not found"
+// @Failure      409            {object}  response.ErrorResponse "User data already erased or own account"
+// @Failure      500            {object}  response.ErrorResponse "Internal server error"
+// @Router       /admin/users/{id}/erasure [post]
+func (h *DataRequestHandler) RequestErasure(c *gin.Context) {
+	h.addDataRequest(c, h.dataRequestService.RequestErasure)
+}
+
+// GetDataRequest godoc
+// @Summary      Data request status
+// @Description  Retrieves the status of a data export or erasure request. Requires the users:privacy permission and a valid JWT token in the Authorization header.
+// @Tags         Admin
+// @Security     BearerAuth
+// @Param        Authorization  header    string  true   "Bearer access token"
+// @Param        id             path      string  true   "Data request ID (UUID)"
+// @Produce      json
+// @Success      200            {object}  response.DataRequestResponse "Data request"
+// @Failure      400            {object}  response.ErrorResponse "Invalid data request id"
+// @Failure      401            {object}  response.ErrorResponse "Unauthorized – invalid token"
+// @Failure      403            {object}  response.ErrorResponse "Forbidden – insufficient permissions or invalid token type(expected access token)"
+// @Failure      404            {object}  response.ErrorResponse "Data request not found"
+// @Failure      500            {object}  response.ErrorResponse "Internal server error"
+// @Router       /admin/data-requests/{id} [get]
+func (h *DataRequestHandler) GetDataRequest(c *gin.Context) {
+	token, ok := c.Get("token")
+	if !ok {
+		response.HandleError(c, domain.ErrInternal)
+		return
+	}
+	domainToken, ok := token.(*domain.Token)
+	if !ok {
+		response.HandleError(c, domain.ErrInternal)
+		return
+	}
+
+	parsedId, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.HandleError(c, domain.ErrInvalidUUID)
+		return
+	}
+
+	request, err := h.dataRequestService.GetDataRequest(c, domainToken, parsedId)
+	if err != nil {
+		response.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response.NewDataRequestResponse(request))
+}
+
+// GetDataExportArchive godoc
+// @Summary      Download user data export
+// @Description  Downloads the ZIP archive of a completed data export, with a JSON file per kind of data. Archives are deleted 7 days after completion and when the user is erased. Every download is recorded in the audit log. Requires the users:privacy permission and a valid JWT token in the Authorization header.
+// @Tags         Admin
+// @Security     BearerAuth
+// @Param        Authorization  header    string  true   "Bearer access token"
+// @Param        id             path      string  true   "Data request ID (UUID)"
+// @Produce      application/zip
+// @Success      200            {file}    file    "ZIP archive"
+// @Failure      400            {object}  response.ErrorResponse "Invalid data request id"
+// @Failure      401            {object}  response.ErrorResponse "Unauthorized – invalid token"
+// @Failure      403            {object}  response.ErrorResponse "Forbidden – insufficient permissions or invalid token type(expected access token)"
+// @Failure      404            {object}  response.ErrorResponse "Data request not found"
+// @Failure      409            {object}  response.ErrorResponse "Data request is not a completed export"
+// @Failure      410            {object}  response.ErrorResponse "Archive expired or erased"
+// @Failure      500            {object}  response.ErrorResponse "Internal server error"
+// @Router       /admin/data-requests/{id}/archive [get]
+func (h *DataRequestHandler) GetDataExportArchive(c *gin.Context) {
+	token, ok := c.Get("token")
+	if !ok {
+		response.HandleError(c, domain.ErrInternal)
+		return
+	}
+	domainToken, ok := token.(*domain.Token)
+	if !ok {
+		response.HandleError(c, domain.ErrInternal)
+		return
+	}
+
+	parsedId, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.HandleError(c, domain.ErrInvalidUUID)
+		return
+	}
+
+	request, archive, err := h.dataRequestService.GetDataExportArchive(c, domainToken, parsedId)
+	if err != nil {
+		response.HandleError(c, err)
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="user-%s-data.zip"`, request.UserId))
+	c.Data(http.StatusOK, "application/zip", archive)
+}
+
+// addDataRequest parses the user id and queues a data request with add.
+func (h *DataRequestHandler) addDataRequest(
+	c *gin.Context,
+	add func(ctx context.Context, token *domain.Token, userId uuid.UUID) (*domain.DataRequest, error),
+) {
+	token, ok := c.Get("token")
+	if !ok {
+		response.HandleError(c, domain.ErrInternal)
+		return
+	}
+	domainToken, ok := token.(*domain.Token)
+	if !ok {
+		response.HandleError(c, domain.ErrInternal)
+		return
+	}
+
+	parsedId, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.HandleError(c, domain.ErrInvalidUUID)
+		return
+	}
+
+	request, err := add(c, domainToken, parsedId)
+	if err != nil {
+		response.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusAccepted, response.NewDataRequestResponse(request))
+}
@@ -11,6 +11,13 @@ var Module = fx.Module(
 	fx.Provide(NewUserHandler),
 	fx.Provide(NewAuthHandler),
 	fx.Provide(NewAdminHandler),
+	fx.Provide(NewMFAHandler),
+	fx.Provide(NewRoleHandler),
+	fx.Provide(NewAPIKeyHandler),
+	fx.Provide(NewAuditHandler),
+	fx.Provide(NewDataRequestHandler),
+	fx.Provide(NewWebhookHandler),
+	fx.Provide(NewRuntimeSettingsHandler),
 	fx.Provide(NewRouter),
 	fx.Invoke(func(lc fx.Lifecycle, router *Router) {
 		lc.Append(fx.Hook{
@@ -0,0 +1,108 @@
+package http
+
+import (
+	"net/http"
+	"shop-api-go/internal/adapter/handler/http/request"
+	"shop-api-go/internal/adapter/handler/http/response"
+	"shop-api-go/internal/core/domain"
+	"shop-api-go/internal/core/port"
+
+	"github.com/gin-gonic/gin"
+)
+
+// MFAHandler handles HTTP requests related to multi-factor authentication.
+type MFAHandler struct {
+	mfaService port.MFAService
+}
+
+// NewMFAHandler returns a new MFAHandler instance.
+func NewMFAHandler(mfaService port.MFAService) *MFAHandler {
+	return &MFAHandler{
+		mfaService: mfaService,
+	}
+}
+
+// Enroll godoc
+// @Summary      Start MFA enrolment
+// @Description  Generates a new TOTP secret for the user and returns it together with an otpauth:// provisioning URI that can be rendered as a QR code. MFA is not enabled until the enrolment is confirmed.
+// @Tags         Users
+// @Accept       json
+// @Produce      json
+// @Param        request  body      request.MFAEnrollRequest  true  "User credentials"
+// @Success      200      {object}  response.MFAEnrollmentResponse  "TOTP secret and provisioning URI"
+// @Failure      400      {object}  response.ErrorResponse          "Invalid request payload or missing fields"
+// @Failure      401      {object}  response.ErrorResponse          "Invalid credentials"
+// @Failure      409      {object}  response.ErrorResponse          "MFA is already enabled"
+// @Failure      500      {object}  response.ErrorResponse          "Internal server error"
+// @Router       /users/me/mfa/enroll [post]
+func (h *MFAHandler) Enroll(c *gin.Context) {
+	var req request.MFAEnrollRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.HandleBindingError(c, err)
+		return
+	}
+
+	enrollment, err := h.mfaService.Enroll(c, domain.NewMFACredentials(req.Username, req.Password, ""))
+	if err != nil {
+		response.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response.NewMFAEnrollmentResponse(enrollment))
+}
+
+// ConfirmEnrollment godoc
+// @Summary      Confirm MFA enrolment
+// @Description  Enables MFA after validating a TOTP code generated from the enrolled secret. Returns one-time recovery codes that are shown only once.
+// @Tags         Users
+// @Accept       json
+// @Produce      json
+// @Param        request  body      request.MFACodeRequest  true  "User credentials and TOTP code"
+// @Success      200      {object}  response.RecoveryCodesResponse  "MFA enabled — returns recovery codes"
+// @Failure      400      {object}  response.ErrorResponse          "Invalid request payload or MFA not enrolled"
+// @Failure      401      {object}  response.ErrorResponse          "Invalid credentials or code"
+// @Failure      409      {object}  response.ErrorResponse          "MFA is already enabled"
+// @Failure      500      {object}  response.ErrorResponse          "Internal server error"
+// @Router       /users/me/mfa/confirm [post]
+func (h *MFAHandler) ConfirmEnrollment(c *gin.Context) {
+	var req request.MFACodeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.HandleBindingError(c, err)
+		return
+	}
+
+	codes, err := h.mfaService.ConfirmEnrollment(c, domain.NewMFACredentials(req.Username, req.Password, req.Code))
+	if err != nil {
+		response.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response.NewRecoveryCodesResponse(codes))
+}
+
+// Disable godoc
+// @Summary      Disable MFA
+// @Description  Disables MFA after validating a TOTP code and deletes all recovery codes.
+// @Tags         Users
+// @Accept       json
+// @Produce      json
+// @Param        request  body      request.MFACodeRequest  true  "User credentials and TOTP code"
+// @Success      200                                        "MFA disabled successfully"
+// @Failure      400      {object}  response.ErrorResponse  "Invalid request payload or MFA not enrolled"
+// @Failure      401      {object}  response.ErrorResponse  "Invalid credentials or code"
+// @Failure      500      {object}  response.ErrorResponse  "Internal server error"
+// @Router       /users/me/mfa/disable [post]
+func (h *MFAHandler) Disable(c *gin.Context) {
+	var req request.MFACodeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.HandleBindingError(c, err)
+		return
+	}
+
+	if err := h.mfaService.Disable(c, domain.NewMFACredentials(req.Username, req.Password, req.Code)); err != nil {
+		response.HandleError(c, err)
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
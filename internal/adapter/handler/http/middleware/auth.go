@@ -0,0 +1,47 @@
+package middleware
+
+import (
+	"shop-api-go/internal/adapter/handler/http/response"
+	"shop-api-go/internal/core/domain"
+	"shop-api-go/internal/core/port"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AuthMiddleware is a middleware used to authenticate user by JWT, API key or personal access token.
+// Both are sent as bearer tokens and yield a *domain.Token, so handlers do not differ between them.
+//
+// Note: Key value sets the key where the token will be stored in the context.
+func AuthMiddleware(generator port.TokenGenerator, apiKeyService port.APIKeyService, key string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		header := c.GetHeader("Authorization")
+		if header == "" || !strings.HasPrefix(header, "Bearer ") {
+			response.HandleError(c, domain.ErrInvalidToken)
+			c.Abort()
+			return
+		}
+
+		tokenString := strings.TrimPrefix(header, "Bearer ")
+
+		// JWTs always consist of three dot separated parts, API keys never contain a dot.
+		var token *domain.Token
+		var err error
+		if strings.Count(tokenString, ".") == 2 {
+			token, err = generator.ParseToken(tokenString)
+			if err != nil {
+				err = domain.ErrInvalidToken
+			}
+		} else {
+			token, err = apiKeyService.Authenticate(c, tokenString)
+		}
+		if err != nil {
+			response.HandleError(c, err)
+			c.Abort()
+			return
+		}
+
+		c.Set(key, token)
+		c.Next()
+	}
+}
@@ -0,0 +1,22 @@
+package middleware
+
+import (
+	"shop-api-go/internal/adapter/handler/http/response"
+	"shop-api-go/internal/core/domain"
+	"shop-api-go/internal/core/port"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RequireFeature is a middleware used to reject requests while the feature is switched off in the runtime settings.
+func RequireFeature(settingsService port.RuntimeSettingsService, feature domain.Feature) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !settingsService.Current().FeatureEnabled(feature) {
+			response.HandleError(c, domain.ErrFeatureDisabled)
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
@@ -0,0 +1,38 @@
+package middleware
+
+import (
+	"shop-api-go/internal/core/domain"
+	"shop-api-go/internal/core/port"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// ImpersonationAudit is a middleware used to record every request made with an impersonation token
+// in the audit log, including rejected ones.
+//
+// Note: Key value sets the key where the token is stored in the context by AuthMiddleware.
+// The event is written after the handler, so a failure is logged and does not change the response.
+func ImpersonationAudit(auditService port.AuditService, key string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Next()
+
+		value, ok := c.Get(key)
+		if !ok {
+			return
+		}
+		token, ok := value.(*domain.Token)
+		if !ok || !token.IsImpersonated() {
+			return
+		}
+
+		if err := auditService.RecordImpersonatedRequest(c, token, c.Request.Method, c.Request.URL.Path, c.Writer.Status()); err != nil {
+			zap.L().Error(
+				"error recording impersonated request",
+				zap.String("actor_id", token.ActorId.String()),
+				zap.String("user_id", token.UserId.String()),
+				zap.Error(err),
+			)
+		}
+	}
+}
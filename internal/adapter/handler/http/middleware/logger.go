@@ -1,6 +1,7 @@
 package middleware
 
 import (
+	"shop-api-go/internal/adapter/handler/api"
 	"time"
 
 	"github.com/gin-gonic/gin"
@@ -21,6 +22,7 @@ func ZapLogger() gin.HandlerFunc {
 			zap.String("path", c.Request.URL.Path),
 			zap.String("query", c.Request.URL.RawQuery),
 			zap.String("user-agent", c.Request.UserAgent()),
+			zap.String("request-id", c.Writer.Header().Get(api.RequestIdHeader)),
 			zap.Duration("latency", latency),
 		)
 	}
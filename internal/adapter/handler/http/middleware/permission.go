@@ -0,0 +1,37 @@
+package middleware
+
+import (
+	"shop-api-go/internal/adapter/handler/http/response"
+	"shop-api-go/internal/core/domain"
+	"shop-api-go/internal/core/port"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RequirePermission is a middleware used to reject requests whose token is not granted the permission.
+//
+// Note: Key value sets the key where the token is stored in the context by AuthMiddleware.
+func RequirePermission(authorizer port.Authorizer, permission domain.Permission, key string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		value, ok := c.Get(key)
+		if !ok {
+			response.HandleError(c, domain.ErrInvalidToken)
+			c.Abort()
+			return
+		}
+		token, ok := value.(*domain.Token)
+		if !ok {
+			response.HandleError(c, domain.ErrInternal)
+			c.Abort()
+			return
+		}
+
+		if err := authorizer.Authorize(c, token, permission); err != nil {
+			response.HandleError(c, err)
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
@@ -0,0 +1,68 @@
+package middleware
+
+import (
+	"math"
+	"shop-api-go/internal/adapter/handler/http/response"
+	"shop-api-go/internal/core/domain"
+	"shop-api-go/internal/core/port"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// RateLimit is a middleware used to limit the amount of requests sent to a route group.
+//
+// Note: Requests with a token stored under the key are limited per token subject
+// using the limit of the token role, other requests are limited per client IP.
+// The policy of the group is read from the current runtime settings on every request.
+// Requests are allowed if the store fails, so the store is not a single point of failure.
+func RateLimit(store port.RateLimitStore, settingsService port.RuntimeSettingsService, group string, key string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		settings := settingsService.Current()
+		policy, ok := settings.RateLimitPolicy(group)
+		if !settings.RateLimitEnabled || !ok {
+			c.Next()
+			return
+		}
+
+		bucketKey := group + ":ip:" + c.ClientIP()
+		limit := policy.Default
+		if value, ok := c.Get(key); ok {
+			if token, ok := value.(*domain.Token); ok {
+				bucketKey = group + ":user:" + token.UserId.String()
+				limit = policy.ForRole(token.UserRole)
+			}
+		}
+
+		result, err := store.Take(c, bucketKey, limit)
+		if err != nil {
+			zap.L().Warn(
+				"rate limit store failed, allowing request",
+				zap.String("key", bucketKey),
+				zap.Error(err),
+			)
+			c.Next()
+			return
+		}
+
+		c.Header("RateLimit-Limit", strconv.Itoa(result.Limit))
+		c.Header("RateLimit-Remaining", strconv.Itoa(result.Remaining))
+		c.Header("RateLimit-Reset", seconds(result.ResetAfter))
+		c.Header("RateLimit-Policy", strconv.Itoa(limit.Requests)+";w="+seconds(limit.Period))
+
+		if !result.Allowed {
+			c.Header("Retry-After", seconds(result.RetryAfter))
+			response.HandleError(c, domain.ErrRateLimitExceeded)
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
+
+// seconds formats a duration as a whole number of seconds rounded up.
+func seconds(d time.Duration) string {
+	return strconv.Itoa(int(math.Ceil(d.Seconds())))
+}
@@ -0,0 +1,26 @@
+package middleware
+
+import (
+	"shop-api-go/internal/adapter/handler/api"
+	"shop-api-go/internal/core/domain"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// RequestMetadata is a middleware used to attach domain.RequestMetadata to the request context.
+//
+// Note: A new request id is generated if the client did not send a valid one.
+func RequestMetadata() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		requestId := c.GetHeader(api.RequestIdHeader)
+		if _, err := uuid.Parse(requestId); err != nil {
+			requestId = uuid.NewString()
+		}
+		c.Header(api.RequestIdHeader, requestId)
+
+		metadata := domain.NewRequestMetadata(c.ClientIP(), c.Request.UserAgent(), requestId)
+		c.Request = c.Request.WithContext(domain.ContextWithRequestMetadata(c.Request.Context(), metadata))
+		c.Next()
+	}
+}
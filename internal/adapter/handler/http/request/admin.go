@@ -2,17 +2,39 @@ package request
 
 import (
 	"shop-api-go/internal/core/domain"
+	"time"
 )
 
+// UserFilterQuery represents query parameters for filtering users.
+type UserFilterQuery struct {
+	Id          *string            `form:"id"`
+	Username    *string            `form:"username"`
+	Email       *string            `form:"email"`
+	Role        *domain.UserRole   `form:"role" binding:"omitempty,user_role"`
+	Status      *domain.UserStatus `form:"status" binding:"omitempty,oneof=active suspended"`
+	CreatedFrom *time.Time         `form:"createdFrom" time_format:"2006-01-02T15:04:05Z07:00"`
+	CreatedTo   *time.Time         `form:"createdTo" time_format:"2006-01-02T15:04:05Z07:00"`
+	UpdatedFrom *time.Time         `form:"updatedFrom" time_format:"2006-01-02T15:04:05Z07:00"`
+	UpdatedTo   *time.Time         `form:"updatedTo" time_format:"2006-01-02T15:04:05Z07:00"`
+}
+
 // GetUserQuery represents query parameters for fetching user.
 type GetUserQuery struct {
-	Id       *string          `form:"id"`
-	Username *string          `form:"username"`
-	Email    *string          `form:"email"`
-	Role     *domain.UserRole `form:"role" binding:"omitempty,user_role"`
-	Page     *int             `form:"page" binding:"omitempty,min=1"`
-	Cursor   *string          `form:"cursor"`
-	Limit    *int             `form:"limit" binding:"omitempty,min=1"`
+	UserFilterQuery
+	Sort   domain.UserSortField `form:"sort,default=created_at" binding:"oneof=id username email role status created_at updated_at"`
+	Order  string               `form:"order,default=asc" binding:"oneof=asc desc"`
+	Page   *int                 `form:"page" binding:"omitempty,min=1"`
+	Cursor *string              `form:"cursor"`
+	Limit  *int                 `form:"limit" binding:"omitempty,min=1,max=100"`
+	Total  bool                 `form:"total"`
+}
+
+// CreateUser represents create user request body.
+type CreateUser struct {
+	Email    string          `json:"email" binding:"required,email,min_bytes=8,max_bytes=255" example:"newUser@email.com"`
+	Username string          `json:"username" binding:"required,min_bytes=8,max_bytes=255" example:"newUser123"`
+	Password string          `json:"password" binding:"required,password" example:"NewSecret_123"`
+	Role     domain.UserRole `json:"role" binding:"required,user_role" example:"warehouse"`
 }
 
 // UpdateUser represents update user request body.
@@ -22,3 +44,24 @@ type UpdateUser struct {
 	Password *string          `json:"password" binding:"omitempty,password"`
 	Role     *domain.UserRole `json:"role" binding:"omitempty,user_role"`
 }
+
+// ImportUsersQuery represents query parameters for importing users.
+type ImportUsersQuery struct {
+	DryRun bool `form:"dryRun"`
+}
+
+// ImportUser represents a user in a CSV or NDJSON import file.
+//
+// Note: The fields are validated with the same rules as CreateUser.
+type ImportUser struct {
+	Email    string          `json:"email" binding:"required,email,min_bytes=8,max_bytes=255"`
+	Username string          `json:"username" binding:"required,min_bytes=8,max_bytes=255"`
+	Password string          `json:"password" binding:"required,password"`
+	Role     domain.UserRole `json:"role" binding:"required,user_role"`
+}
+
+// ExportUsersQuery represents query parameters for exporting users.
+type ExportUsersQuery struct {
+	UserFilterQuery
+	Format string `form:"format,default=csv" binding:"oneof=csv ndjson"`
+}
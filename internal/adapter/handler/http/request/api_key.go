@@ -0,0 +1,15 @@
+package request
+
+import "shop-api-go/internal/core/domain"
+
+// CreateAPIKeyRequest represents a request body for creating an API key or a personal access token.
+type CreateAPIKeyRequest struct {
+	Name      string              `json:"name" binding:"required,max_bytes=255" example:"Warehouse scanner"`
+	Scopes    []domain.Permission `json:"scopes" binding:"required,dive,required" example:"orders:read"`
+	ExpiresIn *string             `json:"expiresIn" example:"720h"`
+}
+
+// APIKeyUri represents an API key id path parameter.
+type APIKeyUri struct {
+	Id string `uri:"id" binding:"required"`
+}
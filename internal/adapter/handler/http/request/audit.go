@@ -0,0 +1,18 @@
+package request
+
+import (
+	"shop-api-go/internal/core/domain"
+	"time"
+)
+
+// GetAuditEventsQuery represents query parameters for fetching audit events.
+type GetAuditEventsQuery struct {
+	ActorId    *string                 `form:"actorId"`
+	Action     *domain.AuditAction     `form:"action"`
+	TargetType *domain.AuditTargetType `form:"targetType"`
+	TargetId   *string                 `form:"targetId"`
+	From       *time.Time              `form:"from" time_format:"2006-01-02T15:04:05Z07:00"`
+	To         *time.Time              `form:"to" time_format:"2006-01-02T15:04:05Z07:00"`
+	Page       int                     `form:"page" binding:"omitempty,min=1"`
+	Limit      int                     `form:"limit" binding:"required,min=1,max=100"`
+}
@@ -5,3 +5,14 @@ type LoginRequest struct {
 	Username string `json:"username" example:"MyUsername"`
 	Password string `json:"password" example:"Secret_password123"`
 }
+
+// OAuthProviderUri represents an identity provider path parameter.
+type OAuthProviderUri struct {
+	Provider string `uri:"provider" binding:"required" example:"google"`
+}
+
+// OAuthCallbackRequest represents the query of a redirect from an identity provider.
+type OAuthCallbackRequest struct {
+	State string `form:"state" binding:"required"`
+	Code  string `form:"code" binding:"required"`
+}
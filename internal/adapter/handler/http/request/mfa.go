@@ -0,0 +1,31 @@
+package request
+
+import "shop-api-go/internal/core/domain"
+
+// MFAEnrollRequest represents a request body for starting MFA enrolment.
+type MFAEnrollRequest struct {
+	Username string `json:"username" binding:"required" example:"MyUsername"`
+	Password string `json:"password" binding:"required" example:"MyPassword_123"`
+}
+
+// MFACodeRequest represents a request body for confirming or disabling MFA.
+type MFACodeRequest struct {
+	Username string `json:"username" binding:"required" example:"MyUsername"`
+	Password string `json:"password" binding:"required" example:"MyPassword_123"`
+	Code     string `json:"code" binding:"required" example:"123456"`
+}
+
+// VerifyMFARequest represents a request body for finishing a login with MFA.
+type VerifyMFARequest struct {
+	Code string `json:"code" binding:"required" example:"123456"`
+}
+
+// RoleUri represents a user role path parameter.
+type RoleUri struct {
+	Role domain.UserRole `uri:"role" binding:"required,user_role"`
+}
+
+// SetMFARequirementRequest represents a request body for setting the MFA requirement of a role.
+type SetMFARequirementRequest struct {
+	Required *bool `json:"required" binding:"required" example:"true"`
+}
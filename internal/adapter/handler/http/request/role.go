@@ -0,0 +1,18 @@
+package request
+
+import "shop-api-go/internal/core/domain"
+
+// CreateRoleRequest represents a request body for creating a custom role.
+type CreateRoleRequest struct {
+	Name        domain.UserRole     `json:"name" binding:"required,user_role" example:"support"`
+	Description string              `json:"description" binding:"max_bytes=255" example:"Customer support staff"`
+	Permissions []domain.Permission `json:"permissions" binding:"required,dive,required" example:"users:read"`
+}
+
+// UpdateRoleRequest represents a request body for updating a role.
+//
+// Note: Permissions replace all permissions granted to the role.
+type UpdateRoleRequest struct {
+	Description *string             `json:"description" binding:"omitempty,max_bytes=255" example:"Customer support staff"`
+	Permissions []domain.Permission `json:"permissions" binding:"omitempty,dive,required" example:"users:read"`
+}
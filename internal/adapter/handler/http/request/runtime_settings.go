@@ -0,0 +1,24 @@
+package request
+
+import "shop-api-go/internal/core/domain"
+
+// UpdateRuntimeSettingsRequest represents a request body for changing the runtime settings.
+//
+// Note: RateLimits replace the whole policy of the given route groups, Features only switch the given features.
+// Expire times are durations, e.g. 30m.
+type UpdateRuntimeSettingsRequest struct {
+	LogLevel                     *domain.LogLevel                  `json:"logLevel" binding:"omitempty,oneof=debug info warn error" example:"debug"`
+	RateLimitEnabled             *bool                             `json:"rateLimitEnabled" example:"true"`
+	RateLimits                   map[string]RateLimitPolicyRequest `json:"rateLimits" binding:"omitempty,dive"`
+	AccessTokenExpireTime        *string                           `json:"accessTokenExpireTime" example:"30m"`
+	RefreshTokenExpireTime       *string                           `json:"refreshTokenExpireTime" example:"24h"`
+	MFATokenExpireTime           *string                           `json:"mfaTokenExpireTime" example:"5m"`
+	ImpersonationTokenExpireTime *string                           `json:"impersonationTokenExpireTime" example:"15m"`
+	Features                     map[domain.Feature]bool           `json:"features"`
+}
+
+// RateLimitPolicyRequest represents the rate limits of a route group in the requests/period format.
+type RateLimitPolicyRequest struct {
+	Default string            `json:"default" binding:"required" example:"20/1m"`
+	Roles   map[string]string `json:"roles" binding:"omitempty,dive,keys,user_role,endkeys"`
+}
@@ -0,0 +1,29 @@
+package request
+
+import "shop-api-go/internal/core/domain"
+
+// CreateWebhookEndpointRequest represents a request body for creating a webhook endpoint.
+//
+// Note: An endpoint without event types receives every event.
+type CreateWebhookEndpointRequest struct {
+	Url         string             `json:"url" binding:"required,http_url,max=2048" example:"https://partner.example.com/webhooks"`
+	Description string             `json:"description" binding:"max_bytes=255" example:"ERP integration"`
+	EventTypes  []domain.EventType `json:"eventTypes" binding:"omitempty,dive,event_type" example:"user.registered"`
+}
+
+// UpdateWebhookEndpointRequest represents a request body for updating a webhook endpoint.
+//
+// Note: EventTypes replace all event types of the endpoint, an empty list subscribes it to every event.
+type UpdateWebhookEndpointRequest struct {
+	Url         *string            `json:"url" binding:"omitempty,http_url,max=2048" example:"https://partner.example.com/webhooks"`
+	Description *string            `json:"description" binding:"omitempty,max_bytes=255" example:"ERP integration"`
+	EventTypes  []domain.EventType `json:"eventTypes" binding:"omitempty,dive,event_type" example:"user.registered"`
+	Enabled     *bool              `json:"enabled" example:"false"`
+}
+
+// GetWebhookDeliveriesQuery represents query parameters for fetching the deliveries of a webhook endpoint.
+type GetWebhookDeliveriesQuery struct {
+	Status *domain.WebhookDeliveryStatus `form:"status" binding:"omitempty,oneof=pending succeeded dead"`
+	Page   int                           `form:"page" binding:"omitempty,min=1"`
+	Limit  int                           `form:"limit" binding:"required,min=1,max=100"`
+}
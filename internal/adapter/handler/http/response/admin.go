@@ -1,7 +1,6 @@
 package response
 
 import (
-	"encoding/base64"
 	"shop-api-go/internal/core/domain"
 	"time"
 
@@ -10,12 +9,13 @@ import (
 
 // user represents a response with user's information.
 type user struct {
-	Id        uuid.UUID       `json:"id" example:"1bd70616-480b-47b9-91f5-292b4f4a45b1"`
-	Username  string          `json:"username" example:"Viktor123"`
-	Email     string          `json:"email" example:"viktor.stavchev@gmail.com"`
-	Role      domain.UserRole `json:"role" example:"client"`
-	CreatedAt time.Time       `json:"createdAt" example:"2025-10-15T12:37:42.664482Z"`
-	UpdatedAt time.Time       `json:"updatedAt" example:"2025-10-15T12:37:42.664482Z"`
+	Id        uuid.UUID         `json:"id" example:"1bd70616-480b-47b9-91f5-292b4f4a45b1"`
+	Username  string            `json:"username" example:"Viktor123"`
+	Email     string            `json:"email" example:"viktor.stavchev@gmail.com"`
+	Role      domain.UserRole   `json:"role" example:"client"`
+	Status    domain.UserStatus `json:"status" example:"active"`
+	CreatedAt time.Time         `json:"createdAt" example:"2025-10-15T12:37:42.664482Z"`
+	UpdatedAt time.Time         `json:"updatedAt" example:"2025-10-15T12:37:42.664482Z"`
 }
 
 // newUser creates a new user instance.
@@ -25,30 +25,74 @@ func newUser(u *domain.User) user {
 		Username:  u.Username,
 		Email:     u.Email,
 		Role:      u.Role,
+		Status:    u.Status,
 		CreatedAt: u.CreatedAt,
 		UpdatedAt: u.UpdatedAt,
 	}
 }
 
+// AccountResponse represents a response with the account of the authenticated user.
+type AccountResponse user
+
+// NewAccountResponse creates a new AccountResponse instance.
+func NewAccountResponse(u *domain.User) AccountResponse {
+	return AccountResponse(newUser(u))
+}
+
 // FetchingUsersResponse represents a response when fetching users.
 type FetchingUsersResponse struct {
 	Users  []user  `json:"users"`
-	Cursor *string `json:"cursor" example:"MjAyNS0xMC0xNVQxMjo0MDoxOS41NTU4Mjda"`
+	Cursor *string `json:"cursor" example:"eyJzb3J0QnkiOiJjcmVhdGVkX2F0IiwiZGVzYyI6ZmFsc2UsInZhbHVlIjoiMjAyNS0xMC0xNVQxMjo0MDoxOS41NTU4MjdaIiwiaWQiOiIxYmQ3MDYxNi00ODBiLTQ3YjktOTFmNS0yOTJiNGY0YTQ1YjEifQ=="`
+	Total  *int    `json:"total,omitempty" example:"42"`
 }
 
 // NewFetchingUsersResponse creates a new FetchingUsersResponse instance.
-func NewFetchingUsersResponse(result *domain.UsersResult) FetchingUsersResponse {
+//
+// Note: cursor is the encoded result.Cursor, nil when there are no more users.
+func NewFetchingUsersResponse(result *domain.UsersResult, cursor *string) FetchingUsersResponse {
 	users := make([]user, 0, len(result.Users))
 	for _, u := range result.Users {
 		users = append(users, newUser(&u))
 	}
 
-	if result.Cursor != nil {
-		encodedCursor := base64.URLEncoding.EncodeToString([]byte(*result.Cursor))
-		result.Cursor = &encodedCursor
-	}
 	return FetchingUsersResponse{
 		Users:  users,
-		Cursor: result.Cursor,
+		Cursor: cursor,
+		Total:  result.Total,
+	}
+}
+
+// ImportUsersResponse represents a response when importing users.
+type ImportUsersResponse struct {
+	Users  int  `json:"users" example:"25"`
+	DryRun bool `json:"dryRun" example:"false"`
+}
+
+// NewImportUsersResponse creates a new ImportUsersResponse instance.
+func NewImportUsersResponse(users int, dryRun bool) ImportUsersResponse {
+	return ImportUsersResponse{
+		Users:  users,
+		DryRun: dryRun,
+	}
+}
+
+// UserExportHeader is the header of CSV user exports.
+var UserExportHeader = []string{"id", "username", "email", "role", "status", "createdAt", "updatedAt"}
+
+// NewUserExportRecord creates a CSV record of a user with the columns of UserExportHeader.
+func NewUserExportRecord(u *domain.User) []string {
+	return []string{
+		u.Id.String(),
+		u.Username,
+		u.Email,
+		string(u.Role),
+		string(u.Status),
+		u.CreatedAt.Format(time.RFC3339Nano),
+		u.UpdatedAt.Format(time.RFC3339Nano),
 	}
 }
+
+// NewUserExportLine creates the NDJSON object of a user.
+func NewUserExportLine(u *domain.User) any {
+	return newUser(u)
+}
@@ -0,0 +1,68 @@
+package response
+
+import (
+	"shop-api-go/internal/core/domain"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// apiKey represents a response with API key's information.
+type apiKey struct {
+	Id         uuid.UUID           `json:"id" example:"1bd70616-480b-47b9-91f5-292b4f4a45b1"`
+	Name       string              `json:"name" example:"Warehouse scanner"`
+	Type       domain.APIKeyType   `json:"type" example:"api_key"`
+	Prefix     string              `json:"prefix" example:"sk_abcdefghijkl"`
+	Scopes     []domain.Permission `json:"scopes" example:"orders:read"`
+	ExpiresAt  time.Time           `json:"expiresAt" example:"2026-01-13T12:37:42.664482Z"`
+	LastUsedAt *time.Time          `json:"lastUsedAt" example:"2025-10-15T12:40:19.555827Z"`
+	RevokedAt  *time.Time          `json:"revokedAt"`
+	CreatedAt  time.Time           `json:"createdAt" example:"2025-10-15T12:37:42.664482Z"`
+}
+
+// newAPIKey creates a new apiKey instance.
+func newAPIKey(k *domain.APIKey) apiKey {
+	return apiKey{
+		Id:         k.Id,
+		Name:       k.Name,
+		Type:       k.KeyType,
+		Prefix:     k.Prefix,
+		Scopes:     k.Scopes,
+		ExpiresAt:  k.ExpiresAt,
+		LastUsedAt: k.LastUsedAt,
+		RevokedAt:  k.RevokedAt,
+		CreatedAt:  k.CreatedAt,
+	}
+}
+
+// APIKeysResponse represents a response when fetching API keys.
+type APIKeysResponse struct {
+	Keys []apiKey `json:"keys"`
+}
+
+// NewAPIKeysResponse creates a new APIKeysResponse instance.
+func NewAPIKeysResponse(keys []domain.APIKey) *APIKeysResponse {
+	result := make([]apiKey, 0, len(keys))
+	for _, k := range keys {
+		result = append(result, newAPIKey(&k))
+	}
+	return &APIKeysResponse{
+		Keys: result,
+	}
+}
+
+// CreatedAPIKeyResponse represents a response when creating an API key.
+//
+// Note: The secret is shown only once.
+type CreatedAPIKeyResponse struct {
+	apiKey
+	Secret string `json:"secret" example:"sk_abcdefghijkl_mzxw6ytboi4dsnrvgq3tsmjvgy"`
+}
+
+// NewCreatedAPIKeyResponse creates a new CreatedAPIKeyResponse instance.
+func NewCreatedAPIKeyResponse(created *domain.CreatedAPIKey) *CreatedAPIKeyResponse {
+	return &CreatedAPIKeyResponse{
+		apiKey: newAPIKey(created.Key),
+		Secret: created.Secret,
+	}
+}
@@ -0,0 +1,54 @@
+package response
+
+import (
+	"shop-api-go/internal/core/domain"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// auditEvent represents a response with audit event's information.
+type auditEvent struct {
+	Id         uuid.UUID                     `json:"id" example:"1bd70616-480b-47b9-91f5-292b4f4a45b1"`
+	ActorId    *uuid.UUID                    `json:"actorId" example:"6f1c2a8e-0d4b-4f7e-9a51-3c8b7e2d9f10"`
+	Action     domain.AuditAction            `json:"action" example:"user.updated"`
+	TargetType domain.AuditTargetType        `json:"targetType" example:"user"`
+	TargetId   string                        `json:"targetId" example:"1bd70616-480b-47b9-91f5-292b4f4a45b1"`
+	Changes    map[string]domain.AuditChange `json:"changes"`
+	ClientIP   string                        `json:"clientIp" example:"203.0.113.7"`
+	UserAgent  string                        `json:"userAgent" example:"curl/8.5.0"`
+	RequestId  string                        `json:"requestId" example:"0b5e4c1a-7f2d-4e8b-9c3a-1d6f8e2b4a70"`
+	CreatedAt  time.Time                     `json:"createdAt" example:"2025-10-15T12:37:42.664482Z"`
+}
+
+// newAuditEvent creates a new auditEvent instance.
+func newAuditEvent(e *domain.AuditEvent) auditEvent {
+	return auditEvent{
+		Id:         e.Id,
+		ActorId:    e.ActorId,
+		Action:     e.Action,
+		TargetType: e.TargetType,
+		TargetId:   e.TargetId,
+		Changes:    e.Changes,
+		ClientIP:   e.ClientIP,
+		UserAgent:  e.UserAgent,
+		RequestId:  e.RequestId,
+		CreatedAt:  e.CreatedAt,
+	}
+}
+
+// AuditEventsResponse represents a response when fetching audit events.
+type AuditEventsResponse struct {
+	Events []auditEvent `json:"events"`
+}
+
+// NewAuditEventsResponse creates a new AuditEventsResponse instance.
+func NewAuditEventsResponse(events []domain.AuditEvent) AuditEventsResponse {
+	result := make([]auditEvent, 0, len(events))
+	for _, e := range events {
+		result = append(result, newAuditEvent(&e))
+	}
+	return AuditEventsResponse{
+		Events: result,
+	}
+}
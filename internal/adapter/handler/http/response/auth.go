@@ -1,6 +1,9 @@
 package response
 
-import "shop-api-go/internal/core/domain"
+import (
+	"shop-api-go/internal/core/domain"
+	"time"
+)
 
 // TokensResponse represents tokens response.
 type TokensResponse struct {
@@ -15,3 +18,27 @@ func NewTokensResponse(group *domain.TokenGroup) *TokensResponse {
 		RefreshToken: group.RefreshToken,
 	}
 }
+
+// OAuthLinkResponse represents the url to link an external identity at.
+type OAuthLinkResponse struct {
+	Url string `json:"url" example:"https://accounts.google.com/o/oauth2/v2/auth?client_id=..."`
+}
+
+// NewOAuthLinkResponse creates a new OAuthLinkResponse instance.
+func NewOAuthLinkResponse(url string) *OAuthLinkResponse {
+	return &OAuthLinkResponse{Url: url}
+}
+
+// ImpersonationTokenResponse represents an impersonation token response.
+type ImpersonationTokenResponse struct {
+	AccessToken string    `json:"accessToken" example:"eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."`
+	ExpiresAt   time.Time `json:"expiresAt" example:"2025-01-01T12:15:00Z"`
+}
+
+// NewImpersonationTokenResponse creates a new ImpersonationTokenResponse instance.
+func NewImpersonationTokenResponse(token *domain.ImpersonationToken) *ImpersonationTokenResponse {
+	return &ImpersonationTokenResponse{
+		AccessToken: token.AccessToken,
+		ExpiresAt:   token.ExpiresAt,
+	}
+}
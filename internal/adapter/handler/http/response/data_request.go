@@ -0,0 +1,40 @@
+package response
+
+import (
+	"shop-api-go/internal/core/domain"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DataRequestResponse represents a response with the status of a data subject request.
+type DataRequestResponse struct {
+	Id               uuid.UUID                `json:"id" example:"6f1c2a8e-3b4d-4e5f-8a9b-0c1d2e3f4a5b"`
+	UserId           uuid.UUID                `json:"userId" example:"1bd70616-480b-47b9-91f5-292b4f4a45b1"`
+	Kind             domain.DataRequestKind   `json:"kind" example:"export"`
+	Status           domain.DataRequestStatus `json:"status" example:"completed"`
+	RequestedBy      uuid.UUID                `json:"requestedBy" example:"9a8b7c6d-5e4f-4a3b-2c1d-0e9f8a7b6c5d"`
+	Error            string                   `json:"error,omitempty"`
+	ArchiveAvailable bool                     `json:"archiveAvailable" example:"true"`
+	ArchiveExpiresAt *time.Time               `json:"archiveExpiresAt,omitempty" example:"2025-10-22T12:37:42.664482Z"`
+	CreatedAt        time.Time                `json:"createdAt" example:"2025-10-15T12:37:42.664482Z"`
+	UpdatedAt        time.Time                `json:"updatedAt" example:"2025-10-15T12:37:43.102934Z"`
+	CompletedAt      *time.Time               `json:"completedAt" example:"2025-10-15T12:37:43.102934Z"`
+}
+
+// NewDataRequestResponse creates a new DataRequestResponse instance.
+func NewDataRequestResponse(request *domain.DataRequest) *DataRequestResponse {
+	return &DataRequestResponse{
+		Id:               request.Id,
+		UserId:           request.UserId,
+		Kind:             request.Kind,
+		Status:           request.Status,
+		RequestedBy:      request.RequestedBy,
+		Error:            request.Error,
+		ArchiveAvailable: request.HasArchive,
+		ArchiveExpiresAt: request.ArchiveExpiresAt(),
+		CreatedAt:        request.CreatedAt,
+		UpdatedAt:        request.UpdatedAt,
+		CompletedAt:      request.CompletedAt,
+	}
+}
@@ -75,24 +75,292 @@ var errMap = map[error]ErrorResponse{
 		Code:       "TOKEN_NOT_FOUND",
 		Messages:   []string{"Token not found."},
 		statusCode: http.StatusNotFound,
+	}, domain.ErrMFANotEnrolled: {
+		Code:       "MFA_NOT_ENROLLED",
+		Messages:   []string{"MFA is not enrolled."},
+		statusCode: http.StatusBadRequest,
+	}, domain.ErrMFAAlreadyEnabled: {
+		Code:       "MFA_ALREADY_ENABLED",
+		Messages:   []string{"MFA is already enabled."},
+		statusCode: http.StatusConflict,
+	}, domain.ErrInvalidMFACode: {
+		Code:       "INVALID_MFA_CODE",
+		Messages:   []string{"MFA code is invalid."},
+		statusCode: http.StatusUnauthorized,
+	}, domain.ErrMFAEnrollmentRequired: {
+		Code:       "MFA_ENROLLMENT_REQUIRED",
+		Messages:   []string{"MFA must be enabled before logging in."},
+		statusCode: http.StatusForbidden,
+	}, domain.ErrTooManyLoginAttempts: {
+		Code:       "TOO_MANY_LOGIN_ATTEMPTS",
+		Messages:   []string{"Too many failed login attempts, please try again later."},
+		statusCode: http.StatusTooManyRequests,
+	}, domain.ErrRateLimitExceeded: {
+		Code:       "RATE_LIMIT_EXCEEDED",
+		Messages:   []string{"Too many requests, please try again later."},
+		statusCode: http.StatusTooManyRequests,
+	}, domain.ErrIdentityProviderNotFound: {
+		Code:       "IDENTITY_PROVIDER_NOT_FOUND",
+		Messages:   []string{"Identity provider not found."},
+		statusCode: http.StatusNotFound,
+	}, domain.ErrInvalidOAuthState: {
+		Code:       "INVALID_OAUTH_STATE",
+		Messages:   []string{"Login state is invalid or expired, please start the login again."},
+		statusCode: http.StatusBadRequest,
+	}, domain.ErrExternalAuthFailed: {
+		Code:       "EXTERNAL_AUTHENTICATION_FAILED",
+		Messages:   []string{"Identity provider did not authenticate the user."},
+		statusCode: http.StatusUnauthorized,
+	}, domain.ErrExternalEmailNotVerified: {
+		Code:       "EXTERNAL_EMAIL_NOT_VERIFIED",
+		Messages:   []string{"Identity provider did not return a verified email."},
+		statusCode: http.StatusForbidden,
+	}, domain.ErrOAuthLinkRequired: {
+		Code:       "OAUTH_LINK_REQUIRED",
+		Messages:   []string{"A user with this email exists, log in and link the identity to your account."},
+		statusCode: http.StatusConflict,
+	}, domain.ErrIdentityAlreadyLinked: {
+		Code:       "IDENTITY_ALREADY_LINKED",
+		Messages:   []string{"Identity is already linked to another user."},
+		statusCode: http.StatusConflict,
+	}, domain.ErrRoleNotFound: {
+		Code:       "ROLE_NOT_FOUND",
+		Messages:   []string{"Role not found."},
+		statusCode: http.StatusNotFound,
+	}, domain.ErrRoleAlreadyExists: {
+		Code:       "ROLE_ALREADY_EXISTS",
+		Messages:   []string{"Role already exists."},
+		statusCode: http.StatusConflict,
+	}, domain.ErrPermissionNotFound: {
+		Code:       "PERMISSION_NOT_FOUND",
+		Messages:   []string{"Permission not found."},
+		statusCode: http.StatusBadRequest,
+	}, domain.ErrBuiltInRole: {
+		Code:       "BUILT_IN_ROLE",
+		Messages:   []string{"Built-in roles cannot be changed or deleted."},
+		statusCode: http.StatusConflict,
+	}, domain.ErrRoleInUse: {
+		Code:       "ROLE_IN_USE",
+		Messages:   []string{"Role is assigned to users."},
+		statusCode: http.StatusConflict,
+	}, domain.ErrRoleNotAssignable: {
+		Code:       "ROLE_NOT_ASSIGNABLE",
+		Messages:   []string{"Cannot assign or manage a role with permissions you do not have."},
+		statusCode: http.StatusForbidden,
+	}, domain.ErrRoleLockout: {
+		Code:       "ROLE_LOCKOUT",
+		Messages:   []string{"Cannot revoke roles:write from your own role."},
+		statusCode: http.StatusConflict,
+	}, domain.ErrAPIKeyNotFound: {
+		Code:       "API_KEY_NOT_FOUND",
+		Messages:   []string{"API key not found."},
+		statusCode: http.StatusNotFound,
+	}, domain.ErrInvalidAPIKeyExpiry: {
+		Code:       "INVALID_API_KEY_EXPIRY",
+		Messages:   []string{"API key expiry must be a positive duration within the allowed maximum."},
+		statusCode: http.StatusBadRequest,
+	}, domain.ErrInsufficientScope: {
+		Code:       "INSUFFICIENT_SCOPE",
+		Messages:   []string{"API key is not scoped for this action."},
+		statusCode: http.StatusForbidden,
+	}, domain.ErrUserSuspended: {
+		Code:       "USER_SUSPENDED",
+		Messages:   []string{"User account is suspended."},
+		statusCode: http.StatusForbidden,
+	}, domain.ErrUserNotDeleted: {
+		Code:       "USER_NOT_DELETED",
+		Messages:   []string{"User is not deleted."},
+		statusCode: http.StatusConflict,
+	}, domain.ErrSelfLockout: {
+		Code:       "SELF_LOCKOUT",
+		Messages:   []string{"Cannot suspend, delete or erase your own account."},
+		statusCode: http.StatusConflict,
+	}, domain.ErrEmptyImport: {
+		Code:       "EMPTY_IMPORT",
+		Messages:   []string{"Import file contains no users."},
+		statusCode: http.StatusBadRequest,
+	}, domain.ErrImportTooLarge: {
+		Code:       "IMPORT_TOO_LARGE",
+		Messages:   []string{fmt.Sprintf("Import file must contain at most %d users.", domain.MaxUserImportRows)},
+		statusCode: http.StatusRequestEntityTooLarge,
+	}, domain.ErrUnsupportedFormat: {
+		Code:       "UNSUPPORTED_FORMAT",
+		Messages:   []string{"Format is not supported, use CSV or NDJSON."},
+		statusCode: http.StatusUnsupportedMediaType,
+	}, domain.ErrImpersonationForbidden: {
+		Code:       "IMPERSONATION_NOT_ALLOWED",
+		Messages:   []string{"Action is not allowed for impersonation."},
+		statusCode: http.StatusForbidden,
+	}, domain.ErrUserErased: {
+		Code:       "USER_ERASED",
+		Messages:   []string{"User data has been erased."},
+		statusCode: http.StatusConflict,
+	}, domain.ErrDataRequestNotFound: {
+		Code:       "DATA_REQUEST_NOT_FOUND",
+		Messages:   []string{"Data request not found."},
+		statusCode: http.StatusNotFound,
+	}, domain.ErrDataExportNotReady: {
+		Code:       "DATA_EXPORT_NOT_READY",
+		Messages:   []string{"Data request is not a completed export."},
+		statusCode: http.StatusConflict,
+	}, domain.ErrDataExportExpired: {
+		Code:       "DATA_EXPORT_EXPIRED",
+		Messages:   []string{"Data export archive has expired or was erased."},
+		statusCode: http.StatusGone,
+	}, domain.ErrProductNotFound: {
+		Code:       "PRODUCT_NOT_FOUND",
+		Messages:   []string{"Product not found."},
+		statusCode: http.StatusNotFound,
+	}, domain.ErrQueryTooDeep: {
+		Code:       "QUERY_TOO_DEEP",
+		Messages:   []string{"Query nests fields too deep."},
+		statusCode: http.StatusBadRequest,
+	}, domain.ErrQueryTooComplex: {
+		Code:       "QUERY_TOO_COMPLEX",
+		Messages:   []string{"Query is too complex, request fewer fields or smaller pages."},
+		statusCode: http.StatusBadRequest,
+	}, domain.ErrWebhookEndpointNotFound: {
+		Code:       "WEBHOOK_ENDPOINT_NOT_FOUND",
+		Messages:   []string{"Webhook endpoint not found."},
+		statusCode: http.StatusNotFound,
+	}, domain.ErrWebhookDeliveryNotFound: {
+		Code:       "WEBHOOK_DELIVERY_NOT_FOUND",
+		Messages:   []string{"Webhook delivery not found."},
+		statusCode: http.StatusNotFound,
+	}, domain.ErrInvalidWebhookUrl: {
+		Code:       "INVALID_WEBHOOK_URL",
+		Messages:   []string{"Webhook URL must use https and must not point to a private address."},
+		statusCode: http.StatusBadRequest,
+	}, domain.ErrFeatureDisabled: {
+		Code:       "FEATURE_DISABLED",
+		Messages:   []string{"This feature is currently disabled."},
+		statusCode: http.StatusForbidden,
 	},
 }
 
+// passwordViolationMessages contains the messages for each domain.PasswordViolation.
+var passwordViolationMessages = map[domain.PasswordViolation]string{
+	domain.PasswordCommon:           "Password is too common.",
+	domain.PasswordBreached:         "Password has appeared in a data breach.",
+	domain.PasswordContainsUsername: "Password must not contain the username.",
+	domain.PasswordContainsEmail:    "Password must not contain the email.",
+}
+
+// ImportErrorResponse represents a response when lines of an import file are rejected.
+type ImportErrorResponse struct {
+	Code     string           `json:"code" example:"INVALID_IMPORT"`
+	Messages []string         `json:"messages" example:"Import file contains invalid users."`
+	Rows     []importRowError `json:"rows"`
+}
+
+// importRowError represents the reasons a line of an import file was rejected.
+type importRowError struct {
+	Line     int      `json:"line" example:"2"`
+	Messages []string `json:"messages" example:"Username is already in use."`
+}
+
 // HandleError parses the error and return a proper message to the client.
 func HandleError(c *gin.Context, err error) {
-	res, ok := errMap[err]
-	if !ok {
-		res = ErrorResponse{
-			Code:       "INTERNAL_SERVER_ERROR",
-			Messages:   []string{"Server cannot process the request."},
-			statusCode: http.StatusInternalServerError,
+	var importErr *domain.UserImportError
+	if errors.As(err, &importErr) {
+		rows := make([]importRowError, 0, len(importErr.Rows))
+		for _, r := range importErr.Rows {
+			rows = append(rows, importRowError{
+				Line:     r.Line,
+				Messages: importRowMessages(r.Err),
+			})
 		}
+		c.JSON(http.StatusBadRequest, ImportErrorResponse{
+			Code:     "INVALID_IMPORT",
+			Messages: []string{"Import file contains invalid users."},
+			Rows:     rows,
+		})
+		return
 	}
+
+	res := NewErrorResponse(err)
 	c.JSON(res.statusCode, res)
 }
 
+// NewErrorResponse returns the ErrorResponse of a domain error, unknown errors are reported as internal errors.
+func NewErrorResponse(err error) ErrorResponse {
+	var policyErr *domain.PasswordPolicyError
+	if errors.As(err, &policyErr) {
+		return ErrorResponse{
+			Code:       "WEAK_PASSWORD",
+			Messages:   passwordPolicyMessages(policyErr),
+			statusCode: http.StatusBadRequest,
+		}
+	}
+	var settingsErr *domain.RuntimeSettingsError
+	if errors.As(err, &settingsErr) {
+		return ErrorResponse{
+			Code:       "INVALID_RUNTIME_SETTINGS",
+			Messages:   settingsErr.Violations,
+			statusCode: http.StatusBadRequest,
+		}
+	}
+
+	res, ok := errMap[err]
+	if !ok {
+		res = errMap[domain.ErrInternal]
+	}
+	return res
+}
+
+// NewBindingErrorResponse returns the ErrorResponse of a failed request validation.
+func NewBindingErrorResponse(err error) ErrorResponse {
+	return ErrorResponse{
+		Code:       "INVALID_ENTITY",
+		Messages:   bindingErrorMessages(err),
+		statusCode: http.StatusBadRequest,
+	}
+}
+
+// StatusCode returns the HTTP status code of the response.
+func (r ErrorResponse) StatusCode() int {
+	return r.statusCode
+}
+
+// passwordPolicyMessages returns the messages for the violations of a domain.PasswordPolicyError.
+func passwordPolicyMessages(err *domain.PasswordPolicyError) []string {
+	messages := make([]string, 0, len(err.Violations))
+	for _, v := range err.Violations {
+		messages = append(messages, passwordViolationMessages[v])
+	}
+	return messages
+}
+
+// importRowMessages returns the messages for the reason a line of an import file was rejected.
+func importRowMessages(err error) []string {
+	var policyErr *domain.PasswordPolicyError
+	if errors.As(err, &policyErr) {
+		return passwordPolicyMessages(policyErr)
+	}
+	if res, ok := errMap[err]; ok {
+		return res.Messages
+	}
+	return bindingErrorMessages(err)
+}
+
 // HandleBindingError parses the error and returns a proper message to the client.
 func HandleBindingError(c *gin.Context, err error) {
+	messages := bindingErrorMessages(err)
+
+	zap.L().Error("HTTP request error",
+		zap.Int("status", http.StatusBadRequest),
+		zap.Strings("messages", messages),
+		zap.Error(err),
+	)
+
+	c.JSON(http.StatusBadRequest, gin.H{
+		"Code":     "INVALID_ENTITY",
+		"messages": messages,
+	})
+}
+
+// bindingErrorMessages returns a message for every failed validation of a binding error.
+func bindingErrorMessages(err error) []string {
 	var validationsErrors validator.ValidationErrors
 	messages := make([]string, 0, len(validationsErrors))
 
@@ -111,10 +379,16 @@ func HandleBindingError(c *gin.Context, err error) {
 				messages = append(messages, fmt.Sprintf("%s length must be less than %s", e.Field(), e.Param()))
 			case "user_role":
 				messages = append(messages, fmt.Sprintf("%s is not a valid user role", e.Field()))
+			case "event_type":
+				messages = append(messages, fmt.Sprintf("%s is not a published event type", e.Field()))
+			case "http_url":
+				messages = append(messages, fmt.Sprintf("%s is not a valid http or https url", e.Field()))
 			case "min":
 				messages = append(messages, fmt.Sprintf("%s must be more than %s", e.Field(), e.Param()))
 			case "max":
 				messages = append(messages, fmt.Sprintf("%s must be less than %s", e.Field(), e.Param()))
+			case "oneof":
+				messages = append(messages, fmt.Sprintf("%s must be one of %s", e.Field(), e.Param()))
 			default:
 				messages = append(messages, fmt.Sprintf("%s is not a valid type", e.Field()))
 			}
@@ -122,15 +396,5 @@ func HandleBindingError(c *gin.Context, err error) {
 	} else {
 		messages = append(messages, err.Error())
 	}
-
-	zap.L().Error("HTTP request error",
-		zap.Int("status", http.StatusBadRequest),
-		zap.Strings("messages", messages),
-		zap.Error(err),
-	)
-
-	c.JSON(http.StatusBadRequest, gin.H{
-		"Code":     "INVALID_ENTITY",
-		"messages": messages,
-	})
+	return messages
 }
@@ -0,0 +1,66 @@
+package response
+
+import "shop-api-go/internal/core/domain"
+
+// MFAEnrollmentResponse represents a response when starting MFA enrolment.
+type MFAEnrollmentResponse struct {
+	Secret          string `json:"secret" example:"JBSWY3DPEHPK3PXPJBSWY3DPEHPK3PXP"`
+	ProvisioningURI string `json:"provisioningUri" example:"otpauth://totp/shop-api:MyUsername?algorithm=SHA1&digits=6&issuer=shop-api&period=30&secret=JBSWY3DPEHPK3PXPJBSWY3DPEHPK3PXP"`
+}
+
+// NewMFAEnrollmentResponse creates a new MFAEnrollmentResponse instance.
+func NewMFAEnrollmentResponse(enrollment *domain.MFAEnrollment) *MFAEnrollmentResponse {
+	return &MFAEnrollmentResponse{
+		Secret:          enrollment.Secret,
+		ProvisioningURI: enrollment.ProvisioningURI,
+	}
+}
+
+// RecoveryCodesResponse represents a response with MFA recovery codes.
+type RecoveryCodesResponse struct {
+	RecoveryCodes []string `json:"recoveryCodes" example:"abcde-fghij"`
+}
+
+// NewRecoveryCodesResponse creates a new RecoveryCodesResponse instance.
+func NewRecoveryCodesResponse(codes []string) *RecoveryCodesResponse {
+	return &RecoveryCodesResponse{
+		RecoveryCodes: codes,
+	}
+}
+
+// MFAChallengeResponse represents a response when a login requires MFA verification.
+type MFAChallengeResponse struct {
+	MFAToken string `json:"mfaToken" example:"eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."`
+}
+
+// NewMFAChallengeResponse creates a new MFAChallengeResponse instance.
+func NewMFAChallengeResponse(mfaToken string) *MFAChallengeResponse {
+	return &MFAChallengeResponse{
+		MFAToken: mfaToken,
+	}
+}
+
+// roleMFARequirement represents a response with the MFA requirement of a role.
+type roleMFARequirement struct {
+	Role     domain.UserRole `json:"role" example:"admin"`
+	Required bool            `json:"required" example:"true"`
+}
+
+// RoleMFARequirementsResponse represents a response when fetching MFA requirements.
+type RoleMFARequirementsResponse struct {
+	Requirements []roleMFARequirement `json:"requirements"`
+}
+
+// NewRoleMFARequirementsResponse creates a new RoleMFARequirementsResponse instance.
+func NewRoleMFARequirementsResponse(requirements []domain.RoleMFARequirement) *RoleMFARequirementsResponse {
+	result := make([]roleMFARequirement, 0, len(requirements))
+	for _, requirement := range requirements {
+		result = append(result, roleMFARequirement{
+			Role:     requirement.Role,
+			Required: requirement.Required,
+		})
+	}
+	return &RoleMFARequirementsResponse{
+		Requirements: result,
+	}
+}
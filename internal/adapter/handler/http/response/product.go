@@ -0,0 +1,91 @@
+package response
+
+import (
+	"shop-api-go/internal/core/domain"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// ProductResponse represents a response with a product of the catalog.
+type ProductResponse struct {
+	Id          uuid.UUID       `json:"id" example:"3c5e7a9b-1d2f-4a6b-8c0d-2e4f6a8b0c1d"`
+	Name        string          `json:"name" example:"Trail running shoes"`
+	Description string          `json:"description" example:"Lightweight shoes with a grippy outsole for muddy trails."`
+	Price       decimal.Decimal `json:"price" swaggertype:"string" example:"89.99"`
+	Rating      decimal.Decimal `json:"rating" swaggertype:"string" example:"4.5"`
+	Count       int             `json:"count" example:"12"`
+	ImageUrl    string          `json:"imageUrl" example:"https://cdn.example.com/products/trail-shoes.png"`
+	CreatedAt   time.Time       `json:"createdAt" example:"2025-10-15T12:37:42.664482Z"`
+	UpdatedAt   time.Time       `json:"updatedAt" example:"2025-10-15T12:37:42.664482Z"`
+}
+
+// NewProductResponse creates a new ProductResponse instance.
+func NewProductResponse(p *domain.Product) ProductResponse {
+	return ProductResponse{
+		Id:          p.Id,
+		Name:        p.Name,
+		Description: p.Description,
+		Price:       p.Price,
+		Rating:      p.Rating,
+		Count:       p.Count,
+		ImageUrl:    p.ImageUrl,
+		CreatedAt:   p.CreatedAt,
+		UpdatedAt:   p.UpdatedAt,
+	}
+}
+
+// CategorySectionResponse represents a response with a category section.
+type CategorySectionResponse struct {
+	Id   uuid.UUID `json:"id" example:"0a1b2c3d-4e5f-4a6b-8c7d-9e0f1a2b3c4d"`
+	Name string    `json:"name" example:"Sports"`
+}
+
+// NewCategorySectionResponse creates a new CategorySectionResponse instance.
+func NewCategorySectionResponse(s *domain.CategorySection) CategorySectionResponse {
+	return CategorySectionResponse{
+		Id:   s.Id,
+		Name: s.Name,
+	}
+}
+
+// CategoryResponse represents a response with a category, SectionId is nil for categories without a section.
+type CategoryResponse struct {
+	Id        uuid.UUID  `json:"id" example:"5d6e7f8a-9b0c-4d1e-8f2a-3b4c5d6e7f8a"`
+	Name      string     `json:"name" example:"Running"`
+	SectionId *uuid.UUID `json:"sectionId" example:"0a1b2c3d-4e5f-4a6b-8c7d-9e0f1a2b3c4d"`
+}
+
+// NewCategoryResponse creates a new CategoryResponse instance.
+func NewCategoryResponse(c *domain.Category) CategoryResponse {
+	return CategoryResponse{
+		Id:        c.Id,
+		Name:      c.Name,
+		SectionId: nilIfZero(c.SectionId),
+	}
+}
+
+// SubcategoryResponse represents a response with a subcategory, CategoryId is nil for subcategories without a category.
+type SubcategoryResponse struct {
+	Id         uuid.UUID  `json:"id" example:"7f8a9b0c-1d2e-4f3a-8b4c-5d6e7f8a9b0c"`
+	Name       string     `json:"name" example:"Shoes"`
+	CategoryId *uuid.UUID `json:"categoryId" example:"5d6e7f8a-9b0c-4d1e-8f2a-3b4c5d6e7f8a"`
+}
+
+// NewSubcategoryResponse creates a new SubcategoryResponse instance.
+func NewSubcategoryResponse(s *domain.Subcategory) SubcategoryResponse {
+	return SubcategoryResponse{
+		Id:         s.Id,
+		Name:       s.Name,
+		CategoryId: nilIfZero(s.CategoryID),
+	}
+}
+
+// nilIfZero returns nil for uuid.Nil and a pointer to the id otherwise.
+func nilIfZero(id uuid.UUID) *uuid.UUID {
+	if id == uuid.Nil {
+		return nil
+	}
+	return &id
+}
@@ -0,0 +1,57 @@
+package response
+
+import "shop-api-go/internal/core/domain"
+
+// role represents a response with a role and its permissions.
+type role struct {
+	Name        domain.UserRole     `json:"name" example:"warehouse"`
+	Description string              `json:"description" example:"Warehouse staff"`
+	BuiltIn     bool                `json:"builtIn" example:"true"`
+	Permissions []domain.Permission `json:"permissions" example:"products:write"`
+}
+
+// RolesResponse represents a response when fetching roles.
+type RolesResponse struct {
+	Roles []role `json:"roles"`
+}
+
+// NewRolesResponse creates a new RolesResponse instance.
+func NewRolesResponse(roles []domain.Role) *RolesResponse {
+	result := make([]role, 0, len(roles))
+	for _, r := range roles {
+		result = append(result, role{
+			Name:        r.Name,
+			Description: r.Description,
+			BuiltIn:     r.BuiltIn,
+			Permissions: r.Permissions,
+		})
+	}
+	return &RolesResponse{
+		Roles: result,
+	}
+}
+
+// permission represents a response with a permission that can be granted to roles.
+type permission struct {
+	Name        domain.Permission `json:"name" example:"users:read"`
+	Description string            `json:"description" example:"Read user accounts"`
+}
+
+// PermissionsResponse represents a response when fetching permissions.
+type PermissionsResponse struct {
+	Permissions []permission `json:"permissions"`
+}
+
+// NewPermissionsResponse creates a new PermissionsResponse instance.
+func NewPermissionsResponse(permissions []domain.PermissionInfo) *PermissionsResponse {
+	result := make([]permission, 0, len(permissions))
+	for _, p := range permissions {
+		result = append(result, permission{
+			Name:        p.Name,
+			Description: p.Description,
+		})
+	}
+	return &PermissionsResponse{
+		Permissions: result,
+	}
+}
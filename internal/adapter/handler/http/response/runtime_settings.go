@@ -0,0 +1,51 @@
+package response
+
+import "shop-api-go/internal/core/domain"
+
+// rateLimitPolicy represents a response with the rate limits of a route group in the requests/period format.
+type rateLimitPolicy struct {
+	Default string            `json:"default" example:"20/1m0s"`
+	Roles   map[string]string `json:"roles"`
+}
+
+// RuntimeSettingsResponse represents a response with the settings that can be changed without a restart.
+type RuntimeSettingsResponse struct {
+	LogLevel                     domain.LogLevel            `json:"logLevel" example:"info"`
+	RateLimitEnabled             bool                       `json:"rateLimitEnabled" example:"true"`
+	RateLimits                   map[string]rateLimitPolicy `json:"rateLimits"`
+	AccessTokenExpireTime        string                     `json:"accessTokenExpireTime" example:"30m0s"`
+	RefreshTokenExpireTime       string                     `json:"refreshTokenExpireTime" example:"24h0m0s"`
+	MFATokenExpireTime           string                     `json:"mfaTokenExpireTime" example:"5m0s"`
+	ImpersonationTokenExpireTime string                     `json:"impersonationTokenExpireTime" example:"15m0s"`
+	Features                     map[domain.Feature]bool    `json:"features"`
+}
+
+// NewRuntimeSettingsResponse creates a new RuntimeSettingsResponse instance.
+func NewRuntimeSettingsResponse(settings *domain.RuntimeSettings) RuntimeSettingsResponse {
+	rateLimits := make(map[string]rateLimitPolicy, len(settings.RateLimits))
+	for group, policy := range settings.RateLimits {
+		roles := make(map[string]string, len(policy.Roles))
+		for role, limit := range policy.Roles {
+			roles[string(role)] = limit.String()
+		}
+		rateLimits[group] = rateLimitPolicy{
+			Default: policy.Default.String(),
+			Roles:   roles,
+		}
+	}
+	features := make(map[domain.Feature]bool, len(domain.Features))
+	for _, feature := range domain.Features {
+		features[feature] = settings.FeatureEnabled(feature)
+	}
+
+	return RuntimeSettingsResponse{
+		LogLevel:                     settings.LogLevel,
+		RateLimitEnabled:             settings.RateLimitEnabled,
+		RateLimits:                   rateLimits,
+		AccessTokenExpireTime:        settings.AccessTokenExpireTime.String(),
+		RefreshTokenExpireTime:       settings.RefreshTokenExpireTime.String(),
+		MFATokenExpireTime:           settings.MFATokenExpireTime.String(),
+		ImpersonationTokenExpireTime: settings.ImpersonationTokenExpireTime.String(),
+		Features:                     features,
+	}
+}
@@ -0,0 +1,153 @@
+package response
+
+import (
+	"shop-api-go/internal/core/domain"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// WebhookEndpointResponse represents a response with webhook endpoint's information.
+type WebhookEndpointResponse struct {
+	Id          uuid.UUID          `json:"id" example:"3c2b1a09-8f7e-4d6c-9b5a-4e3f2a1b0c9d"`
+	Url         string             `json:"url" example:"https://partner.example.com/webhooks"`
+	Description string             `json:"description" example:"ERP integration"`
+	EventTypes  []domain.EventType `json:"eventTypes" example:"user.registered"`
+	Enabled     bool               `json:"enabled" example:"true"`
+	CreatedAt   time.Time          `json:"createdAt" example:"2025-10-15T12:37:42.664482Z"`
+	UpdatedAt   time.Time          `json:"updatedAt" example:"2025-10-15T12:37:42.664482Z"`
+}
+
+// NewWebhookEndpointResponse creates a new WebhookEndpointResponse instance.
+func NewWebhookEndpointResponse(endpoint *domain.WebhookEndpoint) WebhookEndpointResponse {
+	eventTypes := endpoint.EventTypes
+	if eventTypes == nil {
+		eventTypes = []domain.EventType{}
+	}
+	return WebhookEndpointResponse{
+		Id:          endpoint.Id,
+		Url:         endpoint.Url,
+		Description: endpoint.Description,
+		EventTypes:  eventTypes,
+		Enabled:     endpoint.Enabled,
+		CreatedAt:   endpoint.CreatedAt,
+		UpdatedAt:   endpoint.UpdatedAt,
+	}
+}
+
+// WebhookEndpointsResponse represents a response when fetching webhook endpoints.
+type WebhookEndpointsResponse struct {
+	Endpoints []WebhookEndpointResponse `json:"endpoints"`
+}
+
+// NewWebhookEndpointsResponse creates a new WebhookEndpointsResponse instance.
+func NewWebhookEndpointsResponse(endpoints []domain.WebhookEndpoint) WebhookEndpointsResponse {
+	result := make([]WebhookEndpointResponse, 0, len(endpoints))
+	for _, endpoint := range endpoints {
+		result = append(result, NewWebhookEndpointResponse(&endpoint))
+	}
+	return WebhookEndpointsResponse{
+		Endpoints: result,
+	}
+}
+
+// WebhookSecretResponse represents a response with a webhook endpoint and its signing secret.
+//
+// Note: The secret is only shown when the endpoint is created or its secret is rotated.
+type WebhookSecretResponse struct {
+	WebhookEndpointResponse
+	Secret string `json:"secret" example:"whsec_k3v9x2m7q4w8e1r5t6y0u2i3o4"`
+}
+
+// NewWebhookSecretResponse creates a new WebhookSecretResponse instance.
+func NewWebhookSecretResponse(endpoint *domain.WebhookEndpoint) WebhookSecretResponse {
+	return WebhookSecretResponse{
+		WebhookEndpointResponse: NewWebhookEndpointResponse(endpoint),
+		Secret:                  endpoint.Secret,
+	}
+}
+
+// webhookDelivery represents a response with webhook delivery's information.
+type webhookDelivery struct {
+	Id            uuid.UUID                    `json:"id" example:"9d8c7b6a-5f4e-4d3c-8b2a-1f0e9d8c7b6a"`
+	EndpointId    uuid.UUID                    `json:"endpointId" example:"3c2b1a09-8f7e-4d6c-9b5a-4e3f2a1b0c9d"`
+	EventId       uuid.UUID                    `json:"eventId" example:"6f1c2a8e-3b4d-4e5f-8a9b-0c1d2e3f4a5b"`
+	EventType     domain.EventType             `json:"eventType" example:"user.registered"`
+	Status        domain.WebhookDeliveryStatus `json:"status" example:"pending"`
+	Attempts      int                          `json:"attempts" example:"2"`
+	NextAttemptAt *time.Time                   `json:"nextAttemptAt" example:"2025-10-15T12:39:42.664482Z"`
+	LastError     string                       `json:"lastError,omitempty" example:"unexpected response status 503"`
+	CreatedAt     time.Time                    `json:"createdAt" example:"2025-10-15T12:37:42.664482Z"`
+	UpdatedAt     time.Time                    `json:"updatedAt" example:"2025-10-15T12:38:42.664482Z"`
+	DeliveredAt   *time.Time                   `json:"deliveredAt" example:"2025-10-15T12:38:42.664482Z"`
+}
+
+// newWebhookDelivery creates a new webhookDelivery instance.
+func newWebhookDelivery(d *domain.WebhookDelivery) webhookDelivery {
+	var nextAttemptAt *time.Time
+	if d.Status == domain.WebhookDeliveryPending {
+		nextAttemptAt = &d.NextAttemptAt
+	}
+	return webhookDelivery{
+		Id:            d.Id,
+		EndpointId:    d.EndpointId,
+		EventId:       d.Event.Id,
+		EventType:     d.Event.Type,
+		Status:        d.Status,
+		Attempts:      d.Attempts,
+		NextAttemptAt: nextAttemptAt,
+		LastError:     d.LastError,
+		CreatedAt:     d.CreatedAt,
+		UpdatedAt:     d.UpdatedAt,
+		DeliveredAt:   d.DeliveredAt,
+	}
+}
+
+// WebhookDeliveriesResponse represents a response when fetching the deliveries of a webhook endpoint.
+type WebhookDeliveriesResponse struct {
+	Deliveries []webhookDelivery `json:"deliveries"`
+}
+
+// NewWebhookDeliveriesResponse creates a new WebhookDeliveriesResponse instance.
+func NewWebhookDeliveriesResponse(deliveries []domain.WebhookDelivery) WebhookDeliveriesResponse {
+	result := make([]webhookDelivery, 0, len(deliveries))
+	for _, d := range deliveries {
+		result = append(result, newWebhookDelivery(&d))
+	}
+	return WebhookDeliveriesResponse{
+		Deliveries: result,
+	}
+}
+
+// webhookAttempt represents a response with a single request sent for a webhook delivery.
+type webhookAttempt struct {
+	ResponseStatus int       `json:"responseStatus" example:"503"`
+	Error          string    `json:"error,omitempty" example:"context deadline exceeded"`
+	DurationMs     int64     `json:"durationMs" example:"184"`
+	AttemptedAt    time.Time `json:"attemptedAt" example:"2025-10-15T12:38:42.664482Z"`
+}
+
+// WebhookDeliveryResponse represents a response with a webhook delivery, its event and its attempts.
+type WebhookDeliveryResponse struct {
+	webhookDelivery
+	Payload map[string]any   `json:"payload"`
+	History []webhookAttempt `json:"history"`
+}
+
+// NewWebhookDeliveryResponse creates a new WebhookDeliveryResponse instance.
+func NewWebhookDeliveryResponse(delivery *domain.WebhookDelivery, attempts []domain.WebhookAttempt) WebhookDeliveryResponse {
+	result := make([]webhookAttempt, 0, len(attempts))
+	for _, a := range attempts {
+		result = append(result, webhookAttempt{
+			ResponseStatus: a.ResponseStatus,
+			Error:          a.Error,
+			DurationMs:     a.Duration.Milliseconds(),
+			AttemptedAt:    a.AttemptedAt,
+		})
+	}
+	return WebhookDeliveryResponse{
+		webhookDelivery: newWebhookDelivery(delivery),
+		Payload:         delivery.Event.Payload,
+		History:         result,
+	}
+}
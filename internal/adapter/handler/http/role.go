@@ -0,0 +1,228 @@
+package http
+
+import (
+	"net/http"
+	"shop-api-go/internal/adapter/handler/http/request"
+	"shop-api-go/internal/adapter/handler/http/response"
+	"shop-api-go/internal/core/domain"
+	"shop-api-go/internal/core/port"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RoleHandler represent HTTP handler for role-related requests.
+type RoleHandler struct {
+	roleService port.RoleService
+}
+
+// NewRoleHandler creates a new RoleHandler instance.
+func NewRoleHandler(roleService port.RoleService) *RoleHandler {
+	return &RoleHandler{
+		roleService: roleService,
+	}
+}
+
+// GetRoles godoc
+// @Summary      Roles
+// @Description  Retrieves all roles with the permissions granted to them. Requires the roles:read permission and a valid JWT token in the Authorization header.
+// @Tags         Admin
+// @Security     BearerAuth
+// @Param        Authorization  header    string  true   "Bearer access token"
+// @Produce      json
+// @Success      200  {object}  response.RolesResponse "List of roles"
+// @Failure      401  {object}  response.ErrorResponse "Missing or invalid JWT token"
+// @Failure      403  {object}  response.ErrorResponse "Insufficient permissions or invalid token type(expected access token)"
+// @Failure      500  {object}  response.ErrorResponse "Internal server error"
+// @Router       /admin/roles [get]
+func (h *RoleHandler) GetRoles(c *gin.Context) {
+	token, ok := c.Get("token")
+	if !ok {
+		response.HandleError(c, domain.ErrInternal)
+		return
+	}
+	domainToken, ok := token.(*domain.Token)
+	if !ok {
+		response.HandleError(c, domain.ErrInternal)
+		return
+	}
+
+	roles, err := h.roleService.GetRoles(c, domainToken)
+	if err != nil {
+		response.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response.NewRolesResponse(roles))
+}
+
+// GetPermissions godoc
+// @Summary      Permissions
+// @Description  Retrieves all permissions that can be granted to roles. Requires the roles:read permission and a valid JWT token in the Authorization header.
+// @Tags         Admin
+// @Security     BearerAuth
+// @Param        Authorization  header    string  true   "Bearer access token"
+// @Produce      json
+// @Success      200  {object}  response.PermissionsResponse "List of permissions"
+// @Failure      401  {object}  response.ErrorResponse "Missing or invalid JWT token"
+// @Failure      403  {object}  response.ErrorResponse "Insufficient permissions or invalid token type(expected access token)"
+// @Failure      500  {object}  response.ErrorResponse "Internal server error"
+// @Router       /admin/permissions [get]
+func (h *RoleHandler) GetPermissions(c *gin.Context) {
+	token, ok := c.Get("token")
+	if !ok {
+		response.HandleError(c, domain.ErrInternal)
+		return
+	}
+	domainToken, ok := token.(*domain.Token)
+	if !ok {
+		response.HandleError(c, domain.ErrInternal)
+		return
+	}
+
+	permissions, err := h.roleService.GetPermissions(c, domainToken)
+	if err != nil {
+		response.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response.NewPermissionsResponse(permissions))
+}
+
+// CreateRole godoc
+// @Summary      Create role
+// @Description  Creates a custom role with the given permissions. Requires the roles:write permission and a valid JWT token in the Authorization header.
+// @Tags         Admin
+// @Security     BearerAuth
+// @Accept       json
+// @Param        Authorization  header    string                     true   "Bearer access token"
+// @Param        request        body      request.CreateRoleRequest  true   "Role to create"
+// @Success      201            {string}  string                     "Role created successfully"
+// @Failure      400            {object}  response.ErrorResponse "Invalid request payload or unknown permission"
+// @Failure      401            {object}  response.ErrorResponse "Unauthorized – invalid token"
+// @Failure      403            {object}  response.ErrorResponse "Forbidden – insufficient permissions, invalid token type(expected access token) or role with permissions the caller lacks"
+// @Failure      409            {object}  response.ErrorResponse "Role already exists"
+// @Failure      500            {object}  response.ErrorResponse "Internal server error"
+// @Router       /admin/roles [post]
+func (h *RoleHandler) CreateRole(c *gin.Context) {
+	token, ok := c.Get("token")
+	if !ok {
+		response.HandleError(c, domain.ErrInternal)
+		return
+	}
+	domainToken, ok := token.(*domain.Token)
+	if !ok {
+		response.HandleError(c, domain.ErrInternal)
+		return
+	}
+
+	var req request.CreateRoleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.HandleBindingError(c, err)
+		return
+	}
+
+	if err := h.roleService.CreateRole(
+		c,
+		domainToken,
+		domain.NewRole(req.Name, req.Description, false, req.Permissions),
+	); err != nil {
+		response.HandleError(c, err)
+		return
+	}
+
+	c.Status(http.StatusCreated)
+}
+
+// UpdateRole godoc
+// @Summary      Update role
+// @Description  Updates the description of a custom role and replaces its permissions. Requires the roles:write permission and a valid JWT token in the Authorization header.
+// @Tags         Admin
+// @Security     BearerAuth
+// @Accept       json
+// @Param        Authorization  header    string                     true   "Bearer access token"
+// @Param        role           path      string                     true   "Role name (e.g. 'warehouse')"
+// @Param        request        body      request.UpdateRoleRequest  true   "Fields to update"
+// @Success      200            {string}  string                     "Role updated successfully"
+// @Failure      400            {object}  response.ErrorResponse "Invalid request payload or unknown permission"
+// @Failure      401            {object}  response.ErrorResponse "Unauthorized – invalid token"
+// @Failure      403            {object}  response.ErrorResponse "Forbidden – insufficient permissions, invalid token type(expected access token) or role with permissions the caller lacks"
+// @Failure      404            {object}  response.ErrorResponse "Role not found"
+// @Failure      409            {object}  response.ErrorResponse "Role is built-in or update would revoke roles:write from the caller's own role"
+// @Failure      500            {object}  response.ErrorResponse "Internal server error"
+// @Router       /admin/roles/{role} [put]
+func (h *RoleHandler) UpdateRole(c *gin.Context) {
+	token, ok := c.Get("token")
+	if !ok {
+		response.HandleError(c, domain.ErrInternal)
+		return
+	}
+	domainToken, ok := token.(*domain.Token)
+	if !ok {
+		response.HandleError(c, domain.ErrInternal)
+		return
+	}
+
+	var uri request.RoleUri
+	if err := c.ShouldBindUri(&uri); err != nil {
+		response.HandleBindingError(c, err)
+		return
+	}
+
+	var req request.UpdateRoleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.HandleBindingError(c, err)
+		return
+	}
+
+	if err := h.roleService.UpdateRole(
+		c,
+		domainToken,
+		domain.NewRoleUpdate(uri.Role, req.Description, req.Permissions),
+	); err != nil {
+		response.HandleError(c, err)
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
+
+// DeleteRole godoc
+// @Summary      Delete role
+// @Description  Deletes a custom role that is not assigned to any user. Requires the roles:write permission and a valid JWT token in the Authorization header.
+// @Tags         Admin
+// @Security     BearerAuth
+// @Param        Authorization  header    string  true   "Bearer access token"
+// @Param        role           path      string  true   "Role name (e.g. 'support')"
+// @Success      204            {string}  string  "Role deleted successfully"
+// @Failure      400            {object}  response.ErrorResponse "Invalid role name"
+// @Failure      401            {object}  response.ErrorResponse "Unauthorized – invalid token"
+// @Failure      403            {object}  response.ErrorResponse "Forbidden – insufficient permissions, invalid token type(expected access token) or role with permissions the caller lacks"
+// @Failure      404            {object}  response.ErrorResponse "Role not found"
+// @Failure      409            {object}  response.ErrorResponse "Role is built-in or assigned to users"
+// @Failure      500            {object}  response.ErrorResponse "Internal server error"
+// @Router       /admin/roles/{role} [delete]
+func (h *RoleHandler) DeleteRole(c *gin.Context) {
+	token, ok := c.Get("token")
+	if !ok {
+		response.HandleError(c, domain.ErrInternal)
+		return
+	}
+	domainToken, ok := token.(*domain.Token)
+	if !ok {
+		response.HandleError(c, domain.ErrInternal)
+		return
+	}
+
+	var uri request.RoleUri
+	if err := c.ShouldBindUri(&uri); err != nil {
+		response.HandleBindingError(c, err)
+		return
+	}
+
+	if err := h.roleService.DeleteRole(c, domainToken, uri.Role); err != nil {
+		response.HandleError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
@@ -4,12 +4,13 @@ import (
 	"context"
 	"net/http"
 	"shop-api-go/internal/adapter/config"
+	"shop-api-go/internal/adapter/handler/api"
+	"shop-api-go/internal/adapter/handler/graphql"
 	"shop-api-go/internal/adapter/handler/http/middleware"
+	"shop-api-go/internal/core/domain"
 	"shop-api-go/internal/core/port"
 
 	"github.com/gin-gonic/gin"
-	"github.com/gin-gonic/gin/binding"
-	"github.com/go-playground/validator/v10"
 )
 
 type Router struct {
@@ -19,23 +20,25 @@ type Router struct {
 func NewRouter(
 	appConfig *config.AppConfig,
 	tokenGenerator port.TokenGenerator,
+	rateLimitStore port.RateLimitStore,
+	authorizer port.Authorizer,
+	apiKeyService port.APIKeyService,
+	auditService port.AuditService,
+	runtimeSettingsService port.RuntimeSettingsService,
 	userHandler *UserHandler,
 	adminHandler *AdminHandler,
 	authHandler *AuthHandler,
+	mfaHandler *MFAHandler,
+	roleHandler *RoleHandler,
+	apiKeyHandler *APIKeyHandler,
+	auditHandler *AuditHandler,
+	dataRequestHandler *DataRequestHandler,
+	webhookHandler *WebhookHandler,
+	runtimeSettingsHandler *RuntimeSettingsHandler,
+	graphQLHandler *graphql.Handler,
 ) (*Router, error) {
-	if v, ok := binding.Validator.Engine().(*validator.Validate); ok {
-		if err := v.RegisterValidation("password", validatePassword); err != nil {
-			return nil, err
-		}
-		if err := v.RegisterValidation("min_bytes", validateMinBytesLength); err != nil {
-			return nil, err
-		}
-		if err := v.RegisterValidation("max_bytes", validateMaxBytesLength); err != nil {
-			return nil, err
-		}
-		if err := v.RegisterValidation("user_role", validateUserRole); err != nil {
-			return nil, err
-		}
+	if err := api.RegisterValidations(); err != nil {
+		return nil, err
 	}
 
 	switch appConfig.Environment {
@@ -46,32 +49,142 @@ func NewRouter(
 	}
 
 	r := gin.New()
+	r.ContextWithFallback = true
+	// Without trusted proxies the client IP is the remote address, so X-Forwarded-For cannot be
+	// used to get new login throttling and rate limit buckets.
+	if err := r.SetTrustedProxies(appConfig.TrustedProxies); err != nil {
+		return nil, err
+	}
 	r.Use(gin.Recovery())
+	r.Use(middleware.RequestMetadata())
 	r.Use(middleware.ZapLogger())
-	jwtMiddleware := middleware.JWTMiddleware(tokenGenerator, "token")
+	authMiddleware := middleware.AuthMiddleware(tokenGenerator, apiKeyService, "token")
+	impersonationAudit := middleware.ImpersonationAudit(auditService, "token")
+	rateLimit := func(group config.RateLimitGroup) gin.HandlerFunc {
+		return middleware.RateLimit(rateLimitStore, runtimeSettingsService, string(group), "token")
+	}
+	requirePermission := func(permission domain.Permission) gin.HandlerFunc {
+		return middleware.RequirePermission(authorizer, permission, "token")
+	}
+	requireFeature := func(feature domain.Feature) gin.HandlerFunc {
+		return middleware.RequireFeature(runtimeSettingsService, feature)
+	}
+
+	r.POST(
+		"/api/graphql",
+		requireFeature(domain.FeatureGraphQL),
+		authMiddleware,
+		impersonationAudit,
+		rateLimit(config.UsersRateLimitGroup),
+		graphQLHandler.Serve,
+	)
 
 	v1 := r.Group("/api/v1")
 	{
+		// Rate limits key requests by the token subject if the auth middleware ran before,
+		// so only anonymous routes are limited before it, authenticated ones after it.
 		user := v1.Group("/users")
 		{
-			user.POST("/register", userHandler.Register)
-			user.PATCH("/me", userHandler.UpdateAccount)
+			anonymousUser := user.Group("", rateLimit(config.UsersRateLimitGroup))
+			{
+				anonymousUser.POST("/register", requireFeature(domain.FeatureRegistration), userHandler.Register)
+				anonymousUser.PATCH("/me", userHandler.UpdateAccount)
+			}
+
+			mfa := user.Group("/me/mfa", rateLimit(config.UsersRateLimitGroup))
+			{
+				mfa.POST("/enroll", mfaHandler.Enroll)
+				mfa.POST("/confirm", mfaHandler.ConfirmEnrollment)
+				mfa.POST("/disable", mfaHandler.Disable)
+			}
+
+			apiKey := user.Group("/me/api-keys")
+			apiKey.Use(authMiddleware, impersonationAudit, rateLimit(config.UsersRateLimitGroup))
+			{
+				apiKey.POST("", apiKeyHandler.CreatePersonalAccessToken)
+				apiKey.GET("", apiKeyHandler.GetPersonalAccessTokens)
+				apiKey.DELETE("/:id", apiKeyHandler.RevokeAPIKey)
+			}
 		}
 
 		admin := v1.Group("/admin")
-		admin.Use(jwtMiddleware)
+		admin.Use(authMiddleware, impersonationAudit, rateLimit(config.AdminRateLimitGroup))
 		{
 			adminUser := admin.Group("/users")
 			{
-				adminUser.GET("", adminHandler.GetUsers)
-				adminUser.PATCH("/:id", adminHandler.UpdateUser)
+				adminUser.GET("", requirePermission(domain.UsersRead), adminHandler.GetUsers)
+				adminUser.POST("", requirePermission(domain.UsersWrite), adminHandler.CreateUser)
+				adminUser.POST("/import", requirePermission(domain.UsersWrite), adminHandler.ImportUsers)
+				adminUser.GET("/export", requirePermission(domain.UsersRead), adminHandler.ExportUsers)
+				adminUser.PATCH("/:id", requirePermission(domain.UsersWrite), adminHandler.UpdateUser)
+				adminUser.DELETE("/:id", requirePermission(domain.UsersWrite), adminHandler.DeleteUser)
+				adminUser.POST("/:id/restore", requirePermission(domain.UsersWrite), adminHandler.RestoreUser)
+				adminUser.POST("/:id/suspend", requirePermission(domain.UsersWrite), adminHandler.SuspendUser)
+				adminUser.POST("/:id/reactivate", requirePermission(domain.UsersWrite), adminHandler.ReactivateUser)
+				adminUser.POST("/:id/unlock", requirePermission(domain.UsersWrite), adminHandler.UnlockUser)
+				adminUser.POST("/:id/impersonate", requirePermission(domain.UsersImpersonate), adminHandler.ImpersonateUser)
+				adminUser.POST("/:id/data-export", requirePermission(domain.UsersPrivacy), dataRequestHandler.RequestDataExport)
+				adminUser.POST("/:id/erasure", requirePermission(domain.UsersPrivacy), dataRequestHandler.RequestErasure)
+				adminUser.GET("/:id/api-keys", requirePermission(domain.APIKeysRead), apiKeyHandler.GetUserAPIKeys)
+				adminUser.POST("/:id/api-keys", requirePermission(domain.APIKeysWrite), apiKeyHandler.CreateServiceAPIKey)
+			}
+
+			adminMFA := admin.Group("/mfa")
+			{
+				adminMFA.GET("/roles", requirePermission(domain.RolesRead), adminHandler.GetMFARequirements)
+				adminMFA.PUT("/roles/:role", requirePermission(domain.RolesWrite), adminHandler.SetMFARequirement)
+			}
+
+			adminRole := admin.Group("/roles")
+			{
+				adminRole.GET("", requirePermission(domain.RolesRead), roleHandler.GetRoles)
+				adminRole.POST("", requirePermission(domain.RolesWrite), roleHandler.CreateRole)
+				adminRole.PUT("/:role", requirePermission(domain.RolesWrite), roleHandler.UpdateRole)
+				adminRole.DELETE("/:role", requirePermission(domain.RolesWrite), roleHandler.DeleteRole)
+			}
+			admin.GET("/permissions", requirePermission(domain.RolesRead), roleHandler.GetPermissions)
+			admin.DELETE("/api-keys/:id", requirePermission(domain.APIKeysWrite), apiKeyHandler.RevokeAPIKey)
+			admin.GET("/audit", requirePermission(domain.AuditRead), auditHandler.GetAuditEvents)
+
+			adminDataRequest := admin.Group("/data-requests")
+			{
+				adminDataRequest.GET("/:id", requirePermission(domain.UsersPrivacy), dataRequestHandler.GetDataRequest)
+				adminDataRequest.GET("/:id/archive", requirePermission(domain.UsersPrivacy), dataRequestHandler.GetDataExportArchive)
+			}
+
+			adminWebhook := admin.Group("/webhooks")
+			{
+				adminWebhook.GET("", requirePermission(domain.WebhooksRead), webhookHandler.GetWebhookEndpoints)
+				adminWebhook.POST("", requirePermission(domain.WebhooksWrite), webhookHandler.CreateWebhookEndpoint)
+				adminWebhook.GET("/:id", requirePermission(domain.WebhooksRead), webhookHandler.GetWebhookEndpoint)
+				adminWebhook.PATCH("/:id", requirePermission(domain.WebhooksWrite), webhookHandler.UpdateWebhookEndpoint)
+				adminWebhook.DELETE("/:id", requirePermission(domain.WebhooksWrite), webhookHandler.DeleteWebhookEndpoint)
+				adminWebhook.POST("/:id/secret", requirePermission(domain.WebhooksWrite), webhookHandler.RotateWebhookSecret)
+				adminWebhook.GET("/:id/deliveries", requirePermission(domain.WebhooksRead), webhookHandler.GetWebhookDeliveries)
+			}
+
+			adminWebhookDelivery := admin.Group("/webhook-deliveries")
+			{
+				adminWebhookDelivery.GET("/:id", requirePermission(domain.WebhooksRead), webhookHandler.GetWebhookDelivery)
+				adminWebhookDelivery.POST("/:id/replay", requirePermission(domain.WebhooksWrite), webhookHandler.ReplayWebhookDelivery)
 			}
+
+			admin.GET("/settings", requirePermission(domain.SettingsRead), runtimeSettingsHandler.GetRuntimeSettings)
+			admin.PATCH("/settings", requirePermission(domain.SettingsWrite), runtimeSettingsHandler.UpdateRuntimeSettings)
 		}
 
 		auth := v1.Group("/auth")
 		{
-			auth.POST("/login", authHandler.Login)
-			auth.POST("/refresh", jwtMiddleware, authHandler.RefreshSession)
+			anonymousAuth := auth.Group("", rateLimit(config.AuthRateLimitGroup))
+			{
+				anonymousAuth.POST("/login", authHandler.Login)
+				anonymousAuth.GET("/oauth/:provider/login", requireFeature(domain.FeatureOAuthLogin), authHandler.OAuthLogin)
+				anonymousAuth.GET("/oauth/:provider/callback", requireFeature(domain.FeatureOAuthLogin), authHandler.OAuthCallback)
+			}
+
+			auth.POST("/oauth/:provider/link", authMiddleware, impersonationAudit, rateLimit(config.AuthRateLimitGroup), requireFeature(domain.FeatureOAuthLogin), authHandler.OAuthLink)
+			auth.POST("/refresh", authMiddleware, impersonationAudit, rateLimit(config.AuthRateLimitGroup), authHandler.RefreshSession)
+			auth.POST("/mfa/verify", authMiddleware, impersonationAudit, rateLimit(config.AuthRateLimitGroup), authHandler.VerifyMFA)
 		}
 
 	}
@@ -0,0 +1,143 @@
+package http
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"shop-api-go/internal/adapter/config"
+	"shop-api-go/internal/adapter/storage/memory"
+	"shop-api-go/internal/core/domain"
+	"shop-api-go/internal/core/port/mock"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+// routerMocks contains the mocked dependencies of the router used by the tests.
+type routerMocks struct {
+	tokenGenerator         *mock.MockTokenGenerator
+	apiKeyService          *mock.MockAPIKeyService
+	authService            *mock.MockAuthService
+	runtimeSettingsService *mock.MockRuntimeSettingsService
+}
+
+// newTestRouter creates a Router with every rate limit group allowing a single request per minute.
+func newTestRouter(t *testing.T, appConfig *config.AppConfig) (*Router, *routerMocks) {
+	t.Helper()
+	ctrl := gomock.NewController(t)
+	m := &routerMocks{
+		tokenGenerator:         mock.NewMockTokenGenerator(ctrl),
+		apiKeyService:          mock.NewMockAPIKeyService(ctrl),
+		authService:            mock.NewMockAuthService(ctrl),
+		runtimeSettingsService: mock.NewMockRuntimeSettingsService(ctrl),
+	}
+	policy := domain.RateLimitPolicy{
+		Default: domain.NewRateLimit(1, time.Minute),
+		Roles:   map[domain.UserRole]domain.RateLimit{domain.Admin: domain.NewRateLimit(2, time.Minute)},
+	}
+	m.runtimeSettingsService.
+		EXPECT().
+		Current().
+		Return(&domain.RuntimeSettings{
+			RateLimitEnabled: true,
+			RateLimits: map[string]domain.RateLimitPolicy{
+				string(config.UsersRateLimitGroup): policy,
+				string(config.AuthRateLimitGroup):  policy,
+				string(config.AdminRateLimitGroup): policy,
+			},
+		}).
+		AnyTimes()
+
+	router, err := NewRouter(
+		appConfig,
+		m.tokenGenerator,
+		memory.NewRateLimitStore(),
+		mock.NewMockAuthorizer(ctrl),
+		m.apiKeyService,
+		mock.NewMockAuditService(ctrl),
+		m.runtimeSettingsService,
+		NewUserHandler(mock.NewMockUserService(ctrl)),
+		NewAdminHandler(mock.NewMockAdminService(ctrl)),
+		NewAuthHandler(m.authService),
+		NewMFAHandler(mock.NewMockMFAService(ctrl)),
+		NewRoleHandler(mock.NewMockRoleService(ctrl)),
+		NewAPIKeyHandler(m.apiKeyService),
+		NewAuditHandler(mock.NewMockAuditService(ctrl)),
+		NewDataRequestHandler(mock.NewMockDataRequestService(ctrl)),
+		NewWebhookHandler(mock.NewMockWebhookService(ctrl)),
+		NewRuntimeSettingsHandler(m.runtimeSettingsService),
+		nil,
+	)
+	require.NoError(t, err)
+	return router, m
+}
+
+// serve sends the request to the router from the remote address and returns the status code.
+func (r *Router) serve(request *http.Request, remoteAddr string) int {
+	request.RemoteAddr = remoteAddr
+	recorder := httptest.NewRecorder()
+	r.server.Handler.ServeHTTP(recorder, request)
+	return recorder.Code
+}
+
+func TestRouter_TrustedProxies(t *testing.T) {
+	login := func(forwardedFor string) *http.Request {
+		body := strings.NewReader(`{"username":"username","password":"password"}`)
+		request := httptest.NewRequest(http.MethodPost, "/api/v1/auth/login", body)
+		request.Header.Set("Content-Type", "application/json")
+		request.Header.Set("X-Forwarded-For", forwardedFor)
+		return request
+	}
+	expectLogin := func(m *routerMocks, times int) {
+		m.authService.
+			EXPECT().
+			Login(gomock.Any(), gomock.AssignableToTypeOf(&domain.User{})).
+			Return(nil, domain.ErrWrongCredentials).
+			Times(times)
+	}
+
+	t.Run("forwarded for header of clients is ignored", func(t *testing.T) {
+		router, m := newTestRouter(t, &config.AppConfig{Environment: config.Production})
+		expectLogin(m, 1)
+
+		require.Equal(t, http.StatusUnauthorized, router.serve(login("203.0.113.1"), "198.51.100.7:1234"))
+		require.Equal(t, http.StatusTooManyRequests, router.serve(login("203.0.113.2"), "198.51.100.7:1234"))
+	})
+
+	t.Run("forwarded for header of trusted proxies is used", func(t *testing.T) {
+		router, m := newTestRouter(t, &config.AppConfig{Environment: config.Production, TrustedProxies: []string{"10.0.0.0/8"}})
+		expectLogin(m, 2)
+
+		require.Equal(t, http.StatusUnauthorized, router.serve(login("203.0.113.1"), "10.0.0.1:1234"))
+		require.Equal(t, http.StatusUnauthorized, router.serve(login("203.0.113.2"), "10.0.0.1:1234"))
+		require.Equal(t, http.StatusTooManyRequests, router.serve(login("203.0.113.2"), "10.0.0.2:1234"))
+	})
+}
+
+func TestRouter_AuthenticatedRateLimit(t *testing.T) {
+	router, m := newTestRouter(t, &config.AppConfig{Environment: config.Production})
+	admin := &domain.Token{UserId: uuid.New(), TokenType: domain.AccessToken, UserRole: domain.Admin}
+	client := &domain.Token{UserId: uuid.New(), TokenType: domain.AccessToken, UserRole: domain.Client}
+	m.tokenGenerator.EXPECT().ParseToken("admin.access.token").Return(admin, nil).Times(3)
+	m.tokenGenerator.EXPECT().ParseToken("client.access.token").Return(client, nil).Times(2)
+	m.apiKeyService.
+		EXPECT().
+		GetAPIKeys(gomock.Any(), gomock.AssignableToTypeOf(&domain.Token{}), gomock.AssignableToTypeOf(uuid.UUID{})).
+		Return([]domain.APIKey{}, nil).
+		Times(3)
+	apiKeys := func(token string) *http.Request {
+		request := httptest.NewRequest(http.MethodGet, "/api/v1/users/me/api-keys", nil)
+		request.Header.Set("Authorization", "Bearer "+token)
+		return request
+	}
+
+	// Both users share the IP, the admin role has a limit of 2 requests and other roles of 1.
+	require.Equal(t, http.StatusOK, router.serve(apiKeys("admin.access.token"), "198.51.100.7:1234"))
+	require.Equal(t, http.StatusOK, router.serve(apiKeys("admin.access.token"), "198.51.100.7:1234"))
+	require.Equal(t, http.StatusTooManyRequests, router.serve(apiKeys("admin.access.token"), "198.51.100.7:1234"))
+	require.Equal(t, http.StatusOK, router.serve(apiKeys("client.access.token"), "198.51.100.7:1234"))
+	require.Equal(t, http.StatusTooManyRequests, router.serve(apiKeys("client.access.token"), "198.51.100.7:1234"))
+}
@@ -0,0 +1,165 @@
+package http
+
+import (
+	"fmt"
+	"maps"
+	"net/http"
+	"shop-api-go/internal/adapter/handler/http/request"
+	"shop-api-go/internal/adapter/handler/http/response"
+	"shop-api-go/internal/core/domain"
+	"shop-api-go/internal/core/port"
+	"slices"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RuntimeSettingsHandler represent HTTP handler for runtime settings requests.
+type RuntimeSettingsHandler struct {
+	runtimeSettingsService port.RuntimeSettingsService
+}
+
+// NewRuntimeSettingsHandler creates a new RuntimeSettingsHandler instance.
+func NewRuntimeSettingsHandler(runtimeSettingsService port.RuntimeSettingsService) *RuntimeSettingsHandler {
+	return &RuntimeSettingsHandler{
+		runtimeSettingsService: runtimeSettingsService,
+	}
+}
+
+// GetRuntimeSettings godoc
+// @Summary      Runtime settings
+// @Description  Retrieves the settings that can be changed without a restart: the log level, rate limits, token expire times and feature flags. Requires the settings:read permission and a valid JWT token in the Authorization header.
+// @Tags         Admin
+// @Security     BearerAuth
+// @Param        Authorization  header    string  true   "Bearer access token"
+// @Produce      json
+// @Success      200  {object}  response.RuntimeSettingsResponse "Current runtime settings"
+// @Failure      401  {object}  response.ErrorResponse "Missing or invalid JWT token"
+// @Failure      403  {object}  response.ErrorResponse "Insufficient permissions or invalid token type(expected access token)"
+// @Failure      500  {object}  response.ErrorResponse "Internal server error"
+// @Router       /admin/settings [get]
+func (h *RuntimeSettingsHandler) GetRuntimeSettings(c *gin.Context) {
+	token, ok := c.Get("token")
+	if !ok {
+		response.HandleError(c, domain.ErrInternal)
+		return
+	}
+	domainToken, ok := token.(*domain.Token)
+	if !ok {
+		response.HandleError(c, domain.ErrInternal)
+		return
+	}
+
+	settings, err := h.runtimeSettingsService.GetSettings(c, domainToken)
+	if err != nil {
+		response.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response.NewRuntimeSettingsResponse(settings))
+}
+
+// UpdateRuntimeSettings godoc
+// @Summary      Update runtime settings
+// @Description  Changes the log level, rate limits, token expire times or feature flags without a restart. The change is validated, audited, written to the runtime settings file and applied right away, token expire times apply to tokens issued afterwards. Requires the settings:write permission and a valid JWT token in the Authorization header.
+// @Tags         Admin
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        Authorization  header    string                                true   "Bearer access token"
+// @Param        request        body      request.UpdateRuntimeSettingsRequest  true   "Settings to change"
+// @Success      200            {object}  response.RuntimeSettingsResponse "Updated runtime settings"
+// @Failure      400            {object}  response.ErrorResponse "Invalid request payload, invalid settings or no fields to update"
+// @Failure      401            {object}  response.ErrorResponse "Unauthorized – invalid token"
+// @Failure      403            {object}  response.ErrorResponse "Forbidden – insufficient permissions or invalid token type(expected access token)"
+// @Failure      500            {object}  response.ErrorResponse "Internal server error"
+// @Router       /admin/settings [patch]
+func (h *RuntimeSettingsHandler) UpdateRuntimeSettings(c *gin.Context) {
+	token, ok := c.Get("token")
+	if !ok {
+		response.HandleError(c, domain.ErrInternal)
+		return
+	}
+	domainToken, ok := token.(*domain.Token)
+	if !ok {
+		response.HandleError(c, domain.ErrInternal)
+		return
+	}
+
+	var req request.UpdateRuntimeSettingsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.HandleBindingError(c, err)
+		return
+	}
+
+	update, err := newRuntimeSettingsUpdate(&req)
+	if err != nil {
+		response.HandleError(c, err)
+		return
+	}
+
+	settings, err := h.runtimeSettingsService.UpdateSettings(c, domainToken, update)
+	if err != nil {
+		response.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response.NewRuntimeSettingsResponse(settings))
+}
+
+// newRuntimeSettingsUpdate parses the rate limits and expire times of the request,
+// all values that cannot be parsed are reported at once.
+func newRuntimeSettingsUpdate(req *request.UpdateRuntimeSettingsRequest) (*domain.RuntimeSettingsUpdate, error) {
+	var violations []string
+	update := &domain.RuntimeSettingsUpdate{
+		LogLevel:         req.LogLevel,
+		RateLimitEnabled: req.RateLimitEnabled,
+		Features:         req.Features,
+	}
+
+	if len(req.RateLimits) > 0 {
+		update.RateLimits = make(map[string]domain.RateLimitPolicy, len(req.RateLimits))
+	}
+	for _, group := range slices.Sorted(maps.Keys(req.RateLimits)) {
+		policy := req.RateLimits[group]
+		limit, err := domain.ParseRateLimit(policy.Default)
+		if err != nil {
+			violations = append(violations, fmt.Sprintf("rateLimits.%s.default: %s", group, err))
+		}
+		roles := make(map[domain.UserRole]domain.RateLimit, len(policy.Roles))
+		for _, role := range slices.Sorted(maps.Keys(policy.Roles)) {
+			roleLimit, err := domain.ParseRateLimit(policy.Roles[role])
+			if err != nil {
+				violations = append(violations, fmt.Sprintf("rateLimits.%s.roles.%s: %s", group, role, err))
+			}
+			roles[domain.UserRole(role)] = roleLimit
+		}
+		update.RateLimits[group] = domain.RateLimitPolicy{Default: limit, Roles: roles}
+	}
+
+	for _, expireTime := range []struct {
+		field  string
+		value  *string
+		target **time.Duration
+	}{
+		{"accessTokenExpireTime", req.AccessTokenExpireTime, &update.AccessTokenExpireTime},
+		{"refreshTokenExpireTime", req.RefreshTokenExpireTime, &update.RefreshTokenExpireTime},
+		{"mfaTokenExpireTime", req.MFATokenExpireTime, &update.MFATokenExpireTime},
+		{"impersonationTokenExpireTime", req.ImpersonationTokenExpireTime, &update.ImpersonationTokenExpireTime},
+	} {
+		if expireTime.value == nil {
+			continue
+		}
+		d, err := time.ParseDuration(*expireTime.value)
+		if err != nil {
+			violations = append(violations, fmt.Sprintf("%s must be a duration: %q", expireTime.field, *expireTime.value))
+			continue
+		}
+		*expireTime.target = &d
+	}
+
+	if len(violations) > 0 {
+		return nil, domain.NewRuntimeSettingsError(violations)
+	}
+	return update, nil
+}
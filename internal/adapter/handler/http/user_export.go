@@ -0,0 +1,120 @@
+package http
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"net/http"
+	"shop-api-go/internal/adapter/handler/http/response"
+	"shop-api-go/internal/core/domain"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// exportFlushInterval is the number of users written between flushes of an export to the client.
+const exportFlushInterval = 100
+
+// userExportWriter streams exported users to the client as CSV or NDJSON.
+//
+// Note: The response status and headers are written with the first user, so errors that occur
+// before it are still returned as a proper error response.
+type userExportWriter struct {
+	c       *gin.Context
+	format  string
+	csv     *csv.Writer
+	encoder *json.Encoder
+	started bool
+	written int
+}
+
+// newUserExportWriter creates a new userExportWriter instance.
+func newUserExportWriter(c *gin.Context, format string) *userExportWriter {
+	return &userExportWriter{
+		c:      c,
+		format: format,
+	}
+}
+
+// start writes the response status and headers and, for CSV, the header row.
+func (w *userExportWriter) start() error {
+	w.started = true
+	contentType, extension := "text/csv", "csv"
+	if w.format == "ndjson" {
+		contentType, extension = "application/x-ndjson", "ndjson"
+	}
+
+	w.c.Header("Content-Type", contentType)
+	w.c.Header("Content-Disposition", `attachment; filename="users.`+extension+`"`)
+	w.c.Status(http.StatusOK)
+
+	if w.format == "ndjson" {
+		w.encoder = json.NewEncoder(w.c.Writer)
+		return nil
+	}
+	w.csv = csv.NewWriter(w.c.Writer)
+	return w.csv.Write(response.UserExportHeader)
+}
+
+// write writes a user and periodically flushes the response.
+func (w *userExportWriter) write(user *domain.User) error {
+	if !w.started {
+		if err := w.start(); err != nil {
+			return err
+		}
+	}
+
+	var err error
+	if w.encoder != nil {
+		err = w.encoder.Encode(response.NewUserExportLine(user))
+	} else {
+		err = w.csv.Write(response.NewUserExportRecord(user))
+	}
+	if err != nil {
+		return err
+	}
+
+	w.written++
+	if w.written%exportFlushInterval == 0 {
+		return w.flush()
+	}
+	return nil
+}
+
+// flush sends the buffered users to the client.
+func (w *userExportWriter) flush() error {
+	if w.csv != nil {
+		w.csv.Flush()
+		if err := w.csv.Error(); err != nil {
+			return err
+		}
+	}
+	w.c.Writer.Flush()
+	return nil
+}
+
+// close finishes the export, writing the headers if no user was exported.
+func (w *userExportWriter) close() error {
+	if !w.started {
+		if err := w.start(); err != nil {
+			return err
+		}
+	}
+	return w.flush()
+}
+
+// fail reports an error of the export. Once the export started, the status cannot be changed,
+// so the error is logged and the truncated response is aborted.
+func (w *userExportWriter) fail(err error) {
+	if !w.started {
+		response.HandleError(w.c, err)
+		return
+	}
+
+	zap.L().
+		Error(
+			"exporting users failed",
+			zap.Int("written", w.written),
+			zap.Error(err),
+		)
+	w.c.Abort()
+}
@@ -0,0 +1,164 @@
+package http
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"shop-api-go/internal/adapter/handler/http/request"
+	"shop-api-go/internal/core/domain"
+	"strings"
+
+	"github.com/gin-gonic/gin/binding"
+)
+
+// maxUserImportBytes is the maximum size of a user import file.
+const maxUserImportBytes = 1 << 20
+
+// importColumns are the required columns of a CSV user import.
+var importColumns = []string{"email", "username", "password", "role"}
+
+// parseUserImport parses a CSV or NDJSON user import file depending on the content type
+// and validates every user with the binding rules of request.ImportUser.
+//
+// Note: Lines that cannot be parsed or fail validation are returned as a domain.UserImportError.
+func parseUserImport(contentType string, r io.Reader) ([]domain.UserImportRow, error) {
+	var (
+		rows      []domain.UserImportRow
+		rowErrors []domain.UserImportRowError
+	)
+	addRow := func(line int, user *request.ImportUser, err error) error {
+		if len(rows)+len(rowErrors) >= domain.MaxUserImportRows {
+			return domain.ErrImportTooLarge
+		}
+		if err == nil {
+			err = binding.Validator.ValidateStruct(user)
+		}
+		if err != nil {
+			rowErrors = append(rowErrors, domain.UserImportRowError{Line: line, Err: err})
+			return nil
+		}
+
+		rows = append(rows, *domain.NewUserImportRow(line, domain.User{
+			Email:    user.Email,
+			Username: user.Username,
+			Password: user.Password,
+			Role:     user.Role,
+		}))
+		return nil
+	}
+
+	var err error
+	switch contentType {
+	case "text/csv":
+		err = parseCSVUsers(r, addRow)
+	case "application/x-ndjson", "application/ndjson":
+		err = parseNDJSONUsers(r, addRow)
+	default:
+		return nil, domain.ErrUnsupportedFormat
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if len(rowErrors) > 0 {
+		return nil, domain.NewUserImportError(rowErrors)
+	}
+	if len(rows) == 0 {
+		return nil, domain.ErrEmptyImport
+	}
+	return rows, nil
+}
+
+// parseCSVUsers reads a CSV file with a header containing importColumns in any order
+// and calls addRow for every record.
+func parseCSVUsers(r io.Reader, addRow func(line int, user *request.ImportUser, err error) error) error {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if errors.Is(err, io.EOF) {
+		return domain.ErrEmptyImport
+	} else if err != nil {
+		return importReadError(err)
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, column := range header {
+		columns[strings.ToLower(strings.TrimSpace(column))] = i
+	}
+	for _, column := range importColumns {
+		if _, ok := columns[column]; !ok {
+			return domain.NewUserImportError([]domain.UserImportRowError{
+				{Line: 1, Err: fmt.Errorf("missing column %q", column)},
+			})
+		}
+	}
+
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+
+		var parseErr *csv.ParseError
+		if errors.As(err, &parseErr) {
+			if err = addRow(parseErr.Line, nil, parseErr.Err); err != nil {
+				return err
+			}
+			continue
+		} else if err != nil {
+			return importReadError(err)
+		}
+
+		line, _ := reader.FieldPos(0)
+		if err = addRow(line, &request.ImportUser{
+			Email:    record[columns["email"]],
+			Username: record[columns["username"]],
+			Password: record[columns["password"]],
+			Role:     domain.UserRole(record[columns["role"]]),
+		}, nil); err != nil {
+			return err
+		}
+	}
+}
+
+// parseNDJSONUsers reads a file with a JSON object per line and calls addRow for every non-empty line.
+func parseNDJSONUsers(r io.Reader, addRow func(line int, user *request.ImportUser, err error) error) error {
+	scanner := bufio.NewScanner(r)
+	line := 0
+	for scanner.Scan() {
+		line++
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" {
+			continue
+		}
+
+		var user request.ImportUser
+		if err := addRow(line, &user, json.Unmarshal([]byte(text), &user)); err != nil {
+			return err
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return importReadError(err)
+	}
+	return nil
+}
+
+// importReadError maps errors of reading an import file to domain errors.
+func importReadError(err error) error {
+	var maxBytesErr *http.MaxBytesError
+	if errors.As(err, &maxBytesErr) || errors.Is(err, bufio.ErrTooLong) {
+		return domain.ErrImportTooLarge
+	}
+
+	var parseErr *csv.ParseError
+	if errors.As(err, &parseErr) {
+		return domain.NewUserImportError([]domain.UserImportRowError{{Line: parseErr.Line, Err: parseErr.Err}})
+	}
+	return err
+}
@@ -0,0 +1,418 @@
+package http
+
+import (
+	"net/http"
+	"shop-api-go/internal/adapter/handler/http/request"
+	"shop-api-go/internal/adapter/handler/http/response"
+	"shop-api-go/internal/core/domain"
+	"shop-api-go/internal/core/port"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// WebhookHandler represent HTTP handler for webhook-related requests.
+type WebhookHandler struct {
+	webhookService port.WebhookService
+}
+
+// NewWebhookHandler creates a new WebhookHandler instance.
+func NewWebhookHandler(webhookService port.WebhookService) *WebhookHandler {
+	return &WebhookHandler{
+		webhookService: webhookService,
+	}
+}
+
+// GetWebhookEndpoints godoc
+// @Summary      Webhook endpoints
+// @Description  Retrieves all webhook endpoints without their secrets. Requires the webhooks:read permission and a valid JWT token in the Authorization header.
+// @Tags         Admin
+// @Security     BearerAuth
+// @Param        Authorization  header    string  true   "Bearer access token"
+// @Produce      json
+// @Success      200  {object}  response.WebhookEndpointsResponse "List of webhook endpoints"
+// @Failure      401  {object}  response.ErrorResponse "Missing or invalid JWT token"
+// @Failure      403  {object}  response.ErrorResponse "Insufficient permissions or invalid token type(expected access token)"
+// @Failure      500  {object}  response.ErrorResponse "Internal server error"
+// @Router       /admin/webhooks [get]
+func (h *WebhookHandler) GetWebhookEndpoints(c *gin.Context) {
+	token, ok := c.Get("token")
+	if !ok {
+		response.HandleError(c, domain.ErrInternal)
+		return
+	}
+	domainToken, ok := token.(*domain.Token)
+	if !ok {
+		response.HandleError(c, domain.ErrInternal)
+		return
+	}
+
+	endpoints, err := h.webhookService.GetEndpoints(c, domainToken)
+	if err != nil {
+		response.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response.NewWebhookEndpointsResponse(endpoints))
+}
+
+// GetWebhookEndpoint godoc
+// @Summary      Webhook endpoint
+// @Description  Retrieves a webhook endpoint without its secret. Requires the webhooks:read permission and a valid JWT token in the Authorization header.
+// @Tags         Admin
+// @Security     BearerAuth
+// @Param        Authorization  header    string  true   "Bearer access token"
+// @Param        id             path      string  true   "Webhook endpoint ID (UUID)"
+// @Produce      json
+// @Success      200            {object}  response.WebhookEndpointResponse "Webhook endpoint"
+// @Failure      400            {object}  response.ErrorResponse "Invalid webhook endpoint id"
+// @Failure      401            {object}  response.ErrorResponse "Unauthorized – invalid token"
+// @Failure      403            {object}  response.ErrorResponse "Forbidden – insufficient permissions or invalid token type(expected access token)"
+// @Failure      404            {object}  response.ErrorResponse "Webhook endpoint not found"
+// @Failure      500            {object}  response.ErrorResponse "Internal server error"
+// @Router       /admin/webhooks/{id} [get]
+func (h *WebhookHandler) GetWebhookEndpoint(c *gin.Context) {
+	token, ok := c.Get("token")
+	if !ok {
+		response.HandleError(c, domain.ErrInternal)
+		return
+	}
+	domainToken, ok := token.(*domain.Token)
+	if !ok {
+		response.HandleError(c, domain.ErrInternal)
+		return
+	}
+
+	parsedId, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.HandleError(c, domain.ErrInvalidUUID)
+		return
+	}
+
+	endpoint, err := h.webhookService.GetEndpoint(c, domainToken, parsedId)
+	if err != nil {
+		response.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response.NewWebhookEndpointResponse(endpoint))
+}
+
+// CreateWebhookEndpoint godoc
+// @Summary      Create webhook endpoint
+// @Description  Creates a webhook endpoint receiving the given event types, or every event if none is given. The response contains the secret used to sign the deliveries, it is only shown once. Requires the webhooks:write permission and a valid JWT token in the Authorization header.
+// @Tags         Admin
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        Authorization  header    string                                true   "Bearer access token"
+// @Param        request        body      request.CreateWebhookEndpointRequest  true   "Webhook endpoint to create"
+// @Success      201            {object}  response.WebhookSecretResponse "Created webhook endpoint with its secret"
+// @Failure      400            {object}  response.ErrorResponse "Invalid request payload, unknown event type or URL without https or with a private address"
+// @Failure      401            {object}  response.ErrorResponse "Unauthorized – invalid token"
+// @Failure      403            {object}  response.ErrorResponse "Forbidden – insufficient permissions or invalid token type(expected access token)"
+// @Failure      500            {object}  response.ErrorResponse "Internal server error"
+// @Router       /admin/webhooks [post]
+func (h *WebhookHandler) CreateWebhookEndpoint(c *gin.Context) {
+	token, ok := c.Get("token")
+	if !ok {
+		response.HandleError(c, domain.ErrInternal)
+		return
+	}
+	domainToken, ok := token.(*domain.Token)
+	if !ok {
+		response.HandleError(c, domain.ErrInternal)
+		return
+	}
+
+	var req request.CreateWebhookEndpointRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.HandleBindingError(c, err)
+		return
+	}
+
+	endpoint, err := h.webhookService.CreateEndpoint(
+		c,
+		domainToken,
+		domain.NewWebhookEndpoint(req.Url, req.Description, req.EventTypes),
+	)
+	if err != nil {
+		response.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, response.NewWebhookSecretResponse(endpoint))
+}
+
+// UpdateWebhookEndpoint godoc
+// @Summary      Update webhook endpoint
+// @Description  Updates the url, description, event types or enabled flag of a webhook endpoint. Deliveries of a disabled endpoint are kept and sent once it is enabled again. Requires the webhooks:write permission and a valid JWT token in the Authorization header.
+// @Tags         Admin
+// @Security     BearerAuth
+// @Accept       json
+// @Produce      json
+// @Param        Authorization  header    string                                true   "Bearer access token"
+// @Param        id             path      string                                true   "Webhook endpoint ID (UUID)"
+// @Param        request        body      request.UpdateWebhookEndpointRequest  true   "Fields to update"
+// @Success      200            {object}  response.WebhookEndpointResponse "Updated webhook endpoint"
+// @Failure      400            {object}  response.ErrorResponse "Invalid request payload, unknown event type, URL without https or with a private address or no fields to update"
+// @Failure      401            {object}  response.ErrorResponse "Unauthorized – invalid token"
+// @Failure      403            {object}  response.ErrorResponse "Forbidden – insufficient permissions or invalid token type(expected access token)"
+// @Failure      404            {object}  response.ErrorResponse "Webhook endpoint not found"
+// @Failure      500            {object}  response.ErrorResponse "Internal server error"
+// @Router       /admin/webhooks/{id} [patch]
+func (h *WebhookHandler) UpdateWebhookEndpoint(c *gin.Context) {
+	token, ok := c.Get("token")
+	if !ok {
+		response.HandleError(c, domain.ErrInternal)
+		return
+	}
+	domainToken, ok := token.(*domain.Token)
+	if !ok {
+		response.HandleError(c, domain.ErrInternal)
+		return
+	}
+
+	parsedId, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.HandleError(c, domain.ErrInvalidUUID)
+		return
+	}
+
+	var req request.UpdateWebhookEndpointRequest
+	if err = c.ShouldBindJSON(&req); err != nil {
+		response.HandleBindingError(c, err)
+		return
+	}
+
+	endpoint, err := h.webhookService.UpdateEndpoint(
+		c,
+		domainToken,
+		domain.NewWebhookEndpointUpdate(parsedId, req.Url, req.Description, req.EventTypes, req.Enabled),
+	)
+	if err != nil {
+		response.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response.NewWebhookEndpointResponse(endpoint))
+}
+
+// RotateWebhookSecret godoc
+// @Summary      Rotate webhook secret
+// @Description  Replaces the secret used to sign the deliveries of a webhook endpoint, deliveries are signed with the new secret right away. The response contains the new secret, it is only shown once. Requires the webhooks:write permission and a valid JWT token in the Authorization header.
+// @Tags         Admin
+// @Security     BearerAuth
+// @Produce      json
+// @Param        Authorization  header    string  true   "Bearer access token"
+// @Param        id             path      string  true   "Webhook endpoint ID (UUID)"
+// @Success      200            {object}  response.WebhookSecretResponse "Webhook endpoint with its new secret"
+// @Failure      400            {object}  response.ErrorResponse "Invalid webhook endpoint id"
+// @Failure      401            {object}  response.ErrorResponse "Unauthorized – invalid token"
+// @Failure      403            {object}  response.ErrorResponse "Forbidden – insufficient permissions or invalid token type(expected access token)"
+// @Failure      404            {object}  response.ErrorResponse "Webhook endpoint not found"
+// @Failure      500            {object}  response.ErrorResponse "Internal server error"
+// @Router       /admin/webhooks/{id}/secret [post]
+func (h *WebhookHandler) RotateWebhookSecret(c *gin.Context) {
+	token, ok := c.Get("token")
+	if !ok {
+		response.HandleError(c, domain.ErrInternal)
+		return
+	}
+	domainToken, ok := token.(*domain.Token)
+	if !ok {
+		response.HandleError(c, domain.ErrInternal)
+		return
+	}
+
+	parsedId, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.HandleError(c, domain.ErrInvalidUUID)
+		return
+	}
+
+	endpoint, err := h.webhookService.RotateEndpointSecret(c, domainToken, parsedId)
+	if err != nil {
+		response.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response.NewWebhookSecretResponse(endpoint))
+}
+
+// DeleteWebhookEndpoint godoc
+// @Summary      Delete webhook endpoint
+// @Description  Deletes a webhook endpoint with its deliveries. Requires the webhooks:write permission and a valid JWT token in the Authorization header.
+// @Tags         Admin
+// @Security     BearerAuth
+// @Param        Authorization  header    string  true   "Bearer access token"
+// @Param        id             path      string  true   "Webhook endpoint ID (UUID)"
+// @Success      204            {string}  string  "Webhook endpoint deleted successfully"
+// @Failure      400            {object}  response.ErrorResponse "Invalid webhook endpoint id"
+// @Failure      401            {object}  response.ErrorResponse "Unauthorized – invalid token"
+// @Failure      403            {object}  response.ErrorResponse "Forbidden – insufficient permissions or invalid token type(expected access token)"
+// @Failure      404            {object}  response.ErrorResponse "Webhook endpoint not found"
+// @Failure      500            {object}  response.ErrorResponse "Internal server error"
+// @Router       /admin/webhooks/{id} [delete]
+func (h *WebhookHandler) DeleteWebhookEndpoint(c *gin.Context) {
+	token, ok := c.Get("token")
+	if !ok {
+		response.HandleError(c, domain.ErrInternal)
+		return
+	}
+	domainToken, ok := token.(*domain.Token)
+	if !ok {
+		response.HandleError(c, domain.ErrInternal)
+		return
+	}
+
+	parsedId, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.HandleError(c, domain.ErrInvalidUUID)
+		return
+	}
+
+	if err = h.webhookService.DeleteEndpoint(c, domainToken, parsedId); err != nil {
+		response.HandleError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// GetWebhookDeliveries godoc
+// @Summary      Webhook deliveries
+// @Description  Retrieves the deliveries of a webhook endpoint, newest first, with an optional status filter and pagination. Requires the webhooks:read permission and a valid JWT token in the Authorization header.
+// @Tags         Admin
+// @Security     BearerAuth
+// @Param        Authorization  header    string  true   "Bearer access token"
+// @Param        id             path      string  true   "Webhook endpoint ID (UUID)"
+// @Param        status         query     string  false  "Filter by status ('pending', 'succeeded' or 'dead')"
+// @Param        page           query     int     false  "Page number for pagination (min=1)"
+// @Param        limit          query     int     true   "Maximum number of deliveries to return (min=1, max=100)"
+// @Produce      json
+// @Success      200            {object}  response.WebhookDeliveriesResponse "List of webhook deliveries"
+// @Failure      400            {object}  response.ErrorResponse "Invalid webhook endpoint id or query parameters"
+// @Failure      401            {object}  response.ErrorResponse "Unauthorized – invalid token"
+// @Failure      403            {object}  response.ErrorResponse "Forbidden – insufficient permissions or invalid token type(expected access token)"
+// @Failure      404            {object}  response.ErrorResponse "Webhook endpoint not found"
+// @Failure      500            {object}  response.ErrorResponse "Internal server error"
+// @Router       /admin/webhooks/{id}/deliveries [get]
+func (h *WebhookHandler) GetWebhookDeliveries(c *gin.Context) {
+	token, ok := c.Get("token")
+	if !ok {
+		response.HandleError(c, domain.ErrInternal)
+		return
+	}
+	domainToken, ok := token.(*domain.Token)
+	if !ok {
+		response.HandleError(c, domain.ErrInternal)
+		return
+	}
+
+	parsedId, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.HandleError(c, domain.ErrInvalidUUID)
+		return
+	}
+
+	query := request.GetWebhookDeliveriesQuery{}
+	if err = c.ShouldBindQuery(&query); err != nil {
+		response.HandleBindingError(c, err)
+		return
+	}
+
+	deliveries, err := h.webhookService.GetDeliveries(
+		c,
+		domainToken,
+		domain.NewWebhookDeliveryFilter(parsedId, query.Status, query.Page, query.Limit),
+	)
+	if err != nil {
+		response.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response.NewWebhookDeliveriesResponse(deliveries))
+}
+
+// GetWebhookDelivery godoc
+// @Summary      Webhook delivery
+// @Description  Retrieves a webhook delivery with the payload of its event and the history of its attempts. Requires the webhooks:read permission and a valid JWT token in the Authorization header.
+// @Tags         Admin
+// @Security     BearerAuth
+// @Param        Authorization  header    string  true   "Bearer access token"
+// @Param        id             path      string  true   "Webhook delivery ID (UUID)"
+// @Produce      json
+// @Success      200            {object}  response.WebhookDeliveryResponse "Webhook delivery"
+// @Failure      400            {object}  response.ErrorResponse "Invalid webhook delivery id"
+// @Failure      401            {object}  response.ErrorResponse "Unauthorized – invalid token"
+// @Failure      403            {object}  response.ErrorResponse "Forbidden – insufficient permissions or invalid token type(expected access token)"
+// @Failure      404            {object}  response.ErrorResponse "Webhook delivery not found"
+// @Failure      500            {object}  response.ErrorResponse "Internal server error"
+// @Router       /admin/webhook-deliveries/{id} [get]
+func (h *WebhookHandler) GetWebhookDelivery(c *gin.Context) {
+	token, ok := c.Get("token")
+	if !ok {
+		response.HandleError(c, domain.ErrInternal)
+		return
+	}
+	domainToken, ok := token.(*domain.Token)
+	if !ok {
+		response.HandleError(c, domain.ErrInternal)
+		return
+	}
+
+	parsedId, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.HandleError(c, domain.ErrInvalidUUID)
+		return
+	}
+
+	delivery, attempts, err := h.webhookService.GetDelivery(c, domainToken, parsedId)
+	if err != nil {
+		response.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response.NewWebhookDeliveryResponse(delivery, attempts))
+}
+
+// ReplayWebhookDelivery godoc
+// @Summary      Replay webhook delivery
+// @Description  Queues a webhook delivery to be sent again right away with a fresh set of attempts, e.g. a dead delivery after the partner fixed their endpoint. Requires the webhooks:write permission and a valid JWT token in the Authorization header.
+// @Tags         Admin
+// @Security     BearerAuth
+// @Param        Authorization  header    string  true   "Bearer access token"
+// @Param        id             path      string  true   "Webhook delivery ID (UUID)"
+// @Success      202            {string}  string  "Webhook delivery queued"
+// @Failure      400            {object}  response.ErrorResponse "Invalid webhook delivery id"
+// @Failure      401            {object}  response.ErrorResponse "Unauthorized – invalid token"
+// @Failure      403            {object}  response.ErrorResponse "Forbidden – insufficient permissions or invalid token type(expected access token)"
+// @Failure      404            {object}  response.ErrorResponse "Webhook delivery not found"
+// @Failure      500            {object}  response.ErrorResponse "Internal server error"
+// @Router       /admin/webhook-deliveries/{id}/replay [post]
+func (h *WebhookHandler) ReplayWebhookDelivery(c *gin.Context) {
+	token, ok := c.Get("token")
+	if !ok {
+		response.HandleError(c, domain.ErrInternal)
+		return
+	}
+	domainToken, ok := token.(*domain.Token)
+	if !ok {
+		response.HandleError(c, domain.ErrInternal)
+		return
+	}
+
+	parsedId, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		response.HandleError(c, domain.ErrInvalidUUID)
+		return
+	}
+
+	if err = h.webhookService.ReplayDelivery(c, domainToken, parsedId); err != nil {
+		response.HandleError(c, err)
+		return
+	}
+
+	c.Status(http.StatusAccepted)
+}
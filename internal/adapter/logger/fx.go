@@ -9,7 +9,9 @@ import (
 
 var Module = fx.Module(
 	"logger",
+	fx.Provide(NewAtomicLevel),
 	fx.Provide(NewZapLogger),
+	fx.Provide(NewLevelWatcher),
 	fx.Invoke(func(lc fx.Lifecycle, logger *zap.Logger) {
 		lc.Append(
 			fx.Hook{
@@ -0,0 +1,30 @@
+package logger
+
+import (
+	"shop-api-go/internal/core/domain"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// LevelWatcher implements port.RuntimeSettingsWatcher and swaps the level of the logger.
+type LevelWatcher struct {
+	level zap.AtomicLevel
+}
+
+// NewLevelWatcher creates a new LevelWatcher instance.
+func NewLevelWatcher(level zap.AtomicLevel) *LevelWatcher {
+	return &LevelWatcher{level: level}
+}
+
+func (w *LevelWatcher) ApplySettings(settings *domain.RuntimeSettings) {
+	level, err := zapcore.ParseLevel(string(settings.LogLevel))
+	if err != nil {
+		zap.L().Error("invalid log level", zap.String("level", string(settings.LogLevel)))
+		return
+	}
+	if level != w.level.Level() {
+		zap.L().Info("changing log level", zap.Stringer("from", w.level.Level()), zap.Stringer("to", level))
+		w.level.SetLevel(level)
+	}
+}
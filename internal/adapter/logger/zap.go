@@ -7,16 +7,24 @@ import (
 	"go.uber.org/zap"
 )
 
-// NewZapLogger sets Logger based on the passed config.AppConfig.
-func NewZapLogger(appConfig *config.AppConfig) (*zap.Logger, error) {
+// NewZapLogger sets Logger based on the passed config.AppConfig, its messages are filtered by the level.
+func NewZapLogger(appConfig *config.AppConfig, level zap.AtomicLevel) (*zap.Logger, error) {
 	switch appConfig.Environment {
 	case config.Production:
 		cnf := zap.NewProductionConfig()
 		cnf.DisableStacktrace = true
+		cnf.Level = level
 		return cnf.Build()
 	case config.Development:
-		return zap.NewDevelopment()
+		cnf := zap.NewDevelopmentConfig()
+		cnf.Level = level
+		return cnf.Build()
 	default:
 		return nil, fmt.Errorf("unknown environment: %s", appConfig.Environment)
 	}
 }
+
+// NewAtomicLevel creates the level of the logger from the configured log level.
+func NewAtomicLevel(appConfig *config.AppConfig) (zap.AtomicLevel, error) {
+	return zap.ParseAtomicLevel(string(appConfig.LogLevel))
+}
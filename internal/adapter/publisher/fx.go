@@ -0,0 +1,20 @@
+package publisher
+
+import (
+	"shop-api-go/internal/core/port"
+
+	"go.uber.org/fx"
+)
+
+var Module = fx.Module(
+	"Publisher",
+	fx.Provide(NewLogPublisher),
+	fx.Provide(
+		fx.Annotate(
+			func(logPublisher *LogPublisher, webhookService port.WebhookService) *MultiPublisher {
+				return NewMultiPublisher(logPublisher, webhookService)
+			},
+			fx.As(new(port.EventPublisher)),
+		),
+	),
+)
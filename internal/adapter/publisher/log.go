@@ -0,0 +1,32 @@
+package publisher
+
+import (
+	"context"
+	"shop-api-go/internal/core/domain"
+
+	"go.uber.org/zap"
+)
+
+// LogPublisher implements port.EventPublisher and writes events to the log.
+//
+// Note: It is used until the platform has a message broker, no event is lost as the outbox keeps them.
+type LogPublisher struct{}
+
+// NewLogPublisher creates a new LogPublisher instance.
+func NewLogPublisher() *LogPublisher {
+	return &LogPublisher{}
+}
+
+func (p *LogPublisher) Publish(_ context.Context, event *domain.Event) error {
+	zap.L().Info(
+		"event published",
+		zap.String("id", event.Id.String()),
+		zap.String("type", string(event.Type)),
+		zap.String("aggregateType", string(event.AggregateType)),
+		zap.String("aggregateId", event.AggregateId.String()),
+		zap.Any("payload", event.Payload),
+		zap.Time("occurredAt", event.OccurredAt),
+		zap.Int("attempts", event.Attempts),
+	)
+	return nil
+}
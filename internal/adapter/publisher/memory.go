@@ -0,0 +1,49 @@
+package publisher
+
+import (
+	"context"
+	"shop-api-go/internal/core/domain"
+	"sync"
+)
+
+// MemoryPublisher implements port.EventPublisher and keeps the published events in memory,
+// it is meant for tests.
+type MemoryPublisher struct {
+	mu     sync.Mutex
+	events []domain.Event
+	err    error
+}
+
+// NewMemoryPublisher creates a new MemoryPublisher instance.
+func NewMemoryPublisher() *MemoryPublisher {
+	return &MemoryPublisher{}
+}
+
+func (p *MemoryPublisher) Publish(_ context.Context, event *domain.Event) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.err != nil {
+		return p.err
+	}
+	p.events = append(p.events, *event)
+	return nil
+}
+
+// Events returns a copy of the published events in the order they were published.
+func (p *MemoryPublisher) Events() []domain.Event {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	events := make([]domain.Event, len(p.events))
+	copy(events, p.events)
+	return events
+}
+
+// FailWith makes every following Publish return err until it is called with nil.
+func (p *MemoryPublisher) FailWith(err error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.err = err
+}
@@ -0,0 +1,33 @@
+package publisher
+
+import (
+	"context"
+	"errors"
+	"shop-api-go/internal/core/domain"
+	"shop-api-go/internal/core/port"
+)
+
+// MultiPublisher implements port.EventPublisher and publishes every event to all of its publishers.
+//
+// Note: An event that failed for one publisher is published again to all of them,
+// which is fine as every publisher delivers at least once.
+type MultiPublisher struct {
+	publishers []port.EventPublisher
+}
+
+// NewMultiPublisher creates a new MultiPublisher instance.
+func NewMultiPublisher(publishers ...port.EventPublisher) *MultiPublisher {
+	return &MultiPublisher{
+		publishers: publishers,
+	}
+}
+
+func (p *MultiPublisher) Publish(ctx context.Context, event *domain.Event) error {
+	var errs []error
+	for _, publisher := range p.publishers {
+		if err := publisher.Publish(ctx, event); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
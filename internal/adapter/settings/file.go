@@ -0,0 +1,214 @@
+package settings
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"maps"
+	"os"
+	"path/filepath"
+	"shop-api-go/internal/adapter/config"
+	"shop-api-go/internal/core/domain"
+	"slices"
+	"time"
+
+	"go.uber.org/zap"
+	"gopkg.in/yaml.v3"
+)
+
+// file is the YAML representation of the runtime settings, missing keys keep the configured values.
+type file struct {
+	LogLevel  *string         `yaml:"log_level,omitempty"`
+	RateLimit *rateLimitFile  `yaml:"rate_limit,omitempty"`
+	JWT       *jwtFile        `yaml:"jwt,omitempty"`
+	Features  map[string]bool `yaml:"features,omitempty"`
+}
+
+// rateLimitFile is the YAML representation of the rate limits, limits have the requests/period format.
+type rateLimitFile struct {
+	Enabled *bool                     `yaml:"enabled,omitempty"`
+	Groups  map[string]rateLimitGroup `yaml:"groups,omitempty"`
+}
+
+// rateLimitGroup is the YAML representation of the rate limit policy of a route group.
+type rateLimitGroup struct {
+	Default string            `yaml:"default"`
+	Roles   map[string]string `yaml:"roles,omitempty"`
+}
+
+// jwtFile is the YAML representation of the token expire times, e.g. 30m.
+type jwtFile struct {
+	AccessTokenExpireTime        *string `yaml:"access_token_expire_time,omitempty"`
+	RefreshTokenExpireTime       *string `yaml:"refresh_token_expire_time,omitempty"`
+	MFATokenExpireTime           *string `yaml:"mfa_token_expire_time,omitempty"`
+	ImpersonationTokenExpireTime *string `yaml:"impersonation_token_expire_time,omitempty"`
+}
+
+// FileStore implements port.RuntimeSettingsStore and keeps the runtime settings in a YAML file.
+//
+// Note: Nothing is loaded until the file exists, it is created by the first change.
+type FileStore struct {
+	path string
+}
+
+// NewFileStore creates a new FileStore instance storing the settings in the configured file.
+func NewFileStore(runtimeConfig *config.RuntimeConfig) *FileStore {
+	return &FileStore{path: runtimeConfig.SettingsFile}
+}
+
+func (s *FileStore) LoadSettings(context.Context) (*domain.RuntimeSettingsUpdate, error) {
+	content, err := os.ReadFile(s.path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	} else if err != nil {
+		zap.L().Error("error reading runtime settings file", zap.String("path", s.path), zap.Error(err))
+		return nil, domain.ErrInternal
+	}
+
+	var f file
+	decoder := yaml.NewDecoder(bytes.NewReader(content))
+	decoder.KnownFields(true)
+	if err = decoder.Decode(&f); err != nil && !errors.Is(err, io.EOF) {
+		return nil, domain.NewRuntimeSettingsError([]string{fmt.Sprintf("parsing %s: %s", s.path, err)})
+	}
+	return f.update()
+}
+
+func (s *FileStore) SaveSettings(_ context.Context, settings *domain.RuntimeSettings) error {
+	content, err := yaml.Marshal(newFile(settings))
+	if err != nil {
+		zap.L().Error("error encoding runtime settings", zap.Error(err))
+		return domain.ErrInternal
+	}
+
+	// The file is replaced by a rename, so a reload never reads a partially written file.
+	temp, err := os.CreateTemp(filepath.Dir(s.path), filepath.Base(s.path)+".*")
+	if err == nil {
+		_, err = temp.Write(content)
+		if closeErr := temp.Close(); err == nil {
+			err = closeErr
+		}
+		if err == nil {
+			err = os.Rename(temp.Name(), s.path)
+		}
+		if err != nil {
+			_ = os.Remove(temp.Name())
+		}
+	}
+	if err != nil {
+		zap.L().Error("error writing runtime settings file", zap.String("path", s.path), zap.Error(err))
+		return domain.ErrInternal
+	}
+	return nil
+}
+
+// newFile creates the YAML representation of all settings.
+func newFile(settings *domain.RuntimeSettings) *file {
+	logLevel := string(settings.LogLevel)
+	groups := make(map[string]rateLimitGroup, len(settings.RateLimits))
+	for name, policy := range settings.RateLimits {
+		group := rateLimitGroup{Default: policy.Default.String()}
+		if len(policy.Roles) > 0 {
+			group.Roles = make(map[string]string, len(policy.Roles))
+			for role, limit := range policy.Roles {
+				group.Roles[string(role)] = limit.String()
+			}
+		}
+		groups[name] = group
+	}
+	features := make(map[string]bool, len(domain.Features))
+	for _, feature := range domain.Features {
+		features[string(feature)] = settings.FeatureEnabled(feature)
+	}
+	duration := func(d time.Duration) *string {
+		value := d.String()
+		return &value
+	}
+
+	return &file{
+		LogLevel: &logLevel,
+		RateLimit: &rateLimitFile{
+			Enabled: &settings.RateLimitEnabled,
+			Groups:  groups,
+		},
+		JWT: &jwtFile{
+			AccessTokenExpireTime:        duration(settings.AccessTokenExpireTime),
+			RefreshTokenExpireTime:       duration(settings.RefreshTokenExpireTime),
+			MFATokenExpireTime:           duration(settings.MFATokenExpireTime),
+			ImpersonationTokenExpireTime: duration(settings.ImpersonationTokenExpireTime),
+		},
+		Features: features,
+	}
+}
+
+// update parses the values of the file, all values that cannot be parsed are reported at once.
+func (f *file) update() (*domain.RuntimeSettingsUpdate, error) {
+	var violations []string
+	update := &domain.RuntimeSettingsUpdate{}
+
+	if f.LogLevel != nil {
+		logLevel := domain.LogLevel(*f.LogLevel)
+		update.LogLevel = &logLevel
+	}
+	if f.RateLimit != nil {
+		update.RateLimitEnabled = f.RateLimit.Enabled
+		if len(f.RateLimit.Groups) > 0 {
+			update.RateLimits = make(map[string]domain.RateLimitPolicy, len(f.RateLimit.Groups))
+		}
+		for _, name := range slices.Sorted(maps.Keys(f.RateLimit.Groups)) {
+			group := f.RateLimit.Groups[name]
+			limit, err := domain.ParseRateLimit(group.Default)
+			if err != nil {
+				violations = append(violations, fmt.Sprintf("rate_limit.groups.%s.default: %s", name, err))
+			}
+			policy := domain.RateLimitPolicy{
+				Default: limit,
+				Roles:   make(map[domain.UserRole]domain.RateLimit, len(group.Roles)),
+			}
+			for _, role := range slices.Sorted(maps.Keys(group.Roles)) {
+				roleLimit, err := domain.ParseRateLimit(group.Roles[role])
+				if err != nil {
+					violations = append(violations, fmt.Sprintf("rate_limit.groups.%s.roles.%s: %s", name, role, err))
+				}
+				policy.Roles[domain.UserRole(role)] = roleLimit
+			}
+			update.RateLimits[name] = policy
+		}
+	}
+	if f.JWT != nil {
+		for _, expireTime := range []struct {
+			key    string
+			value  *string
+			target **time.Duration
+		}{
+			{"jwt.access_token_expire_time", f.JWT.AccessTokenExpireTime, &update.AccessTokenExpireTime},
+			{"jwt.refresh_token_expire_time", f.JWT.RefreshTokenExpireTime, &update.RefreshTokenExpireTime},
+			{"jwt.mfa_token_expire_time", f.JWT.MFATokenExpireTime, &update.MFATokenExpireTime},
+			{"jwt.impersonation_token_expire_time", f.JWT.ImpersonationTokenExpireTime, &update.ImpersonationTokenExpireTime},
+		} {
+			if expireTime.value == nil {
+				continue
+			}
+			d, err := time.ParseDuration(*expireTime.value)
+			if err != nil {
+				violations = append(violations, fmt.Sprintf("%s must be a duration: %q", expireTime.key, *expireTime.value))
+				continue
+			}
+			*expireTime.target = &d
+		}
+	}
+	if len(f.Features) > 0 {
+		update.Features = make(map[domain.Feature]bool, len(f.Features))
+		for feature, enabled := range f.Features {
+			update.Features[domain.Feature(feature)] = enabled
+		}
+	}
+
+	if len(violations) > 0 {
+		return nil, domain.NewRuntimeSettingsError(violations)
+	}
+	return update, nil
+}
@@ -0,0 +1,121 @@
+package settings
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"shop-api-go/internal/adapter/config"
+	"shop-api-go/internal/core/domain"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileStore_SaveSettings(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "settings.yaml")
+	store := NewFileStore(&config.RuntimeConfig{SettingsFile: path})
+	settings := &domain.RuntimeSettings{
+		LogLevel:         domain.LogWarn,
+		RateLimitEnabled: true,
+		RateLimits: map[string]domain.RateLimitPolicy{
+			"users": {
+				Default: domain.NewRateLimit(20, time.Minute),
+				Roles:   map[domain.UserRole]domain.RateLimit{domain.Admin: domain.NewRateLimit(100, time.Minute)},
+			},
+		},
+		AccessTokenExpireTime:        10 * time.Minute,
+		RefreshTokenExpireTime:       time.Hour,
+		MFATokenExpireTime:           time.Minute,
+		ImpersonationTokenExpireTime: 5 * time.Minute,
+		Features:                     map[domain.Feature]bool{domain.FeatureGraphQL: false},
+	}
+
+	update, err := store.LoadSettings(ctx)
+	require.NoError(t, err)
+	require.Nil(t, update)
+
+	require.NoError(t, store.SaveSettings(ctx, settings))
+	update, err = store.LoadSettings(ctx)
+	require.NoError(t, err)
+
+	loaded, err := (&domain.RuntimeSettings{RateLimits: map[string]domain.RateLimitPolicy{"users": {}}}).Apply(update)
+	require.NoError(t, err)
+	require.Equal(t, settings.Changes(loaded), map[string]domain.AuditChange{})
+
+	// Temporary files are renamed over the settings file.
+	entries, err := os.ReadDir(filepath.Dir(path))
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+}
+
+func TestFileStore_LoadSettings(t *testing.T) {
+	debug := domain.LogDebug
+
+	tests := []struct {
+		name          string
+		content       string
+		expected      *domain.RuntimeSettingsUpdate
+		expectedError error
+	}{
+		{
+			name:     "empty file",
+			content:  "",
+			expected: &domain.RuntimeSettingsUpdate{},
+		},
+		{
+			name:    "partial file",
+			content: "log_level: debug\nfeatures:\n  registration: false\n",
+			expected: &domain.RuntimeSettingsUpdate{
+				LogLevel: &debug,
+				Features: map[domain.Feature]bool{domain.FeatureRegistration: false},
+			},
+		},
+		{
+			name: "invalid values",
+			content: `
+rate_limit:
+  groups:
+    users:
+      default: fast
+      roles:
+        admin: 0/1m
+jwt:
+  access_token_expire_time: soon
+`,
+			expectedError: domain.NewRuntimeSettingsError([]string{
+				"rate_limit.groups.users.default: rate limit must have requests/period format: fast",
+				"rate_limit.groups.users.roles.admin: rate limit requests must be > 0: 0/1m",
+				`jwt.access_token_expire_time must be a duration: "soon"`,
+			}),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "settings.yaml")
+			require.NoError(t, os.WriteFile(path, []byte(tt.content), 0o600))
+
+			update, err := NewFileStore(&config.RuntimeConfig{SettingsFile: path}).LoadSettings(context.Background())
+			require.Equal(t, tt.expectedError, err)
+			require.Equal(t, tt.expected, update)
+		})
+	}
+
+	t.Run("unknown key", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "settings.yaml")
+		require.NoError(t, os.WriteFile(path, []byte("log-level: debug\n"), 0o600))
+
+		_, err := NewFileStore(&config.RuntimeConfig{SettingsFile: path}).LoadSettings(context.Background())
+		require.ErrorIs(t, err, domain.ErrInvalidRuntimeSettings)
+		require.ErrorContains(t, err, "field log-level not found")
+	})
+
+	t.Run("missing file", func(t *testing.T) {
+		store := NewFileStore(&config.RuntimeConfig{SettingsFile: filepath.Join(t.TempDir(), "settings.yaml")})
+		update, err := store.LoadSettings(context.Background())
+		require.NoError(t, err)
+		require.Nil(t, update)
+	})
+}
@@ -0,0 +1,35 @@
+package settings
+
+import (
+	"database/sql"
+	"shop-api-go/internal/adapter/auth/jwt"
+	"shop-api-go/internal/adapter/config"
+	"shop-api-go/internal/adapter/logger"
+	"shop-api-go/internal/adapter/storage/postgres/repository"
+	"shop-api-go/internal/core/port"
+
+	"go.uber.org/fx"
+)
+
+var Module = fx.Module(
+	"Settings",
+	fx.Provide(newRuntimeSettingsStore),
+	fx.Invoke(func(
+		settingsService port.RuntimeSettingsService,
+		levelWatcher *logger.LevelWatcher,
+		tokenGenerator *jwt.TokenGenerator,
+	) {
+		settingsService.Watch(levelWatcher)
+		settingsService.Watch(tokenGenerator)
+	}),
+)
+
+// newRuntimeSettingsStore creates the port.RuntimeSettingsStore selected by config.RuntimeConfig.
+//
+// Note: Replicas using the file store only share the settings if they share the file.
+func newRuntimeSettingsStore(runtimeConfig *config.RuntimeConfig, db *sql.DB) port.RuntimeSettingsStore {
+	if runtimeConfig.Store == config.FileStore {
+		return NewFileStore(runtimeConfig)
+	}
+	return repository.NewRuntimeSettingsRepository(db)
+}
@@ -0,0 +1,84 @@
+package memory
+
+import (
+	"context"
+	"shop-api-go/internal/core/domain"
+	"sync"
+	"time"
+)
+
+// LoginAttemptRepository implements port.LoginAttemptRepository and keeps
+// the attempts in memory of the current process.
+type LoginAttemptRepository struct {
+	mu       sync.Mutex
+	attempts map[string]domain.LoginAttempts
+	now      func() time.Time
+}
+
+// NewLoginAttemptRepository creates a new LoginAttemptRepository instance.
+func NewLoginAttemptRepository() *LoginAttemptRepository {
+	return &LoginAttemptRepository{
+		attempts: make(map[string]domain.LoginAttempts),
+		now:      time.Now,
+	}
+}
+
+func (r *LoginAttemptRepository) GetLoginAttempts(_ context.Context, key string) (*domain.LoginAttempts, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	attempts, ok := r.attempts[key]
+	if !ok {
+		return domain.NewLoginAttempts(key, 0, time.Time{}, time.Time{}), nil
+	}
+	return &attempts, nil
+}
+
+func (r *LoginAttemptRepository) RecordFailedLogin(_ context.Context, key string, window time.Duration) (*domain.LoginAttempts, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := r.now()
+	attempts, ok := r.attempts[key]
+	if !ok || attempts.LastFailureAt.Before(now.Add(-window)) {
+		attempts.Key = key
+		attempts.Failures = 0
+	}
+	attempts.Failures++
+	attempts.LastFailureAt = now
+
+	r.attempts[key] = attempts
+	return &attempts, nil
+}
+
+func (r *LoginAttemptRepository) BlockLogin(_ context.Context, key string, until time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if attempts, ok := r.attempts[key]; ok {
+		attempts.BlockedUntil = until
+		r.attempts[key] = attempts
+	}
+	return nil
+}
+
+func (r *LoginAttemptRepository) ResetLoginAttempts(_ context.Context, key string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.attempts, key)
+	return nil
+}
+
+func (r *LoginAttemptRepository) DeleteExpiredLoginAttempts(window time.Duration) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := r.now()
+	for key, attempts := range r.attempts {
+		if attempts.LastFailureAt.Before(now.Add(-window)) && attempts.BlockedUntil.Before(now) {
+			delete(r.attempts, key)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,66 @@
+package memory
+
+import (
+	"context"
+	"shop-api-go/internal/core/domain"
+	"sync"
+	"time"
+)
+
+// bucket is the state of a single token bucket.
+type bucket struct {
+	tokens    float64
+	updatedAt time.Time
+}
+
+// RateLimitStore implements port.RateLimitStore and keeps
+// the buckets in memory of the current process.
+type RateLimitStore struct {
+	mu      sync.Mutex
+	buckets map[string]bucket
+	now     func() time.Time
+}
+
+// NewRateLimitStore creates a new RateLimitStore instance.
+func NewRateLimitStore() *RateLimitStore {
+	return &RateLimitStore{
+		buckets: make(map[string]bucket),
+		now:     time.Now,
+	}
+}
+
+func (s *RateLimitStore) Take(_ context.Context, key string, limit domain.RateLimit) (*domain.RateLimitResult, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	capacity := float64(limit.Requests)
+	b, ok := s.buckets[key]
+	if !ok {
+		b = bucket{tokens: capacity, updatedAt: now}
+	}
+
+	b.tokens = min(capacity, b.tokens+now.Sub(b.updatedAt).Seconds()*limit.Rate())
+	b.updatedAt = now
+
+	allowed := b.tokens >= 1
+	if allowed {
+		b.tokens--
+	}
+
+	s.buckets[key] = b
+	return domain.NewRateLimitResult(limit, b.tokens, allowed), nil
+}
+
+func (s *RateLimitStore) DeleteIdleBuckets(idle time.Duration) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	for key, b := range s.buckets {
+		if b.updatedAt.Before(now.Add(-idle)) {
+			delete(s.buckets, key)
+		}
+	}
+	return nil
+}
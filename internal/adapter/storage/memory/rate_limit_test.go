@@ -0,0 +1,62 @@
+package memory
+
+import (
+	"context"
+	"shop-api-go/internal/core/domain"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRateLimitStore_Take(t *testing.T) {
+	now := time.Now()
+	store := NewRateLimitStore()
+	store.now = func() time.Time { return now }
+	limit := domain.NewRateLimit(2, 10*time.Second)
+	ctx := context.Background()
+
+	result, err := store.Take(ctx, "key", limit)
+	require.NoError(t, err)
+	require.Equal(t, &domain.RateLimitResult{Allowed: true, Limit: 2, Remaining: 1, ResetAfter: 5 * time.Second}, result)
+
+	result, err = store.Take(ctx, "key", limit)
+	require.NoError(t, err)
+	require.Equal(t, &domain.RateLimitResult{Allowed: true, Limit: 2, Remaining: 0, ResetAfter: 10 * time.Second}, result)
+
+	result, err = store.Take(ctx, "key", limit)
+	require.NoError(t, err)
+	require.Equal(t, &domain.RateLimitResult{Allowed: false, Limit: 2, Remaining: 0, ResetAfter: 10 * time.Second, RetryAfter: 5 * time.Second}, result)
+
+	result, err = store.Take(ctx, "other", limit)
+	require.NoError(t, err)
+	require.True(t, result.Allowed)
+
+	now = now.Add(5 * time.Second)
+	result, err = store.Take(ctx, "key", limit)
+	require.NoError(t, err)
+	require.Equal(t, &domain.RateLimitResult{Allowed: true, Limit: 2, Remaining: 0, ResetAfter: 10 * time.Second}, result)
+
+	now = now.Add(time.Minute)
+	result, err = store.Take(ctx, "key", limit)
+	require.NoError(t, err)
+	require.Equal(t, 1, result.Remaining)
+}
+
+func TestRateLimitStore_DeleteIdleBuckets(t *testing.T) {
+	now := time.Now()
+	store := NewRateLimitStore()
+	store.now = func() time.Time { return now }
+	limit := domain.NewRateLimit(1, time.Minute)
+	ctx := context.Background()
+
+	_, err := store.Take(ctx, "key", limit)
+	require.NoError(t, err)
+
+	now = now.Add(2 * time.Hour)
+	require.NoError(t, store.DeleteIdleBuckets(time.Hour))
+
+	result, err := store.Take(ctx, "key", limit)
+	require.NoError(t, err)
+	require.True(t, result.Allowed)
+}
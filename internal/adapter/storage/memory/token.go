@@ -0,0 +1,77 @@
+package memory
+
+import (
+	"context"
+	"shop-api-go/internal/core/domain"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// TokenRepository implements port.TokenRepository and keeps
+// the tokens in memory of the current process. It is a fake for tests,
+// checked against the Postgres repository by the storagetest contract.
+//
+// Note: Audit events are not stored and tokens are not checked against the users they belong to.
+type TokenRepository struct {
+	mu     sync.Mutex
+	tokens map[uuid.UUID]domain.Token
+	now    func() time.Time
+}
+
+// NewTokenRepository creates a new TokenRepository instance.
+func NewTokenRepository() *TokenRepository {
+	return &TokenRepository{
+		tokens: make(map[uuid.UUID]domain.Token),
+		now:    time.Now,
+	}
+}
+
+func (r *TokenRepository) AddToken(_ context.Context, token *domain.Token, _ *domain.AuditEvent) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	// Mirrors the primary key violation of the postgres store.
+	if _, ok := r.tokens[token.Id]; ok {
+		return domain.ErrInternal
+	}
+	r.tokens[token.Id] = *token
+	return nil
+}
+
+func (r *TokenRepository) DeleteToken(_ context.Context, id uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.tokens[id]; !ok {
+		return domain.ErrTokenNotFound
+	}
+	delete(r.tokens, id)
+	return nil
+}
+
+func (r *TokenRepository) DeleteAllTokensByUserId(_ context.Context, userId uuid.UUID, _ *domain.AuditEvent) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for id, token := range r.tokens {
+		if token.UserId == userId {
+			delete(r.tokens, id)
+		}
+	}
+	return nil
+}
+
+func (r *TokenRepository) DeleteExpiredTokens() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := r.now()
+	for id, token := range r.tokens {
+		if token.ExpiresAt.Before(now) {
+			delete(r.tokens, id)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,13 @@
+package memory
+
+import (
+	"shop-api-go/internal/adapter/storage/storagetest"
+	"shop-api-go/internal/core/port"
+	"testing"
+)
+
+func TestTokenRepository(t *testing.T) {
+	storagetest.TestTokenRepository(t, func(*testing.T) (port.UserRepository, port.TokenRepository) {
+		return NewUserRepository(), NewTokenRepository()
+	})
+}
@@ -0,0 +1,475 @@
+package memory
+
+import (
+	"bytes"
+	"context"
+	"shop-api-go/internal/core/domain"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// usernameSimilarity is the similarity above which usernames match a filter,
+	// the default pg_trgm.similarity_threshold used by the % operator.
+	usernameSimilarity = 0.3
+	// emailSimilarity is the similarity above which emails match a filter.
+	emailSimilarity = 0.6
+)
+
+// storedUser is a user with the soft-deletion state that is not part of domain.User.
+type storedUser struct {
+	user    domain.User
+	deleted bool
+}
+
+// UserRepository implements port.UserRepository and keeps
+// the users in memory of the current process. It is a fake for tests,
+// checked against the Postgres repository by the storagetest contract.
+//
+// Note: Audit and outbox events are not stored, the memory store has neither an audit log
+// nor an outbox. Roles are not checked, any role is accepted.
+type UserRepository struct {
+	mu    sync.RWMutex
+	users map[uuid.UUID]*storedUser
+	now   func() time.Time
+}
+
+// NewUserRepository creates a new UserRepository instance.
+func NewUserRepository() *UserRepository {
+	return &UserRepository{
+		users: make(map[uuid.UUID]*storedUser),
+		now:   time.Now,
+	}
+}
+
+func (r *UserRepository) AddUser(_ context.Context, user *domain.User, _ *domain.AuditEvent, _ []*domain.Event) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err := r.checkConflicts(user.Id, &user.Username, &user.Email); err != nil {
+		return err
+	}
+	r.insert(user)
+	return nil
+}
+
+func (r *UserRepository) CheckImportConflicts(_ context.Context, rows []domain.UserImportRow) error {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.checkImportConflicts(rows)
+}
+
+// checkImportConflicts returns a domain.UserImportError with every row conflicting with a stored user.
+//
+// Note: r.mu must be held.
+func (r *UserRepository) checkImportConflicts(rows []domain.UserImportRow) error {
+	var rowErrors []domain.UserImportRowError
+	for _, row := range rows {
+		if err := r.checkConflicts(uuid.Nil, &row.User.Username, &row.User.Email); err != nil {
+			rowErrors = append(rowErrors, domain.UserImportRowError{Line: row.Line, Err: err})
+		}
+	}
+	if len(rowErrors) > 0 {
+		return domain.NewUserImportError(rowErrors)
+	}
+	return nil
+}
+
+func (r *UserRepository) AddUsers(_ context.Context, rows []domain.UserImportRow, _ []*domain.AuditEvent, _ []*domain.Event) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err := r.checkImportConflicts(rows); err != nil {
+		return err
+	}
+
+	// Rows conflicting with each other are rejected like the unique constraints do,
+	// without inserting any row of the import.
+	usernames := make(map[string]struct{}, len(rows))
+	emails := make(map[string]struct{}, len(rows))
+	for _, row := range rows {
+		if _, ok := usernames[row.User.Username]; ok {
+			return domain.NewUserImportError([]domain.UserImportRowError{{Line: row.Line, Err: domain.ErrUsernameAlreadyInUse}})
+		}
+		if _, ok := emails[row.User.Email]; ok {
+			return domain.NewUserImportError([]domain.UserImportRowError{{Line: row.Line, Err: domain.ErrEmailAlreadyInUse}})
+		}
+		usernames[row.User.Username] = struct{}{}
+		emails[row.User.Email] = struct{}{}
+	}
+
+	for _, row := range rows {
+		r.insert(&row.User)
+	}
+	return nil
+}
+
+func (r *UserRepository) ExportUsers(_ context.Context, filter *domain.UserFilter, fn func(user *domain.User) error) error {
+	r.mu.RLock()
+	users := r.match(filter)
+	r.mu.RUnlock()
+
+	slices.SortFunc(users, func(a, b domain.User) int {
+		return compareUsers(&a, &b, domain.UserSortCreatedAt)
+	})
+	for i := range users {
+		if err := fn(&users[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *UserRepository) GetUserByUsername(_ context.Context, username string) (*domain.User, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, stored := range r.users {
+		if !stored.deleted && stored.user.Username == username {
+			return &domain.User{
+				Id:       stored.user.Id,
+				Username: stored.user.Username,
+				Email:    stored.user.Email,
+				Password: stored.user.Password,
+				Role:     stored.user.Role,
+				Status:   stored.user.Status,
+			}, nil
+		}
+	}
+	return nil, domain.ErrUserNotFound
+}
+
+func (r *UserRepository) GetUserByEmail(_ context.Context, email string) (*domain.User, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, stored := range r.users {
+		if !stored.deleted && stored.user.Email == email {
+			user := withoutPassword(&stored.user)
+			return &user, nil
+		}
+	}
+	return nil, domain.ErrUserNotFound
+}
+
+func (r *UserRepository) GetUserById(_ context.Context, id uuid.UUID) (*domain.User, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	stored, ok := r.users[id]
+	if !ok || stored.deleted {
+		return nil, domain.ErrUserNotFound
+	}
+	user := withoutPassword(&stored.user)
+	return &user, nil
+}
+
+func (r *UserRepository) GetUsers(_ context.Context, get *domain.GetUsers) ([]domain.User, error) {
+	if !slices.Contains(userSortFields, get.SortBy) {
+		return nil, domain.ErrInvalidQuery
+	}
+
+	var cursor *domain.User
+	if get.Cursor != nil {
+		var err error
+		if cursor, err = cursorUser(get.Cursor, get.SortBy); err != nil {
+			return nil, err
+		}
+	}
+
+	r.mu.RLock()
+	users := r.match(&get.Filter)
+	r.mu.RUnlock()
+
+	direction := 1
+	if get.Descending {
+		direction = -1
+	}
+	if cursor != nil {
+		users = slices.DeleteFunc(users, func(user domain.User) bool {
+			return compareUsers(&user, cursor, get.SortBy)*direction <= 0
+		})
+	}
+	slices.SortFunc(users, func(a, b domain.User) int {
+		return compareUsers(&a, &b, get.SortBy) * direction
+	})
+
+	limit := *get.Limit
+	offset := 0
+	if get.Page != nil {
+		offset = (*get.Page - 1) * limit
+	}
+	if offset >= len(users) {
+		return []domain.User{}, nil
+	}
+	users = users[offset:]
+	if len(users) > limit {
+		users = users[:limit]
+	}
+	return users, nil
+}
+
+func (r *UserRepository) CountUsers(_ context.Context, filter *domain.UserFilter) (int, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return len(r.match(filter)), nil
+}
+
+func (r *UserRepository) UpdateUser(_ context.Context, update *domain.UserUpdate, _ *domain.AuditEvent, _ []*domain.Event) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored, ok := r.users[update.Id]
+	if !ok || stored.deleted {
+		return domain.ErrUserNotFound
+	}
+	if err := r.checkConflicts(update.Id, update.Username, update.Email); err != nil {
+		return err
+	}
+
+	if update.Username != nil {
+		stored.user.Username = *update.Username
+	}
+	if update.Email != nil {
+		stored.user.Email = *update.Email
+	}
+	if update.Password != nil {
+		stored.user.Password = *update.Password
+	}
+	if update.Role != nil {
+		stored.user.Role = *update.Role
+	}
+	stored.user.UpdatedAt = r.timestamp()
+	return nil
+}
+
+func (r *UserRepository) UpdatePasswordHash(_ context.Context, id uuid.UUID, hash string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored, ok := r.users[id]
+	if !ok || stored.deleted {
+		return domain.ErrUserNotFound
+	}
+	stored.user.Password = hash
+	return nil
+}
+
+func (r *UserRepository) SetUserStatus(_ context.Context, id uuid.UUID, status domain.UserStatus, _ *domain.AuditEvent) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored, ok := r.users[id]
+	if !ok || stored.deleted {
+		return domain.ErrUserNotFound
+	}
+	stored.user.Status = status
+	stored.user.UpdatedAt = r.timestamp()
+	return nil
+}
+
+func (r *UserRepository) DeleteUser(_ context.Context, id uuid.UUID, _ *domain.AuditEvent) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored, ok := r.users[id]
+	if !ok || stored.deleted {
+		return domain.ErrUserNotFound
+	}
+	stored.deleted = true
+	return nil
+}
+
+func (r *UserRepository) RestoreUser(_ context.Context, id uuid.UUID, _ *domain.AuditEvent) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored, ok := r.users[id]
+	if !ok {
+		return domain.ErrUserNotFound
+	}
+	if !stored.deleted {
+		return domain.ErrUserNotDeleted
+	}
+	stored.deleted = false
+	stored.user.UpdatedAt = r.timestamp()
+	return nil
+}
+
+// checkConflicts returns the error of the unique constraint the username or email violates,
+// ignoring the user with the id. Soft-deleted users keep their username and email.
+//
+// Note: r.mu must be held.
+func (r *UserRepository) checkConflicts(id uuid.UUID, username, email *string) error {
+	for _, stored := range r.users {
+		if stored.user.Id == id {
+			continue
+		}
+		if username != nil && stored.user.Username == *username {
+			return domain.ErrUsernameAlreadyInUse
+		}
+		if email != nil && stored.user.Email == *email {
+			return domain.ErrEmailAlreadyInUse
+		}
+	}
+	return nil
+}
+
+// insert stores a copy of a new active user.
+//
+// Note: r.mu must be held.
+func (r *UserRepository) insert(user *domain.User) {
+	now := r.timestamp()
+	stored := &storedUser{user: *user}
+	stored.user.Status = domain.UserActive
+	stored.user.CreatedAt = now
+	stored.user.UpdatedAt = now
+	r.users[user.Id] = stored
+}
+
+// match returns the users that are not deleted and match the filter, without their passwords.
+//
+// Note: r.mu must be held.
+func (r *UserRepository) match(filter *domain.UserFilter) []domain.User {
+	users := make([]domain.User, 0, len(r.users))
+	for _, stored := range r.users {
+		if !stored.deleted && matchesFilter(&stored.user, filter) {
+			users = append(users, withoutPassword(&stored.user))
+		}
+	}
+	return users
+}
+
+// timestamp returns the current time with the precision of a postgres timestamp.
+func (r *UserRepository) timestamp() time.Time {
+	return r.now().UTC().Truncate(time.Microsecond)
+}
+
+// matchesFilter reports whether the user matches every field of the filter that is set.
+func matchesFilter(user *domain.User, filter *domain.UserFilter) bool {
+	switch {
+	case filter.Id != nil && user.Id != *filter.Id:
+		return false
+	case filter.Username != nil && similarity(user.Username, *filter.Username) <= usernameSimilarity:
+		return false
+	case filter.Email != nil && similarity(user.Email, *filter.Email) <= emailSimilarity:
+		return false
+	case filter.Role != nil && user.Role != *filter.Role:
+		return false
+	case filter.Status != nil && user.Status != *filter.Status:
+		return false
+	case filter.CreatedFrom != nil && user.CreatedAt.Before(*filter.CreatedFrom):
+		return false
+	case filter.CreatedTo != nil && !user.CreatedAt.Before(*filter.CreatedTo):
+		return false
+	case filter.UpdatedFrom != nil && user.UpdatedAt.Before(*filter.UpdatedFrom):
+		return false
+	case filter.UpdatedTo != nil && !user.UpdatedAt.Before(*filter.UpdatedTo):
+		return false
+	}
+	return true
+}
+
+// withoutPassword returns a copy of the user without the password hash.
+func withoutPassword(user *domain.User) domain.User {
+	copied := *user
+	copied.Password = ""
+	return copied
+}
+
+// userSortFields contains the fields users can be sorted by.
+var userSortFields = []domain.UserSortField{
+	domain.UserSortId,
+	domain.UserSortUsername,
+	domain.UserSortEmail,
+	domain.UserSortRole,
+	domain.UserSortStatus,
+	domain.UserSortCreatedAt,
+	domain.UserSortUpdatedAt,
+}
+
+// compareUsers compares two users by the sort field and then by id.
+func compareUsers(a, b *domain.User, sortBy domain.UserSortField) int {
+	var c int
+	switch sortBy {
+	case domain.UserSortUsername:
+		c = strings.Compare(a.Username, b.Username)
+	case domain.UserSortEmail:
+		c = strings.Compare(a.Email, b.Email)
+	case domain.UserSortRole:
+		c = strings.Compare(string(a.Role), string(b.Role))
+	case domain.UserSortStatus:
+		c = strings.Compare(string(a.Status), string(b.Status))
+	case domain.UserSortCreatedAt:
+		c = a.CreatedAt.Compare(b.CreatedAt)
+	case domain.UserSortUpdatedAt:
+		c = a.UpdatedAt.Compare(b.UpdatedAt)
+	}
+	if c != 0 {
+		return c
+	}
+	return bytes.Compare(a.Id[:], b.Id[:])
+}
+
+// cursorUser returns a user with the sort field and id of the cursor, so it can be compared by compareUsers.
+func cursorUser(cursor *domain.UserCursor, sortBy domain.UserSortField) (*domain.User, error) {
+	user := &domain.User{Id: cursor.Id}
+	switch sortBy {
+	case domain.UserSortUsername:
+		user.Username = cursor.Value
+	case domain.UserSortEmail:
+		user.Email = cursor.Value
+	case domain.UserSortRole:
+		user.Role = domain.UserRole(cursor.Value)
+	case domain.UserSortStatus:
+		user.Status = domain.UserStatus(cursor.Value)
+	case domain.UserSortCreatedAt, domain.UserSortUpdatedAt:
+		after, err := time.Parse(time.RFC3339Nano, cursor.Value)
+		if err != nil {
+			return nil, domain.ErrInvalidCursor
+		}
+		user.CreatedAt, user.UpdatedAt = after, after
+	}
+	return user, nil
+}
+
+// similarity approximates the similarity function of pg_trgm: the number of trigrams
+// both strings share divided by the number of distinct trigrams of both.
+func similarity(a, b string) float64 {
+	trigramsA, trigramsB := trigrams(a), trigrams(b)
+	if len(trigramsA) == 0 || len(trigramsB) == 0 {
+		return 0
+	}
+
+	shared := 0
+	for trigram := range trigramsA {
+		if _, ok := trigramsB[trigram]; ok {
+			shared++
+		}
+	}
+	return float64(shared) / float64(len(trigramsA)+len(trigramsB)-shared)
+}
+
+// trigrams returns the trigrams of s the way pg_trgm extracts them: every word of letters
+// and digits is lowercased and padded with two spaces in front and one behind.
+func trigrams(s string) map[string]struct{} {
+	words := strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	result := make(map[string]struct{})
+	for _, word := range words {
+		padded := []rune("  " + word + " ")
+		for i := 0; i+3 <= len(padded); i++ {
+			result[string(padded[i:i+3])] = struct{}{}
+		}
+	}
+	return result
+}
@@ -0,0 +1,22 @@
+package memory
+
+import (
+	"shop-api-go/internal/adapter/storage/storagetest"
+	"shop-api-go/internal/core/port"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestUserRepository(t *testing.T) {
+	storagetest.TestUserRepository(t, func(*testing.T) port.UserRepository {
+		return NewUserRepository()
+	})
+}
+
+func TestSimilarity(t *testing.T) {
+	require.InDelta(t, 1, similarity("john.smith@example.com", "JOHN.SMITH@example.com"), 1e-9)
+	require.InDelta(t, 0.75, similarity("johnsmith1", "johnsmith"), 1e-9)
+	require.InDelta(t, 0.0, similarity("johnsmith", "..."), 1e-9)
+	require.Less(t, similarity("jane.doe@example.com", "john.smith@example.com"), emailSimilarity)
+}
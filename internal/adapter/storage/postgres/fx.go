@@ -1,6 +1,10 @@
 package postgres
 
 import (
+	"context"
+	"database/sql"
+	"shop-api-go/internal/adapter/config"
+	"shop-api-go/internal/adapter/storage/memory"
 	"shop-api-go/internal/adapter/storage/postgres/repository"
 	"shop-api-go/internal/core/port"
 
@@ -10,6 +14,13 @@ import (
 var Module = fx.Module(
 	"Postgres",
 	fx.Provide(New),
+	fx.Invoke(autoMigrate),
+	fx.Provide(
+		fx.Annotate(
+			repository.NewTxManager,
+			fx.As(new(port.TxManager)),
+		),
+	),
 	fx.Provide(
 		fx.Annotate(
 			repository.NewUserRepository,
@@ -22,4 +33,99 @@ var Module = fx.Module(
 			fx.As(new(port.TokenRepository)),
 		),
 	),
+	fx.Provide(
+		fx.Annotate(
+			repository.NewMFARepository,
+			fx.As(new(port.MFARepository)),
+		),
+	),
+	fx.Provide(
+		fx.Annotate(
+			repository.NewIdentityRepository,
+			fx.As(new(port.IdentityRepository)),
+		),
+	),
+	fx.Provide(
+		fx.Annotate(
+			repository.NewRoleRepository,
+			fx.As(new(port.RoleRepository)),
+		),
+	),
+	fx.Provide(
+		fx.Annotate(
+			repository.NewAPIKeyRepository,
+			fx.As(new(port.APIKeyRepository)),
+		),
+	),
+	fx.Provide(
+		fx.Annotate(
+			repository.NewAuditRepository,
+			fx.As(new(port.AuditRepository)),
+		),
+	),
+	fx.Provide(
+		fx.Annotate(
+			repository.NewDataRequestRepository,
+			fx.As(new(port.DataRequestRepository)),
+		),
+	),
+	fx.Provide(
+		fx.Annotate(
+			repository.NewProductRepository,
+			fx.As(new(port.ProductRepository)),
+		),
+	),
+	fx.Provide(
+		fx.Annotate(
+			repository.NewOutboxRepository,
+			fx.As(new(port.OutboxRepository)),
+		),
+	),
+	fx.Provide(
+		fx.Annotate(
+			repository.NewWebhookRepository,
+			fx.As(new(port.WebhookRepository)),
+		),
+	),
+	fx.Provide(newLoginAttemptRepository),
+	fx.Provide(newRateLimitStore),
 )
+
+// autoMigrate applies the pending migrations on start if config.DBConfig enables it,
+// before the hooks of the modules using the database run.
+func autoMigrate(lc fx.Lifecycle, dbConfig *config.DBConfig, db *sql.DB) {
+	if !dbConfig.AutoMigrate {
+		return
+	}
+
+	lc.Append(
+		fx.Hook{
+			OnStart: func(ctx context.Context) error {
+				migrator, err := NewMigrator(db)
+				if err != nil {
+					return err
+				}
+				return migrator.Up(ctx)
+			},
+		})
+}
+
+// newLoginAttemptRepository creates the port.LoginAttemptRepository selected by config.LoginConfig.
+//
+// Note: The memory store does not share attempts between replicas.
+func newLoginAttemptRepository(loginConfig *config.LoginConfig, db *sql.DB) port.LoginAttemptRepository {
+	if loginConfig.AttemptStore == config.MemoryStore {
+		return memory.NewLoginAttemptRepository()
+	}
+	return repository.NewLoginAttemptRepository(db)
+}
+
+// newRateLimitStore creates the port.RateLimitStore selected by config.RateLimitConfig.
+//
+// Note: The memory store does not share buckets between replicas.
+func newRateLimitStore(rateLimitConfig *config.RateLimitConfig, db *sql.DB) port.RateLimitStore {
+	if rateLimitConfig.Store == config.PostgresStore {
+		return repository.NewRateLimitStore(db)
+	}
+	return memory.NewRateLimitStore()
+}
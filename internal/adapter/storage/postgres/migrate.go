@@ -0,0 +1,355 @@
+package postgres
+
+import (
+	"cmp"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io/fs"
+	"regexp"
+	"shop-api-go/internal/adapter/storage/postgres/migrations"
+	"slices"
+	"strconv"
+
+	"go.uber.org/zap"
+)
+
+// migrationLockKey is the key of the advisory lock held while migrating,
+// so replicas starting at the same time apply every migration once.
+const migrationLockKey = 4_170_923_613
+
+var (
+	// ErrDirtyDatabase is returned when a previous migration failed halfway.
+	// The database has to be fixed manually and the version set with Migrator.Force.
+	ErrDirtyDatabase = errors.New("database is dirty")
+	// ErrUnknownVersion is returned for a version without a migration.
+	ErrUnknownVersion = errors.New("unknown migration version")
+)
+
+// migrationFile matches the names of migration files, e.g. 000001_create_users_table.up.sql.
+var migrationFile = regexp.MustCompile(`^(\d+)_(\w+)\.(up|down)\.sql$`)
+
+// Migration is a versioned change of the database schema.
+type Migration struct {
+	Version uint
+	Name    string
+	Up      string
+	Down    string
+}
+
+// MigrationStatus is the state of the database schema.
+type MigrationStatus struct {
+	// Version is the version of the last applied migration, 0 if none is applied.
+	Version uint
+	// Dirty is set if applying or reverting the migration with Version failed halfway.
+	Dirty bool
+	// Migrations contains all known migrations in the order they are applied.
+	Migrations []Migration
+}
+
+// Migrator applies the migrations embedded in the binary.
+//
+// Note: The applied version is kept in the schema_migrations table of the migrate CLI,
+// so databases migrated by either of them can be migrated by the other.
+type Migrator struct {
+	db         *sql.DB
+	migrations []Migration
+}
+
+// NewMigrator creates a new Migrator instance with the embedded migrations.
+func NewMigrator(db *sql.DB) (*Migrator, error) {
+	loaded, err := loadMigrations(migrations.FS)
+	if err != nil {
+		return nil, err
+	}
+	return &Migrator{db: db, migrations: loaded}, nil
+}
+
+// loadMigrations reads the up and down migrations from the files in fsys.
+func loadMigrations(fsys fs.FS) ([]Migration, error) {
+	names, err := fs.Glob(fsys, "*.sql")
+	if err != nil {
+		return nil, err
+	}
+
+	byVersion := make(map[uint]*Migration)
+	for _, name := range names {
+		match := migrationFile.FindStringSubmatch(name)
+		if match == nil {
+			return nil, fmt.Errorf("invalid migration file name: %s", name)
+		}
+		version, err := strconv.ParseUint(match[1], 10, 64)
+		if err != nil || version == 0 {
+			return nil, fmt.Errorf("invalid migration version: %s", name)
+		}
+		content, err := fs.ReadFile(fsys, name)
+		if err != nil {
+			return nil, fmt.Errorf("reading migration %s: %w", name, err)
+		}
+
+		migration, ok := byVersion[uint(version)]
+		if !ok {
+			migration = &Migration{Version: uint(version), Name: match[2]}
+			byVersion[uint(version)] = migration
+		} else if migration.Name != match[2] {
+			return nil, fmt.Errorf("migration %d has different names: %s and %s", version, migration.Name, match[2])
+		}
+		if match[3] == "up" {
+			migration.Up = string(content)
+		} else {
+			migration.Down = string(content)
+		}
+	}
+
+	loaded := make([]Migration, 0, len(byVersion))
+	for _, migration := range byVersion {
+		if migration.Up == "" || migration.Down == "" {
+			return nil, fmt.Errorf("migration %d must have up and down files", migration.Version)
+		}
+		loaded = append(loaded, *migration)
+	}
+	slices.SortFunc(loaded, func(a, b Migration) int {
+		return cmp.Compare(a.Version, b.Version)
+	})
+	return loaded, nil
+}
+
+// Up applies all pending migrations.
+//
+// Note: A database migrated by a newer release, e.g. during a rolling deployment or after a rollback,
+// is left as it is with a warning instead of failing, as there is nothing to apply.
+func (m *Migrator) Up(ctx context.Context) error {
+	return m.withVersion(ctx, func(conn *sql.Conn, version uint) error {
+		if m.ahead(version) {
+			zap.L().Warn(
+				"database version is ahead of the known migrations, nothing to apply",
+				zap.Uint("version", version),
+				zap.Uint("latest", m.migrations[len(m.migrations)-1].Version),
+			)
+			return nil
+		}
+		current, err := m.index(version)
+		if err != nil {
+			return err
+		}
+		return m.migrate(ctx, conn, current, len(m.migrations)-1)
+	})
+}
+
+// Down reverts the last steps applied migrations, or all of them if fewer are applied.
+func (m *Migrator) Down(ctx context.Context, steps int) error {
+	if steps <= 0 {
+		return fmt.Errorf("steps must be > 0: %d", steps)
+	}
+	return m.withLock(ctx, func(conn *sql.Conn, current int) error {
+		return m.migrate(ctx, conn, current, max(current-steps, -1))
+	})
+}
+
+// To applies or reverts migrations until the version is reached, version 0 reverts all migrations.
+func (m *Migrator) To(ctx context.Context, version uint) error {
+	target, err := m.index(version)
+	if err != nil {
+		return err
+	}
+	return m.withLock(ctx, func(conn *sql.Conn, current int) error {
+		return m.migrate(ctx, conn, current, target)
+	})
+}
+
+// Force sets the version and clears the dirty state without running any migration.
+// It is used to recover after a failed migration was fixed or rolled back manually.
+func (m *Migrator) Force(ctx context.Context, version uint) error {
+	if _, err := m.index(version); err != nil {
+		return err
+	}
+	return m.lock(ctx, func(conn *sql.Conn) error {
+		return setVersion(ctx, conn, version, false)
+	})
+}
+
+// Status returns the current version and all known migrations.
+func (m *Migrator) Status(ctx context.Context) (*MigrationStatus, error) {
+	status := &MigrationStatus{Migrations: m.migrations}
+	err := m.lock(ctx, func(conn *sql.Conn) error {
+		var err error
+		status.Version, status.Dirty, err = getVersion(ctx, conn)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return status, nil
+}
+
+// migrate applies or reverts migrations from the index of the current one to the target index,
+// -1 stands for no applied migration.
+func (m *Migrator) migrate(ctx context.Context, conn *sql.Conn, current, target int) error {
+	for ; current < target; current++ {
+		migration := m.migrations[current+1]
+		if err := m.run(ctx, conn, migration.Version, migration.Up, migration.Version); err != nil {
+			return fmt.Errorf("applying migration %d %s: %w", migration.Version, migration.Name, err)
+		}
+		zap.L().Info("applied migration", zap.Uint("version", migration.Version), zap.String("name", migration.Name))
+	}
+
+	for ; current > target; current-- {
+		migration := m.migrations[current]
+		var previous uint
+		if current > 0 {
+			previous = m.migrations[current-1].Version
+		}
+		if err := m.run(ctx, conn, migration.Version, migration.Down, previous); err != nil {
+			return fmt.Errorf("reverting migration %d %s: %w", migration.Version, migration.Name, err)
+		}
+		zap.L().Info("reverted migration", zap.Uint("version", migration.Version), zap.String("name", migration.Name))
+	}
+	return nil
+}
+
+// run executes the query of a migration and sets the version it leads to. The database stays dirty
+// at the version of the migration if the query fails.
+//
+// Note: Migrations do not run in a transaction, as some statements (e.g. CREATE INDEX CONCURRENTLY) cannot.
+func (m *Migrator) run(ctx context.Context, conn *sql.Conn, version uint, query string, next uint) error {
+	if err := setVersion(ctx, conn, version, true); err != nil {
+		return err
+	}
+	if _, err := conn.ExecContext(ctx, query); err != nil {
+		return err
+	}
+	return setVersion(ctx, conn, next, false)
+}
+
+// index returns the index of the migration with the version, -1 for version 0.
+func (m *Migrator) index(version uint) (int, error) {
+	if version == 0 {
+		return -1, nil
+	}
+	i := slices.IndexFunc(m.migrations, func(migration Migration) bool {
+		return migration.Version == version
+	})
+	if i == -1 {
+		return 0, fmt.Errorf("%w: %d", ErrUnknownVersion, version)
+	}
+	return i, nil
+}
+
+// ahead reports whether the version is newer than every known migration.
+func (m *Migrator) ahead(version uint) bool {
+	return len(m.migrations) > 0 && version > m.migrations[len(m.migrations)-1].Version
+}
+
+// withLock calls fn with the index of the current migration while holding the migration lock.
+// It fails with ErrDirtyDatabase if the database is dirty and with ErrUnknownVersion if the
+// current version has no migration.
+func (m *Migrator) withLock(ctx context.Context, fn func(conn *sql.Conn, current int) error) error {
+	return m.withVersion(ctx, func(conn *sql.Conn, version uint) error {
+		current, err := m.index(version)
+		if err != nil {
+			return err
+		}
+		return fn(conn, current)
+	})
+}
+
+// withVersion calls fn with the current version while holding the migration lock.
+// It fails with ErrDirtyDatabase if the database is dirty.
+func (m *Migrator) withVersion(ctx context.Context, fn func(conn *sql.Conn, version uint) error) error {
+	return m.lock(ctx, func(conn *sql.Conn) error {
+		version, dirty, err := getVersion(ctx, conn)
+		if err != nil {
+			return err
+		}
+		if dirty {
+			return fmt.Errorf("%w: migration %d failed, fix it and force the version", ErrDirtyDatabase, version)
+		}
+		return fn(conn, version)
+	})
+}
+
+// lock calls fn with a connection holding the advisory migration lock, waiting for other
+// replicas to release it first. The schema_migrations table is created if it doesn't exist.
+func (m *Migrator) lock(ctx context.Context, fn func(conn *sql.Conn) error) (err error) {
+	// Advisory locks belong to a session, so every statement has to use the same connection.
+	conn, err := m.db.Conn(ctx)
+	if err != nil {
+		return fmt.Errorf("getting connection: %w", err)
+	}
+	defer func() {
+		closeErr := conn.Close()
+		if closeErr != nil {
+			zap.L().
+				Error(
+					"error closing connection",
+					zap.Error(closeErr),
+				)
+		}
+	}()
+
+	if _, err = conn.ExecContext(ctx, "SELECT pg_advisory_lock($1)", migrationLockKey); err != nil {
+		return fmt.Errorf("acquiring migration lock: %w", err)
+	}
+	defer func() {
+		_, unlockErr := conn.ExecContext(context.WithoutCancel(ctx), "SELECT pg_advisory_unlock($1)", migrationLockKey)
+		if unlockErr != nil && err == nil {
+			err = fmt.Errorf("releasing migration lock: %w", unlockErr)
+		}
+	}()
+
+	_, err = conn.ExecContext(
+		ctx,
+		`CREATE TABLE IF NOT EXISTS schema_migrations (
+			version BIGINT  NOT NULL PRIMARY KEY,
+			dirty   BOOLEAN NOT NULL
+		)`,
+	)
+	if err != nil {
+		return fmt.Errorf("creating schema_migrations table: %w", err)
+	}
+	return fn(conn)
+}
+
+// getVersion returns the applied version and whether it is dirty, version 0 if none is applied.
+func getVersion(ctx context.Context, conn *sql.Conn) (uint, bool, error) {
+	var version int64
+	var dirty bool
+	err := conn.QueryRowContext(ctx, "SELECT version, dirty FROM schema_migrations LIMIT 1").Scan(&version, &dirty)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, false, nil
+	} else if err != nil {
+		return 0, false, fmt.Errorf("getting migration version: %w", err)
+	}
+
+	// The migrate CLI stores -1 while reverting the first migration.
+	if version < 0 {
+		return 0, dirty, nil
+	}
+	return uint(version), dirty, nil
+}
+
+// setVersion replaces the applied version, version 0 removes it.
+func setVersion(ctx context.Context, conn *sql.Conn, version uint, dirty bool) error {
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("starting transaction: %w", err)
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	if _, err = tx.ExecContext(ctx, "DELETE FROM schema_migrations"); err != nil {
+		return fmt.Errorf("clearing migration version: %w", err)
+	}
+	if version > 0 {
+		_, err = tx.ExecContext(ctx, "INSERT INTO schema_migrations (version, dirty) VALUES ($1, $2)", int64(version), dirty)
+		if err != nil {
+			return fmt.Errorf("setting migration version: %w", err)
+		}
+	}
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("committing migration version: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,146 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"os"
+	"shop-api-go/internal/adapter/storage/postgres/migrations"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadMigrations(t *testing.T) {
+	file := func(content string) *fstest.MapFile {
+		return &fstest.MapFile{Data: []byte(content)}
+	}
+
+	tests := []struct {
+		name          string
+		fsys          fstest.MapFS
+		expected      []Migration
+		expectedError string
+	}{
+		{
+			name: "sorted by version",
+			fsys: fstest.MapFS{
+				"000010_add_index.up.sql":      file("CREATE INDEX"),
+				"000010_add_index.down.sql":    file("DROP INDEX"),
+				"000002_create_table.up.sql":   file("CREATE TABLE"),
+				"000002_create_table.down.sql": file("DROP TABLE"),
+			},
+			expected: []Migration{
+				{Version: 2, Name: "create_table", Up: "CREATE TABLE", Down: "DROP TABLE"},
+				{Version: 10, Name: "add_index", Up: "CREATE INDEX", Down: "DROP INDEX"},
+			},
+		}, {
+			name: "invalid name",
+			fsys: fstest.MapFS{
+				"create_table.up.sql": file("CREATE TABLE"),
+			},
+			expectedError: "invalid migration file name: create_table.up.sql",
+		}, {
+			name: "version 0",
+			fsys: fstest.MapFS{
+				"000000_create_table.up.sql":   file("CREATE TABLE"),
+				"000000_create_table.down.sql": file("DROP TABLE"),
+			},
+			expectedError: "invalid migration version: 000000_create_table.down.sql",
+		}, {
+			name: "missing down",
+			fsys: fstest.MapFS{
+				"000001_create_table.up.sql": file("CREATE TABLE"),
+			},
+			expectedError: "migration 1 must have up and down files",
+		}, {
+			name: "different names",
+			fsys: fstest.MapFS{
+				"000001_create_table.up.sql":   file("CREATE TABLE"),
+				"000001_create_users.down.sql": file("DROP TABLE"),
+			},
+			expectedError: "migration 1 has different names",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			loaded, err := loadMigrations(tt.fsys)
+			if tt.expectedError != "" {
+				require.ErrorContains(t, err, tt.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, loaded)
+		})
+	}
+
+	t.Run("embedded migrations", func(t *testing.T) {
+		loaded, err := loadMigrations(migrations.FS)
+		require.NoError(t, err)
+		require.NotEmpty(t, loaded)
+		for i, migration := range loaded {
+			require.Equal(t, uint(i+1), migration.Version)
+		}
+	})
+}
+
+func TestMigrator_Index(t *testing.T) {
+	migrator := &Migrator{migrations: []Migration{{Version: 1}, {Version: 3}}}
+
+	i, err := migrator.index(0)
+	require.NoError(t, err)
+	require.Equal(t, -1, i)
+
+	i, err = migrator.index(3)
+	require.NoError(t, err)
+	require.Equal(t, 1, i)
+
+	_, err = migrator.index(2)
+	require.ErrorIs(t, err, ErrUnknownVersion)
+}
+
+func TestMigrator_Ahead(t *testing.T) {
+	migrator := &Migrator{migrations: []Migration{{Version: 1}, {Version: 3}}}
+
+	require.False(t, migrator.ahead(0))
+	require.False(t, migrator.ahead(2))
+	require.False(t, migrator.ahead(3))
+	require.True(t, migrator.ahead(4))
+	require.False(t, (&Migrator{}).ahead(1))
+}
+
+func TestMigrator_VersionAhead(t *testing.T) {
+	url := os.Getenv("TEST_DATABASE_URL")
+	if url == "" {
+		t.Skip("TEST_DATABASE_URL is not set")
+	}
+	db, err := sql.Open("postgres", url)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, db.Close())
+	})
+
+	ctx := context.Background()
+	migrator, err := NewMigrator(db)
+	require.NoError(t, err)
+	require.NoError(t, migrator.Up(ctx))
+	status, err := migrator.Status(ctx)
+	require.NoError(t, err)
+
+	// A newer release applied a migration this binary does not know.
+	_, err = db.Exec("UPDATE schema_migrations SET version = version + 1")
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_, err := db.Exec("UPDATE schema_migrations SET version = $1", int64(status.Version))
+		require.NoError(t, err)
+	})
+
+	require.NoError(t, migrator.Up(ctx))
+	require.ErrorIs(t, migrator.Down(ctx, 1), ErrUnknownVersion)
+	require.ErrorIs(t, migrator.To(ctx, status.Version), ErrUnknownVersion)
+
+	ahead, err := migrator.Status(ctx)
+	require.NoError(t, err)
+	require.Equal(t, status.Version+1, ahead.Version)
+}
@@ -0,0 +1,27 @@
+CREATE TABLE user_mfa
+(
+    user_id        UUID PRIMARY KEY REFERENCES users (id) ON DELETE CASCADE,
+    secret         VARCHAR(255) NOT NULL,
+    enabled        BOOLEAN      NOT NULL DEFAULT (false),
+    last_used_step BIGINT       NOT NULL DEFAULT (0),
+    created_at     TIMESTAMP    NOT NULL DEFAULT (now()),
+    updated_at     TIMESTAMP    NOT NULL DEFAULT (now())
+);
+
+CREATE TABLE mfa_recovery_codes
+(
+    id        UUID PRIMARY KEY,
+    user_id   UUID REFERENCES users (id) ON DELETE CASCADE,
+    code_hash VARCHAR(255) NOT NULL,
+    used_at   TIMESTAMP,
+    UNIQUE (user_id, code_hash)
+);
+
+CREATE TABLE role_mfa_requirements
+(
+    role     user_role_enum PRIMARY KEY,
+    required BOOLEAN NOT NULL DEFAULT (false)
+);
+
+INSERT INTO role_mfa_requirements(role, required)
+SELECT unnest(enum_range(NULL::user_role_enum)), false;
@@ -0,0 +1,7 @@
+CREATE TABLE rate_limit_buckets
+(
+    key        VARCHAR(320)     PRIMARY KEY,
+    tokens     DOUBLE PRECISION NOT NULL,
+    allowed    BOOLEAN          NOT NULL DEFAULT (true),
+    updated_at TIMESTAMP        NOT NULL DEFAULT (now())
+);
@@ -0,0 +1,21 @@
+CREATE TABLE user_identities
+(
+    id         UUID PRIMARY KEY,
+    user_id    UUID         NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    provider   VARCHAR(64)  NOT NULL,
+    subject    VARCHAR(255) NOT NULL,
+    email      VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP    NOT NULL DEFAULT (now()),
+    UNIQUE (provider, subject)
+);
+
+CREATE INDEX user_identities_user_id_idx ON user_identities (user_id);
+
+CREATE TABLE oauth_states
+(
+    state         VARCHAR(255) PRIMARY KEY,
+    provider      VARCHAR(64)  NOT NULL,
+    nonce         VARCHAR(255) NOT NULL,
+    code_verifier VARCHAR(255) NOT NULL,
+    expires_at    TIMESTAMP    NOT NULL
+);
@@ -0,0 +1,20 @@
+UPDATE users
+SET role = 'client'
+WHERE role NOT IN ('admin', 'client', 'delivery', 'warehouse');
+
+DELETE FROM role_mfa_requirements
+WHERE role NOT IN ('admin', 'client', 'delivery', 'warehouse');
+
+CREATE TYPE user_role_enum AS ENUM ('admin', 'client', 'delivery', 'warehouse');
+
+ALTER TABLE role_mfa_requirements DROP CONSTRAINT IF EXISTS role_mfa_requirements_role_fkey;
+ALTER TABLE role_mfa_requirements ALTER COLUMN role TYPE user_role_enum USING role::user_role_enum;
+
+ALTER TABLE users DROP CONSTRAINT IF EXISTS users_role_fkey;
+ALTER TABLE users ALTER COLUMN role DROP DEFAULT;
+ALTER TABLE users ALTER COLUMN role TYPE user_role_enum USING role::user_role_enum;
+ALTER TABLE users ALTER COLUMN role SET DEFAULT ('client');
+
+DROP TABLE IF EXISTS role_permissions;
+DROP TABLE IF EXISTS permissions;
+DROP TABLE IF EXISTS roles;
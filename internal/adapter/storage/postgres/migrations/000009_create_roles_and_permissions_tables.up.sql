@@ -0,0 +1,57 @@
+CREATE TABLE roles
+(
+    name        VARCHAR(64) PRIMARY KEY,
+    description VARCHAR(255) NOT NULL DEFAULT (''),
+    built_in    BOOLEAN      NOT NULL DEFAULT (false),
+    created_at  TIMESTAMP    NOT NULL DEFAULT (now()),
+    updated_at  TIMESTAMP    NOT NULL DEFAULT (now())
+);
+
+INSERT INTO roles(name, description, built_in)
+VALUES ('admin', 'Manages users, roles and the shop.', true),
+       ('client', 'Buys products.', true),
+       ('delivery', 'Delivers orders.', true),
+       ('warehouse', 'Manages products and prepares orders.', true);
+
+CREATE TABLE permissions
+(
+    name        VARCHAR(64) PRIMARY KEY,
+    description VARCHAR(255) NOT NULL
+);
+
+INSERT INTO permissions(name, description)
+VALUES ('users:read', 'Read any user.'),
+       ('users:write', 'Update and unlock any user.'),
+       ('roles:read', 'Read roles, permissions and MFA requirements.'),
+       ('roles:write', 'Manage roles, permissions and MFA requirements.'),
+       ('products:write', 'Create, update and delete products.'),
+       ('orders:read', 'Read any order.'),
+       ('orders:ship', 'Mark orders as shipped.');
+
+CREATE TABLE role_permissions
+(
+    role       VARCHAR(64) NOT NULL REFERENCES roles (name) ON DELETE CASCADE ON UPDATE CASCADE,
+    permission VARCHAR(64) NOT NULL REFERENCES permissions (name) ON DELETE CASCADE,
+    PRIMARY KEY (role, permission)
+);
+
+INSERT INTO role_permissions(role, permission)
+SELECT 'admin', name
+FROM permissions;
+
+INSERT INTO role_permissions(role, permission)
+VALUES ('warehouse', 'products:write'),
+       ('warehouse', 'orders:read'),
+       ('delivery', 'orders:read'),
+       ('delivery', 'orders:ship');
+
+ALTER TABLE users ALTER COLUMN role DROP DEFAULT;
+ALTER TABLE users ALTER COLUMN role TYPE VARCHAR(64) USING role::text;
+ALTER TABLE users ALTER COLUMN role SET DEFAULT ('client');
+ALTER TABLE users ADD CONSTRAINT users_role_fkey FOREIGN KEY (role) REFERENCES roles (name) ON UPDATE CASCADE;
+
+ALTER TABLE role_mfa_requirements ALTER COLUMN role TYPE VARCHAR(64) USING role::text;
+ALTER TABLE role_mfa_requirements ADD CONSTRAINT role_mfa_requirements_role_fkey
+    FOREIGN KEY (role) REFERENCES roles (name) ON DELETE CASCADE ON UPDATE CASCADE;
+
+DROP TYPE user_role_enum;
@@ -0,0 +1,3 @@
+DELETE FROM permissions WHERE name IN ('api_keys:read', 'api_keys:write');
+
+DROP TABLE IF EXISTS api_keys;
@@ -0,0 +1,24 @@
+CREATE TABLE api_keys
+(
+    id           UUID PRIMARY KEY,
+    user_id      UUID           NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    name         VARCHAR(255)   NOT NULL,
+    key_type     VARCHAR(32)    NOT NULL,
+    prefix       VARCHAR(32)    NOT NULL UNIQUE,
+    secret_hash  VARCHAR(64)    NOT NULL,
+    scopes       VARCHAR(64)[]  NOT NULL DEFAULT ('{}'),
+    expires_at   TIMESTAMP      NOT NULL,
+    last_used_at TIMESTAMP,
+    revoked_at   TIMESTAMP,
+    created_at   TIMESTAMP      NOT NULL DEFAULT (now())
+);
+
+CREATE INDEX api_keys_user_id_idx ON api_keys (user_id);
+
+INSERT INTO permissions(name, description)
+VALUES ('api_keys:read', 'Read the API keys of any user.'),
+       ('api_keys:write', 'Create and revoke API keys of any user.');
+
+INSERT INTO role_permissions(role, permission)
+VALUES ('admin', 'api_keys:read'),
+       ('admin', 'api_keys:write');
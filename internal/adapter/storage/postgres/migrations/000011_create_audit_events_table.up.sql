@@ -0,0 +1,36 @@
+CREATE TABLE audit_events
+(
+    id          UUID PRIMARY KEY,
+    actor_id    UUID,
+    action      VARCHAR(64)  NOT NULL,
+    target_type VARCHAR(64)  NOT NULL,
+    target_id   VARCHAR(255) NOT NULL,
+    changes     JSONB        NOT NULL DEFAULT ('{}'),
+    client_ip   VARCHAR(64)  NOT NULL DEFAULT (''),
+    user_agent  TEXT         NOT NULL DEFAULT (''),
+    request_id  VARCHAR(64)  NOT NULL DEFAULT (''),
+    created_at  TIMESTAMP    NOT NULL DEFAULT (now())
+);
+
+CREATE INDEX audit_events_created_at_idx ON audit_events (created_at DESC);
+CREATE INDEX audit_events_actor_id_idx ON audit_events (actor_id, created_at DESC);
+CREATE INDEX audit_events_target_idx ON audit_events (target_type, target_id, created_at DESC);
+
+CREATE FUNCTION audit_events_append_only() RETURNS TRIGGER AS
+$$
+BEGIN
+    RAISE EXCEPTION 'audit_events is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER audit_events_append_only
+    BEFORE UPDATE OR DELETE
+    ON audit_events
+    FOR EACH ROW
+EXECUTE FUNCTION audit_events_append_only();
+
+INSERT INTO permissions(name, description)
+VALUES ('audit:read', 'Read the audit log.');
+
+INSERT INTO role_permissions(role, permission)
+VALUES ('admin', 'audit:read');
@@ -0,0 +1,5 @@
+ALTER TABLE users
+    ADD COLUMN status     VARCHAR(16) NOT NULL DEFAULT ('active') CHECK ( status IN ('active', 'suspended') ),
+    ADD COLUMN deleted_at TIMESTAMP;
+
+CREATE INDEX users_deleted_at_idx ON users (deleted_at) WHERE deleted_at IS NOT NULL;
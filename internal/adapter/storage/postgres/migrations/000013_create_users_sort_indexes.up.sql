@@ -0,0 +1,3 @@
+CREATE INDEX users_created_at_id_idx ON users (created_at, id) WHERE deleted_at IS NULL;
+CREATE INDEX users_updated_at_id_idx ON users (updated_at, id) WHERE deleted_at IS NULL;
+CREATE INDEX users_email_trgm_key ON users USING gin (email gin_trgm_ops);
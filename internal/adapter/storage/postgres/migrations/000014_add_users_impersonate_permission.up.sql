@@ -0,0 +1,5 @@
+INSERT INTO permissions(name, description)
+VALUES ('users:impersonate', 'Act as another user with a short-lived access token.');
+
+INSERT INTO role_permissions(role, permission)
+VALUES ('admin', 'users:impersonate');
@@ -0,0 +1,6 @@
+DELETE FROM permissions WHERE name = 'users:privacy';
+
+DROP TABLE IF EXISTS data_requests;
+
+ALTER TABLE users
+    DROP COLUMN IF EXISTS erased_at;
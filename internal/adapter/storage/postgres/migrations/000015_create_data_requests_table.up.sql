@@ -0,0 +1,25 @@
+ALTER TABLE users
+    ADD COLUMN erased_at TIMESTAMP;
+
+CREATE TABLE data_requests
+(
+    id           UUID PRIMARY KEY,
+    user_id      UUID        NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    kind         VARCHAR(16) NOT NULL CHECK ( kind IN ('export', 'erasure') ),
+    status       VARCHAR(16) NOT NULL DEFAULT ('pending') CHECK ( status IN ('pending', 'running', 'completed', 'failed') ),
+    requested_by UUID        NOT NULL REFERENCES users (id),
+    error        TEXT        NOT NULL DEFAULT (''),
+    archive      BYTEA,
+    created_at   TIMESTAMP   NOT NULL DEFAULT (now()),
+    updated_at   TIMESTAMP   NOT NULL DEFAULT (now()),
+    completed_at TIMESTAMP
+);
+
+CREATE INDEX data_requests_queue_idx ON data_requests (created_at) WHERE status IN ('pending', 'running');
+CREATE INDEX data_requests_user_id_idx ON data_requests (user_id);
+
+INSERT INTO permissions(name, description)
+VALUES ('users:privacy', 'Export and erase the personal data of any user.');
+
+INSERT INTO role_permissions(role, permission)
+VALUES ('admin', 'users:privacy');
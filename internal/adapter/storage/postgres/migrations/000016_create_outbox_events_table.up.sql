@@ -0,0 +1,16 @@
+CREATE TABLE outbox_events
+(
+    sequence        BIGSERIAL PRIMARY KEY,
+    id              UUID        NOT NULL UNIQUE,
+    type            VARCHAR(64) NOT NULL,
+    aggregate_type  VARCHAR(64) NOT NULL,
+    aggregate_id    UUID        NOT NULL,
+    payload         JSONB       NOT NULL DEFAULT ('{}'),
+    occurred_at     TIMESTAMP   NOT NULL DEFAULT (now()),
+    attempts        INT         NOT NULL DEFAULT (0),
+    next_attempt_at TIMESTAMP   NOT NULL DEFAULT (now()),
+    error           TEXT        NOT NULL DEFAULT (''),
+    published_at    TIMESTAMP
+);
+
+CREATE INDEX outbox_events_pending_idx ON outbox_events (aggregate_type, aggregate_id, sequence) WHERE published_at IS NULL;
@@ -0,0 +1,54 @@
+CREATE TABLE webhook_endpoints
+(
+    id          UUID PRIMARY KEY,
+    url         VARCHAR(2048) NOT NULL,
+    description VARCHAR(255)  NOT NULL DEFAULT (''),
+    event_types VARCHAR(64)[] NOT NULL DEFAULT ('{}'),
+    secret      VARCHAR(64)   NOT NULL,
+    enabled     BOOLEAN       NOT NULL DEFAULT (true),
+    created_at  TIMESTAMP     NOT NULL DEFAULT (now()),
+    updated_at  TIMESTAMP     NOT NULL DEFAULT (now())
+);
+
+CREATE TABLE webhook_deliveries
+(
+    id              UUID PRIMARY KEY,
+    endpoint_id     UUID        NOT NULL REFERENCES webhook_endpoints (id) ON DELETE CASCADE,
+    event_id        UUID        NOT NULL,
+    event_type      VARCHAR(64) NOT NULL,
+    aggregate_type  VARCHAR(64) NOT NULL,
+    aggregate_id    UUID        NOT NULL,
+    payload         JSONB       NOT NULL DEFAULT ('{}'),
+    occurred_at     TIMESTAMP   NOT NULL,
+    status          VARCHAR(16) NOT NULL DEFAULT ('pending') CHECK ( status IN ('pending', 'succeeded', 'dead') ),
+    attempts        INT         NOT NULL DEFAULT (0),
+    next_attempt_at TIMESTAMP   NOT NULL DEFAULT (now()),
+    last_error      TEXT        NOT NULL DEFAULT (''),
+    created_at      TIMESTAMP   NOT NULL DEFAULT (now()),
+    updated_at      TIMESTAMP   NOT NULL DEFAULT (now()),
+    delivered_at    TIMESTAMP,
+    UNIQUE (endpoint_id, event_id)
+);
+
+CREATE INDEX webhook_deliveries_pending_idx ON webhook_deliveries (next_attempt_at) WHERE status = 'pending';
+CREATE INDEX webhook_deliveries_endpoint_id_idx ON webhook_deliveries (endpoint_id, created_at DESC);
+
+CREATE TABLE webhook_delivery_attempts
+(
+    id              UUID PRIMARY KEY,
+    delivery_id     UUID      NOT NULL REFERENCES webhook_deliveries (id) ON DELETE CASCADE,
+    response_status INT       NOT NULL DEFAULT (0),
+    error           TEXT      NOT NULL DEFAULT (''),
+    duration_ms     INT       NOT NULL,
+    attempted_at    TIMESTAMP NOT NULL
+);
+
+CREATE INDEX webhook_delivery_attempts_delivery_id_idx ON webhook_delivery_attempts (delivery_id, attempted_at);
+
+INSERT INTO permissions(name, description)
+VALUES ('webhooks:read', 'Read webhook endpoints and their deliveries.'),
+       ('webhooks:write', 'Manage webhook endpoints and replay deliveries.');
+
+INSERT INTO role_permissions(role, permission)
+VALUES ('admin', 'webhooks:read'),
+       ('admin', 'webhooks:write');
@@ -0,0 +1 @@
+DELETE FROM permissions WHERE name IN ('settings:read', 'settings:write');
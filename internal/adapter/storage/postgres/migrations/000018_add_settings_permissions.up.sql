@@ -0,0 +1,7 @@
+INSERT INTO permissions(name, description)
+VALUES ('settings:read', 'Read the runtime settings.'),
+       ('settings:write', 'Change the runtime settings, e.g. the log level, rate limits, token lifetimes and feature flags.');
+
+INSERT INTO role_permissions(role, permission)
+VALUES ('admin', 'settings:read'),
+       ('admin', 'settings:write');
@@ -0,0 +1,2 @@
+ALTER TABLE oauth_states
+    ADD COLUMN user_id UUID REFERENCES users (id) ON DELETE CASCADE;
@@ -0,0 +1,8 @@
+DROP FUNCTION IF EXISTS audit_changes_erased(JSONB);
+
+CREATE OR REPLACE FUNCTION audit_events_append_only() RETURNS TRIGGER AS
+$$
+BEGIN
+    RAISE EXCEPTION 'audit_events is append-only';
+END;
+$$ LANGUAGE plpgsql;
@@ -0,0 +1,41 @@
+-- Erasures redact the personal data in the changes, client IP and user agent of audit events while
+-- shop.audit_redaction is on in their transaction. Nothing else of an event can change and events are never deleted.
+CREATE OR REPLACE FUNCTION audit_events_append_only() RETURNS TRIGGER AS
+$$
+BEGIN
+    IF TG_OP = 'UPDATE'
+        AND current_setting('shop.audit_redaction', true) = 'on'
+        AND (NEW.id, NEW.action, NEW.target_type, NEW.target_id, NEW.request_id, NEW.created_at)
+            = (OLD.id, OLD.action, OLD.target_type, OLD.target_id, OLD.request_id, OLD.created_at)
+        AND NEW.actor_id IS NOT DISTINCT FROM OLD.actor_id THEN
+        RETURN NEW;
+    END IF;
+    RAISE EXCEPTION 'audit_events is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+-- audit_changes_erased replaces the usernames and emails in audit changes and drops the usernames
+-- and client IPs from the login attempt keys of blocked logins, e.g. blockedUntil:user:johnsmith.
+CREATE FUNCTION audit_changes_erased(changes JSONB) RETURNS JSONB AS
+$$
+SELECT COALESCE(
+    jsonb_object_agg(
+        CASE
+            WHEN e.key LIKE 'blockedUntil:%' THEN split_part(e.key, ':', 1) || ':' || split_part(e.key, ':', 2)
+            ELSE e.key
+        END,
+        CASE
+            WHEN e.key IN ('username', 'email') AND jsonb_typeof(e.change) = 'object' THEN (
+                SELECT jsonb_object_agg(
+                    c.field,
+                    CASE WHEN jsonb_typeof(c.value) = 'null' THEN c.value ELSE to_jsonb('[REDACTED]'::text) END
+                )
+                FROM jsonb_each(e.change) AS c(field, value)
+            )
+            ELSE e.change
+        END
+    ),
+    '{}'::jsonb
+)
+FROM jsonb_each(changes) AS e(key, change)
+$$ LANGUAGE sql IMMUTABLE;
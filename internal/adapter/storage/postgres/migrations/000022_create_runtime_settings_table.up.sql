@@ -0,0 +1,6 @@
+CREATE TABLE runtime_settings
+(
+    id         BOOLEAN PRIMARY KEY DEFAULT (TRUE) CHECK (id),
+    settings   JSONB     NOT NULL,
+    updated_at TIMESTAMP NOT NULL DEFAULT (now())
+);
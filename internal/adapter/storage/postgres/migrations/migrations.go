@@ -0,0 +1,9 @@
+// Package migrations embeds the SQL migrations of the database schema into the binary.
+package migrations
+
+import "embed"
+
+// FS contains the migrations, named <version>_<name>.<up|down>.sql.
+//
+//go:embed *.sql
+var FS embed.FS
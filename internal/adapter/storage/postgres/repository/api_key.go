@@ -0,0 +1,227 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"shop-api-go/internal/core/domain"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"go.uber.org/zap"
+)
+
+// APIKeyRepository implements port.APIKeyRepository and provides
+// access to postgres database.
+type APIKeyRepository struct {
+	db *sql.DB
+}
+
+// NewAPIKeyRepository creates a new APIKeyRepository instance.
+func NewAPIKeyRepository(db *sql.DB) *APIKeyRepository {
+	return &APIKeyRepository{
+		db: db,
+	}
+}
+
+// selectAPIKeys selects all columns of api keys in the order expected by scanAPIKey.
+const selectAPIKeys = `SELECT id, user_id, name, key_type, prefix, secret_hash, scopes,
+	expires_at, last_used_at, revoked_at, created_at
+	FROM api_keys`
+
+func (r *APIKeyRepository) AddAPIKey(ctx context.Context, key *domain.APIKey, event *domain.AuditEvent) error {
+	scopes := make([]string, 0, len(key.Scopes))
+	for _, scope := range key.Scopes {
+		scopes = append(scopes, string(scope))
+	}
+
+	return withTx(ctx, r.db, func(tx *sql.Tx) error {
+		_, err := tx.ExecContext(
+			ctx,
+			`INSERT INTO api_keys(id, user_id, name, key_type, prefix, secret_hash, scopes, expires_at)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`,
+			key.Id,
+			key.UserId,
+			key.Name,
+			key.KeyType,
+			key.Prefix,
+			key.SecretHash,
+			pq.Array(scopes),
+			key.ExpiresAt.UTC(),
+		)
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == "23503" {
+			return domain.ErrUserNotFound
+		} else if err != nil {
+			zap.L().
+				Error(
+					"adding api key failed",
+					zap.String("userId", key.UserId.String()),
+					zap.Error(err),
+				)
+			return domain.ErrInternal
+		}
+
+		return addAuditEvent(ctx, tx, event)
+	})
+}
+
+func (r *APIKeyRepository) GetAPIKeyById(ctx context.Context, id uuid.UUID) (*domain.APIKey, error) {
+	key, err := scanAPIKey(conn(ctx, r.db).QueryRowContext(ctx, selectAPIKeys+" WHERE id = $1", id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, domain.ErrAPIKeyNotFound
+	} else if err != nil {
+		zap.L().
+			Error(
+				"fetching api key failed",
+				zap.String("id", id.String()),
+				zap.Error(err),
+			)
+		return nil, domain.ErrInternal
+	}
+	return key, nil
+}
+
+func (r *APIKeyRepository) GetAPIKeyByPrefix(ctx context.Context, prefix string) (*domain.APIKey, error) {
+	key, err := scanAPIKey(conn(ctx, r.db).QueryRowContext(ctx, selectAPIKeys+" WHERE prefix = $1", prefix))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, domain.ErrAPIKeyNotFound
+	} else if err != nil {
+		zap.L().
+			Error(
+				"fetching api key failed",
+				zap.String("prefix", prefix),
+				zap.Error(err),
+			)
+		return nil, domain.ErrInternal
+	}
+	return key, nil
+}
+
+func (r *APIKeyRepository) GetAPIKeysByUserId(ctx context.Context, userId uuid.UUID) ([]domain.APIKey, error) {
+	rows, err := conn(ctx, r.db).QueryContext(ctx, selectAPIKeys+" WHERE user_id = $1 ORDER BY created_at DESC", userId)
+	if err != nil {
+		zap.L().
+			Error(
+				"fetching api keys failed",
+				zap.String("userId", userId.String()),
+				zap.Error(err),
+			)
+		return nil, domain.ErrInternal
+	}
+
+	defer func() {
+		closeErr := rows.Close()
+		if closeErr != nil {
+			zap.L().
+				Error(
+					"error closing rows",
+					zap.Error(closeErr),
+				)
+		}
+	}()
+
+	keys := make([]domain.APIKey, 0)
+	for rows.Next() {
+		key, err := scanAPIKey(rows)
+		if err != nil {
+			zap.L().
+				Error(
+					"error parsing row",
+					zap.Error(err),
+				)
+			return nil, domain.ErrInternal
+		}
+		keys = append(keys, *key)
+	}
+
+	return keys, nil
+}
+
+func (r *APIKeyRepository) UpdateLastUsed(ctx context.Context, id uuid.UUID, usedAt time.Time) error {
+	_, err := conn(ctx, r.db).ExecContext(
+		ctx,
+		"UPDATE api_keys SET last_used_at = $1 WHERE id = $2",
+		usedAt.UTC(),
+		id,
+	)
+	if err != nil {
+		zap.L().
+			Error(
+				"updating api key last used failed",
+				zap.String("id", id.String()),
+				zap.Error(err),
+			)
+		return domain.ErrInternal
+	}
+	return nil
+}
+
+func (r *APIKeyRepository) RevokeAPIKey(ctx context.Context, id uuid.UUID, event *domain.AuditEvent) error {
+	return withTx(ctx, r.db, func(tx *sql.Tx) error {
+		result, err := tx.ExecContext(
+			ctx,
+			"UPDATE api_keys SET revoked_at = now() WHERE id = $1 AND revoked_at IS NULL",
+			id,
+		)
+		if err != nil {
+			zap.L().
+				Error(
+					"revoking api key failed",
+					zap.String("id", id.String()),
+					zap.Error(err),
+				)
+			return domain.ErrInternal
+		}
+
+		rowsAffected, err := result.RowsAffected()
+		if err != nil {
+			zap.L().
+				Error(
+					"error getting rows affected",
+					zap.Error(err),
+				)
+			return domain.ErrInternal
+		}
+		if rowsAffected == 0 {
+			return domain.ErrAPIKeyNotFound
+		}
+
+		return addAuditEvent(ctx, tx, event)
+	})
+}
+
+// scanAPIKey scans a row selected by selectAPIKeys into domain.APIKey.
+func scanAPIKey(row scanner) (*domain.APIKey, error) {
+	var key domain.APIKey
+	var scopes pq.StringArray
+	var lastUsedAt, revokedAt sql.NullTime
+	if err := row.Scan(
+		&key.Id,
+		&key.UserId,
+		&key.Name,
+		&key.KeyType,
+		&key.Prefix,
+		&key.SecretHash,
+		&scopes,
+		&key.ExpiresAt,
+		&lastUsedAt,
+		&revokedAt,
+		&key.CreatedAt,
+	); err != nil {
+		return nil, err
+	}
+
+	key.Scopes = make([]domain.Permission, 0, len(scopes))
+	for _, scope := range scopes {
+		key.Scopes = append(key.Scopes, domain.Permission(scope))
+	}
+	if lastUsedAt.Valid {
+		key.LastUsedAt = &lastUsedAt.Time
+	}
+	if revokedAt.Valid {
+		key.RevokedAt = &revokedAt.Time
+	}
+	return &key, nil
+}
@@ -0,0 +1,191 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"shop-api-go/internal/core/domain"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// AuditRepository implements port.AuditRepository and provides
+// access to postgres database.
+type AuditRepository struct {
+	db *sql.DB
+}
+
+// NewAuditRepository creates a new AuditRepository instance.
+func NewAuditRepository(db *sql.DB) *AuditRepository {
+	return &AuditRepository{
+		db: db,
+	}
+}
+
+func (r *AuditRepository) AddAuditEvent(ctx context.Context, event *domain.AuditEvent) error {
+	return addAuditEvent(ctx, conn(ctx, r.db), event)
+}
+
+func (r *AuditRepository) GetAuditEvents(ctx context.Context, filter *domain.AuditFilter) ([]domain.AuditEvent, error) {
+	conditions := make([]string, 0, 6)
+	args := make([]any, 0, 8)
+	addCondition := func(condition string, arg any) {
+		args = append(args, arg)
+		conditions = append(conditions, strings.ReplaceAll(condition, "?", "$"+strconv.Itoa(len(args))))
+	}
+
+	if filter.ActorId != nil {
+		addCondition("actor_id = ?", *filter.ActorId)
+	}
+	if filter.Action != nil {
+		addCondition("action = ?", *filter.Action)
+	}
+	if filter.TargetType != nil {
+		addCondition("target_type = ?", *filter.TargetType)
+	}
+	if filter.TargetId != nil {
+		addCondition("target_id = ?", *filter.TargetId)
+	}
+	if filter.From != nil {
+		addCondition("created_at >= ?", filter.From.UTC())
+	}
+	if filter.To != nil {
+		addCondition("created_at < ?", filter.To.UTC())
+	}
+
+	query := selectAuditEvents
+	if len(conditions) > 0 {
+		query += " WHERE " + strings.Join(conditions, " AND ")
+	}
+	args = append(args, filter.Limit, (filter.Page-1)*filter.Limit)
+	query += " ORDER BY created_at DESC, id LIMIT $" + strconv.Itoa(len(args)-1) + " OFFSET $" + strconv.Itoa(len(args))
+
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query, args...)
+	if err != nil {
+		zap.L().
+			Error(
+				"fetching audit events failed",
+				zap.Error(err),
+			)
+		return nil, domain.ErrInternal
+	}
+
+	defer func() {
+		closeErr := rows.Close()
+		if closeErr != nil {
+			zap.L().
+				Error(
+					"error closing rows",
+					zap.Error(closeErr),
+				)
+		}
+	}()
+
+	events := make([]domain.AuditEvent, 0)
+	for rows.Next() {
+		event, err := scanAuditEvent(rows)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, *event)
+	}
+
+	return events, nil
+}
+
+// selectAuditEvents selects all columns of audit events in the order expected by scanAuditEvent.
+const selectAuditEvents = `SELECT id, actor_id, action, target_type, target_id, changes,
+	client_ip, user_agent, request_id, created_at
+	FROM audit_events`
+
+// scanAuditEvent scans a row selected by selectAuditEvents into domain.AuditEvent.
+//
+// Note: Failures are logged and returned as domain.ErrInternal.
+func scanAuditEvent(row scanner) (*domain.AuditEvent, error) {
+	var event domain.AuditEvent
+	var actorId uuid.NullUUID
+	var changes []byte
+	if err := row.Scan(
+		&event.Id,
+		&actorId,
+		&event.Action,
+		&event.TargetType,
+		&event.TargetId,
+		&changes,
+		&event.ClientIP,
+		&event.UserAgent,
+		&event.RequestId,
+		&event.CreatedAt,
+	); err != nil {
+		zap.L().
+			Error(
+				"error parsing row",
+				zap.Error(err),
+			)
+		return nil, domain.ErrInternal
+	}
+	if err := json.Unmarshal(changes, &event.Changes); err != nil {
+		zap.L().
+			Error(
+				"error parsing audit changes",
+				zap.String("id", event.Id.String()),
+				zap.Error(err),
+			)
+		return nil, domain.ErrInternal
+	}
+	if actorId.Valid {
+		event.ActorId = &actorId.UUID
+	}
+	return &event, nil
+}
+
+// addAuditEvent appends an event to the audit log using the executor,
+// so that it can be written in the same transaction as the audited change.
+func addAuditEvent(ctx context.Context, executor execer, event *domain.AuditEvent) error {
+	changes, err := json.Marshal(event.Changes)
+	if err != nil {
+		zap.L().
+			Error(
+				"encoding audit changes failed",
+				zap.String("action", string(event.Action)),
+				zap.Error(err),
+			)
+		return domain.ErrInternal
+	}
+
+	var actorId uuid.NullUUID
+	if event.ActorId != nil {
+		actorId = uuid.NullUUID{UUID: *event.ActorId, Valid: true}
+	}
+
+	_, err = executor.ExecContext(
+		ctx,
+		`INSERT INTO audit_events(id, actor_id, action, target_type, target_id, changes,
+		client_ip, user_agent, request_id, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)`,
+		event.Id,
+		actorId,
+		event.Action,
+		event.TargetType,
+		event.TargetId,
+		changes,
+		event.ClientIP,
+		event.UserAgent,
+		event.RequestId,
+		event.CreatedAt.UTC(),
+	)
+	if err != nil {
+		zap.L().
+			Error(
+				"adding audit event failed",
+				zap.String("action", string(event.Action)),
+				zap.String("targetId", event.TargetId),
+				zap.Error(err),
+			)
+		return domain.ErrInternal
+	}
+	return nil
+}
@@ -20,33 +20,36 @@ func NewTokenRepository(db *sql.DB) *TokenRepository {
 	return &TokenRepository{db: db}
 }
 
-func (t *TokenRepository) AddToken(ctx context.Context, token *domain.Token) error {
-	_, err := t.db.ExecContext(
-		ctx,
-		`INSERT INTO tokens(id, user_id, token_type, expires)
-		VALUES ($1, $2, $3, $4)`,
-		token.Id,
-		token.UserId,
-		token.TokenType,
-		token.ExpiresAt,
-	)
-	if err != nil {
-		zap.L().
-			Error(
-				"failed to insert token",
-				zap.String("tokenId", token.Id.String()),
-				zap.String("userId", token.UserId.String()),
-				zap.String("tokenType", string(token.TokenType)),
-				zap.String("userRole", string(token.UserRole)),
-				zap.Error(err),
-			)
-		return domain.ErrInternal
-	}
-	return nil
+func (t *TokenRepository) AddToken(ctx context.Context, token *domain.Token, event *domain.AuditEvent) error {
+	return withTx(ctx, t.db, func(tx *sql.Tx) error {
+		_, err := tx.ExecContext(
+			ctx,
+			`INSERT INTO tokens(id, user_id, token_type, expires)
+			VALUES ($1, $2, $3, $4)`,
+			token.Id,
+			token.UserId,
+			token.TokenType,
+			token.ExpiresAt,
+		)
+		if err != nil {
+			zap.L().
+				Error(
+					"failed to insert token",
+					zap.String("tokenId", token.Id.String()),
+					zap.String("userId", token.UserId.String()),
+					zap.String("tokenType", string(token.TokenType)),
+					zap.String("userRole", string(token.UserRole)),
+					zap.Error(err),
+				)
+			return domain.ErrInternal
+		}
+
+		return addAuditEvent(ctx, tx, event)
+	})
 }
 
 func (t *TokenRepository) DeleteToken(ctx context.Context, id uuid.UUID) error {
-	result, err := t.db.ExecContext(ctx, "DELETE FROM tokens WHERE id = $1", id)
+	result, err := conn(ctx, t.db).ExecContext(ctx, "DELETE FROM tokens WHERE id = $1", id)
 	if err != nil {
 		zap.L().
 			Error(
@@ -73,18 +76,21 @@ func (t *TokenRepository) DeleteToken(ctx context.Context, id uuid.UUID) error {
 	return nil
 }
 
-func (t *TokenRepository) DeleteAllTokensByUserId(ctx context.Context, userId uuid.UUID) error {
-	_, err := t.db.ExecContext(ctx, "DELETE FROM tokens WHERE user_id = $1", userId)
-	if err != nil {
-		zap.L().
-			Error(
-				"failed to delete all tokens",
-				zap.String("userId", userId.String()),
-				zap.Error(err),
-			)
-		return domain.ErrInternal
-	}
-	return nil
+func (t *TokenRepository) DeleteAllTokensByUserId(ctx context.Context, userId uuid.UUID, event *domain.AuditEvent) error {
+	return withTx(ctx, t.db, func(tx *sql.Tx) error {
+		_, err := tx.ExecContext(ctx, "DELETE FROM tokens WHERE user_id = $1", userId)
+		if err != nil {
+			zap.L().
+				Error(
+					"failed to delete all tokens",
+					zap.String("userId", userId.String()),
+					zap.Error(err),
+				)
+			return domain.ErrInternal
+		}
+
+		return addAuditEvent(ctx, tx, event)
+	})
 }
 
 func (t *TokenRepository) DeleteExpiredTokens() error {
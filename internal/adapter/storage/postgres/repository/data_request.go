@@ -0,0 +1,557 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"shop-api-go/internal/core/domain"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// DataRequestRepository implements port.DataRequestRepository and provides
+// access to postgres database.
+type DataRequestRepository struct {
+	db *sql.DB
+}
+
+// NewDataRequestRepository creates a new DataRequestRepository instance.
+func NewDataRequestRepository(db *sql.DB) *DataRequestRepository {
+	return &DataRequestRepository{
+		db: db,
+	}
+}
+
+// dataRequestColumns are the columns of data requests in the order expected by scanDataRequest,
+// the archive itself is only fetched by GetDataExportArchive.
+const dataRequestColumns = `id, user_id, kind, status, requested_by, error, archive IS NOT NULL,
+	created_at, updated_at, completed_at`
+
+// eraseUserStatements anonymise the personal data of a user and delete his credentials,
+// the user itself is kept so that records referencing him stay intact.
+//
+// Note: The statements run in order, those reading the username or email run before the user is anonymised
+// and those writing the anonymised ones after. The audit events are only redacted while
+// enableAuditRedaction is in effect.
+var eraseUserStatements = []string{
+	`DELETE FROM login_attempts
+	WHERE key IN ('user:' || (SELECT lower(username) FROM users WHERE id = $1::uuid), 'mfa:' || $1::uuid::text)`,
+	`UPDATE audit_events
+	SET changes    = audit_changes_erased(changes),
+		client_ip  = CASE WHEN actor_id IS NULL OR actor_id = $1 THEN '' ELSE client_ip END,
+		user_agent = CASE WHEN actor_id IS NULL OR actor_id = $1 THEN '' ELSE user_agent END
+	WHERE actor_id = $1 OR (target_type = 'user' AND target_id = $1::text)`,
+	`UPDATE users
+	SET username   = 'erased_' || replace(id::text, '-', ''),
+		email      = replace(id::text, '-', '') || '@erased.invalid',
+		password   = '',
+		deleted_at = COALESCE(deleted_at, now()),
+		erased_at  = now(),
+		updated_at = now()
+	WHERE id = $1`,
+	`UPDATE outbox_events
+	SET payload = payload || jsonb_strip_nulls(jsonb_build_object(
+		'username', CASE WHEN payload ? 'username' THEN users.username END,
+		'email', CASE WHEN payload ? 'email' THEN users.email END
+	))
+	FROM users
+	WHERE users.id = $1 AND aggregate_type = 'user' AND aggregate_id = $1`,
+	`UPDATE webhook_deliveries
+	SET payload = payload || jsonb_strip_nulls(jsonb_build_object(
+		'username', CASE WHEN payload ? 'username' THEN users.username END,
+		'email', CASE WHEN payload ? 'email' THEN users.email END
+	))
+	FROM users
+	WHERE users.id = $1 AND aggregate_type = 'user' AND aggregate_id = $1`,
+	`DELETE FROM tokens WHERE user_id = $1`,
+	`DELETE FROM mfa_recovery_codes WHERE user_id = $1`,
+	`DELETE FROM user_mfa WHERE user_id = $1`,
+	`DELETE FROM user_identities WHERE user_id = $1`,
+	`DELETE FROM oauth_states WHERE user_id = $1`,
+	`DELETE FROM api_keys WHERE user_id = $1`,
+	`UPDATE data_requests SET archive = NULL WHERE user_id = $1 AND archive IS NOT NULL`,
+}
+
+// enableAuditRedaction lets the statements of the transaction redact personal data of audit events,
+// which are append-only otherwise.
+const enableAuditRedaction = `SELECT set_config('shop.audit_redaction', 'on', true)`
+
+// disableAuditRedaction makes audit events append-only again, for the rest of a transaction joined by the erasure.
+const disableAuditRedaction = `SELECT set_config('shop.audit_redaction', 'off', true)`
+
+func (r *DataRequestRepository) AddDataRequest(ctx context.Context, request *domain.DataRequest, event *domain.AuditEvent) error {
+	return withTx(ctx, r.db, func(tx *sql.Tx) error {
+		var erasedAt sql.NullTime
+		err := tx.QueryRowContext(
+			ctx,
+			`SELECT erased_at FROM users WHERE id = $1 FOR SHARE`,
+			request.UserId,
+		).Scan(&erasedAt)
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.ErrUserNotFound
+		} else if err != nil {
+			zap.L().
+				Error(
+					"fetching user failed",
+					zap.String("id", request.UserId.String()),
+					zap.Error(err),
+				)
+			return domain.ErrInternal
+		}
+		if erasedAt.Valid {
+			return domain.ErrUserErased
+		}
+
+		_, err = tx.ExecContext(
+			ctx,
+			`INSERT INTO data_requests(id, user_id, kind, status, requested_by, created_at, updated_at)
+			VALUES ($1, $2, $3, $4, $5, $6, $7)`,
+			request.Id,
+			request.UserId,
+			request.Kind,
+			request.Status,
+			request.RequestedBy,
+			request.CreatedAt.UTC(),
+			request.UpdatedAt.UTC(),
+		)
+		if err != nil {
+			zap.L().
+				Error(
+					"adding data request failed",
+					zap.String("userId", request.UserId.String()),
+					zap.String("kind", string(request.Kind)),
+					zap.Error(err),
+				)
+			return domain.ErrInternal
+		}
+
+		return addAuditEvent(ctx, tx, event)
+	})
+}
+
+func (r *DataRequestRepository) GetDataRequestById(ctx context.Context, id uuid.UUID) (*domain.DataRequest, error) {
+	request, err := scanDataRequest(conn(ctx, r.db).QueryRowContext(
+		ctx,
+		`SELECT `+dataRequestColumns+` FROM data_requests WHERE id = $1`,
+		id,
+	))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, domain.ErrDataRequestNotFound
+	} else if err != nil {
+		zap.L().
+			Error(
+				"fetching data request failed",
+				zap.String("id", id.String()),
+				zap.Error(err),
+			)
+		return nil, domain.ErrInternal
+	}
+	return request, nil
+}
+
+func (r *DataRequestRepository) GetDataExportArchive(ctx context.Context, id uuid.UUID) ([]byte, error) {
+	var archive []byte
+	err := conn(ctx, r.db).QueryRowContext(ctx, `SELECT archive FROM data_requests WHERE id = $1`, id).Scan(&archive)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, domain.ErrDataRequestNotFound
+	} else if err != nil {
+		zap.L().
+			Error(
+				"fetching data export archive failed",
+				zap.String("id", id.String()),
+				zap.Error(err),
+			)
+		return nil, domain.ErrInternal
+	}
+	if archive == nil {
+		return nil, domain.ErrDataExportExpired
+	}
+	return archive, nil
+}
+
+func (r *DataRequestRepository) ClaimDataRequest(ctx context.Context) (*domain.DataRequest, error) {
+	// SKIP LOCKED lets every replica run the task without claiming the same request.
+	request, err := scanDataRequest(conn(ctx, r.db).QueryRowContext(
+		ctx,
+		`UPDATE data_requests
+		SET status = $1, updated_at = now()
+		WHERE id = (
+			SELECT id
+			FROM data_requests
+			WHERE status = $2 OR (status = $1 AND updated_at < $3)
+			ORDER BY created_at
+			LIMIT 1
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING `+dataRequestColumns,
+		domain.DataRequestRunning,
+		domain.DataRequestPending,
+		time.Now().Add(-domain.DataRequestTimeout).UTC(),
+	))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, domain.ErrDataRequestNotFound
+	} else if err != nil {
+		zap.L().
+			Error(
+				"claiming data request failed",
+				zap.Error(err),
+			)
+		return nil, domain.ErrInternal
+	}
+	return request, nil
+}
+
+func (r *DataRequestRepository) GetUserData(ctx context.Context, userId uuid.UUID) (*domain.UserData, error) {
+	var data domain.UserData
+	var deletedAt sql.NullTime
+	err := conn(ctx, r.db).QueryRowContext(
+		ctx,
+		`SELECT id, username, email, role, status, created_at, updated_at, deleted_at
+		FROM users
+		WHERE id = $1`,
+		userId,
+	).Scan(
+		&data.User.Id,
+		&data.User.Username,
+		&data.User.Email,
+		&data.User.Role,
+		&data.User.Status,
+		&data.User.CreatedAt,
+		&data.User.UpdatedAt,
+		&deletedAt,
+	)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, domain.ErrUserNotFound
+	} else if err != nil {
+		zap.L().
+			Error(
+				"fetching user failed",
+				zap.String("id", userId.String()),
+				zap.Error(err),
+			)
+		return nil, domain.ErrInternal
+	}
+	if deletedAt.Valid {
+		data.User.DeletedAt = &deletedAt.Time
+	}
+
+	data.Sessions, err = queryUserData(
+		ctx,
+		conn(ctx, r.db),
+		"tokens",
+		`SELECT id, user_id, token_type, expires FROM tokens WHERE user_id = $1 ORDER BY expires DESC`,
+		userId,
+		func(row scanner) (*domain.Token, error) {
+			token := domain.Token{UserRole: data.User.Role}
+			err := row.Scan(&token.Id, &token.UserId, &token.TokenType, &token.ExpiresAt)
+			return &token, err
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	err = conn(ctx, r.db).QueryRowContext(ctx, `SELECT enabled FROM user_mfa WHERE user_id = $1`, userId).Scan(&data.MFAEnabled)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		zap.L().
+			Error(
+				"fetching user mfa failed",
+				zap.String("userId", userId.String()),
+				zap.Error(err),
+			)
+		return nil, domain.ErrInternal
+	}
+
+	data.Identities, err = queryUserData(
+		ctx,
+		conn(ctx, r.db),
+		"user_identities",
+		`SELECT id, user_id, provider, subject, email, created_at
+		FROM user_identities
+		WHERE user_id = $1
+		ORDER BY created_at`,
+		userId,
+		func(row scanner) (*domain.UserIdentity, error) {
+			var identity domain.UserIdentity
+			err := row.Scan(
+				&identity.Id,
+				&identity.UserId,
+				&identity.Provider,
+				&identity.Subject,
+				&identity.Email,
+				&identity.CreatedAt,
+			)
+			return &identity, err
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	data.APIKeys, err = queryUserData(
+		ctx,
+		conn(ctx, r.db),
+		"api_keys",
+		selectAPIKeys+` WHERE user_id = $1 ORDER BY created_at DESC`,
+		userId,
+		scanAPIKey,
+	)
+	if err != nil {
+		return nil, err
+	}
+	for i := range data.APIKeys {
+		data.APIKeys[i].SecretHash = ""
+	}
+
+	data.AuditEvents, err = queryUserData(
+		ctx,
+		conn(ctx, r.db),
+		"audit_events",
+		selectAuditEvents+` WHERE actor_id = $1 OR (target_type = 'user' AND target_id = $1::text)
+		ORDER BY created_at DESC, id`,
+		userId,
+		scanAuditEvent,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &data, nil
+}
+
+func (r *DataRequestRepository) CompleteDataExport(ctx context.Context, id uuid.UUID, archive []byte) error {
+	result, err := conn(ctx, r.db).ExecContext(
+		ctx,
+		`UPDATE data_requests
+		SET status = $2, archive = $3, completed_at = now(), updated_at = now()
+		WHERE id = $1`,
+		id,
+		domain.DataRequestCompleted,
+		archive,
+	)
+	if err != nil {
+		zap.L().
+			Error(
+				"completing data export failed",
+				zap.String("id", id.String()),
+				zap.Error(err),
+			)
+		return domain.ErrInternal
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		zap.L().
+			Error(
+				"error getting rows affected",
+				zap.Error(err),
+			)
+		return domain.ErrInternal
+	}
+	if rowsAffected == 0 {
+		return domain.ErrDataRequestNotFound
+	}
+	return nil
+}
+
+func (r *DataRequestRepository) EraseUser(ctx context.Context, request *domain.DataRequest, event *domain.AuditEvent) error {
+	return withTx(ctx, r.db, func(tx *sql.Tx) error {
+		var erasedAt sql.NullTime
+		err := tx.QueryRowContext(
+			ctx,
+			`SELECT erased_at FROM users WHERE id = $1 FOR UPDATE`,
+			request.UserId,
+		).Scan(&erasedAt)
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.ErrUserNotFound
+		} else if err != nil {
+			zap.L().
+				Error(
+					"fetching user failed",
+					zap.String("id", request.UserId.String()),
+					zap.Error(err),
+				)
+			return domain.ErrInternal
+		}
+
+		// A second erasure queued before the first one ran has nothing left to erase.
+		if !erasedAt.Valid {
+			if err = eraseUser(ctx, tx, request.UserId); err != nil {
+				return err
+			}
+		}
+
+		_, err = tx.ExecContext(
+			ctx,
+			`UPDATE data_requests SET status = $2, completed_at = now(), updated_at = now() WHERE id = $1`,
+			request.Id,
+			domain.DataRequestCompleted,
+		)
+		if err != nil {
+			zap.L().
+				Error(
+					"completing erasure failed",
+					zap.String("id", request.Id.String()),
+					zap.Error(err),
+				)
+			return domain.ErrInternal
+		}
+
+		return addAuditEvent(ctx, tx, event)
+	})
+}
+
+// eraseUser runs eraseUserStatements for the user with the redaction of audit events enabled.
+func eraseUser(ctx context.Context, tx *sql.Tx, userId uuid.UUID) error {
+	if _, err := tx.ExecContext(ctx, enableAuditRedaction); err != nil {
+		zap.L().
+			Error(
+				"enabling audit redaction failed",
+				zap.Error(err),
+			)
+		return domain.ErrInternal
+	}
+
+	for _, statement := range eraseUserStatements {
+		if _, err := tx.ExecContext(ctx, statement, userId); err != nil {
+			zap.L().
+				Error(
+					"erasing user failed",
+					zap.String("id", userId.String()),
+					zap.Error(err),
+				)
+			return domain.ErrInternal
+		}
+	}
+
+	if _, err := tx.ExecContext(ctx, disableAuditRedaction); err != nil {
+		zap.L().
+			Error(
+				"disabling audit redaction failed",
+				zap.Error(err),
+			)
+		return domain.ErrInternal
+	}
+	return nil
+}
+
+func (r *DataRequestRepository) FailDataRequest(ctx context.Context, id uuid.UUID, reason string) error {
+	_, err := conn(ctx, r.db).ExecContext(
+		ctx,
+		`UPDATE data_requests SET status = $2, error = $3, updated_at = now() WHERE id = $1`,
+		id,
+		domain.DataRequestFailed,
+		reason,
+	)
+	if err != nil {
+		zap.L().
+			Error(
+				"failing data request failed",
+				zap.String("id", id.String()),
+				zap.Error(err),
+			)
+		return domain.ErrInternal
+	}
+	return nil
+}
+
+func (r *DataRequestRepository) DeleteExpiredDataExports() error {
+	_, err := r.db.Exec(
+		`UPDATE data_requests SET archive = NULL WHERE archive IS NOT NULL AND completed_at < $1`,
+		time.Now().Add(-domain.DataExportRetention).UTC(),
+	)
+	if err != nil {
+		zap.L().
+			Error(
+				"failed to delete expired data exports",
+				zap.Error(err),
+			)
+		return domain.ErrInternal
+	}
+	return nil
+}
+
+// scanDataRequest scans a row with dataRequestColumns into domain.DataRequest.
+func scanDataRequest(row scanner) (*domain.DataRequest, error) {
+	var request domain.DataRequest
+	var completedAt sql.NullTime
+	if err := row.Scan(
+		&request.Id,
+		&request.UserId,
+		&request.Kind,
+		&request.Status,
+		&request.RequestedBy,
+		&request.Error,
+		&request.HasArchive,
+		&request.CreatedAt,
+		&request.UpdatedAt,
+		&completedAt,
+	); err != nil {
+		return nil, err
+	}
+	if completedAt.Valid {
+		request.CompletedAt = &completedAt.Time
+	}
+	return &request, nil
+}
+
+// queryUserData fetches the rows of a table holding data of a user and scans them with scan.
+func queryUserData[T any](
+	ctx context.Context,
+	db querier,
+	table string,
+	query string,
+	userId uuid.UUID,
+	scan func(row scanner) (*T, error),
+) ([]T, error) {
+	rows, err := db.QueryContext(ctx, query, userId)
+	if err != nil {
+		zap.L().
+			Error(
+				"fetching user data failed",
+				zap.String("table", table),
+				zap.String("userId", userId.String()),
+				zap.Error(err),
+			)
+		return nil, domain.ErrInternal
+	}
+
+	defer func() {
+		closeErr := rows.Close()
+		if closeErr != nil {
+			zap.L().
+				Error(
+					"error closing rows",
+					zap.Error(closeErr),
+				)
+		}
+	}()
+
+	result := make([]T, 0)
+	for rows.Next() {
+		value, err := scan(rows)
+		if err != nil {
+			zap.L().
+				Error(
+					"error parsing row",
+					zap.String("table", table),
+					zap.Error(err),
+				)
+			return nil, domain.ErrInternal
+		}
+		result = append(result, *value)
+	}
+	if err = rows.Err(); err != nil {
+		zap.L().
+			Error(
+				"error iterating rows",
+				zap.String("table", table),
+				zap.Error(err),
+			)
+		return nil, domain.ErrInternal
+	}
+
+	return result, nil
+}
@@ -0,0 +1,129 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"shop-api-go/internal/core/domain"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/require"
+)
+
+// countRowsContaining returns the number of rows of every table of the schema whose text contains the value.
+func countRowsContaining(t *testing.T, db *sql.DB, value string) map[string]int {
+	t.Helper()
+
+	rows, err := db.Query(
+		`SELECT table_name FROM information_schema.tables
+		WHERE table_schema = current_schema() AND table_type = 'BASE TABLE'`,
+	)
+	require.NoError(t, err)
+	var tables []string
+	for rows.Next() {
+		var table string
+		require.NoError(t, rows.Scan(&table))
+		tables = append(tables, table)
+	}
+	require.NoError(t, rows.Err())
+	require.NoError(t, rows.Close())
+
+	counts := make(map[string]int)
+	for _, table := range tables {
+		var count int
+		err = db.QueryRow(
+			`SELECT count(*) FROM `+pq.QuoteIdentifier(table)+` AS t WHERE strpos(lower(t::text), lower($1)) > 0`,
+			value,
+		).Scan(&count)
+		require.NoError(t, err)
+		if count > 0 {
+			counts[table] = count
+		}
+	}
+	return counts
+}
+
+func TestDataRequestRepository_EraseUser(t *testing.T) {
+	db := newTestDB(t)
+	users := NewUserRepository(db)
+	audit := NewAuditRepository(db)
+	loginAttempts := NewLoginAttemptRepository(db)
+	webhooks := NewWebhookRepository(db)
+	dataRequests := NewDataRequestRepository(db)
+
+	// Audit events are never deleted, so the personal data is unique to this run.
+	suffix := strings.ReplaceAll(uuid.NewString(), "-", "")[:12]
+	username := "erasure" + suffix
+	email := "erasure." + suffix + "@example.com"
+	userAgent := "erasure-agent/" + suffix
+	clientIP := "2001:db8::" + suffix[:4]
+	ctx := domain.ContextWithRequestMetadata(context.Background(), domain.NewRequestMetadata(clientIP, userAgent, ""))
+
+	user := domain.NewUser(uuid.New(), username, email, "password-hash", domain.Client, time.Time{}, time.Time{})
+	registered := domain.NewUserEvent(domain.EventUserRegistered, user)
+	require.NoError(t, users.AddUser(
+		ctx,
+		user,
+		domain.NewAuditEvent(ctx, &user.Id, domain.AuditUserRegistered, domain.AuditTargetUser, user.Id.String()).
+			WithChange("username", nil, username).
+			WithChange("email", nil, email),
+		[]*domain.Event{registered},
+	))
+
+	key := domain.UserLoginAttemptsKey(username)
+	_, err := loginAttempts.RecordFailedLogin(ctx, key, time.Hour)
+	require.NoError(t, err)
+	require.NoError(t, audit.AddAuditEvent(
+		ctx,
+		domain.NewAuditEvent(ctx, nil, domain.AuditLoginFailed, domain.AuditTargetUser, user.Id.String()).
+			WithChange("blockedUntil:"+key, nil, time.Now().Add(time.Minute)).
+			WithChange("blockedUntil:"+domain.IPLoginAttemptsKey(clientIP), nil, time.Now().Add(time.Minute)),
+	))
+
+	endpoint := domain.NewWebhookEndpoint("https://partner.example.com/webhooks", "", []domain.EventType{domain.EventUserRegistered})
+	require.NoError(t, webhooks.AddEndpoint(
+		context.Background(),
+		endpoint,
+		domain.NewAuditEvent(context.Background(), nil, domain.AuditWebhookCreated, domain.AuditTargetWebhook, endpoint.Id.String()),
+	))
+	t.Cleanup(func() {
+		_, err := db.Exec(`DELETE FROM webhook_endpoints WHERE id = $1`, endpoint.Id)
+		require.NoError(t, err)
+	})
+	require.NoError(t, webhooks.AddDeliveries(context.Background(), []*domain.WebhookDelivery{
+		domain.NewWebhookDelivery(endpoint.Id, registered),
+	}))
+
+	request := domain.NewDataRequest(user.Id, user.Id, domain.DataErasure)
+	require.NoError(t, dataRequests.AddDataRequest(
+		ctx,
+		request,
+		domain.NewAuditEvent(ctx, &user.Id, domain.AuditErasureRequested, domain.AuditTargetUser, user.Id.String()),
+	))
+
+	for _, value := range []string{username, email, userAgent, clientIP} {
+		require.NotEmpty(t, countRowsContaining(t, db, value), value)
+	}
+
+	require.NoError(t, dataRequests.EraseUser(
+		context.Background(),
+		request,
+		domain.NewAuditEvent(context.Background(), nil, domain.AuditUserErased, domain.AuditTargetUser, user.Id.String()),
+	))
+
+	for _, value := range []string{username, email, userAgent, clientIP} {
+		require.Empty(t, countRowsContaining(t, db, value), value)
+	}
+
+	// The events are kept, only their personal data is replaced.
+	targetId := user.Id.String()
+	events, err := audit.GetAuditEvents(context.Background(), &domain.AuditFilter{TargetId: &targetId, Page: 1, Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, events, 4)
+
+	_, err = db.Exec(`UPDATE audit_events SET changes = '{}' WHERE target_id = $1`, user.Id.String())
+	require.ErrorContains(t, err, "audit_events is append-only")
+}
@@ -0,0 +1,224 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"shop-api-go/internal/core/domain"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"go.uber.org/zap"
+)
+
+// IdentityRepository implements port.IdentityRepository and provides
+// access to postgres database.
+type IdentityRepository struct {
+	db *sql.DB
+}
+
+// NewIdentityRepository creates a new IdentityRepository instance.
+func NewIdentityRepository(db *sql.DB) *IdentityRepository {
+	return &IdentityRepository{
+		db: db,
+	}
+}
+
+func (r *IdentityRepository) GetUserIdentity(ctx context.Context, provider, subject string) (*domain.UserIdentity, error) {
+	row := conn(ctx, r.db).QueryRowContext(
+		ctx,
+		`SELECT id, user_id, provider, subject, email, created_at
+		FROM user_identities
+		WHERE provider = $1 AND subject = $2`,
+		provider,
+		subject,
+	)
+
+	var identity domain.UserIdentity
+	err := row.Scan(
+		&identity.Id,
+		&identity.UserId,
+		&identity.Provider,
+		&identity.Subject,
+		&identity.Email,
+		&identity.CreatedAt,
+	)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, domain.ErrIdentityNotFound
+	} else if err != nil {
+		zap.L().
+			Error(
+				"fetching user identity failed",
+				zap.String("provider", provider),
+				zap.String("subject", subject),
+				zap.Error(err),
+			)
+		return nil, domain.ErrInternal
+	}
+	return &identity, nil
+}
+
+func (r *IdentityRepository) AddUserIdentity(ctx context.Context, identity *domain.UserIdentity) error {
+	if err := addUserIdentity(ctx, conn(ctx, r.db), identity); err != nil {
+		zap.L().
+			Error(
+				"adding user identity failed",
+				zap.String("userId", identity.UserId.String()),
+				zap.String("provider", identity.Provider),
+				zap.Error(err),
+			)
+		return domain.ErrInternal
+	}
+	return nil
+}
+
+func (r *IdentityRepository) AddUserWithIdentity(
+	ctx context.Context,
+	user *domain.User,
+	identity *domain.UserIdentity,
+	outbox []*domain.Event,
+) error {
+	return withTx(ctx, r.db, func(tx *sql.Tx) error {
+		_, err := tx.ExecContext(
+			ctx,
+			`INSERT INTO users (id, username, email, password, role)
+			VALUES ($1, $2, $3, $4, $5)`,
+			user.Id,
+			user.Username,
+			user.Email,
+			user.Password,
+			user.Role,
+		)
+		if err != nil {
+			var pqErr *pq.Error
+			if errors.As(err, &pqErr) && pqErr.Code == "23505" {
+				switch pqErr.Constraint {
+				case "users_username_key":
+					return domain.ErrUsernameAlreadyInUse
+				case "users_email_key":
+					return domain.ErrEmailAlreadyInUse
+				}
+			}
+
+			zap.L().
+				Error(
+					"adding user failed",
+					zap.String("id", user.Id.String()),
+					zap.String("username", user.Username),
+					zap.String("email", user.Email),
+					zap.Error(err),
+				)
+			return domain.ErrInternal
+		}
+
+		if err = addUserIdentity(ctx, tx, identity); err != nil {
+			zap.L().
+				Error(
+					"adding user identity failed",
+					zap.String("userId", identity.UserId.String()),
+					zap.String("provider", identity.Provider),
+					zap.Error(err),
+				)
+			return domain.ErrInternal
+		}
+
+		return addOutboxEvents(ctx, tx, outbox)
+	})
+}
+
+func (r *IdentityRepository) AddOAuthState(ctx context.Context, state *domain.OAuthState) error {
+	var userId uuid.NullUUID
+	if state.UserId != nil {
+		userId = uuid.NullUUID{UUID: *state.UserId, Valid: true}
+	}
+
+	_, err := conn(ctx, r.db).ExecContext(
+		ctx,
+		`INSERT INTO oauth_states(state, provider, nonce, code_verifier, expires_at, user_id)
+		VALUES ($1, $2, $3, $4, $5, $6)`,
+		state.State,
+		state.Provider,
+		state.Nonce,
+		state.CodeVerifier,
+		state.ExpiresAt.UTC(),
+		userId,
+	)
+	if err != nil {
+		zap.L().
+			Error(
+				"adding oauth state failed",
+				zap.String("provider", state.Provider),
+				zap.Error(err),
+			)
+		return domain.ErrInternal
+	}
+	return nil
+}
+
+func (r *IdentityRepository) ConsumeOAuthState(ctx context.Context, state string) (*domain.OAuthState, error) {
+	row := conn(ctx, r.db).QueryRowContext(
+		ctx,
+		`DELETE FROM oauth_states
+		WHERE state = $1
+		RETURNING state, provider, nonce, code_verifier, expires_at, user_id`,
+		state,
+	)
+
+	var oauthState domain.OAuthState
+	var userId uuid.NullUUID
+	err := row.Scan(
+		&oauthState.State,
+		&oauthState.Provider,
+		&oauthState.Nonce,
+		&oauthState.CodeVerifier,
+		&oauthState.ExpiresAt,
+		&userId,
+	)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, domain.ErrInvalidOAuthState
+	} else if err != nil {
+		zap.L().
+			Error(
+				"consuming oauth state failed",
+				zap.Error(err),
+			)
+		return nil, domain.ErrInternal
+	}
+	if userId.Valid {
+		oauthState.UserId = &userId.UUID
+	}
+	return &oauthState, nil
+}
+
+func (r *IdentityRepository) DeleteExpiredOAuthStates() error {
+	_, err := r.db.Exec("DELETE FROM oauth_states WHERE expires_at < now()")
+	if err != nil {
+		zap.L().
+			Error(
+				"failed to delete expired oauth states",
+				zap.Error(err),
+			)
+		return domain.ErrInternal
+	}
+	return nil
+}
+
+// execer is implemented by both *sql.DB and *sql.Tx.
+type execer interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+}
+
+// addUserIdentity inserts an identity using the executor.
+func addUserIdentity(ctx context.Context, executor execer, identity *domain.UserIdentity) error {
+	_, err := executor.ExecContext(
+		ctx,
+		`INSERT INTO user_identities(id, user_id, provider, subject, email)
+		VALUES ($1, $2, $3, $4, $5)`,
+		identity.Id,
+		identity.UserId,
+		identity.Provider,
+		identity.Subject,
+		identity.Email,
+	)
+	return err
+}
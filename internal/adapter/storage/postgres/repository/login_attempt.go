@@ -0,0 +1,142 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"shop-api-go/internal/core/domain"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// LoginAttemptRepository implements port.LoginAttemptRepository and provides
+// access to postgres database.
+type LoginAttemptRepository struct {
+	db *sql.DB
+}
+
+// NewLoginAttemptRepository creates a new LoginAttemptRepository instance.
+func NewLoginAttemptRepository(db *sql.DB) *LoginAttemptRepository {
+	return &LoginAttemptRepository{
+		db: db,
+	}
+}
+
+func (r *LoginAttemptRepository) GetLoginAttempts(ctx context.Context, key string) (*domain.LoginAttempts, error) {
+	row := conn(ctx, r.db).QueryRowContext(
+		ctx,
+		`SELECT key, failures, last_failure_at, blocked_until
+		FROM login_attempts
+		WHERE key = $1`,
+		key,
+	)
+
+	attempts, err := scanLoginAttempts(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return domain.NewLoginAttempts(key, 0, time.Time{}, time.Time{}), nil
+	} else if err != nil {
+		zap.L().
+			Error(
+				"fetching login attempts failed",
+				zap.String("key", key),
+				zap.Error(err),
+			)
+		return nil, domain.ErrInternal
+	}
+	return attempts, nil
+}
+
+func (r *LoginAttemptRepository) RecordFailedLogin(ctx context.Context, key string, window time.Duration) (*domain.LoginAttempts, error) {
+	row := conn(ctx, r.db).QueryRowContext(
+		ctx,
+		`INSERT INTO login_attempts(key, failures, last_failure_at)
+		VALUES ($1, 1, now())
+		ON CONFLICT (key) DO UPDATE
+		SET failures = CASE
+			WHEN login_attempts.last_failure_at < now() - make_interval(secs => $2) THEN 1
+			ELSE login_attempts.failures + 1
+		END,
+		last_failure_at = now()
+		RETURNING key, failures, last_failure_at, blocked_until`,
+		key,
+		window.Seconds(),
+	)
+
+	attempts, err := scanLoginAttempts(row)
+	if err != nil {
+		zap.L().
+			Error(
+				"recording failed login failed",
+				zap.String("key", key),
+				zap.Error(err),
+			)
+		return nil, domain.ErrInternal
+	}
+	return attempts, nil
+}
+
+func (r *LoginAttemptRepository) BlockLogin(ctx context.Context, key string, until time.Time) error {
+	_, err := conn(ctx, r.db).ExecContext(
+		ctx,
+		`UPDATE login_attempts
+		SET blocked_until = $1
+		WHERE key = $2`,
+		until.UTC(),
+		key,
+	)
+	if err != nil {
+		zap.L().
+			Error(
+				"blocking login failed",
+				zap.String("key", key),
+				zap.Time("until", until),
+				zap.Error(err),
+			)
+		return domain.ErrInternal
+	}
+	return nil
+}
+
+func (r *LoginAttemptRepository) ResetLoginAttempts(ctx context.Context, key string) error {
+	_, err := conn(ctx, r.db).ExecContext(ctx, "DELETE FROM login_attempts WHERE key = $1", key)
+	if err != nil {
+		zap.L().
+			Error(
+				"resetting login attempts failed",
+				zap.String("key", key),
+				zap.Error(err),
+			)
+		return domain.ErrInternal
+	}
+	return nil
+}
+
+func (r *LoginAttemptRepository) DeleteExpiredLoginAttempts(window time.Duration) error {
+	_, err := r.db.Exec(
+		`DELETE FROM login_attempts
+		WHERE last_failure_at < now() - make_interval(secs => $1)
+		AND (blocked_until IS NULL OR blocked_until < now())`,
+		window.Seconds(),
+	)
+	if err != nil {
+		zap.L().
+			Error(
+				"failed to delete expired login attempts",
+				zap.Error(err),
+			)
+		return domain.ErrInternal
+	}
+	return nil
+}
+
+// scanLoginAttempts scans a row into domain.LoginAttempts.
+func scanLoginAttempts(row *sql.Row) (*domain.LoginAttempts, error) {
+	var attempts domain.LoginAttempts
+	var blockedUntil sql.NullTime
+	if err := row.Scan(&attempts.Key, &attempts.Failures, &attempts.LastFailureAt, &blockedUntil); err != nil {
+		return nil, err
+	}
+	attempts.BlockedUntil = blockedUntil.Time
+	return &attempts, nil
+}
@@ -0,0 +1,342 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"shop-api-go/internal/core/domain"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"go.uber.org/zap"
+)
+
+// MFARepository implements port.MFARepository and provides
+// access to postgres database.
+type MFARepository struct {
+	db *sql.DB
+}
+
+// NewMFARepository creates a new MFARepository instance.
+func NewMFARepository(db *sql.DB) *MFARepository {
+	return &MFARepository{
+		db: db,
+	}
+}
+
+func (r *MFARepository) GetUserMFA(ctx context.Context, userId uuid.UUID) (*domain.UserMFA, error) {
+	row := conn(ctx, r.db).QueryRowContext(
+		ctx,
+		`SELECT user_id, secret, enabled, last_used_step
+		FROM user_mfa
+		WHERE user_id = $1`,
+		userId,
+	)
+
+	var mfa domain.UserMFA
+	err := row.Scan(&mfa.UserId, &mfa.Secret, &mfa.Enabled, &mfa.LastUsedStep)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, domain.ErrMFANotEnrolled
+	} else if err != nil {
+		zap.L().
+			Error(
+				"fetching user mfa failed",
+				zap.String("userId", userId.String()),
+				zap.Error(err),
+			)
+		return nil, domain.ErrInternal
+	}
+	return &mfa, nil
+}
+
+func (r *MFARepository) SaveUserMFA(ctx context.Context, mfa *domain.UserMFA) error {
+	_, err := conn(ctx, r.db).ExecContext(
+		ctx,
+		`INSERT INTO user_mfa(user_id, secret, enabled, last_used_step)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (user_id) DO UPDATE
+		SET secret = EXCLUDED.secret,
+		enabled = EXCLUDED.enabled,
+		last_used_step = EXCLUDED.last_used_step,
+		updated_at = now()`,
+		mfa.UserId,
+		mfa.Secret,
+		mfa.Enabled,
+		mfa.LastUsedStep,
+	)
+	if err != nil {
+		zap.L().
+			Error(
+				"saving user mfa failed",
+				zap.String("userId", mfa.UserId.String()),
+				zap.Error(err),
+			)
+		return domain.ErrInternal
+	}
+	return nil
+}
+
+func (r *MFARepository) EnableUserMFA(ctx context.Context, userId uuid.UUID, lastUsedStep int64, codes []domain.RecoveryCode, event *domain.AuditEvent) error {
+	return withTx(ctx, r.db, func(tx *sql.Tx) error {
+		result, err := tx.ExecContext(
+			ctx,
+			`UPDATE user_mfa
+			SET enabled = true, last_used_step = $1, updated_at = now()
+			WHERE user_id = $2`,
+			lastUsedStep,
+			userId,
+		)
+		if err != nil {
+			zap.L().
+				Error(
+					"enabling user mfa failed",
+					zap.String("userId", userId.String()),
+					zap.Error(err),
+				)
+			return domain.ErrInternal
+		}
+
+		rowsAffected, err := result.RowsAffected()
+		if err != nil {
+			zap.L().
+				Error(
+					"error getting rows affected",
+					zap.Error(err),
+				)
+			return domain.ErrInternal
+		}
+		if rowsAffected == 0 {
+			return domain.ErrMFANotEnrolled
+		}
+
+		if _, err = tx.ExecContext(ctx, "DELETE FROM mfa_recovery_codes WHERE user_id = $1", userId); err != nil {
+			zap.L().
+				Error(
+					"deleting recovery codes failed",
+					zap.String("userId", userId.String()),
+					zap.Error(err),
+				)
+			return domain.ErrInternal
+		}
+
+		for _, code := range codes {
+			if _, err = tx.ExecContext(
+				ctx,
+				`INSERT INTO mfa_recovery_codes(id, user_id, code_hash)
+				VALUES ($1, $2, $3)`,
+				code.Id,
+				code.UserId,
+				code.CodeHash,
+			); err != nil {
+				zap.L().
+					Error(
+						"inserting recovery code failed",
+						zap.String("userId", userId.String()),
+						zap.Error(err),
+					)
+				return domain.ErrInternal
+			}
+		}
+
+		return addAuditEvent(ctx, tx, event)
+	})
+}
+
+func (r *MFARepository) DeleteUserMFA(ctx context.Context, userId uuid.UUID, event *domain.AuditEvent) error {
+	return withTx(ctx, r.db, func(tx *sql.Tx) error {
+		if _, err := tx.ExecContext(ctx, "DELETE FROM mfa_recovery_codes WHERE user_id = $1", userId); err != nil {
+			zap.L().
+				Error(
+					"deleting recovery codes failed",
+					zap.String("userId", userId.String()),
+					zap.Error(err),
+				)
+			return domain.ErrInternal
+		}
+
+		result, err := tx.ExecContext(ctx, "DELETE FROM user_mfa WHERE user_id = $1", userId)
+		if err != nil {
+			zap.L().
+				Error(
+					"deleting user mfa failed",
+					zap.String("userId", userId.String()),
+					zap.Error(err),
+				)
+			return domain.ErrInternal
+		}
+
+		rowsAffected, err := result.RowsAffected()
+		if err != nil {
+			zap.L().
+				Error(
+					"error getting rows affected",
+					zap.Error(err),
+				)
+			return domain.ErrInternal
+		}
+		if rowsAffected == 0 {
+			return domain.ErrMFANotEnrolled
+		}
+
+		return addAuditEvent(ctx, tx, event)
+	})
+}
+
+func (r *MFARepository) UpdateLastUsedStep(ctx context.Context, userId uuid.UUID, step int64) error {
+	result, err := conn(ctx, r.db).ExecContext(
+		ctx,
+		`UPDATE user_mfa
+		SET last_used_step = $1, updated_at = now()
+		WHERE user_id = $2 AND last_used_step < $1`,
+		step,
+		userId,
+	)
+	if err != nil {
+		zap.L().
+			Error(
+				"updating last used step failed",
+				zap.String("userId", userId.String()),
+				zap.Error(err),
+			)
+		return domain.ErrInternal
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		zap.L().
+			Error(
+				"error getting rows affected",
+				zap.Error(err),
+			)
+		return domain.ErrInternal
+	}
+	if rowsAffected == 0 {
+		return domain.ErrInvalidMFACode
+	}
+	return nil
+}
+
+func (r *MFARepository) UseRecoveryCode(ctx context.Context, userId uuid.UUID, codeHash string) error {
+	result, err := conn(ctx, r.db).ExecContext(
+		ctx,
+		`UPDATE mfa_recovery_codes
+		SET used_at = now()
+		WHERE user_id = $1 AND code_hash = $2 AND used_at IS NULL`,
+		userId,
+		codeHash,
+	)
+	if err != nil {
+		zap.L().
+			Error(
+				"using recovery code failed",
+				zap.String("userId", userId.String()),
+				zap.Error(err),
+			)
+		return domain.ErrInternal
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		zap.L().
+			Error(
+				"error getting rows affected",
+				zap.Error(err),
+			)
+		return domain.ErrInternal
+	}
+	if rowsAffected == 0 {
+		return domain.ErrInvalidMFACode
+	}
+	return nil
+}
+
+func (r *MFARepository) GetRoleMFARequirements(ctx context.Context) ([]domain.RoleMFARequirement, error) {
+	rows, err := conn(ctx, r.db).QueryContext(
+		ctx,
+		`SELECT role, required FROM role_mfa_requirements
+		ORDER BY role`,
+	)
+	if err != nil {
+		zap.L().
+			Error(
+				"fetching role mfa requirements failed",
+				zap.Error(err),
+			)
+		return nil, domain.ErrInternal
+	}
+
+	defer func() {
+		closeErr := rows.Close()
+		if closeErr != nil {
+			zap.L().
+				Error(
+					"error closing rows",
+					zap.Error(closeErr),
+				)
+		}
+	}()
+
+	requirements := make([]domain.RoleMFARequirement, 0)
+	for rows.Next() {
+		var requirement domain.RoleMFARequirement
+		if err = rows.Scan(&requirement.Role, &requirement.Required); err != nil {
+			zap.L().
+				Error(
+					"error parsing row",
+					zap.Error(err),
+				)
+			return nil, domain.ErrInternal
+		}
+		requirements = append(requirements, requirement)
+	}
+
+	return requirements, nil
+}
+
+func (r *MFARepository) IsMFARequired(ctx context.Context, role domain.UserRole) (bool, error) {
+	var required bool
+	err := conn(ctx, r.db).QueryRowContext(
+		ctx,
+		"SELECT required FROM role_mfa_requirements WHERE role = $1",
+		role,
+	).Scan(&required)
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	} else if err != nil {
+		zap.L().
+			Error(
+				"fetching role mfa requirement failed",
+				zap.String("role", string(role)),
+				zap.Error(err),
+			)
+		return false, domain.ErrInternal
+	}
+	return required, nil
+}
+
+func (r *MFARepository) SetRoleMFARequirement(ctx context.Context, requirement *domain.RoleMFARequirement, event *domain.AuditEvent) error {
+	return withTx(ctx, r.db, func(tx *sql.Tx) error {
+		_, err := tx.ExecContext(
+			ctx,
+			`INSERT INTO role_mfa_requirements(role, required)
+			VALUES ($1, $2)
+			ON CONFLICT (role) DO UPDATE SET required = EXCLUDED.required`,
+			requirement.Role,
+			requirement.Required,
+		)
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == "23503" {
+			return domain.ErrRoleNotFound
+		} else if err != nil {
+			zap.L().
+				Error(
+					"setting role mfa requirement failed",
+					zap.String("role", string(requirement.Role)),
+					zap.Error(err),
+				)
+			return domain.ErrInternal
+		}
+
+		return addAuditEvent(ctx, tx, event)
+	})
+}
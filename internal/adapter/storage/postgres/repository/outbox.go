@@ -0,0 +1,223 @@
+package repository
+
+import (
+	"cmp"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"shop-api-go/internal/core/domain"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// OutboxRepository implements port.OutboxRepository and provides
+// access to postgres database.
+type OutboxRepository struct {
+	db *sql.DB
+}
+
+// NewOutboxRepository creates a new OutboxRepository instance.
+func NewOutboxRepository(db *sql.DB) *OutboxRepository {
+	return &OutboxRepository{
+		db: db,
+	}
+}
+
+func (r *OutboxRepository) ClaimEvents(ctx context.Context, limit int) ([]domain.Event, error) {
+	// SKIP LOCKED lets every replica run the relay without claiming the same event, and the claim
+	// keeps the event from being published concurrently until it times out.
+	rows, err := conn(ctx, r.db).QueryContext(
+		ctx,
+		`UPDATE outbox_events
+		SET attempts = attempts + 1, next_attempt_at = $1
+		WHERE sequence IN (
+			SELECT o.sequence
+			FROM outbox_events o
+			WHERE o.published_at IS NULL AND o.next_attempt_at <= now()
+			AND NOT EXISTS (
+				SELECT 1 FROM outbox_events p
+				WHERE p.aggregate_type = o.aggregate_type
+				AND p.aggregate_id = o.aggregate_id
+				AND p.published_at IS NULL
+				AND p.sequence < o.sequence
+			)
+			ORDER BY o.sequence
+			LIMIT $2
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING sequence, id, type, aggregate_type, aggregate_id, payload, occurred_at, attempts`,
+		time.Now().Add(domain.EventClaimTimeout).UTC(),
+		limit,
+	)
+	if err != nil {
+		zap.L().
+			Error(
+				"claiming outbox events failed",
+				zap.Error(err),
+			)
+		return nil, domain.ErrInternal
+	}
+
+	defer func() {
+		closeErr := rows.Close()
+		if closeErr != nil {
+			zap.L().
+				Error(
+					"error closing rows",
+					zap.Error(closeErr),
+				)
+		}
+	}()
+
+	// RETURNING does not keep the order of the subquery.
+	type claimed struct {
+		sequence int64
+		event    domain.Event
+	}
+	events := make([]claimed, 0, limit)
+	for rows.Next() {
+		var c claimed
+		var payload []byte
+		if err = rows.Scan(
+			&c.sequence,
+			&c.event.Id,
+			&c.event.Type,
+			&c.event.AggregateType,
+			&c.event.AggregateId,
+			&payload,
+			&c.event.OccurredAt,
+			&c.event.Attempts,
+		); err != nil {
+			zap.L().
+				Error(
+					"error parsing outbox event",
+					zap.Error(err),
+				)
+			return nil, domain.ErrInternal
+		}
+		if err = json.Unmarshal(payload, &c.event.Payload); err != nil {
+			zap.L().
+				Error(
+					"decoding outbox event payload failed",
+					zap.String("id", c.event.Id.String()),
+					zap.Error(err),
+				)
+			return nil, domain.ErrInternal
+		}
+		events = append(events, c)
+	}
+	if err = rows.Err(); err != nil {
+		zap.L().
+			Error(
+				"error iterating rows",
+				zap.Error(err),
+			)
+		return nil, domain.ErrInternal
+	}
+
+	slices.SortFunc(events, func(a, b claimed) int {
+		return cmp.Compare(a.sequence, b.sequence)
+	})
+	result := make([]domain.Event, 0, len(events))
+	for _, c := range events {
+		result = append(result, c.event)
+	}
+	return result, nil
+}
+
+func (r *OutboxRepository) MarkEventPublished(ctx context.Context, id uuid.UUID) error {
+	_, err := conn(ctx, r.db).ExecContext(
+		ctx,
+		`UPDATE outbox_events SET published_at = now(), error = '' WHERE id = $1`,
+		id,
+	)
+	if err != nil {
+		zap.L().
+			Error(
+				"marking outbox event published failed",
+				zap.String("id", id.String()),
+				zap.Error(err),
+			)
+		return domain.ErrInternal
+	}
+	return nil
+}
+
+func (r *OutboxRepository) MarkEventFailed(ctx context.Context, id uuid.UUID, nextAttemptAt time.Time, reason string) error {
+	_, err := conn(ctx, r.db).ExecContext(
+		ctx,
+		`UPDATE outbox_events SET next_attempt_at = $1, error = $2 WHERE id = $3`,
+		nextAttemptAt.UTC(),
+		reason,
+		id,
+	)
+	if err != nil {
+		zap.L().
+			Error(
+				"storing outbox event failure failed",
+				zap.String("id", id.String()),
+				zap.Error(err),
+			)
+		return domain.ErrInternal
+	}
+	return nil
+}
+
+func (r *OutboxRepository) DeletePublishedEvents(retention time.Duration) error {
+	_, err := r.db.Exec(
+		`DELETE FROM outbox_events WHERE published_at < now() - make_interval(secs => $1)`,
+		retention.Seconds(),
+	)
+	if err != nil {
+		zap.L().
+			Error(
+				"failed to delete published outbox events",
+				zap.Error(err),
+			)
+		return domain.ErrInternal
+	}
+	return nil
+}
+
+// addOutboxEvents stores events in the outbox using the executor,
+// so that they can be written in the same transaction as the change they describe.
+func addOutboxEvents(ctx context.Context, executor execer, events []*domain.Event) error {
+	for _, event := range events {
+		payload, err := json.Marshal(event.Payload)
+		if err != nil {
+			zap.L().
+				Error(
+					"encoding outbox event payload failed",
+					zap.String("type", string(event.Type)),
+					zap.Error(err),
+				)
+			return domain.ErrInternal
+		}
+
+		_, err = executor.ExecContext(
+			ctx,
+			`INSERT INTO outbox_events(id, type, aggregate_type, aggregate_id, payload, occurred_at)
+			VALUES ($1, $2, $3, $4, $5, $6)`,
+			event.Id,
+			event.Type,
+			event.AggregateType,
+			event.AggregateId,
+			payload,
+			event.OccurredAt.UTC(),
+		)
+		if err != nil {
+			zap.L().
+				Error(
+					"adding outbox event failed",
+					zap.String("type", string(event.Type)),
+					zap.String("aggregateId", event.AggregateId.String()),
+					zap.Error(err),
+				)
+			return domain.ErrInternal
+		}
+	}
+	return nil
+}
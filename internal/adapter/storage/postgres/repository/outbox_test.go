@@ -0,0 +1,48 @@
+package repository
+
+import (
+	"context"
+	"shop-api-go/internal/core/domain"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOutboxRepository_DeletePublishedEvents(t *testing.T) {
+	db := newTestDB(t)
+	outbox := NewOutboxRepository(db)
+	ctx := context.Background()
+
+	aggregateId := uuid.New()
+	t.Cleanup(func() {
+		_, err := db.Exec(`DELETE FROM outbox_events WHERE aggregate_id = $1`, aggregateId)
+		require.NoError(t, err)
+	})
+	expired := domain.NewEvent(domain.EventUserRegistered, domain.EventAggregateUser, aggregateId, nil)
+	recent := domain.NewEvent(domain.EventUserCreated, domain.EventAggregateUser, aggregateId, nil)
+	pending := domain.NewEvent(domain.EventUserRoleChanged, domain.EventAggregateUser, aggregateId, nil)
+	require.NoError(t, addOutboxEvents(ctx, db, []*domain.Event{expired, recent, pending}))
+
+	require.NoError(t, outbox.MarkEventPublished(ctx, expired.Id))
+	require.NoError(t, outbox.MarkEventPublished(ctx, recent.Id))
+	_, err := db.Exec(`UPDATE outbox_events SET published_at = now() - interval '2 days' WHERE id = $1`, expired.Id)
+	require.NoError(t, err)
+	_, err = db.Exec(`UPDATE outbox_events SET occurred_at = now() - interval '2 days' WHERE id = $1`, pending.Id)
+	require.NoError(t, err)
+
+	require.NoError(t, outbox.DeletePublishedEvents(24*time.Hour))
+
+	rows, err := db.Query(`SELECT id FROM outbox_events WHERE aggregate_id = $1 ORDER BY sequence`, aggregateId)
+	require.NoError(t, err)
+	var ids []uuid.UUID
+	for rows.Next() {
+		var id uuid.UUID
+		require.NoError(t, rows.Scan(&id))
+		ids = append(ids, id)
+	}
+	require.NoError(t, rows.Err())
+	require.NoError(t, rows.Close())
+	require.Equal(t, []uuid.UUID{recent.Id, pending.Id}, ids)
+}
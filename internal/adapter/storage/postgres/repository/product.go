@@ -0,0 +1,353 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"shop-api-go/internal/core/domain"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"go.uber.org/zap"
+)
+
+// ProductRepository implements port.ProductRepository and provides
+// access to postgres database.
+type ProductRepository struct {
+	db *sql.DB
+}
+
+// NewProductRepository creates a new ProductRepository instance.
+func NewProductRepository(db *sql.DB) *ProductRepository {
+	return &ProductRepository{
+		db: db,
+	}
+}
+
+// selectProducts selects products without their subcategories.
+const selectProducts = `SELECT p.id, p.name, p.description, p.price, p.rating, p.count, p.image_url, p.created_at, p.updated_at
+	FROM products p`
+
+func (r *ProductRepository) GetProducts(ctx context.Context, filter *domain.ProductFilter) ([]domain.Product, error) {
+	query := selectProducts
+	args := []any{filter.Limit, (filter.Page - 1) * filter.Limit}
+	if filter.SubcategoryId != nil {
+		query += ` WHERE EXISTS (
+			SELECT 1 FROM products_subcategories ps WHERE ps.product_id = p.id AND ps.subcategory_id = $3
+		)`
+		args = append(args, *filter.SubcategoryId)
+	}
+	query += ` ORDER BY p.created_at DESC, p.id LIMIT $1 OFFSET $2`
+
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query, args...)
+	if err != nil {
+		zap.L().
+			Error(
+				"fetching products failed",
+				zap.Error(err),
+			)
+		return nil, domain.ErrInternal
+	}
+
+	defer func() {
+		closeErr := rows.Close()
+		if closeErr != nil {
+			zap.L().
+				Error(
+					"error closing rows",
+					zap.Error(closeErr),
+				)
+		}
+	}()
+
+	products := make([]domain.Product, 0, filter.Limit)
+	for rows.Next() {
+		product, err := scanProduct(rows)
+		if err != nil {
+			zap.L().
+				Error(
+					"error parsing product",
+					zap.Error(err),
+				)
+			return nil, domain.ErrInternal
+		}
+		products = append(products, *product)
+	}
+	if err = rows.Err(); err != nil {
+		zap.L().
+			Error(
+				"error iterating rows",
+				zap.Error(err),
+			)
+		return nil, domain.ErrInternal
+	}
+
+	return products, nil
+}
+
+func (r *ProductRepository) GetProductById(ctx context.Context, id uuid.UUID) (*domain.Product, error) {
+	product, err := scanProduct(conn(ctx, r.db).QueryRowContext(ctx, selectProducts+` WHERE p.id = $1`, id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, domain.ErrProductNotFound
+	} else if err != nil {
+		zap.L().
+			Error(
+				"fetching product failed",
+				zap.String("id", id.String()),
+				zap.Error(err),
+			)
+		return nil, domain.ErrInternal
+	}
+	return product, nil
+}
+
+func (r *ProductRepository) GetCategorySections(ctx context.Context) ([]domain.CategorySection, error) {
+	rows, err := conn(ctx, r.db).QueryContext(ctx, `SELECT id, name FROM category_sections ORDER BY name`)
+	if err != nil {
+		zap.L().
+			Error(
+				"fetching category sections failed",
+				zap.Error(err),
+			)
+		return nil, domain.ErrInternal
+	}
+
+	defer func() {
+		closeErr := rows.Close()
+		if closeErr != nil {
+			zap.L().
+				Error(
+					"error closing rows",
+					zap.Error(closeErr),
+				)
+		}
+	}()
+
+	sections := make([]domain.CategorySection, 0)
+	for rows.Next() {
+		var section domain.CategorySection
+		if err = rows.Scan(&section.Id, &section.Name); err != nil {
+			zap.L().
+				Error(
+					"error parsing category section",
+					zap.Error(err),
+				)
+			return nil, domain.ErrInternal
+		}
+		sections = append(sections, section)
+	}
+	if err = rows.Err(); err != nil {
+		zap.L().
+			Error(
+				"error iterating rows",
+				zap.Error(err),
+			)
+		return nil, domain.ErrInternal
+	}
+
+	return sections, nil
+}
+
+func (r *ProductRepository) GetCategorySectionsByIds(ctx context.Context, ids []uuid.UUID) (map[uuid.UUID]domain.CategorySection, error) {
+	sections, err := queryByIds(
+		ctx,
+		conn(ctx, r.db),
+		"category_sections",
+		`SELECT id, name FROM category_sections WHERE id = ANY($1::uuid[])`,
+		ids,
+		func(row scanner) (uuid.UUID, *domain.CategorySection, error) {
+			var section domain.CategorySection
+			err := row.Scan(&section.Id, &section.Name)
+			return section.Id, &section, err
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+	return firstByIds(sections), nil
+}
+
+func (r *ProductRepository) GetCategoriesByIds(ctx context.Context, ids []uuid.UUID) (map[uuid.UUID]domain.Category, error) {
+	categories, err := queryByIds(
+		ctx,
+		conn(ctx, r.db),
+		"categories",
+		`SELECT id, name, section_id FROM categories WHERE id = ANY($1::uuid[])`,
+		ids,
+		func(row scanner) (uuid.UUID, *domain.Category, error) {
+			category, err := scanCategory(row)
+			if err != nil {
+				return uuid.Nil, nil, err
+			}
+			return category.Id, category, nil
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+	return firstByIds(categories), nil
+}
+
+func (r *ProductRepository) GetCategoriesBySectionIds(ctx context.Context, sectionIds []uuid.UUID) (map[uuid.UUID][]domain.Category, error) {
+	return queryByIds(
+		ctx,
+		conn(ctx, r.db),
+		"categories",
+		`SELECT id, name, section_id FROM categories WHERE section_id = ANY($1::uuid[]) ORDER BY name`,
+		sectionIds,
+		func(row scanner) (uuid.UUID, *domain.Category, error) {
+			category, err := scanCategory(row)
+			if err != nil {
+				return uuid.Nil, nil, err
+			}
+			return category.SectionId, category, nil
+		},
+	)
+}
+
+func (r *ProductRepository) GetSubcategoriesByCategoryIds(ctx context.Context, categoryIds []uuid.UUID) (map[uuid.UUID][]domain.Subcategory, error) {
+	return queryByIds(
+		ctx,
+		conn(ctx, r.db),
+		"subcategories",
+		`SELECT id, name, category_id FROM subcategories WHERE category_id = ANY($1::uuid[]) ORDER BY name`,
+		categoryIds,
+		func(row scanner) (uuid.UUID, *domain.Subcategory, error) {
+			var subcategory domain.Subcategory
+			err := row.Scan(&subcategory.Id, &subcategory.Name, &subcategory.CategoryID)
+			return subcategory.CategoryID, &subcategory, err
+		},
+	)
+}
+
+func (r *ProductRepository) GetSubcategoriesByProductIds(ctx context.Context, productIds []uuid.UUID) (map[uuid.UUID][]domain.Subcategory, error) {
+	return queryByIds(
+		ctx,
+		conn(ctx, r.db),
+		"products_subcategories",
+		`SELECT ps.product_id, s.id, s.name, s.category_id
+		FROM products_subcategories ps
+		JOIN subcategories s ON s.id = ps.subcategory_id
+		WHERE ps.product_id = ANY($1::uuid[])
+		ORDER BY s.name`,
+		productIds,
+		func(row scanner) (uuid.UUID, *domain.Subcategory, error) {
+			var productId uuid.UUID
+			var categoryId uuid.NullUUID
+			var subcategory domain.Subcategory
+			err := row.Scan(&productId, &subcategory.Id, &subcategory.Name, &categoryId)
+			subcategory.CategoryID = categoryId.UUID
+			return productId, &subcategory, err
+		},
+	)
+}
+
+// scanProduct scans a row selected by selectProducts into domain.Product.
+func scanProduct(row scanner) (*domain.Product, error) {
+	var product domain.Product
+	err := row.Scan(
+		&product.Id,
+		&product.Name,
+		&product.Description,
+		&product.Price,
+		&product.Rating,
+		&product.Count,
+		&product.ImageUrl,
+		&product.CreatedAt,
+		&product.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &product, nil
+}
+
+// scanCategory scans a row with the id, name and section_id of a category into domain.Category.
+//
+// Note: Categories without a section have uuid.Nil as SectionId.
+func scanCategory(row scanner) (*domain.Category, error) {
+	var category domain.Category
+	var sectionId uuid.NullUUID
+	if err := row.Scan(&category.Id, &category.Name, &sectionId); err != nil {
+		return nil, err
+	}
+	category.SectionId = sectionId.UUID
+	return &category, nil
+}
+
+// queryByIds runs a query taking an array of ids as $1 and groups the scanned rows
+// by the id returned from scan.
+func queryByIds[T any](
+	ctx context.Context,
+	db querier,
+	table string,
+	query string,
+	ids []uuid.UUID,
+	scan func(row scanner) (uuid.UUID, *T, error),
+) (map[uuid.UUID][]T, error) {
+	result := make(map[uuid.UUID][]T, len(ids))
+	if len(ids) == 0 {
+		return result, nil
+	}
+
+	params := make([]string, 0, len(ids))
+	for _, id := range ids {
+		params = append(params, id.String())
+	}
+	rows, err := db.QueryContext(ctx, query, pq.Array(params))
+	if err != nil {
+		zap.L().
+			Error(
+				"fetching by ids failed",
+				zap.String("table", table),
+				zap.Int("ids", len(ids)),
+				zap.Error(err),
+			)
+		return nil, domain.ErrInternal
+	}
+
+	defer func() {
+		closeErr := rows.Close()
+		if closeErr != nil {
+			zap.L().
+				Error(
+					"error closing rows",
+					zap.Error(closeErr),
+				)
+		}
+	}()
+
+	for rows.Next() {
+		id, value, err := scan(rows)
+		if err != nil {
+			zap.L().
+				Error(
+					"error parsing row",
+					zap.String("table", table),
+					zap.Error(err),
+				)
+			return nil, domain.ErrInternal
+		}
+		result[id] = append(result[id], *value)
+	}
+	if err = rows.Err(); err != nil {
+		zap.L().
+			Error(
+				"error iterating rows",
+				zap.String("table", table),
+				zap.Error(err),
+			)
+		return nil, domain.ErrInternal
+	}
+
+	return result, nil
+}
+
+// firstByIds keeps the first value of every id grouped by queryByIds.
+func firstByIds[T any](grouped map[uuid.UUID][]T) map[uuid.UUID]T {
+	result := make(map[uuid.UUID]T, len(grouped))
+	for id, values := range grouped {
+		result[id] = values[0]
+	}
+	return result
+}
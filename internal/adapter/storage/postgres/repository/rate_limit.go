@@ -0,0 +1,73 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"shop-api-go/internal/core/domain"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// RateLimitStore implements port.RateLimitStore and provides
+// access to postgres database.
+type RateLimitStore struct {
+	db *sql.DB
+}
+
+// NewRateLimitStore creates a new RateLimitStore instance.
+func NewRateLimitStore(db *sql.DB) *RateLimitStore {
+	return &RateLimitStore{
+		db: db,
+	}
+}
+
+func (s *RateLimitStore) Take(ctx context.Context, key string, limit domain.RateLimit) (*domain.RateLimitResult, error) {
+	// Note: The refilled amount of tokens is repeated because SET expressions
+	// can only see the values of the row before the update.
+	var tokens float64
+	var allowed bool
+	err := s.db.QueryRowContext(
+		ctx,
+		`INSERT INTO rate_limit_buckets AS b (key, tokens, allowed, updated_at)
+		VALUES ($1, $2 - 1, true, now())
+		ON CONFLICT (key) DO UPDATE
+		SET tokens = CASE
+			WHEN LEAST($2, b.tokens + EXTRACT(EPOCH FROM (now() - b.updated_at)) * $3) >= 1
+			THEN LEAST($2, b.tokens + EXTRACT(EPOCH FROM (now() - b.updated_at)) * $3) - 1
+			ELSE LEAST($2, b.tokens + EXTRACT(EPOCH FROM (now() - b.updated_at)) * $3)
+		END,
+		allowed = LEAST($2, b.tokens + EXTRACT(EPOCH FROM (now() - b.updated_at)) * $3) >= 1,
+		updated_at = now()
+		RETURNING tokens, allowed`,
+		key,
+		float64(limit.Requests),
+		limit.Rate(),
+	).Scan(&tokens, &allowed)
+	if err != nil {
+		zap.L().
+			Error(
+				"taking rate limit token failed",
+				zap.String("key", key),
+				zap.Error(err),
+			)
+		return nil, domain.ErrInternal
+	}
+	return domain.NewRateLimitResult(limit, tokens, allowed), nil
+}
+
+func (s *RateLimitStore) DeleteIdleBuckets(idle time.Duration) error {
+	_, err := s.db.Exec(
+		"DELETE FROM rate_limit_buckets WHERE updated_at < now() - make_interval(secs => $1)",
+		idle.Seconds(),
+	)
+	if err != nil {
+		zap.L().
+			Error(
+				"failed to delete idle rate limit buckets",
+				zap.Error(err),
+			)
+		return domain.ErrInternal
+	}
+	return nil
+}
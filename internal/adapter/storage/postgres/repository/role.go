@@ -0,0 +1,341 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"shop-api-go/internal/core/domain"
+
+	"github.com/lib/pq"
+	"go.uber.org/zap"
+)
+
+// RoleRepository implements port.RoleRepository and provides
+// access to postgres database.
+type RoleRepository struct {
+	db *sql.DB
+}
+
+// NewRoleRepository creates a new RoleRepository instance.
+func NewRoleRepository(db *sql.DB) *RoleRepository {
+	return &RoleRepository{
+		db: db,
+	}
+}
+
+// selectRoles selects roles with an array of their permissions.
+const selectRoles = `SELECT r.name, r.description, r.built_in,
+	COALESCE(array_agg(rp.permission ORDER BY rp.permission) FILTER (WHERE rp.permission IS NOT NULL), '{}')
+	FROM roles r
+	LEFT JOIN role_permissions rp ON rp.role = r.name`
+
+func (r *RoleRepository) GetRoles(ctx context.Context) ([]domain.Role, error) {
+	rows, err := conn(ctx, r.db).QueryContext(
+		ctx,
+		selectRoles+`
+		GROUP BY r.name
+		ORDER BY r.name`,
+	)
+	if err != nil {
+		zap.L().
+			Error(
+				"fetching roles failed",
+				zap.Error(err),
+			)
+		return nil, domain.ErrInternal
+	}
+
+	defer func() {
+		closeErr := rows.Close()
+		if closeErr != nil {
+			zap.L().
+				Error(
+					"error closing rows",
+					zap.Error(closeErr),
+				)
+		}
+	}()
+
+	roles := make([]domain.Role, 0)
+	for rows.Next() {
+		role, err := scanRole(rows)
+		if err != nil {
+			zap.L().
+				Error(
+					"error parsing row",
+					zap.Error(err),
+				)
+			return nil, domain.ErrInternal
+		}
+		roles = append(roles, *role)
+	}
+
+	return roles, nil
+}
+
+func (r *RoleRepository) GetRole(ctx context.Context, name domain.UserRole) (*domain.Role, error) {
+	row := conn(ctx, r.db).QueryRowContext(
+		ctx,
+		selectRoles+`
+		WHERE r.name = $1
+		GROUP BY r.name`,
+		name,
+	)
+
+	role, err := scanRole(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, domain.ErrRoleNotFound
+	} else if err != nil {
+		zap.L().
+			Error(
+				"fetching role failed",
+				zap.String("role", string(name)),
+				zap.Error(err),
+			)
+		return nil, domain.ErrInternal
+	}
+	return role, nil
+}
+
+func (r *RoleRepository) GetPermissions(ctx context.Context) ([]domain.PermissionInfo, error) {
+	rows, err := conn(ctx, r.db).QueryContext(ctx, "SELECT name, description FROM permissions ORDER BY name")
+	if err != nil {
+		zap.L().
+			Error(
+				"fetching permissions failed",
+				zap.Error(err),
+			)
+		return nil, domain.ErrInternal
+	}
+
+	defer func() {
+		closeErr := rows.Close()
+		if closeErr != nil {
+			zap.L().
+				Error(
+					"error closing rows",
+					zap.Error(closeErr),
+				)
+		}
+	}()
+
+	permissions := make([]domain.PermissionInfo, 0)
+	for rows.Next() {
+		var permission domain.PermissionInfo
+		if err = rows.Scan(&permission.Name, &permission.Description); err != nil {
+			zap.L().
+				Error(
+					"error parsing row",
+					zap.Error(err),
+				)
+			return nil, domain.ErrInternal
+		}
+		permissions = append(permissions, permission)
+	}
+
+	return permissions, nil
+}
+
+func (r *RoleRepository) HasPermission(ctx context.Context, role domain.UserRole, permission domain.Permission) (bool, error) {
+	var granted bool
+	err := conn(ctx, r.db).QueryRowContext(
+		ctx,
+		`SELECT EXISTS(
+			SELECT 1 FROM role_permissions
+			WHERE role = $1 AND permission = $2
+		)`,
+		role,
+		permission,
+	).Scan(&granted)
+	if err != nil {
+		zap.L().
+			Error(
+				"checking role permission failed",
+				zap.String("role", string(role)),
+				zap.String("permission", string(permission)),
+				zap.Error(err),
+			)
+		return false, domain.ErrInternal
+	}
+	return granted, nil
+}
+
+func (r *RoleRepository) AddRole(ctx context.Context, role *domain.Role, event *domain.AuditEvent) error {
+	return withTx(ctx, r.db, func(tx *sql.Tx) error {
+		_, err := tx.ExecContext(
+			ctx,
+			`INSERT INTO roles(name, description, built_in)
+			VALUES ($1, $2, false)`,
+			role.Name,
+			role.Description,
+		)
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == "23505" {
+			return domain.ErrRoleAlreadyExists
+		} else if err != nil {
+			zap.L().
+				Error(
+					"adding role failed",
+					zap.String("role", string(role.Name)),
+					zap.Error(err),
+				)
+			return domain.ErrInternal
+		}
+
+		if err = addRolePermissions(ctx, tx, role.Name, role.Permissions); err != nil {
+			return err
+		}
+
+		if _, err = tx.ExecContext(
+			ctx,
+			"INSERT INTO role_mfa_requirements(role, required) VALUES ($1, false)",
+			role.Name,
+		); err != nil {
+			zap.L().
+				Error(
+					"adding role mfa requirement failed",
+					zap.String("role", string(role.Name)),
+					zap.Error(err),
+				)
+			return domain.ErrInternal
+		}
+
+		return addAuditEvent(ctx, tx, event)
+	})
+}
+
+func (r *RoleRepository) UpdateRole(ctx context.Context, update *domain.RoleUpdate, event *domain.AuditEvent) error {
+	return withTx(ctx, r.db, func(tx *sql.Tx) error {
+		result, err := tx.ExecContext(
+			ctx,
+			`UPDATE roles
+			SET description = COALESCE($1, description), updated_at = now()
+			WHERE name = $2`,
+			update.Description,
+			update.Name,
+		)
+		if err != nil {
+			zap.L().
+				Error(
+					"updating role failed",
+					zap.String("role", string(update.Name)),
+					zap.Error(err),
+				)
+			return domain.ErrInternal
+		}
+
+		rowsAffected, err := result.RowsAffected()
+		if err != nil {
+			zap.L().
+				Error(
+					"error getting rows affected",
+					zap.Error(err),
+				)
+			return domain.ErrInternal
+		}
+		if rowsAffected == 0 {
+			return domain.ErrRoleNotFound
+		}
+
+		if update.Permissions != nil {
+			if _, err = tx.ExecContext(ctx, "DELETE FROM role_permissions WHERE role = $1", update.Name); err != nil {
+				zap.L().
+					Error(
+						"deleting role permissions failed",
+						zap.String("role", string(update.Name)),
+						zap.Error(err),
+					)
+				return domain.ErrInternal
+			}
+
+			if err = addRolePermissions(ctx, tx, update.Name, update.Permissions); err != nil {
+				return err
+			}
+		}
+
+		return addAuditEvent(ctx, tx, event)
+	})
+}
+
+func (r *RoleRepository) DeleteRole(ctx context.Context, name domain.UserRole, event *domain.AuditEvent) error {
+	return withTx(ctx, r.db, func(tx *sql.Tx) error {
+		result, err := tx.ExecContext(ctx, "DELETE FROM roles WHERE name = $1", name)
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == "23503" {
+			return domain.ErrRoleInUse
+		} else if err != nil {
+			zap.L().
+				Error(
+					"deleting role failed",
+					zap.String("role", string(name)),
+					zap.Error(err),
+				)
+			return domain.ErrInternal
+		}
+
+		rowsAffected, err := result.RowsAffected()
+		if err != nil {
+			zap.L().
+				Error(
+					"error getting rows affected",
+					zap.Error(err),
+				)
+			return domain.ErrInternal
+		}
+		if rowsAffected == 0 {
+			return domain.ErrRoleNotFound
+		}
+
+		return addAuditEvent(ctx, tx, event)
+	})
+}
+
+// addRolePermissions grants the permissions to a role within the transaction.
+func addRolePermissions(ctx context.Context, tx *sql.Tx, role domain.UserRole, permissions []domain.Permission) error {
+	names := make([]string, 0, len(permissions))
+	for _, permission := range permissions {
+		names = append(names, string(permission))
+	}
+
+	_, err := tx.ExecContext(
+		ctx,
+		`INSERT INTO role_permissions(role, permission)
+		SELECT $1, unnest($2::varchar[])
+		ON CONFLICT DO NOTHING`,
+		role,
+		pq.Array(names),
+	)
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) && pqErr.Code == "23503" {
+		return domain.ErrPermissionNotFound
+	} else if err != nil {
+		zap.L().
+			Error(
+				"adding role permissions failed",
+				zap.String("role", string(role)),
+				zap.Error(err),
+			)
+		return domain.ErrInternal
+	}
+	return nil
+}
+
+// scanner is implemented by both *sql.Row and *sql.Rows.
+type scanner interface {
+	Scan(dest ...any) error
+}
+
+// scanRole scans a row selected by selectRoles into domain.Role.
+func scanRole(row scanner) (*domain.Role, error) {
+	var role domain.Role
+	var permissions pq.StringArray
+	if err := row.Scan(&role.Name, &role.Description, &role.BuiltIn, &permissions); err != nil {
+		return nil, err
+	}
+
+	role.Permissions = make([]domain.Permission, 0, len(permissions))
+	for _, permission := range permissions {
+		role.Permissions = append(role.Permissions, domain.Permission(permission))
+	}
+	return &role, nil
+}
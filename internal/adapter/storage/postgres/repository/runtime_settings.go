@@ -0,0 +1,182 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"shop-api-go/internal/core/domain"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// storedRuntimeSettings is the JSON representation of the runtime settings,
+// limits have the requests/period format and expire times the duration format.
+type storedRuntimeSettings struct {
+	LogLevel                     string                           `json:"logLevel"`
+	RateLimitEnabled             bool                             `json:"rateLimitEnabled"`
+	RateLimits                   map[string]storedRateLimitPolicy `json:"rateLimits"`
+	AccessTokenExpireTime        string                           `json:"accessTokenExpireTime"`
+	RefreshTokenExpireTime       string                           `json:"refreshTokenExpireTime"`
+	MFATokenExpireTime           string                           `json:"mfaTokenExpireTime"`
+	ImpersonationTokenExpireTime string                           `json:"impersonationTokenExpireTime"`
+	Features                     map[domain.Feature]bool          `json:"features"`
+}
+
+// storedRateLimitPolicy is the JSON representation of the rate limit policy of a route group.
+type storedRateLimitPolicy struct {
+	Default string            `json:"default"`
+	Roles   map[string]string `json:"roles,omitempty"`
+}
+
+// RuntimeSettingsRepository implements port.RuntimeSettingsStore and keeps the runtime settings
+// in a single row of the postgres database, so they are shared by all replicas.
+type RuntimeSettingsRepository struct {
+	db *sql.DB
+}
+
+// NewRuntimeSettingsRepository creates a new RuntimeSettingsRepository instance.
+func NewRuntimeSettingsRepository(db *sql.DB) *RuntimeSettingsRepository {
+	return &RuntimeSettingsRepository{
+		db: db,
+	}
+}
+
+func (r *RuntimeSettingsRepository) LoadSettings(ctx context.Context) (*domain.RuntimeSettingsUpdate, error) {
+	var content []byte
+	err := conn(ctx, r.db).QueryRowContext(ctx, `SELECT settings FROM runtime_settings`).Scan(&content)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	} else if err != nil {
+		zap.L().
+			Error(
+				"fetching runtime settings failed",
+				zap.Error(err),
+			)
+		return nil, domain.ErrInternal
+	}
+
+	var stored storedRuntimeSettings
+	if err = json.Unmarshal(content, &stored); err != nil {
+		zap.L().
+			Error(
+				"decoding runtime settings failed",
+				zap.Error(err),
+			)
+		return nil, domain.ErrInternal
+	}
+	update, err := stored.update()
+	if err != nil {
+		zap.L().
+			Error(
+				"parsing runtime settings failed",
+				zap.Error(err),
+			)
+		return nil, domain.ErrInternal
+	}
+	return update, nil
+}
+
+func (r *RuntimeSettingsRepository) SaveSettings(ctx context.Context, settings *domain.RuntimeSettings) error {
+	content, err := json.Marshal(newStoredRuntimeSettings(settings))
+	if err != nil {
+		zap.L().
+			Error(
+				"encoding runtime settings failed",
+				zap.Error(err),
+			)
+		return domain.ErrInternal
+	}
+
+	_, err = conn(ctx, r.db).ExecContext(
+		ctx,
+		`INSERT INTO runtime_settings(settings) VALUES ($1)
+		ON CONFLICT (id) DO UPDATE SET settings = EXCLUDED.settings, updated_at = now()`,
+		content,
+	)
+	if err != nil {
+		zap.L().
+			Error(
+				"saving runtime settings failed",
+				zap.Error(err),
+			)
+		return domain.ErrInternal
+	}
+	return nil
+}
+
+// newStoredRuntimeSettings creates the JSON representation of all settings.
+func newStoredRuntimeSettings(settings *domain.RuntimeSettings) *storedRuntimeSettings {
+	rateLimits := make(map[string]storedRateLimitPolicy, len(settings.RateLimits))
+	for group, policy := range settings.RateLimits {
+		stored := storedRateLimitPolicy{Default: policy.Default.String()}
+		if len(policy.Roles) > 0 {
+			stored.Roles = make(map[string]string, len(policy.Roles))
+			for role, limit := range policy.Roles {
+				stored.Roles[string(role)] = limit.String()
+			}
+		}
+		rateLimits[group] = stored
+	}
+	features := make(map[domain.Feature]bool, len(domain.Features))
+	for _, feature := range domain.Features {
+		features[feature] = settings.FeatureEnabled(feature)
+	}
+
+	return &storedRuntimeSettings{
+		LogLevel:                     string(settings.LogLevel),
+		RateLimitEnabled:             settings.RateLimitEnabled,
+		RateLimits:                   rateLimits,
+		AccessTokenExpireTime:        settings.AccessTokenExpireTime.String(),
+		RefreshTokenExpireTime:       settings.RefreshTokenExpireTime.String(),
+		MFATokenExpireTime:           settings.MFATokenExpireTime.String(),
+		ImpersonationTokenExpireTime: settings.ImpersonationTokenExpireTime.String(),
+		Features:                     features,
+	}
+}
+
+// update parses the stored values into an update setting every field.
+func (s *storedRuntimeSettings) update() (*domain.RuntimeSettingsUpdate, error) {
+	logLevel := domain.LogLevel(s.LogLevel)
+	update := &domain.RuntimeSettingsUpdate{
+		LogLevel:         &logLevel,
+		RateLimitEnabled: &s.RateLimitEnabled,
+		RateLimits:       make(map[string]domain.RateLimitPolicy, len(s.RateLimits)),
+		Features:         s.Features,
+	}
+
+	for group, stored := range s.RateLimits {
+		limit, err := domain.ParseRateLimit(stored.Default)
+		if err != nil {
+			return nil, err
+		}
+		policy := domain.RateLimitPolicy{
+			Default: limit,
+			Roles:   make(map[domain.UserRole]domain.RateLimit, len(stored.Roles)),
+		}
+		for role, value := range stored.Roles {
+			if policy.Roles[domain.UserRole(role)], err = domain.ParseRateLimit(value); err != nil {
+				return nil, err
+			}
+		}
+		update.RateLimits[group] = policy
+	}
+
+	for _, expireTime := range []struct {
+		value  string
+		target **time.Duration
+	}{
+		{s.AccessTokenExpireTime, &update.AccessTokenExpireTime},
+		{s.RefreshTokenExpireTime, &update.RefreshTokenExpireTime},
+		{s.MFATokenExpireTime, &update.MFATokenExpireTime},
+		{s.ImpersonationTokenExpireTime, &update.ImpersonationTokenExpireTime},
+	} {
+		d, err := time.ParseDuration(expireTime.value)
+		if err != nil {
+			return nil, err
+		}
+		*expireTime.target = &d
+	}
+	return update, nil
+}
@@ -0,0 +1,82 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"shop-api-go/internal/core/domain"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// testRuntimeSettings returns configured settings and settings changed on top of them.
+func testRuntimeSettings() (*domain.RuntimeSettings, *domain.RuntimeSettings) {
+	baseline := &domain.RuntimeSettings{
+		LogLevel:         domain.LogInfo,
+		RateLimitEnabled: true,
+		RateLimits: map[string]domain.RateLimitPolicy{
+			"users": {
+				Default: domain.NewRateLimit(20, time.Minute),
+				Roles:   map[domain.UserRole]domain.RateLimit{domain.Admin: domain.NewRateLimit(100, time.Minute)},
+			},
+		},
+		AccessTokenExpireTime:        30 * time.Minute,
+		RefreshTokenExpireTime:       24 * time.Hour,
+		MFATokenExpireTime:           5 * time.Minute,
+		ImpersonationTokenExpireTime: 15 * time.Minute,
+	}
+	settings := *baseline
+	settings.LogLevel = domain.LogDebug
+	settings.AccessTokenExpireTime = 10 * time.Minute
+	settings.Features = map[domain.Feature]bool{domain.FeatureRegistration: false}
+	return baseline, &settings
+}
+
+// requireLoaded checks that the loaded update applied to the baseline restores the saved settings.
+func requireLoaded(t *testing.T, baseline, saved *domain.RuntimeSettings, update *domain.RuntimeSettingsUpdate) {
+	t.Helper()
+	loaded, err := baseline.Apply(update)
+	require.NoError(t, err)
+	require.Equal(t, saved.LogLevel, loaded.LogLevel)
+	require.Equal(t, saved.RateLimits, loaded.RateLimits)
+	require.Equal(t, saved.AccessTokenExpireTime, loaded.AccessTokenExpireTime)
+	require.False(t, loaded.FeatureEnabled(domain.FeatureRegistration))
+	require.True(t, loaded.FeatureEnabled(domain.FeatureGraphQL))
+}
+
+func TestStoredRuntimeSettings(t *testing.T) {
+	baseline, settings := testRuntimeSettings()
+	content, err := json.Marshal(newStoredRuntimeSettings(settings))
+	require.NoError(t, err)
+
+	var stored storedRuntimeSettings
+	require.NoError(t, json.Unmarshal(content, &stored))
+	update, err := stored.update()
+	require.NoError(t, err)
+	requireLoaded(t, baseline, settings, update)
+
+	stored.RateLimits["users"] = storedRateLimitPolicy{Default: "fast"}
+	_, err = stored.update()
+	require.Error(t, err)
+}
+
+func TestRuntimeSettingsRepository(t *testing.T) {
+	db := newTestDB(t)
+	_, err := db.Exec("DELETE FROM runtime_settings")
+	require.NoError(t, err)
+	store := NewRuntimeSettingsRepository(db)
+	ctx := context.Background()
+
+	update, err := store.LoadSettings(ctx)
+	require.NoError(t, err)
+	require.Nil(t, update)
+
+	baseline, settings := testRuntimeSettings()
+	for range 2 {
+		require.NoError(t, store.SaveSettings(ctx, settings))
+	}
+	update, err = store.LoadSettings(ctx)
+	require.NoError(t, err)
+	requireLoaded(t, baseline, settings, update)
+}
@@ -0,0 +1,90 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"shop-api-go/internal/core/domain"
+
+	"go.uber.org/zap"
+)
+
+// txKey is the context key of the transaction started by TxManager.WithinTx.
+type txKey struct{}
+
+// querier is implemented by both *sql.DB and *sql.Tx.
+type querier interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+// TxManager implements port.TxManager and runs functions in a postgres transaction
+// that the repositories of this package pick up from the context.
+type TxManager struct {
+	db *sql.DB
+}
+
+// NewTxManager creates a new TxManager instance.
+func NewTxManager(db *sql.DB) *TxManager {
+	return &TxManager{db: db}
+}
+
+func (m *TxManager) WithinTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	return withTx(ctx, m.db, func(tx *sql.Tx) error {
+		return fn(context.WithValue(ctx, txKey{}, tx))
+	})
+}
+
+// conn returns the transaction carried by ctx, or db if there is none.
+func conn(ctx context.Context, db *sql.DB) querier {
+	if tx, ok := ctx.Value(txKey{}).(*sql.Tx); ok {
+		return tx
+	}
+	return db
+}
+
+// withTx runs fn in a transaction that is committed if fn returns nil and rolled back otherwise.
+// If ctx already carries a transaction, fn joins it and the outermost caller commits or rolls back.
+//
+// Note: fn must return domain errors, failures of the transaction itself are logged and
+// returned as domain.ErrInternal.
+func withTx(ctx context.Context, db *sql.DB, fn func(tx *sql.Tx) error) error {
+	if tx, ok := ctx.Value(txKey{}).(*sql.Tx); ok {
+		return fn(tx)
+	}
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		zap.L().
+			Error(
+				"beginning transaction failed",
+				zap.Error(err),
+			)
+		return domain.ErrInternal
+	}
+	defer func() {
+		rollbackErr := tx.Rollback()
+		if rollbackErr != nil && !errors.Is(rollbackErr, sql.ErrTxDone) {
+			zap.L().
+				Error(
+					"rolling back transaction failed",
+					zap.Error(rollbackErr),
+				)
+		}
+	}()
+
+	if err = fn(tx); err != nil {
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		zap.L().
+			Error(
+				"committing transaction failed",
+				zap.Error(err),
+			)
+		return domain.ErrInternal
+	}
+	return nil
+}
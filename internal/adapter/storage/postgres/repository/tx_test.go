@@ -0,0 +1,137 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"shop-api-go/internal/core/domain"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeConnector is a driver.Connector recording the statements and transaction calls
+// of its connections by their first word, e.g. "begin", "DELETE" or "rollback".
+type fakeConnector struct {
+	mu    sync.Mutex
+	calls []string
+}
+
+func (c *fakeConnector) record(call string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.calls = append(c.calls, call)
+}
+
+func (c *fakeConnector) Connect(context.Context) (driver.Conn, error) {
+	return &fakeConn{connector: c}, nil
+}
+
+func (c *fakeConnector) Driver() driver.Driver {
+	return nil
+}
+
+type fakeConn struct {
+	connector *fakeConnector
+}
+
+func (c *fakeConn) Prepare(string) (driver.Stmt, error) {
+	return nil, errors.New("not supported")
+}
+
+func (c *fakeConn) Close() error {
+	return nil
+}
+
+func (c *fakeConn) Begin() (driver.Tx, error) {
+	c.connector.record("begin")
+	return &fakeTx{connector: c.connector}, nil
+}
+
+func (c *fakeConn) ExecContext(_ context.Context, query string, _ []driver.NamedValue) (driver.Result, error) {
+	c.connector.record(strings.Fields(query)[0])
+	return driver.RowsAffected(1), nil
+}
+
+type fakeTx struct {
+	connector *fakeConnector
+}
+
+func (t *fakeTx) Commit() error {
+	t.connector.record("commit")
+	return nil
+}
+
+func (t *fakeTx) Rollback() error {
+	t.connector.record("rollback")
+	return nil
+}
+
+func TestTxManager_WithinTx(t *testing.T) {
+	userId := uuid.New()
+	event := domain.NewAuditEvent(context.Background(), nil, domain.AuditTokensRevoked, domain.AuditTargetUser, userId.String())
+
+	tests := []struct {
+		name          string
+		fn            func(ctx context.Context, tokenRepository *TokenRepository) error
+		expectedCalls []string
+		expectedError error
+	}{
+		{
+			name: "commits repository calls",
+			fn: func(ctx context.Context, tokenRepository *TokenRepository) error {
+				if err := tokenRepository.DeleteToken(ctx, uuid.New()); err != nil {
+					return err
+				}
+				return tokenRepository.DeleteAllTokensByUserId(ctx, userId, event)
+			},
+			expectedCalls: []string{"begin", "DELETE", "DELETE", "INSERT", "commit"},
+		}, {
+			name: "rolls back repository calls on error",
+			fn: func(ctx context.Context, tokenRepository *TokenRepository) error {
+				if err := tokenRepository.DeleteAllTokensByUserId(ctx, userId, event); err != nil {
+					return err
+				}
+				return domain.ErrInternal
+			},
+			expectedCalls: []string{"begin", "DELETE", "INSERT", "rollback"},
+			expectedError: domain.ErrInternal,
+		}, {
+			name: "nested call joins outer transaction",
+			fn: func(ctx context.Context, tokenRepository *TokenRepository) error {
+				return NewTxManager(tokenRepository.db).WithinTx(ctx, func(ctx context.Context) error {
+					return tokenRepository.DeleteToken(ctx, uuid.New())
+				})
+			},
+			expectedCalls: []string{"begin", "DELETE", "commit"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			connector := &fakeConnector{}
+			db := sql.OpenDB(connector)
+			defer db.Close()
+			tokenRepository := NewTokenRepository(db)
+
+			err := NewTxManager(db).WithinTx(context.Background(), func(ctx context.Context) error {
+				return tt.fn(ctx, tokenRepository)
+			})
+			require.ErrorIs(t, err, tt.expectedError)
+			require.Equal(t, tt.expectedCalls, connector.calls)
+		})
+	}
+
+	t.Run("repository calls outside run without transaction", func(t *testing.T) {
+		connector := &fakeConnector{}
+		db := sql.OpenDB(connector)
+		defer db.Close()
+
+		require.NoError(t, NewTokenRepository(db).DeleteToken(context.Background(), uuid.New()))
+		require.Equal(t, []string{"DELETE"}, connector.calls)
+	})
+}
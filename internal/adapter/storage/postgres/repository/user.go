@@ -5,6 +5,8 @@ import (
 	"database/sql"
 	"errors"
 	"shop-api-go/internal/core/domain"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -25,165 +27,192 @@ func NewUserRepository(db *sql.DB) *UserRepository {
 	}
 }
 
-func (r *UserRepository) AddUser(ctx context.Context, user *domain.User) error {
-	_, err := r.db.ExecContext(
-		ctx,
-		`INSERT INTO users (id, username, email, password)
-		VALUES ($1, $2, $3, $4)`,
-		user.Id,
-		user.Username,
-		user.Email,
-		user.Password,
-	)
+func (r *UserRepository) AddUser(ctx context.Context, user *domain.User, event *domain.AuditEvent, outbox []*domain.Event) error {
+	return withTx(ctx, r.db, func(tx *sql.Tx) error {
+		_, err := tx.ExecContext(
+			ctx,
+			`INSERT INTO users (id, username, email, password, role)
+			VALUES ($1, $2, $3, $4, $5)`,
+			user.Id,
+			user.Username,
+			user.Email,
+			user.Password,
+			user.Role,
+		)
 
-	if err != nil {
-		var pqErr *pq.Error
-		if errors.As(err, &pqErr) && pqErr.Code == "23505" {
-			switch pqErr.Constraint {
-			case "users_username_key":
-				return domain.ErrUsernameAlreadyInUse
-			case "users_email_key":
-				return domain.ErrEmailAlreadyInUse
+		if err != nil {
+			var pqErr *pq.Error
+			if errors.As(err, &pqErr) && pqErr.Code == "23505" {
+				switch pqErr.Constraint {
+				case "users_username_key":
+					return domain.ErrUsernameAlreadyInUse
+				case "users_email_key":
+					return domain.ErrEmailAlreadyInUse
+				}
+			} else if errors.As(err, &pqErr) && pqErr.Code == "23503" && pqErr.Constraint == "users_role_fkey" {
+				return domain.ErrRoleNotFound
 			}
-		}
-
-		zap.L().
-			Error(
-				"adding user failed",
-				zap.String("id", user.Id.String()),
-				zap.String("username", user.Username),
-				zap.String("email", user.Email),
-				zap.Error(err),
-			)
-		return domain.ErrInternal
-	}
-	return nil
-}
-
-func (r *UserRepository) GetUserByUsername(ctx context.Context, username string) (*domain.User, error) {
-	var user domain.User
-	row := r.db.QueryRowContext(
-		ctx,
-		`SELECT id, username, email, password, role FROM users
-                WHERE username = $1`,
-		username,
-	)
 
-	err := row.Scan(&user.Id, &user.Username, &user.Email, &user.Password, &user.Role)
-	if err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
-			return nil, domain.ErrUserNotFound
-		} else {
 			zap.L().
 				Error(
-					"fetching user failed",
-					zap.String("username", username),
+					"adding user failed",
+					zap.String("id", user.Id.String()),
+					zap.String("username", user.Username),
+					zap.String("email", user.Email),
 					zap.Error(err),
 				)
-			return nil, domain.ErrInternal
+			return domain.ErrInternal
 		}
-	}
-	return &user, nil
-}
 
-func (r *UserRepository) GetUserById(ctx context.Context, id uuid.UUID) (*domain.User, error) {
-	row := r.db.QueryRowContext(
-		ctx,
-		`SELECT id, username, email, role, created_at, updated_at 
-		FROM users
-		WHERE id = $1`,
-		id)
+		if err = addAuditEvent(ctx, tx, event); err != nil {
+			return err
+		}
+		return addOutboxEvents(ctx, tx, outbox)
+	})
+}
 
-	var user domain.User
-	err := row.Scan(&user.Id, &user.Username, &user.Email, &user.Role, &user.CreatedAt, &user.UpdatedAt)
+func (r *UserRepository) AddUsers(ctx context.Context, rows []domain.UserImportRow, events []*domain.AuditEvent, outbox []*domain.Event) error {
+	return withTx(ctx, r.db, func(tx *sql.Tx) error {
+		if err := checkImportConflicts(ctx, tx, rows); err != nil {
+			return err
+		}
 
-	if errors.Is(err, sql.ErrNoRows) {
-		return nil, domain.ErrUserNotFound
-	} else if err != nil {
-		zap.L().
-			Error(
-				"fetching user failed",
-				zap.String("id", id.String()),
-				zap.Error(err),
+		for _, row := range rows {
+			_, err := tx.ExecContext(
+				ctx,
+				`INSERT INTO users (id, username, email, password, role)
+				VALUES ($1, $2, $3, $4, $5)`,
+				row.User.Id,
+				row.User.Username,
+				row.User.Email,
+				row.User.Password,
+				row.User.Role,
 			)
-		return nil, domain.ErrInternal
-	}
-	return &user, nil
+
+			var pqErr *pq.Error
+			if errors.As(err, &pqErr) && pqErr.Code == "23503" && pqErr.Constraint == "users_role_fkey" {
+				return domain.NewUserImportError([]domain.UserImportRowError{{Line: row.Line, Err: domain.ErrRoleNotFound}})
+			} else if errors.As(err, &pqErr) && pqErr.Code == "23505" && pqErr.Constraint == "users_username_key" {
+				return domain.NewUserImportError([]domain.UserImportRowError{{Line: row.Line, Err: domain.ErrUsernameAlreadyInUse}})
+			} else if errors.As(err, &pqErr) && pqErr.Code == "23505" && pqErr.Constraint == "users_email_key" {
+				return domain.NewUserImportError([]domain.UserImportRowError{{Line: row.Line, Err: domain.ErrEmailAlreadyInUse}})
+			} else if err != nil {
+				zap.L().
+					Error(
+						"importing user failed",
+						zap.Int("line", row.Line),
+						zap.String("username", row.User.Username),
+						zap.String("email", row.User.Email),
+						zap.Error(err),
+					)
+				return domain.ErrInternal
+			}
+		}
+
+		for _, event := range events {
+			if err := addAuditEvent(ctx, tx, event); err != nil {
+				return err
+			}
+		}
+		return addOutboxEvents(ctx, tx, outbox)
+	})
 }
 
-func (r *UserRepository) GetUsersByOffestPagination(ctx context.Context, page, limit int, role *domain.UserRole) ([]domain.User, error) {
-	rows, err := r.db.QueryContext(
+func (r *UserRepository) CheckImportConflicts(ctx context.Context, rows []domain.UserImportRow) error {
+	return checkImportConflicts(ctx, conn(ctx, r.db), rows)
+}
+
+// checkImportConflicts returns a domain.UserImportError with every row whose username or email
+// is already used by an existing user, including soft-deleted ones.
+func checkImportConflicts(ctx context.Context, q querier, rows []domain.UserImportRow) error {
+	usernames := make([]string, 0, len(rows))
+	emails := make([]string, 0, len(rows))
+	for _, row := range rows {
+		usernames = append(usernames, row.User.Username)
+		emails = append(emails, row.User.Email)
+	}
+
+	result, err := q.QueryContext(
 		ctx,
-		`SELECT id, username, email, role, created_at, updated_at 
-		FROM users
-		WHERE $1::user_role_enum IS NULL OR $1::user_role_enum = role
-		OFFSET $2 LIMIT $3`,
-		role,
-		(page-1)*limit,
-		limit,
+		`SELECT username, email FROM users
+		WHERE username = ANY($1::varchar[]) OR email = ANY($2::varchar[])`,
+		pq.Array(usernames),
+		pq.Array(emails),
 	)
 	if err != nil {
 		zap.L().
 			Error(
-				"fetching users failed",
-				zap.Int("page", page),
-				zap.Int("limit", limit),
+				"checking import conflicts failed",
 				zap.Error(err),
 			)
-		return nil, domain.ErrInternal
+		return domain.ErrInternal
 	}
 
 	defer func() {
-		closeErr := rows.Close()
+		closeErr := result.Close()
 		if closeErr != nil {
 			zap.L().
 				Error(
-					"closing rows failed",
+					"error closing rows",
 					zap.Error(closeErr),
 				)
 		}
 	}()
 
-	users := make([]domain.User, 0, limit)
-	for rows.Next() {
-		var user domain.User
-		scanErr := rows.Scan(&user.Id, &user.Username, &user.Email, &user.Role, &user.CreatedAt, &user.UpdatedAt)
-		if scanErr != nil {
+	takenUsernames := make(map[string]struct{})
+	takenEmails := make(map[string]struct{})
+	for result.Next() {
+		var username, email string
+		if err = result.Scan(&username, &email); err != nil {
 			zap.L().
 				Error(
 					"error parsing row",
-					zap.Error(scanErr),
+					zap.Error(err),
 				)
-			return users, domain.ErrInternal
+			return domain.ErrInternal
 		}
-
-		users = append(users, user)
+		takenUsernames[username] = struct{}{}
+		takenEmails[email] = struct{}{}
+	}
+	if err = result.Err(); err != nil {
+		zap.L().
+			Error(
+				"error iterating rows",
+				zap.Error(err),
+			)
+		return domain.ErrInternal
 	}
 
-	return users, nil
+	var rowErrors []domain.UserImportRowError
+	for _, row := range rows {
+		if _, ok := takenUsernames[row.User.Username]; ok {
+			rowErrors = append(rowErrors, domain.UserImportRowError{Line: row.Line, Err: domain.ErrUsernameAlreadyInUse})
+		} else if _, ok = takenEmails[row.User.Email]; ok {
+			rowErrors = append(rowErrors, domain.UserImportRowError{Line: row.Line, Err: domain.ErrEmailAlreadyInUse})
+		}
+	}
+	if len(rowErrors) > 0 {
+		return domain.NewUserImportError(rowErrors)
+	}
+	return nil
 }
 
-func (r *UserRepository) GetUsersByTimePagination(ctx context.Context, after time.Time, limit int, role *domain.UserRole) ([]domain.User, error) {
-	rows, err := r.db.QueryContext(
+func (r *UserRepository) ExportUsers(ctx context.Context, filter *domain.UserFilter, fn func(user *domain.User) error) error {
+	conditions := newUserConditions(filter)
+	rows, err := conn(ctx, r.db).QueryContext(
 		ctx,
-		`SELECT id, username, email, role, created_at, updated_at FROM users
-		WHERE created_at > $1 AND ($2::user_role_enum IS NULL OR $2::user_role_enum = role)
-		ORDER BY created_at
-		LIMIT $3`,
-		after,
-		role,
-		limit,
+		`SELECT id, username, email, role, status, created_at, updated_at FROM users`+
+			conditions.where()+
+			" ORDER BY created_at, id",
+		conditions.args...,
 	)
-
 	if err != nil {
 		zap.L().
 			Error(
-				"error fetching users",
-				zap.Time("after", after),
-				zap.Int("limit", limit),
+				"error exporting users",
 				zap.Error(err),
 			)
-		return nil, domain.ErrInternal
+		return domain.ErrInternal
 	}
 
 	defer func() {
@@ -197,41 +226,225 @@ func (r *UserRepository) GetUsersByTimePagination(ctx context.Context, after tim
 		}
 	}()
 
-	users := make([]domain.User, 0, limit)
 	for rows.Next() {
 		var user domain.User
-		err = rows.Scan(&user.Id, &user.Username, &user.Email, &user.Role, &user.CreatedAt, &user.UpdatedAt)
+		err = rows.Scan(&user.Id, &user.Username, &user.Email, &user.Role, &user.Status, &user.CreatedAt, &user.UpdatedAt)
 		if err != nil {
 			zap.L().
 				Error(
 					"error parsing row",
 					zap.Error(err),
 				)
-			return nil, domain.ErrInternal
+			return domain.ErrInternal
+		}
+
+		if err = fn(&user); err != nil {
+			return err
 		}
-		users = append(users, user)
 	}
 
-	return users, nil
+	if err = rows.Err(); err != nil {
+		zap.L().
+			Error(
+				"error iterating rows",
+				zap.Error(err),
+			)
+		return domain.ErrInternal
+	}
+	return nil
 }
 
-func (r *UserRepository) SearchUserByUsername(ctx context.Context, username string, limit int, role *domain.UserRole) ([]domain.User, error) {
-	rows, err := r.db.QueryContext(
+func (r *UserRepository) GetUserByUsername(ctx context.Context, username string) (*domain.User, error) {
+	var user domain.User
+	row := conn(ctx, r.db).QueryRowContext(
 		ctx,
-		`SELECT id, username, email, role, created_at, updated_at FROM users
-		WHERE username % $1 AND ($2::user_role_enum IS NULL OR $2::user_role_enum = role)
-		ORDER BY similarity(username, $1) DESC
-		LIMIT $3`,
+		`SELECT id, username, email, password, role, status FROM users
+                WHERE username = $1 AND deleted_at IS NULL`,
 		username,
-		role,
-		limit,
 	)
 
+	err := row.Scan(&user.Id, &user.Username, &user.Email, &user.Password, &user.Role, &user.Status)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrUserNotFound
+		} else {
+			zap.L().
+				Error(
+					"fetching user failed",
+					zap.String("username", username),
+					zap.Error(err),
+				)
+			return nil, domain.ErrInternal
+		}
+	}
+	return &user, nil
+}
+
+func (r *UserRepository) GetUserByEmail(ctx context.Context, email string) (*domain.User, error) {
+	row := conn(ctx, r.db).QueryRowContext(
+		ctx,
+		`SELECT id, username, email, role, status, created_at, updated_at
+		FROM users
+		WHERE email = $1 AND deleted_at IS NULL`,
+		email)
+
+	var user domain.User
+	err := row.Scan(&user.Id, &user.Username, &user.Email, &user.Role, &user.Status, &user.CreatedAt, &user.UpdatedAt)
+
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, domain.ErrUserNotFound
+	} else if err != nil {
+		zap.L().
+			Error(
+				"fetching user failed",
+				zap.String("email", email),
+				zap.Error(err),
+			)
+		return nil, domain.ErrInternal
+	}
+	return &user, nil
+}
+
+func (r *UserRepository) GetUserById(ctx context.Context, id uuid.UUID) (*domain.User, error) {
+	row := conn(ctx, r.db).QueryRowContext(
+		ctx,
+		`SELECT id, username, email, role, status, created_at, updated_at
+		FROM users
+		WHERE id = $1 AND deleted_at IS NULL`,
+		id)
+
+	var user domain.User
+	err := row.Scan(&user.Id, &user.Username, &user.Email, &user.Role, &user.Status, &user.CreatedAt, &user.UpdatedAt)
+
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, domain.ErrUserNotFound
+	} else if err != nil {
+		zap.L().
+			Error(
+				"fetching user failed",
+				zap.String("id", id.String()),
+				zap.Error(err),
+			)
+		return nil, domain.ErrInternal
+	}
+	return &user, nil
+}
+
+// userSortColumns maps the fields users can be sorted by to their columns.
+var userSortColumns = map[domain.UserSortField]string{
+	domain.UserSortId:        "id",
+	domain.UserSortUsername:  "username",
+	domain.UserSortEmail:     "email",
+	domain.UserSortRole:      "role",
+	domain.UserSortStatus:    "status",
+	domain.UserSortCreatedAt: "created_at",
+	domain.UserSortUpdatedAt: "updated_at",
+}
+
+// userConditions builds the WHERE clause of queries filtering users.
+type userConditions struct {
+	conditions []string
+	args       []any
+}
+
+// newUserConditions creates a new userConditions instance matching the users that are
+// not deleted and match the filter.
+func newUserConditions(filter *domain.UserFilter) *userConditions {
+	c := &userConditions{
+		conditions: []string{"deleted_at IS NULL"},
+		args:       make([]any, 0, 12),
+	}
+
+	if filter.Id != nil {
+		c.add("id = ?", *filter.Id)
+	}
+	if filter.Username != nil {
+		c.add("username % ?::varchar", *filter.Username)
+	}
+	if filter.Email != nil {
+		c.add("similarity(email, ?::varchar) > 0.6", *filter.Email)
+	}
+	if filter.Role != nil {
+		c.add("role = ?", *filter.Role)
+	}
+	if filter.Status != nil {
+		c.add("status = ?", *filter.Status)
+	}
+	if filter.CreatedFrom != nil {
+		c.add("created_at >= ?", filter.CreatedFrom.UTC())
+	}
+	if filter.CreatedTo != nil {
+		c.add("created_at < ?", filter.CreatedTo.UTC())
+	}
+	if filter.UpdatedFrom != nil {
+		c.add("updated_at >= ?", filter.UpdatedFrom.UTC())
+	}
+	if filter.UpdatedTo != nil {
+		c.add("updated_at < ?", filter.UpdatedTo.UTC())
+	}
+	return c
+}
+
+// add adds a condition, replacing each ? with the placeholder of the next argument.
+func (c *userConditions) add(condition string, args ...any) {
+	for _, arg := range args {
+		c.args = append(c.args, arg)
+		condition = strings.Replace(condition, "?", "$"+strconv.Itoa(len(c.args)), 1)
+	}
+	c.conditions = append(c.conditions, condition)
+}
+
+// where returns the WHERE clause of the conditions.
+func (c *userConditions) where() string {
+	return " WHERE " + strings.Join(c.conditions, " AND ")
+}
+
+func (r *UserRepository) GetUsers(ctx context.Context, get *domain.GetUsers) ([]domain.User, error) {
+	column, ok := userSortColumns[get.SortBy]
+	if !ok {
+		return nil, domain.ErrInvalidQuery
+	}
+	direction, operator := "ASC", ">"
+	if get.Descending {
+		direction, operator = "DESC", "<"
+	}
+
+	conditions := newUserConditions(&get.Filter)
+	if get.Cursor != nil {
+		switch get.SortBy {
+		case domain.UserSortId:
+			conditions.add("id "+operator+" ?", get.Cursor.Id)
+		case domain.UserSortCreatedAt, domain.UserSortUpdatedAt:
+			after, err := time.Parse(time.RFC3339Nano, get.Cursor.Value)
+			if err != nil {
+				return nil, domain.ErrInvalidCursor
+			}
+			conditions.add("("+column+", id) "+operator+" (?, ?)", after.UTC(), get.Cursor.Id)
+		default:
+			conditions.add("("+column+", id) "+operator+" (?::varchar, ?)", get.Cursor.Value, get.Cursor.Id)
+		}
+	}
+
+	limit := *get.Limit
+	query := `SELECT id, username, email, role, status, created_at, updated_at FROM users` +
+		conditions.where() +
+		" ORDER BY " + column + " " + direction
+	if get.SortBy != domain.UserSortId {
+		query += ", id " + direction
+	}
+	args := append(conditions.args, limit)
+	query += " LIMIT $" + strconv.Itoa(len(args))
+	if get.Page != nil {
+		args = append(args, (*get.Page-1)*limit)
+		query += " OFFSET $" + strconv.Itoa(len(args))
+	}
+
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query, args...)
 	if err != nil {
 		zap.L().
 			Error(
-				"error searching for user",
-				zap.String("username", username),
+				"error fetching users",
+				zap.String("sortBy", string(get.SortBy)),
 				zap.Int("limit", limit),
 				zap.Error(err),
 			)
@@ -252,7 +465,7 @@ func (r *UserRepository) SearchUserByUsername(ctx context.Context, username stri
 	users := make([]domain.User, 0, limit)
 	for rows.Next() {
 		var user domain.User
-		err = rows.Scan(&user.Id, &user.Username, &user.Email, &user.Role, &user.CreatedAt, &user.UpdatedAt)
+		err = rows.Scan(&user.Id, &user.Username, &user.Email, &user.Role, &user.Status, &user.CreatedAt, &user.UpdatedAt)
 		if err != nil {
 			zap.L().
 				Error(
@@ -267,101 +480,106 @@ func (r *UserRepository) SearchUserByUsername(ctx context.Context, username stri
 	return users, nil
 }
 
-func (r *UserRepository) SearchUserByEmail(ctx context.Context, email string, limit int, role *domain.UserRole) ([]domain.User, error) {
+func (r *UserRepository) CountUsers(ctx context.Context, filter *domain.UserFilter) (int, error) {
+	conditions := newUserConditions(filter)
 
-	rows, err := r.db.QueryContext(
+	var count int
+	err := conn(ctx, r.db).QueryRowContext(
 		ctx,
-		`SELECT id, username, email, role, created_at, updated_at FROM users
-		WHERE similarity(email, $1) > 0.6 AND ($2::user_role_enum IS NULL OR $2::user_role_enum = role)
-		ORDER BY similarity(email, $1) DESC
-		limit $3`,
-		email,
-		role,
-		limit,
-	)
-
+		`SELECT count(*) FROM users`+conditions.where(),
+		conditions.args...,
+	).Scan(&count)
 	if err != nil {
 		zap.L().
 			Error(
-				"error searching for user",
-				zap.String("email", email),
-				zap.Int("limit", limit),
+				"error counting users",
 				zap.Error(err),
 			)
-		return nil, domain.ErrInternal
+		return 0, domain.ErrInternal
 	}
+	return count, nil
+}
+
+func (r *UserRepository) UpdateUser(ctx context.Context, update *domain.UserUpdate, event *domain.AuditEvent, outbox []*domain.Event) error {
+	return withTx(ctx, r.db, func(tx *sql.Tx) error {
+		result, err := tx.ExecContext(
+			ctx,
+			`UPDATE users 
+			SET username = COALESCE($1, username),
+			email = COALESCE($2, email),
+			password = COALESCE($3, password),
+			role = COALESCE($4, role),
+			updated_at = now()
+			WHERE id = $5 AND deleted_at IS NULL`,
+			update.Username,
+			update.Email,
+			update.Password,
+			update.Role,
+			update.Id,
+		)
+
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == "23505" && pqErr.Constraint == "users_username_key" {
+			return domain.ErrUsernameAlreadyInUse
+		} else if errors.As(err, &pqErr) && pqErr.Code == "23505" && pqErr.Constraint == "users_email_key" {
+			return domain.ErrEmailAlreadyInUse
+		} else if errors.As(err, &pqErr) && pqErr.Code == "23503" && pqErr.Constraint == "users_role_fkey" {
+			return domain.ErrRoleNotFound
+		} else if err != nil {
+			zapFields := make([]zap.Field, 0, 4)
+			if update.Username != nil {
+				zapFields = append(zapFields, zap.String("username", *update.Username))
+			}
+			if update.Email != nil {
+				zapFields = append(zapFields, zap.String("email", *update.Email))
+			}
+			if update.Role != nil {
+				zapFields = append(zapFields, zap.String("role", string(*update.Role)))
+			}
+			zapFields = append(zapFields, zap.Error(err))
 
-	defer func() {
-		closeErr := rows.Close()
-		if closeErr != nil {
 			zap.L().
 				Error(
-					"error closing rows",
-					zap.Error(closeErr),
+					"error updating user",
+					zapFields...,
 				)
+			return domain.ErrInternal
 		}
-	}()
 
-	users := make([]domain.User, 0, limit)
-	for rows.Next() {
-		var user domain.User
-		err = rows.Scan(&user.Id, &user.Username, &user.Email, &user.Role, &user.CreatedAt, &user.UpdatedAt)
+		rowsAffected, err := result.RowsAffected()
 		if err != nil {
 			zap.L().
 				Error(
-					"error parsing row",
+					"error getting rows affected",
 					zap.Error(err),
 				)
-			return nil, domain.ErrInternal
+			return domain.ErrInternal
 		}
 
-		users = append(users, user)
-	}
+		if rowsAffected == 0 {
+			return domain.ErrUserNotFound
+		}
 
-	return users, nil
+		if err = addAuditEvent(ctx, tx, event); err != nil {
+			return err
+		}
+		return addOutboxEvents(ctx, tx, outbox)
+	})
 }
 
-func (r *UserRepository) UpdateUser(ctx context.Context, update *domain.UserUpdate) error {
-	result, err := r.db.ExecContext(
+func (r *UserRepository) UpdatePasswordHash(ctx context.Context, id uuid.UUID, hash string) error {
+	result, err := conn(ctx, r.db).ExecContext(
 		ctx,
-		`UPDATE users 
-		SET username = COALESCE($1, username),
-		email = COALESCE($2, email),
-		password = COALESCE($3, password),
-		role = COALESCE($4, role),
-		updated_at = now()
-		WHERE id = $5`,
-		update.Username,
-		update.Email,
-		update.Password,
-		update.Role,
-		update.Id,
+		`UPDATE users SET password = $1 WHERE id = $2 AND deleted_at IS NULL`,
+		hash,
+		id,
 	)
-
-	var pqErr *pq.Error
-	if errors.As(err, &pqErr) && pqErr.Code == "23505" {
-		switch {
-		case pqErr.Constraint == "users_username_key":
-			return domain.ErrUsernameAlreadyInUse
-		case pqErr.Constraint == "users_email_key":
-			return domain.ErrEmailAlreadyInUse
-		}
-	} else if err != nil {
-		zapFields := make([]zap.Field, 0, 3)
-		if update.Username != nil {
-			zapFields = append(zapFields, zap.String("username", *update.Username))
-		}
-		if update.Email != nil {
-			zapFields = append(zapFields, zap.String("email", *update.Email))
-		}
-		if update.Role != nil {
-			zapFields = append(zapFields, zap.String("role", string(*update.Role)))
-		}
-
+	if err != nil {
 		zap.L().
 			Error(
-				"error updating user",
-				zapFields...,
+				"error updating password hash",
+				zap.String("id", id.String()),
+				zap.Error(err),
 			)
 		return domain.ErrInternal
 	}
@@ -381,3 +599,117 @@ func (r *UserRepository) UpdateUser(ctx context.Context, update *domain.UserUpda
 	}
 	return nil
 }
+
+func (r *UserRepository) SetUserStatus(ctx context.Context, id uuid.UUID, status domain.UserStatus, event *domain.AuditEvent) error {
+	return withTx(ctx, r.db, func(tx *sql.Tx) error {
+		result, err := tx.ExecContext(
+			ctx,
+			`UPDATE users SET status = $1, updated_at = now() WHERE id = $2 AND deleted_at IS NULL`,
+			status,
+			id,
+		)
+		if err != nil {
+			zap.L().
+				Error(
+					"error setting user status",
+					zap.String("id", id.String()),
+					zap.String("status", string(status)),
+					zap.Error(err),
+				)
+			return domain.ErrInternal
+		}
+
+		rowsAffected, err := result.RowsAffected()
+		if err != nil {
+			zap.L().
+				Error(
+					"error getting rows affected",
+					zap.Error(err),
+				)
+			return domain.ErrInternal
+		}
+
+		if rowsAffected == 0 {
+			return domain.ErrUserNotFound
+		}
+		return addAuditEvent(ctx, tx, event)
+	})
+}
+
+func (r *UserRepository) DeleteUser(ctx context.Context, id uuid.UUID, event *domain.AuditEvent) error {
+	return withTx(ctx, r.db, func(tx *sql.Tx) error {
+		result, err := tx.ExecContext(
+			ctx,
+			`UPDATE users SET deleted_at = now() WHERE id = $1 AND deleted_at IS NULL`,
+			id,
+		)
+		if err != nil {
+			zap.L().
+				Error(
+					"error deleting user",
+					zap.String("id", id.String()),
+					zap.Error(err),
+				)
+			return domain.ErrInternal
+		}
+
+		rowsAffected, err := result.RowsAffected()
+		if err != nil {
+			zap.L().
+				Error(
+					"error getting rows affected",
+					zap.Error(err),
+				)
+			return domain.ErrInternal
+		}
+
+		if rowsAffected == 0 {
+			return domain.ErrUserNotFound
+		}
+		return addAuditEvent(ctx, tx, event)
+	})
+}
+
+func (r *UserRepository) RestoreUser(ctx context.Context, id uuid.UUID, event *domain.AuditEvent) error {
+	return withTx(ctx, r.db, func(tx *sql.Tx) error {
+		var deletedAt, erasedAt sql.NullTime
+		err := tx.QueryRowContext(
+			ctx,
+			`SELECT deleted_at, erased_at FROM users WHERE id = $1 FOR UPDATE`,
+			id,
+		).Scan(&deletedAt, &erasedAt)
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.ErrUserNotFound
+		} else if err != nil {
+			zap.L().
+				Error(
+					"fetching user failed",
+					zap.String("id", id.String()),
+					zap.Error(err),
+				)
+			return domain.ErrInternal
+		}
+		if !deletedAt.Valid {
+			return domain.ErrUserNotDeleted
+		}
+		if erasedAt.Valid {
+			return domain.ErrUserErased
+		}
+
+		_, err = tx.ExecContext(
+			ctx,
+			`UPDATE users SET deleted_at = NULL, updated_at = now() WHERE id = $1`,
+			id,
+		)
+		if err != nil {
+			zap.L().
+				Error(
+					"error restoring user",
+					zap.String("id", id.String()),
+					zap.Error(err),
+				)
+			return domain.ErrInternal
+		}
+		return addAuditEvent(ctx, tx, event)
+	})
+}
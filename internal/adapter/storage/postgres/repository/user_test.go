@@ -0,0 +1,45 @@
+package repository
+
+import (
+	"database/sql"
+	"os"
+	"shop-api-go/internal/adapter/storage/storagetest"
+	"shop-api-go/internal/core/port"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// newTestDB connects to the migrated database of TEST_DATABASE_URL and empties the users
+// with every table referencing them. The test is skipped if the variable is not set.
+//
+// Note: The tables are emptied, never point TEST_DATABASE_URL at a database with data you need.
+func newTestDB(t *testing.T) *sql.DB {
+	url := os.Getenv("TEST_DATABASE_URL")
+	if url == "" {
+		t.Skip("TEST_DATABASE_URL is not set")
+	}
+
+	db, err := sql.Open("postgres", url)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, db.Close())
+	})
+
+	_, err = db.Exec("TRUNCATE users CASCADE")
+	require.NoError(t, err)
+	return db
+}
+
+func TestUserRepository(t *testing.T) {
+	storagetest.TestUserRepository(t, func(t *testing.T) port.UserRepository {
+		return NewUserRepository(newTestDB(t))
+	})
+}
+
+func TestTokenRepository(t *testing.T) {
+	storagetest.TestTokenRepository(t, func(t *testing.T) (port.UserRepository, port.TokenRepository) {
+		db := newTestDB(t)
+		return NewUserRepository(db), NewTokenRepository(db)
+	})
+}
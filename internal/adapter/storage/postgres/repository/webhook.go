@@ -0,0 +1,599 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"shop-api-go/internal/core/domain"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"go.uber.org/zap"
+)
+
+// WebhookRepository implements port.WebhookRepository and provides
+// access to postgres database.
+type WebhookRepository struct {
+	db *sql.DB
+}
+
+// NewWebhookRepository creates a new WebhookRepository instance.
+func NewWebhookRepository(db *sql.DB) *WebhookRepository {
+	return &WebhookRepository{
+		db: db,
+	}
+}
+
+const selectWebhookEndpoints = `SELECT id, url, description, event_types, secret, enabled, created_at, updated_at
+	FROM webhook_endpoints`
+
+// webhookDeliveryColumns are the columns of webhook deliveries in the order expected by scanWebhookDelivery.
+const webhookDeliveryColumns = `id, endpoint_id, event_id, event_type, aggregate_type, aggregate_id, payload,
+	occurred_at, status, attempts, next_attempt_at, last_error, created_at, updated_at, delivered_at`
+
+func (r *WebhookRepository) AddEndpoint(ctx context.Context, endpoint *domain.WebhookEndpoint, event *domain.AuditEvent) error {
+	return withTx(ctx, r.db, func(tx *sql.Tx) error {
+		_, err := tx.ExecContext(
+			ctx,
+			`INSERT INTO webhook_endpoints(id, url, description, event_types, secret, enabled, created_at, updated_at)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`,
+			endpoint.Id,
+			endpoint.Url,
+			endpoint.Description,
+			pq.Array(eventTypeNames(endpoint.EventTypes)),
+			endpoint.Secret,
+			endpoint.Enabled,
+			endpoint.CreatedAt.UTC(),
+			endpoint.UpdatedAt.UTC(),
+		)
+		if err != nil {
+			zap.L().
+				Error(
+					"adding webhook endpoint failed",
+					zap.String("url", endpoint.Url),
+					zap.Error(err),
+				)
+			return domain.ErrInternal
+		}
+
+		return addAuditEvent(ctx, tx, event)
+	})
+}
+
+func (r *WebhookRepository) GetEndpoints(ctx context.Context) ([]domain.WebhookEndpoint, error) {
+	return r.queryEndpoints(ctx, selectWebhookEndpoints+` ORDER BY created_at, id`)
+}
+
+func (r *WebhookRepository) GetEndpointById(ctx context.Context, id uuid.UUID) (*domain.WebhookEndpoint, error) {
+	endpoint, err := scanWebhookEndpoint(conn(ctx, r.db).QueryRowContext(ctx, selectWebhookEndpoints+` WHERE id = $1`, id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, domain.ErrWebhookEndpointNotFound
+	} else if err != nil {
+		zap.L().
+			Error(
+				"fetching webhook endpoint failed",
+				zap.String("id", id.String()),
+				zap.Error(err),
+			)
+		return nil, domain.ErrInternal
+	}
+	return endpoint, nil
+}
+
+func (r *WebhookRepository) GetSubscribedEndpoints(ctx context.Context, eventType domain.EventType) ([]domain.WebhookEndpoint, error) {
+	return r.queryEndpoints(
+		ctx,
+		selectWebhookEndpoints+` WHERE enabled AND (cardinality(event_types) = 0 OR $1 = ANY(event_types))
+		ORDER BY created_at, id`,
+		eventType,
+	)
+}
+
+func (r *WebhookRepository) UpdateEndpoint(ctx context.Context, endpoint *domain.WebhookEndpoint, event *domain.AuditEvent) error {
+	return withTx(ctx, r.db, func(tx *sql.Tx) error {
+		result, err := tx.ExecContext(
+			ctx,
+			`UPDATE webhook_endpoints
+			SET url = $2, description = $3, event_types = $4, secret = $5, enabled = $6, updated_at = $7
+			WHERE id = $1`,
+			endpoint.Id,
+			endpoint.Url,
+			endpoint.Description,
+			pq.Array(eventTypeNames(endpoint.EventTypes)),
+			endpoint.Secret,
+			endpoint.Enabled,
+			endpoint.UpdatedAt.UTC(),
+		)
+		if err != nil {
+			zap.L().
+				Error(
+					"updating webhook endpoint failed",
+					zap.String("id", endpoint.Id.String()),
+					zap.Error(err),
+				)
+			return domain.ErrInternal
+		}
+
+		rowsAffected, err := result.RowsAffected()
+		if err != nil {
+			zap.L().
+				Error(
+					"error getting rows affected",
+					zap.Error(err),
+				)
+			return domain.ErrInternal
+		}
+		if rowsAffected == 0 {
+			return domain.ErrWebhookEndpointNotFound
+		}
+
+		return addAuditEvent(ctx, tx, event)
+	})
+}
+
+func (r *WebhookRepository) DeleteEndpoint(ctx context.Context, id uuid.UUID, event *domain.AuditEvent) error {
+	return withTx(ctx, r.db, func(tx *sql.Tx) error {
+		result, err := tx.ExecContext(ctx, `DELETE FROM webhook_endpoints WHERE id = $1`, id)
+		if err != nil {
+			zap.L().
+				Error(
+					"deleting webhook endpoint failed",
+					zap.String("id", id.String()),
+					zap.Error(err),
+				)
+			return domain.ErrInternal
+		}
+
+		rowsAffected, err := result.RowsAffected()
+		if err != nil {
+			zap.L().
+				Error(
+					"error getting rows affected",
+					zap.Error(err),
+				)
+			return domain.ErrInternal
+		}
+		if rowsAffected == 0 {
+			return domain.ErrWebhookEndpointNotFound
+		}
+
+		return addAuditEvent(ctx, tx, event)
+	})
+}
+
+func (r *WebhookRepository) AddDeliveries(ctx context.Context, deliveries []*domain.WebhookDelivery) error {
+	return withTx(ctx, r.db, func(tx *sql.Tx) error {
+		for _, delivery := range deliveries {
+			payload, err := json.Marshal(delivery.Event.Payload)
+			if err != nil {
+				zap.L().
+					Error(
+						"encoding webhook delivery payload failed",
+						zap.String("eventId", delivery.Event.Id.String()),
+						zap.Error(err),
+					)
+				return domain.ErrInternal
+			}
+
+			// The endpoint may have been deleted since it was fetched, its deliveries are dropped with it.
+			_, err = tx.ExecContext(
+				ctx,
+				`INSERT INTO webhook_deliveries(
+					id, endpoint_id, event_id, event_type, aggregate_type, aggregate_id, payload,
+					occurred_at, status, next_attempt_at, created_at, updated_at
+				)
+				SELECT $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12
+				WHERE EXISTS (SELECT 1 FROM webhook_endpoints WHERE id = $2)
+				ON CONFLICT (endpoint_id, event_id) DO NOTHING`,
+				delivery.Id,
+				delivery.EndpointId,
+				delivery.Event.Id,
+				delivery.Event.Type,
+				delivery.Event.AggregateType,
+				delivery.Event.AggregateId,
+				payload,
+				delivery.Event.OccurredAt.UTC(),
+				delivery.Status,
+				delivery.NextAttemptAt.UTC(),
+				delivery.CreatedAt.UTC(),
+				delivery.UpdatedAt.UTC(),
+			)
+			if err != nil {
+				zap.L().
+					Error(
+						"adding webhook delivery failed",
+						zap.String("endpointId", delivery.EndpointId.String()),
+						zap.String("eventId", delivery.Event.Id.String()),
+						zap.Error(err),
+					)
+				return domain.ErrInternal
+			}
+		}
+		return nil
+	})
+}
+
+func (r *WebhookRepository) ClaimDeliveries(ctx context.Context, limit int) ([]domain.WebhookDelivery, error) {
+	// SKIP LOCKED lets every replica run workers without claiming the same delivery, and the claim
+	// keeps the delivery from being sent concurrently until it times out.
+	return r.queryDeliveries(
+		ctx,
+		`WITH claimed AS (
+			UPDATE webhook_deliveries
+			SET next_attempt_at = $1
+			WHERE id IN (
+				SELECT d.id
+				FROM webhook_deliveries d
+				JOIN webhook_endpoints e ON e.id = d.endpoint_id
+				WHERE d.status = $2 AND d.next_attempt_at <= now() AND e.enabled
+				ORDER BY d.next_attempt_at
+				LIMIT $3
+				FOR UPDATE OF d SKIP LOCKED
+			)
+			RETURNING `+webhookDeliveryColumns+`
+		)
+		SELECT `+webhookDeliveryColumns+` FROM claimed ORDER BY created_at, id`,
+		time.Now().Add(domain.WebhookDeliveryTimeout).UTC(),
+		domain.WebhookDeliveryPending,
+		limit,
+	)
+}
+
+func (r *WebhookRepository) GetDeliveries(ctx context.Context, filter *domain.WebhookDeliveryFilter) ([]domain.WebhookDelivery, error) {
+	query := `SELECT ` + webhookDeliveryColumns + ` FROM webhook_deliveries WHERE endpoint_id = $1`
+	args := []any{filter.EndpointId}
+	if filter.Status != nil {
+		args = append(args, *filter.Status)
+		query += " AND status = $" + strconv.Itoa(len(args))
+	}
+
+	args = append(args, filter.Limit, (filter.Page-1)*filter.Limit)
+	query += " ORDER BY created_at DESC, id LIMIT $" + strconv.Itoa(len(args)-1) + " OFFSET $" + strconv.Itoa(len(args))
+	return r.queryDeliveries(ctx, query, args...)
+}
+
+func (r *WebhookRepository) GetDeliveryById(ctx context.Context, id uuid.UUID) (*domain.WebhookDelivery, error) {
+	delivery, err := scanWebhookDelivery(conn(ctx, r.db).QueryRowContext(
+		ctx,
+		`SELECT `+webhookDeliveryColumns+` FROM webhook_deliveries WHERE id = $1`,
+		id,
+	))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, domain.ErrWebhookDeliveryNotFound
+	} else if err != nil {
+		zap.L().
+			Error(
+				"fetching webhook delivery failed",
+				zap.String("id", id.String()),
+				zap.Error(err),
+			)
+		return nil, domain.ErrInternal
+	}
+	return delivery, nil
+}
+
+func (r *WebhookRepository) GetDeliveryAttempts(ctx context.Context, deliveryId uuid.UUID) ([]domain.WebhookAttempt, error) {
+	rows, err := conn(ctx, r.db).QueryContext(
+		ctx,
+		`SELECT id, delivery_id, response_status, error, duration_ms, attempted_at
+		FROM webhook_delivery_attempts
+		WHERE delivery_id = $1
+		ORDER BY attempted_at, id`,
+		deliveryId,
+	)
+	if err != nil {
+		zap.L().
+			Error(
+				"fetching webhook delivery attempts failed",
+				zap.String("deliveryId", deliveryId.String()),
+				zap.Error(err),
+			)
+		return nil, domain.ErrInternal
+	}
+
+	defer func() {
+		closeErr := rows.Close()
+		if closeErr != nil {
+			zap.L().
+				Error(
+					"error closing rows",
+					zap.Error(closeErr),
+				)
+		}
+	}()
+
+	attempts := make([]domain.WebhookAttempt, 0)
+	for rows.Next() {
+		var attempt domain.WebhookAttempt
+		var durationMs int64
+		if err = rows.Scan(
+			&attempt.Id,
+			&attempt.DeliveryId,
+			&attempt.ResponseStatus,
+			&attempt.Error,
+			&durationMs,
+			&attempt.AttemptedAt,
+		); err != nil {
+			zap.L().
+				Error(
+					"error parsing webhook delivery attempt",
+					zap.Error(err),
+				)
+			return nil, domain.ErrInternal
+		}
+		attempt.Duration = time.Duration(durationMs) * time.Millisecond
+		attempts = append(attempts, attempt)
+	}
+	if err = rows.Err(); err != nil {
+		zap.L().
+			Error(
+				"error iterating rows",
+				zap.Error(err),
+			)
+		return nil, domain.ErrInternal
+	}
+
+	return attempts, nil
+}
+
+func (r *WebhookRepository) RecordDeliveryAttempt(
+	ctx context.Context,
+	delivery *domain.WebhookDelivery,
+	attempt *domain.WebhookAttempt,
+) error {
+	return withTx(ctx, r.db, func(tx *sql.Tx) error {
+		_, err := tx.ExecContext(
+			ctx,
+			`INSERT INTO webhook_delivery_attempts(id, delivery_id, response_status, error, duration_ms, attempted_at)
+			SELECT $1, $2, $3, $4, $5, $6
+			WHERE EXISTS (SELECT 1 FROM webhook_deliveries WHERE id = $2)`,
+			attempt.Id,
+			attempt.DeliveryId,
+			attempt.ResponseStatus,
+			attempt.Error,
+			attempt.Duration.Milliseconds(),
+			attempt.AttemptedAt.UTC(),
+		)
+		if err != nil {
+			zap.L().
+				Error(
+					"adding webhook delivery attempt failed",
+					zap.String("deliveryId", attempt.DeliveryId.String()),
+					zap.Error(err),
+				)
+			return domain.ErrInternal
+		}
+
+		var deliveredAt *time.Time
+		if delivery.DeliveredAt != nil {
+			utc := delivery.DeliveredAt.UTC()
+			deliveredAt = &utc
+		}
+		_, err = tx.ExecContext(
+			ctx,
+			`UPDATE webhook_deliveries
+			SET status = $2, attempts = $3, next_attempt_at = $4, last_error = $5, updated_at = $6, delivered_at = $7
+			WHERE id = $1`,
+			delivery.Id,
+			delivery.Status,
+			delivery.Attempts,
+			delivery.NextAttemptAt.UTC(),
+			delivery.LastError,
+			delivery.UpdatedAt.UTC(),
+			deliveredAt,
+		)
+		if err != nil {
+			zap.L().
+				Error(
+					"updating webhook delivery failed",
+					zap.String("id", delivery.Id.String()),
+					zap.Error(err),
+				)
+			return domain.ErrInternal
+		}
+		return nil
+	})
+}
+
+func (r *WebhookRepository) ReplayDelivery(ctx context.Context, id uuid.UUID, event *domain.AuditEvent) error {
+	return withTx(ctx, r.db, func(tx *sql.Tx) error {
+		result, err := tx.ExecContext(
+			ctx,
+			`UPDATE webhook_deliveries
+			SET status = $2, attempts = 0, next_attempt_at = now(), updated_at = now(), delivered_at = NULL
+			WHERE id = $1`,
+			id,
+			domain.WebhookDeliveryPending,
+		)
+		if err != nil {
+			zap.L().
+				Error(
+					"replaying webhook delivery failed",
+					zap.String("id", id.String()),
+					zap.Error(err),
+				)
+			return domain.ErrInternal
+		}
+
+		rowsAffected, err := result.RowsAffected()
+		if err != nil {
+			zap.L().
+				Error(
+					"error getting rows affected",
+					zap.Error(err),
+				)
+			return domain.ErrInternal
+		}
+		if rowsAffected == 0 {
+			return domain.ErrWebhookDeliveryNotFound
+		}
+
+		return addAuditEvent(ctx, tx, event)
+	})
+}
+
+// queryEndpoints fetches the endpoints selected by the query.
+func (r *WebhookRepository) queryEndpoints(ctx context.Context, query string, args ...any) ([]domain.WebhookEndpoint, error) {
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query, args...)
+	if err != nil {
+		zap.L().
+			Error(
+				"fetching webhook endpoints failed",
+				zap.Error(err),
+			)
+		return nil, domain.ErrInternal
+	}
+
+	defer func() {
+		closeErr := rows.Close()
+		if closeErr != nil {
+			zap.L().
+				Error(
+					"error closing rows",
+					zap.Error(closeErr),
+				)
+		}
+	}()
+
+	endpoints := make([]domain.WebhookEndpoint, 0)
+	for rows.Next() {
+		endpoint, err := scanWebhookEndpoint(rows)
+		if err != nil {
+			zap.L().
+				Error(
+					"error parsing webhook endpoint",
+					zap.Error(err),
+				)
+			return nil, domain.ErrInternal
+		}
+		endpoints = append(endpoints, *endpoint)
+	}
+	if err = rows.Err(); err != nil {
+		zap.L().
+			Error(
+				"error iterating rows",
+				zap.Error(err),
+			)
+		return nil, domain.ErrInternal
+	}
+
+	return endpoints, nil
+}
+
+// queryDeliveries fetches the deliveries selected by the query with webhookDeliveryColumns.
+func (r *WebhookRepository) queryDeliveries(ctx context.Context, query string, args ...any) ([]domain.WebhookDelivery, error) {
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query, args...)
+	if err != nil {
+		zap.L().
+			Error(
+				"fetching webhook deliveries failed",
+				zap.Error(err),
+			)
+		return nil, domain.ErrInternal
+	}
+
+	defer func() {
+		closeErr := rows.Close()
+		if closeErr != nil {
+			zap.L().
+				Error(
+					"error closing rows",
+					zap.Error(closeErr),
+				)
+		}
+	}()
+
+	deliveries := make([]domain.WebhookDelivery, 0)
+	for rows.Next() {
+		delivery, err := scanWebhookDelivery(rows)
+		if err != nil {
+			zap.L().
+				Error(
+					"error parsing webhook delivery",
+					zap.Error(err),
+				)
+			return nil, domain.ErrInternal
+		}
+		deliveries = append(deliveries, *delivery)
+	}
+	if err = rows.Err(); err != nil {
+		zap.L().
+			Error(
+				"error iterating rows",
+				zap.Error(err),
+			)
+		return nil, domain.ErrInternal
+	}
+
+	return deliveries, nil
+}
+
+// scanWebhookEndpoint scans a row selected by selectWebhookEndpoints into domain.WebhookEndpoint.
+func scanWebhookEndpoint(row scanner) (*domain.WebhookEndpoint, error) {
+	var endpoint domain.WebhookEndpoint
+	var eventTypes pq.StringArray
+	if err := row.Scan(
+		&endpoint.Id,
+		&endpoint.Url,
+		&endpoint.Description,
+		&eventTypes,
+		&endpoint.Secret,
+		&endpoint.Enabled,
+		&endpoint.CreatedAt,
+		&endpoint.UpdatedAt,
+	); err != nil {
+		return nil, err
+	}
+
+	endpoint.EventTypes = make([]domain.EventType, 0, len(eventTypes))
+	for _, eventType := range eventTypes {
+		endpoint.EventTypes = append(endpoint.EventTypes, domain.EventType(eventType))
+	}
+	return &endpoint, nil
+}
+
+// scanWebhookDelivery scans a row with webhookDeliveryColumns into domain.WebhookDelivery.
+func scanWebhookDelivery(row scanner) (*domain.WebhookDelivery, error) {
+	var delivery domain.WebhookDelivery
+	var payload []byte
+	var deliveredAt sql.NullTime
+	if err := row.Scan(
+		&delivery.Id,
+		&delivery.EndpointId,
+		&delivery.Event.Id,
+		&delivery.Event.Type,
+		&delivery.Event.AggregateType,
+		&delivery.Event.AggregateId,
+		&payload,
+		&delivery.Event.OccurredAt,
+		&delivery.Status,
+		&delivery.Attempts,
+		&delivery.NextAttemptAt,
+		&delivery.LastError,
+		&delivery.CreatedAt,
+		&delivery.UpdatedAt,
+		&deliveredAt,
+	); err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(payload, &delivery.Event.Payload); err != nil {
+		return nil, err
+	}
+	if deliveredAt.Valid {
+		delivery.DeliveredAt = &deliveredAt.Time
+	}
+	return &delivery, nil
+}
+
+// eventTypeNames converts event types into strings for a varchar array.
+func eventTypeNames(eventTypes []domain.EventType) []string {
+	names := make([]string, 0, len(eventTypes))
+	for _, eventType := range eventTypes {
+		names = append(names, string(eventType))
+	}
+	return names
+}
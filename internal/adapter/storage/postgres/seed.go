@@ -0,0 +1,181 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"shop-api-go/internal/adapter/storage/seed"
+
+	"github.com/lib/pq"
+)
+
+// SeedResult is the number of rows seeded into a table.
+type SeedResult struct {
+	Table string
+	// Rows is the number of rows in the dataset.
+	Rows int
+	// Inserted is the number of rows that did not exist yet.
+	Inserted int64
+}
+
+// seedTable describes how the rows of a dataset are seeded into a table.
+type seedTable struct {
+	table   string
+	columns []string
+	rows    [][]any
+	// insert moves the rows from the temporary table into the table, skipping the existing ones
+	// and the ones whose parents were skipped, e.g. a category of a section that exists with another id.
+	insert string
+}
+
+// Seeder inserts datasets in bulk, rows are copied into temporary tables with COPY
+// and moved into the tables with a single statement per table.
+type Seeder struct {
+	db *sql.DB
+}
+
+// NewSeeder creates a new Seeder instance.
+func NewSeeder(db *sql.DB) *Seeder {
+	return &Seeder{db: db}
+}
+
+// Seed inserts the rows of the dataset that do not exist yet in one transaction, so seeding the same dataset
+// again inserts nothing. Rows conflicting with existing ones, e.g. a user with a taken username, are skipped,
+// as are links of products to subcategories that do not exist.
+//
+// Note: The passwords of the users have to be hashed already.
+func (s *Seeder) Seed(ctx context.Context, dataset *seed.Dataset) ([]SeedResult, error) {
+	tables := seedTables(dataset)
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("starting transaction: %w", err)
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	results := make([]SeedResult, 0, len(tables))
+	for _, table := range tables {
+		inserted, err := seedRows(ctx, tx, &table)
+		if err != nil {
+			return nil, fmt.Errorf("seeding %s: %w", table.table, err)
+		}
+		results = append(results, SeedResult{Table: table.table, Rows: len(table.rows), Inserted: inserted})
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, fmt.Errorf("committing seed: %w", err)
+	}
+	return results, nil
+}
+
+// seedRows copies the rows into a temporary table dropped on commit and moves the new ones into the table.
+func seedRows(ctx context.Context, tx *sql.Tx, table *seedTable) (int64, error) {
+	temporary := "seed_" + table.table
+	_, err := tx.ExecContext(
+		ctx,
+		fmt.Sprintf("CREATE TEMP TABLE %s (LIKE %s INCLUDING DEFAULTS) ON COMMIT DROP", temporary, table.table),
+	)
+	if err != nil {
+		return 0, err
+	}
+
+	stmt, err := tx.PrepareContext(ctx, pq.CopyIn(temporary, table.columns...))
+	if err != nil {
+		return 0, err
+	}
+	defer func() {
+		_ = stmt.Close()
+	}()
+
+	for _, row := range table.rows {
+		if _, err = stmt.ExecContext(ctx, row...); err != nil {
+			return 0, err
+		}
+	}
+	// An Exec without arguments flushes the copied rows.
+	if _, err = stmt.ExecContext(ctx); err != nil {
+		return 0, err
+	}
+
+	result, err := tx.ExecContext(ctx, table.insert)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+// seedTables returns the rows of the dataset per table, parents before their children.
+func seedTables(dataset *seed.Dataset) []seedTable {
+	sections := seedTable{
+		table:   "category_sections",
+		columns: []string{"id", "name"},
+		insert:  `INSERT INTO category_sections SELECT * FROM seed_category_sections ON CONFLICT DO NOTHING`,
+	}
+	for _, section := range dataset.Sections {
+		sections.rows = append(sections.rows, []any{section.Id, section.Name})
+	}
+
+	categories := seedTable{
+		table:   "categories",
+		columns: []string{"id", "name", "section_id"},
+		insert: `INSERT INTO categories
+				 SELECT s.* FROM seed_categories s JOIN category_sections p ON p.id = s.section_id
+				 ON CONFLICT DO NOTHING`,
+	}
+	for _, category := range dataset.Categories {
+		categories.rows = append(categories.rows, []any{category.Id, category.Name, category.SectionId})
+	}
+
+	subcategories := seedTable{
+		table:   "subcategories",
+		columns: []string{"id", "name", "category_id"},
+		insert: `INSERT INTO subcategories
+				 SELECT s.* FROM seed_subcategories s JOIN categories p ON p.id = s.category_id
+				 ON CONFLICT DO NOTHING`,
+	}
+	for _, subcategory := range dataset.Subcategories {
+		subcategories.rows = append(subcategories.rows, []any{subcategory.Id, subcategory.Name, subcategory.CategoryID})
+	}
+
+	products := seedTable{
+		table:   "products",
+		columns: []string{"id", "name", "description", "price", "rating", "count", "image_url"},
+		insert:  `INSERT INTO products SELECT * FROM seed_products ON CONFLICT DO NOTHING`,
+	}
+	links := seedTable{
+		table:   "products_subcategories",
+		columns: []string{"product_id", "subcategory_id"},
+		insert: `INSERT INTO products_subcategories
+				 SELECT s.* FROM seed_products_subcategories s
+				 JOIN products p ON p.id = s.product_id
+				 JOIN subcategories c ON c.id = s.subcategory_id
+				 ON CONFLICT DO NOTHING`,
+	}
+	for _, product := range dataset.Products {
+		products.rows = append(products.rows, []any{
+			product.Id,
+			product.Name,
+			product.Description,
+			product.Price,
+			product.Rating,
+			product.Count,
+			product.ImageUrl,
+		})
+		for _, subcategory := range product.Subcategories {
+			links.rows = append(links.rows, []any{product.Id, subcategory.Id})
+		}
+	}
+
+	users := seedTable{
+		table:   "users",
+		columns: []string{"id", "username", "email", "password", "role", "status"},
+		insert:  `INSERT INTO users SELECT * FROM seed_users ON CONFLICT DO NOTHING`,
+	}
+	for _, user := range dataset.Users {
+		users.rows = append(users.rows, []any{user.Id, user.Username, user.Email, user.Password, user.Role, user.Status})
+	}
+
+	return []seedTable{sections, categories, subcategories, products, links, users}
+}
@@ -0,0 +1,66 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"os"
+	"shop-api-go/internal/adapter/storage/seed"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSeeder_Seed(t *testing.T) {
+	url := os.Getenv("TEST_DATABASE_URL")
+	if url == "" {
+		t.Skip("TEST_DATABASE_URL is not set")
+	}
+	db, err := sql.Open("postgres", url)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, db.Close())
+	})
+	_, err = db.Exec("TRUNCATE users, category_sections, products CASCADE")
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	dataset := seed.Generate(&seed.Options{
+		Seed:                     1,
+		Sections:                 2,
+		CategoriesPerSection:     2,
+		SubcategoriesPerCategory: 2,
+		Products:                 50,
+		UsersPerRole:             2,
+		Password:                 "password-hash",
+	})
+	seeder := NewSeeder(db)
+
+	results, err := seeder.Seed(ctx, dataset)
+	require.NoError(t, err)
+	for _, result := range results {
+		require.Equal(t, int64(result.Rows), result.Inserted, result.Table)
+	}
+
+	// Seeding again inserts nothing, seeding more products only inserts the new ones.
+	results, err = seeder.Seed(ctx, dataset)
+	require.NoError(t, err)
+	for _, result := range results {
+		require.Zero(t, result.Inserted, result.Table)
+	}
+
+	dataset.Products = append(dataset.Products, seed.Generate(&seed.Options{
+		Seed:                     2,
+		Sections:                 1,
+		CategoriesPerSection:     1,
+		SubcategoriesPerCategory: 1,
+		Products:                 51,
+	}).Products[50])
+	results, err = seeder.Seed(ctx, dataset)
+	require.NoError(t, err)
+	require.Equal(t, "products", results[3].Table)
+	require.Equal(t, int64(1), results[3].Inserted)
+
+	var count int
+	require.NoError(t, db.QueryRow("SELECT count(*) FROM users WHERE username LIKE '%0002'").Scan(&count))
+	require.Equal(t, 4, count)
+}
@@ -0,0 +1,78 @@
+// Package seed creates demo and test data for the catalog and the users, either generated from a seed
+// or loaded from fixture files.
+package seed
+
+import (
+	"shop-api-go/internal/core/domain"
+	"shop-api-go/internal/core/port"
+
+	"github.com/google/uuid"
+)
+
+// namespace is the namespace of the ids of seeded rows. Ids are derived from names,
+// so seeding the same data twice creates the same rows.
+var namespace = uuid.MustParse("6f1c2a9e-3b7d-4c5e-9a8f-2d4b6e8c0a1f")
+
+// Dataset contains the rows to seed.
+//
+// Note: Products reference their subcategories by id only, the other fields of Product.Subcategories are ignored.
+type Dataset struct {
+	Sections      []domain.CategorySection
+	Categories    []domain.Category
+	Subcategories []domain.Subcategory
+	Products      []domain.Product
+	Users         []domain.User
+}
+
+// Append adds the rows of other to the dataset.
+func (d *Dataset) Append(other *Dataset) {
+	d.Sections = append(d.Sections, other.Sections...)
+	d.Categories = append(d.Categories, other.Categories...)
+	d.Subcategories = append(d.Subcategories, other.Subcategories...)
+	d.Products = append(d.Products, other.Products...)
+	d.Users = append(d.Users, other.Users...)
+}
+
+// HashPasswords replaces the plain passwords of the users with their hashes.
+// Every distinct password is hashed once, as hashing is slow by design.
+func (d *Dataset) HashPasswords(hasher port.PasswordHasher) error {
+	hashes := make(map[string]string)
+	for i := range d.Users {
+		password := d.Users[i].Password
+		hash, ok := hashes[password]
+		if !ok {
+			var err error
+			if hash, err = hasher.Hash(password); err != nil {
+				return err
+			}
+			hashes[password] = hash
+		}
+		d.Users[i].Password = hash
+	}
+	return nil
+}
+
+// sectionId returns the id of the section with the name.
+func sectionId(section string) uuid.UUID {
+	return uuid.NewSHA1(namespace, []byte("section/"+section))
+}
+
+// categoryId returns the id of the category with the name in the section.
+func categoryId(section, category string) uuid.UUID {
+	return uuid.NewSHA1(namespace, []byte("category/"+section+"/"+category))
+}
+
+// subcategoryId returns the id of the subcategory with the name in the category of the section.
+func subcategoryId(section, category, subcategory string) uuid.UUID {
+	return uuid.NewSHA1(namespace, []byte("subcategory/"+section+"/"+category+"/"+subcategory))
+}
+
+// productId returns the id of the product with the name.
+func productId(product string) uuid.UUID {
+	return uuid.NewSHA1(namespace, []byte("product/"+product))
+}
+
+// userId returns the id of the user with the username.
+func userId(username string) uuid.UUID {
+	return uuid.NewSHA1(namespace, []byte("user/"+username))
+}
@@ -0,0 +1,37 @@
+package seed
+
+import (
+	"errors"
+	"shop-api-go/internal/core/domain"
+	"shop-api-go/internal/core/port/mock"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestDataset_HashPasswords(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	hasher := mock.NewMockPasswordHasher(ctrl)
+	hasher.EXPECT().Hash("first-password").Return("first-hash", nil).Times(1)
+	hasher.EXPECT().Hash("second-password").Return("second-hash", nil).Times(1)
+
+	dataset := &Dataset{Users: []domain.User{
+		{Username: "firstuser", Password: "first-password"},
+		{Username: "seconduser", Password: "second-password"},
+		{Username: "thirduser", Password: "first-password"},
+	}}
+	require.NoError(t, dataset.HashPasswords(hasher))
+	require.Equal(t, "first-hash", dataset.Users[0].Password)
+	require.Equal(t, "second-hash", dataset.Users[1].Password)
+	require.Equal(t, "first-hash", dataset.Users[2].Password)
+}
+
+func TestDataset_HashPasswords_Error(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	hasher := mock.NewMockPasswordHasher(ctrl)
+	hasher.EXPECT().Hash("password").Return("", errors.New("hash error"))
+
+	dataset := &Dataset{Users: []domain.User{{Username: "firstuser", Password: "password"}}}
+	require.EqualError(t, dataset.HashPasswords(hasher), "hash error")
+}
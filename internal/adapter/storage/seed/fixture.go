@@ -0,0 +1,189 @@
+package seed
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"shop-api-go/internal/core/domain"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"gopkg.in/yaml.v3"
+)
+
+// fixture is the format of fixture files, e.g.
+//
+//	sections:
+//	  - name: Electronics
+//	    categories:
+//	      - name: Phones
+//	        subcategories: [Smartphones]
+//	products:
+//	  - name: Aurora X1 Smartphone
+//	    description: A smartphone with a bright display and a long battery life.
+//	    price: 499.99
+//	    rating: 4.5
+//	    count: 25
+//	    imageUrl: https://example.com/aurora-x1.png
+//	    subcategories: [Electronics/Phones/Smartphones]
+//	users:
+//	  - username: demoadmin
+//	    email: demo.admin@example.com
+//	    password: Demo-Password-1
+//	    role: admin
+type fixture struct {
+	Sections []fixtureSection `json:"sections" yaml:"sections"`
+	Products []fixtureProduct `json:"products" yaml:"products"`
+	Users    []fixtureUser    `json:"users" yaml:"users"`
+}
+
+type fixtureSection struct {
+	Name       string            `json:"name" yaml:"name"`
+	Categories []fixtureCategory `json:"categories" yaml:"categories"`
+}
+
+type fixtureCategory struct {
+	Name          string   `json:"name" yaml:"name"`
+	Subcategories []string `json:"subcategories" yaml:"subcategories"`
+}
+
+type fixtureProduct struct {
+	Name        string          `json:"name" yaml:"name"`
+	Description string          `json:"description" yaml:"description"`
+	Price       decimal.Decimal `json:"price" yaml:"price"`
+	Rating      decimal.Decimal `json:"rating" yaml:"rating"`
+	Count       int             `json:"count" yaml:"count"`
+	ImageUrl    string          `json:"imageUrl" yaml:"imageUrl"`
+	// Subcategories contains the paths of the subcategories, e.g. Electronics/Phones/Smartphones.
+	// They may be defined in another fixture or be generated.
+	Subcategories []string `json:"subcategories" yaml:"subcategories"`
+}
+
+type fixtureUser struct {
+	Username string          `json:"username" yaml:"username"`
+	Email    string          `json:"email" yaml:"email"`
+	Password string          `json:"password" yaml:"password"`
+	Role     domain.UserRole `json:"role" yaml:"role"`
+}
+
+// LoadFixture reads a dataset from a YAML (.yaml, .yml) or JSON (.json) fixture file.
+// Unknown fields are rejected, so typos don't silently drop data.
+//
+// Note: The passwords of the users are plain and have to be hashed before seeding.
+func LoadFixture(path string) (*Dataset, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var f fixture
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		decoder := yaml.NewDecoder(bytes.NewReader(content))
+		decoder.KnownFields(true)
+		err = decoder.Decode(&f)
+	case ".json":
+		decoder := json.NewDecoder(bytes.NewReader(content))
+		decoder.DisallowUnknownFields()
+		err = decoder.Decode(&f)
+	default:
+		return nil, fmt.Errorf("unsupported fixture file %s, use .yaml, .yml or .json", path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", path, err)
+	}
+
+	dataset, err := f.dataset()
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return dataset, nil
+}
+
+// dataset converts the fixture into a dataset with the same ids as generated rows of the same names.
+func (f *fixture) dataset() (*Dataset, error) {
+	dataset := &Dataset{}
+	for _, section := range f.Sections {
+		if section.Name == "" {
+			return nil, errors.New("section without name")
+		}
+		parentSection := domain.CategorySection{Id: sectionId(section.Name), Name: section.Name}
+		dataset.Sections = append(dataset.Sections, parentSection)
+
+		for _, category := range section.Categories {
+			if category.Name == "" {
+				return nil, fmt.Errorf("category without name in section %s", section.Name)
+			}
+			parentCategory := domain.Category{Id: categoryId(section.Name, category.Name), Name: category.Name, SectionId: parentSection.Id}
+			dataset.Categories = append(dataset.Categories, parentCategory)
+
+			for _, subcategory := range category.Subcategories {
+				if subcategory == "" {
+					return nil, fmt.Errorf("empty subcategory in category %s/%s", section.Name, category.Name)
+				}
+				dataset.Subcategories = append(dataset.Subcategories, domain.Subcategory{
+					Id:         subcategoryId(section.Name, category.Name, subcategory),
+					Name:       subcategory,
+					CategoryID: parentCategory.Id,
+				})
+			}
+		}
+	}
+
+	for _, product := range f.Products {
+		if product.Name == "" {
+			return nil, errors.New("product without name")
+		}
+		subcategories := make([]domain.Subcategory, 0, len(product.Subcategories))
+		for _, path := range product.Subcategories {
+			id, err := subcategoryPathId(path)
+			if err != nil {
+				return nil, fmt.Errorf("product %s: %w", product.Name, err)
+			}
+			subcategories = append(subcategories, domain.Subcategory{Id: id})
+		}
+		dataset.Products = append(dataset.Products, *domain.NewProduct(
+			productId(product.Name),
+			product.Name,
+			product.Description,
+			product.Price,
+			product.Rating,
+			product.Count,
+			product.ImageUrl,
+			subcategories,
+			time.Time{},
+			time.Time{},
+		))
+	}
+
+	for _, user := range f.Users {
+		if user.Username == "" {
+			return nil, errors.New("user without username")
+		}
+		dataset.Users = append(dataset.Users, *domain.NewUser(
+			userId(user.Username),
+			user.Username,
+			user.Email,
+			user.Password,
+			user.Role,
+			time.Time{},
+			time.Time{},
+		))
+	}
+	return dataset, nil
+}
+
+// subcategoryPathId returns the id of the subcategory with a path like Electronics/Phones/Smartphones.
+func subcategoryPathId(path string) (uuid.UUID, error) {
+	parts := strings.Split(path, "/")
+	if len(parts) != 3 || slices.Contains(parts, "") {
+		return uuid.Nil, fmt.Errorf("invalid subcategory path %q, use section/category/subcategory", path)
+	}
+	return subcategoryId(parts[0], parts[1], parts[2]), nil
+}
@@ -0,0 +1,184 @@
+package seed
+
+import (
+	"os"
+	"path/filepath"
+	"shop-api-go/internal/core/domain"
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/require"
+)
+
+const yamlFixture = `
+sections:
+  - name: Electronics
+    categories:
+      - name: Phones
+        subcategories: [Smartphones, Cases]
+products:
+  - name: Aurora X1 Smartphone
+    description: A smartphone with a bright display and a long battery life.
+    price: 499.99
+    rating: 4.5
+    count: 25
+    imageUrl: https://example.com/aurora-x1.png
+    subcategories: [Electronics/Phones/Smartphones, Books/Essentials/Basic]
+users:
+  - username: demoadmin
+    email: demo.admin@example.com
+    password: Demo-Password-1
+    role: admin
+`
+
+const jsonFixture = `{
+  "sections": [
+    {"name": "Electronics", "categories": [{"name": "Phones", "subcategories": ["Smartphones", "Cases"]}]}
+  ],
+  "products": [
+    {
+      "name": "Aurora X1 Smartphone",
+      "description": "A smartphone with a bright display and a long battery life.",
+      "price": 499.99,
+      "rating": 4.5,
+      "count": 25,
+      "imageUrl": "https://example.com/aurora-x1.png",
+      "subcategories": ["Electronics/Phones/Smartphones", "Books/Essentials/Basic"]
+    }
+  ],
+  "users": [
+    {"username": "demoadmin", "email": "demo.admin@example.com", "password": "Demo-Password-1", "role": "admin"}
+  ]
+}`
+
+// writeFixture writes the content into a temporary file with the name and returns its path.
+func writeFixture(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+func TestLoadFixture(t *testing.T) {
+	electronics := sectionId("Electronics")
+	phones := categoryId("Electronics", "Phones")
+	expected := &Dataset{
+		Sections:   []domain.CategorySection{{Id: electronics, Name: "Electronics"}},
+		Categories: []domain.Category{{Id: phones, Name: "Phones", SectionId: electronics}},
+		Subcategories: []domain.Subcategory{
+			{Id: subcategoryId("Electronics", "Phones", "Smartphones"), Name: "Smartphones", CategoryID: phones},
+			{Id: subcategoryId("Electronics", "Phones", "Cases"), Name: "Cases", CategoryID: phones},
+		},
+		Products: []domain.Product{
+			{
+				Id:          productId("Aurora X1 Smartphone"),
+				Name:        "Aurora X1 Smartphone",
+				Description: "A smartphone with a bright display and a long battery life.",
+				Price:       decimal.RequireFromString("499.99"),
+				Rating:      decimal.RequireFromString("4.5"),
+				Count:       25,
+				ImageUrl:    "https://example.com/aurora-x1.png",
+				Subcategories: []domain.Subcategory{
+					{Id: subcategoryId("Electronics", "Phones", "Smartphones")},
+					// Subcategories of other fixtures or generated ones can be referenced by their path.
+					{Id: subcategoryId("Books", "Essentials", "Basic")},
+				},
+			},
+		},
+		Users: []domain.User{
+			{
+				Id:       userId("demoadmin"),
+				Username: "demoadmin",
+				Email:    "demo.admin@example.com",
+				Password: "Demo-Password-1",
+				Role:     domain.Admin,
+				Status:   domain.UserActive,
+			},
+		},
+	}
+
+	tests := []struct {
+		name          string
+		file          string
+		content       string
+		expected      *Dataset
+		expectedError string
+	}{
+		{
+			name:     "yaml",
+			file:     "catalog.yaml",
+			content:  yamlFixture,
+			expected: expected,
+		},
+		{
+			name:     "json",
+			file:     "catalog.json",
+			content:  jsonFixture,
+			expected: expected,
+		},
+		{
+			name:     "yml",
+			file:     "catalog.yml",
+			content:  "users: []",
+			expected: &Dataset{},
+		},
+		{
+			name:          "unsupported extension",
+			file:          "catalog.toml",
+			content:       "",
+			expectedError: "unsupported fixture file",
+		},
+		{
+			name:          "unknown yaml field",
+			file:          "catalog.yaml",
+			content:       "users:\n  - username: demoadmin\n    nickname: demo\n",
+			expectedError: "field nickname not found",
+		},
+		{
+			name:          "unknown json field",
+			file:          "catalog.json",
+			content:       `{"user": []}`,
+			expectedError: `unknown field "user"`,
+		},
+		{
+			name:          "invalid subcategory path",
+			file:          "catalog.yaml",
+			content:       "products:\n  - name: Aurora X1 Smartphone\n    subcategories: [Electronics/Smartphones]\n",
+			expectedError: `product Aurora X1 Smartphone: invalid subcategory path "Electronics/Smartphones"`,
+		},
+		{
+			name:          "category without name",
+			file:          "catalog.json",
+			content:       `{"sections": [{"name": "Electronics", "categories": [{"subcategories": ["Cases"]}]}]}`,
+			expectedError: "category without name in section Electronics",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			dataset, err := LoadFixture(writeFixture(t, test.file, test.content))
+			if test.expectedError != "" {
+				require.ErrorContains(t, err, test.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, test.expected, dataset)
+		})
+	}
+}
+
+func TestLoadFixture_SameIdsAsGenerated(t *testing.T) {
+	generated := Generate(&Options{Sections: 1, CategoriesPerSection: 1, SubcategoriesPerCategory: 1, UsersPerRole: 1})
+
+	dataset, err := LoadFixture(writeFixture(
+		t,
+		"catalog.yaml",
+		"sections:\n  - name: Electronics\n    categories:\n      - name: Accessories\n        subcategories: [Basic]\n"+
+			"users:\n  - username: admin0001\n    email: admin0001@seed.example.com\n    role: admin\n",
+	))
+	require.NoError(t, err)
+	require.Equal(t, generated.Sections, dataset.Sections)
+	require.Equal(t, generated.Categories, dataset.Categories)
+	require.Equal(t, generated.Subcategories, dataset.Subcategories)
+	require.Equal(t, generated.Users[0].Id, dataset.Users[0].Id)
+}
@@ -0,0 +1,139 @@
+package seed
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"shop-api-go/internal/core/domain"
+	"strings"
+
+	"github.com/shopspring/decimal"
+)
+
+// roles contains every role users are generated for.
+var roles = []domain.UserRole{domain.Admin, domain.Client, domain.Delivery, domain.Warehouse}
+
+var (
+	sectionNames = []string{
+		"Electronics", "Home and Garden", "Fashion", "Sports and Outdoors", "Books",
+		"Toys and Games", "Health and Beauty", "Automotive", "Groceries", "Office Supplies",
+	}
+	categoryNames = []string{
+		"Accessories", "Essentials", "Bestsellers", "New Arrivals", "Professional",
+		"Kids", "Outdoor", "Storage", "Gifts", "Clearance",
+	}
+	subcategoryNames = []string{
+		"Basic", "Premium", "Compact", "Large", "Wireless",
+		"Handmade", "Eco", "Travel", "Limited Edition", "Refurbished",
+	}
+	adjectives = []string{
+		"Classic", "Modern", "Compact", "Deluxe", "Smart",
+		"Rugged", "Premium", "Vintage", "Wireless", "Ergonomic",
+	}
+	nouns = []string{
+		"Lamp", "Backpack", "Headphones", "Kettle", "Chair", "Watch",
+		"Jacket", "Blender", "Notebook", "Speaker", "Sneakers", "Tent",
+	}
+	qualities = []string{
+		"reliable", "durable", "lightweight", "stylish", "affordable", "versatile",
+	}
+)
+
+// Options configures the generated data. The same options always generate the same data.
+type Options struct {
+	// Seed seeds the random generator choosing prices, ratings, counts and subcategories.
+	Seed                     uint64
+	Sections                 int
+	CategoriesPerSection     int
+	SubcategoriesPerCategory int
+	Products                 int
+	// UsersPerRole is the number of users generated for every role.
+	UsersPerRole int
+	// Password is the plain password of every generated user.
+	Password string
+}
+
+// Generate creates a dataset with the number of rows set by the options.
+//
+// Note: Products are only generated if there is at least one subcategory to put them in.
+func Generate(options *Options) *Dataset {
+	rng := rand.New(rand.NewPCG(options.Seed, options.Seed))
+	dataset := &Dataset{}
+
+	for i := range options.Sections {
+		section := domain.CategorySection{Name: pick(sectionNames, i)}
+		section.Id = sectionId(section.Name)
+		dataset.Sections = append(dataset.Sections, section)
+
+		for j := range options.CategoriesPerSection {
+			category := domain.Category{Name: pick(categoryNames, j), SectionId: section.Id}
+			category.Id = categoryId(section.Name, category.Name)
+			dataset.Categories = append(dataset.Categories, category)
+
+			for k := range options.SubcategoriesPerCategory {
+				subcategory := domain.Subcategory{Name: pick(subcategoryNames, k), CategoryID: category.Id}
+				subcategory.Id = subcategoryId(section.Name, category.Name, subcategory.Name)
+				dataset.Subcategories = append(dataset.Subcategories, subcategory)
+			}
+		}
+	}
+
+	if len(dataset.Subcategories) > 0 {
+		for i := range options.Products {
+			dataset.Products = append(dataset.Products, generateProduct(rng, i, dataset.Subcategories))
+		}
+	}
+
+	for _, role := range roles {
+		for i := range options.UsersPerRole {
+			username := fmt.Sprintf("%s%04d", role, i+1)
+			user := domain.User{
+				Id:       userId(username),
+				Username: username,
+				Email:    username + "@seed.example.com",
+				Password: options.Password,
+				Role:     role,
+				Status:   domain.UserActive,
+			}
+			dataset.Users = append(dataset.Users, user)
+		}
+	}
+	return dataset
+}
+
+// generateProduct creates the i-th product in one or two of the subcategories.
+func generateProduct(rng *rand.Rand, i int, subcategories []domain.Subcategory) domain.Product {
+	adjective := adjectives[rng.IntN(len(adjectives))]
+	noun := nouns[rng.IntN(len(nouns))]
+	name := fmt.Sprintf("%s %s %05d", adjective, noun, i+1)
+
+	product := domain.Product{
+		Id:   productId(name),
+		Name: name,
+		Description: fmt.Sprintf(
+			"The %s %s is a %s choice for everyday use.",
+			strings.ToLower(adjective),
+			strings.ToLower(noun),
+			qualities[rng.IntN(len(qualities))],
+		),
+		// Prices range from 1.00 to 999.99 and ratings from 0.0 to 5.0.
+		Price:    decimal.New(int64(rng.IntN(99_900)+100), -2),
+		Rating:   decimal.New(int64(rng.IntN(51)), -1),
+		Count:    rng.IntN(500),
+		ImageUrl: fmt.Sprintf("https://picsum.photos/seed/product%05d/640/480", i+1),
+	}
+
+	first := rng.IntN(len(subcategories))
+	product.Subcategories = []domain.Subcategory{subcategories[first]}
+	if second := rng.IntN(len(subcategories)); rng.IntN(3) == 0 && second != first {
+		product.Subcategories = append(product.Subcategories, subcategories[second])
+	}
+	return product
+}
+
+// pick returns the i-th name, numbering the names once all of them are used, e.g. "Books 2".
+func pick(names []string, i int) string {
+	if i < len(names) {
+		return names[i]
+	}
+	return fmt.Sprintf("%s %d", names[i%len(names)], i/len(names)+1)
+}
@@ -0,0 +1,99 @@
+package seed
+
+import (
+	"shop-api-go/internal/core/domain"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerate(t *testing.T) {
+	options := &Options{
+		Seed:                     42,
+		Sections:                 12,
+		CategoriesPerSection:     3,
+		SubcategoriesPerCategory: 2,
+		Products:                 200,
+		UsersPerRole:             3,
+		Password:                 "Seed-Password-1",
+	}
+
+	dataset := Generate(options)
+	require.Len(t, dataset.Sections, 12)
+	require.Len(t, dataset.Categories, 36)
+	require.Len(t, dataset.Subcategories, 72)
+	require.Len(t, dataset.Products, 200)
+	require.Len(t, dataset.Users, 12)
+
+	t.Run("same options generate same data", func(t *testing.T) {
+		require.Equal(t, dataset, Generate(options))
+	})
+
+	t.Run("other seed generates other products", func(t *testing.T) {
+		other := *options
+		other.Seed = 43
+		generated := Generate(&other)
+		require.Equal(t, dataset.Subcategories, generated.Subcategories)
+		require.Equal(t, dataset.Users, generated.Users)
+		require.NotEqual(t, dataset.Products, generated.Products)
+	})
+
+	t.Run("names are unique", func(t *testing.T) {
+		sections := make(map[string]bool)
+		for _, section := range dataset.Sections {
+			require.False(t, sections[section.Name], section.Name)
+			sections[section.Name] = true
+		}
+		require.True(t, sections["Electronics 2"])
+
+		products := make(map[string]bool)
+		for _, product := range dataset.Products {
+			require.False(t, products[product.Name], product.Name)
+			products[product.Name] = true
+		}
+	})
+
+	t.Run("rows satisfy the constraints of the tables", func(t *testing.T) {
+		subcategories := make(map[uuid.UUID]bool)
+		for _, subcategory := range dataset.Subcategories {
+			subcategories[subcategory.Id] = true
+		}
+
+		for _, product := range dataset.Products {
+			require.Greater(t, len(product.Name), 8)
+			require.Greater(t, len(product.Description), 24)
+			require.True(t, product.Price.IsPositive(), product.Price)
+			require.LessOrEqual(t, product.Price.Exponent(), int32(0))
+			require.GreaterOrEqual(t, product.Price.Exponent(), int32(-2))
+			require.False(t, product.Rating.IsNegative(), product.Rating)
+			require.True(t, product.Rating.LessThanOrEqual(decimal.NewFromInt(5)), product.Rating)
+			require.GreaterOrEqual(t, product.Count, 0)
+			require.NotEmpty(t, product.Subcategories)
+			for _, subcategory := range product.Subcategories {
+				require.True(t, subcategories[subcategory.Id])
+			}
+		}
+
+		roles := make(map[domain.UserRole]int)
+		for _, user := range dataset.Users {
+			require.GreaterOrEqual(t, len(user.Username), 8)
+			require.GreaterOrEqual(t, len(user.Email), 12)
+			require.Equal(t, "Seed-Password-1", user.Password)
+			require.Equal(t, domain.UserActive, user.Status)
+			roles[user.Role]++
+		}
+		require.Equal(
+			t,
+			map[domain.UserRole]int{domain.Admin: 3, domain.Client: 3, domain.Delivery: 3, domain.Warehouse: 3},
+			roles,
+		)
+	})
+}
+
+func TestGenerate_NoSubcategories(t *testing.T) {
+	dataset := Generate(&Options{Seed: 1, Sections: 2, Products: 10})
+	require.Len(t, dataset.Sections, 2)
+	require.Empty(t, dataset.Products)
+}
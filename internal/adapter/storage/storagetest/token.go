@@ -0,0 +1,65 @@
+package storagetest
+
+import (
+	"context"
+	"shop-api-go/internal/core/domain"
+	"shop-api-go/internal/core/port"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+// TestTokenRepository runs the contract tests of port.TokenRepository, newRepositories has to
+// return an empty token repository and the user repository of the users the tokens belong to.
+func TestTokenRepository(t *testing.T, newRepositories func(t *testing.T) (port.UserRepository, port.TokenRepository)) {
+	ctx := context.Background()
+
+	t.Run("add and delete token", func(t *testing.T) {
+		userRepository, tokenRepository := newRepositories(t)
+		users := addUsers(t, userRepository, newUser("johnsmith", "john.smith@example.com"))
+
+		token := domain.NewToken(uuid.New(), users[0].Id, users[0].Role, domain.RefreshToken, time.Now().Add(time.Hour))
+		require.NoError(t, tokenRepository.AddToken(ctx, token, userEvent(domain.AuditLoginSucceeded, users[0].Id)))
+		require.Error(t, tokenRepository.AddToken(ctx, token, userEvent(domain.AuditLoginSucceeded, users[0].Id)))
+
+		require.NoError(t, tokenRepository.DeleteToken(ctx, token.Id))
+		require.ErrorIs(t, tokenRepository.DeleteToken(ctx, token.Id), domain.ErrTokenNotFound)
+	})
+
+	t.Run("delete all tokens of user", func(t *testing.T) {
+		userRepository, tokenRepository := newRepositories(t)
+		users := addUsers(t, userRepository,
+			newUser("johnsmith", "john.smith@example.com"),
+			newUser("janedoe1", "jane.doe@example.com"),
+		)
+
+		var tokens []*domain.Token
+		for _, user := range []domain.User{users[0], users[0], users[1]} {
+			token := domain.NewToken(uuid.New(), user.Id, user.Role, domain.RefreshToken, time.Now().Add(time.Hour))
+			require.NoError(t, tokenRepository.AddToken(ctx, token, userEvent(domain.AuditLoginSucceeded, user.Id)))
+			tokens = append(tokens, token)
+		}
+
+		require.NoError(t, tokenRepository.DeleteAllTokensByUserId(ctx, users[0].Id, userEvent(domain.AuditTokensRevoked, users[0].Id)))
+		require.ErrorIs(t, tokenRepository.DeleteToken(ctx, tokens[0].Id), domain.ErrTokenNotFound)
+		require.ErrorIs(t, tokenRepository.DeleteToken(ctx, tokens[1].Id), domain.ErrTokenNotFound)
+		require.NoError(t, tokenRepository.DeleteToken(ctx, tokens[2].Id))
+	})
+
+	t.Run("delete expired tokens", func(t *testing.T) {
+		userRepository, tokenRepository := newRepositories(t)
+		users := addUsers(t, userRepository, newUser("johnsmith", "john.smith@example.com"))
+
+		// The expiries are a day apart from now, so time zones of the backends do not matter.
+		expired := domain.NewToken(uuid.New(), users[0].Id, users[0].Role, domain.RefreshToken, time.Now().Add(-24*time.Hour))
+		valid := domain.NewToken(uuid.New(), users[0].Id, users[0].Role, domain.RefreshToken, time.Now().Add(24*time.Hour))
+		require.NoError(t, tokenRepository.AddToken(ctx, expired, userEvent(domain.AuditLoginSucceeded, users[0].Id)))
+		require.NoError(t, tokenRepository.AddToken(ctx, valid, userEvent(domain.AuditLoginSucceeded, users[0].Id)))
+
+		require.NoError(t, tokenRepository.DeleteExpiredTokens())
+		require.ErrorIs(t, tokenRepository.DeleteToken(ctx, expired.Id), domain.ErrTokenNotFound)
+		require.NoError(t, tokenRepository.DeleteToken(ctx, valid.Id))
+	})
+}
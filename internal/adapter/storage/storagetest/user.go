@@ -0,0 +1,476 @@
+// Package storagetest contains the contract tests every storage backend of the repositories has to pass,
+// so the backends stay interchangeable.
+package storagetest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"shop-api-go/internal/core/domain"
+	"shop-api-go/internal/core/port"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+// newUser creates a user that satisfies the constraints of every backend.
+func newUser(username, email string) *domain.User {
+	return domain.NewUser(uuid.New(), username, email, "password-hash", domain.Client, time.Time{}, time.Time{})
+}
+
+// addUsers adds the users to the repository and returns them as they are stored, without passwords.
+func addUsers(t *testing.T, repository port.UserRepository, users ...*domain.User) []domain.User {
+	t.Helper()
+	ctx := context.Background()
+
+	stored := make([]domain.User, 0, len(users))
+	for _, user := range users {
+		require.NoError(t, repository.AddUser(ctx, user, userEvent(domain.AuditUserCreated, user.Id), nil))
+		got, err := repository.GetUserById(ctx, user.Id)
+		require.NoError(t, err)
+		stored = append(stored, *got)
+	}
+	return stored
+}
+
+// userEvent creates an audit event without an actor for the user.
+func userEvent(action domain.AuditAction, userId uuid.UUID) *domain.AuditEvent {
+	return domain.NewAuditEvent(context.Background(), nil, action, domain.AuditTargetUser, userId.String())
+}
+
+// userIds returns the ids of the users in their order.
+func userIds(users []domain.User) []uuid.UUID {
+	ids := make([]uuid.UUID, 0, len(users))
+	for _, user := range users {
+		ids = append(ids, user.Id)
+	}
+	return ids
+}
+
+// TestUserRepository runs the contract tests of port.UserRepository,
+// newRepository has to return an empty repository on every call.
+func TestUserRepository(t *testing.T, newRepository func(t *testing.T) port.UserRepository) {
+	ctx := context.Background()
+
+	t.Run("add and get user", func(t *testing.T) {
+		repository := newRepository(t)
+		user := newUser("johnsmith", "john.smith@example.com")
+		user.Role = domain.Warehouse
+		require.NoError(t, repository.AddUser(ctx, user, userEvent(domain.AuditUserCreated, user.Id), nil))
+
+		byId, err := repository.GetUserById(ctx, user.Id)
+		require.NoError(t, err)
+		require.Equal(t, user.Id, byId.Id)
+		require.Equal(t, "johnsmith", byId.Username)
+		require.Equal(t, "john.smith@example.com", byId.Email)
+		require.Empty(t, byId.Password)
+		require.Equal(t, domain.Warehouse, byId.Role)
+		require.Equal(t, domain.UserActive, byId.Status)
+		require.False(t, byId.CreatedAt.IsZero())
+		require.False(t, byId.UpdatedAt.IsZero())
+
+		byEmail, err := repository.GetUserByEmail(ctx, user.Email)
+		require.NoError(t, err)
+		require.Equal(t, byId, byEmail)
+
+		byUsername, err := repository.GetUserByUsername(ctx, user.Username)
+		require.NoError(t, err)
+		require.Equal(t, user.Id, byUsername.Id)
+		require.Equal(t, "password-hash", byUsername.Password)
+		require.Equal(t, domain.UserActive, byUsername.Status)
+
+		_, err = repository.GetUserById(ctx, uuid.New())
+		require.ErrorIs(t, err, domain.ErrUserNotFound)
+		_, err = repository.GetUserByEmail(ctx, "jane.doe@example.com")
+		require.ErrorIs(t, err, domain.ErrUserNotFound)
+		_, err = repository.GetUserByUsername(ctx, "janedoe1")
+		require.ErrorIs(t, err, domain.ErrUserNotFound)
+	})
+
+	t.Run("add user with taken username or email", func(t *testing.T) {
+		repository := newRepository(t)
+		users := addUsers(t, repository, newUser("johnsmith", "john.smith@example.com"))
+
+		user := newUser("johnsmith", "other.john@example.com")
+		err := repository.AddUser(ctx, user, userEvent(domain.AuditUserCreated, user.Id), nil)
+		require.ErrorIs(t, err, domain.ErrUsernameAlreadyInUse)
+
+		user = newUser("otherjohn", "john.smith@example.com")
+		err = repository.AddUser(ctx, user, userEvent(domain.AuditUserCreated, user.Id), nil)
+		require.ErrorIs(t, err, domain.ErrEmailAlreadyInUse)
+
+		// Deleted users keep their username and email, so they can be restored.
+		require.NoError(t, repository.DeleteUser(ctx, users[0].Id, userEvent(domain.AuditUserDeleted, users[0].Id)))
+		user = newUser("johnsmith", "other.john@example.com")
+		err = repository.AddUser(ctx, user, userEvent(domain.AuditUserCreated, user.Id), nil)
+		require.ErrorIs(t, err, domain.ErrUsernameAlreadyInUse)
+	})
+
+	t.Run("add user concurrently", func(t *testing.T) {
+		repository := newRepository(t)
+
+		var wg sync.WaitGroup
+		errs := make([]error, 10)
+		for i := range errs {
+			wg.Go(func() {
+				user := newUser("johnsmith", fmt.Sprintf("john.smith%d@example.com", i))
+				errs[i] = repository.AddUser(ctx, user, userEvent(domain.AuditUserCreated, user.Id), nil)
+			})
+		}
+		wg.Wait()
+
+		added := 0
+		for _, err := range errs {
+			if err == nil {
+				added++
+			} else {
+				require.ErrorIs(t, err, domain.ErrUsernameAlreadyInUse)
+			}
+		}
+		require.Equal(t, 1, added)
+	})
+
+	t.Run("add users", func(t *testing.T) {
+		repository := newRepository(t)
+		addUsers(t, repository, newUser("johnsmith", "john.smith@example.com"))
+
+		importEvent := func(user *domain.User) []*domain.AuditEvent {
+			return []*domain.AuditEvent{userEvent(domain.AuditUserImported, user.Id)}
+		}
+
+		conflicting := newUser("johnsmith", "other.john@example.com")
+		valid := newUser("janedoe1", "jane.doe@example.com")
+		err := repository.CheckImportConflicts(ctx, []domain.UserImportRow{
+			{Line: 2, User: *valid},
+			{Line: 3, User: *conflicting},
+		})
+		var importErr *domain.UserImportError
+		require.ErrorAs(t, err, &importErr)
+		require.Equal(t, []domain.UserImportRowError{{Line: 3, Err: domain.ErrUsernameAlreadyInUse}}, importErr.Rows)
+		require.NoError(t, repository.CheckImportConflicts(ctx, []domain.UserImportRow{{Line: 2, User: *valid}}))
+
+		err = repository.AddUsers(ctx, []domain.UserImportRow{
+			{Line: 2, User: *valid},
+			{Line: 3, User: *conflicting},
+		}, importEvent(valid), nil)
+		require.ErrorAs(t, err, &importErr)
+		require.Equal(t, []domain.UserImportRowError{{Line: 3, Err: domain.ErrUsernameAlreadyInUse}}, importErr.Rows)
+		_, err = repository.GetUserById(ctx, valid.Id)
+		require.ErrorIs(t, err, domain.ErrUserNotFound)
+
+		duplicate := newUser("janedoe2", "jane.doe@example.com")
+		err = repository.AddUsers(ctx, []domain.UserImportRow{
+			{Line: 2, User: *valid},
+			{Line: 3, User: *duplicate},
+		}, importEvent(valid), nil)
+		require.ErrorAs(t, err, &importErr)
+		require.Equal(t, []domain.UserImportRowError{{Line: 3, Err: domain.ErrEmailAlreadyInUse}}, importErr.Rows)
+		_, err = repository.GetUserById(ctx, valid.Id)
+		require.ErrorIs(t, err, domain.ErrUserNotFound)
+
+		other := newUser("janedoe2", "jane.doe2@example.com")
+		require.NoError(t, repository.AddUsers(ctx, []domain.UserImportRow{
+			{Line: 2, User: *valid},
+			{Line: 3, User: *other},
+		}, importEvent(valid), nil))
+		count, err := repository.CountUsers(ctx, &domain.UserFilter{})
+		require.NoError(t, err)
+		require.Equal(t, 3, count)
+	})
+
+	t.Run("update user", func(t *testing.T) {
+		repository := newRepository(t)
+		users := addUsers(t, repository,
+			newUser("johnsmith", "john.smith@example.com"),
+			newUser("janedoe1", "jane.doe@example.com"),
+		)
+		event := userEvent(domain.AuditUserUpdated, users[0].Id)
+
+		taken := "janedoe1"
+		err := repository.UpdateUser(ctx, &domain.UserUpdate{Id: users[0].Id, Username: &taken}, event, nil)
+		require.ErrorIs(t, err, domain.ErrUsernameAlreadyInUse)
+		taken = "jane.doe@example.com"
+		err = repository.UpdateUser(ctx, &domain.UserUpdate{Id: users[0].Id, Email: &taken}, event, nil)
+		require.ErrorIs(t, err, domain.ErrEmailAlreadyInUse)
+
+		username, email, password, role := "johnsmith", "johnny.smith@example.com", "new-hash", domain.Delivery
+		require.NoError(t, repository.UpdateUser(ctx, &domain.UserUpdate{
+			Id:       users[0].Id,
+			Username: &username,
+			Email:    &email,
+			Password: &password,
+			Role:     &role,
+		}, event, nil))
+		updated, err := repository.GetUserByUsername(ctx, username)
+		require.NoError(t, err)
+		require.Equal(t, email, updated.Email)
+		require.Equal(t, password, updated.Password)
+		require.Equal(t, role, updated.Role)
+		updated, err = repository.GetUserById(ctx, users[0].Id)
+		require.NoError(t, err)
+		require.False(t, updated.UpdatedAt.Before(users[0].UpdatedAt))
+
+		err = repository.UpdateUser(ctx, &domain.UserUpdate{Id: uuid.New(), Email: &email}, event, nil)
+		require.ErrorIs(t, err, domain.ErrUserNotFound)
+	})
+
+	t.Run("update password hash and status", func(t *testing.T) {
+		repository := newRepository(t)
+		users := addUsers(t, repository, newUser("johnsmith", "john.smith@example.com"))
+
+		require.NoError(t, repository.UpdatePasswordHash(ctx, users[0].Id, "rehashed"))
+		require.NoError(t, repository.SetUserStatus(ctx, users[0].Id, domain.UserSuspended, userEvent(domain.AuditUserSuspended, users[0].Id)))
+		user, err := repository.GetUserByUsername(ctx, "johnsmith")
+		require.NoError(t, err)
+		require.Equal(t, "rehashed", user.Password)
+		require.Equal(t, domain.UserSuspended, user.Status)
+
+		require.ErrorIs(t, repository.UpdatePasswordHash(ctx, uuid.New(), "rehashed"), domain.ErrUserNotFound)
+		err = repository.SetUserStatus(ctx, uuid.New(), domain.UserSuspended, userEvent(domain.AuditUserSuspended, users[0].Id))
+		require.ErrorIs(t, err, domain.ErrUserNotFound)
+	})
+
+	t.Run("delete and restore user", func(t *testing.T) {
+		repository := newRepository(t)
+		users := addUsers(t, repository, newUser("johnsmith", "john.smith@example.com"))
+		id := users[0].Id
+
+		require.ErrorIs(t, repository.RestoreUser(ctx, id, userEvent(domain.AuditUserRestored, id)), domain.ErrUserNotDeleted)
+		require.NoError(t, repository.DeleteUser(ctx, id, userEvent(domain.AuditUserDeleted, id)))
+		require.ErrorIs(t, repository.DeleteUser(ctx, id, userEvent(domain.AuditUserDeleted, id)), domain.ErrUserNotFound)
+
+		_, err := repository.GetUserById(ctx, id)
+		require.ErrorIs(t, err, domain.ErrUserNotFound)
+		_, err = repository.GetUserByUsername(ctx, "johnsmith")
+		require.ErrorIs(t, err, domain.ErrUserNotFound)
+		_, err = repository.GetUserByEmail(ctx, "john.smith@example.com")
+		require.ErrorIs(t, err, domain.ErrUserNotFound)
+		count, err := repository.CountUsers(ctx, &domain.UserFilter{})
+		require.NoError(t, err)
+		require.Zero(t, count)
+
+		require.NoError(t, repository.RestoreUser(ctx, id, userEvent(domain.AuditUserRestored, id)))
+		_, err = repository.GetUserById(ctx, id)
+		require.NoError(t, err)
+
+		require.ErrorIs(t, repository.DeleteUser(ctx, uuid.New(), userEvent(domain.AuditUserDeleted, id)), domain.ErrUserNotFound)
+		require.ErrorIs(t, repository.RestoreUser(ctx, uuid.New(), userEvent(domain.AuditUserRestored, id)), domain.ErrUserNotFound)
+	})
+
+	t.Run("get users by page", func(t *testing.T) {
+		repository := newRepository(t)
+		users := addUsers(t, repository,
+			newUser("username3", "user3@example.com"),
+			newUser("username1", "user1@example.com"),
+			newUser("username5", "user5@example.com"),
+			newUser("username2", "user2@example.com"),
+			newUser("username4", "user4@example.com"),
+			newUser("username6", "user6@example.com"),
+		)
+		require.NoError(t, repository.DeleteUser(ctx, users[5].Id, userEvent(domain.AuditUserDeleted, users[5].Id)))
+
+		limit := 2
+		tests := []struct {
+			name       string
+			sortBy     domain.UserSortField
+			descending bool
+			page       int
+			expected   []uuid.UUID
+		}{
+			{
+				name:     "first page",
+				sortBy:   domain.UserSortUsername,
+				page:     1,
+				expected: []uuid.UUID{users[1].Id, users[3].Id},
+			}, {
+				name:     "second page",
+				sortBy:   domain.UserSortUsername,
+				page:     2,
+				expected: []uuid.UUID{users[0].Id, users[4].Id},
+			}, {
+				name:     "last page",
+				sortBy:   domain.UserSortEmail,
+				page:     3,
+				expected: []uuid.UUID{users[2].Id},
+			}, {
+				name:       "descending",
+				sortBy:     domain.UserSortUsername,
+				descending: true,
+				page:       1,
+				expected:   []uuid.UUID{users[2].Id, users[4].Id},
+			}, {
+				name:     "page after last",
+				sortBy:   domain.UserSortUsername,
+				page:     4,
+				expected: []uuid.UUID{},
+			},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				got, err := repository.GetUsers(ctx, &domain.GetUsers{
+					SortBy:     tt.sortBy,
+					Descending: tt.descending,
+					Page:       &tt.page,
+					Limit:      &limit,
+				})
+				require.NoError(t, err)
+				require.Equal(t, tt.expected, userIds(got))
+			})
+		}
+	})
+
+	t.Run("get users by cursor", func(t *testing.T) {
+		repository := newRepository(t)
+		users := addUsers(t, repository,
+			newUser("username1", "user1@example.com"),
+			newUser("username2", "user2@example.com"),
+			newUser("username3", "user3@example.com"),
+			newUser("username4", "user4@example.com"),
+			newUser("username5", "user5@example.com"),
+		)
+		// Every user shares the role, so the id decides the order.
+		require.NoError(t, repository.SetUserStatus(ctx, users[2].Id, domain.UserSuspended, userEvent(domain.AuditUserSuspended, users[2].Id)))
+
+		for _, sortBy := range []domain.UserSortField{
+			domain.UserSortId,
+			domain.UserSortUsername,
+			domain.UserSortRole,
+			domain.UserSortStatus,
+			domain.UserSortCreatedAt,
+			domain.UserSortUpdatedAt,
+		} {
+			for _, descending := range []bool{false, true} {
+				t.Run(fmt.Sprintf("%s descending %t", sortBy, descending), func(t *testing.T) {
+					limit := 2
+					get := &domain.GetUsers{SortBy: sortBy, Descending: descending, Limit: &limit}
+
+					var pages []domain.User
+					for range len(users) {
+						page, err := repository.GetUsers(ctx, get)
+						require.NoError(t, err)
+						require.LessOrEqual(t, len(page), limit)
+						if len(page) == 0 {
+							break
+						}
+						pages = append(pages, page...)
+						get.Cursor = domain.NewUserCursor(&page[len(page)-1], sortBy, descending)
+					}
+
+					limit = len(users)
+					get.Cursor = nil
+					all, err := repository.GetUsers(ctx, get)
+					require.NoError(t, err)
+					require.Len(t, all, len(users))
+					require.Equal(t, userIds(all), userIds(pages))
+				})
+			}
+		}
+	})
+
+	t.Run("get users with invalid query", func(t *testing.T) {
+		repository := newRepository(t)
+		limit := 10
+
+		_, err := repository.GetUsers(ctx, &domain.GetUsers{SortBy: domain.UserSortField("password"), Limit: &limit})
+		require.ErrorIs(t, err, domain.ErrInvalidQuery)
+
+		_, err = repository.GetUsers(ctx, &domain.GetUsers{
+			SortBy: domain.UserSortCreatedAt,
+			Cursor: &domain.UserCursor{SortBy: domain.UserSortCreatedAt, Value: "yesterday", Id: uuid.New()},
+			Limit:  &limit,
+		})
+		require.ErrorIs(t, err, domain.ErrInvalidCursor)
+	})
+
+	t.Run("filter users", func(t *testing.T) {
+		repository := newRepository(t)
+		users := addUsers(t, repository,
+			newUser("johnsmith1", "john.smith@example.com"),
+			newUser("janedoe22", "jane.doe@example.com"),
+			newUser("warehouse7", "warehouse@example.com"),
+		)
+		role := domain.Warehouse
+		require.NoError(t, repository.UpdateUser(ctx, &domain.UserUpdate{Id: users[2].Id, Role: &role}, userEvent(domain.AuditUserUpdated, users[2].Id), nil))
+		require.NoError(t, repository.SetUserStatus(ctx, users[1].Id, domain.UserSuspended, userEvent(domain.AuditUserSuspended, users[1].Id)))
+
+		username, email, status := "johnsmith", "john.smith@example.com", domain.UserSuspended
+		tests := []struct {
+			name     string
+			filter   domain.UserFilter
+			expected []uuid.UUID
+		}{
+			{
+				name:     "no filter",
+				filter:   domain.UserFilter{},
+				expected: []uuid.UUID{users[0].Id, users[1].Id, users[2].Id},
+			}, {
+				name:     "id",
+				filter:   domain.UserFilter{Id: &users[1].Id},
+				expected: []uuid.UUID{users[1].Id},
+			}, {
+				name:     "similar username",
+				filter:   domain.UserFilter{Username: &username},
+				expected: []uuid.UUID{users[0].Id},
+			}, {
+				name:     "similar email",
+				filter:   domain.UserFilter{Email: &email},
+				expected: []uuid.UUID{users[0].Id},
+			}, {
+				name:     "role",
+				filter:   domain.UserFilter{Role: &role},
+				expected: []uuid.UUID{users[2].Id},
+			}, {
+				name:     "status",
+				filter:   domain.UserFilter{Status: &status},
+				expected: []uuid.UUID{users[1].Id},
+			},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				limit := 10
+				got, err := repository.GetUsers(ctx, &domain.GetUsers{Filter: tt.filter, SortBy: domain.UserSortUsername, Limit: &limit})
+				require.NoError(t, err)
+				require.ElementsMatch(t, tt.expected, userIds(got))
+
+				count, err := repository.CountUsers(ctx, &tt.filter)
+				require.NoError(t, err)
+				require.Equal(t, len(tt.expected), count)
+			})
+		}
+	})
+
+	t.Run("export users", func(t *testing.T) {
+		repository := newRepository(t)
+		users := addUsers(t, repository,
+			newUser("username1", "user1@example.com"),
+			newUser("username2", "user2@example.com"),
+			newUser("username3", "user3@example.com"),
+		)
+		require.NoError(t, repository.DeleteUser(ctx, users[1].Id, userEvent(domain.AuditUserDeleted, users[1].Id)))
+
+		var exported []domain.User
+		require.NoError(t, repository.ExportUsers(ctx, &domain.UserFilter{}, func(user *domain.User) error {
+			exported = append(exported, *user)
+			return nil
+		}))
+		require.ElementsMatch(t, []uuid.UUID{users[0].Id, users[2].Id}, userIds(exported))
+		require.False(t, exported[1].CreatedAt.Before(exported[0].CreatedAt))
+		for _, user := range exported {
+			require.Empty(t, user.Password)
+		}
+
+		errStop := errors.New("stop")
+		calls := 0
+		err := repository.ExportUsers(ctx, &domain.UserFilter{}, func(*domain.User) error {
+			calls++
+			return errStop
+		})
+		require.ErrorIs(t, err, errStop)
+		require.Equal(t, 1, calls)
+	})
+}
@@ -0,0 +1,17 @@
+package webhook
+
+import (
+	"shop-api-go/internal/core/port"
+
+	"go.uber.org/fx"
+)
+
+var Module = fx.Module(
+	"Webhook",
+	fx.Provide(
+		fx.Annotate(
+			NewHTTPSender,
+			fx.As(new(port.WebhookSender)),
+		),
+	),
+)
@@ -0,0 +1,135 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/netip"
+	"shop-api-go/internal/adapter/config"
+	"shop-api-go/internal/core/domain"
+	"syscall"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// SignatureHeader contains the domain.SignWebhook signature of the request body.
+	SignatureHeader = "X-Webhook-Signature"
+	// DeliveryHeader contains the id of the delivery, it is the same for every attempt.
+	DeliveryHeader = "X-Webhook-Delivery"
+	// EventHeader contains the type of the delivered event.
+	EventHeader = "X-Webhook-Event"
+	// userAgent identifies webhook requests.
+	userAgent = "shop-api-webhooks/1"
+	// maxResponseBytes is how much of a response body is read before the connection is released.
+	maxResponseBytes = 64 << 10
+)
+
+// payload represents the JSON body of a webhook request.
+type payload struct {
+	Id            uuid.UUID                 `json:"id"`
+	Type          domain.EventType          `json:"type"`
+	AggregateType domain.EventAggregateType `json:"aggregateType"`
+	AggregateId   uuid.UUID                 `json:"aggregateId"`
+	OccurredAt    time.Time                 `json:"occurredAt"`
+	Data          map[string]any            `json:"data"`
+}
+
+// HTTPSender implements port.WebhookSender and posts deliveries as signed JSON requests.
+//
+// Note: Redirects are not followed, so a 3xx response counts as a failed attempt. Unless private networks
+// are allowed, every address is checked after it was resolved, right before the connection is opened,
+// so a host name cannot resolve to a private address once its URL was accepted. No proxy is used for the same reason.
+type HTTPSender struct {
+	client       *http.Client
+	requireHTTPS bool
+}
+
+// errPrivateAddress is returned for connections to an address rejected by domain.IsPrivateAddress.
+var errPrivateAddress = errors.New("webhook endpoint resolves to a private address")
+
+// NewHTTPSender creates a new HTTPSender instance.
+func NewHTTPSender(webhookConfig *config.WebhookConfig) *HTTPSender {
+	dialer := &net.Dialer{Timeout: webhookConfig.Timeout}
+	if !webhookConfig.AllowPrivateNetworks {
+		dialer.Control = rejectPrivateAddress
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+
+	return &HTTPSender{
+		client: &http.Client{
+			Timeout:   webhookConfig.Timeout,
+			Transport: transport,
+			CheckRedirect: func(*http.Request, []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+		requireHTTPS: webhookConfig.RequireHTTPS,
+	}
+}
+
+// rejectPrivateAddress is a net.Dialer control function rejecting connections to private addresses.
+func rejectPrivateAddress(_, address string, _ syscall.RawConn) error {
+	addrPort, err := netip.ParseAddrPort(address)
+	if err != nil {
+		return err
+	}
+	if domain.IsPrivateAddress(addrPort.Addr()) {
+		return errPrivateAddress
+	}
+	return nil
+}
+
+func (s *HTTPSender) Send(
+	ctx context.Context,
+	endpoint *domain.WebhookEndpoint,
+	delivery *domain.WebhookDelivery,
+) *domain.WebhookAttempt {
+	startedAt := time.Now()
+	failed := func(err error) *domain.WebhookAttempt {
+		return domain.NewWebhookAttempt(delivery.Id, 0, err.Error(), time.Since(startedAt), startedAt)
+	}
+
+	body, err := json.Marshal(payload{
+		Id:            delivery.Event.Id,
+		Type:          delivery.Event.Type,
+		AggregateType: delivery.Event.AggregateType,
+		AggregateId:   delivery.Event.AggregateId,
+		OccurredAt:    delivery.Event.OccurredAt,
+		Data:          delivery.Event.Payload,
+	})
+	if err != nil {
+		return failed(err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint.Url, bytes.NewReader(body))
+	if err != nil {
+		return failed(err)
+	}
+	// Endpoints may have been stored before https was required.
+	if s.requireHTTPS && req.URL.Scheme != "https" {
+		return failed(domain.ErrInvalidWebhookUrl)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", userAgent)
+	req.Header.Set(DeliveryHeader, delivery.Id.String())
+	req.Header.Set(EventHeader, string(delivery.Event.Type))
+	req.Header.Set(SignatureHeader, domain.SignWebhook(endpoint.Secret, startedAt, body))
+
+	res, err := s.client.Do(req)
+	if err != nil {
+		return failed(err)
+	}
+	// Drain a bounded part of the body so that the connection can be reused.
+	_, _ = io.Copy(io.Discard, io.LimitReader(res.Body, maxResponseBytes))
+	_ = res.Body.Close()
+
+	return domain.NewWebhookAttempt(delivery.Id, res.StatusCode, "", time.Since(startedAt), startedAt)
+}
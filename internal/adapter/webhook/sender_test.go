@@ -0,0 +1,170 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"shop-api-go/internal/adapter/config"
+	"shop-api-go/internal/core/domain"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHTTPSender_Send(t *testing.T) {
+	event := domain.NewEvent(domain.EventUserRegistered, domain.EventAggregateUser, uuid.New(), map[string]any{"username": "Username"})
+	delivery := domain.NewWebhookDelivery(uuid.New(), event)
+	sender := NewHTTPSender(&config.WebhookConfig{Timeout: time.Second, AllowPrivateNetworks: true})
+
+	t.Run("success", func(t *testing.T) {
+		var header http.Header
+		var body []byte
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			header = r.Header
+			body, _ = io.ReadAll(r.Body)
+			w.WriteHeader(http.StatusNoContent)
+		}))
+		defer server.Close()
+		endpoint := &domain.WebhookEndpoint{Url: server.URL, Secret: "whsec_secret", Enabled: true}
+
+		attempt := sender.Send(context.Background(), endpoint, delivery)
+		require.True(t, attempt.Succeeded())
+		require.Equal(t, http.StatusNoContent, attempt.ResponseStatus)
+		require.Equal(t, delivery.Id, attempt.DeliveryId)
+
+		require.Equal(t, "application/json", header.Get("Content-Type"))
+		require.Equal(t, delivery.Id.String(), header.Get(DeliveryHeader))
+		require.Equal(t, string(domain.EventUserRegistered), header.Get(EventHeader))
+
+		signature := header.Get(SignatureHeader)
+		unix, err := strconv.ParseInt(strings.TrimPrefix(strings.Split(signature, ",")[0], "t="), 10, 64)
+		require.NoError(t, err)
+		require.Equal(t, domain.SignWebhook("whsec_secret", time.Unix(unix, 0), body), signature)
+
+		var decoded map[string]any
+		require.NoError(t, json.Unmarshal(body, &decoded))
+		require.Equal(t, event.Id.String(), decoded["id"])
+		require.Equal(t, "user.registered", decoded["type"])
+		require.Equal(t, event.AggregateId.String(), decoded["aggregateId"])
+		require.Equal(t, map[string]any{"username": "Username"}, decoded["data"])
+	})
+
+	t.Run("error status", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}))
+		defer server.Close()
+
+		attempt := sender.Send(context.Background(), &domain.WebhookEndpoint{Url: server.URL}, delivery)
+		require.False(t, attempt.Succeeded())
+		require.Equal(t, http.StatusServiceUnavailable, attempt.ResponseStatus)
+		require.Empty(t, attempt.Error)
+	})
+
+	t.Run("redirect not followed", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			http.Redirect(w, r, "/elsewhere", http.StatusFound)
+		}))
+		defer server.Close()
+
+		attempt := sender.Send(context.Background(), &domain.WebhookEndpoint{Url: server.URL}, delivery)
+		require.False(t, attempt.Succeeded())
+		require.Equal(t, http.StatusFound, attempt.ResponseStatus)
+	})
+
+	t.Run("connection error", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {}))
+		server.Close()
+
+		attempt := sender.Send(context.Background(), &domain.WebhookEndpoint{Url: server.URL}, delivery)
+		require.False(t, attempt.Succeeded())
+		require.Zero(t, attempt.ResponseStatus)
+		require.NotEmpty(t, attempt.Error)
+	})
+}
+
+func TestHTTPSender_Send_Rejected(t *testing.T) {
+	event := domain.NewEvent(domain.EventUserRegistered, domain.EventAggregateUser, uuid.New(), nil)
+	delivery := domain.NewWebhookDelivery(uuid.New(), event)
+	var called bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		called = true
+	}))
+	defer server.Close()
+	_, port, _ := strings.Cut(strings.TrimPrefix(server.URL, "http://"), ":")
+
+	tests := []struct {
+		name          string
+		webhookConfig *config.WebhookConfig
+		url           string
+		expectedError string
+	}{
+		{
+			name:          "loopback address",
+			webhookConfig: &config.WebhookConfig{Timeout: time.Second},
+			url:           server.URL,
+			expectedError: errPrivateAddress.Error(),
+		}, {
+			name:          "host name resolving to loopback address",
+			webhookConfig: &config.WebhookConfig{Timeout: time.Second},
+			url:           "http://localhost:" + port,
+			expectedError: errPrivateAddress.Error(),
+		}, {
+			name:          "http url with https required",
+			webhookConfig: &config.WebhookConfig{Timeout: time.Second, RequireHTTPS: true, AllowPrivateNetworks: true},
+			url:           server.URL,
+			expectedError: domain.ErrInvalidWebhookUrl.Error(),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			called = false
+			sender := NewHTTPSender(tt.webhookConfig)
+
+			attempt := sender.Send(context.Background(), &domain.WebhookEndpoint{Url: tt.url}, delivery)
+			require.False(t, attempt.Succeeded())
+			require.Zero(t, attempt.ResponseStatus)
+			require.Contains(t, attempt.Error, tt.expectedError)
+			require.False(t, called)
+		})
+	}
+}
+
+func TestRejectPrivateAddress(t *testing.T) {
+	tests := []struct {
+		address  string
+		rejected bool
+	}{
+		{address: "127.0.0.1:443", rejected: true},
+		{address: "10.0.0.1:443", rejected: true},
+		{address: "172.16.0.1:443", rejected: true},
+		{address: "192.168.1.1:443", rejected: true},
+		{address: "169.254.169.254:80", rejected: true},
+		{address: "0.0.0.0:443", rejected: true},
+		{address: "[::1]:443", rejected: true},
+		{address: "[::]:443", rejected: true},
+		{address: "[fe80::1]:443", rejected: true},
+		{address: "[fd00::1]:443", rejected: true},
+		{address: "[::ffff:127.0.0.1]:443", rejected: true},
+		{address: "93.184.216.34:443", rejected: false},
+		{address: "[2606:2800:220:1:248:1893:25c8:1946]:443", rejected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.address, func(t *testing.T) {
+			err := rejectPrivateAddress("tcp", tt.address, nil)
+			if tt.rejected {
+				require.ErrorIs(t, err, errPrivateAddress)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
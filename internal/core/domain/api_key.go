@@ -0,0 +1,113 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// APIKeyType is an enum for API key's type.
+type APIKeyType string
+
+// APIKeyType enum values.
+const (
+	// ServiceAPIKey is created by an admin for integrations like the ERP or warehouse scanners.
+	ServiceAPIKey = APIKeyType("api_key")
+	// PersonalAccessToken is created by a user for himself.
+	PersonalAccessToken = APIKeyType("personal_access_token")
+)
+
+// APIKey is an entity representing a hashed API key or personal access token.
+type APIKey struct {
+	Id         uuid.UUID
+	UserId     uuid.UUID
+	Name       string
+	KeyType    APIKeyType
+	Prefix     string
+	SecretHash string
+	Scopes     []Permission
+	ExpiresAt  time.Time
+	LastUsedAt *time.Time
+	RevokedAt  *time.Time
+	CreatedAt  time.Time
+}
+
+// NewAPIKey creates a new APIKey instance.
+func NewAPIKey(
+	id, userId uuid.UUID,
+	name string,
+	keyType APIKeyType,
+	prefix, secretHash string,
+	scopes []Permission,
+	expiresAt time.Time,
+) *APIKey {
+	return &APIKey{
+		Id:         id,
+		UserId:     userId,
+		Name:       name,
+		KeyType:    keyType,
+		Prefix:     prefix,
+		SecretHash: secretHash,
+		Scopes:     scopes,
+		ExpiresAt:  expiresAt,
+	}
+}
+
+// IsActive reports whether the key is neither revoked nor expired at specific time.
+func (k *APIKey) IsActive(now time.Time) bool {
+	return k.RevokedAt == nil && now.Before(k.ExpiresAt)
+}
+
+// APIKeySecret is a DTO containing a generated API key and its identification prefix.
+type APIKeySecret struct {
+	Prefix string
+	Secret string
+}
+
+// NewAPIKeySecret creates a new APIKeySecret instance.
+func NewAPIKeySecret(prefix, secret string) *APIKeySecret {
+	return &APIKeySecret{
+		Prefix: prefix,
+		Secret: secret,
+	}
+}
+
+// CreateAPIKey is a DTO for creating an API key.
+type CreateAPIKey struct {
+	UserId    uuid.UUID
+	Name      string
+	Scopes    []Permission
+	ExpiresIn *time.Duration
+}
+
+// NewCreateAPIKey creates a new CreateAPIKey instance.
+func NewCreateAPIKey(userId uuid.UUID, name string, scopes []Permission, expiresIn *time.Duration) *CreateAPIKey {
+	return &CreateAPIKey{
+		UserId:    userId,
+		Name:      name,
+		Scopes:    scopes,
+		ExpiresIn: expiresIn,
+	}
+}
+
+// CreatedAPIKey is a DTO containing a created API key and its secret.
+//
+// Note: The secret is only stored hashed, so it cannot be shown again.
+type CreatedAPIKey struct {
+	Key    *APIKey
+	Secret string
+}
+
+// NewCreatedAPIKey creates a new CreatedAPIKey instance.
+func NewCreatedAPIKey(key *APIKey, secret string) *CreatedAPIKey {
+	return &CreatedAPIKey{
+		Key:    key,
+		Secret: secret,
+	}
+}
+
+// APIKeyPolicy contains the limits for the expiry of API keys.
+type APIKeyPolicy struct {
+	DefaultExpireTime time.Duration
+	MaxExpireTime     time.Duration
+}
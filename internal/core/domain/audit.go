@@ -0,0 +1,150 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// AuditAction is an enum for actions recorded in the audit log.
+type AuditAction string
+
+// AuditAction enum values.
+const (
+	AuditLoginSucceeded        = AuditAction("auth.login_succeeded")
+	AuditLoginFailed           = AuditAction("auth.login_failed")
+	AuditMFAFailed             = AuditAction("auth.mfa_failed")
+	AuditSessionRefreshed      = AuditAction("auth.session_refreshed")
+	AuditImpersonationStarted  = AuditAction("auth.impersonation_started")
+	AuditImpersonatedRequest   = AuditAction("auth.impersonated_request")
+	AuditUserRegistered        = AuditAction("user.registered")
+	AuditUserCreated           = AuditAction("user.created")
+	AuditUserImported          = AuditAction("user.imported")
+	AuditUserUpdated           = AuditAction("user.updated")
+	AuditUserSuspended         = AuditAction("user.suspended")
+	AuditUserReactivated       = AuditAction("user.reactivated")
+	AuditUserDeleted           = AuditAction("user.deleted")
+	AuditUserRestored          = AuditAction("user.restored")
+	AuditUserUnlocked          = AuditAction("user.unlocked")
+	AuditUserErased            = AuditAction("user.erased")
+	AuditDataExportRequested   = AuditAction("user.data_export_requested")
+	AuditDataExportDownloaded  = AuditAction("user.data_export_downloaded")
+	AuditErasureRequested      = AuditAction("user.erasure_requested")
+	AuditTokensRevoked         = AuditAction("user.tokens_revoked")
+	AuditMFAEnabled            = AuditAction("user.mfa_enabled")
+	AuditMFADisabled           = AuditAction("user.mfa_disabled")
+	AuditAPIKeyCreated         = AuditAction("api_key.created")
+	AuditAPIKeyRevoked         = AuditAction("api_key.revoked")
+	AuditRoleCreated           = AuditAction("role.created")
+	AuditRoleUpdated           = AuditAction("role.updated")
+	AuditRoleDeleted           = AuditAction("role.deleted")
+	AuditMFARequirementUpdated = AuditAction("role.mfa_requirement_updated")
+	AuditWebhookCreated        = AuditAction("webhook.created")
+	AuditWebhookUpdated        = AuditAction("webhook.updated")
+	AuditWebhookDeleted        = AuditAction("webhook.deleted")
+	AuditWebhookSecretRotated  = AuditAction("webhook.secret_rotated")
+	AuditWebhookReplayed       = AuditAction("webhook.delivery_replayed")
+	AuditSettingsUpdated       = AuditAction("settings.updated")
+	AuditSettingsReloaded      = AuditAction("settings.reloaded")
+)
+
+// AuditTargetType is an enum for the types of entities affected by audited actions.
+type AuditTargetType string
+
+// AuditTargetType enum values.
+const (
+	AuditTargetUser = AuditTargetType("user")
+	// AuditTargetUsername is used for failed logins of usernames that do not exist.
+	AuditTargetUsername = AuditTargetType("username")
+	AuditTargetAPIKey   = AuditTargetType("api_key")
+	AuditTargetRole     = AuditTargetType("role")
+	AuditTargetWebhook  = AuditTargetType("webhook")
+	AuditTargetSettings = AuditTargetType("settings")
+)
+
+// AuditRedacted replaces the values of secrets in audit changes.
+const AuditRedacted = "[REDACTED]"
+
+// AuditChange is a value object with the value of a field before and after an action.
+type AuditChange struct {
+	Before any `json:"before"`
+	After  any `json:"after"`
+}
+
+// AuditEvent is an entity representing an append-only record of an audited action.
+type AuditEvent struct {
+	Id         uuid.UUID
+	ActorId    *uuid.UUID
+	Action     AuditAction
+	TargetType AuditTargetType
+	TargetId   string
+	Changes    map[string]AuditChange
+	ClientIP   string
+	UserAgent  string
+	RequestId  string
+	CreatedAt  time.Time
+}
+
+// NewAuditEvent creates a new AuditEvent instance with the request metadata carried by ctx.
+//
+// Note: A nil actor means the action was not performed by an authenticated user, e.g. a failed login.
+func NewAuditEvent(ctx context.Context, actorId *uuid.UUID, action AuditAction, targetType AuditTargetType, targetId string) *AuditEvent {
+	metadata := RequestMetadataFromContext(ctx)
+	return &AuditEvent{
+		Id:         uuid.New(),
+		ActorId:    actorId,
+		Action:     action,
+		TargetType: targetType,
+		TargetId:   targetId,
+		Changes:    make(map[string]AuditChange),
+		ClientIP:   metadata.ClientIP,
+		UserAgent:  metadata.UserAgent,
+		RequestId:  metadata.RequestId,
+		CreatedAt:  time.Now(),
+	}
+}
+
+// WithChange records the value of a field before and after the action.
+func (e *AuditEvent) WithChange(field string, before, after any) *AuditEvent {
+	e.Changes[field] = AuditChange{Before: before, After: after}
+	return e
+}
+
+// WithRedactedChange records that a secret field changed without its values.
+func (e *AuditEvent) WithRedactedChange(field string) *AuditEvent {
+	return e.WithChange(field, AuditRedacted, AuditRedacted)
+}
+
+// AuditFilter is a DTO for querying the audit log, nil fields are not filtered.
+type AuditFilter struct {
+	ActorId    *uuid.UUID
+	Action     *AuditAction
+	TargetType *AuditTargetType
+	TargetId   *string
+	From       *time.Time
+	To         *time.Time
+	Page       int
+	Limit      int
+}
+
+// NewAuditFilter creates a new AuditFilter instance.
+func NewAuditFilter(
+	actorId *uuid.UUID,
+	action *AuditAction,
+	targetType *AuditTargetType,
+	targetId *string,
+	from, to *time.Time,
+	page, limit int,
+) *AuditFilter {
+	return &AuditFilter{
+		ActorId:    actorId,
+		Action:     action,
+		TargetType: targetType,
+		TargetId:   targetId,
+		From:       from,
+		To:         to,
+		Page:       page,
+		Limit:      limit,
+	}
+}
@@ -0,0 +1,86 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DataRequestKind is an enum for the kinds of data subject requests.
+type DataRequestKind string
+
+// DataRequestKind enum values.
+const (
+	// DataExport compiles all data stored about a user into a downloadable archive.
+	DataExport = DataRequestKind("export")
+	// DataErasure anonymises the personal data of a user and deletes his credentials.
+	DataErasure = DataRequestKind("erasure")
+)
+
+// DataRequestStatus is an enum for the status of a data subject request.
+type DataRequestStatus string
+
+// DataRequestStatus enum values.
+const (
+	DataRequestPending   = DataRequestStatus("pending")
+	DataRequestRunning   = DataRequestStatus("running")
+	DataRequestCompleted = DataRequestStatus("completed")
+	DataRequestFailed    = DataRequestStatus("failed")
+)
+
+// DataExportRetention is how long the archive of a completed export can be downloaded.
+const DataExportRetention = 7 * 24 * time.Hour
+
+// DataRequestTimeout is how long a request can run before it is claimed again,
+// e.g. after the instance running it stopped.
+const DataRequestTimeout = time.Hour
+
+// DataRequest is an entity representing a data subject request processed in the background.
+type DataRequest struct {
+	Id          uuid.UUID
+	UserId      uuid.UUID
+	Kind        DataRequestKind
+	Status      DataRequestStatus
+	RequestedBy uuid.UUID
+	Error       string
+	HasArchive  bool
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+	CompletedAt *time.Time
+}
+
+// NewDataRequest creates a new pending DataRequest instance.
+func NewDataRequest(userId, requestedBy uuid.UUID, kind DataRequestKind) *DataRequest {
+	now := time.Now()
+	return &DataRequest{
+		Id:          uuid.New(),
+		UserId:      userId,
+		Kind:        kind,
+		Status:      DataRequestPending,
+		RequestedBy: requestedBy,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+}
+
+// ArchiveExpiresAt returns the time the archive of a completed export is deleted,
+// it returns nil for other requests.
+func (r *DataRequest) ArchiveExpiresAt() *time.Time {
+	if r.Kind != DataExport || r.CompletedAt == nil {
+		return nil
+	}
+	expiresAt := r.CompletedAt.Add(DataExportRetention)
+	return &expiresAt
+}
+
+// UserData is a value object with all data stored about a user, compiled for a data export.
+//
+// Note: Secrets like password, MFA secret and API key hashes are never part of it.
+type UserData struct {
+	User        User
+	Sessions    []Token
+	MFAEnabled  bool
+	Identities  []UserIdentity
+	APIKeys     []APIKey
+	AuditEvents []AuditEvent
+}
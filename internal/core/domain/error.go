@@ -46,4 +46,138 @@ var (
 
 	// ErrTokenNotFound indicates that the expected token was not found.
 	ErrTokenNotFound = errors.New("token not found")
+
+	// ErrMFANotEnrolled indicates that the user has not started MFA enrolment.
+	ErrMFANotEnrolled = errors.New("mfa not enrolled")
+
+	// ErrMFAAlreadyEnabled indicates that the user already has MFA enabled.
+	ErrMFAAlreadyEnabled = errors.New("mfa already enabled")
+
+	// ErrInvalidMFACode indicates that the provided TOTP or recovery code is invalid.
+	ErrInvalidMFACode = errors.New("invalid mfa code")
+
+	// ErrMFAEnrollmentRequired indicates that the user's role requires MFA but the user has not enabled it.
+	ErrMFAEnrollmentRequired = errors.New("mfa enrollment required")
+
+	// ErrTooManyLoginAttempts indicates that logins are temporarily blocked after too many failures.
+	ErrTooManyLoginAttempts = errors.New("too many login attempts")
+
+	// ErrRateLimitExceeded indicates that the client sent too many requests.
+	ErrRateLimitExceeded = errors.New("rate limit exceeded")
+
+	// ErrIdentityProviderNotFound indicates that the identity provider is not configured.
+	ErrIdentityProviderNotFound = errors.New("identity provider not found")
+
+	// ErrIdentityNotFound indicates that no user is linked to the external identity.
+	ErrIdentityNotFound = errors.New("identity not found")
+
+	// ErrInvalidOAuthState indicates that the login state is unknown, expired or already used.
+	ErrInvalidOAuthState = errors.New("invalid oauth state")
+
+	// ErrExternalAuthFailed indicates that the identity provider did not authenticate the user.
+	ErrExternalAuthFailed = errors.New("external authentication failed")
+
+	// ErrExternalEmailNotVerified indicates that the identity provider did not return a verified email.
+	ErrExternalEmailNotVerified = errors.New("external email not verified")
+
+	// ErrOAuthLinkRequired indicates that the identity may only be linked to the user with the same email while he is logged in.
+	ErrOAuthLinkRequired = errors.New("oauth link required")
+
+	// ErrIdentityAlreadyLinked indicates that the external identity is linked to another user.
+	ErrIdentityAlreadyLinked = errors.New("identity already linked")
+
+	// ErrRoleNotFound indicates that the role does not exist.
+	ErrRoleNotFound = errors.New("role not found")
+
+	// ErrRoleAlreadyExists indicates that a role with the same name already exists.
+	ErrRoleAlreadyExists = errors.New("role already exists")
+
+	// ErrPermissionNotFound indicates that at least one of the permissions does not exist.
+	ErrPermissionNotFound = errors.New("permission not found")
+
+	// ErrBuiltInRole indicates that a built-in role cannot be changed or deleted.
+	ErrBuiltInRole = errors.New("built-in role")
+
+	// ErrRoleInUse indicates that a role cannot be deleted while users have it.
+	ErrRoleInUse = errors.New("role in use")
+
+	// ErrRoleNotAssignable indicates that a role grants permissions the acting user does not have,
+	// so it cannot be assigned, created or edited by them.
+	ErrRoleNotAssignable = errors.New("role not assignable")
+
+	// ErrRoleLockout indicates that the update would remove the permission to manage roles from the caller's own role.
+	ErrRoleLockout = errors.New("role lockout")
+
+	// ErrAPIKeyNotFound indicates that the API key does not exist.
+	ErrAPIKeyNotFound = errors.New("api key not found")
+
+	// ErrInvalidAPIKeyExpiry indicates that the expiry of an API key is in the past or exceeds the maximum.
+	ErrInvalidAPIKeyExpiry = errors.New("invalid api key expiry")
+
+	// ErrInsufficientScope indicates that the API key used is not scoped for the action.
+	ErrInsufficientScope = errors.New("insufficient scope")
+
+	// ErrUserSuspended indicates that the user's account is suspended.
+	ErrUserSuspended = errors.New("user suspended")
+
+	// ErrUserNotDeleted indicates that a user cannot be restored because it is not deleted.
+	ErrUserNotDeleted = errors.New("user not deleted")
+
+	// ErrSelfLockout indicates that an admin tried to suspend, delete or erase his own account.
+	ErrSelfLockout = errors.New("self lockout")
+
+	// ErrInvalidImport indicates that lines of an import file were rejected, see UserImportError.
+	ErrInvalidImport = errors.New("invalid import")
+
+	// ErrEmptyImport indicates that an import file contains no users.
+	ErrEmptyImport = errors.New("empty import")
+
+	// ErrImportTooLarge indicates that an import file contains more than MaxUserImportRows users.
+	ErrImportTooLarge = errors.New("import too large")
+
+	// ErrUnsupportedFormat indicates that the requested or uploaded format is not supported.
+	ErrUnsupportedFormat = errors.New("unsupported format")
+
+	// ErrWeakPassword indicates that a password violates the password policy, see PasswordPolicyError.
+	ErrWeakPassword = errors.New("weak password")
+
+	// ErrImpersonationForbidden indicates that the action cannot be performed with or to start an impersonation.
+	ErrImpersonationForbidden = errors.New("impersonation forbidden")
+
+	// ErrUserErased indicates that the personal data of a user has been erased.
+	ErrUserErased = errors.New("user erased")
+
+	// ErrDataRequestNotFound indicates that the data request does not exist.
+	ErrDataRequestNotFound = errors.New("data request not found")
+
+	// ErrDataExportNotReady indicates that the data request is not a completed export.
+	ErrDataExportNotReady = errors.New("data export not ready")
+
+	// ErrDataExportExpired indicates that the archive of a data export was deleted after DataExportRetention or an erasure.
+	ErrDataExportExpired = errors.New("data export expired")
+
+	// ErrProductNotFound indicates that the product does not exist.
+	ErrProductNotFound = errors.New("product not found")
+
+	// ErrQueryTooDeep indicates that a GraphQL query nests fields deeper than allowed.
+	ErrQueryTooDeep = errors.New("query too deep")
+
+	// ErrQueryTooComplex indicates that the estimated cost of a GraphQL query exceeds the allowed complexity.
+	ErrQueryTooComplex = errors.New("query too complex")
+
+	// ErrWebhookEndpointNotFound indicates that the webhook endpoint does not exist.
+	ErrWebhookEndpointNotFound = errors.New("webhook endpoint not found")
+
+	// ErrWebhookDeliveryNotFound indicates that the webhook delivery does not exist.
+	ErrWebhookDeliveryNotFound = errors.New("webhook delivery not found")
+
+	// ErrInvalidWebhookUrl indicates that a webhook endpoint URL does not use https
+	// or points to a private address, see WebhookPolicy.CheckUrl.
+	ErrInvalidWebhookUrl = errors.New("invalid webhook url")
+
+	// ErrInvalidRuntimeSettings indicates that runtime settings are invalid, see RuntimeSettingsError.
+	ErrInvalidRuntimeSettings = errors.New("invalid runtime settings")
+
+	// ErrFeatureDisabled indicates that the feature was switched off in the runtime settings.
+	ErrFeatureDisabled = errors.New("feature disabled")
 )
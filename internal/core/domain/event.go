@@ -0,0 +1,98 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// EventType is an enum for the domain events published to the rest of the platform.
+type EventType string
+
+// EventType enum values.
+const (
+	EventUserRegistered  = EventType("user.registered")
+	EventUserCreated     = EventType("user.created")
+	EventUserRoleChanged = EventType("user.role_changed")
+)
+
+// EventTypes contains all event types that are published.
+var EventTypes = []EventType{EventUserRegistered, EventUserCreated, EventUserRoleChanged}
+
+// EventAggregateType is an enum for the types of entities domain events are about.
+type EventAggregateType string
+
+// EventAggregateType enum values.
+const (
+	EventAggregateUser = EventAggregateType("user")
+)
+
+// Event is an entity representing a domain event stored in the outbox until it is published.
+//
+// Note: Events of the same aggregate are published in the order they were stored.
+type Event struct {
+	Id            uuid.UUID
+	Type          EventType
+	AggregateType EventAggregateType
+	AggregateId   uuid.UUID
+	Payload       map[string]any
+	OccurredAt    time.Time
+	// Attempts is the number of times the event was claimed for publishing, including the current one.
+	Attempts int
+}
+
+// NewEvent creates a new Event instance.
+func NewEvent(eventType EventType, aggregateType EventAggregateType, aggregateId uuid.UUID, payload map[string]any) *Event {
+	return &Event{
+		Id:            uuid.New(),
+		Type:          eventType,
+		AggregateType: aggregateType,
+		AggregateId:   aggregateId,
+		Payload:       payload,
+		OccurredAt:    time.Now(),
+	}
+}
+
+// NewUserEvent creates a new Event instance about a user with its public fields as payload.
+func NewUserEvent(eventType EventType, user *User) *Event {
+	return NewEvent(eventType, EventAggregateUser, user.Id, map[string]any{
+		"id":       user.Id,
+		"username": user.Username,
+		"email":    user.Email,
+		"role":     user.Role,
+	})
+}
+
+// NewUserRoleChangedEvent creates a new Event instance about the role change of a user.
+func NewUserRoleChangedEvent(userId uuid.UUID, before, after UserRole) *Event {
+	return NewEvent(EventUserRoleChanged, EventAggregateUser, userId, map[string]any{
+		"id":     userId,
+		"before": before,
+		"after":  after,
+	})
+}
+
+// EventClaimTimeout is how long a claimed event is hidden from other relays before it is claimed again,
+// e.g. after the instance publishing it stopped.
+const EventClaimTimeout = time.Minute
+
+// OutboxPolicy is a value object describing how events are relayed from the outbox.
+type OutboxPolicy struct {
+	RelayInterval time.Duration
+	BatchSize     int
+	BaseBackoff   time.Duration
+	MaxBackoff    time.Duration
+	Retention     time.Duration
+}
+
+// NextAttemptAt returns when an event that failed to publish is retried.
+//
+// Note: The first retry waits BaseBackoff, every further attempt doubles it up to MaxBackoff.
+// Events are retried until they are published.
+func (p OutboxPolicy) NextAttemptAt(attempts int, failedAt time.Time) time.Time {
+	backoff := p.BaseBackoff
+	for i := 1; i < attempts && backoff < p.MaxBackoff; i++ {
+		backoff *= 2
+	}
+	return failedAt.Add(min(backoff, p.MaxBackoff))
+}
@@ -0,0 +1,34 @@
+package domain
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestOutboxPolicy_NextAttemptAt(t *testing.T) {
+	policy := OutboxPolicy{
+		BaseBackoff: time.Second,
+		MaxBackoff:  5 * time.Second,
+	}
+	now := time.Now()
+
+	tests := []struct {
+		attempts int
+		expected time.Time
+	}{
+		{1, now.Add(time.Second)},
+		{2, now.Add(2 * time.Second)},
+		{3, now.Add(4 * time.Second)},
+		{4, now.Add(5 * time.Second)},
+		{10, now.Add(5 * time.Second)},
+	}
+
+	for _, test := range tests {
+		t.Run(strconv.Itoa(test.attempts), func(t *testing.T) {
+			require.Equal(t, test.expected, policy.NextAttemptAt(test.attempts, now))
+		})
+	}
+}
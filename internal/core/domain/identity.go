@@ -0,0 +1,88 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// UserIdentity is an entity representing an external identity linked to a user.
+type UserIdentity struct {
+	Id        uuid.UUID
+	UserId    uuid.UUID
+	Provider  string
+	Subject   string
+	Email     string
+	CreatedAt time.Time
+}
+
+// NewUserIdentity creates a new UserIdentity instance.
+func NewUserIdentity(id, userId uuid.UUID, provider, subject, email string, createdAt time.Time) *UserIdentity {
+	return &UserIdentity{
+		Id:        id,
+		UserId:    userId,
+		Provider:  provider,
+		Subject:   subject,
+		Email:     email,
+		CreatedAt: createdAt,
+	}
+}
+
+// ExternalIdentity is a DTO describing a user authenticated by an identity provider.
+type ExternalIdentity struct {
+	Provider          string
+	Subject           string
+	Email             string
+	EmailVerified     bool
+	PreferredUsername string
+}
+
+// NewExternalIdentity creates a new ExternalIdentity instance.
+func NewExternalIdentity(provider, subject, email string, emailVerified bool, preferredUsername string) *ExternalIdentity {
+	return &ExternalIdentity{
+		Provider:          provider,
+		Subject:           subject,
+		Email:             email,
+		EmailVerified:     emailVerified,
+		PreferredUsername: preferredUsername,
+	}
+}
+
+// OAuthState is an entity representing a pending login with an identity provider.
+//
+// Note: State protects against CSRF, Nonce binds the ID token to the login
+// and CodeVerifier is the PKCE secret of the authorization code.
+// UserId is only set if the login links the identity to a logged in user.
+type OAuthState struct {
+	State        string
+	Provider     string
+	Nonce        string
+	CodeVerifier string
+	ExpiresAt    time.Time
+	UserId       *uuid.UUID
+}
+
+// NewOAuthState creates a new OAuthState instance.
+func NewOAuthState(state, provider, nonce, codeVerifier string, expiresAt time.Time) *OAuthState {
+	return &OAuthState{
+		State:        state,
+		Provider:     provider,
+		Nonce:        nonce,
+		CodeVerifier: codeVerifier,
+		ExpiresAt:    expiresAt,
+	}
+}
+
+// OAuthAuthorization is a DTO containing the started login and the url of the identity provider.
+type OAuthAuthorization struct {
+	State *OAuthState
+	URL   string
+}
+
+// NewOAuthAuthorization creates a new OAuthAuthorization instance.
+func NewOAuthAuthorization(state *OAuthState, url string) *OAuthAuthorization {
+	return &OAuthAuthorization{
+		State: state,
+		URL:   url,
+	}
+}
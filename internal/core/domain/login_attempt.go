@@ -0,0 +1,76 @@
+package domain
+
+import (
+	"strings"
+	"time"
+)
+
+// LoginAttempts is an entity representing the failed login attempts of a key.
+type LoginAttempts struct {
+	Key           string
+	Failures      int
+	LastFailureAt time.Time
+	BlockedUntil  time.Time
+}
+
+// NewLoginAttempts creates a new LoginAttempts instance.
+func NewLoginAttempts(key string, failures int, lastFailureAt, blockedUntil time.Time) *LoginAttempts {
+	return &LoginAttempts{
+		Key:           key,
+		Failures:      failures,
+		LastFailureAt: lastFailureAt,
+		BlockedUntil:  blockedUntil,
+	}
+}
+
+// UserLoginAttemptsKey returns the key used to track failed logins of a username.
+func UserLoginAttemptsKey(username string) string {
+	return "user:" + strings.ToLower(username)
+}
+
+// IPLoginAttemptsKey returns the key used to track failed logins of a client IP.
+func IPLoginAttemptsKey(ip string) string {
+	return "ip:" + ip
+}
+
+// MFALoginAttemptsKey returns the key used to track failed MFA verifications of a user.
+func MFALoginAttemptsKey(userId string) string {
+	return "mfa:" + userId
+}
+
+// LoginThrottlePolicy is a value object describing how failed logins of a key are throttled.
+type LoginThrottlePolicy struct {
+	MaxFailures     int
+	LockoutDuration time.Duration
+	BaseBackoff     time.Duration
+	MaxBackoff      time.Duration
+}
+
+// BlockedUntil returns until when a key with the given failures is blocked.
+//
+// Note: Once MaxFailures is reached the key is locked out for LockoutDuration,
+// before that every failure blocks it for BaseBackoff doubled per previous failure.
+func (p LoginThrottlePolicy) BlockedUntil(failures int, lastFailureAt time.Time) time.Time {
+	if failures <= 0 {
+		return time.Time{}
+	}
+	if p.MaxFailures > 0 && failures >= p.MaxFailures {
+		return lastFailureAt.Add(p.LockoutDuration)
+	}
+	if p.BaseBackoff <= 0 {
+		return time.Time{}
+	}
+
+	backoff := p.BaseBackoff
+	for i := 1; i < failures && backoff < p.MaxBackoff; i++ {
+		backoff *= 2
+	}
+	return lastFailureAt.Add(min(backoff, p.MaxBackoff))
+}
+
+// LoginThrottle is a value object grouping the throttle policies of each key kind.
+type LoginThrottle struct {
+	User   LoginThrottlePolicy
+	IP     LoginThrottlePolicy
+	Window time.Duration
+}
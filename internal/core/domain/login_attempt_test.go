@@ -0,0 +1,38 @@
+package domain
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoginThrottlePolicy_BlockedUntil(t *testing.T) {
+	policy := LoginThrottlePolicy{
+		MaxFailures:     5,
+		LockoutDuration: 15 * time.Minute,
+		BaseBackoff:     time.Second,
+		MaxBackoff:      5 * time.Second,
+	}
+	now := time.Now()
+
+	tests := []struct {
+		failures int
+		expected time.Time
+	}{
+		{0, time.Time{}},
+		{1, now.Add(time.Second)},
+		{2, now.Add(2 * time.Second)},
+		{3, now.Add(4 * time.Second)},
+		{4, now.Add(5 * time.Second)},
+		{5, now.Add(15 * time.Minute)},
+		{6, now.Add(15 * time.Minute)},
+	}
+
+	for _, test := range tests {
+		t.Run(strconv.Itoa(test.failures), func(t *testing.T) {
+			require.Equal(t, test.expected, policy.BlockedUntil(test.failures, now))
+		})
+	}
+}
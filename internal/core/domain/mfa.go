@@ -0,0 +1,100 @@
+package domain
+
+import (
+	"github.com/google/uuid"
+)
+
+// UserMFA is an entity representing a user's TOTP enrolment.
+type UserMFA struct {
+	UserId       uuid.UUID
+	Secret       string
+	Enabled      bool
+	LastUsedStep int64
+}
+
+// NewUserMFA creates a new UserMFA instance.
+func NewUserMFA(userId uuid.UUID, secret string, enabled bool, lastUsedStep int64) *UserMFA {
+	return &UserMFA{
+		UserId:       userId,
+		Secret:       secret,
+		Enabled:      enabled,
+		LastUsedStep: lastUsedStep,
+	}
+}
+
+// RecoveryCode is an entity representing a hashed one-time recovery code.
+type RecoveryCode struct {
+	Id       uuid.UUID
+	UserId   uuid.UUID
+	CodeHash string
+}
+
+// NewRecoveryCode creates a new RecoveryCode instance.
+func NewRecoveryCode(id, userId uuid.UUID, codeHash string) *RecoveryCode {
+	return &RecoveryCode{
+		Id:       id,
+		UserId:   userId,
+		CodeHash: codeHash,
+	}
+}
+
+// RoleMFARequirement is an entity representing whether a role must use MFA.
+type RoleMFARequirement struct {
+	Role     UserRole
+	Required bool
+}
+
+// NewRoleMFARequirement creates a new RoleMFARequirement instance.
+func NewRoleMFARequirement(role UserRole, required bool) *RoleMFARequirement {
+	return &RoleMFARequirement{
+		Role:     role,
+		Required: required,
+	}
+}
+
+// MFACredentials is a DTO for a user to manage his MFA settings.
+type MFACredentials struct {
+	Username string
+	Password string
+	Code     string
+}
+
+// NewMFACredentials creates a new MFACredentials instance.
+func NewMFACredentials(username, password, code string) *MFACredentials {
+	return &MFACredentials{
+		Username: username,
+		Password: password,
+		Code:     code,
+	}
+}
+
+// MFAEnrollment is a DTO returned when a user starts TOTP enrolment.
+type MFAEnrollment struct {
+	Secret          string
+	ProvisioningURI string
+}
+
+// NewMFAEnrollment creates a new MFAEnrollment instance.
+func NewMFAEnrollment(secret, provisioningURI string) *MFAEnrollment {
+	return &MFAEnrollment{
+		Secret:          secret,
+		ProvisioningURI: provisioningURI,
+	}
+}
+
+// LoginResult is a DTO for the result of a login attempt.
+//
+// Note: Exactly one of TokenGroup and MFAToken is set. MFAToken is set
+// when the user has MFA enabled and must verify a code to finish the login.
+type LoginResult struct {
+	TokenGroup *TokenGroup
+	MFAToken   *string
+}
+
+// NewLoginResult creates a new LoginResult instance.
+func NewLoginResult(tokenGroup *TokenGroup, mfaToken *string) *LoginResult {
+	return &LoginResult{
+		TokenGroup: tokenGroup,
+		MFAToken:   mfaToken,
+	}
+}
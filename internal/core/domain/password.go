@@ -0,0 +1,40 @@
+package domain
+
+import "strings"
+
+// PasswordViolation is an enum for the reasons a password is rejected by the password policy.
+type PasswordViolation string
+
+// PasswordViolation enum values.
+const (
+	PasswordCommon           = PasswordViolation("common")
+	PasswordBreached         = PasswordViolation("breached")
+	PasswordContainsUsername = PasswordViolation("contains_username")
+	PasswordContainsEmail    = PasswordViolation("contains_email")
+)
+
+// PasswordPolicyError is returned when a password violates the password policy.
+//
+// Note: errors.Is(err, ErrWeakPassword) matches any PasswordPolicyError.
+type PasswordPolicyError struct {
+	Violations []PasswordViolation
+}
+
+// NewPasswordPolicyError creates a new PasswordPolicyError instance.
+func NewPasswordPolicyError(violations []PasswordViolation) *PasswordPolicyError {
+	return &PasswordPolicyError{
+		Violations: violations,
+	}
+}
+
+func (e *PasswordPolicyError) Error() string {
+	reasons := make([]string, 0, len(e.Violations))
+	for _, v := range e.Violations {
+		reasons = append(reasons, string(v))
+	}
+	return ErrWeakPassword.Error() + ": " + strings.Join(reasons, ", ")
+}
+
+func (e *PasswordPolicyError) Is(target error) bool {
+	return target == ErrWeakPassword
+}
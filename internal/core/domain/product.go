@@ -67,3 +67,22 @@ func NewProduct(
 		UpdatedAt:     updatedAt,
 	}
 }
+
+// MaxProductsLimit is the maximum number of products fetched at once.
+const MaxProductsLimit = 100
+
+// ProductFilter is a DTO for querying products, nil fields are not filtered.
+type ProductFilter struct {
+	SubcategoryId *uuid.UUID
+	Page          int
+	Limit         int
+}
+
+// NewProductFilter creates a new ProductFilter instance.
+func NewProductFilter(subcategoryId *uuid.UUID, page, limit int) *ProductFilter {
+	return &ProductFilter{
+		SubcategoryId: subcategoryId,
+		Page:          page,
+		Limit:         limit,
+	}
+}
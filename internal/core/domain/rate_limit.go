@@ -0,0 +1,118 @@
+package domain
+
+import (
+	"fmt"
+	"maps"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// RateLimit is a value object describing a token bucket that holds Requests
+// tokens and refills all of them over Period.
+type RateLimit struct {
+	Requests int
+	Period   time.Duration
+}
+
+// NewRateLimit creates a new RateLimit instance.
+func NewRateLimit(requests int, period time.Duration) RateLimit {
+	return RateLimit{
+		Requests: requests,
+		Period:   period,
+	}
+}
+
+// ParseRateLimit parses a rate limit in the requests/period format, e.g. 10/1m.
+func ParseRateLimit(value string) (RateLimit, error) {
+	requests, period, ok := strings.Cut(value, "/")
+	if !ok {
+		return RateLimit{}, fmt.Errorf("rate limit must have requests/period format: %s", value)
+	}
+
+	r, err := strconv.Atoi(requests)
+	if err != nil || r <= 0 {
+		return RateLimit{}, fmt.Errorf("rate limit requests must be > 0: %s", value)
+	}
+	p, err := time.ParseDuration(period)
+	if err != nil || p <= 0 {
+		return RateLimit{}, fmt.Errorf("rate limit period must be > 0: %s", value)
+	}
+	return NewRateLimit(r, p), nil
+}
+
+// String formats the rate limit in the requests/period format parsed by ParseRateLimit.
+func (l RateLimit) String() string {
+	return strconv.Itoa(l.Requests) + "/" + l.Period.String()
+}
+
+// isValid reports whether the rate limit has requests and period > 0.
+func (l RateLimit) isValid() bool {
+	return l.Requests > 0 && l.Period > 0
+}
+
+// Rate returns the number of tokens refilled per second.
+func (l RateLimit) Rate() float64 {
+	return float64(l.Requests) / l.Period.Seconds()
+}
+
+// RateLimitPolicy is a value object describing the rate limits of a route group.
+type RateLimitPolicy struct {
+	Default RateLimit
+	Roles   map[UserRole]RateLimit
+}
+
+// ForRole returns the rate limit of a role, falling back to the default one.
+func (p RateLimitPolicy) ForRole(role UserRole) RateLimit {
+	if limit, ok := p.Roles[role]; ok {
+		return limit
+	}
+	return p.Default
+}
+
+// Highest returns the rate limit with the highest rate of the default and the role limits,
+// it is the limit of callers whose role is not known yet.
+func (p RateLimitPolicy) Highest() RateLimit {
+	highest := p.Default
+	for _, role := range slices.Sorted(maps.Keys(p.Roles)) {
+		if limit := p.Roles[role]; limit.Rate() > highest.Rate() {
+			highest = limit
+		}
+	}
+	return highest
+}
+
+// String formats the default rate limit followed by the rate limits of the roles, e.g. 20/1m0s admin=600/1m0s.
+func (p RateLimitPolicy) String() string {
+	parts := []string{p.Default.String()}
+	for _, role := range slices.Sorted(maps.Keys(p.Roles)) {
+		parts = append(parts, string(role)+"="+p.Roles[role].String())
+	}
+	return strings.Join(parts, " ")
+}
+
+// RateLimitResult is a DTO describing the state of a bucket after taking a token.
+type RateLimitResult struct {
+	Allowed    bool
+	Limit      int
+	Remaining  int
+	ResetAfter time.Duration
+	RetryAfter time.Duration
+}
+
+// NewRateLimitResult creates a new RateLimitResult instance from the tokens left in the bucket.
+func NewRateLimitResult(limit RateLimit, tokens float64, allowed bool) *RateLimitResult {
+	rate := limit.Rate()
+	result := &RateLimitResult{
+		Allowed:    allowed,
+		Limit:      limit.Requests,
+		Remaining:  int(math.Floor(tokens)),
+		ResetAfter: time.Duration((float64(limit.Requests) - tokens) / rate * float64(time.Second)),
+	}
+	if !allowed {
+		result.RetryAfter = time.Duration((1 - tokens) / rate * float64(time.Second))
+	}
+	return result
+}
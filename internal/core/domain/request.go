@@ -0,0 +1,37 @@
+package domain
+
+import "context"
+
+// RequestMetadata is a value object describing the origin of a request.
+type RequestMetadata struct {
+	ClientIP  string
+	UserAgent string
+	RequestId string
+}
+
+// NewRequestMetadata creates a new RequestMetadata instance.
+func NewRequestMetadata(clientIP, userAgent, requestId string) *RequestMetadata {
+	return &RequestMetadata{
+		ClientIP:  clientIP,
+		UserAgent: userAgent,
+		RequestId: requestId,
+	}
+}
+
+// requestMetadataKey is the context key of RequestMetadata.
+type requestMetadataKey struct{}
+
+// ContextWithRequestMetadata returns a copy of ctx that carries the metadata.
+func ContextWithRequestMetadata(ctx context.Context, metadata *RequestMetadata) context.Context {
+	return context.WithValue(ctx, requestMetadataKey{}, metadata)
+}
+
+// RequestMetadataFromContext returns the metadata carried by ctx.
+//
+// Note: Empty metadata is returned if ctx does not carry any.
+func RequestMetadataFromContext(ctx context.Context) *RequestMetadata {
+	if metadata, ok := ctx.Value(requestMetadataKey{}).(*RequestMetadata); ok && metadata != nil {
+		return metadata
+	}
+	return &RequestMetadata{}
+}
@@ -0,0 +1,103 @@
+package domain
+
+// Permission is an enum for actions that can be granted to roles.
+type Permission string
+
+// Permission enum values.
+const (
+	UsersRead     Permission = "users:read"
+	UsersWrite    Permission = "users:write"
+	RolesRead     Permission = "roles:read"
+	RolesWrite    Permission = "roles:write"
+	ProductsWrite Permission = "products:write"
+	OrdersRead    Permission = "orders:read"
+	OrdersShip    Permission = "orders:ship"
+	APIKeysRead   Permission = "api_keys:read"
+	APIKeysWrite  Permission = "api_keys:write"
+	AuditRead     Permission = "audit:read"
+	WebhooksRead  Permission = "webhooks:read"
+	WebhooksWrite Permission = "webhooks:write"
+	SettingsRead  Permission = "settings:read"
+	SettingsWrite Permission = "settings:write"
+
+	UsersImpersonate Permission = "users:impersonate"
+	UsersPrivacy     Permission = "users:privacy"
+)
+
+// AllowsImpersonation reports whether the permission can be used by an impersonation token,
+// permissions that change users, roles or credentials or export personal data are reserved to the real user.
+func (p Permission) AllowsImpersonation() bool {
+	switch p {
+	case UsersWrite, UsersImpersonate, UsersPrivacy, RolesWrite, APIKeysWrite, WebhooksWrite, SettingsWrite:
+		return false
+	}
+	return true
+}
+
+// PermissionInfo is an entity representing a permission that can be granted to roles.
+type PermissionInfo struct {
+	Name        Permission
+	Description string
+}
+
+// NewPermissionInfo creates a new PermissionInfo instance.
+func NewPermissionInfo(name Permission, description string) *PermissionInfo {
+	return &PermissionInfo{
+		Name:        name,
+		Description: description,
+	}
+}
+
+// Role is an entity representing a role and the permissions granted to it.
+type Role struct {
+	Name        UserRole
+	Description string
+	BuiltIn     bool
+	Permissions []Permission
+}
+
+// NewRole creates a new Role instance.
+func NewRole(name UserRole, description string, builtIn bool, permissions []Permission) *Role {
+	return &Role{
+		Name:        name,
+		Description: description,
+		BuiltIn:     builtIn,
+		Permissions: permissions,
+	}
+}
+
+// HasPermission reports whether the permission is granted to the role.
+func (r *Role) HasPermission(permission Permission) bool {
+	for _, p := range r.Permissions {
+		if p == permission {
+			return true
+		}
+	}
+	return false
+}
+
+// Includes reports whether every permission granted to the other role is granted to the role.
+func (r *Role) Includes(other *Role) bool {
+	for _, p := range other.Permissions {
+		if !r.HasPermission(p) {
+			return false
+		}
+	}
+	return true
+}
+
+// RoleUpdate is a DTO for updating the description and the permissions of a role.
+type RoleUpdate struct {
+	Name        UserRole
+	Description *string
+	Permissions []Permission
+}
+
+// NewRoleUpdate creates a new RoleUpdate instance.
+func NewRoleUpdate(name UserRole, description *string, permissions []Permission) *RoleUpdate {
+	return &RoleUpdate{
+		Name:        name,
+		Description: description,
+		Permissions: permissions,
+	}
+}
@@ -0,0 +1,229 @@
+package domain
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+)
+
+// LogLevel is an enum for the minimum level of the logged messages.
+type LogLevel string
+
+// LogLevel enum values.
+const (
+	LogDebug LogLevel = "debug"
+	LogInfo  LogLevel = "info"
+	LogWarn  LogLevel = "warn"
+	LogError LogLevel = "error"
+)
+
+// IsValid reports whether the log level is one of the enum values.
+func (l LogLevel) IsValid() bool {
+	switch l {
+	case LogDebug, LogInfo, LogWarn, LogError:
+		return true
+	}
+	return false
+}
+
+// Feature is an enum for features that can be switched off at runtime.
+type Feature string
+
+// Feature enum values.
+const (
+	FeatureRegistration Feature = "registration"
+	FeatureOAuthLogin   Feature = "oauth_login"
+	FeatureGraphQL      Feature = "graphql"
+)
+
+// Features contains all Feature enum values.
+var Features = []Feature{FeatureRegistration, FeatureOAuthLogin, FeatureGraphQL}
+
+// RuntimeSettings is a value object with the settings that can be changed without a restart.
+//
+// Note: RuntimeSettings are shared between goroutines, they are replaced as a whole and never changed in place.
+type RuntimeSettings struct {
+	LogLevel                     LogLevel
+	RateLimitEnabled             bool
+	RateLimits                   map[string]RateLimitPolicy
+	AccessTokenExpireTime        time.Duration
+	RefreshTokenExpireTime       time.Duration
+	MFATokenExpireTime           time.Duration
+	ImpersonationTokenExpireTime time.Duration
+	Features                     map[Feature]bool
+}
+
+// FeatureEnabled reports whether the feature is enabled, features are enabled unless switched off.
+func (s *RuntimeSettings) FeatureEnabled(feature Feature) bool {
+	enabled, ok := s.Features[feature]
+	return !ok || enabled
+}
+
+// RateLimitPolicy returns the rate limit policy of a route group.
+func (s *RuntimeSettings) RateLimitPolicy(group string) (RateLimitPolicy, bool) {
+	policy, ok := s.RateLimits[group]
+	return policy, ok
+}
+
+// Apply returns a copy of the settings with the fields of the update set and checks it,
+// the settings themselves are not changed. A nil update returns an unchanged copy.
+func (s *RuntimeSettings) Apply(update *RuntimeSettingsUpdate) (*RuntimeSettings, error) {
+	next := *s
+	next.RateLimits = maps.Clone(s.RateLimits)
+	next.Features = maps.Clone(s.Features)
+	if update == nil {
+		return &next, nil
+	}
+
+	var violations []string
+	if update.LogLevel != nil {
+		if !update.LogLevel.IsValid() {
+			violations = append(violations, fmt.Sprintf("unknown log level: %s", *update.LogLevel))
+		}
+		next.LogLevel = *update.LogLevel
+	}
+	if update.RateLimitEnabled != nil {
+		next.RateLimitEnabled = *update.RateLimitEnabled
+	}
+	for _, group := range slices.Sorted(maps.Keys(update.RateLimits)) {
+		if _, ok := s.RateLimits[group]; !ok {
+			violations = append(violations, fmt.Sprintf("unknown rate limit group: %s", group))
+			continue
+		}
+		policy := update.RateLimits[group]
+		if !policy.Default.isValid() {
+			violations = append(violations, fmt.Sprintf("rate limit of %s must have requests and period > 0", group))
+		}
+		for _, role := range slices.Sorted(maps.Keys(policy.Roles)) {
+			if !policy.Roles[role].isValid() {
+				violations = append(violations, fmt.Sprintf("rate limit of %s for %s must have requests and period > 0", group, role))
+			}
+		}
+		next.RateLimits[group] = policy
+	}
+	for _, expireTime := range []struct {
+		name   string
+		update *time.Duration
+		target *time.Duration
+		max    time.Duration
+	}{
+		{"access token expire time", update.AccessTokenExpireTime, &next.AccessTokenExpireTime, MaxAccessTokenExpireTime},
+		{"refresh token expire time", update.RefreshTokenExpireTime, &next.RefreshTokenExpireTime, MaxRefreshTokenExpireTime},
+		{"mfa token expire time", update.MFATokenExpireTime, &next.MFATokenExpireTime, MaxMFATokenExpireTime},
+		{
+			"impersonation token expire time",
+			update.ImpersonationTokenExpireTime,
+			&next.ImpersonationTokenExpireTime,
+			MaxImpersonationTokenExpireTime,
+		},
+	} {
+		if expireTime.update == nil {
+			continue
+		}
+		if *expireTime.update <= 0 || *expireTime.update > expireTime.max {
+			violations = append(violations, fmt.Sprintf(
+				"%s must be > 0 and <= %s: %s", expireTime.name, expireTime.max, *expireTime.update,
+			))
+		}
+		*expireTime.target = *expireTime.update
+	}
+	for _, feature := range slices.Sorted(maps.Keys(update.Features)) {
+		if !slices.Contains(Features, feature) {
+			violations = append(violations, fmt.Sprintf("unknown feature: %s", feature))
+			continue
+		}
+		if next.Features == nil {
+			next.Features = make(map[Feature]bool)
+		}
+		next.Features[feature] = update.Features[feature]
+	}
+
+	if len(violations) > 0 {
+		return nil, NewRuntimeSettingsError(violations)
+	}
+	return &next, nil
+}
+
+// Changes returns the fields that differ between the settings and next with their values formatted for the audit log.
+func (s *RuntimeSettings) Changes(next *RuntimeSettings) map[string]AuditChange {
+	changes := make(map[string]AuditChange)
+	change := func(field string, before, after any) {
+		if before != after {
+			changes[field] = AuditChange{Before: before, After: after}
+		}
+	}
+
+	change("logLevel", string(s.LogLevel), string(next.LogLevel))
+	change("rateLimitEnabled", s.RateLimitEnabled, next.RateLimitEnabled)
+	for group, policy := range next.RateLimits {
+		before := s.RateLimits[group]
+		change("rateLimits."+group, before.String(), policy.String())
+	}
+	change("accessTokenExpireTime", s.AccessTokenExpireTime.String(), next.AccessTokenExpireTime.String())
+	change("refreshTokenExpireTime", s.RefreshTokenExpireTime.String(), next.RefreshTokenExpireTime.String())
+	change("mfaTokenExpireTime", s.MFATokenExpireTime.String(), next.MFATokenExpireTime.String())
+	change(
+		"impersonationTokenExpireTime",
+		s.ImpersonationTokenExpireTime.String(),
+		next.ImpersonationTokenExpireTime.String(),
+	)
+	for _, feature := range Features {
+		change("features."+string(feature), s.FeatureEnabled(feature), next.FeatureEnabled(feature))
+	}
+	return changes
+}
+
+// RuntimeSettingsUpdate is a DTO for changing runtime settings, nil fields are kept.
+//
+// Note: RateLimits replace the whole policy of the given route groups, Features only switch the given features.
+type RuntimeSettingsUpdate struct {
+	LogLevel                     *LogLevel
+	RateLimitEnabled             *bool
+	RateLimits                   map[string]RateLimitPolicy
+	AccessTokenExpireTime        *time.Duration
+	RefreshTokenExpireTime       *time.Duration
+	MFATokenExpireTime           *time.Duration
+	ImpersonationTokenExpireTime *time.Duration
+	Features                     map[Feature]bool
+}
+
+// IsEmpty reports whether the update does not set any field.
+func (u *RuntimeSettingsUpdate) IsEmpty() bool {
+	return u.LogLevel == nil &&
+		u.RateLimitEnabled == nil &&
+		len(u.RateLimits) == 0 &&
+		u.AccessTokenExpireTime == nil &&
+		u.RefreshTokenExpireTime == nil &&
+		u.MFATokenExpireTime == nil &&
+		u.ImpersonationTokenExpireTime == nil &&
+		len(u.Features) == 0
+}
+
+// RuntimeSettingsPolicy is a value object describing how often the stored runtime settings are checked for changes.
+type RuntimeSettingsPolicy struct {
+	PollInterval time.Duration
+}
+
+// RuntimeSettingsError is returned when runtime settings are invalid.
+//
+// Note: errors.Is(err, ErrInvalidRuntimeSettings) matches any RuntimeSettingsError.
+type RuntimeSettingsError struct {
+	Violations []string
+}
+
+// NewRuntimeSettingsError creates a new RuntimeSettingsError instance.
+func NewRuntimeSettingsError(violations []string) *RuntimeSettingsError {
+	return &RuntimeSettingsError{
+		Violations: violations,
+	}
+}
+
+func (e *RuntimeSettingsError) Error() string {
+	return ErrInvalidRuntimeSettings.Error() + ": " + strings.Join(e.Violations, ", ")
+}
+
+func (e *RuntimeSettingsError) Is(target error) bool {
+	return target == ErrInvalidRuntimeSettings
+}
@@ -13,15 +13,31 @@ type TokenType string
 const (
 	AccessToken  = TokenType("access_token")
 	RefreshToken = TokenType("refresh_token")
+	MFAToken     = TokenType("mfa_token")
+)
+
+// Upper bounds of the token expire times, checked for the configured and the runtime settings,
+// so a mistaken or malicious change cannot issue long-lived tokens.
+const (
+	MaxAccessTokenExpireTime        = 24 * time.Hour
+	MaxRefreshTokenExpireTime       = 30 * 24 * time.Hour
+	MaxMFATokenExpireTime           = 30 * time.Minute
+	MaxImpersonationTokenExpireTime = time.Hour
 )
 
 // Token is an entity representing a token.
+//
+// Note: Tokens authenticated by an API key have the Id of the key and non-nil Scopes
+// that restrict the permissions of the role. Impersonation tokens have the Id of the
+// admin acting as the user in ActorId.
 type Token struct {
 	Id        uuid.UUID
 	UserId    uuid.UUID
 	TokenType TokenType
 	UserRole  UserRole
 	ExpiresAt time.Time
+	Scopes    []Permission
+	ActorId   *uuid.UUID
 }
 
 // NewToken creates a new Token instance.
@@ -35,6 +51,56 @@ func NewToken(id, userId uuid.UUID, userRole UserRole, tokenType TokenType, expi
 	}
 }
 
+// NewOperatorToken creates an admin access token for operators with access to the servers,
+// e.g. through the admin CLI. It is never signed or stored and names no user,
+// so its UserId and the actor of its audit events is the nil UUID.
+func NewOperatorToken() *Token {
+	return &Token{
+		Id:        uuid.New(),
+		UserId:    uuid.Nil,
+		TokenType: AccessToken,
+		UserRole:  Admin,
+	}
+}
+
+// IsAPIKey reports whether the token was authenticated by an API key.
+func (t *Token) IsAPIKey() bool {
+	return t.Scopes != nil
+}
+
+// IsImpersonated reports whether the token was issued to an admin acting as the user.
+func (t *Token) IsImpersonated() bool {
+	return t.ActorId != nil
+}
+
+// HasScope reports whether the token is scoped for the permission,
+// tokens not authenticated by an API key have every scope.
+func (t *Token) HasScope(permission Permission) bool {
+	if !t.IsAPIKey() {
+		return true
+	}
+	for _, scope := range t.Scopes {
+		if scope == permission {
+			return true
+		}
+	}
+	return false
+}
+
+// ImpersonationToken is an entity representing a signed access token issued to an admin acting as a user.
+type ImpersonationToken struct {
+	AccessToken string
+	ExpiresAt   time.Time
+}
+
+// NewImpersonationToken creates a new ImpersonationToken instance.
+func NewImpersonationToken(accessToken string, expiresAt time.Time) *ImpersonationToken {
+	return &ImpersonationToken{
+		AccessToken: accessToken,
+		ExpiresAt:   expiresAt,
+	}
+}
+
 // TokenGroup is an entity representing a group of signed access and refresh token.
 type TokenGroup struct {
 	AccessToken  string
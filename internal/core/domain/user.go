@@ -7,6 +7,8 @@ import (
 )
 
 // UserRole is an emum for user's role.
+//
+// Note: Custom roles can be created by admins, the values below are the built-in roles.
 type UserRole string
 
 // UserRole enum values.
@@ -17,15 +19,28 @@ const (
 	Warehouse UserRole = "warehouse"
 )
 
+// UserStatus is an enum for the status of a user's account.
+type UserStatus string
+
+// UserStatus enum values.
+const (
+	UserActive    UserStatus = "active"
+	UserSuspended UserStatus = "suspended"
+)
+
 // User is an entity representing a user.
+//
+// Note: Deleted users are soft-deleted, they keep their username and email until restored.
 type User struct {
 	Id        uuid.UUID
 	Username  string
 	Email     string
 	Password  string
 	Role      UserRole
+	Status    UserStatus
 	CreatedAt time.Time
 	UpdatedAt time.Time
+	DeletedAt *time.Time
 }
 
 // NewUser creates a new User instance.
@@ -36,6 +51,7 @@ func NewUser(id uuid.UUID, username, email, password string, role UserRole, crea
 		Email:     email,
 		Password:  password,
 		Role:      role,
+		Status:    UserActive,
 		CreatedAt: createdAt,
 		UpdatedAt: UpdatedAt,
 	}
@@ -61,27 +77,122 @@ func NewUserUpdate(id uuid.UUID, username, email, password *string, role *UserRo
 	}
 }
 
+// UserSortField is an enum for the fields users can be sorted by.
+type UserSortField string
+
+// UserSortField enum values.
+const (
+	UserSortId        = UserSortField("id")
+	UserSortUsername  = UserSortField("username")
+	UserSortEmail     = UserSortField("email")
+	UserSortRole      = UserSortField("role")
+	UserSortStatus    = UserSortField("status")
+	UserSortCreatedAt = UserSortField("created_at")
+	UserSortUpdatedAt = UserSortField("updated_at")
+)
+
+// UserFilter is a DTO for filtering users, nil fields are not filtered.
+//
+// Note: Username and Email are fuzzy matched, the time ranges include the start and exclude the end.
+type UserFilter struct {
+	Id          *uuid.UUID
+	Username    *string
+	Email       *string
+	Role        *UserRole
+	Status      *UserStatus
+	CreatedFrom *time.Time
+	CreatedTo   *time.Time
+	UpdatedFrom *time.Time
+	UpdatedTo   *time.Time
+}
+
+// NewUserFilter creates a new UserFilter instance.
+func NewUserFilter(
+	id *uuid.UUID,
+	username, email *string,
+	role *UserRole,
+	status *UserStatus,
+	createdFrom, createdTo, updatedFrom, updatedTo *time.Time,
+) *UserFilter {
+	return &UserFilter{
+		Id:          id,
+		Username:    username,
+		Email:       email,
+		Role:        role,
+		Status:      status,
+		CreatedFrom: createdFrom,
+		CreatedTo:   createdTo,
+		UpdatedFrom: updatedFrom,
+		UpdatedTo:   updatedTo,
+	}
+}
+
+// UserCursor is a keyset cursor pointing after the last user of a page.
+// The id breaks ties between users with the same value of the sort field.
+type UserCursor struct {
+	SortBy     UserSortField
+	Descending bool
+	Value      string
+	Id         uuid.UUID
+}
+
+// NewUserCursor creates a new UserCursor instance pointing after the user.
+func NewUserCursor(user *User, sortBy UserSortField, descending bool) *UserCursor {
+	var value string
+	switch sortBy {
+	case UserSortUsername:
+		value = user.Username
+	case UserSortEmail:
+		value = user.Email
+	case UserSortRole:
+		value = string(user.Role)
+	case UserSortStatus:
+		value = string(user.Status)
+	case UserSortCreatedAt:
+		value = user.CreatedAt.Format(time.RFC3339Nano)
+	case UserSortUpdatedAt:
+		value = user.UpdatedAt.Format(time.RFC3339Nano)
+	}
+
+	return &UserCursor{
+		SortBy:     sortBy,
+		Descending: descending,
+		Value:      value,
+		Id:         user.Id,
+	}
+}
+
 // GetUsers is a DTO for getting users info.
+//
+// Note: Users are paginated either by Page or by Cursor, a query without both returns the first page.
 type GetUsers struct {
-	Id       *uuid.UUID
-	Username *string
-	Email    *string
-	Role     *UserRole
-	Page     *int
-	After    *time.Time
-	Limit    *int
+	Filter     UserFilter
+	SortBy     UserSortField
+	Descending bool
+	Page       *int
+	Cursor     *UserCursor
+	Limit      *int
+	WithTotal  bool
 }
 
 // NewGetUsers creates a new GetUsers instance.
-func NewGetUsers(id *uuid.UUID, username, email *string, role *UserRole, page *int, after *time.Time, limit *int) *GetUsers {
+func NewGetUsers(
+	filter *UserFilter,
+	sortBy UserSortField,
+	descending bool,
+	page *int,
+	cursor *UserCursor,
+	limit *int,
+	withTotal bool,
+) *GetUsers {
 	return &GetUsers{
-		Id:       id,
-		Username: username,
-		Email:    email,
-		Role:     role,
-		Page:     page,
-		After:    after,
-		Limit:    limit,
+		Filter:     *filter,
+		SortBy:     sortBy,
+		Descending: descending,
+		Page:       page,
+		Cursor:     cursor,
+		Limit:      limit,
+		WithTotal:  withTotal,
 	}
 }
 
@@ -106,15 +217,19 @@ func NewUpdateAccount(username, password string, newUsername, newEmail, newPassw
 }
 
 // UsersResult is a DTO for fetching users result.
+//
+// Note: Cursor is nil when there are no more users, Total is nil unless requested.
 type UsersResult struct {
 	Users  []User
-	Cursor *string
+	Cursor *UserCursor
+	Total  *int
 }
 
 // NewUsersResult creates a new UsersResult instance.
-func NewUsersResult(users []User, cursor *string) *UsersResult {
+func NewUsersResult(users []User, cursor *UserCursor, total *int) *UsersResult {
 	return &UsersResult{
 		Users:  users,
 		Cursor: cursor,
+		Total:  total,
 	}
 }
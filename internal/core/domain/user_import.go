@@ -0,0 +1,56 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+)
+
+// MaxUserImportRows is the maximum number of users that can be imported at once.
+const MaxUserImportRows = 1000
+
+// UserImportRow is a user parsed from a line of an import file.
+type UserImportRow struct {
+	Line int
+	User User
+}
+
+// NewUserImportRow creates a new UserImportRow instance.
+func NewUserImportRow(line int, user User) *UserImportRow {
+	return &UserImportRow{
+		Line: line,
+		User: user,
+	}
+}
+
+// UserImportRowError is the reason a line of an import file was rejected.
+type UserImportRowError struct {
+	Line int
+	Err  error
+}
+
+// UserImportError is returned when at least one line of an import file is rejected.
+// No users are imported in that case.
+//
+// Note: errors.Is(err, ErrInvalidImport) matches any UserImportError.
+type UserImportError struct {
+	Rows []UserImportRowError
+}
+
+// NewUserImportError creates a new UserImportError instance.
+func NewUserImportError(rows []UserImportRowError) *UserImportError {
+	return &UserImportError{
+		Rows: rows,
+	}
+}
+
+func (e *UserImportError) Error() string {
+	reasons := make([]string, 0, len(e.Rows))
+	for _, r := range e.Rows {
+		reasons = append(reasons, fmt.Sprintf("line %d: %v", r.Line, r.Err))
+	}
+	return ErrInvalidImport.Error() + ": " + strings.Join(reasons, ", ")
+}
+
+func (e *UserImportError) Is(target error) bool {
+	return target == ErrInvalidImport
+}
@@ -0,0 +1,269 @@
+package domain
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/netip"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// WebhookEndpoint is an entity representing a partner URL receiving the events it subscribed to.
+//
+// Note: The secret is stored in plain text since it is needed to sign every delivery.
+type WebhookEndpoint struct {
+	Id          uuid.UUID
+	Url         string
+	Description string
+	// EventTypes are the events delivered to the endpoint, every event is delivered if it is empty.
+	EventTypes []EventType
+	Secret     string
+	Enabled    bool
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+// NewWebhookEndpoint creates a new enabled WebhookEndpoint instance with a new secret.
+func NewWebhookEndpoint(url, description string, eventTypes []EventType) *WebhookEndpoint {
+	now := time.Now()
+	return &WebhookEndpoint{
+		Id:          uuid.New(),
+		Url:         url,
+		Description: description,
+		EventTypes:  eventTypes,
+		Secret:      NewWebhookSecret(),
+		Enabled:     true,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+}
+
+// IsSubscribed reports whether events of specific type are delivered to the endpoint.
+func (e *WebhookEndpoint) IsSubscribed(eventType EventType) bool {
+	return e.Enabled && (len(e.EventTypes) == 0 || slices.Contains(e.EventTypes, eventType))
+}
+
+// WebhookEndpointUpdate is a DTO for updating a webhook endpoint, nil fields are left unchanged.
+//
+// Note: EventTypes replace all event types of the endpoint.
+type WebhookEndpointUpdate struct {
+	Id          uuid.UUID
+	Url         *string
+	Description *string
+	EventTypes  []EventType
+	Enabled     *bool
+}
+
+// NewWebhookEndpointUpdate creates a new WebhookEndpointUpdate instance.
+func NewWebhookEndpointUpdate(
+	id uuid.UUID,
+	url, description *string,
+	eventTypes []EventType,
+	enabled *bool,
+) *WebhookEndpointUpdate {
+	return &WebhookEndpointUpdate{
+		Id:          id,
+		Url:         url,
+		Description: description,
+		EventTypes:  eventTypes,
+		Enabled:     enabled,
+	}
+}
+
+// webhookSecretPrefix is the prefix of webhook endpoint secrets.
+const webhookSecretPrefix = "whsec_"
+
+// NewWebhookSecret returns a new random secret for signing webhook deliveries.
+func NewWebhookSecret() string {
+	return webhookSecretPrefix + strings.ToLower(rand.Text())
+}
+
+// SignWebhook returns the signature of a delivery body sent at specific time in the
+// t=<unix timestamp>,v1=<hex HMAC-SHA256 of "<unix timestamp>.<body>"> format.
+//
+// Note: The timestamp is signed so that receivers can reject replayed requests.
+func SignWebhook(secret string, timestamp time.Time, body []byte) string {
+	unix := strconv.FormatInt(timestamp.Unix(), 10)
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(unix))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "t=" + unix + ",v1=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// WebhookDeliveryStatus is an enum for the status of a webhook delivery.
+type WebhookDeliveryStatus string
+
+// WebhookDeliveryStatus enum values.
+const (
+	// WebhookDeliveryPending is waiting for its first or next attempt.
+	WebhookDeliveryPending = WebhookDeliveryStatus("pending")
+	// WebhookDeliverySucceeded was acknowledged by the endpoint with a 2xx response.
+	WebhookDeliverySucceeded = WebhookDeliveryStatus("succeeded")
+	// WebhookDeliveryDead failed WebhookPolicy.MaxAttempts times and is only retried when replayed.
+	WebhookDeliveryDead = WebhookDeliveryStatus("dead")
+)
+
+// WebhookDeliveryTimeout is how long a claimed delivery is hidden from other workers before it is claimed again,
+// e.g. after the instance delivering it stopped.
+const WebhookDeliveryTimeout = 5 * time.Minute
+
+// WebhookDelivery is an entity representing an event to deliver to a webhook endpoint.
+type WebhookDelivery struct {
+	Id            uuid.UUID
+	EndpointId    uuid.UUID
+	Event         Event
+	Status        WebhookDeliveryStatus
+	Attempts      int
+	NextAttemptAt time.Time
+	LastError     string
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+	DeliveredAt   *time.Time
+}
+
+// NewWebhookDelivery creates a new pending WebhookDelivery instance.
+func NewWebhookDelivery(endpointId uuid.UUID, event *Event) *WebhookDelivery {
+	now := time.Now()
+	return &WebhookDelivery{
+		Id:            uuid.New(),
+		EndpointId:    endpointId,
+		Event:         *event,
+		Status:        WebhookDeliveryPending,
+		NextAttemptAt: now,
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}
+}
+
+// WebhookAttempt is an entity representing a single request sent for a webhook delivery.
+type WebhookAttempt struct {
+	Id         uuid.UUID
+	DeliveryId uuid.UUID
+	// ResponseStatus is the HTTP status of the response, 0 if no response was received.
+	ResponseStatus int
+	Error          string
+	Duration       time.Duration
+	AttemptedAt    time.Time
+}
+
+// NewWebhookAttempt creates a new WebhookAttempt instance.
+func NewWebhookAttempt(
+	deliveryId uuid.UUID,
+	responseStatus int,
+	err string,
+	duration time.Duration,
+	attemptedAt time.Time,
+) *WebhookAttempt {
+	return &WebhookAttempt{
+		Id:             uuid.New(),
+		DeliveryId:     deliveryId,
+		ResponseStatus: responseStatus,
+		Error:          err,
+		Duration:       duration,
+		AttemptedAt:    attemptedAt,
+	}
+}
+
+// Succeeded reports whether the endpoint acknowledged the delivery.
+func (a *WebhookAttempt) Succeeded() bool {
+	return a.Error == "" && a.ResponseStatus >= 200 && a.ResponseStatus < 300
+}
+
+// WebhookDeliveryFilter is a value object for filtering the deliveries of a webhook endpoint.
+type WebhookDeliveryFilter struct {
+	EndpointId uuid.UUID
+	Status     *WebhookDeliveryStatus
+	Page       int
+	Limit      int
+}
+
+// NewWebhookDeliveryFilter creates a new WebhookDeliveryFilter instance.
+func NewWebhookDeliveryFilter(endpointId uuid.UUID, status *WebhookDeliveryStatus, page, limit int) *WebhookDeliveryFilter {
+	return &WebhookDeliveryFilter{
+		EndpointId: endpointId,
+		Status:     status,
+		Page:       page,
+		Limit:      limit,
+	}
+}
+
+// WebhookPolicy is a value object describing how webhook deliveries are sent and retried.
+//
+// Note: Endpoint URLs must use https if RequireHTTPS is set and must not point to a private
+// address unless AllowPrivateNetworks is set.
+type WebhookPolicy struct {
+	Workers              int
+	PollInterval         time.Duration
+	BatchSize            int
+	MaxAttempts          int
+	BaseBackoff          time.Duration
+	MaxBackoff           time.Duration
+	RequireHTTPS         bool
+	AllowPrivateNetworks bool
+}
+
+// CheckUrl returns ErrInvalidWebhookUrl if the endpoint URL is not allowed by the policy.
+//
+// Note: Only IP literals are checked here, host names are checked once they are resolved by the sender.
+func (p WebhookPolicy) CheckUrl(rawUrl string) error {
+	u, err := url.Parse(rawUrl)
+	if err != nil || u.Host == "" || (u.Scheme != "https" && (p.RequireHTTPS || u.Scheme != "http")) {
+		return ErrInvalidWebhookUrl
+	}
+	if addr, err := netip.ParseAddr(u.Hostname()); err == nil && !p.AllowPrivateNetworks && IsPrivateAddress(addr) {
+		return ErrInvalidWebhookUrl
+	}
+	return nil
+}
+
+// IsPrivateAddress reports whether the address is a loopback, private, link-local or unspecified address,
+// which webhooks must not reach since they belong to the network of the server.
+func IsPrivateAddress(addr netip.Addr) bool {
+	addr = addr.Unmap()
+	return addr.IsLoopback() ||
+		addr.IsPrivate() ||
+		addr.IsLinkLocalUnicast() ||
+		addr.IsLinkLocalMulticast() ||
+		addr.IsInterfaceLocalMulticast() ||
+		addr.IsUnspecified()
+}
+
+// Record applies an attempt to the delivery: it succeeds, is retried after a backoff
+// or becomes dead after MaxAttempts failed attempts.
+//
+// Note: The first retry waits BaseBackoff, every further attempt doubles it up to MaxBackoff.
+func (p WebhookPolicy) Record(delivery *WebhookDelivery, attempt *WebhookAttempt) {
+	delivery.Attempts++
+	delivery.UpdatedAt = attempt.AttemptedAt
+	if attempt.Succeeded() {
+		delivery.Status = WebhookDeliverySucceeded
+		delivery.LastError = ""
+		deliveredAt := attempt.AttemptedAt
+		delivery.DeliveredAt = &deliveredAt
+		return
+	}
+
+	delivery.LastError = attempt.Error
+	if delivery.LastError == "" {
+		delivery.LastError = "unexpected response status " + strconv.Itoa(attempt.ResponseStatus)
+	}
+	if delivery.Attempts >= p.MaxAttempts {
+		delivery.Status = WebhookDeliveryDead
+		return
+	}
+
+	backoff := p.BaseBackoff
+	for i := 1; i < delivery.Attempts && backoff < p.MaxBackoff; i++ {
+		backoff *= 2
+	}
+	delivery.Status = WebhookDeliveryPending
+	delivery.NextAttemptAt = attempt.AttemptedAt.Add(min(backoff, p.MaxBackoff))
+}
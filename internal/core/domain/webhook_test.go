@@ -0,0 +1,128 @@
+package domain
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSignWebhook(t *testing.T) {
+	body := []byte(`{"type":"user.registered"}`)
+	timestamp := time.Unix(1760000000, 0)
+
+	mac := hmac.New(sha256.New, []byte("whsec_secret"))
+	mac.Write([]byte("1760000000." + string(body)))
+	expected := "t=1760000000,v1=" + hex.EncodeToString(mac.Sum(nil))
+
+	require.Equal(t, expected, SignWebhook("whsec_secret", timestamp, body))
+	require.NotEqual(t, expected, SignWebhook("whsec_other", timestamp, body))
+	require.NotEqual(t, expected, SignWebhook("whsec_secret", timestamp.Add(time.Second), body))
+}
+
+func TestWebhookEndpoint_IsSubscribed(t *testing.T) {
+	all := &WebhookEndpoint{Enabled: true}
+	require.True(t, all.IsSubscribed(EventUserCreated))
+
+	filtered := &WebhookEndpoint{Enabled: true, EventTypes: []EventType{EventUserRegistered}}
+	require.True(t, filtered.IsSubscribed(EventUserRegistered))
+	require.False(t, filtered.IsSubscribed(EventUserCreated))
+
+	disabled := &WebhookEndpoint{EventTypes: []EventType{EventUserRegistered}}
+	require.False(t, disabled.IsSubscribed(EventUserRegistered))
+}
+
+func TestWebhookPolicy_Record(t *testing.T) {
+	policy := WebhookPolicy{
+		MaxAttempts: 4,
+		BaseBackoff: time.Second,
+		MaxBackoff:  3 * time.Second,
+	}
+	now := time.Now()
+
+	tests := []struct {
+		name                  string
+		attempts              int
+		attempt               *WebhookAttempt
+		expectedStatus        WebhookDeliveryStatus
+		expectedNextAttemptAt time.Time
+		expectedLastError     string
+	}{
+		{
+			name:                  "success",
+			attempts:              1,
+			attempt:               NewWebhookAttempt(uuid.Nil, 204, "", time.Millisecond, now),
+			expectedStatus:        WebhookDeliverySucceeded,
+			expectedNextAttemptAt: now,
+			expectedLastError:     "",
+		}, {
+			name:                  "first failure",
+			attempts:              0,
+			attempt:               NewWebhookAttempt(uuid.Nil, 0, "connection refused", time.Millisecond, now),
+			expectedStatus:        WebhookDeliveryPending,
+			expectedNextAttemptAt: now.Add(time.Second),
+			expectedLastError:     "connection refused",
+		}, {
+			name:                  "failure backoff doubles",
+			attempts:              1,
+			attempt:               NewWebhookAttempt(uuid.Nil, 500, "", time.Millisecond, now),
+			expectedStatus:        WebhookDeliveryPending,
+			expectedNextAttemptAt: now.Add(2 * time.Second),
+			expectedLastError:     "unexpected response status 500",
+		}, {
+			name:                  "failure backoff capped",
+			attempts:              2,
+			attempt:               NewWebhookAttempt(uuid.Nil, 302, "", time.Millisecond, now),
+			expectedStatus:        WebhookDeliveryPending,
+			expectedNextAttemptAt: now.Add(3 * time.Second),
+			expectedLastError:     "unexpected response status 302",
+		}, {
+			name:                  "dead after max attempts",
+			attempts:              3,
+			attempt:               NewWebhookAttempt(uuid.Nil, 503, "", time.Millisecond, now),
+			expectedStatus:        WebhookDeliveryDead,
+			expectedNextAttemptAt: now,
+			expectedLastError:     "unexpected response status 503",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			delivery := &WebhookDelivery{Status: WebhookDeliveryPending, Attempts: test.attempts, NextAttemptAt: now}
+			policy.Record(delivery, test.attempt)
+
+			require.Equal(t, test.attempts+1, delivery.Attempts)
+			require.Equal(t, test.expectedStatus, delivery.Status)
+			require.Equal(t, test.expectedNextAttemptAt, delivery.NextAttemptAt)
+			require.Equal(t, test.expectedLastError, delivery.LastError)
+			if test.expectedStatus == WebhookDeliverySucceeded {
+				require.Equal(t, &now, delivery.DeliveredAt)
+			} else {
+				require.Nil(t, delivery.DeliveredAt)
+			}
+		})
+	}
+}
+
+func TestWebhookPolicy_CheckUrl(t *testing.T) {
+	strict := WebhookPolicy{RequireHTTPS: true}
+	require.NoError(t, strict.CheckUrl("https://partner.example.com/hooks"))
+	require.NoError(t, strict.CheckUrl("https://93.184.216.34/hooks"))
+	require.ErrorIs(t, strict.CheckUrl("http://partner.example.com/hooks"), ErrInvalidWebhookUrl)
+	require.ErrorIs(t, strict.CheckUrl("ftp://partner.example.com/hooks"), ErrInvalidWebhookUrl)
+	require.ErrorIs(t, strict.CheckUrl("https:///hooks"), ErrInvalidWebhookUrl)
+	require.ErrorIs(t, strict.CheckUrl("https://127.0.0.1/hooks"), ErrInvalidWebhookUrl)
+	require.ErrorIs(t, strict.CheckUrl("https://10.1.2.3/hooks"), ErrInvalidWebhookUrl)
+	require.ErrorIs(t, strict.CheckUrl("https://169.254.169.254/latest/meta-data"), ErrInvalidWebhookUrl)
+	require.ErrorIs(t, strict.CheckUrl("https://0.0.0.0/hooks"), ErrInvalidWebhookUrl)
+	require.ErrorIs(t, strict.CheckUrl("https://[::1]/hooks"), ErrInvalidWebhookUrl)
+
+	development := WebhookPolicy{AllowPrivateNetworks: true}
+	require.NoError(t, development.CheckUrl("http://localhost:8080/hooks"))
+	require.NoError(t, development.CheckUrl("http://127.0.0.1:8080/hooks"))
+	require.ErrorIs(t, development.CheckUrl("ftp://127.0.0.1/hooks"), ErrInvalidWebhookUrl)
+}
@@ -3,12 +3,40 @@ package port
 import (
 	"context"
 	"shop-api-go/internal/core/domain"
+
+	"github.com/google/uuid"
 )
 
 // AdminService is an interface for interacting with user-related business logic.
 type AdminService interface {
 	// GetUsers fetches users by passed filters.
 	GetUsers(ctx context.Context, token *domain.Token, get *domain.GetUsers) (*domain.UsersResult, error)
+	// CreateUser adds a new user with a specific role.
+	CreateUser(ctx context.Context, token *domain.Token, user *domain.User) error
+	// ImportUsers validates and, unless dryRun is set, creates the users of an import file.
+	// It returns the number of valid users or a domain.UserImportError with the rejected lines.
+	ImportUsers(ctx context.Context, token *domain.Token, rows []domain.UserImportRow, dryRun bool) (int, error)
+	// ExportUsers calls fn for every user matching the filter.
+	ExportUsers(ctx context.Context, token *domain.Token, filter *domain.UserFilter, fn func(user *domain.User) error) error
 	// UpdateUser updates a specific user field.
 	UpdateUser(ctx context.Context, token *domain.Token, update *domain.UserUpdate) error
+	// SuspendUser suspends a user, preventing him from logging in or refreshing his session.
+	SuspendUser(ctx context.Context, token *domain.Token, id uuid.UUID) error
+	// ReactivateUser reactivates a suspended user.
+	ReactivateUser(ctx context.Context, token *domain.Token, id uuid.UUID) error
+	// DeleteUser soft-deletes a user and revokes his tokens.
+	DeleteUser(ctx context.Context, token *domain.Token, id uuid.UUID) error
+	// RestoreUser restores a soft-deleted user.
+	RestoreUser(ctx context.Context, token *domain.Token, id uuid.UUID) error
+	// UnlockUser deletes the failed login attempts of a user.
+	UnlockUser(ctx context.Context, token *domain.Token, id uuid.UUID) error
+	// RevokeUserSessions revokes every token of a user, signing them out everywhere.
+	RevokeUserSessions(ctx context.Context, token *domain.Token, id uuid.UUID) error
+	// ImpersonateUser issues a short-lived access token for a user that names the admin as actor.
+	// No refresh token is issued, the admin has to impersonate the user again once it expires.
+	ImpersonateUser(ctx context.Context, token *domain.Token, id uuid.UUID) (*domain.ImpersonationToken, error)
+	// GetMFARequirements fetches the MFA requirement of every role.
+	GetMFARequirements(ctx context.Context, token *domain.Token) ([]domain.RoleMFARequirement, error)
+	// SetMFARequirement sets whether a role must use MFA.
+	SetMFARequirement(ctx context.Context, token *domain.Token, requirement *domain.RoleMFARequirement) error
 }
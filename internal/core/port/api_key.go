@@ -0,0 +1,52 @@
+package port
+
+import (
+	"context"
+	"shop-api-go/internal/core/domain"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// APIKeyGenerator is an interface for generating and hashing API keys.
+type APIKeyGenerator interface {
+	// Generate returns a new random API key of specific type.
+	Generate(keyType domain.APIKeyType) (*domain.APIKeySecret, error)
+	// Prefix returns the identification prefix of an API key, it returns false if the value is not an API key.
+	Prefix(secret string) (string, bool)
+	// Hash returns hashed version of the API key.
+	Hash(secret string) string
+	// Compare validates that the API key and the hash match.
+	Compare(secret, hash string) bool
+}
+
+// APIKeyRepository is an interface for interacting with API key-related data.
+type APIKeyRepository interface {
+	// AddAPIKey inserts a new API key and writes the audit event in the same transaction.
+	AddAPIKey(ctx context.Context, key *domain.APIKey, event *domain.AuditEvent) error
+	// GetAPIKeyById fetches an API key by specific id.
+	GetAPIKeyById(ctx context.Context, id uuid.UUID) (*domain.APIKey, error)
+	// GetAPIKeyByPrefix fetches an API key by specific prefix.
+	GetAPIKeyByPrefix(ctx context.Context, prefix string) (*domain.APIKey, error)
+	// GetAPIKeysByUserId fetches all API keys of a user.
+	GetAPIKeysByUserId(ctx context.Context, userId uuid.UUID) ([]domain.APIKey, error)
+	// UpdateLastUsed stores the last time an API key was used.
+	UpdateLastUsed(ctx context.Context, id uuid.UUID, usedAt time.Time) error
+	// RevokeAPIKey revokes an API key by specific id and writes the audit event in the same transaction.
+	RevokeAPIKey(ctx context.Context, id uuid.UUID, event *domain.AuditEvent) error
+}
+
+// APIKeyService is an interface for interacting with API key-related business logic.
+type APIKeyService interface {
+	// Authenticate validates an API key and returns a *domain.Token for the owner of the key.
+	Authenticate(ctx context.Context, secret string) (*domain.Token, error)
+	// CreatePersonalAccessToken creates a personal access token for the owner of the token.
+	CreatePersonalAccessToken(ctx context.Context, token *domain.Token, create *domain.CreateAPIKey) (*domain.CreatedAPIKey, error)
+	// CreateServiceAPIKey creates a service API key for any user.
+	CreateServiceAPIKey(ctx context.Context, token *domain.Token, create *domain.CreateAPIKey) (*domain.CreatedAPIKey, error)
+	// GetAPIKeys fetches the API keys of a user, users can fetch their own keys without permission.
+	// API keys are only managed with tokens issued at login, never with an API key.
+	GetAPIKeys(ctx context.Context, token *domain.Token, userId uuid.UUID) ([]domain.APIKey, error)
+	// RevokeAPIKey revokes an API key, users can revoke their own keys without permission.
+	RevokeAPIKey(ctx context.Context, token *domain.Token, id uuid.UUID) error
+}
@@ -0,0 +1,25 @@
+package port
+
+import (
+	"context"
+	"shop-api-go/internal/core/domain"
+)
+
+// AuditRepository is an interface for interacting with the audit log.
+//
+// Note: Repositories that change audited data write the audit event in the same transaction
+// as the change, AddAuditEvent is only used for actions without such change, e.g. failed logins.
+type AuditRepository interface {
+	// AddAuditEvent appends an event to the audit log.
+	AddAuditEvent(ctx context.Context, event *domain.AuditEvent) error
+	// GetAuditEvents fetches the events matching the filter ordered from newest to oldest.
+	GetAuditEvents(ctx context.Context, filter *domain.AuditFilter) ([]domain.AuditEvent, error)
+}
+
+// AuditService is an interface for interacting with audit-related business logic.
+type AuditService interface {
+	// GetAuditEvents fetches the events matching the filter.
+	GetAuditEvents(ctx context.Context, token *domain.Token, filter *domain.AuditFilter) ([]domain.AuditEvent, error)
+	// RecordImpersonatedRequest appends a request made with an impersonation token to the audit log.
+	RecordImpersonatedRequest(ctx context.Context, token *domain.Token, method, path string, status int) error
+}
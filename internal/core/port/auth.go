@@ -3,6 +3,7 @@ package port
 import (
 	"context"
 	"shop-api-go/internal/core/domain"
+	"time"
 
 	"github.com/google/uuid"
 )
@@ -21,24 +22,52 @@ type PasswordHasher interface {
 	Hash(password string) (string, error)
 	// Compare validates that the password and the hash match.
 	Compare(password, hash string) error
+	// NeedsRehash checks whether the hash was produced with an outdated algorithm or parameters.
+	NeedsRehash(hash string) bool
 }
 
 // TokenRepository is an interface for interacting with token-related data.
 type TokenRepository interface {
-	// AddToken insets a new token into the database.
-	AddToken(ctx context.Context, token *domain.Token) error
+	// AddToken insets a new token into the database and writes the audit event in the same transaction.
+	AddToken(ctx context.Context, token *domain.Token, event *domain.AuditEvent) error
 	// DeleteToken deletes a token with specified id.
 	DeleteToken(ctx context.Context, id uuid.UUID) error
-	// DeleteAllTokensByUserId deletes all tokens with specific user id.
-	DeleteAllTokensByUserId(ctx context.Context, userId uuid.UUID) error
+	// DeleteAllTokensByUserId deletes all tokens with specific user id and writes the audit event in the same transaction.
+	DeleteAllTokensByUserId(ctx context.Context, userId uuid.UUID, event *domain.AuditEvent) error
 	// DeleteExpiredTokens deletes all tokens that have expired.
 	DeleteExpiredTokens() error
 }
 
+// LoginAttemptRepository is an interface for tracking failed login attempts.
+type LoginAttemptRepository interface {
+	// GetLoginAttempts fetches the failed login attempts of a key.
+	//
+	// Note: Attempts with no failures are returned if the key is not tracked.
+	GetLoginAttempts(ctx context.Context, key string) (*domain.LoginAttempts, error)
+	// RecordFailedLogin increments the failures of a key and returns the updated attempts,
+	// failures older than the window are discarded.
+	RecordFailedLogin(ctx context.Context, key string, window time.Duration) (*domain.LoginAttempts, error)
+	// BlockLogin blocks logins of a key until specific time.
+	BlockLogin(ctx context.Context, key string, until time.Time) error
+	// ResetLoginAttempts deletes the failed login attempts of a key.
+	ResetLoginAttempts(ctx context.Context, key string) error
+	// DeleteExpiredLoginAttempts deletes all unblocked attempts with no failures within the window.
+	DeleteExpiredLoginAttempts(window time.Duration) error
+}
+
 // AuthService is an interface for interacting with auth-related business logic.
 type AuthService interface {
-	// Login validates user credentials and returns domain.TokenGroup.
-	Login(ctx context.Context, user *domain.User) (*domain.TokenGroup, error)
+	// Login validates user credentials and returns domain.LoginResult.
+	Login(ctx context.Context, user *domain.User) (*domain.LoginResult, error)
+	// VerifyMFA uses an MFA token and a TOTP or recovery code to finish the login.
+	VerifyMFA(ctx context.Context, token *domain.Token, code string) (*domain.TokenGroup, error)
+	// StartOAuthLogin starts a login with an external identity provider and returns the url to redirect the user to.
+	StartOAuthLogin(ctx context.Context, provider string) (string, error)
+	// StartOAuthLink starts linking an external identity to the logged in user and returns the url to redirect the user to.
+	StartOAuthLink(ctx context.Context, token *domain.Token, provider string) (string, error)
+	// FinishOAuthLogin exchanges the authorization code of a login, links the external identity
+	// to the user linking it, an existing unprivileged user or a new client user and returns domain.LoginResult.
+	FinishOAuthLogin(ctx context.Context, provider, state, code string) (*domain.LoginResult, error)
 	// RefreshSession uses a refresh token to refresh user session.
 	RefreshSession(ctx context.Context, token *domain.Token) (*domain.TokenGroup, error)
 }
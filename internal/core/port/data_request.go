@@ -0,0 +1,53 @@
+package port
+
+import (
+	"context"
+	"shop-api-go/internal/core/domain"
+
+	"github.com/google/uuid"
+)
+
+// DataRequestRepository is an interface for interacting with data subject requests and the data they cover.
+type DataRequestRepository interface {
+	// AddDataRequest stores a pending request for a user whose data has not been erased.
+	AddDataRequest(ctx context.Context, request *domain.DataRequest, event *domain.AuditEvent) error
+	// GetDataRequestById fetches a request without its archive.
+	GetDataRequestById(ctx context.Context, id uuid.UUID) (*domain.DataRequest, error)
+	// GetDataExportArchive fetches the archive of a completed export.
+	GetDataExportArchive(ctx context.Context, id uuid.UUID) ([]byte, error)
+	// ClaimDataRequest marks the oldest pending or timed out request as running and returns it,
+	// it returns domain.ErrDataRequestNotFound if there is none.
+	ClaimDataRequest(ctx context.Context) (*domain.DataRequest, error)
+	// GetUserData fetches all data stored about a user, including a soft-deleted one.
+	GetUserData(ctx context.Context, userId uuid.UUID) (*domain.UserData, error)
+	// CompleteDataExport stores the archive of an export and marks it completed.
+	CompleteDataExport(ctx context.Context, id uuid.UUID, archive []byte) error
+	// EraseUser anonymises the personal data of the user of an erasure request, also in his audit events,
+	// domain events and webhook deliveries, deletes his credentials, login attempts and export archives,
+	// and marks the request completed.
+	EraseUser(ctx context.Context, request *domain.DataRequest, event *domain.AuditEvent) error
+	// FailDataRequest marks a request failed with the reason.
+	FailDataRequest(ctx context.Context, id uuid.UUID, reason string) error
+	// DeleteExpiredDataExports deletes the archives of exports completed before the retention.
+	DeleteExpiredDataExports() error
+}
+
+// UserDataArchiver is an interface for compiling user data into a downloadable archive.
+type UserDataArchiver interface {
+	// Archive returns the encoded archive of the data.
+	Archive(data *domain.UserData) ([]byte, error)
+}
+
+// DataRequestService is an interface for interacting with data subject request-related business logic.
+type DataRequestService interface {
+	// RequestDataExport queues the export of all data stored about a user.
+	RequestDataExport(ctx context.Context, token *domain.Token, userId uuid.UUID) (*domain.DataRequest, error)
+	// RequestErasure queues the erasure of the personal data of a user.
+	RequestErasure(ctx context.Context, token *domain.Token, userId uuid.UUID) (*domain.DataRequest, error)
+	// GetDataRequest fetches the status of a request.
+	GetDataRequest(ctx context.Context, token *domain.Token, id uuid.UUID) (*domain.DataRequest, error)
+	// GetDataExportArchive fetches the archive of a completed export.
+	GetDataExportArchive(ctx context.Context, token *domain.Token, id uuid.UUID) (*domain.DataRequest, []byte, error)
+	// ProcessDataRequests runs the pending requests one by one until none is left.
+	ProcessDataRequests(ctx context.Context) error
+}
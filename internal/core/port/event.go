@@ -0,0 +1,42 @@
+package port
+
+import (
+	"context"
+	"shop-api-go/internal/core/domain"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// OutboxRepository is an interface for interacting with the events waiting in the outbox.
+//
+// Note: Repositories that change data other services are notified about write the events in the same
+// transaction as the change, so an event is stored if and only if its change is committed.
+type OutboxRepository interface {
+	// ClaimEvents hides up to limit events that are due from other relays for domain.EventClaimTimeout,
+	// increments their attempts and returns them ordered by when they were stored.
+	//
+	// Note: Only the oldest unpublished event of every aggregate is claimed, so the events of an aggregate
+	// are never published out of order or concurrently.
+	ClaimEvents(ctx context.Context, limit int) ([]domain.Event, error)
+	// MarkEventPublished marks an event published.
+	MarkEventPublished(ctx context.Context, id uuid.UUID) error
+	// MarkEventFailed stores the reason an event could not be published and when it is retried.
+	MarkEventFailed(ctx context.Context, id uuid.UUID, nextAttemptAt time.Time, reason string) error
+	// DeletePublishedEvents deletes all events published before the retention.
+	DeletePublishedEvents(retention time.Duration) error
+}
+
+// EventPublisher is an interface for publishing domain events to the rest of the platform.
+//
+// Note: Events are delivered at least once, consumers have to deduplicate them by id.
+type EventPublisher interface {
+	// Publish delivers an event, an error means it has to be published again.
+	Publish(ctx context.Context, event *domain.Event) error
+}
+
+// OutboxService is an interface for relaying the events of the outbox to the EventPublisher.
+type OutboxService interface {
+	// RelayEvents publishes the due events until none is left, events that fail are retried with backoff.
+	RelayEvents(ctx context.Context) error
+}
@@ -0,0 +1,30 @@
+package port
+
+import (
+	"context"
+	"shop-api-go/internal/core/domain"
+)
+
+// IdentityProvider is an interface for authenticating users with external identity providers.
+type IdentityProvider interface {
+	// Authorize starts a login with the provider and returns the state and the url to redirect the user to.
+	Authorize(ctx context.Context, provider string) (*domain.OAuthAuthorization, error)
+	// Exchange exchanges the authorization code of a login and returns the authenticated identity.
+	Exchange(ctx context.Context, state *domain.OAuthState, code string) (*domain.ExternalIdentity, error)
+}
+
+// IdentityRepository is an interface for interacting with external identities and pending logins.
+type IdentityRepository interface {
+	// GetUserIdentity fetches the identity of a provider with specified subject.
+	GetUserIdentity(ctx context.Context, provider, subject string) (*domain.UserIdentity, error)
+	// AddUserIdentity links a new identity to an existing user.
+	AddUserIdentity(ctx context.Context, identity *domain.UserIdentity) error
+	// AddUserWithIdentity inserts a new user together with its identity and writes the outbox events in the same transaction.
+	AddUserWithIdentity(ctx context.Context, user *domain.User, identity *domain.UserIdentity, outbox []*domain.Event) error
+	// AddOAuthState inserts a new pending login.
+	AddOAuthState(ctx context.Context, state *domain.OAuthState) error
+	// ConsumeOAuthState deletes a pending login and returns it, so that it can be used only once.
+	ConsumeOAuthState(ctx context.Context, state string) (*domain.OAuthState, error)
+	// DeleteExpiredOAuthStates deletes all pending logins that have expired.
+	DeleteExpiredOAuthStates() error
+}
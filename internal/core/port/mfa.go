@@ -0,0 +1,55 @@
+package port
+
+import (
+	"context"
+	"shop-api-go/internal/core/domain"
+
+	"github.com/google/uuid"
+)
+
+// MFAProvider is an interface for generating and validating TOTP secrets and recovery codes.
+type MFAProvider interface {
+	// GenerateSecret returns a new random TOTP secret.
+	GenerateSecret() (string, error)
+	// ProvisioningURI returns an otpauth:// URI that can be encoded as a QR code.
+	ProvisioningURI(accountName, secret string) string
+	// ValidateCode validates the code against the secret and returns the matched time step.
+	ValidateCode(code, secret string) (int64, bool)
+	// GenerateRecoveryCodes returns count new random recovery codes.
+	GenerateRecoveryCodes(count int) ([]string, error)
+	// HashRecoveryCode returns the keyed hash a recovery code is stored and looked up by.
+	HashRecoveryCode(code string) string
+}
+
+// MFARepository is an interface for interacting with MFA-related data.
+type MFARepository interface {
+	// GetUserMFA fetches the MFA enrolment of a user.
+	GetUserMFA(ctx context.Context, userId uuid.UUID) (*domain.UserMFA, error)
+	// SaveUserMFA inserts or replaces a pending MFA enrolment of a user.
+	SaveUserMFA(ctx context.Context, mfa *domain.UserMFA) error
+	// EnableUserMFA enables MFA of a user, stores his recovery codes and writes the audit event in the same transaction.
+	EnableUserMFA(ctx context.Context, userId uuid.UUID, lastUsedStep int64, codes []domain.RecoveryCode, event *domain.AuditEvent) error
+	// DeleteUserMFA deletes the MFA enrolment and recovery codes of a user and writes the audit event in the same transaction.
+	DeleteUserMFA(ctx context.Context, userId uuid.UUID, event *domain.AuditEvent) error
+	// UpdateLastUsedStep stores the last used time step, it fails if the step was already used.
+	UpdateLastUsedStep(ctx context.Context, userId uuid.UUID, step int64) error
+	// UseRecoveryCode marks the unused recovery code of a user with the hash as used,
+	// it fails with domain.ErrInvalidMFACode if there is none.
+	UseRecoveryCode(ctx context.Context, userId uuid.UUID, codeHash string) error
+	// GetRoleMFARequirements fetches the MFA requirement of every role.
+	GetRoleMFARequirements(ctx context.Context) ([]domain.RoleMFARequirement, error)
+	// IsMFARequired checks whether a role must use MFA.
+	IsMFARequired(ctx context.Context, role domain.UserRole) (bool, error)
+	// SetRoleMFARequirement sets the MFA requirement of a role and writes the audit event in the same transaction.
+	SetRoleMFARequirement(ctx context.Context, requirement *domain.RoleMFARequirement, event *domain.AuditEvent) error
+}
+
+// MFAService is an interface for interacting with MFA-related business logic.
+type MFAService interface {
+	// Enroll starts TOTP enrolment and returns the secret and provisioning URI.
+	Enroll(ctx context.Context, credentials *domain.MFACredentials) (*domain.MFAEnrollment, error)
+	// ConfirmEnrollment enables MFA after validating a code and returns the recovery codes.
+	ConfirmEnrollment(ctx context.Context, credentials *domain.MFACredentials) ([]string, error)
+	// Disable disables MFA after validating a code.
+	Disable(ctx context.Context, credentials *domain.MFACredentials) error
+}
@@ -14,6 +14,7 @@ import (
 	reflect "reflect"
 	domain "shop-api-go/internal/core/domain"
 
+	uuid "github.com/google/uuid"
 	gomock "go.uber.org/mock/gomock"
 )
 
@@ -41,6 +42,63 @@ func (m *MockAdminService) EXPECT() *MockAdminServiceMockRecorder {
 	return m.recorder
 }
 
+// CreateUser mocks base method.
+func (m *MockAdminService) CreateUser(ctx context.Context, token *domain.Token, user *domain.User) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateUser", ctx, token, user)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateUser indicates an expected call of CreateUser.
+func (mr *MockAdminServiceMockRecorder) CreateUser(ctx, token, user any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUser", reflect.TypeOf((*MockAdminService)(nil).CreateUser), ctx, token, user)
+}
+
+// DeleteUser mocks base method.
+func (m *MockAdminService) DeleteUser(ctx context.Context, token *domain.Token, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteUser", ctx, token, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteUser indicates an expected call of DeleteUser.
+func (mr *MockAdminServiceMockRecorder) DeleteUser(ctx, token, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUser", reflect.TypeOf((*MockAdminService)(nil).DeleteUser), ctx, token, id)
+}
+
+// ExportUsers mocks base method.
+func (m *MockAdminService) ExportUsers(ctx context.Context, token *domain.Token, filter *domain.UserFilter, fn func(*domain.User) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportUsers", ctx, token, filter, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ExportUsers indicates an expected call of ExportUsers.
+func (mr *MockAdminServiceMockRecorder) ExportUsers(ctx, token, filter, fn any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportUsers", reflect.TypeOf((*MockAdminService)(nil).ExportUsers), ctx, token, filter, fn)
+}
+
+// GetMFARequirements mocks base method.
+func (m *MockAdminService) GetMFARequirements(ctx context.Context, token *domain.Token) ([]domain.RoleMFARequirement, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMFARequirements", ctx, token)
+	ret0, _ := ret[0].([]domain.RoleMFARequirement)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMFARequirements indicates an expected call of GetMFARequirements.
+func (mr *MockAdminServiceMockRecorder) GetMFARequirements(ctx, token any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMFARequirements", reflect.TypeOf((*MockAdminService)(nil).GetMFARequirements), ctx, token)
+}
+
 // GetUsers mocks base method.
 func (m *MockAdminService) GetUsers(ctx context.Context, token *domain.Token, get *domain.GetUsers) (*domain.UsersResult, error) {
 	m.ctrl.T.Helper()
@@ -56,6 +114,120 @@ func (mr *MockAdminServiceMockRecorder) GetUsers(ctx, token, get any) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUsers", reflect.TypeOf((*MockAdminService)(nil).GetUsers), ctx, token, get)
 }
 
+// ImpersonateUser mocks base method.
+func (m *MockAdminService) ImpersonateUser(ctx context.Context, token *domain.Token, id uuid.UUID) (*domain.ImpersonationToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ImpersonateUser", ctx, token, id)
+	ret0, _ := ret[0].(*domain.ImpersonationToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ImpersonateUser indicates an expected call of ImpersonateUser.
+func (mr *MockAdminServiceMockRecorder) ImpersonateUser(ctx, token, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImpersonateUser", reflect.TypeOf((*MockAdminService)(nil).ImpersonateUser), ctx, token, id)
+}
+
+// ImportUsers mocks base method.
+func (m *MockAdminService) ImportUsers(ctx context.Context, token *domain.Token, rows []domain.UserImportRow, dryRun bool) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ImportUsers", ctx, token, rows, dryRun)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ImportUsers indicates an expected call of ImportUsers.
+func (mr *MockAdminServiceMockRecorder) ImportUsers(ctx, token, rows, dryRun any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImportUsers", reflect.TypeOf((*MockAdminService)(nil).ImportUsers), ctx, token, rows, dryRun)
+}
+
+// ReactivateUser mocks base method.
+func (m *MockAdminService) ReactivateUser(ctx context.Context, token *domain.Token, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReactivateUser", ctx, token, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReactivateUser indicates an expected call of ReactivateUser.
+func (mr *MockAdminServiceMockRecorder) ReactivateUser(ctx, token, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReactivateUser", reflect.TypeOf((*MockAdminService)(nil).ReactivateUser), ctx, token, id)
+}
+
+// RestoreUser mocks base method.
+func (m *MockAdminService) RestoreUser(ctx context.Context, token *domain.Token, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreUser", ctx, token, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RestoreUser indicates an expected call of RestoreUser.
+func (mr *MockAdminServiceMockRecorder) RestoreUser(ctx, token, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreUser", reflect.TypeOf((*MockAdminService)(nil).RestoreUser), ctx, token, id)
+}
+
+// RevokeUserSessions mocks base method.
+func (m *MockAdminService) RevokeUserSessions(ctx context.Context, token *domain.Token, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeUserSessions", ctx, token, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeUserSessions indicates an expected call of RevokeUserSessions.
+func (mr *MockAdminServiceMockRecorder) RevokeUserSessions(ctx, token, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeUserSessions", reflect.TypeOf((*MockAdminService)(nil).RevokeUserSessions), ctx, token, id)
+}
+
+// SetMFARequirement mocks base method.
+func (m *MockAdminService) SetMFARequirement(ctx context.Context, token *domain.Token, requirement *domain.RoleMFARequirement) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetMFARequirement", ctx, token, requirement)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetMFARequirement indicates an expected call of SetMFARequirement.
+func (mr *MockAdminServiceMockRecorder) SetMFARequirement(ctx, token, requirement any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetMFARequirement", reflect.TypeOf((*MockAdminService)(nil).SetMFARequirement), ctx, token, requirement)
+}
+
+// SuspendUser mocks base method.
+func (m *MockAdminService) SuspendUser(ctx context.Context, token *domain.Token, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SuspendUser", ctx, token, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SuspendUser indicates an expected call of SuspendUser.
+func (mr *MockAdminServiceMockRecorder) SuspendUser(ctx, token, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SuspendUser", reflect.TypeOf((*MockAdminService)(nil).SuspendUser), ctx, token, id)
+}
+
+// UnlockUser mocks base method.
+func (m *MockAdminService) UnlockUser(ctx context.Context, token *domain.Token, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnlockUser", ctx, token, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UnlockUser indicates an expected call of UnlockUser.
+func (mr *MockAdminServiceMockRecorder) UnlockUser(ctx, token, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnlockUser", reflect.TypeOf((*MockAdminService)(nil).UnlockUser), ctx, token, id)
+}
+
 // UpdateUser mocks base method.
 func (m *MockAdminService) UpdateUser(ctx context.Context, token *domain.Token, update *domain.UserUpdate) error {
 	m.ctrl.T.Helper()
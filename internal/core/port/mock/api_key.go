@@ -0,0 +1,311 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/core/port/api_key.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/core/port/api_key.go -destination=internal/core/port/mock/api_key.go -package=mock
+//
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+	domain "shop-api-go/internal/core/domain"
+	time "time"
+
+	uuid "github.com/google/uuid"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockAPIKeyGenerator is a mock of APIKeyGenerator interface.
+type MockAPIKeyGenerator struct {
+	ctrl     *gomock.Controller
+	recorder *MockAPIKeyGeneratorMockRecorder
+	isgomock struct{}
+}
+
+// MockAPIKeyGeneratorMockRecorder is the mock recorder for MockAPIKeyGenerator.
+type MockAPIKeyGeneratorMockRecorder struct {
+	mock *MockAPIKeyGenerator
+}
+
+// NewMockAPIKeyGenerator creates a new mock instance.
+func NewMockAPIKeyGenerator(ctrl *gomock.Controller) *MockAPIKeyGenerator {
+	mock := &MockAPIKeyGenerator{ctrl: ctrl}
+	mock.recorder = &MockAPIKeyGeneratorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAPIKeyGenerator) EXPECT() *MockAPIKeyGeneratorMockRecorder {
+	return m.recorder
+}
+
+// Compare mocks base method.
+func (m *MockAPIKeyGenerator) Compare(secret, hash string) bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Compare", secret, hash)
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// Compare indicates an expected call of Compare.
+func (mr *MockAPIKeyGeneratorMockRecorder) Compare(secret, hash any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Compare", reflect.TypeOf((*MockAPIKeyGenerator)(nil).Compare), secret, hash)
+}
+
+// Generate mocks base method.
+func (m *MockAPIKeyGenerator) Generate(keyType domain.APIKeyType) (*domain.APIKeySecret, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Generate", keyType)
+	ret0, _ := ret[0].(*domain.APIKeySecret)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Generate indicates an expected call of Generate.
+func (mr *MockAPIKeyGeneratorMockRecorder) Generate(keyType any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Generate", reflect.TypeOf((*MockAPIKeyGenerator)(nil).Generate), keyType)
+}
+
+// Hash mocks base method.
+func (m *MockAPIKeyGenerator) Hash(secret string) string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Hash", secret)
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// Hash indicates an expected call of Hash.
+func (mr *MockAPIKeyGeneratorMockRecorder) Hash(secret any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Hash", reflect.TypeOf((*MockAPIKeyGenerator)(nil).Hash), secret)
+}
+
+// Prefix mocks base method.
+func (m *MockAPIKeyGenerator) Prefix(secret string) (string, bool) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Prefix", secret)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(bool)
+	return ret0, ret1
+}
+
+// Prefix indicates an expected call of Prefix.
+func (mr *MockAPIKeyGeneratorMockRecorder) Prefix(secret any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Prefix", reflect.TypeOf((*MockAPIKeyGenerator)(nil).Prefix), secret)
+}
+
+// MockAPIKeyRepository is a mock of APIKeyRepository interface.
+type MockAPIKeyRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockAPIKeyRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockAPIKeyRepositoryMockRecorder is the mock recorder for MockAPIKeyRepository.
+type MockAPIKeyRepositoryMockRecorder struct {
+	mock *MockAPIKeyRepository
+}
+
+// NewMockAPIKeyRepository creates a new mock instance.
+func NewMockAPIKeyRepository(ctrl *gomock.Controller) *MockAPIKeyRepository {
+	mock := &MockAPIKeyRepository{ctrl: ctrl}
+	mock.recorder = &MockAPIKeyRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAPIKeyRepository) EXPECT() *MockAPIKeyRepositoryMockRecorder {
+	return m.recorder
+}
+
+// AddAPIKey mocks base method.
+func (m *MockAPIKeyRepository) AddAPIKey(ctx context.Context, key *domain.APIKey, event *domain.AuditEvent) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddAPIKey", ctx, key, event)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddAPIKey indicates an expected call of AddAPIKey.
+func (mr *MockAPIKeyRepositoryMockRecorder) AddAPIKey(ctx, key, event any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddAPIKey", reflect.TypeOf((*MockAPIKeyRepository)(nil).AddAPIKey), ctx, key, event)
+}
+
+// GetAPIKeyById mocks base method.
+func (m *MockAPIKeyRepository) GetAPIKeyById(ctx context.Context, id uuid.UUID) (*domain.APIKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAPIKeyById", ctx, id)
+	ret0, _ := ret[0].(*domain.APIKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAPIKeyById indicates an expected call of GetAPIKeyById.
+func (mr *MockAPIKeyRepositoryMockRecorder) GetAPIKeyById(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAPIKeyById", reflect.TypeOf((*MockAPIKeyRepository)(nil).GetAPIKeyById), ctx, id)
+}
+
+// GetAPIKeyByPrefix mocks base method.
+func (m *MockAPIKeyRepository) GetAPIKeyByPrefix(ctx context.Context, prefix string) (*domain.APIKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAPIKeyByPrefix", ctx, prefix)
+	ret0, _ := ret[0].(*domain.APIKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAPIKeyByPrefix indicates an expected call of GetAPIKeyByPrefix.
+func (mr *MockAPIKeyRepositoryMockRecorder) GetAPIKeyByPrefix(ctx, prefix any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAPIKeyByPrefix", reflect.TypeOf((*MockAPIKeyRepository)(nil).GetAPIKeyByPrefix), ctx, prefix)
+}
+
+// GetAPIKeysByUserId mocks base method.
+func (m *MockAPIKeyRepository) GetAPIKeysByUserId(ctx context.Context, userId uuid.UUID) ([]domain.APIKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAPIKeysByUserId", ctx, userId)
+	ret0, _ := ret[0].([]domain.APIKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAPIKeysByUserId indicates an expected call of GetAPIKeysByUserId.
+func (mr *MockAPIKeyRepositoryMockRecorder) GetAPIKeysByUserId(ctx, userId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAPIKeysByUserId", reflect.TypeOf((*MockAPIKeyRepository)(nil).GetAPIKeysByUserId), ctx, userId)
+}
+
+// RevokeAPIKey mocks base method.
+func (m *MockAPIKeyRepository) RevokeAPIKey(ctx context.Context, id uuid.UUID, event *domain.AuditEvent) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeAPIKey", ctx, id, event)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeAPIKey indicates an expected call of RevokeAPIKey.
+func (mr *MockAPIKeyRepositoryMockRecorder) RevokeAPIKey(ctx, id, event any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeAPIKey", reflect.TypeOf((*MockAPIKeyRepository)(nil).RevokeAPIKey), ctx, id, event)
+}
+
+// UpdateLastUsed mocks base method.
+func (m *MockAPIKeyRepository) UpdateLastUsed(ctx context.Context, id uuid.UUID, usedAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateLastUsed", ctx, id, usedAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateLastUsed indicates an expected call of UpdateLastUsed.
+func (mr *MockAPIKeyRepositoryMockRecorder) UpdateLastUsed(ctx, id, usedAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateLastUsed", reflect.TypeOf((*MockAPIKeyRepository)(nil).UpdateLastUsed), ctx, id, usedAt)
+}
+
+// MockAPIKeyService is a mock of APIKeyService interface.
+type MockAPIKeyService struct {
+	ctrl     *gomock.Controller
+	recorder *MockAPIKeyServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockAPIKeyServiceMockRecorder is the mock recorder for MockAPIKeyService.
+type MockAPIKeyServiceMockRecorder struct {
+	mock *MockAPIKeyService
+}
+
+// NewMockAPIKeyService creates a new mock instance.
+func NewMockAPIKeyService(ctrl *gomock.Controller) *MockAPIKeyService {
+	mock := &MockAPIKeyService{ctrl: ctrl}
+	mock.recorder = &MockAPIKeyServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAPIKeyService) EXPECT() *MockAPIKeyServiceMockRecorder {
+	return m.recorder
+}
+
+// Authenticate mocks base method.
+func (m *MockAPIKeyService) Authenticate(ctx context.Context, secret string) (*domain.Token, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Authenticate", ctx, secret)
+	ret0, _ := ret[0].(*domain.Token)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Authenticate indicates an expected call of Authenticate.
+func (mr *MockAPIKeyServiceMockRecorder) Authenticate(ctx, secret any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Authenticate", reflect.TypeOf((*MockAPIKeyService)(nil).Authenticate), ctx, secret)
+}
+
+// CreatePersonalAccessToken mocks base method.
+func (m *MockAPIKeyService) CreatePersonalAccessToken(ctx context.Context, token *domain.Token, create *domain.CreateAPIKey) (*domain.CreatedAPIKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePersonalAccessToken", ctx, token, create)
+	ret0, _ := ret[0].(*domain.CreatedAPIKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreatePersonalAccessToken indicates an expected call of CreatePersonalAccessToken.
+func (mr *MockAPIKeyServiceMockRecorder) CreatePersonalAccessToken(ctx, token, create any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePersonalAccessToken", reflect.TypeOf((*MockAPIKeyService)(nil).CreatePersonalAccessToken), ctx, token, create)
+}
+
+// CreateServiceAPIKey mocks base method.
+func (m *MockAPIKeyService) CreateServiceAPIKey(ctx context.Context, token *domain.Token, create *domain.CreateAPIKey) (*domain.CreatedAPIKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateServiceAPIKey", ctx, token, create)
+	ret0, _ := ret[0].(*domain.CreatedAPIKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateServiceAPIKey indicates an expected call of CreateServiceAPIKey.
+func (mr *MockAPIKeyServiceMockRecorder) CreateServiceAPIKey(ctx, token, create any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateServiceAPIKey", reflect.TypeOf((*MockAPIKeyService)(nil).CreateServiceAPIKey), ctx, token, create)
+}
+
+// GetAPIKeys mocks base method.
+func (m *MockAPIKeyService) GetAPIKeys(ctx context.Context, token *domain.Token, userId uuid.UUID) ([]domain.APIKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAPIKeys", ctx, token, userId)
+	ret0, _ := ret[0].([]domain.APIKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAPIKeys indicates an expected call of GetAPIKeys.
+func (mr *MockAPIKeyServiceMockRecorder) GetAPIKeys(ctx, token, userId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAPIKeys", reflect.TypeOf((*MockAPIKeyService)(nil).GetAPIKeys), ctx, token, userId)
+}
+
+// RevokeAPIKey mocks base method.
+func (m *MockAPIKeyService) RevokeAPIKey(ctx context.Context, token *domain.Token, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeAPIKey", ctx, token, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeAPIKey indicates an expected call of RevokeAPIKey.
+func (mr *MockAPIKeyServiceMockRecorder) RevokeAPIKey(ctx, token, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeAPIKey", reflect.TypeOf((*MockAPIKeyService)(nil).RevokeAPIKey), ctx, token, id)
+}
@@ -0,0 +1,124 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/core/port/audit.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/core/port/audit.go -destination=internal/core/port/mock/audit.go -package=mock
+//
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+	domain "shop-api-go/internal/core/domain"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockAuditRepository is a mock of AuditRepository interface.
+type MockAuditRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockAuditRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockAuditRepositoryMockRecorder is the mock recorder for MockAuditRepository.
+type MockAuditRepositoryMockRecorder struct {
+	mock *MockAuditRepository
+}
+
+// NewMockAuditRepository creates a new mock instance.
+func NewMockAuditRepository(ctrl *gomock.Controller) *MockAuditRepository {
+	mock := &MockAuditRepository{ctrl: ctrl}
+	mock.recorder = &MockAuditRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAuditRepository) EXPECT() *MockAuditRepositoryMockRecorder {
+	return m.recorder
+}
+
+// AddAuditEvent mocks base method.
+func (m *MockAuditRepository) AddAuditEvent(ctx context.Context, event *domain.AuditEvent) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddAuditEvent", ctx, event)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddAuditEvent indicates an expected call of AddAuditEvent.
+func (mr *MockAuditRepositoryMockRecorder) AddAuditEvent(ctx, event any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddAuditEvent", reflect.TypeOf((*MockAuditRepository)(nil).AddAuditEvent), ctx, event)
+}
+
+// GetAuditEvents mocks base method.
+func (m *MockAuditRepository) GetAuditEvents(ctx context.Context, filter *domain.AuditFilter) ([]domain.AuditEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAuditEvents", ctx, filter)
+	ret0, _ := ret[0].([]domain.AuditEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAuditEvents indicates an expected call of GetAuditEvents.
+func (mr *MockAuditRepositoryMockRecorder) GetAuditEvents(ctx, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAuditEvents", reflect.TypeOf((*MockAuditRepository)(nil).GetAuditEvents), ctx, filter)
+}
+
+// MockAuditService is a mock of AuditService interface.
+type MockAuditService struct {
+	ctrl     *gomock.Controller
+	recorder *MockAuditServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockAuditServiceMockRecorder is the mock recorder for MockAuditService.
+type MockAuditServiceMockRecorder struct {
+	mock *MockAuditService
+}
+
+// NewMockAuditService creates a new mock instance.
+func NewMockAuditService(ctrl *gomock.Controller) *MockAuditService {
+	mock := &MockAuditService{ctrl: ctrl}
+	mock.recorder = &MockAuditServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAuditService) EXPECT() *MockAuditServiceMockRecorder {
+	return m.recorder
+}
+
+// GetAuditEvents mocks base method.
+func (m *MockAuditService) GetAuditEvents(ctx context.Context, token *domain.Token, filter *domain.AuditFilter) ([]domain.AuditEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAuditEvents", ctx, token, filter)
+	ret0, _ := ret[0].([]domain.AuditEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAuditEvents indicates an expected call of GetAuditEvents.
+func (mr *MockAuditServiceMockRecorder) GetAuditEvents(ctx, token, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAuditEvents", reflect.TypeOf((*MockAuditService)(nil).GetAuditEvents), ctx, token, filter)
+}
+
+// RecordImpersonatedRequest mocks base method.
+func (m *MockAuditService) RecordImpersonatedRequest(ctx context.Context, token *domain.Token, method, path string, status int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordImpersonatedRequest", ctx, token, method, path, status)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecordImpersonatedRequest indicates an expected call of RecordImpersonatedRequest.
+func (mr *MockAuditServiceMockRecorder) RecordImpersonatedRequest(ctx, token, method, path, status any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordImpersonatedRequest", reflect.TypeOf((*MockAuditService)(nil).RecordImpersonatedRequest), ctx, token, method, path, status)
+}
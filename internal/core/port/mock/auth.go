@@ -13,6 +13,7 @@ import (
 	context "context"
 	reflect "reflect"
 	domain "shop-api-go/internal/core/domain"
+	time "time"
 
 	uuid "github.com/google/uuid"
 	gomock "go.uber.org/mock/gomock"
@@ -125,6 +126,20 @@ func (mr *MockPasswordHasherMockRecorder) Hash(password any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Hash", reflect.TypeOf((*MockPasswordHasher)(nil).Hash), password)
 }
 
+// NeedsRehash mocks base method.
+func (m *MockPasswordHasher) NeedsRehash(hash string) bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NeedsRehash", hash)
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// NeedsRehash indicates an expected call of NeedsRehash.
+func (mr *MockPasswordHasherMockRecorder) NeedsRehash(hash any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NeedsRehash", reflect.TypeOf((*MockPasswordHasher)(nil).NeedsRehash), hash)
+}
+
 // MockTokenRepository is a mock of TokenRepository interface.
 type MockTokenRepository struct {
 	ctrl     *gomock.Controller
@@ -150,31 +165,31 @@ func (m *MockTokenRepository) EXPECT() *MockTokenRepositoryMockRecorder {
 }
 
 // AddToken mocks base method.
-func (m *MockTokenRepository) AddToken(ctx context.Context, token *domain.Token) error {
+func (m *MockTokenRepository) AddToken(ctx context.Context, token *domain.Token, event *domain.AuditEvent) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "AddToken", ctx, token)
+	ret := m.ctrl.Call(m, "AddToken", ctx, token, event)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // AddToken indicates an expected call of AddToken.
-func (mr *MockTokenRepositoryMockRecorder) AddToken(ctx, token any) *gomock.Call {
+func (mr *MockTokenRepositoryMockRecorder) AddToken(ctx, token, event any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddToken", reflect.TypeOf((*MockTokenRepository)(nil).AddToken), ctx, token)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddToken", reflect.TypeOf((*MockTokenRepository)(nil).AddToken), ctx, token, event)
 }
 
 // DeleteAllTokensByUserId mocks base method.
-func (m *MockTokenRepository) DeleteAllTokensByUserId(ctx context.Context, userId uuid.UUID) error {
+func (m *MockTokenRepository) DeleteAllTokensByUserId(ctx context.Context, userId uuid.UUID, event *domain.AuditEvent) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteAllTokensByUserId", ctx, userId)
+	ret := m.ctrl.Call(m, "DeleteAllTokensByUserId", ctx, userId, event)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // DeleteAllTokensByUserId indicates an expected call of DeleteAllTokensByUserId.
-func (mr *MockTokenRepositoryMockRecorder) DeleteAllTokensByUserId(ctx, userId any) *gomock.Call {
+func (mr *MockTokenRepositoryMockRecorder) DeleteAllTokensByUserId(ctx, userId, event any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAllTokensByUserId", reflect.TypeOf((*MockTokenRepository)(nil).DeleteAllTokensByUserId), ctx, userId)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAllTokensByUserId", reflect.TypeOf((*MockTokenRepository)(nil).DeleteAllTokensByUserId), ctx, userId, event)
 }
 
 // DeleteExpiredTokens mocks base method.
@@ -205,6 +220,102 @@ func (mr *MockTokenRepositoryMockRecorder) DeleteToken(ctx, id any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteToken", reflect.TypeOf((*MockTokenRepository)(nil).DeleteToken), ctx, id)
 }
 
+// MockLoginAttemptRepository is a mock of LoginAttemptRepository interface.
+type MockLoginAttemptRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockLoginAttemptRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockLoginAttemptRepositoryMockRecorder is the mock recorder for MockLoginAttemptRepository.
+type MockLoginAttemptRepositoryMockRecorder struct {
+	mock *MockLoginAttemptRepository
+}
+
+// NewMockLoginAttemptRepository creates a new mock instance.
+func NewMockLoginAttemptRepository(ctrl *gomock.Controller) *MockLoginAttemptRepository {
+	mock := &MockLoginAttemptRepository{ctrl: ctrl}
+	mock.recorder = &MockLoginAttemptRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockLoginAttemptRepository) EXPECT() *MockLoginAttemptRepositoryMockRecorder {
+	return m.recorder
+}
+
+// BlockLogin mocks base method.
+func (m *MockLoginAttemptRepository) BlockLogin(ctx context.Context, key string, until time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BlockLogin", ctx, key, until)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// BlockLogin indicates an expected call of BlockLogin.
+func (mr *MockLoginAttemptRepositoryMockRecorder) BlockLogin(ctx, key, until any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BlockLogin", reflect.TypeOf((*MockLoginAttemptRepository)(nil).BlockLogin), ctx, key, until)
+}
+
+// DeleteExpiredLoginAttempts mocks base method.
+func (m *MockLoginAttemptRepository) DeleteExpiredLoginAttempts(window time.Duration) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteExpiredLoginAttempts", window)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteExpiredLoginAttempts indicates an expected call of DeleteExpiredLoginAttempts.
+func (mr *MockLoginAttemptRepositoryMockRecorder) DeleteExpiredLoginAttempts(window any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteExpiredLoginAttempts", reflect.TypeOf((*MockLoginAttemptRepository)(nil).DeleteExpiredLoginAttempts), window)
+}
+
+// GetLoginAttempts mocks base method.
+func (m *MockLoginAttemptRepository) GetLoginAttempts(ctx context.Context, key string) (*domain.LoginAttempts, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLoginAttempts", ctx, key)
+	ret0, _ := ret[0].(*domain.LoginAttempts)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLoginAttempts indicates an expected call of GetLoginAttempts.
+func (mr *MockLoginAttemptRepositoryMockRecorder) GetLoginAttempts(ctx, key any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLoginAttempts", reflect.TypeOf((*MockLoginAttemptRepository)(nil).GetLoginAttempts), ctx, key)
+}
+
+// RecordFailedLogin mocks base method.
+func (m *MockLoginAttemptRepository) RecordFailedLogin(ctx context.Context, key string, window time.Duration) (*domain.LoginAttempts, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordFailedLogin", ctx, key, window)
+	ret0, _ := ret[0].(*domain.LoginAttempts)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RecordFailedLogin indicates an expected call of RecordFailedLogin.
+func (mr *MockLoginAttemptRepositoryMockRecorder) RecordFailedLogin(ctx, key, window any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordFailedLogin", reflect.TypeOf((*MockLoginAttemptRepository)(nil).RecordFailedLogin), ctx, key, window)
+}
+
+// ResetLoginAttempts mocks base method.
+func (m *MockLoginAttemptRepository) ResetLoginAttempts(ctx context.Context, key string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResetLoginAttempts", ctx, key)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ResetLoginAttempts indicates an expected call of ResetLoginAttempts.
+func (mr *MockLoginAttemptRepositoryMockRecorder) ResetLoginAttempts(ctx, key any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetLoginAttempts", reflect.TypeOf((*MockLoginAttemptRepository)(nil).ResetLoginAttempts), ctx, key)
+}
+
 // MockAuthService is a mock of AuthService interface.
 type MockAuthService struct {
 	ctrl     *gomock.Controller
@@ -229,11 +340,26 @@ func (m *MockAuthService) EXPECT() *MockAuthServiceMockRecorder {
 	return m.recorder
 }
 
+// FinishOAuthLogin mocks base method.
+func (m *MockAuthService) FinishOAuthLogin(ctx context.Context, provider, state, code string) (*domain.LoginResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FinishOAuthLogin", ctx, provider, state, code)
+	ret0, _ := ret[0].(*domain.LoginResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FinishOAuthLogin indicates an expected call of FinishOAuthLogin.
+func (mr *MockAuthServiceMockRecorder) FinishOAuthLogin(ctx, provider, state, code any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FinishOAuthLogin", reflect.TypeOf((*MockAuthService)(nil).FinishOAuthLogin), ctx, provider, state, code)
+}
+
 // Login mocks base method.
-func (m *MockAuthService) Login(ctx context.Context, user *domain.User) (*domain.TokenGroup, error) {
+func (m *MockAuthService) Login(ctx context.Context, user *domain.User) (*domain.LoginResult, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "Login", ctx, user)
-	ret0, _ := ret[0].(*domain.TokenGroup)
+	ret0, _ := ret[0].(*domain.LoginResult)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -258,3 +384,48 @@ func (mr *MockAuthServiceMockRecorder) RefreshSession(ctx, token any) *gomock.Ca
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RefreshSession", reflect.TypeOf((*MockAuthService)(nil).RefreshSession), ctx, token)
 }
+
+// StartOAuthLink mocks base method.
+func (m *MockAuthService) StartOAuthLink(ctx context.Context, token *domain.Token, provider string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StartOAuthLink", ctx, token, provider)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StartOAuthLink indicates an expected call of StartOAuthLink.
+func (mr *MockAuthServiceMockRecorder) StartOAuthLink(ctx, token, provider any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartOAuthLink", reflect.TypeOf((*MockAuthService)(nil).StartOAuthLink), ctx, token, provider)
+}
+
+// StartOAuthLogin mocks base method.
+func (m *MockAuthService) StartOAuthLogin(ctx context.Context, provider string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StartOAuthLogin", ctx, provider)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StartOAuthLogin indicates an expected call of StartOAuthLogin.
+func (mr *MockAuthServiceMockRecorder) StartOAuthLogin(ctx, provider any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartOAuthLogin", reflect.TypeOf((*MockAuthService)(nil).StartOAuthLogin), ctx, provider)
+}
+
+// VerifyMFA mocks base method.
+func (m *MockAuthService) VerifyMFA(ctx context.Context, token *domain.Token, code string) (*domain.TokenGroup, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VerifyMFA", ctx, token, code)
+	ret0, _ := ret[0].(*domain.TokenGroup)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// VerifyMFA indicates an expected call of VerifyMFA.
+func (mr *MockAuthServiceMockRecorder) VerifyMFA(ctx, token, code any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyMFA", reflect.TypeOf((*MockAuthService)(nil).VerifyMFA), ctx, token, code)
+}
@@ -0,0 +1,311 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/core/port/data_request.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/core/port/data_request.go -destination=internal/core/port/mock/data_request.go -package=mock
+//
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+	domain "shop-api-go/internal/core/domain"
+
+	uuid "github.com/google/uuid"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockDataRequestRepository is a mock of DataRequestRepository interface.
+type MockDataRequestRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockDataRequestRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockDataRequestRepositoryMockRecorder is the mock recorder for MockDataRequestRepository.
+type MockDataRequestRepositoryMockRecorder struct {
+	mock *MockDataRequestRepository
+}
+
+// NewMockDataRequestRepository creates a new mock instance.
+func NewMockDataRequestRepository(ctrl *gomock.Controller) *MockDataRequestRepository {
+	mock := &MockDataRequestRepository{ctrl: ctrl}
+	mock.recorder = &MockDataRequestRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDataRequestRepository) EXPECT() *MockDataRequestRepositoryMockRecorder {
+	return m.recorder
+}
+
+// AddDataRequest mocks base method.
+func (m *MockDataRequestRepository) AddDataRequest(ctx context.Context, request *domain.DataRequest, event *domain.AuditEvent) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddDataRequest", ctx, request, event)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddDataRequest indicates an expected call of AddDataRequest.
+func (mr *MockDataRequestRepositoryMockRecorder) AddDataRequest(ctx, request, event any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddDataRequest", reflect.TypeOf((*MockDataRequestRepository)(nil).AddDataRequest), ctx, request, event)
+}
+
+// ClaimDataRequest mocks base method.
+func (m *MockDataRequestRepository) ClaimDataRequest(ctx context.Context) (*domain.DataRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimDataRequest", ctx)
+	ret0, _ := ret[0].(*domain.DataRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimDataRequest indicates an expected call of ClaimDataRequest.
+func (mr *MockDataRequestRepositoryMockRecorder) ClaimDataRequest(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimDataRequest", reflect.TypeOf((*MockDataRequestRepository)(nil).ClaimDataRequest), ctx)
+}
+
+// CompleteDataExport mocks base method.
+func (m *MockDataRequestRepository) CompleteDataExport(ctx context.Context, id uuid.UUID, archive []byte) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CompleteDataExport", ctx, id, archive)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CompleteDataExport indicates an expected call of CompleteDataExport.
+func (mr *MockDataRequestRepositoryMockRecorder) CompleteDataExport(ctx, id, archive any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CompleteDataExport", reflect.TypeOf((*MockDataRequestRepository)(nil).CompleteDataExport), ctx, id, archive)
+}
+
+// DeleteExpiredDataExports mocks base method.
+func (m *MockDataRequestRepository) DeleteExpiredDataExports() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteExpiredDataExports")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteExpiredDataExports indicates an expected call of DeleteExpiredDataExports.
+func (mr *MockDataRequestRepositoryMockRecorder) DeleteExpiredDataExports() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteExpiredDataExports", reflect.TypeOf((*MockDataRequestRepository)(nil).DeleteExpiredDataExports))
+}
+
+// EraseUser mocks base method.
+func (m *MockDataRequestRepository) EraseUser(ctx context.Context, request *domain.DataRequest, event *domain.AuditEvent) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EraseUser", ctx, request, event)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// EraseUser indicates an expected call of EraseUser.
+func (mr *MockDataRequestRepositoryMockRecorder) EraseUser(ctx, request, event any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EraseUser", reflect.TypeOf((*MockDataRequestRepository)(nil).EraseUser), ctx, request, event)
+}
+
+// FailDataRequest mocks base method.
+func (m *MockDataRequestRepository) FailDataRequest(ctx context.Context, id uuid.UUID, reason string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FailDataRequest", ctx, id, reason)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// FailDataRequest indicates an expected call of FailDataRequest.
+func (mr *MockDataRequestRepositoryMockRecorder) FailDataRequest(ctx, id, reason any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FailDataRequest", reflect.TypeOf((*MockDataRequestRepository)(nil).FailDataRequest), ctx, id, reason)
+}
+
+// GetDataExportArchive mocks base method.
+func (m *MockDataRequestRepository) GetDataExportArchive(ctx context.Context, id uuid.UUID) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDataExportArchive", ctx, id)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDataExportArchive indicates an expected call of GetDataExportArchive.
+func (mr *MockDataRequestRepositoryMockRecorder) GetDataExportArchive(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDataExportArchive", reflect.TypeOf((*MockDataRequestRepository)(nil).GetDataExportArchive), ctx, id)
+}
+
+// GetDataRequestById mocks base method.
+func (m *MockDataRequestRepository) GetDataRequestById(ctx context.Context, id uuid.UUID) (*domain.DataRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDataRequestById", ctx, id)
+	ret0, _ := ret[0].(*domain.DataRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDataRequestById indicates an expected call of GetDataRequestById.
+func (mr *MockDataRequestRepositoryMockRecorder) GetDataRequestById(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDataRequestById", reflect.TypeOf((*MockDataRequestRepository)(nil).GetDataRequestById), ctx, id)
+}
+
+// GetUserData mocks base method.
+func (m *MockDataRequestRepository) GetUserData(ctx context.Context, userId uuid.UUID) (*domain.UserData, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserData", ctx, userId)
+	ret0, _ := ret[0].(*domain.UserData)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserData indicates an expected call of GetUserData.
+func (mr *MockDataRequestRepositoryMockRecorder) GetUserData(ctx, userId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserData", reflect.TypeOf((*MockDataRequestRepository)(nil).GetUserData), ctx, userId)
+}
+
+// MockUserDataArchiver is a mock of UserDataArchiver interface.
+type MockUserDataArchiver struct {
+	ctrl     *gomock.Controller
+	recorder *MockUserDataArchiverMockRecorder
+	isgomock struct{}
+}
+
+// MockUserDataArchiverMockRecorder is the mock recorder for MockUserDataArchiver.
+type MockUserDataArchiverMockRecorder struct {
+	mock *MockUserDataArchiver
+}
+
+// NewMockUserDataArchiver creates a new mock instance.
+func NewMockUserDataArchiver(ctrl *gomock.Controller) *MockUserDataArchiver {
+	mock := &MockUserDataArchiver{ctrl: ctrl}
+	mock.recorder = &MockUserDataArchiverMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUserDataArchiver) EXPECT() *MockUserDataArchiverMockRecorder {
+	return m.recorder
+}
+
+// Archive mocks base method.
+func (m *MockUserDataArchiver) Archive(data *domain.UserData) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Archive", data)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Archive indicates an expected call of Archive.
+func (mr *MockUserDataArchiverMockRecorder) Archive(data any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Archive", reflect.TypeOf((*MockUserDataArchiver)(nil).Archive), data)
+}
+
+// MockDataRequestService is a mock of DataRequestService interface.
+type MockDataRequestService struct {
+	ctrl     *gomock.Controller
+	recorder *MockDataRequestServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockDataRequestServiceMockRecorder is the mock recorder for MockDataRequestService.
+type MockDataRequestServiceMockRecorder struct {
+	mock *MockDataRequestService
+}
+
+// NewMockDataRequestService creates a new mock instance.
+func NewMockDataRequestService(ctrl *gomock.Controller) *MockDataRequestService {
+	mock := &MockDataRequestService{ctrl: ctrl}
+	mock.recorder = &MockDataRequestServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDataRequestService) EXPECT() *MockDataRequestServiceMockRecorder {
+	return m.recorder
+}
+
+// GetDataExportArchive mocks base method.
+func (m *MockDataRequestService) GetDataExportArchive(ctx context.Context, token *domain.Token, id uuid.UUID) (*domain.DataRequest, []byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDataExportArchive", ctx, token, id)
+	ret0, _ := ret[0].(*domain.DataRequest)
+	ret1, _ := ret[1].([]byte)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetDataExportArchive indicates an expected call of GetDataExportArchive.
+func (mr *MockDataRequestServiceMockRecorder) GetDataExportArchive(ctx, token, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDataExportArchive", reflect.TypeOf((*MockDataRequestService)(nil).GetDataExportArchive), ctx, token, id)
+}
+
+// GetDataRequest mocks base method.
+func (m *MockDataRequestService) GetDataRequest(ctx context.Context, token *domain.Token, id uuid.UUID) (*domain.DataRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDataRequest", ctx, token, id)
+	ret0, _ := ret[0].(*domain.DataRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDataRequest indicates an expected call of GetDataRequest.
+func (mr *MockDataRequestServiceMockRecorder) GetDataRequest(ctx, token, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDataRequest", reflect.TypeOf((*MockDataRequestService)(nil).GetDataRequest), ctx, token, id)
+}
+
+// ProcessDataRequests mocks base method.
+func (m *MockDataRequestService) ProcessDataRequests(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProcessDataRequests", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ProcessDataRequests indicates an expected call of ProcessDataRequests.
+func (mr *MockDataRequestServiceMockRecorder) ProcessDataRequests(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProcessDataRequests", reflect.TypeOf((*MockDataRequestService)(nil).ProcessDataRequests), ctx)
+}
+
+// RequestDataExport mocks base method.
+func (m *MockDataRequestService) RequestDataExport(ctx context.Context, token *domain.Token, userId uuid.UUID) (*domain.DataRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RequestDataExport", ctx, token, userId)
+	ret0, _ := ret[0].(*domain.DataRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RequestDataExport indicates an expected call of RequestDataExport.
+func (mr *MockDataRequestServiceMockRecorder) RequestDataExport(ctx, token, userId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RequestDataExport", reflect.TypeOf((*MockDataRequestService)(nil).RequestDataExport), ctx, token, userId)
+}
+
+// RequestErasure mocks base method.
+func (m *MockDataRequestService) RequestErasure(ctx context.Context, token *domain.Token, userId uuid.UUID) (*domain.DataRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RequestErasure", ctx, token, userId)
+	ret0, _ := ret[0].(*domain.DataRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RequestErasure indicates an expected call of RequestErasure.
+func (mr *MockDataRequestServiceMockRecorder) RequestErasure(ctx, token, userId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RequestErasure", reflect.TypeOf((*MockDataRequestService)(nil).RequestErasure), ctx, token, userId)
+}
@@ -0,0 +1,177 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/core/port/event.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/core/port/event.go -destination=internal/core/port/mock/event.go -package=mock
+//
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+	domain "shop-api-go/internal/core/domain"
+	time "time"
+
+	uuid "github.com/google/uuid"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockOutboxRepository is a mock of OutboxRepository interface.
+type MockOutboxRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockOutboxRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockOutboxRepositoryMockRecorder is the mock recorder for MockOutboxRepository.
+type MockOutboxRepositoryMockRecorder struct {
+	mock *MockOutboxRepository
+}
+
+// NewMockOutboxRepository creates a new mock instance.
+func NewMockOutboxRepository(ctrl *gomock.Controller) *MockOutboxRepository {
+	mock := &MockOutboxRepository{ctrl: ctrl}
+	mock.recorder = &MockOutboxRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOutboxRepository) EXPECT() *MockOutboxRepositoryMockRecorder {
+	return m.recorder
+}
+
+// ClaimEvents mocks base method.
+func (m *MockOutboxRepository) ClaimEvents(ctx context.Context, limit int) ([]domain.Event, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimEvents", ctx, limit)
+	ret0, _ := ret[0].([]domain.Event)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimEvents indicates an expected call of ClaimEvents.
+func (mr *MockOutboxRepositoryMockRecorder) ClaimEvents(ctx, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimEvents", reflect.TypeOf((*MockOutboxRepository)(nil).ClaimEvents), ctx, limit)
+}
+
+// DeletePublishedEvents mocks base method.
+func (m *MockOutboxRepository) DeletePublishedEvents(retention time.Duration) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeletePublishedEvents", retention)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeletePublishedEvents indicates an expected call of DeletePublishedEvents.
+func (mr *MockOutboxRepositoryMockRecorder) DeletePublishedEvents(retention any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePublishedEvents", reflect.TypeOf((*MockOutboxRepository)(nil).DeletePublishedEvents), retention)
+}
+
+// MarkEventFailed mocks base method.
+func (m *MockOutboxRepository) MarkEventFailed(ctx context.Context, id uuid.UUID, nextAttemptAt time.Time, reason string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkEventFailed", ctx, id, nextAttemptAt, reason)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkEventFailed indicates an expected call of MarkEventFailed.
+func (mr *MockOutboxRepositoryMockRecorder) MarkEventFailed(ctx, id, nextAttemptAt, reason any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkEventFailed", reflect.TypeOf((*MockOutboxRepository)(nil).MarkEventFailed), ctx, id, nextAttemptAt, reason)
+}
+
+// MarkEventPublished mocks base method.
+func (m *MockOutboxRepository) MarkEventPublished(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkEventPublished", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkEventPublished indicates an expected call of MarkEventPublished.
+func (mr *MockOutboxRepositoryMockRecorder) MarkEventPublished(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkEventPublished", reflect.TypeOf((*MockOutboxRepository)(nil).MarkEventPublished), ctx, id)
+}
+
+// MockEventPublisher is a mock of EventPublisher interface.
+type MockEventPublisher struct {
+	ctrl     *gomock.Controller
+	recorder *MockEventPublisherMockRecorder
+	isgomock struct{}
+}
+
+// MockEventPublisherMockRecorder is the mock recorder for MockEventPublisher.
+type MockEventPublisherMockRecorder struct {
+	mock *MockEventPublisher
+}
+
+// NewMockEventPublisher creates a new mock instance.
+func NewMockEventPublisher(ctrl *gomock.Controller) *MockEventPublisher {
+	mock := &MockEventPublisher{ctrl: ctrl}
+	mock.recorder = &MockEventPublisherMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockEventPublisher) EXPECT() *MockEventPublisherMockRecorder {
+	return m.recorder
+}
+
+// Publish mocks base method.
+func (m *MockEventPublisher) Publish(ctx context.Context, event *domain.Event) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Publish", ctx, event)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Publish indicates an expected call of Publish.
+func (mr *MockEventPublisherMockRecorder) Publish(ctx, event any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Publish", reflect.TypeOf((*MockEventPublisher)(nil).Publish), ctx, event)
+}
+
+// MockOutboxService is a mock of OutboxService interface.
+type MockOutboxService struct {
+	ctrl     *gomock.Controller
+	recorder *MockOutboxServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockOutboxServiceMockRecorder is the mock recorder for MockOutboxService.
+type MockOutboxServiceMockRecorder struct {
+	mock *MockOutboxService
+}
+
+// NewMockOutboxService creates a new mock instance.
+func NewMockOutboxService(ctrl *gomock.Controller) *MockOutboxService {
+	mock := &MockOutboxService{ctrl: ctrl}
+	mock.recorder = &MockOutboxServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOutboxService) EXPECT() *MockOutboxServiceMockRecorder {
+	return m.recorder
+}
+
+// RelayEvents mocks base method.
+func (m *MockOutboxService) RelayEvents(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RelayEvents", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RelayEvents indicates an expected call of RelayEvents.
+func (mr *MockOutboxServiceMockRecorder) RelayEvents(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RelayEvents", reflect.TypeOf((*MockOutboxService)(nil).RelayEvents), ctx)
+}
@@ -0,0 +1,182 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/core/port/identity.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/core/port/identity.go -destination=internal/core/port/mock/identity.go -package=mock
+//
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+	domain "shop-api-go/internal/core/domain"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockIdentityProvider is a mock of IdentityProvider interface.
+type MockIdentityProvider struct {
+	ctrl     *gomock.Controller
+	recorder *MockIdentityProviderMockRecorder
+	isgomock struct{}
+}
+
+// MockIdentityProviderMockRecorder is the mock recorder for MockIdentityProvider.
+type MockIdentityProviderMockRecorder struct {
+	mock *MockIdentityProvider
+}
+
+// NewMockIdentityProvider creates a new mock instance.
+func NewMockIdentityProvider(ctrl *gomock.Controller) *MockIdentityProvider {
+	mock := &MockIdentityProvider{ctrl: ctrl}
+	mock.recorder = &MockIdentityProviderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIdentityProvider) EXPECT() *MockIdentityProviderMockRecorder {
+	return m.recorder
+}
+
+// Authorize mocks base method.
+func (m *MockIdentityProvider) Authorize(ctx context.Context, provider string) (*domain.OAuthAuthorization, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Authorize", ctx, provider)
+	ret0, _ := ret[0].(*domain.OAuthAuthorization)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Authorize indicates an expected call of Authorize.
+func (mr *MockIdentityProviderMockRecorder) Authorize(ctx, provider any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Authorize", reflect.TypeOf((*MockIdentityProvider)(nil).Authorize), ctx, provider)
+}
+
+// Exchange mocks base method.
+func (m *MockIdentityProvider) Exchange(ctx context.Context, state *domain.OAuthState, code string) (*domain.ExternalIdentity, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Exchange", ctx, state, code)
+	ret0, _ := ret[0].(*domain.ExternalIdentity)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Exchange indicates an expected call of Exchange.
+func (mr *MockIdentityProviderMockRecorder) Exchange(ctx, state, code any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Exchange", reflect.TypeOf((*MockIdentityProvider)(nil).Exchange), ctx, state, code)
+}
+
+// MockIdentityRepository is a mock of IdentityRepository interface.
+type MockIdentityRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockIdentityRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockIdentityRepositoryMockRecorder is the mock recorder for MockIdentityRepository.
+type MockIdentityRepositoryMockRecorder struct {
+	mock *MockIdentityRepository
+}
+
+// NewMockIdentityRepository creates a new mock instance.
+func NewMockIdentityRepository(ctrl *gomock.Controller) *MockIdentityRepository {
+	mock := &MockIdentityRepository{ctrl: ctrl}
+	mock.recorder = &MockIdentityRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIdentityRepository) EXPECT() *MockIdentityRepositoryMockRecorder {
+	return m.recorder
+}
+
+// AddOAuthState mocks base method.
+func (m *MockIdentityRepository) AddOAuthState(ctx context.Context, state *domain.OAuthState) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddOAuthState", ctx, state)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddOAuthState indicates an expected call of AddOAuthState.
+func (mr *MockIdentityRepositoryMockRecorder) AddOAuthState(ctx, state any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddOAuthState", reflect.TypeOf((*MockIdentityRepository)(nil).AddOAuthState), ctx, state)
+}
+
+// AddUserIdentity mocks base method.
+func (m *MockIdentityRepository) AddUserIdentity(ctx context.Context, identity *domain.UserIdentity) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddUserIdentity", ctx, identity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddUserIdentity indicates an expected call of AddUserIdentity.
+func (mr *MockIdentityRepositoryMockRecorder) AddUserIdentity(ctx, identity any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddUserIdentity", reflect.TypeOf((*MockIdentityRepository)(nil).AddUserIdentity), ctx, identity)
+}
+
+// AddUserWithIdentity mocks base method.
+func (m *MockIdentityRepository) AddUserWithIdentity(ctx context.Context, user *domain.User, identity *domain.UserIdentity, outbox []*domain.Event) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddUserWithIdentity", ctx, user, identity, outbox)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddUserWithIdentity indicates an expected call of AddUserWithIdentity.
+func (mr *MockIdentityRepositoryMockRecorder) AddUserWithIdentity(ctx, user, identity, outbox any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddUserWithIdentity", reflect.TypeOf((*MockIdentityRepository)(nil).AddUserWithIdentity), ctx, user, identity, outbox)
+}
+
+// ConsumeOAuthState mocks base method.
+func (m *MockIdentityRepository) ConsumeOAuthState(ctx context.Context, state string) (*domain.OAuthState, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConsumeOAuthState", ctx, state)
+	ret0, _ := ret[0].(*domain.OAuthState)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ConsumeOAuthState indicates an expected call of ConsumeOAuthState.
+func (mr *MockIdentityRepositoryMockRecorder) ConsumeOAuthState(ctx, state any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConsumeOAuthState", reflect.TypeOf((*MockIdentityRepository)(nil).ConsumeOAuthState), ctx, state)
+}
+
+// DeleteExpiredOAuthStates mocks base method.
+func (m *MockIdentityRepository) DeleteExpiredOAuthStates() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteExpiredOAuthStates")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteExpiredOAuthStates indicates an expected call of DeleteExpiredOAuthStates.
+func (mr *MockIdentityRepositoryMockRecorder) DeleteExpiredOAuthStates() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteExpiredOAuthStates", reflect.TypeOf((*MockIdentityRepository)(nil).DeleteExpiredOAuthStates))
+}
+
+// GetUserIdentity mocks base method.
+func (m *MockIdentityRepository) GetUserIdentity(ctx context.Context, provider, subject string) (*domain.UserIdentity, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserIdentity", ctx, provider, subject)
+	ret0, _ := ret[0].(*domain.UserIdentity)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserIdentity indicates an expected call of GetUserIdentity.
+func (mr *MockIdentityRepositoryMockRecorder) GetUserIdentity(ctx, provider, subject any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserIdentity", reflect.TypeOf((*MockIdentityRepository)(nil).GetUserIdentity), ctx, provider, subject)
+}
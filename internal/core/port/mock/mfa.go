@@ -0,0 +1,337 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/core/port/mfa.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/core/port/mfa.go -destination=internal/core/port/mock/mfa.go -package=mock
+//
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+	domain "shop-api-go/internal/core/domain"
+
+	uuid "github.com/google/uuid"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockMFAProvider is a mock of MFAProvider interface.
+type MockMFAProvider struct {
+	ctrl     *gomock.Controller
+	recorder *MockMFAProviderMockRecorder
+	isgomock struct{}
+}
+
+// MockMFAProviderMockRecorder is the mock recorder for MockMFAProvider.
+type MockMFAProviderMockRecorder struct {
+	mock *MockMFAProvider
+}
+
+// NewMockMFAProvider creates a new mock instance.
+func NewMockMFAProvider(ctrl *gomock.Controller) *MockMFAProvider {
+	mock := &MockMFAProvider{ctrl: ctrl}
+	mock.recorder = &MockMFAProviderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMFAProvider) EXPECT() *MockMFAProviderMockRecorder {
+	return m.recorder
+}
+
+// GenerateRecoveryCodes mocks base method.
+func (m *MockMFAProvider) GenerateRecoveryCodes(count int) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GenerateRecoveryCodes", count)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GenerateRecoveryCodes indicates an expected call of GenerateRecoveryCodes.
+func (mr *MockMFAProviderMockRecorder) GenerateRecoveryCodes(count any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GenerateRecoveryCodes", reflect.TypeOf((*MockMFAProvider)(nil).GenerateRecoveryCodes), count)
+}
+
+// GenerateSecret mocks base method.
+func (m *MockMFAProvider) GenerateSecret() (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GenerateSecret")
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GenerateSecret indicates an expected call of GenerateSecret.
+func (mr *MockMFAProviderMockRecorder) GenerateSecret() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GenerateSecret", reflect.TypeOf((*MockMFAProvider)(nil).GenerateSecret))
+}
+
+// HashRecoveryCode mocks base method.
+func (m *MockMFAProvider) HashRecoveryCode(code string) string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HashRecoveryCode", code)
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// HashRecoveryCode indicates an expected call of HashRecoveryCode.
+func (mr *MockMFAProviderMockRecorder) HashRecoveryCode(code any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HashRecoveryCode", reflect.TypeOf((*MockMFAProvider)(nil).HashRecoveryCode), code)
+}
+
+// ProvisioningURI mocks base method.
+func (m *MockMFAProvider) ProvisioningURI(accountName, secret string) string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProvisioningURI", accountName, secret)
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// ProvisioningURI indicates an expected call of ProvisioningURI.
+func (mr *MockMFAProviderMockRecorder) ProvisioningURI(accountName, secret any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProvisioningURI", reflect.TypeOf((*MockMFAProvider)(nil).ProvisioningURI), accountName, secret)
+}
+
+// ValidateCode mocks base method.
+func (m *MockMFAProvider) ValidateCode(code, secret string) (int64, bool) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ValidateCode", code, secret)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(bool)
+	return ret0, ret1
+}
+
+// ValidateCode indicates an expected call of ValidateCode.
+func (mr *MockMFAProviderMockRecorder) ValidateCode(code, secret any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValidateCode", reflect.TypeOf((*MockMFAProvider)(nil).ValidateCode), code, secret)
+}
+
+// MockMFARepository is a mock of MFARepository interface.
+type MockMFARepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockMFARepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockMFARepositoryMockRecorder is the mock recorder for MockMFARepository.
+type MockMFARepositoryMockRecorder struct {
+	mock *MockMFARepository
+}
+
+// NewMockMFARepository creates a new mock instance.
+func NewMockMFARepository(ctrl *gomock.Controller) *MockMFARepository {
+	mock := &MockMFARepository{ctrl: ctrl}
+	mock.recorder = &MockMFARepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMFARepository) EXPECT() *MockMFARepositoryMockRecorder {
+	return m.recorder
+}
+
+// DeleteUserMFA mocks base method.
+func (m *MockMFARepository) DeleteUserMFA(ctx context.Context, userId uuid.UUID, event *domain.AuditEvent) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteUserMFA", ctx, userId, event)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteUserMFA indicates an expected call of DeleteUserMFA.
+func (mr *MockMFARepositoryMockRecorder) DeleteUserMFA(ctx, userId, event any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUserMFA", reflect.TypeOf((*MockMFARepository)(nil).DeleteUserMFA), ctx, userId, event)
+}
+
+// EnableUserMFA mocks base method.
+func (m *MockMFARepository) EnableUserMFA(ctx context.Context, userId uuid.UUID, lastUsedStep int64, codes []domain.RecoveryCode, event *domain.AuditEvent) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnableUserMFA", ctx, userId, lastUsedStep, codes, event)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// EnableUserMFA indicates an expected call of EnableUserMFA.
+func (mr *MockMFARepositoryMockRecorder) EnableUserMFA(ctx, userId, lastUsedStep, codes, event any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnableUserMFA", reflect.TypeOf((*MockMFARepository)(nil).EnableUserMFA), ctx, userId, lastUsedStep, codes, event)
+}
+
+// GetRoleMFARequirements mocks base method.
+func (m *MockMFARepository) GetRoleMFARequirements(ctx context.Context) ([]domain.RoleMFARequirement, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRoleMFARequirements", ctx)
+	ret0, _ := ret[0].([]domain.RoleMFARequirement)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRoleMFARequirements indicates an expected call of GetRoleMFARequirements.
+func (mr *MockMFARepositoryMockRecorder) GetRoleMFARequirements(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRoleMFARequirements", reflect.TypeOf((*MockMFARepository)(nil).GetRoleMFARequirements), ctx)
+}
+
+// GetUserMFA mocks base method.
+func (m *MockMFARepository) GetUserMFA(ctx context.Context, userId uuid.UUID) (*domain.UserMFA, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserMFA", ctx, userId)
+	ret0, _ := ret[0].(*domain.UserMFA)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserMFA indicates an expected call of GetUserMFA.
+func (mr *MockMFARepositoryMockRecorder) GetUserMFA(ctx, userId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserMFA", reflect.TypeOf((*MockMFARepository)(nil).GetUserMFA), ctx, userId)
+}
+
+// IsMFARequired mocks base method.
+func (m *MockMFARepository) IsMFARequired(ctx context.Context, role domain.UserRole) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsMFARequired", ctx, role)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsMFARequired indicates an expected call of IsMFARequired.
+func (mr *MockMFARepositoryMockRecorder) IsMFARequired(ctx, role any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsMFARequired", reflect.TypeOf((*MockMFARepository)(nil).IsMFARequired), ctx, role)
+}
+
+// SaveUserMFA mocks base method.
+func (m *MockMFARepository) SaveUserMFA(ctx context.Context, mfa *domain.UserMFA) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveUserMFA", ctx, mfa)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveUserMFA indicates an expected call of SaveUserMFA.
+func (mr *MockMFARepositoryMockRecorder) SaveUserMFA(ctx, mfa any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveUserMFA", reflect.TypeOf((*MockMFARepository)(nil).SaveUserMFA), ctx, mfa)
+}
+
+// SetRoleMFARequirement mocks base method.
+func (m *MockMFARepository) SetRoleMFARequirement(ctx context.Context, requirement *domain.RoleMFARequirement, event *domain.AuditEvent) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetRoleMFARequirement", ctx, requirement, event)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetRoleMFARequirement indicates an expected call of SetRoleMFARequirement.
+func (mr *MockMFARepositoryMockRecorder) SetRoleMFARequirement(ctx, requirement, event any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetRoleMFARequirement", reflect.TypeOf((*MockMFARepository)(nil).SetRoleMFARequirement), ctx, requirement, event)
+}
+
+// UpdateLastUsedStep mocks base method.
+func (m *MockMFARepository) UpdateLastUsedStep(ctx context.Context, userId uuid.UUID, step int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateLastUsedStep", ctx, userId, step)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateLastUsedStep indicates an expected call of UpdateLastUsedStep.
+func (mr *MockMFARepositoryMockRecorder) UpdateLastUsedStep(ctx, userId, step any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateLastUsedStep", reflect.TypeOf((*MockMFARepository)(nil).UpdateLastUsedStep), ctx, userId, step)
+}
+
+// UseRecoveryCode mocks base method.
+func (m *MockMFARepository) UseRecoveryCode(ctx context.Context, userId uuid.UUID, codeHash string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UseRecoveryCode", ctx, userId, codeHash)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UseRecoveryCode indicates an expected call of UseRecoveryCode.
+func (mr *MockMFARepositoryMockRecorder) UseRecoveryCode(ctx, userId, codeHash any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UseRecoveryCode", reflect.TypeOf((*MockMFARepository)(nil).UseRecoveryCode), ctx, userId, codeHash)
+}
+
+// MockMFAService is a mock of MFAService interface.
+type MockMFAService struct {
+	ctrl     *gomock.Controller
+	recorder *MockMFAServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockMFAServiceMockRecorder is the mock recorder for MockMFAService.
+type MockMFAServiceMockRecorder struct {
+	mock *MockMFAService
+}
+
+// NewMockMFAService creates a new mock instance.
+func NewMockMFAService(ctrl *gomock.Controller) *MockMFAService {
+	mock := &MockMFAService{ctrl: ctrl}
+	mock.recorder = &MockMFAServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMFAService) EXPECT() *MockMFAServiceMockRecorder {
+	return m.recorder
+}
+
+// ConfirmEnrollment mocks base method.
+func (m *MockMFAService) ConfirmEnrollment(ctx context.Context, credentials *domain.MFACredentials) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConfirmEnrollment", ctx, credentials)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ConfirmEnrollment indicates an expected call of ConfirmEnrollment.
+func (mr *MockMFAServiceMockRecorder) ConfirmEnrollment(ctx, credentials any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConfirmEnrollment", reflect.TypeOf((*MockMFAService)(nil).ConfirmEnrollment), ctx, credentials)
+}
+
+// Disable mocks base method.
+func (m *MockMFAService) Disable(ctx context.Context, credentials *domain.MFACredentials) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Disable", ctx, credentials)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Disable indicates an expected call of Disable.
+func (mr *MockMFAServiceMockRecorder) Disable(ctx, credentials any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Disable", reflect.TypeOf((*MockMFAService)(nil).Disable), ctx, credentials)
+}
+
+// Enroll mocks base method.
+func (m *MockMFAService) Enroll(ctx context.Context, credentials *domain.MFACredentials) (*domain.MFAEnrollment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Enroll", ctx, credentials)
+	ret0, _ := ret[0].(*domain.MFAEnrollment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Enroll indicates an expected call of Enroll.
+func (mr *MockMFAServiceMockRecorder) Enroll(ctx, credentials any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Enroll", reflect.TypeOf((*MockMFAService)(nil).Enroll), ctx, credentials)
+}
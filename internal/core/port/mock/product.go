@@ -0,0 +1,307 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/core/port/product.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/core/port/product.go -destination=internal/core/port/mock/product.go -package=mock
+//
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+	domain "shop-api-go/internal/core/domain"
+
+	uuid "github.com/google/uuid"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockProductRepository is a mock of ProductRepository interface.
+type MockProductRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockProductRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockProductRepositoryMockRecorder is the mock recorder for MockProductRepository.
+type MockProductRepositoryMockRecorder struct {
+	mock *MockProductRepository
+}
+
+// NewMockProductRepository creates a new mock instance.
+func NewMockProductRepository(ctrl *gomock.Controller) *MockProductRepository {
+	mock := &MockProductRepository{ctrl: ctrl}
+	mock.recorder = &MockProductRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockProductRepository) EXPECT() *MockProductRepositoryMockRecorder {
+	return m.recorder
+}
+
+// GetCategoriesByIds mocks base method.
+func (m *MockProductRepository) GetCategoriesByIds(ctx context.Context, ids []uuid.UUID) (map[uuid.UUID]domain.Category, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCategoriesByIds", ctx, ids)
+	ret0, _ := ret[0].(map[uuid.UUID]domain.Category)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCategoriesByIds indicates an expected call of GetCategoriesByIds.
+func (mr *MockProductRepositoryMockRecorder) GetCategoriesByIds(ctx, ids any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCategoriesByIds", reflect.TypeOf((*MockProductRepository)(nil).GetCategoriesByIds), ctx, ids)
+}
+
+// GetCategoriesBySectionIds mocks base method.
+func (m *MockProductRepository) GetCategoriesBySectionIds(ctx context.Context, sectionIds []uuid.UUID) (map[uuid.UUID][]domain.Category, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCategoriesBySectionIds", ctx, sectionIds)
+	ret0, _ := ret[0].(map[uuid.UUID][]domain.Category)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCategoriesBySectionIds indicates an expected call of GetCategoriesBySectionIds.
+func (mr *MockProductRepositoryMockRecorder) GetCategoriesBySectionIds(ctx, sectionIds any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCategoriesBySectionIds", reflect.TypeOf((*MockProductRepository)(nil).GetCategoriesBySectionIds), ctx, sectionIds)
+}
+
+// GetCategorySections mocks base method.
+func (m *MockProductRepository) GetCategorySections(ctx context.Context) ([]domain.CategorySection, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCategorySections", ctx)
+	ret0, _ := ret[0].([]domain.CategorySection)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCategorySections indicates an expected call of GetCategorySections.
+func (mr *MockProductRepositoryMockRecorder) GetCategorySections(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCategorySections", reflect.TypeOf((*MockProductRepository)(nil).GetCategorySections), ctx)
+}
+
+// GetCategorySectionsByIds mocks base method.
+func (m *MockProductRepository) GetCategorySectionsByIds(ctx context.Context, ids []uuid.UUID) (map[uuid.UUID]domain.CategorySection, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCategorySectionsByIds", ctx, ids)
+	ret0, _ := ret[0].(map[uuid.UUID]domain.CategorySection)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCategorySectionsByIds indicates an expected call of GetCategorySectionsByIds.
+func (mr *MockProductRepositoryMockRecorder) GetCategorySectionsByIds(ctx, ids any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCategorySectionsByIds", reflect.TypeOf((*MockProductRepository)(nil).GetCategorySectionsByIds), ctx, ids)
+}
+
+// GetProductById mocks base method.
+func (m *MockProductRepository) GetProductById(ctx context.Context, id uuid.UUID) (*domain.Product, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProductById", ctx, id)
+	ret0, _ := ret[0].(*domain.Product)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProductById indicates an expected call of GetProductById.
+func (mr *MockProductRepositoryMockRecorder) GetProductById(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProductById", reflect.TypeOf((*MockProductRepository)(nil).GetProductById), ctx, id)
+}
+
+// GetProducts mocks base method.
+func (m *MockProductRepository) GetProducts(ctx context.Context, filter *domain.ProductFilter) ([]domain.Product, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProducts", ctx, filter)
+	ret0, _ := ret[0].([]domain.Product)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProducts indicates an expected call of GetProducts.
+func (mr *MockProductRepositoryMockRecorder) GetProducts(ctx, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProducts", reflect.TypeOf((*MockProductRepository)(nil).GetProducts), ctx, filter)
+}
+
+// GetSubcategoriesByCategoryIds mocks base method.
+func (m *MockProductRepository) GetSubcategoriesByCategoryIds(ctx context.Context, categoryIds []uuid.UUID) (map[uuid.UUID][]domain.Subcategory, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSubcategoriesByCategoryIds", ctx, categoryIds)
+	ret0, _ := ret[0].(map[uuid.UUID][]domain.Subcategory)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSubcategoriesByCategoryIds indicates an expected call of GetSubcategoriesByCategoryIds.
+func (mr *MockProductRepositoryMockRecorder) GetSubcategoriesByCategoryIds(ctx, categoryIds any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSubcategoriesByCategoryIds", reflect.TypeOf((*MockProductRepository)(nil).GetSubcategoriesByCategoryIds), ctx, categoryIds)
+}
+
+// GetSubcategoriesByProductIds mocks base method.
+func (m *MockProductRepository) GetSubcategoriesByProductIds(ctx context.Context, productIds []uuid.UUID) (map[uuid.UUID][]domain.Subcategory, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSubcategoriesByProductIds", ctx, productIds)
+	ret0, _ := ret[0].(map[uuid.UUID][]domain.Subcategory)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSubcategoriesByProductIds indicates an expected call of GetSubcategoriesByProductIds.
+func (mr *MockProductRepositoryMockRecorder) GetSubcategoriesByProductIds(ctx, productIds any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSubcategoriesByProductIds", reflect.TypeOf((*MockProductRepository)(nil).GetSubcategoriesByProductIds), ctx, productIds)
+}
+
+// MockProductService is a mock of ProductService interface.
+type MockProductService struct {
+	ctrl     *gomock.Controller
+	recorder *MockProductServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockProductServiceMockRecorder is the mock recorder for MockProductService.
+type MockProductServiceMockRecorder struct {
+	mock *MockProductService
+}
+
+// NewMockProductService creates a new mock instance.
+func NewMockProductService(ctrl *gomock.Controller) *MockProductService {
+	mock := &MockProductService{ctrl: ctrl}
+	mock.recorder = &MockProductServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockProductService) EXPECT() *MockProductServiceMockRecorder {
+	return m.recorder
+}
+
+// GetCategoriesByIds mocks base method.
+func (m *MockProductService) GetCategoriesByIds(ctx context.Context, ids []uuid.UUID) (map[uuid.UUID]domain.Category, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCategoriesByIds", ctx, ids)
+	ret0, _ := ret[0].(map[uuid.UUID]domain.Category)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCategoriesByIds indicates an expected call of GetCategoriesByIds.
+func (mr *MockProductServiceMockRecorder) GetCategoriesByIds(ctx, ids any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCategoriesByIds", reflect.TypeOf((*MockProductService)(nil).GetCategoriesByIds), ctx, ids)
+}
+
+// GetCategorySections mocks base method.
+func (m *MockProductService) GetCategorySections(ctx context.Context) ([]domain.CategorySection, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCategorySections", ctx)
+	ret0, _ := ret[0].([]domain.CategorySection)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCategorySections indicates an expected call of GetCategorySections.
+func (mr *MockProductServiceMockRecorder) GetCategorySections(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCategorySections", reflect.TypeOf((*MockProductService)(nil).GetCategorySections), ctx)
+}
+
+// GetCategorySectionsByIds mocks base method.
+func (m *MockProductService) GetCategorySectionsByIds(ctx context.Context, ids []uuid.UUID) (map[uuid.UUID]domain.CategorySection, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCategorySectionsByIds", ctx, ids)
+	ret0, _ := ret[0].(map[uuid.UUID]domain.CategorySection)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCategorySectionsByIds indicates an expected call of GetCategorySectionsByIds.
+func (mr *MockProductServiceMockRecorder) GetCategorySectionsByIds(ctx, ids any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCategorySectionsByIds", reflect.TypeOf((*MockProductService)(nil).GetCategorySectionsByIds), ctx, ids)
+}
+
+// GetCategorySubcategories mocks base method.
+func (m *MockProductService) GetCategorySubcategories(ctx context.Context, categoryIds []uuid.UUID) (map[uuid.UUID][]domain.Subcategory, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCategorySubcategories", ctx, categoryIds)
+	ret0, _ := ret[0].(map[uuid.UUID][]domain.Subcategory)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCategorySubcategories indicates an expected call of GetCategorySubcategories.
+func (mr *MockProductServiceMockRecorder) GetCategorySubcategories(ctx, categoryIds any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCategorySubcategories", reflect.TypeOf((*MockProductService)(nil).GetCategorySubcategories), ctx, categoryIds)
+}
+
+// GetProduct mocks base method.
+func (m *MockProductService) GetProduct(ctx context.Context, id uuid.UUID) (*domain.Product, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProduct", ctx, id)
+	ret0, _ := ret[0].(*domain.Product)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProduct indicates an expected call of GetProduct.
+func (mr *MockProductServiceMockRecorder) GetProduct(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProduct", reflect.TypeOf((*MockProductService)(nil).GetProduct), ctx, id)
+}
+
+// GetProductSubcategories mocks base method.
+func (m *MockProductService) GetProductSubcategories(ctx context.Context, productIds []uuid.UUID) (map[uuid.UUID][]domain.Subcategory, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProductSubcategories", ctx, productIds)
+	ret0, _ := ret[0].(map[uuid.UUID][]domain.Subcategory)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProductSubcategories indicates an expected call of GetProductSubcategories.
+func (mr *MockProductServiceMockRecorder) GetProductSubcategories(ctx, productIds any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProductSubcategories", reflect.TypeOf((*MockProductService)(nil).GetProductSubcategories), ctx, productIds)
+}
+
+// GetProducts mocks base method.
+func (m *MockProductService) GetProducts(ctx context.Context, filter *domain.ProductFilter) ([]domain.Product, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProducts", ctx, filter)
+	ret0, _ := ret[0].([]domain.Product)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProducts indicates an expected call of GetProducts.
+func (mr *MockProductServiceMockRecorder) GetProducts(ctx, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProducts", reflect.TypeOf((*MockProductService)(nil).GetProducts), ctx, filter)
+}
+
+// GetSectionCategories mocks base method.
+func (m *MockProductService) GetSectionCategories(ctx context.Context, sectionIds []uuid.UUID) (map[uuid.UUID][]domain.Category, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSectionCategories", ctx, sectionIds)
+	ret0, _ := ret[0].(map[uuid.UUID][]domain.Category)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSectionCategories indicates an expected call of GetSectionCategories.
+func (mr *MockProductServiceMockRecorder) GetSectionCategories(ctx, sectionIds any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSectionCategories", reflect.TypeOf((*MockProductService)(nil).GetSectionCategories), ctx, sectionIds)
+}
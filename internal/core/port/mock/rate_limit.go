@@ -0,0 +1,72 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/core/port/rate_limit.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/core/port/rate_limit.go -destination=internal/core/port/mock/rate_limit.go -package=mock
+//
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+	domain "shop-api-go/internal/core/domain"
+	time "time"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockRateLimitStore is a mock of RateLimitStore interface.
+type MockRateLimitStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockRateLimitStoreMockRecorder
+	isgomock struct{}
+}
+
+// MockRateLimitStoreMockRecorder is the mock recorder for MockRateLimitStore.
+type MockRateLimitStoreMockRecorder struct {
+	mock *MockRateLimitStore
+}
+
+// NewMockRateLimitStore creates a new mock instance.
+func NewMockRateLimitStore(ctrl *gomock.Controller) *MockRateLimitStore {
+	mock := &MockRateLimitStore{ctrl: ctrl}
+	mock.recorder = &MockRateLimitStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRateLimitStore) EXPECT() *MockRateLimitStoreMockRecorder {
+	return m.recorder
+}
+
+// DeleteIdleBuckets mocks base method.
+func (m *MockRateLimitStore) DeleteIdleBuckets(idle time.Duration) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteIdleBuckets", idle)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteIdleBuckets indicates an expected call of DeleteIdleBuckets.
+func (mr *MockRateLimitStoreMockRecorder) DeleteIdleBuckets(idle any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteIdleBuckets", reflect.TypeOf((*MockRateLimitStore)(nil).DeleteIdleBuckets), idle)
+}
+
+// Take mocks base method.
+func (m *MockRateLimitStore) Take(ctx context.Context, key string, limit domain.RateLimit) (*domain.RateLimitResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Take", ctx, key, limit)
+	ret0, _ := ret[0].(*domain.RateLimitResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Take indicates an expected call of Take.
+func (mr *MockRateLimitStoreMockRecorder) Take(ctx, key, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Take", reflect.TypeOf((*MockRateLimitStore)(nil).Take), ctx, key, limit)
+}
@@ -0,0 +1,202 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/core/port/runtime_settings.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/core/port/runtime_settings.go -destination=internal/core/port/mock/runtime_settings.go -package=mock
+//
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+	domain "shop-api-go/internal/core/domain"
+	port "shop-api-go/internal/core/port"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockRuntimeSettingsStore is a mock of RuntimeSettingsStore interface.
+type MockRuntimeSettingsStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockRuntimeSettingsStoreMockRecorder
+	isgomock struct{}
+}
+
+// MockRuntimeSettingsStoreMockRecorder is the mock recorder for MockRuntimeSettingsStore.
+type MockRuntimeSettingsStoreMockRecorder struct {
+	mock *MockRuntimeSettingsStore
+}
+
+// NewMockRuntimeSettingsStore creates a new mock instance.
+func NewMockRuntimeSettingsStore(ctrl *gomock.Controller) *MockRuntimeSettingsStore {
+	mock := &MockRuntimeSettingsStore{ctrl: ctrl}
+	mock.recorder = &MockRuntimeSettingsStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRuntimeSettingsStore) EXPECT() *MockRuntimeSettingsStoreMockRecorder {
+	return m.recorder
+}
+
+// LoadSettings mocks base method.
+func (m *MockRuntimeSettingsStore) LoadSettings(ctx context.Context) (*domain.RuntimeSettingsUpdate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LoadSettings", ctx)
+	ret0, _ := ret[0].(*domain.RuntimeSettingsUpdate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LoadSettings indicates an expected call of LoadSettings.
+func (mr *MockRuntimeSettingsStoreMockRecorder) LoadSettings(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadSettings", reflect.TypeOf((*MockRuntimeSettingsStore)(nil).LoadSettings), ctx)
+}
+
+// SaveSettings mocks base method.
+func (m *MockRuntimeSettingsStore) SaveSettings(ctx context.Context, settings *domain.RuntimeSettings) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveSettings", ctx, settings)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveSettings indicates an expected call of SaveSettings.
+func (mr *MockRuntimeSettingsStoreMockRecorder) SaveSettings(ctx, settings any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveSettings", reflect.TypeOf((*MockRuntimeSettingsStore)(nil).SaveSettings), ctx, settings)
+}
+
+// MockRuntimeSettingsWatcher is a mock of RuntimeSettingsWatcher interface.
+type MockRuntimeSettingsWatcher struct {
+	ctrl     *gomock.Controller
+	recorder *MockRuntimeSettingsWatcherMockRecorder
+	isgomock struct{}
+}
+
+// MockRuntimeSettingsWatcherMockRecorder is the mock recorder for MockRuntimeSettingsWatcher.
+type MockRuntimeSettingsWatcherMockRecorder struct {
+	mock *MockRuntimeSettingsWatcher
+}
+
+// NewMockRuntimeSettingsWatcher creates a new mock instance.
+func NewMockRuntimeSettingsWatcher(ctrl *gomock.Controller) *MockRuntimeSettingsWatcher {
+	mock := &MockRuntimeSettingsWatcher{ctrl: ctrl}
+	mock.recorder = &MockRuntimeSettingsWatcherMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRuntimeSettingsWatcher) EXPECT() *MockRuntimeSettingsWatcherMockRecorder {
+	return m.recorder
+}
+
+// ApplySettings mocks base method.
+func (m *MockRuntimeSettingsWatcher) ApplySettings(settings *domain.RuntimeSettings) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "ApplySettings", settings)
+}
+
+// ApplySettings indicates an expected call of ApplySettings.
+func (mr *MockRuntimeSettingsWatcherMockRecorder) ApplySettings(settings any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApplySettings", reflect.TypeOf((*MockRuntimeSettingsWatcher)(nil).ApplySettings), settings)
+}
+
+// MockRuntimeSettingsService is a mock of RuntimeSettingsService interface.
+type MockRuntimeSettingsService struct {
+	ctrl     *gomock.Controller
+	recorder *MockRuntimeSettingsServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockRuntimeSettingsServiceMockRecorder is the mock recorder for MockRuntimeSettingsService.
+type MockRuntimeSettingsServiceMockRecorder struct {
+	mock *MockRuntimeSettingsService
+}
+
+// NewMockRuntimeSettingsService creates a new mock instance.
+func NewMockRuntimeSettingsService(ctrl *gomock.Controller) *MockRuntimeSettingsService {
+	mock := &MockRuntimeSettingsService{ctrl: ctrl}
+	mock.recorder = &MockRuntimeSettingsServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRuntimeSettingsService) EXPECT() *MockRuntimeSettingsServiceMockRecorder {
+	return m.recorder
+}
+
+// Current mocks base method.
+func (m *MockRuntimeSettingsService) Current() *domain.RuntimeSettings {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Current")
+	ret0, _ := ret[0].(*domain.RuntimeSettings)
+	return ret0
+}
+
+// Current indicates an expected call of Current.
+func (mr *MockRuntimeSettingsServiceMockRecorder) Current() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Current", reflect.TypeOf((*MockRuntimeSettingsService)(nil).Current))
+}
+
+// GetSettings mocks base method.
+func (m *MockRuntimeSettingsService) GetSettings(ctx context.Context, token *domain.Token) (*domain.RuntimeSettings, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSettings", ctx, token)
+	ret0, _ := ret[0].(*domain.RuntimeSettings)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSettings indicates an expected call of GetSettings.
+func (mr *MockRuntimeSettingsServiceMockRecorder) GetSettings(ctx, token any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSettings", reflect.TypeOf((*MockRuntimeSettingsService)(nil).GetSettings), ctx, token)
+}
+
+// ReloadSettings mocks base method.
+func (m *MockRuntimeSettingsService) ReloadSettings(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReloadSettings", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReloadSettings indicates an expected call of ReloadSettings.
+func (mr *MockRuntimeSettingsServiceMockRecorder) ReloadSettings(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReloadSettings", reflect.TypeOf((*MockRuntimeSettingsService)(nil).ReloadSettings), ctx)
+}
+
+// UpdateSettings mocks base method.
+func (m *MockRuntimeSettingsService) UpdateSettings(ctx context.Context, token *domain.Token, update *domain.RuntimeSettingsUpdate) (*domain.RuntimeSettings, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateSettings", ctx, token, update)
+	ret0, _ := ret[0].(*domain.RuntimeSettings)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateSettings indicates an expected call of UpdateSettings.
+func (mr *MockRuntimeSettingsServiceMockRecorder) UpdateSettings(ctx, token, update any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSettings", reflect.TypeOf((*MockRuntimeSettingsService)(nil).UpdateSettings), ctx, token, update)
+}
+
+// Watch mocks base method.
+func (m *MockRuntimeSettingsService) Watch(watcher port.RuntimeSettingsWatcher) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Watch", watcher)
+}
+
+// Watch indicates an expected call of Watch.
+func (mr *MockRuntimeSettingsServiceMockRecorder) Watch(watcher any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Watch", reflect.TypeOf((*MockRuntimeSettingsService)(nil).Watch), watcher)
+}
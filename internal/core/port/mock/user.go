@@ -13,7 +13,6 @@ import (
 	context "context"
 	reflect "reflect"
 	domain "shop-api-go/internal/core/domain"
-	time "time"
 
 	uuid "github.com/google/uuid"
 	gomock "go.uber.org/mock/gomock"
@@ -44,17 +43,103 @@ func (m *MockUserRepository) EXPECT() *MockUserRepositoryMockRecorder {
 }
 
 // AddUser mocks base method.
-func (m *MockUserRepository) AddUser(ctx context.Context, user *domain.User) error {
+func (m *MockUserRepository) AddUser(ctx context.Context, user *domain.User, event *domain.AuditEvent, outbox []*domain.Event) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "AddUser", ctx, user)
+	ret := m.ctrl.Call(m, "AddUser", ctx, user, event, outbox)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // AddUser indicates an expected call of AddUser.
-func (mr *MockUserRepositoryMockRecorder) AddUser(ctx, user any) *gomock.Call {
+func (mr *MockUserRepositoryMockRecorder) AddUser(ctx, user, event, outbox any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddUser", reflect.TypeOf((*MockUserRepository)(nil).AddUser), ctx, user)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddUser", reflect.TypeOf((*MockUserRepository)(nil).AddUser), ctx, user, event, outbox)
+}
+
+// AddUsers mocks base method.
+func (m *MockUserRepository) AddUsers(ctx context.Context, rows []domain.UserImportRow, events []*domain.AuditEvent, outbox []*domain.Event) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddUsers", ctx, rows, events, outbox)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddUsers indicates an expected call of AddUsers.
+func (mr *MockUserRepositoryMockRecorder) AddUsers(ctx, rows, events, outbox any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddUsers", reflect.TypeOf((*MockUserRepository)(nil).AddUsers), ctx, rows, events, outbox)
+}
+
+// CheckImportConflicts mocks base method.
+func (m *MockUserRepository) CheckImportConflicts(ctx context.Context, rows []domain.UserImportRow) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckImportConflicts", ctx, rows)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CheckImportConflicts indicates an expected call of CheckImportConflicts.
+func (mr *MockUserRepositoryMockRecorder) CheckImportConflicts(ctx, rows any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckImportConflicts", reflect.TypeOf((*MockUserRepository)(nil).CheckImportConflicts), ctx, rows)
+}
+
+// CountUsers mocks base method.
+func (m *MockUserRepository) CountUsers(ctx context.Context, filter *domain.UserFilter) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountUsers", ctx, filter)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountUsers indicates an expected call of CountUsers.
+func (mr *MockUserRepositoryMockRecorder) CountUsers(ctx, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountUsers", reflect.TypeOf((*MockUserRepository)(nil).CountUsers), ctx, filter)
+}
+
+// DeleteUser mocks base method.
+func (m *MockUserRepository) DeleteUser(ctx context.Context, id uuid.UUID, event *domain.AuditEvent) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteUser", ctx, id, event)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteUser indicates an expected call of DeleteUser.
+func (mr *MockUserRepositoryMockRecorder) DeleteUser(ctx, id, event any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUser", reflect.TypeOf((*MockUserRepository)(nil).DeleteUser), ctx, id, event)
+}
+
+// ExportUsers mocks base method.
+func (m *MockUserRepository) ExportUsers(ctx context.Context, filter *domain.UserFilter, fn func(*domain.User) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportUsers", ctx, filter, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ExportUsers indicates an expected call of ExportUsers.
+func (mr *MockUserRepositoryMockRecorder) ExportUsers(ctx, filter, fn any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportUsers", reflect.TypeOf((*MockUserRepository)(nil).ExportUsers), ctx, filter, fn)
+}
+
+// GetUserByEmail mocks base method.
+func (m *MockUserRepository) GetUserByEmail(ctx context.Context, email string) (*domain.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserByEmail", ctx, email)
+	ret0, _ := ret[0].(*domain.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserByEmail indicates an expected call of GetUserByEmail.
+func (mr *MockUserRepositoryMockRecorder) GetUserByEmail(ctx, email any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByEmail", reflect.TypeOf((*MockUserRepository)(nil).GetUserByEmail), ctx, email)
 }
 
 // GetUserById mocks base method.
@@ -87,78 +172,113 @@ func (mr *MockUserRepositoryMockRecorder) GetUserByUsername(ctx, username any) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByUsername", reflect.TypeOf((*MockUserRepository)(nil).GetUserByUsername), ctx, username)
 }
 
-// GetUsersByOffestPagination mocks base method.
-func (m *MockUserRepository) GetUsersByOffestPagination(ctx context.Context, page, limit int, role *domain.UserRole) ([]domain.User, error) {
+// GetUsers mocks base method.
+func (m *MockUserRepository) GetUsers(ctx context.Context, get *domain.GetUsers) ([]domain.User, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetUsersByOffestPagination", ctx, page, limit, role)
+	ret := m.ctrl.Call(m, "GetUsers", ctx, get)
 	ret0, _ := ret[0].([]domain.User)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetUsersByOffestPagination indicates an expected call of GetUsersByOffestPagination.
-func (mr *MockUserRepositoryMockRecorder) GetUsersByOffestPagination(ctx, page, limit, role any) *gomock.Call {
+// GetUsers indicates an expected call of GetUsers.
+func (mr *MockUserRepositoryMockRecorder) GetUsers(ctx, get any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUsersByOffestPagination", reflect.TypeOf((*MockUserRepository)(nil).GetUsersByOffestPagination), ctx, page, limit, role)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUsers", reflect.TypeOf((*MockUserRepository)(nil).GetUsers), ctx, get)
 }
 
-// GetUsersByTimePagination mocks base method.
-func (m *MockUserRepository) GetUsersByTimePagination(ctx context.Context, after time.Time, limit int, role *domain.UserRole) ([]domain.User, error) {
+// RestoreUser mocks base method.
+func (m *MockUserRepository) RestoreUser(ctx context.Context, id uuid.UUID, event *domain.AuditEvent) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetUsersByTimePagination", ctx, after, limit, role)
-	ret0, _ := ret[0].([]domain.User)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret := m.ctrl.Call(m, "RestoreUser", ctx, id, event)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
-// GetUsersByTimePagination indicates an expected call of GetUsersByTimePagination.
-func (mr *MockUserRepositoryMockRecorder) GetUsersByTimePagination(ctx, after, limit, role any) *gomock.Call {
+// RestoreUser indicates an expected call of RestoreUser.
+func (mr *MockUserRepositoryMockRecorder) RestoreUser(ctx, id, event any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUsersByTimePagination", reflect.TypeOf((*MockUserRepository)(nil).GetUsersByTimePagination), ctx, after, limit, role)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreUser", reflect.TypeOf((*MockUserRepository)(nil).RestoreUser), ctx, id, event)
 }
 
-// SearchUserByEmail mocks base method.
-func (m *MockUserRepository) SearchUserByEmail(ctx context.Context, email string, limit int, role *domain.UserRole) ([]domain.User, error) {
+// SetUserStatus mocks base method.
+func (m *MockUserRepository) SetUserStatus(ctx context.Context, id uuid.UUID, status domain.UserStatus, event *domain.AuditEvent) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "SearchUserByEmail", ctx, email, limit, role)
-	ret0, _ := ret[0].([]domain.User)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret := m.ctrl.Call(m, "SetUserStatus", ctx, id, status, event)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
-// SearchUserByEmail indicates an expected call of SearchUserByEmail.
-func (mr *MockUserRepositoryMockRecorder) SearchUserByEmail(ctx, email, limit, role any) *gomock.Call {
+// SetUserStatus indicates an expected call of SetUserStatus.
+func (mr *MockUserRepositoryMockRecorder) SetUserStatus(ctx, id, status, event any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchUserByEmail", reflect.TypeOf((*MockUserRepository)(nil).SearchUserByEmail), ctx, email, limit, role)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetUserStatus", reflect.TypeOf((*MockUserRepository)(nil).SetUserStatus), ctx, id, status, event)
 }
 
-// SearchUserByUsername mocks base method.
-func (m *MockUserRepository) SearchUserByUsername(ctx context.Context, username string, limit int, role *domain.UserRole) ([]domain.User, error) {
+// UpdatePasswordHash mocks base method.
+func (m *MockUserRepository) UpdatePasswordHash(ctx context.Context, id uuid.UUID, hash string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "SearchUserByUsername", ctx, username, limit, role)
-	ret0, _ := ret[0].([]domain.User)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret := m.ctrl.Call(m, "UpdatePasswordHash", ctx, id, hash)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
-// SearchUserByUsername indicates an expected call of SearchUserByUsername.
-func (mr *MockUserRepositoryMockRecorder) SearchUserByUsername(ctx, username, limit, role any) *gomock.Call {
+// UpdatePasswordHash indicates an expected call of UpdatePasswordHash.
+func (mr *MockUserRepositoryMockRecorder) UpdatePasswordHash(ctx, id, hash any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchUserByUsername", reflect.TypeOf((*MockUserRepository)(nil).SearchUserByUsername), ctx, username, limit, role)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePasswordHash", reflect.TypeOf((*MockUserRepository)(nil).UpdatePasswordHash), ctx, id, hash)
 }
 
 // UpdateUser mocks base method.
-func (m *MockUserRepository) UpdateUser(ctx context.Context, update *domain.UserUpdate) error {
+func (m *MockUserRepository) UpdateUser(ctx context.Context, update *domain.UserUpdate, event *domain.AuditEvent, outbox []*domain.Event) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateUser", ctx, update)
+	ret := m.ctrl.Call(m, "UpdateUser", ctx, update, event, outbox)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // UpdateUser indicates an expected call of UpdateUser.
-func (mr *MockUserRepositoryMockRecorder) UpdateUser(ctx, update any) *gomock.Call {
+func (mr *MockUserRepositoryMockRecorder) UpdateUser(ctx, update, event, outbox any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUser", reflect.TypeOf((*MockUserRepository)(nil).UpdateUser), ctx, update)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUser", reflect.TypeOf((*MockUserRepository)(nil).UpdateUser), ctx, update, event, outbox)
+}
+
+// MockPasswordPolicy is a mock of PasswordPolicy interface.
+type MockPasswordPolicy struct {
+	ctrl     *gomock.Controller
+	recorder *MockPasswordPolicyMockRecorder
+	isgomock struct{}
+}
+
+// MockPasswordPolicyMockRecorder is the mock recorder for MockPasswordPolicy.
+type MockPasswordPolicyMockRecorder struct {
+	mock *MockPasswordPolicy
+}
+
+// NewMockPasswordPolicy creates a new mock instance.
+func NewMockPasswordPolicy(ctrl *gomock.Controller) *MockPasswordPolicy {
+	mock := &MockPasswordPolicy{ctrl: ctrl}
+	mock.recorder = &MockPasswordPolicyMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPasswordPolicy) EXPECT() *MockPasswordPolicyMockRecorder {
+	return m.recorder
+}
+
+// Validate mocks base method.
+func (m *MockPasswordPolicy) Validate(password, username, email string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Validate", password, username, email)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Validate indicates an expected call of Validate.
+func (mr *MockPasswordPolicyMockRecorder) Validate(password, username, email any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Validate", reflect.TypeOf((*MockPasswordPolicy)(nil).Validate), password, username, email)
 }
 
 // MockUserService is a mock of UserService interface.
@@ -185,6 +305,21 @@ func (m *MockUserService) EXPECT() *MockUserServiceMockRecorder {
 	return m.recorder
 }
 
+// GetAccount mocks base method.
+func (m *MockUserService) GetAccount(ctx context.Context, token *domain.Token) (*domain.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAccount", ctx, token)
+	ret0, _ := ret[0].(*domain.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAccount indicates an expected call of GetAccount.
+func (mr *MockUserServiceMockRecorder) GetAccount(ctx, token any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccount", reflect.TypeOf((*MockUserService)(nil).GetAccount), ctx, token)
+}
+
 // Register mocks base method.
 func (m *MockUserService) Register(ctx context.Context, user *domain.User) error {
 	m.ctrl.T.Helper()
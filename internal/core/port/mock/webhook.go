@@ -0,0 +1,471 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/core/port/webhook.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/core/port/webhook.go -destination=internal/core/port/mock/webhook.go -package=mock
+//
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+	domain "shop-api-go/internal/core/domain"
+
+	uuid "github.com/google/uuid"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockWebhookRepository is a mock of WebhookRepository interface.
+type MockWebhookRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockWebhookRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockWebhookRepositoryMockRecorder is the mock recorder for MockWebhookRepository.
+type MockWebhookRepositoryMockRecorder struct {
+	mock *MockWebhookRepository
+}
+
+// NewMockWebhookRepository creates a new mock instance.
+func NewMockWebhookRepository(ctrl *gomock.Controller) *MockWebhookRepository {
+	mock := &MockWebhookRepository{ctrl: ctrl}
+	mock.recorder = &MockWebhookRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWebhookRepository) EXPECT() *MockWebhookRepositoryMockRecorder {
+	return m.recorder
+}
+
+// AddDeliveries mocks base method.
+func (m *MockWebhookRepository) AddDeliveries(ctx context.Context, deliveries []*domain.WebhookDelivery) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddDeliveries", ctx, deliveries)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddDeliveries indicates an expected call of AddDeliveries.
+func (mr *MockWebhookRepositoryMockRecorder) AddDeliveries(ctx, deliveries any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddDeliveries", reflect.TypeOf((*MockWebhookRepository)(nil).AddDeliveries), ctx, deliveries)
+}
+
+// AddEndpoint mocks base method.
+func (m *MockWebhookRepository) AddEndpoint(ctx context.Context, endpoint *domain.WebhookEndpoint, event *domain.AuditEvent) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddEndpoint", ctx, endpoint, event)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddEndpoint indicates an expected call of AddEndpoint.
+func (mr *MockWebhookRepositoryMockRecorder) AddEndpoint(ctx, endpoint, event any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddEndpoint", reflect.TypeOf((*MockWebhookRepository)(nil).AddEndpoint), ctx, endpoint, event)
+}
+
+// ClaimDeliveries mocks base method.
+func (m *MockWebhookRepository) ClaimDeliveries(ctx context.Context, limit int) ([]domain.WebhookDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimDeliveries", ctx, limit)
+	ret0, _ := ret[0].([]domain.WebhookDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimDeliveries indicates an expected call of ClaimDeliveries.
+func (mr *MockWebhookRepositoryMockRecorder) ClaimDeliveries(ctx, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimDeliveries", reflect.TypeOf((*MockWebhookRepository)(nil).ClaimDeliveries), ctx, limit)
+}
+
+// DeleteEndpoint mocks base method.
+func (m *MockWebhookRepository) DeleteEndpoint(ctx context.Context, id uuid.UUID, event *domain.AuditEvent) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteEndpoint", ctx, id, event)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteEndpoint indicates an expected call of DeleteEndpoint.
+func (mr *MockWebhookRepositoryMockRecorder) DeleteEndpoint(ctx, id, event any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteEndpoint", reflect.TypeOf((*MockWebhookRepository)(nil).DeleteEndpoint), ctx, id, event)
+}
+
+// GetDeliveries mocks base method.
+func (m *MockWebhookRepository) GetDeliveries(ctx context.Context, filter *domain.WebhookDeliveryFilter) ([]domain.WebhookDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDeliveries", ctx, filter)
+	ret0, _ := ret[0].([]domain.WebhookDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDeliveries indicates an expected call of GetDeliveries.
+func (mr *MockWebhookRepositoryMockRecorder) GetDeliveries(ctx, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDeliveries", reflect.TypeOf((*MockWebhookRepository)(nil).GetDeliveries), ctx, filter)
+}
+
+// GetDeliveryAttempts mocks base method.
+func (m *MockWebhookRepository) GetDeliveryAttempts(ctx context.Context, deliveryId uuid.UUID) ([]domain.WebhookAttempt, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDeliveryAttempts", ctx, deliveryId)
+	ret0, _ := ret[0].([]domain.WebhookAttempt)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDeliveryAttempts indicates an expected call of GetDeliveryAttempts.
+func (mr *MockWebhookRepositoryMockRecorder) GetDeliveryAttempts(ctx, deliveryId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDeliveryAttempts", reflect.TypeOf((*MockWebhookRepository)(nil).GetDeliveryAttempts), ctx, deliveryId)
+}
+
+// GetDeliveryById mocks base method.
+func (m *MockWebhookRepository) GetDeliveryById(ctx context.Context, id uuid.UUID) (*domain.WebhookDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDeliveryById", ctx, id)
+	ret0, _ := ret[0].(*domain.WebhookDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDeliveryById indicates an expected call of GetDeliveryById.
+func (mr *MockWebhookRepositoryMockRecorder) GetDeliveryById(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDeliveryById", reflect.TypeOf((*MockWebhookRepository)(nil).GetDeliveryById), ctx, id)
+}
+
+// GetEndpointById mocks base method.
+func (m *MockWebhookRepository) GetEndpointById(ctx context.Context, id uuid.UUID) (*domain.WebhookEndpoint, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEndpointById", ctx, id)
+	ret0, _ := ret[0].(*domain.WebhookEndpoint)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetEndpointById indicates an expected call of GetEndpointById.
+func (mr *MockWebhookRepositoryMockRecorder) GetEndpointById(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEndpointById", reflect.TypeOf((*MockWebhookRepository)(nil).GetEndpointById), ctx, id)
+}
+
+// GetEndpoints mocks base method.
+func (m *MockWebhookRepository) GetEndpoints(ctx context.Context) ([]domain.WebhookEndpoint, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEndpoints", ctx)
+	ret0, _ := ret[0].([]domain.WebhookEndpoint)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetEndpoints indicates an expected call of GetEndpoints.
+func (mr *MockWebhookRepositoryMockRecorder) GetEndpoints(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEndpoints", reflect.TypeOf((*MockWebhookRepository)(nil).GetEndpoints), ctx)
+}
+
+// GetSubscribedEndpoints mocks base method.
+func (m *MockWebhookRepository) GetSubscribedEndpoints(ctx context.Context, eventType domain.EventType) ([]domain.WebhookEndpoint, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSubscribedEndpoints", ctx, eventType)
+	ret0, _ := ret[0].([]domain.WebhookEndpoint)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSubscribedEndpoints indicates an expected call of GetSubscribedEndpoints.
+func (mr *MockWebhookRepositoryMockRecorder) GetSubscribedEndpoints(ctx, eventType any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSubscribedEndpoints", reflect.TypeOf((*MockWebhookRepository)(nil).GetSubscribedEndpoints), ctx, eventType)
+}
+
+// RecordDeliveryAttempt mocks base method.
+func (m *MockWebhookRepository) RecordDeliveryAttempt(ctx context.Context, delivery *domain.WebhookDelivery, attempt *domain.WebhookAttempt) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordDeliveryAttempt", ctx, delivery, attempt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecordDeliveryAttempt indicates an expected call of RecordDeliveryAttempt.
+func (mr *MockWebhookRepositoryMockRecorder) RecordDeliveryAttempt(ctx, delivery, attempt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordDeliveryAttempt", reflect.TypeOf((*MockWebhookRepository)(nil).RecordDeliveryAttempt), ctx, delivery, attempt)
+}
+
+// ReplayDelivery mocks base method.
+func (m *MockWebhookRepository) ReplayDelivery(ctx context.Context, id uuid.UUID, event *domain.AuditEvent) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReplayDelivery", ctx, id, event)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReplayDelivery indicates an expected call of ReplayDelivery.
+func (mr *MockWebhookRepositoryMockRecorder) ReplayDelivery(ctx, id, event any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReplayDelivery", reflect.TypeOf((*MockWebhookRepository)(nil).ReplayDelivery), ctx, id, event)
+}
+
+// UpdateEndpoint mocks base method.
+func (m *MockWebhookRepository) UpdateEndpoint(ctx context.Context, endpoint *domain.WebhookEndpoint, event *domain.AuditEvent) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateEndpoint", ctx, endpoint, event)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateEndpoint indicates an expected call of UpdateEndpoint.
+func (mr *MockWebhookRepositoryMockRecorder) UpdateEndpoint(ctx, endpoint, event any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateEndpoint", reflect.TypeOf((*MockWebhookRepository)(nil).UpdateEndpoint), ctx, endpoint, event)
+}
+
+// MockWebhookSender is a mock of WebhookSender interface.
+type MockWebhookSender struct {
+	ctrl     *gomock.Controller
+	recorder *MockWebhookSenderMockRecorder
+	isgomock struct{}
+}
+
+// MockWebhookSenderMockRecorder is the mock recorder for MockWebhookSender.
+type MockWebhookSenderMockRecorder struct {
+	mock *MockWebhookSender
+}
+
+// NewMockWebhookSender creates a new mock instance.
+func NewMockWebhookSender(ctrl *gomock.Controller) *MockWebhookSender {
+	mock := &MockWebhookSender{ctrl: ctrl}
+	mock.recorder = &MockWebhookSenderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWebhookSender) EXPECT() *MockWebhookSenderMockRecorder {
+	return m.recorder
+}
+
+// Send mocks base method.
+func (m *MockWebhookSender) Send(ctx context.Context, endpoint *domain.WebhookEndpoint, delivery *domain.WebhookDelivery) *domain.WebhookAttempt {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Send", ctx, endpoint, delivery)
+	ret0, _ := ret[0].(*domain.WebhookAttempt)
+	return ret0
+}
+
+// Send indicates an expected call of Send.
+func (mr *MockWebhookSenderMockRecorder) Send(ctx, endpoint, delivery any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Send", reflect.TypeOf((*MockWebhookSender)(nil).Send), ctx, endpoint, delivery)
+}
+
+// MockWebhookService is a mock of WebhookService interface.
+type MockWebhookService struct {
+	ctrl     *gomock.Controller
+	recorder *MockWebhookServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockWebhookServiceMockRecorder is the mock recorder for MockWebhookService.
+type MockWebhookServiceMockRecorder struct {
+	mock *MockWebhookService
+}
+
+// NewMockWebhookService creates a new mock instance.
+func NewMockWebhookService(ctrl *gomock.Controller) *MockWebhookService {
+	mock := &MockWebhookService{ctrl: ctrl}
+	mock.recorder = &MockWebhookServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWebhookService) EXPECT() *MockWebhookServiceMockRecorder {
+	return m.recorder
+}
+
+// ClaimDeliveries mocks base method.
+func (m *MockWebhookService) ClaimDeliveries(ctx context.Context) ([]domain.WebhookDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimDeliveries", ctx)
+	ret0, _ := ret[0].([]domain.WebhookDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimDeliveries indicates an expected call of ClaimDeliveries.
+func (mr *MockWebhookServiceMockRecorder) ClaimDeliveries(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimDeliveries", reflect.TypeOf((*MockWebhookService)(nil).ClaimDeliveries), ctx)
+}
+
+// CreateEndpoint mocks base method.
+func (m *MockWebhookService) CreateEndpoint(ctx context.Context, token *domain.Token, endpoint *domain.WebhookEndpoint) (*domain.WebhookEndpoint, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateEndpoint", ctx, token, endpoint)
+	ret0, _ := ret[0].(*domain.WebhookEndpoint)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateEndpoint indicates an expected call of CreateEndpoint.
+func (mr *MockWebhookServiceMockRecorder) CreateEndpoint(ctx, token, endpoint any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateEndpoint", reflect.TypeOf((*MockWebhookService)(nil).CreateEndpoint), ctx, token, endpoint)
+}
+
+// DeleteEndpoint mocks base method.
+func (m *MockWebhookService) DeleteEndpoint(ctx context.Context, token *domain.Token, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteEndpoint", ctx, token, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteEndpoint indicates an expected call of DeleteEndpoint.
+func (mr *MockWebhookServiceMockRecorder) DeleteEndpoint(ctx, token, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteEndpoint", reflect.TypeOf((*MockWebhookService)(nil).DeleteEndpoint), ctx, token, id)
+}
+
+// Deliver mocks base method.
+func (m *MockWebhookService) Deliver(ctx context.Context, delivery *domain.WebhookDelivery) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Deliver", ctx, delivery)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Deliver indicates an expected call of Deliver.
+func (mr *MockWebhookServiceMockRecorder) Deliver(ctx, delivery any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Deliver", reflect.TypeOf((*MockWebhookService)(nil).Deliver), ctx, delivery)
+}
+
+// GetDeliveries mocks base method.
+func (m *MockWebhookService) GetDeliveries(ctx context.Context, token *domain.Token, filter *domain.WebhookDeliveryFilter) ([]domain.WebhookDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDeliveries", ctx, token, filter)
+	ret0, _ := ret[0].([]domain.WebhookDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDeliveries indicates an expected call of GetDeliveries.
+func (mr *MockWebhookServiceMockRecorder) GetDeliveries(ctx, token, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDeliveries", reflect.TypeOf((*MockWebhookService)(nil).GetDeliveries), ctx, token, filter)
+}
+
+// GetDelivery mocks base method.
+func (m *MockWebhookService) GetDelivery(ctx context.Context, token *domain.Token, id uuid.UUID) (*domain.WebhookDelivery, []domain.WebhookAttempt, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDelivery", ctx, token, id)
+	ret0, _ := ret[0].(*domain.WebhookDelivery)
+	ret1, _ := ret[1].([]domain.WebhookAttempt)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetDelivery indicates an expected call of GetDelivery.
+func (mr *MockWebhookServiceMockRecorder) GetDelivery(ctx, token, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDelivery", reflect.TypeOf((*MockWebhookService)(nil).GetDelivery), ctx, token, id)
+}
+
+// GetEndpoint mocks base method.
+func (m *MockWebhookService) GetEndpoint(ctx context.Context, token *domain.Token, id uuid.UUID) (*domain.WebhookEndpoint, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEndpoint", ctx, token, id)
+	ret0, _ := ret[0].(*domain.WebhookEndpoint)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetEndpoint indicates an expected call of GetEndpoint.
+func (mr *MockWebhookServiceMockRecorder) GetEndpoint(ctx, token, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEndpoint", reflect.TypeOf((*MockWebhookService)(nil).GetEndpoint), ctx, token, id)
+}
+
+// GetEndpoints mocks base method.
+func (m *MockWebhookService) GetEndpoints(ctx context.Context, token *domain.Token) ([]domain.WebhookEndpoint, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEndpoints", ctx, token)
+	ret0, _ := ret[0].([]domain.WebhookEndpoint)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetEndpoints indicates an expected call of GetEndpoints.
+func (mr *MockWebhookServiceMockRecorder) GetEndpoints(ctx, token any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEndpoints", reflect.TypeOf((*MockWebhookService)(nil).GetEndpoints), ctx, token)
+}
+
+// Publish mocks base method.
+func (m *MockWebhookService) Publish(ctx context.Context, event *domain.Event) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Publish", ctx, event)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Publish indicates an expected call of Publish.
+func (mr *MockWebhookServiceMockRecorder) Publish(ctx, event any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Publish", reflect.TypeOf((*MockWebhookService)(nil).Publish), ctx, event)
+}
+
+// ReplayDelivery mocks base method.
+func (m *MockWebhookService) ReplayDelivery(ctx context.Context, token *domain.Token, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReplayDelivery", ctx, token, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReplayDelivery indicates an expected call of ReplayDelivery.
+func (mr *MockWebhookServiceMockRecorder) ReplayDelivery(ctx, token, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReplayDelivery", reflect.TypeOf((*MockWebhookService)(nil).ReplayDelivery), ctx, token, id)
+}
+
+// RotateEndpointSecret mocks base method.
+func (m *MockWebhookService) RotateEndpointSecret(ctx context.Context, token *domain.Token, id uuid.UUID) (*domain.WebhookEndpoint, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RotateEndpointSecret", ctx, token, id)
+	ret0, _ := ret[0].(*domain.WebhookEndpoint)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RotateEndpointSecret indicates an expected call of RotateEndpointSecret.
+func (mr *MockWebhookServiceMockRecorder) RotateEndpointSecret(ctx, token, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RotateEndpointSecret", reflect.TypeOf((*MockWebhookService)(nil).RotateEndpointSecret), ctx, token, id)
+}
+
+// UpdateEndpoint mocks base method.
+func (m *MockWebhookService) UpdateEndpoint(ctx context.Context, token *domain.Token, update *domain.WebhookEndpointUpdate) (*domain.WebhookEndpoint, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateEndpoint", ctx, token, update)
+	ret0, _ := ret[0].(*domain.WebhookEndpoint)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateEndpoint indicates an expected call of UpdateEndpoint.
+func (mr *MockWebhookServiceMockRecorder) UpdateEndpoint(ctx, token, update any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateEndpoint", reflect.TypeOf((*MockWebhookService)(nil).UpdateEndpoint), ctx, token, update)
+}
@@ -0,0 +1,51 @@
+package port
+
+import (
+	"context"
+	"shop-api-go/internal/core/domain"
+
+	"github.com/google/uuid"
+)
+
+// ProductRepository is an interface for interacting with the product catalog.
+//
+// Note: The batch methods fetch the related entities of many ids in a single query,
+// ids without related entities are missing from the returned map.
+type ProductRepository interface {
+	// GetProducts fetches a page of products matching the filter ordered from newest to oldest, without their subcategories.
+	GetProducts(ctx context.Context, filter *domain.ProductFilter) ([]domain.Product, error)
+	// GetProductById fetches a product by specific id, without its subcategories.
+	GetProductById(ctx context.Context, id uuid.UUID) (*domain.Product, error)
+	// GetCategorySections fetches all category sections ordered by name.
+	GetCategorySections(ctx context.Context) ([]domain.CategorySection, error)
+	// GetCategorySectionsByIds fetches the category sections with the given ids.
+	GetCategorySectionsByIds(ctx context.Context, ids []uuid.UUID) (map[uuid.UUID]domain.CategorySection, error)
+	// GetCategoriesByIds fetches the categories with the given ids.
+	GetCategoriesByIds(ctx context.Context, ids []uuid.UUID) (map[uuid.UUID]domain.Category, error)
+	// GetCategoriesBySectionIds fetches the categories of the given sections ordered by name.
+	GetCategoriesBySectionIds(ctx context.Context, sectionIds []uuid.UUID) (map[uuid.UUID][]domain.Category, error)
+	// GetSubcategoriesByCategoryIds fetches the subcategories of the given categories ordered by name.
+	GetSubcategoriesByCategoryIds(ctx context.Context, categoryIds []uuid.UUID) (map[uuid.UUID][]domain.Subcategory, error)
+	// GetSubcategoriesByProductIds fetches the subcategories of the given products ordered by name.
+	GetSubcategoriesByProductIds(ctx context.Context, productIds []uuid.UUID) (map[uuid.UUID][]domain.Subcategory, error)
+}
+
+// ProductService is an interface for interacting with the product catalog business logic.
+type ProductService interface {
+	// GetProducts fetches a page of products matching the filter.
+	GetProducts(ctx context.Context, filter *domain.ProductFilter) ([]domain.Product, error)
+	// GetProduct fetches a product by specific id.
+	GetProduct(ctx context.Context, id uuid.UUID) (*domain.Product, error)
+	// GetCategorySections fetches all category sections.
+	GetCategorySections(ctx context.Context) ([]domain.CategorySection, error)
+	// GetCategorySectionsByIds fetches the category sections with the given ids.
+	GetCategorySectionsByIds(ctx context.Context, ids []uuid.UUID) (map[uuid.UUID]domain.CategorySection, error)
+	// GetCategoriesByIds fetches the categories with the given ids.
+	GetCategoriesByIds(ctx context.Context, ids []uuid.UUID) (map[uuid.UUID]domain.Category, error)
+	// GetSectionCategories fetches the categories of the given sections.
+	GetSectionCategories(ctx context.Context, sectionIds []uuid.UUID) (map[uuid.UUID][]domain.Category, error)
+	// GetCategorySubcategories fetches the subcategories of the given categories.
+	GetCategorySubcategories(ctx context.Context, categoryIds []uuid.UUID) (map[uuid.UUID][]domain.Subcategory, error)
+	// GetProductSubcategories fetches the subcategories of the given products.
+	GetProductSubcategories(ctx context.Context, productIds []uuid.UUID) (map[uuid.UUID][]domain.Subcategory, error)
+}
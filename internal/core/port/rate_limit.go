@@ -0,0 +1,15 @@
+package port
+
+import (
+	"context"
+	"shop-api-go/internal/core/domain"
+	"time"
+)
+
+// RateLimitStore is an interface for storing token buckets used for rate limiting.
+type RateLimitStore interface {
+	// Take refills the bucket of a key, takes a token if one is available and returns the result.
+	Take(ctx context.Context, key string, limit domain.RateLimit) (*domain.RateLimitResult, error)
+	// DeleteIdleBuckets deletes all buckets that were not used within the duration.
+	DeleteIdleBuckets(idle time.Duration) error
+}
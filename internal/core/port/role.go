@@ -0,0 +1,47 @@
+package port
+
+import (
+	"context"
+	"shop-api-go/internal/core/domain"
+)
+
+// Authorizer is an interface for checking the permissions of tokens.
+type Authorizer interface {
+	// Authorize returns domain.ErrInvalidTokenType if the token is not an access token,
+	// domain.ErrInsufficientScope if the token is an API key not scoped for the permission
+	// and domain.ErrInvalidTokenRole if the role of the token is not granted the permission.
+	Authorize(ctx context.Context, token *domain.Token, permission domain.Permission) error
+}
+
+// RoleRepository is an interface for interacting with role-related data.
+type RoleRepository interface {
+	// GetRoles fetches all roles with their permissions.
+	GetRoles(ctx context.Context) ([]domain.Role, error)
+	// GetRole fetches a role with its permissions by specific name.
+	GetRole(ctx context.Context, name domain.UserRole) (*domain.Role, error)
+	// GetPermissions fetches all permissions that can be granted to roles.
+	GetPermissions(ctx context.Context) ([]domain.PermissionInfo, error)
+	// HasPermission reports whether the permission is granted to the role.
+	HasPermission(ctx context.Context, role domain.UserRole, permission domain.Permission) (bool, error)
+	// AddRole inserts a new role with its permissions and writes the audit event in the same transaction.
+	AddRole(ctx context.Context, role *domain.Role, event *domain.AuditEvent) error
+	// UpdateRole updates the description and replaces the permissions of a role
+	// and writes the audit event in the same transaction.
+	UpdateRole(ctx context.Context, update *domain.RoleUpdate, event *domain.AuditEvent) error
+	// DeleteRole deletes a role by specific name and writes the audit event in the same transaction.
+	DeleteRole(ctx context.Context, name domain.UserRole, event *domain.AuditEvent) error
+}
+
+// RoleService is an interface for interacting with role-related business logic.
+type RoleService interface {
+	// GetRoles fetches all roles with their permissions.
+	GetRoles(ctx context.Context, token *domain.Token) ([]domain.Role, error)
+	// GetPermissions fetches all permissions that can be granted to roles.
+	GetPermissions(ctx context.Context, token *domain.Token) ([]domain.PermissionInfo, error)
+	// CreateRole creates a new custom role.
+	CreateRole(ctx context.Context, token *domain.Token, role *domain.Role) error
+	// UpdateRole updates the description and replaces the permissions of a role.
+	UpdateRole(ctx context.Context, token *domain.Token, update *domain.RoleUpdate) error
+	// DeleteRole deletes a custom role that no user has.
+	DeleteRole(ctx context.Context, token *domain.Token, name domain.UserRole) error
+}
@@ -0,0 +1,40 @@
+package port
+
+import (
+	"context"
+	"shop-api-go/internal/core/domain"
+)
+
+// RuntimeSettingsStore is an interface for persisting the runtime settings.
+type RuntimeSettingsStore interface {
+	// LoadSettings fetches the stored settings, nil if no settings are stored.
+	LoadSettings(ctx context.Context) (*domain.RuntimeSettingsUpdate, error)
+	// SaveSettings replaces the stored settings.
+	SaveSettings(ctx context.Context, settings *domain.RuntimeSettings) error
+}
+
+// RuntimeSettingsWatcher is an interface for components that apply the runtime settings.
+type RuntimeSettingsWatcher interface {
+	// ApplySettings applies the settings, it is called with the current settings when the watcher
+	// is registered and after every change.
+	//
+	// Note: ApplySettings is called while requests are served, so the settings must be swapped atomically.
+	ApplySettings(settings *domain.RuntimeSettings)
+}
+
+// RuntimeSettingsService is an interface for interacting with runtime settings business logic.
+type RuntimeSettingsService interface {
+	// Current returns the current settings without authorization, e.g. for middlewares.
+	//
+	// Note: The returned settings must not be changed.
+	Current() *domain.RuntimeSettings
+	// Watch registers a watcher and applies the current settings to it.
+	Watch(watcher RuntimeSettingsWatcher)
+	// GetSettings fetches the current settings.
+	GetSettings(ctx context.Context, token *domain.Token) (*domain.RuntimeSettings, error)
+	// UpdateSettings validates a change of the settings, audits and stores it in one transaction,
+	// applies it and returns the new settings.
+	UpdateSettings(ctx context.Context, token *domain.Token, update *domain.RuntimeSettingsUpdate) (*domain.RuntimeSettings, error)
+	// ReloadSettings applies the stored settings on top of the configured ones if they changed since the last reload.
+	ReloadSettings(ctx context.Context) error
+}
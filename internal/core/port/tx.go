@@ -0,0 +1,12 @@
+package port
+
+import "context"
+
+// TxManager is an interface for running several repository calls as a single unit of work.
+type TxManager interface {
+	// WithinTx calls fn with a ctx carrying a transaction that the repositories pick up, the transaction is
+	// committed if fn returns nil and rolled back otherwise.
+	//
+	// Note: Calls nested in fn join the outer transaction, so only the outermost call commits.
+	WithinTx(ctx context.Context, fn func(ctx context.Context) error) error
+}
@@ -3,29 +3,50 @@ package port
 import (
 	"context"
 	"shop-api-go/internal/core/domain"
-	"time"
 
 	"github.com/google/uuid"
 )
 
 // UserRepository is an interface for interacting with user-related data.
 type UserRepository interface {
-	// AddUser inserts a new user into the database.
-	AddUser(ctx context.Context, user *domain.User) error
+	// AddUser inserts a new user into the database and writes the audit event and the outbox events in the same transaction.
+	AddUser(ctx context.Context, user *domain.User, event *domain.AuditEvent, outbox []*domain.Event) error
+	// AddUsers inserts the users of an import with their audit events and outbox events in a single transaction.
+	// If any user conflicts with an existing one, nothing is inserted and a domain.UserImportError is returned.
+	AddUsers(ctx context.Context, rows []domain.UserImportRow, events []*domain.AuditEvent, outbox []*domain.Event) error
+	// CheckImportConflicts returns a domain.UserImportError with every row whose username or email
+	// is already used by an existing user, including soft-deleted ones.
+	CheckImportConflicts(ctx context.Context, rows []domain.UserImportRow) error
+	// ExportUsers calls fn for every user matching the filter, ordered by creation time, until fn returns an error.
+	ExportUsers(ctx context.Context, filter *domain.UserFilter, fn func(user *domain.User) error) error
 	// GetUserByUsername fetches a user by specific username.
 	GetUserByUsername(ctx context.Context, username string) (*domain.User, error)
+	// GetUserByEmail fetches a user by specific email.
+	GetUserByEmail(ctx context.Context, email string) (*domain.User, error)
 	// GetUserById fetches a user by specific.
 	GetUserById(ctx context.Context, id uuid.UUID) (*domain.User, error)
-	// GetUsersByOffestPagination fetches users using offset pagination.
-	GetUsersByOffestPagination(ctx context.Context, page, limit int, role *domain.UserRole) ([]domain.User, error)
-	// GetUsersByTimePagination fetches users using time pagination.
-	GetUsersByTimePagination(ctx context.Context, after time.Time, limit int, role *domain.UserRole) ([]domain.User, error)
-	// SearchUserByUsername searches for users with similar to the provided username.
-	SearchUserByUsername(ctx context.Context, username string, limit int, role *domain.UserRole) ([]domain.User, error)
-	// SearchUserByEmail searches for users with similar to the provided email.
-	SearchUserByEmail(ctx context.Context, email string, limit int, role *domain.UserRole) ([]domain.User, error)
-	// UpdateUser updates the fields of a user by specific id.
-	UpdateUser(ctx context.Context, update *domain.UserUpdate) error
+	// GetUsers fetches a page of users matching the filter, sorted by the sort field and id.
+	GetUsers(ctx context.Context, get *domain.GetUsers) ([]domain.User, error)
+	// CountUsers counts the users matching the filter.
+	CountUsers(ctx context.Context, filter *domain.UserFilter) (int, error)
+	// UpdateUser updates the fields of a user by specific id and writes the audit event and the outbox events
+	// in the same transaction.
+	UpdateUser(ctx context.Context, update *domain.UserUpdate, event *domain.AuditEvent, outbox []*domain.Event) error
+	// UpdatePasswordHash replaces the password hash of a user without changing the password.
+	UpdatePasswordHash(ctx context.Context, id uuid.UUID, hash string) error
+	// SetUserStatus sets the status of a user and writes the audit event in the same transaction.
+	SetUserStatus(ctx context.Context, id uuid.UUID, status domain.UserStatus, event *domain.AuditEvent) error
+	// DeleteUser soft-deletes a user and writes the audit event in the same transaction.
+	DeleteUser(ctx context.Context, id uuid.UUID, event *domain.AuditEvent) error
+	// RestoreUser restores a soft-deleted user and writes the audit event in the same transaction.
+	RestoreUser(ctx context.Context, id uuid.UUID, event *domain.AuditEvent) error
+}
+
+// PasswordPolicy is an interface for screening new passwords.
+type PasswordPolicy interface {
+	// Validate checks the password of a user with the given username and email,
+	// a violation is reported with domain.PasswordPolicyError.
+	Validate(password, username, email string) error
 }
 
 // UserService is an interface for interacting with user-related business logic.
@@ -34,4 +55,6 @@ type UserService interface {
 	Register(ctx context.Context, user *domain.User) error
 	// UpdateAccount updates user's account.
 	UpdateAccount(ctx context.Context, update *domain.UpdateAccount) error
+	// GetAccount fetches the account of the token's user.
+	GetAccount(ctx context.Context, token *domain.Token) (*domain.User, error)
 }
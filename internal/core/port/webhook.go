@@ -0,0 +1,79 @@
+package port
+
+import (
+	"context"
+	"shop-api-go/internal/core/domain"
+
+	"github.com/google/uuid"
+)
+
+// WebhookRepository is an interface for interacting with webhook endpoints and their deliveries.
+type WebhookRepository interface {
+	// AddEndpoint inserts a new endpoint and writes the audit event in the same transaction.
+	AddEndpoint(ctx context.Context, endpoint *domain.WebhookEndpoint, event *domain.AuditEvent) error
+	// GetEndpoints fetches all endpoints ordered from oldest to newest.
+	GetEndpoints(ctx context.Context) ([]domain.WebhookEndpoint, error)
+	// GetEndpointById fetches an endpoint by specific id.
+	GetEndpointById(ctx context.Context, id uuid.UUID) (*domain.WebhookEndpoint, error)
+	// GetSubscribedEndpoints fetches the enabled endpoints events of specific type are delivered to.
+	GetSubscribedEndpoints(ctx context.Context, eventType domain.EventType) ([]domain.WebhookEndpoint, error)
+	// UpdateEndpoint stores the url, description, event types, secret and enabled flag of an endpoint
+	// and writes the audit event in the same transaction.
+	UpdateEndpoint(ctx context.Context, endpoint *domain.WebhookEndpoint, event *domain.AuditEvent) error
+	// DeleteEndpoint deletes an endpoint with its deliveries and writes the audit event in the same transaction.
+	DeleteEndpoint(ctx context.Context, id uuid.UUID, event *domain.AuditEvent) error
+	// AddDeliveries inserts new pending deliveries, deliveries of an event already queued for
+	// the same endpoint are skipped so that republished events are delivered once.
+	AddDeliveries(ctx context.Context, deliveries []*domain.WebhookDelivery) error
+	// ClaimDeliveries hides up to limit pending deliveries that are due and whose endpoint is enabled
+	// from other workers for domain.WebhookDeliveryTimeout and returns them ordered by when they are due.
+	ClaimDeliveries(ctx context.Context, limit int) ([]domain.WebhookDelivery, error)
+	// GetDeliveries fetches the deliveries matching the filter ordered from newest to oldest.
+	GetDeliveries(ctx context.Context, filter *domain.WebhookDeliveryFilter) ([]domain.WebhookDelivery, error)
+	// GetDeliveryById fetches a delivery by specific id.
+	GetDeliveryById(ctx context.Context, id uuid.UUID) (*domain.WebhookDelivery, error)
+	// GetDeliveryAttempts fetches the attempts of a delivery ordered from oldest to newest.
+	GetDeliveryAttempts(ctx context.Context, deliveryId uuid.UUID) ([]domain.WebhookAttempt, error)
+	// RecordDeliveryAttempt inserts the attempt and stores the status, attempts, next attempt and last error
+	// of its delivery in the same transaction.
+	RecordDeliveryAttempt(ctx context.Context, delivery *domain.WebhookDelivery, attempt *domain.WebhookAttempt) error
+	// ReplayDelivery resets the attempts of a delivery and queues it for immediate delivery,
+	// and writes the audit event in the same transaction.
+	ReplayDelivery(ctx context.Context, id uuid.UUID, event *domain.AuditEvent) error
+}
+
+// WebhookSender is an interface for sending webhook deliveries to partner endpoints.
+type WebhookSender interface {
+	// Send posts the signed event of the delivery to the endpoint and returns the outcome,
+	// failures are reported by the attempt instead of an error.
+	Send(ctx context.Context, endpoint *domain.WebhookEndpoint, delivery *domain.WebhookDelivery) *domain.WebhookAttempt
+}
+
+// WebhookService is an interface for interacting with webhook-related business logic.
+type WebhookService interface {
+	// GetEndpoints fetches all endpoints without their secrets.
+	GetEndpoints(ctx context.Context, token *domain.Token) ([]domain.WebhookEndpoint, error)
+	// GetEndpoint fetches an endpoint without its secret.
+	GetEndpoint(ctx context.Context, token *domain.Token, id uuid.UUID) (*domain.WebhookEndpoint, error)
+	// CreateEndpoint creates a new endpoint and returns it with its secret.
+	CreateEndpoint(ctx context.Context, token *domain.Token, endpoint *domain.WebhookEndpoint) (*domain.WebhookEndpoint, error)
+	// UpdateEndpoint updates an endpoint and returns it without its secret.
+	UpdateEndpoint(ctx context.Context, token *domain.Token, update *domain.WebhookEndpointUpdate) (*domain.WebhookEndpoint, error)
+	// RotateEndpointSecret replaces the secret of an endpoint and returns it with the new secret.
+	RotateEndpointSecret(ctx context.Context, token *domain.Token, id uuid.UUID) (*domain.WebhookEndpoint, error)
+	// DeleteEndpoint deletes an endpoint with its deliveries.
+	DeleteEndpoint(ctx context.Context, token *domain.Token, id uuid.UUID) error
+	// GetDeliveries fetches the deliveries of an endpoint matching the filter.
+	GetDeliveries(ctx context.Context, token *domain.Token, filter *domain.WebhookDeliveryFilter) ([]domain.WebhookDelivery, error)
+	// GetDelivery fetches a delivery with its attempts.
+	GetDelivery(ctx context.Context, token *domain.Token, id uuid.UUID) (*domain.WebhookDelivery, []domain.WebhookAttempt, error)
+	// ReplayDelivery queues a delivery for immediate delivery with a fresh set of attempts, e.g. a dead one.
+	ReplayDelivery(ctx context.Context, token *domain.Token, id uuid.UUID) error
+	// Publish queues a delivery of an event for every endpoint subscribed to it,
+	// it implements EventPublisher so that the outbox relays events to webhooks.
+	Publish(ctx context.Context, event *domain.Event) error
+	// ClaimDeliveries claims the due deliveries for a worker.
+	ClaimDeliveries(ctx context.Context) ([]domain.WebhookDelivery, error)
+	// Deliver sends a claimed delivery and records the attempt.
+	Deliver(ctx context.Context, delivery *domain.WebhookDelivery) error
+}
@@ -2,9 +2,13 @@ package service
 
 import (
 	"context"
+	"errors"
+	"runtime"
 	"shop-api-go/internal/core/domain"
 	"shop-api-go/internal/core/port"
-	"time"
+	"sync"
+
+	"github.com/google/uuid"
 )
 
 // AdminService implements port.AdminService interface and provides access to admin-related business logic.
@@ -12,79 +16,240 @@ type AdminService struct {
 	userRepository  port.UserRepository
 	tokenRepository port.TokenRepository
 	passwordHasher  port.PasswordHasher
+	passwordPolicy  port.PasswordPolicy
+	mfaRepository   port.MFARepository
+
+	loginAttemptRepository port.LoginAttemptRepository
+	tokenGenerator         port.TokenGenerator
+	authorizer             port.Authorizer
+	roleRepository         port.RoleRepository
+	auditRepository        port.AuditRepository
+	txManager              port.TxManager
 }
 
 // NewAdminService creates a new AdminService instance.
-func NewAdminService(userRepository port.UserRepository, tokenRepository port.TokenRepository, passwordHasher port.PasswordHasher) *AdminService {
+func NewAdminService(
+	userRepository port.UserRepository,
+	tokenRepository port.TokenRepository,
+	passwordHasher port.PasswordHasher,
+	passwordPolicy port.PasswordPolicy,
+	mfaRepository port.MFARepository,
+	loginAttemptRepository port.LoginAttemptRepository,
+	tokenGenerator port.TokenGenerator,
+	authorizer port.Authorizer,
+	roleRepository port.RoleRepository,
+	auditRepository port.AuditRepository,
+	txManager port.TxManager,
+) *AdminService {
 	return &AdminService{
-		userRepository:  userRepository,
-		tokenRepository: tokenRepository,
-		passwordHasher:  passwordHasher,
+		userRepository:         userRepository,
+		tokenRepository:        tokenRepository,
+		passwordHasher:         passwordHasher,
+		passwordPolicy:         passwordPolicy,
+		mfaRepository:          mfaRepository,
+		loginAttemptRepository: loginAttemptRepository,
+		tokenGenerator:         tokenGenerator,
+		authorizer:             authorizer,
+		roleRepository:         roleRepository,
+		auditRepository:        auditRepository,
+		txManager:              txManager,
 	}
 }
 
 func (s *AdminService) GetUsers(ctx context.Context, token *domain.Token, get *domain.GetUsers) (*domain.UsersResult, error) {
-	if token.TokenType != domain.AccessToken {
-		return nil, domain.ErrInvalidTokenType
+	if err := s.authorizer.Authorize(ctx, token, domain.UsersRead); err != nil {
+		return nil, err
+	}
+	if get.Limit == nil {
+		if get.Filter.Id == nil {
+			return nil, domain.ErrLimitNotSet
+		}
+		limit := 1
+		get.Limit = &limit
+	}
+	if get.Page != nil && get.Cursor != nil {
+		return nil, domain.ErrInvalidQuery
 	}
-	if token.UserRole != domain.Admin {
-		return nil, domain.ErrInvalidTokenRole
+	if get.Cursor != nil && (get.Cursor.SortBy != get.SortBy || get.Cursor.Descending != get.Descending) {
+		return nil, domain.ErrInvalidCursor
 	}
-	if get.Id == nil && get.Limit == nil {
-		return nil, domain.ErrLimitNotSet
+
+	users, err := s.userRepository.GetUsers(ctx, get)
+	if err != nil {
+		return nil, err
 	}
 
-	switch {
-	case get.Id != nil:
-		user, err := s.userRepository.GetUserById(ctx, *get.Id)
+	result := domain.NewUsersResult(users, nil, nil)
+	if get.Page == nil && len(users) == *get.Limit {
+		result.Cursor = domain.NewUserCursor(&users[len(users)-1], get.SortBy, get.Descending)
+	}
+	if get.WithTotal {
+		total, err := s.userRepository.CountUsers(ctx, &get.Filter)
 		if err != nil {
 			return nil, err
 		}
+		result.Total = &total
+	}
+	return result, nil
+}
 
-		return domain.NewUsersResult([]domain.User{*user}, nil), nil
-	case get.Username != nil:
-		users, err := s.userRepository.SearchUserByUsername(ctx, *get.Username, *get.Limit, get.Role)
-		if err != nil {
-			return nil, err
+func (s *AdminService) CreateUser(ctx context.Context, token *domain.Token, user *domain.User) error {
+	if err := s.authorizer.Authorize(ctx, token, domain.UsersWrite); err != nil {
+		return err
+	}
+	if err := s.checkRoleAssignable(ctx, token, user.Role); err != nil {
+		return err
+	}
+	if err := s.passwordPolicy.Validate(user.Password, user.Username, user.Email); err != nil {
+		return err
+	}
+
+	user.Id = uuid.New()
+	user.Status = domain.UserActive
+	hash, err := s.passwordHasher.Hash(user.Password)
+	if err != nil {
+		return err
+	}
+	user.Password = hash
+
+	event := domain.NewAuditEvent(ctx, &token.UserId, domain.AuditUserCreated, domain.AuditTargetUser, user.Id.String()).
+		WithChange("username", nil, user.Username).
+		WithChange("email", nil, user.Email).
+		WithChange("role", nil, user.Role)
+	return s.userRepository.AddUser(ctx, user, event, []*domain.Event{domain.NewUserEvent(domain.EventUserCreated, user)})
+}
+
+func (s *AdminService) ImportUsers(
+	ctx context.Context,
+	token *domain.Token,
+	rows []domain.UserImportRow,
+	dryRun bool,
+) (int, error) {
+	if err := s.authorizer.Authorize(ctx, token, domain.UsersWrite); err != nil {
+		return 0, err
+	}
+	if len(rows) == 0 {
+		return 0, domain.ErrEmptyImport
+	}
+	if len(rows) > domain.MaxUserImportRows {
+		return 0, domain.ErrImportTooLarge
+	}
+
+	var rowErrors []domain.UserImportRowError
+	usernames := make(map[string]struct{}, len(rows))
+	emails := make(map[string]struct{}, len(rows))
+	roles := make(map[domain.UserRole]error)
+	for _, row := range rows {
+		roleErr, ok := roles[row.User.Role]
+		if !ok {
+			roleErr = s.checkRoleAssignable(ctx, token, row.User.Role)
+			if roleErr != nil && !errors.Is(roleErr, domain.ErrRoleNotFound) && !errors.Is(roleErr, domain.ErrRoleNotAssignable) {
+				return 0, roleErr
+			}
+			roles[row.User.Role] = roleErr
+		}
+		if roleErr != nil {
+			rowErrors = append(rowErrors, domain.UserImportRowError{Line: row.Line, Err: roleErr})
+			continue
 		}
 
-		return domain.NewUsersResult(users, nil), nil
-	case get.Email != nil:
-		users, err := s.userRepository.SearchUserByEmail(ctx, *get.Email, *get.Limit, get.Role)
-		if err != nil {
-			return nil, err
+		if _, ok := usernames[row.User.Username]; ok {
+			rowErrors = append(rowErrors, domain.UserImportRowError{Line: row.Line, Err: domain.ErrUsernameAlreadyInUse})
+			continue
 		}
+		usernames[row.User.Username] = struct{}{}
 
-		return domain.NewUsersResult(users, nil), nil
-	case get.Page != nil:
-		users, err := s.userRepository.GetUsersByOffestPagination(ctx, *get.Page, *get.Limit, get.Role)
-		if err != nil {
-			return nil, err
+		if _, ok := emails[row.User.Email]; ok {
+			rowErrors = append(rowErrors, domain.UserImportRowError{Line: row.Line, Err: domain.ErrEmailAlreadyInUse})
+			continue
 		}
+		emails[row.User.Email] = struct{}{}
 
-		return domain.NewUsersResult(users, nil), nil
-	case get.After != nil:
-		users, err := s.userRepository.GetUsersByTimePagination(ctx, *get.After, *get.Limit, get.Role)
-		if err != nil {
-			return nil, err
+		if err := s.passwordPolicy.Validate(row.User.Password, row.User.Username, row.User.Email); err != nil {
+			rowErrors = append(rowErrors, domain.UserImportRowError{Line: row.Line, Err: err})
 		}
+	}
+	if len(rowErrors) > 0 {
+		return 0, domain.NewUserImportError(rowErrors)
+	}
+	// Conflicts with existing users are checked before hashing, so that a dry run reports them
+	// and a rejected import does not hash every password. AddUsers checks them again in its transaction.
+	if err := s.userRepository.CheckImportConflicts(ctx, rows); err != nil {
+		return 0, err
+	}
+	if dryRun {
+		return len(rows), nil
+	}
+
+	if err := s.hashImportPasswords(rows); err != nil {
+		return 0, err
+	}
+	events := make([]*domain.AuditEvent, 0, len(rows))
+	outbox := make([]*domain.Event, 0, len(rows))
+	for i := range rows {
+		user := &rows[i].User
+		user.Id = uuid.New()
+		user.Status = domain.UserActive
+
+		events = append(
+			events,
+			domain.NewAuditEvent(ctx, &token.UserId, domain.AuditUserImported, domain.AuditTargetUser, user.Id.String()).
+				WithChange("username", nil, user.Username).
+				WithChange("email", nil, user.Email).
+				WithChange("role", nil, user.Role),
+		)
+		outbox = append(outbox, domain.NewUserEvent(domain.EventUserCreated, user))
+	}
+
+	if err := s.userRepository.AddUsers(ctx, rows, events, outbox); err != nil {
+		return 0, err
+	}
+	return len(rows), nil
+}
+
+// hashImportPasswords hashes the passwords of the rows with at most one worker per CPU,
+// as hashing is CPU bound and an import has up to domain.MaxUserImportRows users.
+func (s *AdminService) hashImportPasswords(rows []domain.UserImportRow) error {
+	indexes := make(chan int)
+	errs := make([]error, len(rows))
+	var wg sync.WaitGroup
+	for range min(runtime.GOMAXPROCS(0), len(rows)) {
+		wg.Go(func() {
+			for i := range indexes {
+				rows[i].User.Password, errs[i] = s.passwordHasher.Hash(rows[i].User.Password)
+			}
+		})
+	}
+	for i := range rows {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
 
-		var cursor string
-		if len(users) > 0 {
-			cursor = users[len(users)-1].CreatedAt.Format(time.RFC3339Nano)
+	for _, err := range errs {
+		if err != nil {
+			return err
 		}
-		return domain.NewUsersResult(users, &cursor), err
-	default:
-		return nil, domain.ErrInvalidQuery
 	}
+	return nil
+}
+
+func (s *AdminService) ExportUsers(
+	ctx context.Context,
+	token *domain.Token,
+	filter *domain.UserFilter,
+	fn func(user *domain.User) error,
+) error {
+	if err := s.authorizer.Authorize(ctx, token, domain.UsersRead); err != nil {
+		return err
+	}
+
+	return s.userRepository.ExportUsers(ctx, filter, fn)
 }
 
 func (s *AdminService) UpdateUser(ctx context.Context, token *domain.Token, update *domain.UserUpdate) error {
-	if token.TokenType != domain.AccessToken {
-		return domain.ErrInvalidTokenType
-	}
-	if token.UserRole != domain.Admin {
-		return domain.ErrInvalidTokenRole
+	if err := s.authorizer.Authorize(ctx, token, domain.UsersWrite); err != nil {
+		return err
 	}
 
 	hasFieldToUpdate := false
@@ -96,11 +261,6 @@ func (s *AdminService) UpdateUser(ctx context.Context, token *domain.Token, upda
 		hasFieldToUpdate = true
 	}
 	if update.Password != nil {
-		hash, err := s.passwordHasher.Hash(*update.Password)
-		if err != nil {
-			return err
-		}
-		update.Password = &hash
 		hasFieldToUpdate = true
 	}
 	if update.Role != nil {
@@ -111,13 +271,290 @@ func (s *AdminService) UpdateUser(ctx context.Context, token *domain.Token, upda
 		return domain.ErrNoFieldsToUpdate
 	}
 
-	if err := s.userRepository.UpdateUser(ctx, update); err != nil {
+	user, err := s.userRepository.GetUserById(ctx, update.Id)
+	if err != nil {
+		return err
+	}
+	// A user with more permissions than the actor could be taken over, e.g. by changing their password.
+	if err = s.checkRoleAssignable(ctx, token, user.Role); err != nil {
+		return err
+	}
+	if update.Role != nil {
+		if err = s.checkRoleAssignable(ctx, token, *update.Role); err != nil {
+			return err
+		}
+	}
+
+	if update.Password != nil {
+		username, email := user.Username, user.Email
+		if update.Username != nil {
+			username = *update.Username
+		}
+		if update.Email != nil {
+			email = *update.Email
+		}
+		if err = s.passwordPolicy.Validate(*update.Password, username, email); err != nil {
+			return err
+		}
+
+		hash, err := s.passwordHasher.Hash(*update.Password)
+		if err != nil {
+			return err
+		}
+		update.Password = &hash
+	}
+
+	var outbox []*domain.Event
+	if update.Role != nil && *update.Role != user.Role {
+		outbox = append(outbox, domain.NewUserRoleChangedEvent(user.Id, user.Role, *update.Role))
+	}
+	return s.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		if err := s.userRepository.UpdateUser(ctx, update, userUpdateAuditEvent(ctx, token.UserId, user, update), outbox); err != nil {
+			return err
+		}
+
+		return s.tokenRepository.DeleteAllTokensByUserId(
+			ctx,
+			update.Id,
+			tokensRevokedAuditEvent(ctx, token.UserId, update.Id),
+		)
+	})
+}
+
+func (s *AdminService) SuspendUser(ctx context.Context, token *domain.Token, id uuid.UUID) error {
+	return s.setUserStatus(ctx, token, id, domain.UserSuspended, domain.AuditUserSuspended)
+}
+
+func (s *AdminService) ReactivateUser(ctx context.Context, token *domain.Token, id uuid.UUID) error {
+	return s.setUserStatus(ctx, token, id, domain.UserActive, domain.AuditUserReactivated)
+}
+
+// setUserStatus sets the status of a user. Issued tokens are kept, so access tokens
+// stay valid until they expire and refresh tokens can be used again after reactivation.
+func (s *AdminService) setUserStatus(
+	ctx context.Context,
+	token *domain.Token,
+	id uuid.UUID,
+	status domain.UserStatus,
+	action domain.AuditAction,
+) error {
+	if err := s.authorizer.Authorize(ctx, token, domain.UsersWrite); err != nil {
+		return err
+	}
+	if id == token.UserId {
+		return domain.ErrSelfLockout
+	}
+
+	user, err := s.getManageableUser(ctx, token, id)
+	if err != nil {
+		return err
+	}
+
+	event := domain.NewAuditEvent(ctx, &token.UserId, action, domain.AuditTargetUser, id.String()).
+		WithChange("status", user.Status, status)
+	return s.userRepository.SetUserStatus(ctx, id, status, event)
+}
+
+func (s *AdminService) DeleteUser(ctx context.Context, token *domain.Token, id uuid.UUID) error {
+	if err := s.authorizer.Authorize(ctx, token, domain.UsersWrite); err != nil {
+		return err
+	}
+	if id == token.UserId {
+		return domain.ErrSelfLockout
+	}
+	if _, err := s.getManageableUser(ctx, token, id); err != nil {
+		return err
+	}
+
+	return s.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		err := s.userRepository.DeleteUser(
+			ctx,
+			id,
+			domain.NewAuditEvent(ctx, &token.UserId, domain.AuditUserDeleted, domain.AuditTargetUser, id.String()),
+		)
+		if err != nil {
+			return err
+		}
+
+		return s.tokenRepository.DeleteAllTokensByUserId(ctx, id, tokensRevokedAuditEvent(ctx, token.UserId, id))
+	})
+}
+
+func (s *AdminService) RestoreUser(ctx context.Context, token *domain.Token, id uuid.UUID) error {
+	if err := s.authorizer.Authorize(ctx, token, domain.UsersWrite); err != nil {
+		return err
+	}
+
+	// Deleted users cannot be fetched, so their role is checked after the restore and rolls it back.
+	return s.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		err := s.userRepository.RestoreUser(
+			ctx,
+			id,
+			domain.NewAuditEvent(ctx, &token.UserId, domain.AuditUserRestored, domain.AuditTargetUser, id.String()),
+		)
+		if err != nil {
+			return err
+		}
+
+		_, err = s.getManageableUser(ctx, token, id)
+		return err
+	})
+}
+
+func (s *AdminService) UnlockUser(ctx context.Context, token *domain.Token, id uuid.UUID) error {
+	if err := s.authorizer.Authorize(ctx, token, domain.UsersWrite); err != nil {
+		return err
+	}
+
+	user, err := s.getManageableUser(ctx, token, id)
+	if err != nil {
+		return err
+	}
+
+	if err = s.loginAttemptRepository.ResetLoginAttempts(ctx, domain.UserLoginAttemptsKey(user.Username)); err != nil {
+		return err
+	}
+	if err = s.loginAttemptRepository.ResetLoginAttempts(ctx, domain.MFALoginAttemptsKey(user.Id.String())); err != nil {
+		return err
+	}
+
+	// Login attempts may be kept in memory, so the event cannot share a transaction with the reset.
+	return s.auditRepository.AddAuditEvent(
+		ctx,
+		domain.NewAuditEvent(ctx, &token.UserId, domain.AuditUserUnlocked, domain.AuditTargetUser, user.Id.String()),
+	)
+}
+
+func (s *AdminService) RevokeUserSessions(ctx context.Context, token *domain.Token, id uuid.UUID) error {
+	if err := s.authorizer.Authorize(ctx, token, domain.UsersWrite); err != nil {
+		return err
+	}
+
+	// Distinguish an unknown user from one without tokens.
+	if _, err := s.getManageableUser(ctx, token, id); err != nil {
+		return err
+	}
+	return s.tokenRepository.DeleteAllTokensByUserId(ctx, id, tokensRevokedAuditEvent(ctx, token.UserId, id))
+}
+
+func (s *AdminService) ImpersonateUser(ctx context.Context, token *domain.Token, id uuid.UUID) (*domain.ImpersonationToken, error) {
+	if token.IsAPIKey() {
+		return nil, domain.ErrInvalidTokenType
+	}
+	if err := s.authorizer.Authorize(ctx, token, domain.UsersImpersonate); err != nil {
+		return nil, err
+	}
+	if id == token.UserId {
+		return nil, domain.ErrImpersonationForbidden
+	}
+
+	user, err := s.userRepository.GetUserById(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if user.Status == domain.UserSuspended {
+		return nil, domain.ErrUserSuspended
+	}
+	if err = s.checkImpersonationTarget(ctx, token, user.Role); err != nil {
+		return nil, err
+	}
+
+	impersonationToken := domain.Token{
+		Id:        uuid.New(),
+		UserId:    user.Id,
+		TokenType: domain.AccessToken,
+		UserRole:  user.Role,
+		ActorId:   &token.UserId,
+	}
+	signedToken, err := s.tokenGenerator.SignToken(&impersonationToken)
+	if err != nil {
+		return nil, err
+	}
+
+	// The token is not stored, so the event cannot share a transaction with it.
+	event := domain.NewAuditEvent(ctx, &token.UserId, domain.AuditImpersonationStarted, domain.AuditTargetUser, user.Id.String()).
+		WithChange("expiresAt", nil, impersonationToken.ExpiresAt)
+	if err = s.auditRepository.AddAuditEvent(ctx, event); err != nil {
+		return nil, err
+	}
+
+	return domain.NewImpersonationToken(signedToken, impersonationToken.ExpiresAt), nil
+}
+
+func (s *AdminService) GetMFARequirements(ctx context.Context, token *domain.Token) ([]domain.RoleMFARequirement, error) {
+	if err := s.authorizer.Authorize(ctx, token, domain.RolesRead); err != nil {
+		return nil, err
+	}
+
+	return s.mfaRepository.GetRoleMFARequirements(ctx)
+}
+
+func (s *AdminService) SetMFARequirement(ctx context.Context, token *domain.Token, requirement *domain.RoleMFARequirement) error {
+	if err := s.authorizer.Authorize(ctx, token, domain.RolesWrite); err != nil {
+		return err
+	}
+
+	required, err := s.mfaRepository.IsMFARequired(ctx, requirement.Role)
+	if err != nil {
+		return err
+	}
+
+	event := domain.NewAuditEvent(ctx, &token.UserId, domain.AuditMFARequirementUpdated, domain.AuditTargetRole, string(requirement.Role)).
+		WithChange("required", required, requirement.Required)
+	return s.mfaRepository.SetRoleMFARequirement(ctx, requirement, event)
+}
+
+// getManageableUser fetches a user by id and returns domain.ErrRoleNotAssignable if their role grants
+// a permission the actor lacks, so the actor cannot lock out, sign out or delete users above them.
+func (s *AdminService) getManageableUser(ctx context.Context, token *domain.Token, id uuid.UUID) (*domain.User, error) {
+	user, err := s.userRepository.GetUserById(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err = s.checkRoleAssignable(ctx, token, user.Role); err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+// checkRoleAssignable returns domain.ErrRoleNotAssignable if the role grants a permission that is not granted
+// to the role of the token or not in its scopes, so the actor cannot give users more permissions than they have.
+func (s *AdminService) checkRoleAssignable(ctx context.Context, token *domain.Token, name domain.UserRole) error {
+	actor, err := s.roleRepository.GetRole(ctx, token.UserRole)
+	if err != nil {
+		return err
+	}
+	role, err := s.roleRepository.GetRole(ctx, name)
+	if err != nil {
 		return err
 	}
 
-	if err := s.tokenRepository.DeleteAllTokensByUserId(ctx, token.UserId); err != nil {
+	if !actor.Includes(role) {
+		return domain.ErrRoleNotAssignable
+	}
+	for _, p := range role.Permissions {
+		if !token.HasScope(p) {
+			return domain.ErrRoleNotAssignable
+		}
+	}
+	return nil
+}
+
+// checkImpersonationTarget returns domain.ErrImpersonationForbidden unless the role is below the role of the token:
+// it must not grant a permission the actor lacks and must not grant every permission of the actor,
+// so an impersonation never gains permissions and admins cannot act as other admins.
+func (s *AdminService) checkImpersonationTarget(ctx context.Context, token *domain.Token, name domain.UserRole) error {
+	actor, err := s.roleRepository.GetRole(ctx, token.UserRole)
+	if err != nil {
+		return err
+	}
+	role, err := s.roleRepository.GetRole(ctx, name)
+	if err != nil {
 		return err
 	}
 
+	if !actor.Includes(role) || role.Includes(actor) {
+		return domain.ErrImpersonationForbidden
+	}
 	return nil
 }
@@ -13,129 +13,253 @@ import (
 	"go.uber.org/mock/gomock"
 )
 
+// adminMocks contains all mocked dependencies of service.AdminService.
+type adminMocks struct {
+	userRepository  *mock.MockUserRepository
+	tokenRepository *mock.MockTokenRepository
+	passwordHasher  *mock.MockPasswordHasher
+	passwordPolicy  *mock.MockPasswordPolicy
+	mfaRepository   *mock.MockMFARepository
+
+	loginAttemptRepository *mock.MockLoginAttemptRepository
+	tokenGenerator         *mock.MockTokenGenerator
+	authorizer             *mock.MockAuthorizer
+	roleRepository         *mock.MockRoleRepository
+	auditRepository        *mock.MockAuditRepository
+	txManager              *mock.MockTxManager
+}
+
+// newAdminMocks creates a new adminMocks instance.
+func newAdminMocks(ctrl *gomock.Controller) *adminMocks {
+	return &adminMocks{
+		userRepository:  mock.NewMockUserRepository(ctrl),
+		tokenRepository: mock.NewMockTokenRepository(ctrl),
+		passwordHasher:  mock.NewMockPasswordHasher(ctrl),
+		passwordPolicy:  mock.NewMockPasswordPolicy(ctrl),
+		mfaRepository:   mock.NewMockMFARepository(ctrl),
+
+		loginAttemptRepository: mock.NewMockLoginAttemptRepository(ctrl),
+		tokenGenerator:         mock.NewMockTokenGenerator(ctrl),
+		authorizer:             mock.NewMockAuthorizer(ctrl),
+		roleRepository:         mock.NewMockRoleRepository(ctrl),
+		auditRepository:        mock.NewMockAuditRepository(ctrl),
+		txManager:              mock.NewMockTxManager(ctrl),
+	}
+}
+
+// adminService creates a service.AdminService using the mocks.
+func (m *adminMocks) adminService() *service.AdminService {
+	return service.NewAdminService(
+		m.userRepository,
+		m.tokenRepository,
+		m.passwordHasher,
+		m.passwordPolicy,
+		m.mfaRepository,
+		m.loginAttemptRepository,
+		m.tokenGenerator,
+		m.authorizer,
+		m.roleRepository,
+		m.auditRepository,
+		m.txManager,
+	)
+}
+
+// expectAuthorize expects the permission to be checked and returns err.
+func (m *adminMocks) expectAuthorize(permission domain.Permission, err error) {
+	m.authorizer.
+		EXPECT().
+		Authorize(
+			gomock.AssignableToTypeOf(context.Background()),
+			gomock.AssignableToTypeOf(&domain.Token{}),
+			permission,
+		).
+		Return(err)
+}
+
+// support is a role that manages users, but cannot manage products or orders.
+const support domain.UserRole = "support"
+
+// expectRoles sets up the role repository to return the built-in roles and the support role,
+// in or outside of a unit of work.
+func (m *adminMocks) expectRoles() {
+	roles := map[domain.UserRole]*domain.Role{
+		domain.Admin: domain.NewRole(domain.Admin, "", true, []domain.Permission{
+			domain.UsersRead, domain.UsersWrite, domain.UsersImpersonate, domain.ProductsWrite, domain.OrdersRead, domain.OrdersShip,
+		}),
+		domain.Warehouse: domain.NewRole(domain.Warehouse, "", true, []domain.Permission{domain.ProductsWrite, domain.OrdersRead}),
+		domain.Delivery:  domain.NewRole(domain.Delivery, "", true, []domain.Permission{domain.OrdersRead, domain.OrdersShip}),
+		domain.Client:    domain.NewRole(domain.Client, "", true, nil),
+		support:          domain.NewRole(support, "", false, []domain.Permission{domain.UsersRead, domain.UsersWrite, domain.UsersImpersonate}),
+	}
+	m.roleRepository.
+		EXPECT().
+		GetRole(gomock.Any(), gomock.AssignableToTypeOf(domain.Client)).
+		DoAndReturn(func(_ context.Context, name domain.UserRole) (*domain.Role, error) {
+			role, ok := roles[name]
+			if !ok {
+				return nil, domain.ErrRoleNotFound
+			}
+			return role, nil
+		}).
+		AnyTimes()
+}
+
 func TestAdminService_GetUsers(t *testing.T) {
+	token := &domain.Token{
+		TokenType: domain.AccessToken,
+		UserRole:  domain.Admin,
+	}
+	limit := 2
+	createdAt := time.Date(2025, 10, 15, 12, 37, 42, 664482000, time.UTC)
+	users := []domain.User{
+		{Id: uuid.New(), Username: "firstUser", CreatedAt: createdAt},
+		{Id: uuid.New(), Username: "secondUser", CreatedAt: createdAt},
+	}
+	role := domain.Warehouse
+	username := "user"
+
 	tests := []struct {
 		name           string
-		token          *domain.Token
 		get            *domain.GetUsers
 		expectedError  error
 		expectedResult *domain.UsersResult
-		mockSetup      func(
-			mockUserRepository *mock.MockUserRepository,
-			mockTokenRepository *mock.MockTokenRepository,
-			mockPasswordHasher *mock.MockPasswordHasher,
-		)
+		mockSetup      func(m *adminMocks)
 	}{
 		{
-			name: "success",
-			token: &domain.Token{
-				TokenType: domain.AccessToken,
-				UserRole:  domain.Admin,
+			name: "success by id without limit",
+			get: &domain.GetUsers{
+				Filter: domain.UserFilter{Id: &users[0].Id},
+				SortBy: domain.UserSortCreatedAt,
+			},
+			expectedResult: &domain.UsersResult{
+				Users:  users[:1],
+				Cursor: domain.NewUserCursor(&users[0], domain.UserSortCreatedAt, false),
+			},
+			mockSetup: func(m *adminMocks) {
+				m.expectAuthorize(domain.UsersRead, nil)
+				m.userRepository.
+					EXPECT().
+					GetUsers(
+						gomock.AssignableToTypeOf(context.Background()),
+						gomock.Cond(func(get *domain.GetUsers) bool {
+							return *get.Limit == 1
+						}),
+					).
+					Return(users[:1], nil)
 			},
+		}, {
+			name: "success combined filters with cursor and total",
 			get: &domain.GetUsers{
-				Id: &uuid.UUID{},
+				Filter:    domain.UserFilter{Username: &username, Role: &role},
+				SortBy:    domain.UserSortCreatedAt,
+				Cursor:    &domain.UserCursor{SortBy: domain.UserSortCreatedAt},
+				Limit:     &limit,
+				WithTotal: true,
 			},
-			expectedError: nil,
 			expectedResult: &domain.UsersResult{
-				Users: []domain.User{
-					{
-						Id:       uuid.UUID{},
-						Username: "fetchedUser",
-					},
-				},
-			},
-			mockSetup: func(
-				mockUserRepository *mock.MockUserRepository,
-				mockTokenRepository *mock.MockTokenRepository,
-				mockPasswordHasher *mock.MockPasswordHasher,
-			) {
-				mockUserRepository.
+				Users:  users,
+				Cursor: domain.NewUserCursor(&users[1], domain.UserSortCreatedAt, false),
+				Total:  &limit,
+			},
+			mockSetup: func(m *adminMocks) {
+				m.expectAuthorize(domain.UsersRead, nil)
+				m.userRepository.
 					EXPECT().
-					GetUserById(
+					GetUsers(gomock.AssignableToTypeOf(context.Background()), gomock.AssignableToTypeOf(&domain.GetUsers{})).
+					Return(users, nil)
+				m.userRepository.
+					EXPECT().
+					CountUsers(
 						gomock.AssignableToTypeOf(context.Background()),
-						gomock.AssignableToTypeOf(uuid.UUID{}),
+						&domain.UserFilter{Username: &username, Role: &role},
 					).
-					Return(&domain.User{
-						Id:       uuid.UUID{},
-						Username: "fetchedUser",
-					}, nil)
-
+					Return(2, nil)
 			},
 		}, {
-			name: "error invalid token type",
-			token: &domain.Token{
-				TokenType: domain.RefreshToken,
-				UserRole:  domain.Admin,
+			name: "success last page without cursor",
+			get: &domain.GetUsers{
+				SortBy:     domain.UserSortUsername,
+				Descending: true,
+				Limit:      &limit,
 			},
-			expectedError: domain.ErrInvalidTokenType,
-			mockSetup: func(
-				mockUserRepository *mock.MockUserRepository,
-				mockTokenRepository *mock.MockTokenRepository,
-				mockPasswordHasher *mock.MockPasswordHasher,
-			) {
-
+			expectedResult: &domain.UsersResult{
+				Users: users[:1],
+			},
+			mockSetup: func(m *adminMocks) {
+				m.expectAuthorize(domain.UsersRead, nil)
+				m.userRepository.
+					EXPECT().
+					GetUsers(gomock.AssignableToTypeOf(context.Background()), gomock.AssignableToTypeOf(&domain.GetUsers{})).
+					Return(users[:1], nil)
 			},
 		}, {
-			name: "error invalid user role",
-			token: &domain.Token{
-				TokenType: domain.AccessToken,
+			name: "success page without cursor",
+			get: &domain.GetUsers{
+				SortBy: domain.UserSortCreatedAt,
+				Page:   new(int),
+				Limit:  &limit,
 			},
-			expectedError: domain.ErrInvalidTokenRole,
-			mockSetup: func(
-				mockUserRepository *mock.MockUserRepository,
-				mockTokenRepository *mock.MockTokenRepository,
-				mockPasswordHasher *mock.MockPasswordHasher,
-			) {
-
+			expectedResult: &domain.UsersResult{
+				Users: users,
+			},
+			mockSetup: func(m *adminMocks) {
+				m.expectAuthorize(domain.UsersRead, nil)
+				m.userRepository.
+					EXPECT().
+					GetUsers(gomock.AssignableToTypeOf(context.Background()), gomock.AssignableToTypeOf(&domain.GetUsers{})).
+					Return(users, nil)
+			},
+		}, {
+			name: "error invalid token type",
+			get: &domain.GetUsers{
+				Limit: &limit,
+			},
+			expectedError: domain.ErrInvalidTokenType,
+			mockSetup: func(m *adminMocks) {
+				m.expectAuthorize(domain.UsersRead, domain.ErrInvalidTokenType)
 			},
 		}, {
 			name: "error limit not set",
-			token: &domain.Token{
-				TokenType: domain.AccessToken,
-				UserRole:  domain.Admin,
+			get: &domain.GetUsers{
+				SortBy: domain.UserSortCreatedAt,
 			},
 			expectedError: domain.ErrLimitNotSet,
+			mockSetup: func(m *adminMocks) {
+				m.expectAuthorize(domain.UsersRead, nil)
+			},
+		}, {
+			name: "error page and cursor",
 			get: &domain.GetUsers{
-				After: &time.Time{},
+				SortBy: domain.UserSortCreatedAt,
+				Page:   new(int),
+				Cursor: &domain.UserCursor{SortBy: domain.UserSortCreatedAt},
+				Limit:  &limit,
 			},
-			mockSetup: func(
-				mockUserRepository *mock.MockUserRepository,
-				mockTokenRepository *mock.MockTokenRepository,
-				mockPasswordHasher *mock.MockPasswordHasher,
-			) {
-
+			expectedError: domain.ErrInvalidQuery,
+			mockSetup: func(m *adminMocks) {
+				m.expectAuthorize(domain.UsersRead, nil)
 			},
 		}, {
-			name: "error invalid query",
-			token: &domain.Token{
-				TokenType: domain.AccessToken,
-				UserRole:  domain.Admin,
-			},
+			name: "error cursor of another sort",
 			get: &domain.GetUsers{
-				Limit: new(int),
+				SortBy: domain.UserSortUsername,
+				Cursor: &domain.UserCursor{SortBy: domain.UserSortCreatedAt},
+				Limit:  &limit,
 			},
-			expectedError: domain.ErrInvalidQuery,
-			mockSetup: func(
-				mockUserRepository *mock.MockUserRepository,
-				mockTokenRepository *mock.MockTokenRepository,
-				mockPasswordHasher *mock.MockPasswordHasher,
-			) {
-
+			expectedError: domain.ErrInvalidCursor,
+			mockSetup: func(m *adminMocks) {
+				m.expectAuthorize(domain.UsersRead, nil)
 			},
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			ctrl := gomock.NewController(t)
-			mockUserRepository := mock.NewMockUserRepository(ctrl)
-			mockTokenRepository := mock.NewMockTokenRepository(ctrl)
-			mockPasswordHasher := mock.NewMockPasswordHasher(ctrl)
-			tt.mockSetup(mockUserRepository, mockTokenRepository, mockPasswordHasher)
+			m := newAdminMocks(gomock.NewController(t))
+			tt.mockSetup(m)
 
-			result, err := service.
-				NewAdminService(mockUserRepository, mockTokenRepository, mockPasswordHasher).
-				GetUsers(context.Background(), tt.token, tt.get)
+			result, err := m.adminService().
+				GetUsers(context.Background(), token, tt.get)
 
 			if tt.expectedError == nil {
 				require.NoError(t, err)
@@ -147,123 +271,1537 @@ func TestAdminService_GetUsers(t *testing.T) {
 	}
 
 }
+func TestAdminService_CreateUser(t *testing.T) {
+	newUser := func() *domain.User {
+		return &domain.User{
+			Username: "warehouse",
+			Email:    "warehouse@email.com",
+			Password: "password",
+			Role:     domain.Warehouse,
+		}
+	}
+
+	tests := []struct {
+		name          string
+		token         *domain.Token
+		user          *domain.User
+		expectedError error
+		mockSetup     func(m *adminMocks)
+	}{
+		{
+			name:          "success",
+			token:         &domain.Token{TokenType: domain.AccessToken, UserRole: domain.Admin},
+			user:          newUser(),
+			expectedError: nil,
+			mockSetup: func(m *adminMocks) {
+				m.expectAuthorize(domain.UsersWrite, nil)
+				m.expectRoles()
+				gomock.InOrder(
+					m.passwordPolicy.EXPECT().
+						Validate("password", "warehouse", "warehouse@email.com").
+						Return(nil),
+					m.passwordHasher.EXPECT().
+						Hash("password").
+						Return("hashedPassword", nil),
+					m.userRepository.
+						EXPECT().
+						AddUser(
+							gomock.AssignableToTypeOf(context.Background()),
+							gomock.Cond(func(user *domain.User) bool {
+								return user.Password == "hashedPassword" &&
+									user.Role == domain.Warehouse &&
+									user.Status == domain.UserActive
+							}),
+							gomock.Cond(func(event *domain.AuditEvent) bool {
+								return event.Action == domain.AuditUserCreated
+							}),
+							gomock.Cond(func(outbox []*domain.Event) bool {
+								return len(outbox) == 1 &&
+									outbox[0].Type == domain.EventUserCreated &&
+									outbox[0].Payload["role"] == domain.Warehouse
+							}),
+						).
+						Return(nil),
+				)
+			},
+		}, {
+			name:          "error weak password",
+			token:         &domain.Token{TokenType: domain.AccessToken, UserRole: domain.Admin},
+			user:          newUser(),
+			expectedError: domain.ErrWeakPassword,
+			mockSetup: func(m *adminMocks) {
+				m.expectAuthorize(domain.UsersWrite, nil)
+				m.expectRoles()
+				m.passwordPolicy.EXPECT().
+					Validate("password", "warehouse", "warehouse@email.com").
+					Return(domain.NewPasswordPolicyError([]domain.PasswordViolation{domain.PasswordCommon}))
+			},
+		}, {
+			name:          "error role not found",
+			token:         &domain.Token{TokenType: domain.AccessToken, UserRole: domain.Admin},
+			user:          &domain.User{Username: "user", Email: "user@email.com", Password: "password", Role: "unknown"},
+			expectedError: domain.ErrRoleNotFound,
+			mockSetup: func(m *adminMocks) {
+				m.expectAuthorize(domain.UsersWrite, nil)
+				m.expectRoles()
+			},
+		}, {
+			name:          "error role with permissions of another role",
+			token:         &domain.Token{TokenType: domain.AccessToken, UserRole: support},
+			user:          newUser(),
+			expectedError: domain.ErrRoleNotAssignable,
+			mockSetup: func(m *adminMocks) {
+				m.expectAuthorize(domain.UsersWrite, nil)
+				m.expectRoles()
+			},
+		}, {
+			name:          "error role with permissions out of api key scopes",
+			token:         &domain.Token{TokenType: domain.AccessToken, UserRole: domain.Admin, Scopes: []domain.Permission{domain.UsersWrite}},
+			user:          newUser(),
+			expectedError: domain.ErrRoleNotAssignable,
+			mockSetup: func(m *adminMocks) {
+				m.expectAuthorize(domain.UsersWrite, nil)
+				m.expectRoles()
+			},
+		}, {
+			name:          "error invalid token role",
+			token:         &domain.Token{TokenType: domain.AccessToken, UserRole: domain.Client},
+			user:          newUser(),
+			expectedError: domain.ErrInvalidTokenRole,
+			mockSetup: func(m *adminMocks) {
+				m.expectAuthorize(domain.UsersWrite, domain.ErrInvalidTokenRole)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := newAdminMocks(gomock.NewController(t))
+			tt.mockSetup(m)
+
+			err := m.adminService().CreateUser(context.Background(), tt.token, tt.user)
+			require.ErrorIs(t, err, tt.expectedError)
+		})
+	}
+}
+
+func TestAdminService_ImportUsers(t *testing.T) {
+	admin := &domain.Token{
+		TokenType: domain.AccessToken,
+		UserRole:  domain.Admin,
+	}
+	newRows := func() []domain.UserImportRow {
+		return []domain.UserImportRow{
+			*domain.NewUserImportRow(2, domain.User{
+				Username: "warehouse",
+				Email:    "warehouse@email.com",
+				Password: "password",
+				Role:     domain.Warehouse,
+			}),
+			*domain.NewUserImportRow(3, domain.User{
+				Username: "delivery",
+				Email:    "delivery@email.com",
+				Password: "password",
+				Role:     domain.Delivery,
+			}),
+		}
+	}
+
+	tests := []struct {
+		name          string
+		token         *domain.Token
+		rows          []domain.UserImportRow
+		dryRun        bool
+		expectedError error
+		expectedLines []int
+		expectedUsers int
+		mockSetup     func(m *adminMocks)
+	}{
+		{
+			name:          "success",
+			token:         admin,
+			rows:          newRows(),
+			expectedUsers: 2,
+			mockSetup: func(m *adminMocks) {
+				m.expectAuthorize(domain.UsersWrite, nil)
+				m.expectRoles()
+				m.passwordPolicy.EXPECT().
+					Validate("password", gomock.Any(), gomock.Any()).
+					Return(nil).
+					Times(2)
+				m.userRepository.
+					EXPECT().
+					CheckImportConflicts(gomock.AssignableToTypeOf(context.Background()), gomock.Len(2)).
+					Return(nil)
+				m.passwordHasher.EXPECT().
+					Hash("password").
+					Return("hashedPassword", nil).
+					Times(2)
+				m.userRepository.
+					EXPECT().
+					AddUsers(
+						gomock.AssignableToTypeOf(context.Background()),
+						gomock.Cond(func(rows []domain.UserImportRow) bool {
+							for _, row := range rows {
+								if row.User.Password != "hashedPassword" || row.User.Status != domain.UserActive {
+									return false
+								}
+							}
+							return len(rows) == 2
+						}),
+						gomock.Cond(func(events []*domain.AuditEvent) bool {
+							return len(events) == 2 && events[0].Action == domain.AuditUserImported
+						}),
+						gomock.Cond(func(outbox []*domain.Event) bool {
+							return len(outbox) == 2 && outbox[1].Type == domain.EventUserCreated
+						}),
+					).
+					Return(nil)
+			},
+		}, {
+			name:          "success dry run",
+			token:         admin,
+			rows:          newRows(),
+			dryRun:        true,
+			expectedUsers: 2,
+			mockSetup: func(m *adminMocks) {
+				m.expectAuthorize(domain.UsersWrite, nil)
+				m.expectRoles()
+				m.passwordPolicy.EXPECT().
+					Validate("password", gomock.Any(), gomock.Any()).
+					Return(nil).
+					Times(2)
+				m.userRepository.
+					EXPECT().
+					CheckImportConflicts(gomock.AssignableToTypeOf(context.Background()), gomock.Len(2)).
+					Return(nil)
+			},
+		}, {
+			name:          "error weak password",
+			token:         admin,
+			rows:          newRows(),
+			expectedError: domain.ErrInvalidImport,
+			expectedLines: []int{3},
+			mockSetup: func(m *adminMocks) {
+				m.expectAuthorize(domain.UsersWrite, nil)
+				m.expectRoles()
+				m.passwordPolicy.EXPECT().
+					Validate("password", "warehouse", "warehouse@email.com").
+					Return(nil)
+				m.passwordPolicy.EXPECT().
+					Validate("password", "delivery", "delivery@email.com").
+					Return(domain.NewPasswordPolicyError([]domain.PasswordViolation{domain.PasswordCommon}))
+			},
+		}, {
+			name:  "error duplicate username",
+			token: admin,
+			rows: append(newRows(), *domain.NewUserImportRow(4, domain.User{
+				Username: "warehouse",
+				Email:    "other@email.com",
+				Password: "password",
+				Role:     domain.Warehouse,
+			})),
+			expectedError: domain.ErrInvalidImport,
+			expectedLines: []int{4},
+			mockSetup: func(m *adminMocks) {
+				m.expectAuthorize(domain.UsersWrite, nil)
+				m.expectRoles()
+				m.passwordPolicy.EXPECT().
+					Validate("password", gomock.Any(), gomock.Any()).
+					Return(nil).
+					Times(2)
+			},
+		}, {
+			name:          "error conflicting user",
+			token:         admin,
+			rows:          newRows(),
+			dryRun:        false,
+			expectedError: domain.ErrInvalidImport,
+			expectedLines: []int{3},
+			mockSetup: func(m *adminMocks) {
+				m.expectAuthorize(domain.UsersWrite, nil)
+				m.expectRoles()
+				m.passwordPolicy.EXPECT().
+					Validate("password", gomock.Any(), gomock.Any()).
+					Return(nil).
+					Times(2)
+				m.userRepository.
+					EXPECT().
+					CheckImportConflicts(gomock.AssignableToTypeOf(context.Background()), gomock.Len(2)).
+					Return(domain.NewUserImportError([]domain.UserImportRowError{
+						{Line: 3, Err: domain.ErrUsernameAlreadyInUse},
+					}))
+			},
+		}, {
+			name:          "error conflicting user in dry run",
+			token:         admin,
+			rows:          newRows(),
+			dryRun:        true,
+			expectedError: domain.ErrInvalidImport,
+			expectedLines: []int{3},
+			mockSetup: func(m *adminMocks) {
+				m.expectAuthorize(domain.UsersWrite, nil)
+				m.expectRoles()
+				m.passwordPolicy.EXPECT().
+					Validate("password", gomock.Any(), gomock.Any()).
+					Return(nil).
+					Times(2)
+				m.userRepository.
+					EXPECT().
+					CheckImportConflicts(gomock.AssignableToTypeOf(context.Background()), gomock.Len(2)).
+					Return(domain.NewUserImportError([]domain.UserImportRowError{
+						{Line: 3, Err: domain.ErrUsernameAlreadyInUse},
+					}))
+			},
+		}, {
+			name:          "error user added during import",
+			token:         admin,
+			rows:          newRows(),
+			expectedError: domain.ErrInvalidImport,
+			expectedLines: []int{2},
+			mockSetup: func(m *adminMocks) {
+				m.expectAuthorize(domain.UsersWrite, nil)
+				m.expectRoles()
+				m.passwordPolicy.EXPECT().
+					Validate("password", gomock.Any(), gomock.Any()).
+					Return(nil).
+					Times(2)
+				m.userRepository.
+					EXPECT().
+					CheckImportConflicts(gomock.AssignableToTypeOf(context.Background()), gomock.Len(2)).
+					Return(nil)
+				m.passwordHasher.EXPECT().
+					Hash("password").
+					Return("hashedPassword", nil).
+					Times(2)
+				m.userRepository.
+					EXPECT().
+					AddUsers(
+						gomock.AssignableToTypeOf(context.Background()),
+						gomock.Any(),
+						gomock.Any(),
+						gomock.Any(),
+					).
+					Return(domain.NewUserImportError([]domain.UserImportRowError{
+						{Line: 2, Err: domain.ErrEmailAlreadyInUse},
+					}))
+			},
+		}, {
+			name:          "error role not assignable",
+			token:         &domain.Token{TokenType: domain.AccessToken, UserRole: support},
+			rows:          newRows(),
+			expectedError: domain.ErrInvalidImport,
+			expectedLines: []int{2, 3},
+			mockSetup: func(m *adminMocks) {
+				m.expectAuthorize(domain.UsersWrite, nil)
+				m.expectRoles()
+			},
+		}, {
+			name:          "error empty import",
+			token:         admin,
+			expectedError: domain.ErrEmptyImport,
+			mockSetup: func(m *adminMocks) {
+				m.expectAuthorize(domain.UsersWrite, nil)
+			},
+		}, {
+			name:          "error import too large",
+			token:         admin,
+			rows:          make([]domain.UserImportRow, domain.MaxUserImportRows+1),
+			expectedError: domain.ErrImportTooLarge,
+			mockSetup: func(m *adminMocks) {
+				m.expectAuthorize(domain.UsersWrite, nil)
+			},
+		}, {
+			name:          "error invalid token role",
+			token:         admin,
+			rows:          newRows(),
+			expectedError: domain.ErrInvalidTokenRole,
+			mockSetup: func(m *adminMocks) {
+				m.expectAuthorize(domain.UsersWrite, domain.ErrInvalidTokenRole)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := newAdminMocks(gomock.NewController(t))
+			tt.mockSetup(m)
+
+			users, err := m.adminService().ImportUsers(context.Background(), tt.token, tt.rows, tt.dryRun)
+			require.ErrorIs(t, err, tt.expectedError)
+			require.Equal(t, tt.expectedUsers, users)
+
+			if tt.expectedLines != nil {
+				var importErr *domain.UserImportError
+				require.ErrorAs(t, err, &importErr)
+
+				lines := make([]int, 0, len(importErr.Rows))
+				for _, row := range importErr.Rows {
+					lines = append(lines, row.Line)
+				}
+				require.Equal(t, tt.expectedLines, lines)
+			}
+		})
+	}
+}
+
+func TestAdminService_ExportUsers(t *testing.T) {
+	token := &domain.Token{
+		TokenType: domain.AccessToken,
+		UserRole:  domain.Admin,
+	}
+	role := domain.Warehouse
+	filter := domain.NewUserFilter(nil, nil, nil, &role, nil, nil, nil, nil, nil)
+
+	tests := []struct {
+		name          string
+		expectedError error
+		mockSetup     func(m *adminMocks)
+	}{
+		{
+			name:          "success",
+			expectedError: nil,
+			mockSetup: func(m *adminMocks) {
+				m.expectAuthorize(domain.UsersRead, nil)
+				m.userRepository.
+					EXPECT().
+					ExportUsers(gomock.AssignableToTypeOf(context.Background()), filter, gomock.Any()).
+					DoAndReturn(func(_ context.Context, _ *domain.UserFilter, fn func(user *domain.User) error) error {
+						return fn(&domain.User{Username: "warehouse", Role: domain.Warehouse})
+					})
+			},
+		}, {
+			name:          "error invalid token role",
+			expectedError: domain.ErrInvalidTokenRole,
+			mockSetup: func(m *adminMocks) {
+				m.expectAuthorize(domain.UsersRead, domain.ErrInvalidTokenRole)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := newAdminMocks(gomock.NewController(t))
+			tt.mockSetup(m)
+
+			var exported []string
+			err := m.adminService().ExportUsers(context.Background(), token, filter, func(user *domain.User) error {
+				exported = append(exported, user.Username)
+				return nil
+			})
+			require.ErrorIs(t, err, tt.expectedError)
+			if tt.expectedError == nil {
+				require.Equal(t, []string{"warehouse"}, exported)
+			}
+		})
+	}
+}
+
 func TestAdminService_UpdateUser(t *testing.T) {
 	username := "newUsername"
+	password := "newUsername_1"
+	role := domain.Warehouse
+	adminId := uuid.New()
+	userId := uuid.New()
 
 	tests := []struct {
 		name          string
 		token         *domain.Token
 		update        *domain.UserUpdate
 		expectedError error
-		mockSetup     func(
-			mockUserRepository *mock.MockUserRepository,
-			mockTokenRepository *mock.MockTokenRepository,
-			mockPasswordHasher *mock.MockPasswordHasher,
-		)
+		mockSetup     func(m *adminMocks)
 	}{
 		{
 			name: "success",
 			token: &domain.Token{
 				TokenType: domain.AccessToken,
+				UserId:    adminId,
 				UserRole:  domain.Admin,
 			},
 			update: &domain.UserUpdate{
-				Id:       uuid.UUID{},
+				Id:       userId,
 				Username: &username,
 			},
 			expectedError: nil,
-			mockSetup: func(
-				mockUserRepository *mock.MockUserRepository,
-				mockTokenRepository *mock.MockTokenRepository,
-				mockPasswordHasher *mock.MockPasswordHasher,
-			) {
-				mockUserRepository.
+			mockSetup: func(m *adminMocks) {
+				m.expectAuthorize(domain.UsersWrite, nil)
+				m.expectRoles()
+				m.userRepository.
 					EXPECT().
-					UpdateUser(
+					GetUserById(
 						gomock.AssignableToTypeOf(context.Background()),
+						userId,
+					).
+					Return(&domain.User{Id: userId, Username: "oldUsername", Role: domain.Client}, nil)
+				expectWithinTx(m.txManager)
+				m.userRepository.
+					EXPECT().
+					UpdateUser(
+						inTx(),
 						gomock.Eq(&domain.UserUpdate{
-							Id:       uuid.UUID{},
+							Id:       userId,
 							Username: &username,
 						}),
+						gomock.Cond(func(event *domain.AuditEvent) bool {
+							return event.Action == domain.AuditUserUpdated &&
+								*event.ActorId == adminId &&
+								event.Changes["username"] == domain.AuditChange{Before: "oldUsername", After: username}
+						}),
+						gomock.Nil(),
 					).
 					Return(nil)
-				mockTokenRepository.
+				m.tokenRepository.
 					EXPECT().
 					DeleteAllTokensByUserId(
+						inTx(),
+						userId,
+						gomock.AssignableToTypeOf(&domain.AuditEvent{}),
+					).
+					Return(nil)
+			},
+		}, {
+			name: "success role change publishes event",
+			token: &domain.Token{
+				TokenType: domain.AccessToken,
+				UserId:    adminId,
+				UserRole:  domain.Admin,
+			},
+			update: &domain.UserUpdate{
+				Id:   userId,
+				Role: &role,
+			},
+			expectedError: nil,
+			mockSetup: func(m *adminMocks) {
+				m.expectAuthorize(domain.UsersWrite, nil)
+				m.expectRoles()
+				m.userRepository.
+					EXPECT().
+					GetUserById(
 						gomock.AssignableToTypeOf(context.Background()),
-						gomock.AssignableToTypeOf(uuid.UUID{}),
+						userId,
+					).
+					Return(&domain.User{Id: userId, Username: "oldUsername", Role: domain.Client}, nil)
+				expectWithinTx(m.txManager)
+				m.userRepository.
+					EXPECT().
+					UpdateUser(
+						inTx(),
+						gomock.AssignableToTypeOf(&domain.UserUpdate{}),
+						gomock.AssignableToTypeOf(&domain.AuditEvent{}),
+						gomock.Cond(func(outbox []*domain.Event) bool {
+							return len(outbox) == 1 &&
+								outbox[0].Type == domain.EventUserRoleChanged &&
+								outbox[0].AggregateId == userId &&
+								outbox[0].Payload["before"] == domain.Client &&
+								outbox[0].Payload["after"] == domain.Warehouse
+						}),
+					).
+					Return(nil)
+				m.tokenRepository.
+					EXPECT().
+					DeleteAllTokensByUserId(
+						inTx(),
+						userId,
+						gomock.AssignableToTypeOf(&domain.AuditEvent{}),
 					).
 					Return(nil)
 			},
 		}, {
-			name: "error invalid token type",
+			name: "error revoking tokens rolls back update",
 			token: &domain.Token{
-				TokenType: domain.RefreshToken,
+				TokenType: domain.AccessToken,
+				UserId:    adminId,
 				UserRole:  domain.Admin,
 			},
 			update: &domain.UserUpdate{
-				Id:       uuid.UUID{},
+				Id:       userId,
 				Username: &username,
 			},
-			expectedError: domain.ErrInvalidTokenType,
-			mockSetup: func(
-				mockUserRepository *mock.MockUserRepository,
-				mockTokenRepository *mock.MockTokenRepository,
-				mockPasswordHasher *mock.MockPasswordHasher,
-			) {
-
+			expectedError: domain.ErrInternal,
+			mockSetup: func(m *adminMocks) {
+				m.expectAuthorize(domain.UsersWrite, nil)
+				m.expectRoles()
+				m.userRepository.
+					EXPECT().
+					GetUserById(
+						gomock.AssignableToTypeOf(context.Background()),
+						userId,
+					).
+					Return(&domain.User{Id: userId, Username: "oldUsername", Role: domain.Client}, nil)
+				gomock.InOrder(
+					expectWithinTx(m.txManager),
+					m.userRepository.
+						EXPECT().
+						UpdateUser(
+							inTx(),
+							gomock.AssignableToTypeOf(&domain.UserUpdate{}),
+							gomock.AssignableToTypeOf(&domain.AuditEvent{}),
+							gomock.Nil(),
+						).
+						Return(nil),
+					m.tokenRepository.
+						EXPECT().
+						DeleteAllTokensByUserId(
+							inTx(),
+							userId,
+							gomock.AssignableToTypeOf(&domain.AuditEvent{}),
+						).
+						Return(domain.ErrInternal),
+				)
 			},
 		}, {
-			name: "error invalid token role",
+			name: "error user not found",
 			token: &domain.Token{
 				TokenType: domain.AccessToken,
-				UserRole:  domain.Client,
+				UserId:    adminId,
+				UserRole:  domain.Admin,
 			},
 			update: &domain.UserUpdate{
-				Id:       uuid.UUID{},
+				Id:       userId,
 				Username: &username,
 			},
-			expectedError: domain.ErrInvalidTokenRole,
-			mockSetup: func(
-				mockUserRepository *mock.MockUserRepository,
-				mockTokenRepository *mock.MockTokenRepository,
-				mockPasswordHasher *mock.MockPasswordHasher,
-			) {
-
+			expectedError: domain.ErrUserNotFound,
+			mockSetup: func(m *adminMocks) {
+				m.expectAuthorize(domain.UsersWrite, nil)
+				m.expectRoles()
+				m.userRepository.
+					EXPECT().
+					GetUserById(
+						gomock.AssignableToTypeOf(context.Background()),
+						userId,
+					).
+					Return(nil, domain.ErrUserNotFound)
 			},
 		}, {
-			name: "error no fields to update",
+			name: "error weak password",
 			token: &domain.Token{
 				TokenType: domain.AccessToken,
+				UserId:    adminId,
 				UserRole:  domain.Admin,
 			},
 			update: &domain.UserUpdate{
-				Id: uuid.UUID{},
+				Id:       userId,
+				Username: &username,
+				Password: &password,
 			},
-			expectedError: domain.ErrNoFieldsToUpdate,
-			mockSetup: func(
-				mockUserRepository *mock.MockUserRepository,
-				mockTokenRepository *mock.MockTokenRepository,
-				mockPasswordHasher *mock.MockPasswordHasher,
-			) {
-
+			expectedError: domain.ErrWeakPassword,
+			mockSetup: func(m *adminMocks) {
+				m.expectAuthorize(domain.UsersWrite, nil)
+				m.expectRoles()
+				m.userRepository.
+					EXPECT().
+					GetUserById(
+						gomock.AssignableToTypeOf(context.Background()),
+						userId,
+					).
+					Return(&domain.User{Id: userId, Username: "oldUsername", Email: "user@email.com", Role: domain.Client}, nil)
+				m.passwordPolicy.
+					EXPECT().
+					Validate(password, username, "user@email.com").
+					Return(domain.NewPasswordPolicyError([]domain.PasswordViolation{domain.PasswordContainsUsername}))
+			},
+		}, {
+			name: "error user with permissions of another role",
+			token: &domain.Token{
+				TokenType: domain.AccessToken,
+				UserId:    adminId,
+				UserRole:  support,
+			},
+			update: &domain.UserUpdate{
+				Id:       userId,
+				Password: &password,
+			},
+			expectedError: domain.ErrRoleNotAssignable,
+			mockSetup: func(m *adminMocks) {
+				m.expectAuthorize(domain.UsersWrite, nil)
+				m.expectRoles()
+				m.userRepository.
+					EXPECT().
+					GetUserById(
+						gomock.AssignableToTypeOf(context.Background()),
+						userId,
+					).
+					Return(&domain.User{Id: userId, Username: "oldUsername", Role: domain.Admin}, nil)
+			},
+		}, {
+			name: "error role with permissions of another role",
+			token: &domain.Token{
+				TokenType: domain.AccessToken,
+				UserId:    adminId,
+				UserRole:  support,
+			},
+			update: &domain.UserUpdate{
+				Id:   userId,
+				Role: &role,
+			},
+			expectedError: domain.ErrRoleNotAssignable,
+			mockSetup: func(m *adminMocks) {
+				m.expectAuthorize(domain.UsersWrite, nil)
+				m.expectRoles()
+				m.userRepository.
+					EXPECT().
+					GetUserById(
+						gomock.AssignableToTypeOf(context.Background()),
+						userId,
+					).
+					Return(&domain.User{Id: userId, Username: "oldUsername", Role: domain.Client}, nil)
+			},
+		}, {
+			name: "error invalid token type",
+			token: &domain.Token{
+				TokenType: domain.RefreshToken,
+				UserRole:  domain.Admin,
+			},
+			update: &domain.UserUpdate{
+				Id:       uuid.UUID{},
+				Username: &username,
+			},
+			expectedError: domain.ErrInvalidTokenType,
+			mockSetup: func(m *adminMocks) {
+				m.expectAuthorize(domain.UsersWrite, domain.ErrInvalidTokenType)
+			},
+		}, {
+			name: "error invalid token role",
+			token: &domain.Token{
+				TokenType: domain.AccessToken,
+				UserRole:  domain.Client,
+			},
+			update: &domain.UserUpdate{
+				Id:       uuid.UUID{},
+				Username: &username,
+			},
+			expectedError: domain.ErrInvalidTokenRole,
+			mockSetup: func(m *adminMocks) {
+				m.expectAuthorize(domain.UsersWrite, domain.ErrInvalidTokenRole)
+			},
+		}, {
+			name: "error no fields to update",
+			token: &domain.Token{
+				TokenType: domain.AccessToken,
+				UserRole:  domain.Admin,
+			},
+			update: &domain.UserUpdate{
+				Id: uuid.UUID{},
+			},
+			expectedError: domain.ErrNoFieldsToUpdate,
+			mockSetup: func(m *adminMocks) {
+				m.expectAuthorize(domain.UsersWrite, nil)
 			},
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			ctrl := gomock.NewController(t)
-			mockUserRepository := mock.NewMockUserRepository(ctrl)
-			mockTokenRepository := mock.NewMockTokenRepository(ctrl)
-			mockPasswordHasher := mock.NewMockPasswordHasher(ctrl)
+			m := newAdminMocks(gomock.NewController(t))
+			tt.mockSetup(m)
 
-			tt.mockSetup(mockUserRepository, mockTokenRepository, mockPasswordHasher)
-
-			err := service.
-				NewAdminService(mockUserRepository, mockTokenRepository, mockPasswordHasher).
+			err := m.adminService().
 				UpdateUser(context.Background(), tt.token, tt.update)
 			require.ErrorIs(t, err, tt.expectedError)
 		})
 	}
 }
+
+func TestAdminService_SuspendUser(t *testing.T) {
+	adminId := uuid.New()
+	id := uuid.New()
+	admin := &domain.Token{UserId: adminId, TokenType: domain.AccessToken, UserRole: domain.Admin}
+
+	tests := []struct {
+		name          string
+		token         *domain.Token
+		id            uuid.UUID
+		expectedError error
+		mockSetup     func(m *adminMocks)
+	}{
+		{
+			name:          "success",
+			token:         admin,
+			id:            id,
+			expectedError: nil,
+			mockSetup: func(m *adminMocks) {
+				m.expectAuthorize(domain.UsersWrite, nil)
+				m.expectRoles()
+				gomock.InOrder(
+					m.userRepository.
+						EXPECT().
+						GetUserById(gomock.AssignableToTypeOf(context.Background()), id).
+						Return(&domain.User{Id: id, Role: domain.Warehouse, Status: domain.UserActive}, nil),
+					m.userRepository.
+						EXPECT().
+						SetUserStatus(
+							gomock.AssignableToTypeOf(context.Background()),
+							id,
+							domain.UserSuspended,
+							gomock.Cond(func(event *domain.AuditEvent) bool {
+								return event.Action == domain.AuditUserSuspended && *event.ActorId == adminId
+							}),
+						).
+						Return(nil),
+				)
+			},
+		}, {
+			name:          "error user with permissions the actor lacks",
+			token:         &domain.Token{UserId: adminId, TokenType: domain.AccessToken, UserRole: support},
+			id:            id,
+			expectedError: domain.ErrRoleNotAssignable,
+			mockSetup: func(m *adminMocks) {
+				m.expectAuthorize(domain.UsersWrite, nil)
+				m.expectRoles()
+				m.userRepository.
+					EXPECT().
+					GetUserById(gomock.AssignableToTypeOf(context.Background()), id).
+					Return(&domain.User{Id: id, Role: domain.Admin, Status: domain.UserActive}, nil)
+			},
+		}, {
+			name:          "error own account",
+			token:         admin,
+			id:            adminId,
+			expectedError: domain.ErrSelfLockout,
+			mockSetup: func(m *adminMocks) {
+				m.expectAuthorize(domain.UsersWrite, nil)
+			},
+		}, {
+			name:          "error user not found",
+			token:         admin,
+			id:            id,
+			expectedError: domain.ErrUserNotFound,
+			mockSetup: func(m *adminMocks) {
+				m.expectAuthorize(domain.UsersWrite, nil)
+				m.userRepository.
+					EXPECT().
+					GetUserById(gomock.AssignableToTypeOf(context.Background()), id).
+					Return(nil, domain.ErrUserNotFound)
+			},
+		}, {
+			name:          "error invalid token role",
+			token:         admin,
+			id:            id,
+			expectedError: domain.ErrInvalidTokenRole,
+			mockSetup: func(m *adminMocks) {
+				m.expectAuthorize(domain.UsersWrite, domain.ErrInvalidTokenRole)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := newAdminMocks(gomock.NewController(t))
+			tt.mockSetup(m)
+
+			err := m.adminService().SuspendUser(context.Background(), tt.token, tt.id)
+			require.ErrorIs(t, err, tt.expectedError)
+		})
+	}
+}
+
+func TestAdminService_DeleteUser(t *testing.T) {
+	adminId := uuid.New()
+	id := uuid.New()
+	admin := &domain.Token{UserId: adminId, TokenType: domain.AccessToken, UserRole: domain.Admin}
+
+	tests := []struct {
+		name          string
+		token         *domain.Token
+		id            uuid.UUID
+		expectedError error
+		mockSetup     func(m *adminMocks)
+	}{
+		{
+			name:          "success",
+			token:         admin,
+			id:            id,
+			expectedError: nil,
+			mockSetup: func(m *adminMocks) {
+				m.expectAuthorize(domain.UsersWrite, nil)
+				m.expectRoles()
+				gomock.InOrder(
+					m.userRepository.
+						EXPECT().
+						GetUserById(gomock.AssignableToTypeOf(context.Background()), id).
+						Return(&domain.User{Id: id, Role: domain.Client}, nil),
+					expectWithinTx(m.txManager),
+					m.userRepository.
+						EXPECT().
+						DeleteUser(
+							inTx(),
+							id,
+							gomock.Cond(func(event *domain.AuditEvent) bool {
+								return event.Action == domain.AuditUserDeleted
+							}),
+						).
+						Return(nil),
+					m.tokenRepository.
+						EXPECT().
+						DeleteAllTokensByUserId(
+							inTx(),
+							id,
+							gomock.Cond(func(event *domain.AuditEvent) bool {
+								return event.Action == domain.AuditTokensRevoked
+							}),
+						).
+						Return(nil),
+				)
+			},
+		}, {
+			name:          "error revoking tokens rolls back deletion",
+			token:         admin,
+			id:            id,
+			expectedError: domain.ErrInternal,
+			mockSetup: func(m *adminMocks) {
+				m.expectAuthorize(domain.UsersWrite, nil)
+				m.expectRoles()
+				gomock.InOrder(
+					m.userRepository.
+						EXPECT().
+						GetUserById(gomock.AssignableToTypeOf(context.Background()), id).
+						Return(&domain.User{Id: id, Role: domain.Client}, nil),
+					expectWithinTx(m.txManager),
+					m.userRepository.
+						EXPECT().
+						DeleteUser(
+							inTx(),
+							id,
+							gomock.AssignableToTypeOf(&domain.AuditEvent{}),
+						).
+						Return(nil),
+					m.tokenRepository.
+						EXPECT().
+						DeleteAllTokensByUserId(
+							inTx(),
+							id,
+							gomock.AssignableToTypeOf(&domain.AuditEvent{}),
+						).
+						Return(domain.ErrInternal),
+				)
+			},
+		}, {
+			name:          "error own account",
+			token:         admin,
+			id:            adminId,
+			expectedError: domain.ErrSelfLockout,
+			mockSetup: func(m *adminMocks) {
+				m.expectAuthorize(domain.UsersWrite, nil)
+			},
+		}, {
+			name:          "error user not found",
+			token:         admin,
+			id:            id,
+			expectedError: domain.ErrUserNotFound,
+			mockSetup: func(m *adminMocks) {
+				m.expectAuthorize(domain.UsersWrite, nil)
+				m.userRepository.
+					EXPECT().
+					GetUserById(gomock.AssignableToTypeOf(context.Background()), id).
+					Return(nil, domain.ErrUserNotFound)
+			},
+		}, {
+			name:          "error user with permissions the actor lacks",
+			token:         &domain.Token{UserId: adminId, TokenType: domain.AccessToken, UserRole: support},
+			id:            id,
+			expectedError: domain.ErrRoleNotAssignable,
+			mockSetup: func(m *adminMocks) {
+				m.expectAuthorize(domain.UsersWrite, nil)
+				m.expectRoles()
+				m.userRepository.
+					EXPECT().
+					GetUserById(gomock.AssignableToTypeOf(context.Background()), id).
+					Return(&domain.User{Id: id, Role: domain.Admin}, nil)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := newAdminMocks(gomock.NewController(t))
+			tt.mockSetup(m)
+
+			err := m.adminService().DeleteUser(context.Background(), tt.token, tt.id)
+			require.ErrorIs(t, err, tt.expectedError)
+		})
+	}
+}
+
+func TestAdminService_RestoreUser(t *testing.T) {
+	id := uuid.New()
+
+	tests := []struct {
+		name          string
+		token         *domain.Token
+		expectedError error
+		mockSetup     func(m *adminMocks)
+	}{
+		{
+			name:          "success",
+			token:         &domain.Token{TokenType: domain.AccessToken, UserRole: domain.Admin},
+			expectedError: nil,
+			mockSetup: func(m *adminMocks) {
+				m.expectAuthorize(domain.UsersWrite, nil)
+				m.expectRoles()
+				gomock.InOrder(
+					expectWithinTx(m.txManager),
+					m.userRepository.
+						EXPECT().
+						RestoreUser(
+							inTx(),
+							id,
+							gomock.Cond(func(event *domain.AuditEvent) bool {
+								return event.Action == domain.AuditUserRestored
+							}),
+						).
+						Return(nil),
+					m.userRepository.
+						EXPECT().
+						GetUserById(inTx(), id).
+						Return(&domain.User{Id: id, Role: domain.Client}, nil),
+				)
+			},
+		}, {
+			name:          "error user with permissions the actor lacks rolls back restore",
+			token:         &domain.Token{TokenType: domain.AccessToken, UserRole: support},
+			expectedError: domain.ErrRoleNotAssignable,
+			mockSetup: func(m *adminMocks) {
+				m.expectAuthorize(domain.UsersWrite, nil)
+				m.expectRoles()
+				gomock.InOrder(
+					expectWithinTx(m.txManager),
+					m.userRepository.
+						EXPECT().
+						RestoreUser(
+							inTx(),
+							id,
+							gomock.AssignableToTypeOf(&domain.AuditEvent{}),
+						).
+						Return(nil),
+					m.userRepository.
+						EXPECT().
+						GetUserById(inTx(), id).
+						Return(&domain.User{Id: id, Role: domain.Admin}, nil),
+				)
+			},
+		}, {
+			name:          "error user not deleted",
+			token:         &domain.Token{TokenType: domain.AccessToken, UserRole: domain.Admin},
+			expectedError: domain.ErrUserNotDeleted,
+			mockSetup: func(m *adminMocks) {
+				m.expectAuthorize(domain.UsersWrite, nil)
+				expectWithinTx(m.txManager)
+				m.userRepository.
+					EXPECT().
+					RestoreUser(
+						inTx(),
+						id,
+						gomock.AssignableToTypeOf(&domain.AuditEvent{}),
+					).
+					Return(domain.ErrUserNotDeleted)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := newAdminMocks(gomock.NewController(t))
+			tt.mockSetup(m)
+
+			err := m.adminService().RestoreUser(context.Background(), tt.token, id)
+			require.ErrorIs(t, err, tt.expectedError)
+		})
+	}
+}
+
+func TestAdminService_UnlockUser(t *testing.T) {
+	id := uuid.New()
+
+	tests := []struct {
+		name          string
+		token         *domain.Token
+		expectedError error
+		mockSetup     func(m *adminMocks)
+	}{
+		{
+			name: "success",
+			token: &domain.Token{
+				TokenType: domain.AccessToken,
+				UserRole:  domain.Admin,
+			},
+			expectedError: nil,
+			mockSetup: func(m *adminMocks) {
+				m.expectAuthorize(domain.UsersWrite, nil)
+				m.expectRoles()
+				gomock.InOrder(
+					m.userRepository.
+						EXPECT().
+						GetUserById(
+							gomock.AssignableToTypeOf(context.Background()),
+							id,
+						).
+						Return(&domain.User{Id: id, Username: "Username", Role: domain.Client}, nil),
+					m.loginAttemptRepository.
+						EXPECT().
+						ResetLoginAttempts(
+							gomock.AssignableToTypeOf(context.Background()),
+							"user:username",
+						).
+						Return(nil),
+					m.loginAttemptRepository.
+						EXPECT().
+						ResetLoginAttempts(
+							gomock.AssignableToTypeOf(context.Background()),
+							"mfa:"+id.String(),
+						).
+						Return(nil),
+					m.auditRepository.
+						EXPECT().
+						AddAuditEvent(
+							gomock.AssignableToTypeOf(context.Background()),
+							gomock.Cond(func(event *domain.AuditEvent) bool {
+								return event.Action == domain.AuditUserUnlocked && event.TargetId == id.String()
+							}),
+						).
+						Return(nil),
+				)
+			},
+		}, {
+			name: "error user not found",
+			token: &domain.Token{
+				TokenType: domain.AccessToken,
+				UserRole:  domain.Admin,
+			},
+			expectedError: domain.ErrUserNotFound,
+			mockSetup: func(m *adminMocks) {
+				m.expectAuthorize(domain.UsersWrite, nil)
+				m.userRepository.
+					EXPECT().
+					GetUserById(
+						gomock.AssignableToTypeOf(context.Background()),
+						id,
+					).
+					Return(nil, domain.ErrUserNotFound)
+			},
+		}, {
+			name: "error user with permissions the actor lacks",
+			token: &domain.Token{
+				TokenType: domain.AccessToken,
+				UserRole:  support,
+			},
+			expectedError: domain.ErrRoleNotAssignable,
+			mockSetup: func(m *adminMocks) {
+				m.expectAuthorize(domain.UsersWrite, nil)
+				m.expectRoles()
+				m.userRepository.
+					EXPECT().
+					GetUserById(
+						gomock.AssignableToTypeOf(context.Background()),
+						id,
+					).
+					Return(&domain.User{Id: id, Username: "Username", Role: domain.Admin}, nil)
+			},
+		}, {
+			name: "error invalid token role",
+			token: &domain.Token{
+				TokenType: domain.AccessToken,
+				UserRole:  domain.Warehouse,
+			},
+			expectedError: domain.ErrInvalidTokenRole,
+			mockSetup: func(m *adminMocks) {
+				m.expectAuthorize(domain.UsersWrite, domain.ErrInvalidTokenRole)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := newAdminMocks(gomock.NewController(t))
+			tt.mockSetup(m)
+
+			err := m.adminService().UnlockUser(context.Background(), tt.token, id)
+			require.ErrorIs(t, err, tt.expectedError)
+		})
+	}
+}
+
+func TestAdminService_RevokeUserSessions(t *testing.T) {
+	adminId := uuid.New()
+	id := uuid.New()
+
+	tests := []struct {
+		name          string
+		token         *domain.Token
+		expectedError error
+		mockSetup     func(m *adminMocks)
+	}{
+		{
+			name: "success",
+			token: &domain.Token{
+				UserId:    adminId,
+				TokenType: domain.AccessToken,
+				UserRole:  domain.Admin,
+			},
+			expectedError: nil,
+			mockSetup: func(m *adminMocks) {
+				m.expectAuthorize(domain.UsersWrite, nil)
+				m.expectRoles()
+				gomock.InOrder(
+					m.userRepository.
+						EXPECT().
+						GetUserById(
+							gomock.AssignableToTypeOf(context.Background()),
+							id,
+						).
+						Return(&domain.User{Id: id, Role: domain.Client}, nil),
+					m.tokenRepository.
+						EXPECT().
+						DeleteAllTokensByUserId(
+							gomock.AssignableToTypeOf(context.Background()),
+							id,
+							gomock.Cond(func(event *domain.AuditEvent) bool {
+								return event.Action == domain.AuditTokensRevoked &&
+									*event.ActorId == adminId &&
+									event.TargetId == id.String()
+							}),
+						).
+						Return(nil),
+				)
+			},
+		}, {
+			name: "error user not found",
+			token: &domain.Token{
+				TokenType: domain.AccessToken,
+				UserRole:  domain.Admin,
+			},
+			expectedError: domain.ErrUserNotFound,
+			mockSetup: func(m *adminMocks) {
+				m.expectAuthorize(domain.UsersWrite, nil)
+				m.userRepository.
+					EXPECT().
+					GetUserById(
+						gomock.AssignableToTypeOf(context.Background()),
+						id,
+					).
+					Return(nil, domain.ErrUserNotFound)
+			},
+		}, {
+			name: "error user with permissions the actor lacks",
+			token: &domain.Token{
+				TokenType: domain.AccessToken,
+				UserRole:  support,
+			},
+			expectedError: domain.ErrRoleNotAssignable,
+			mockSetup: func(m *adminMocks) {
+				m.expectAuthorize(domain.UsersWrite, nil)
+				m.expectRoles()
+				m.userRepository.
+					EXPECT().
+					GetUserById(
+						gomock.AssignableToTypeOf(context.Background()),
+						id,
+					).
+					Return(&domain.User{Id: id, Username: "Username", Role: domain.Admin}, nil)
+			},
+		}, {
+			name: "error invalid token role",
+			token: &domain.Token{
+				TokenType: domain.AccessToken,
+				UserRole:  domain.Warehouse,
+			},
+			expectedError: domain.ErrInvalidTokenRole,
+			mockSetup: func(m *adminMocks) {
+				m.expectAuthorize(domain.UsersWrite, domain.ErrInvalidTokenRole)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := newAdminMocks(gomock.NewController(t))
+			tt.mockSetup(m)
+
+			err := m.adminService().RevokeUserSessions(context.Background(), tt.token, id)
+			require.ErrorIs(t, err, tt.expectedError)
+		})
+	}
+}
+
+func TestAdminService_ImpersonateUser(t *testing.T) {
+	adminId := uuid.New()
+	id := uuid.New()
+	expiresAt := time.Now().Add(15 * time.Minute)
+
+	tests := []struct {
+		name           string
+		token          *domain.Token
+		id             uuid.UUID
+		expectedError  error
+		expectedResult *domain.ImpersonationToken
+		mockSetup      func(m *adminMocks)
+	}{
+		{
+			name: "success",
+			token: &domain.Token{
+				UserId:    adminId,
+				TokenType: domain.AccessToken,
+				UserRole:  domain.Admin,
+			},
+			id:             id,
+			expectedError:  nil,
+			expectedResult: domain.NewImpersonationToken("signed", expiresAt),
+			mockSetup: func(m *adminMocks) {
+				m.expectAuthorize(domain.UsersImpersonate, nil)
+				m.expectRoles()
+				gomock.InOrder(
+					m.userRepository.
+						EXPECT().
+						GetUserById(
+							gomock.AssignableToTypeOf(context.Background()),
+							id,
+						).
+						Return(&domain.User{Id: id, Role: domain.Client, Status: domain.UserActive}, nil),
+					m.tokenGenerator.
+						EXPECT().
+						SignToken(gomock.Cond(func(token *domain.Token) bool {
+							return token.UserId == id &&
+								token.TokenType == domain.AccessToken &&
+								token.UserRole == domain.Client &&
+								*token.ActorId == adminId
+						})).
+						DoAndReturn(func(token *domain.Token) (string, error) {
+							token.ExpiresAt = expiresAt
+							return "signed", nil
+						}),
+					m.auditRepository.
+						EXPECT().
+						AddAuditEvent(
+							gomock.AssignableToTypeOf(context.Background()),
+							gomock.Cond(func(event *domain.AuditEvent) bool {
+								return event.Action == domain.AuditImpersonationStarted &&
+									*event.ActorId == adminId &&
+									event.TargetId == id.String()
+							}),
+						).
+						Return(nil),
+				)
+			},
+		}, {
+			name: "error impersonation forbidden self",
+			token: &domain.Token{
+				UserId:    adminId,
+				TokenType: domain.AccessToken,
+				UserRole:  domain.Admin,
+			},
+			id:            adminId,
+			expectedError: domain.ErrImpersonationForbidden,
+			mockSetup: func(m *adminMocks) {
+				m.expectAuthorize(domain.UsersImpersonate, nil)
+			},
+		}, {
+			name: "error impersonation forbidden role with more permissions",
+			token: &domain.Token{
+				UserId:    adminId,
+				TokenType: domain.AccessToken,
+				UserRole:  support,
+			},
+			id:            id,
+			expectedError: domain.ErrImpersonationForbidden,
+			mockSetup: func(m *adminMocks) {
+				m.expectAuthorize(domain.UsersImpersonate, nil)
+				m.expectRoles()
+				m.userRepository.
+					EXPECT().
+					GetUserById(
+						gomock.AssignableToTypeOf(context.Background()),
+						id,
+					).
+					Return(&domain.User{Id: id, Role: domain.Admin, Status: domain.UserActive}, nil)
+			},
+		}, {
+			name: "error impersonation forbidden same role",
+			token: &domain.Token{
+				UserId:    adminId,
+				TokenType: domain.AccessToken,
+				UserRole:  domain.Admin,
+			},
+			id:            id,
+			expectedError: domain.ErrImpersonationForbidden,
+			mockSetup: func(m *adminMocks) {
+				m.expectAuthorize(domain.UsersImpersonate, nil)
+				m.expectRoles()
+				m.userRepository.
+					EXPECT().
+					GetUserById(
+						gomock.AssignableToTypeOf(context.Background()),
+						id,
+					).
+					Return(&domain.User{Id: id, Role: domain.Admin, Status: domain.UserActive}, nil)
+			},
+		}, {
+			name: "error impersonation forbidden role with other permissions",
+			token: &domain.Token{
+				UserId:    adminId,
+				TokenType: domain.AccessToken,
+				UserRole:  support,
+			},
+			id:            id,
+			expectedError: domain.ErrImpersonationForbidden,
+			mockSetup: func(m *adminMocks) {
+				m.expectAuthorize(domain.UsersImpersonate, nil)
+				m.expectRoles()
+				m.userRepository.
+					EXPECT().
+					GetUserById(
+						gomock.AssignableToTypeOf(context.Background()),
+						id,
+					).
+					Return(&domain.User{Id: id, Role: domain.Warehouse, Status: domain.UserActive}, nil)
+			},
+		}, {
+			name: "error user suspended",
+			token: &domain.Token{
+				UserId:    adminId,
+				TokenType: domain.AccessToken,
+				UserRole:  domain.Admin,
+			},
+			id:            id,
+			expectedError: domain.ErrUserSuspended,
+			mockSetup: func(m *adminMocks) {
+				m.expectAuthorize(domain.UsersImpersonate, nil)
+				m.userRepository.
+					EXPECT().
+					GetUserById(
+						gomock.AssignableToTypeOf(context.Background()),
+						id,
+					).
+					Return(&domain.User{Id: id, Role: domain.Client, Status: domain.UserSuspended}, nil)
+			},
+		}, {
+			name: "error user not found",
+			token: &domain.Token{
+				UserId:    adminId,
+				TokenType: domain.AccessToken,
+				UserRole:  domain.Admin,
+			},
+			id:            id,
+			expectedError: domain.ErrUserNotFound,
+			mockSetup: func(m *adminMocks) {
+				m.expectAuthorize(domain.UsersImpersonate, nil)
+				m.userRepository.
+					EXPECT().
+					GetUserById(
+						gomock.AssignableToTypeOf(context.Background()),
+						id,
+					).
+					Return(nil, domain.ErrUserNotFound)
+			},
+		}, {
+			name: "error invalid token type api key",
+			token: &domain.Token{
+				UserId:    adminId,
+				TokenType: domain.AccessToken,
+				UserRole:  domain.Admin,
+				Scopes:    []domain.Permission{domain.UsersImpersonate},
+			},
+			id:            id,
+			expectedError: domain.ErrInvalidTokenType,
+			mockSetup: func(m *adminMocks) {
+
+			},
+		}, {
+			name: "error invalid token role",
+			token: &domain.Token{
+				UserId:    adminId,
+				TokenType: domain.AccessToken,
+				UserRole:  domain.Warehouse,
+			},
+			id:            id,
+			expectedError: domain.ErrInvalidTokenRole,
+			mockSetup: func(m *adminMocks) {
+				m.expectAuthorize(domain.UsersImpersonate, domain.ErrInvalidTokenRole)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := newAdminMocks(gomock.NewController(t))
+			tt.mockSetup(m)
+
+			result, err := m.adminService().ImpersonateUser(context.Background(), tt.token, tt.id)
+			if tt.expectedError == nil {
+				require.NoError(t, err)
+				require.Equal(t, tt.expectedResult, result)
+			} else {
+				require.ErrorIs(t, err, tt.expectedError)
+			}
+		})
+	}
+}
+
+func TestAdminService_SetMFARequirement(t *testing.T) {
+	requirement := domain.NewRoleMFARequirement(domain.Admin, true)
+
+	tests := []struct {
+		name          string
+		token         *domain.Token
+		expectedError error
+		mockSetup     func(m *adminMocks)
+	}{
+		{
+			name: "success",
+			token: &domain.Token{
+				TokenType: domain.AccessToken,
+				UserRole:  domain.Admin,
+			},
+			expectedError: nil,
+			mockSetup: func(m *adminMocks) {
+				m.expectAuthorize(domain.RolesWrite, nil)
+				m.mfaRepository.
+					EXPECT().
+					IsMFARequired(
+						gomock.AssignableToTypeOf(context.Background()),
+						domain.Admin,
+					).
+					Return(false, nil)
+				m.mfaRepository.
+					EXPECT().
+					SetRoleMFARequirement(
+						gomock.AssignableToTypeOf(context.Background()),
+						gomock.Eq(requirement),
+						gomock.Cond(func(event *domain.AuditEvent) bool {
+							return event.Changes["required"] == domain.AuditChange{Before: false, After: true}
+						}),
+					).
+					Return(nil)
+			},
+		}, {
+			name: "error invalid token type",
+			token: &domain.Token{
+				TokenType: domain.MFAToken,
+				UserRole:  domain.Admin,
+			},
+			expectedError: domain.ErrInvalidTokenType,
+			mockSetup: func(m *adminMocks) {
+				m.expectAuthorize(domain.RolesWrite, domain.ErrInvalidTokenType)
+			},
+		}, {
+			name: "error invalid token role",
+			token: &domain.Token{
+				TokenType: domain.AccessToken,
+				UserRole:  domain.Client,
+			},
+			expectedError: domain.ErrInvalidTokenRole,
+			mockSetup: func(m *adminMocks) {
+				m.expectAuthorize(domain.RolesWrite, domain.ErrInvalidTokenRole)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := newAdminMocks(gomock.NewController(t))
+			tt.mockSetup(m)
+
+			err := m.adminService().
+				SetMFARequirement(context.Background(), tt.token, requirement)
+			require.ErrorIs(t, err, tt.expectedError)
+		})
+	}
+}
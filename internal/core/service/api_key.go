@@ -0,0 +1,203 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"shop-api-go/internal/core/domain"
+	"shop-api-go/internal/core/port"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// lastUsedResolution is the minimal duration between two updates of the last use of an API key,
+// so that busy integrations do not write on every request.
+const lastUsedResolution = time.Minute
+
+// APIKeyService implements port.APIKeyService interface and provides access to API key-related business logic.
+type APIKeyService struct {
+	apiKeyRepository port.APIKeyRepository
+	apiKeyGenerator  port.APIKeyGenerator
+	userRepository   port.UserRepository
+	roleRepository   port.RoleRepository
+	authorizer       port.Authorizer
+	apiKeyPolicy     *domain.APIKeyPolicy
+}
+
+// NewAPIKeyService creates a new APIKeyService instance.
+func NewAPIKeyService(
+	apiKeyRepository port.APIKeyRepository,
+	apiKeyGenerator port.APIKeyGenerator,
+	userRepository port.UserRepository,
+	roleRepository port.RoleRepository,
+	authorizer port.Authorizer,
+	apiKeyPolicy *domain.APIKeyPolicy,
+) *APIKeyService {
+	return &APIKeyService{
+		apiKeyRepository: apiKeyRepository,
+		apiKeyGenerator:  apiKeyGenerator,
+		userRepository:   userRepository,
+		roleRepository:   roleRepository,
+		authorizer:       authorizer,
+		apiKeyPolicy:     apiKeyPolicy,
+	}
+}
+
+func (s *APIKeyService) Authenticate(ctx context.Context, secret string) (*domain.Token, error) {
+	prefix, ok := s.apiKeyGenerator.Prefix(secret)
+	if !ok {
+		return nil, domain.ErrInvalidToken
+	}
+
+	key, err := s.apiKeyRepository.GetAPIKeyByPrefix(ctx, prefix)
+	if errors.Is(err, domain.ErrAPIKeyNotFound) {
+		return nil, domain.ErrInvalidToken
+	} else if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	if !s.apiKeyGenerator.Compare(secret, key.SecretHash) || !key.IsActive(now) {
+		return nil, domain.ErrInvalidToken
+	}
+
+	user, err := s.userRepository.GetUserById(ctx, key.UserId)
+	if errors.Is(err, domain.ErrUserNotFound) {
+		return nil, domain.ErrInvalidToken
+	} else if err != nil {
+		return nil, err
+	}
+	if user.Status == domain.UserSuspended {
+		return nil, domain.ErrUserSuspended
+	}
+
+	if key.LastUsedAt == nil || now.Sub(*key.LastUsedAt) >= lastUsedResolution {
+		if err = s.apiKeyRepository.UpdateLastUsed(ctx, key.Id, now); err != nil {
+			return nil, err
+		}
+	}
+
+	token := domain.NewToken(key.Id, key.UserId, user.Role, domain.AccessToken, key.ExpiresAt)
+	token.Scopes = make([]domain.Permission, 0, len(key.Scopes))
+	token.Scopes = append(token.Scopes, key.Scopes...)
+	return token, nil
+}
+
+func (s *APIKeyService) CreatePersonalAccessToken(ctx context.Context, token *domain.Token, create *domain.CreateAPIKey) (*domain.CreatedAPIKey, error) {
+	if token.TokenType != domain.AccessToken || token.IsAPIKey() {
+		return nil, domain.ErrInvalidTokenType
+	}
+	if token.IsImpersonated() {
+		return nil, domain.ErrImpersonationForbidden
+	}
+
+	create.UserId = token.UserId
+	return s.createAPIKey(ctx, token, domain.PersonalAccessToken, create)
+}
+
+func (s *APIKeyService) CreateServiceAPIKey(ctx context.Context, token *domain.Token, create *domain.CreateAPIKey) (*domain.CreatedAPIKey, error) {
+	if token.IsAPIKey() {
+		return nil, domain.ErrInvalidTokenType
+	}
+	if err := s.authorizer.Authorize(ctx, token, domain.APIKeysWrite); err != nil {
+		return nil, err
+	}
+
+	return s.createAPIKey(ctx, token, domain.ServiceAPIKey, create)
+}
+
+func (s *APIKeyService) GetAPIKeys(ctx context.Context, token *domain.Token, userId uuid.UUID) ([]domain.APIKey, error) {
+	if token.TokenType != domain.AccessToken || token.IsAPIKey() {
+		return nil, domain.ErrInvalidTokenType
+	}
+	if userId != token.UserId {
+		if err := s.authorizer.Authorize(ctx, token, domain.APIKeysRead); err != nil {
+			return nil, err
+		}
+	}
+
+	return s.apiKeyRepository.GetAPIKeysByUserId(ctx, userId)
+}
+
+func (s *APIKeyService) RevokeAPIKey(ctx context.Context, token *domain.Token, id uuid.UUID) error {
+	// A leaked key must not be able to list or revoke the other keys of its user.
+	if token.TokenType != domain.AccessToken || token.IsAPIKey() {
+		return domain.ErrInvalidTokenType
+	}
+	if token.IsImpersonated() {
+		return domain.ErrImpersonationForbidden
+	}
+
+	key, err := s.apiKeyRepository.GetAPIKeyById(ctx, id)
+	if err != nil {
+		return err
+	}
+	if key.UserId != token.UserId {
+		if err = s.authorizer.Authorize(ctx, token, domain.APIKeysWrite); err != nil {
+			return err
+		}
+	}
+
+	event := domain.NewAuditEvent(ctx, &token.UserId, domain.AuditAPIKeyRevoked, domain.AuditTargetAPIKey, id.String()).
+		WithChange("revoked", false, true)
+	return s.apiKeyRepository.RevokeAPIKey(ctx, id, event)
+}
+
+// createAPIKey validates the expiry and the scopes, generates a new key and stores its hash.
+func (s *APIKeyService) createAPIKey(
+	ctx context.Context,
+	token *domain.Token,
+	keyType domain.APIKeyType,
+	create *domain.CreateAPIKey,
+) (*domain.CreatedAPIKey, error) {
+	expiresIn := s.apiKeyPolicy.DefaultExpireTime
+	if create.ExpiresIn != nil {
+		expiresIn = *create.ExpiresIn
+	}
+	if expiresIn <= 0 || expiresIn > s.apiKeyPolicy.MaxExpireTime {
+		return nil, domain.ErrInvalidAPIKeyExpiry
+	}
+
+	permissions, err := s.roleRepository.GetPermissions(ctx)
+	if err != nil {
+		return nil, err
+	}
+	known := make(map[domain.Permission]bool, len(permissions))
+	for _, permission := range permissions {
+		known[permission.Name] = true
+	}
+	scopes := make([]domain.Permission, 0, len(create.Scopes))
+	for _, scope := range create.Scopes {
+		if !known[scope] {
+			return nil, domain.ErrPermissionNotFound
+		}
+		scopes = append(scopes, scope)
+	}
+
+	secret, err := s.apiKeyGenerator.Generate(keyType)
+	if err != nil {
+		return nil, err
+	}
+
+	key := domain.NewAPIKey(
+		uuid.New(),
+		create.UserId,
+		create.Name,
+		keyType,
+		secret.Prefix,
+		s.apiKeyGenerator.Hash(secret.Secret),
+		scopes,
+		time.Now().Add(expiresIn),
+	)
+	event := domain.NewAuditEvent(ctx, &token.UserId, domain.AuditAPIKeyCreated, domain.AuditTargetAPIKey, key.Id.String()).
+		WithChange("userId", nil, key.UserId).
+		WithChange("type", nil, key.KeyType).
+		WithChange("prefix", nil, key.Prefix).
+		WithChange("scopes", nil, key.Scopes).
+		WithChange("expiresAt", nil, key.ExpiresAt)
+	if err = s.apiKeyRepository.AddAPIKey(ctx, key, event); err != nil {
+		return nil, err
+	}
+
+	return domain.NewCreatedAPIKey(key, secret.Secret), nil
+}
@@ -0,0 +1,374 @@
+package service_test
+
+import (
+	"context"
+	"shop-api-go/internal/core/domain"
+	"shop-api-go/internal/core/port/mock"
+	"shop-api-go/internal/core/service"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+// apiKeyMocks contains all mocked dependencies of service.APIKeyService.
+type apiKeyMocks struct {
+	apiKeyRepository *mock.MockAPIKeyRepository
+	apiKeyGenerator  *mock.MockAPIKeyGenerator
+	userRepository   *mock.MockUserRepository
+	roleRepository   *mock.MockRoleRepository
+	authorizer       *mock.MockAuthorizer
+}
+
+// newAPIKeyMocks creates a new apiKeyMocks instance.
+func newAPIKeyMocks(ctrl *gomock.Controller) *apiKeyMocks {
+	return &apiKeyMocks{
+		apiKeyRepository: mock.NewMockAPIKeyRepository(ctrl),
+		apiKeyGenerator:  mock.NewMockAPIKeyGenerator(ctrl),
+		userRepository:   mock.NewMockUserRepository(ctrl),
+		roleRepository:   mock.NewMockRoleRepository(ctrl),
+		authorizer:       mock.NewMockAuthorizer(ctrl),
+	}
+}
+
+// apiKeyService creates a service.APIKeyService using the mocks.
+func (m *apiKeyMocks) apiKeyService() *service.APIKeyService {
+	return service.NewAPIKeyService(
+		m.apiKeyRepository,
+		m.apiKeyGenerator,
+		m.userRepository,
+		m.roleRepository,
+		m.authorizer,
+		&domain.APIKeyPolicy{
+			DefaultExpireTime: 24 * time.Hour,
+			MaxExpireTime:     48 * time.Hour,
+		},
+	)
+}
+
+func TestAPIKeyService_Authenticate(t *testing.T) {
+	const secret = "sk_abcdefghijkl_secret"
+	userId := uuid.New()
+	recentlyUsed := time.Now().Add(-time.Second)
+	revoked := time.Now().Add(-time.Hour)
+
+	activeKey := func() *domain.APIKey {
+		return domain.NewAPIKey(
+			uuid.New(), userId, "ERP", domain.ServiceAPIKey, "sk_abcdefghijkl", "hash",
+			[]domain.Permission{domain.OrdersRead}, time.Now().Add(time.Hour),
+		)
+	}
+
+	tests := []struct {
+		name          string
+		expectedError error
+		expectedToken bool
+		mockSetup     func(m *apiKeyMocks)
+	}{
+		{
+			name:          "success",
+			expectedError: nil,
+			expectedToken: true,
+			mockSetup: func(m *apiKeyMocks) {
+				key := activeKey()
+				m.apiKeyGenerator.EXPECT().Prefix(secret).Return("sk_abcdefghijkl", true)
+				m.apiKeyRepository.
+					EXPECT().
+					GetAPIKeyByPrefix(gomock.AssignableToTypeOf(context.Background()), "sk_abcdefghijkl").
+					Return(key, nil)
+				m.apiKeyGenerator.EXPECT().Compare(secret, "hash").Return(true)
+				m.userRepository.
+					EXPECT().
+					GetUserById(gomock.AssignableToTypeOf(context.Background()), userId).
+					Return(&domain.User{Id: userId, Role: domain.Warehouse}, nil)
+				m.apiKeyRepository.
+					EXPECT().
+					UpdateLastUsed(
+						gomock.AssignableToTypeOf(context.Background()),
+						key.Id,
+						gomock.AssignableToTypeOf(time.Time{}),
+					).
+					Return(nil)
+			},
+		}, {
+			name:          "success recently used",
+			expectedError: nil,
+			expectedToken: true,
+			mockSetup: func(m *apiKeyMocks) {
+				key := activeKey()
+				key.LastUsedAt = &recentlyUsed
+				m.apiKeyGenerator.EXPECT().Prefix(secret).Return("sk_abcdefghijkl", true)
+				m.apiKeyRepository.
+					EXPECT().
+					GetAPIKeyByPrefix(gomock.AssignableToTypeOf(context.Background()), "sk_abcdefghijkl").
+					Return(key, nil)
+				m.apiKeyGenerator.EXPECT().Compare(secret, "hash").Return(true)
+				m.userRepository.
+					EXPECT().
+					GetUserById(gomock.AssignableToTypeOf(context.Background()), userId).
+					Return(&domain.User{Id: userId, Role: domain.Warehouse}, nil)
+			},
+		}, {
+			name:          "error user suspended",
+			expectedError: domain.ErrUserSuspended,
+			mockSetup: func(m *apiKeyMocks) {
+				m.apiKeyGenerator.EXPECT().Prefix(secret).Return("sk_abcdefghijkl", true)
+				m.apiKeyRepository.
+					EXPECT().
+					GetAPIKeyByPrefix(gomock.AssignableToTypeOf(context.Background()), "sk_abcdefghijkl").
+					Return(activeKey(), nil)
+				m.apiKeyGenerator.EXPECT().Compare(secret, "hash").Return(true)
+				m.userRepository.
+					EXPECT().
+					GetUserById(gomock.AssignableToTypeOf(context.Background()), userId).
+					Return(&domain.User{Id: userId, Role: domain.Warehouse, Status: domain.UserSuspended}, nil)
+			},
+		}, {
+			name:          "error not an api key",
+			expectedError: domain.ErrInvalidToken,
+			mockSetup: func(m *apiKeyMocks) {
+				m.apiKeyGenerator.EXPECT().Prefix(secret).Return("", false)
+			},
+		}, {
+			name:          "error key not found",
+			expectedError: domain.ErrInvalidToken,
+			mockSetup: func(m *apiKeyMocks) {
+				m.apiKeyGenerator.EXPECT().Prefix(secret).Return("sk_abcdefghijkl", true)
+				m.apiKeyRepository.
+					EXPECT().
+					GetAPIKeyByPrefix(gomock.AssignableToTypeOf(context.Background()), "sk_abcdefghijkl").
+					Return(nil, domain.ErrAPIKeyNotFound)
+			},
+		}, {
+			name:          "error wrong secret",
+			expectedError: domain.ErrInvalidToken,
+			mockSetup: func(m *apiKeyMocks) {
+				m.apiKeyGenerator.EXPECT().Prefix(secret).Return("sk_abcdefghijkl", true)
+				m.apiKeyRepository.
+					EXPECT().
+					GetAPIKeyByPrefix(gomock.AssignableToTypeOf(context.Background()), "sk_abcdefghijkl").
+					Return(activeKey(), nil)
+				m.apiKeyGenerator.EXPECT().Compare(secret, "hash").Return(false)
+			},
+		}, {
+			name:          "error revoked",
+			expectedError: domain.ErrInvalidToken,
+			mockSetup: func(m *apiKeyMocks) {
+				key := activeKey()
+				key.RevokedAt = &revoked
+				m.apiKeyGenerator.EXPECT().Prefix(secret).Return("sk_abcdefghijkl", true)
+				m.apiKeyRepository.
+					EXPECT().
+					GetAPIKeyByPrefix(gomock.AssignableToTypeOf(context.Background()), "sk_abcdefghijkl").
+					Return(key, nil)
+				m.apiKeyGenerator.EXPECT().Compare(secret, "hash").Return(true)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := newAPIKeyMocks(gomock.NewController(t))
+			tt.mockSetup(m)
+
+			token, err := m.apiKeyService().Authenticate(context.Background(), secret)
+			require.ErrorIs(t, err, tt.expectedError)
+			if tt.expectedToken {
+				require.Equal(t, domain.AccessToken, token.TokenType)
+				require.Equal(t, userId, token.UserId)
+				require.Equal(t, domain.Warehouse, token.UserRole)
+				require.True(t, token.IsAPIKey())
+				require.Equal(t, []domain.Permission{domain.OrdersRead}, token.Scopes)
+			}
+		})
+	}
+}
+
+func TestAPIKeyService_CreatePersonalAccessToken(t *testing.T) {
+	userId := uuid.New()
+	token := &domain.Token{
+		TokenType: domain.AccessToken,
+		UserId:    userId,
+		UserRole:  domain.Client,
+	}
+	actorId := uuid.New()
+	tooLong := 72 * time.Hour
+
+	tests := []struct {
+		name          string
+		token         *domain.Token
+		create        *domain.CreateAPIKey
+		expectedError error
+		mockSetup     func(m *apiKeyMocks)
+	}{
+		{
+			name:          "success",
+			token:         token,
+			create:        domain.NewCreateAPIKey(uuid.Nil, "CLI", []domain.Permission{domain.OrdersRead}, nil),
+			expectedError: nil,
+			mockSetup: func(m *apiKeyMocks) {
+				m.roleRepository.
+					EXPECT().
+					GetPermissions(gomock.AssignableToTypeOf(context.Background())).
+					Return([]domain.PermissionInfo{{Name: domain.OrdersRead}}, nil)
+				m.apiKeyGenerator.
+					EXPECT().
+					Generate(domain.PersonalAccessToken).
+					Return(domain.NewAPIKeySecret("pat_abcdefghijkl", "pat_abcdefghijkl_secret"), nil)
+				m.apiKeyGenerator.EXPECT().Hash("pat_abcdefghijkl_secret").Return("hash")
+				m.apiKeyRepository.
+					EXPECT().
+					AddAPIKey(
+						gomock.AssignableToTypeOf(context.Background()),
+						gomock.Cond(func(key *domain.APIKey) bool {
+							return key.UserId == userId &&
+								key.KeyType == domain.PersonalAccessToken &&
+								key.Prefix == "pat_abcdefghijkl" &&
+								key.SecretHash == "hash"
+						}),
+						gomock.Cond(func(event *domain.AuditEvent) bool {
+							return event.Action == domain.AuditAPIKeyCreated && *event.ActorId == userId
+						}),
+					).
+					Return(nil)
+			},
+		}, {
+			name: "error api key principal",
+			token: &domain.Token{
+				TokenType: domain.AccessToken,
+				UserId:    userId,
+				Scopes:    []domain.Permission{},
+			},
+			create:        domain.NewCreateAPIKey(uuid.Nil, "CLI", nil, nil),
+			expectedError: domain.ErrInvalidTokenType,
+			mockSetup: func(m *apiKeyMocks) {
+
+			},
+		}, {
+			name: "error impersonation forbidden",
+			token: &domain.Token{
+				TokenType: domain.AccessToken,
+				UserId:    userId,
+				UserRole:  domain.Client,
+				ActorId:   &actorId,
+			},
+			create:        domain.NewCreateAPIKey(uuid.Nil, "CLI", nil, nil),
+			expectedError: domain.ErrImpersonationForbidden,
+			mockSetup: func(m *apiKeyMocks) {
+
+			},
+		}, {
+			name:          "error expiry exceeds maximum",
+			token:         token,
+			create:        domain.NewCreateAPIKey(uuid.Nil, "CLI", nil, &tooLong),
+			expectedError: domain.ErrInvalidAPIKeyExpiry,
+			mockSetup: func(m *apiKeyMocks) {
+
+			},
+		}, {
+			name:          "error unknown scope",
+			token:         token,
+			create:        domain.NewCreateAPIKey(uuid.Nil, "CLI", []domain.Permission{"orders:delete"}, nil),
+			expectedError: domain.ErrPermissionNotFound,
+			mockSetup: func(m *apiKeyMocks) {
+				m.roleRepository.
+					EXPECT().
+					GetPermissions(gomock.AssignableToTypeOf(context.Background())).
+					Return([]domain.PermissionInfo{{Name: domain.OrdersRead}}, nil)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := newAPIKeyMocks(gomock.NewController(t))
+			tt.mockSetup(m)
+
+			created, err := m.apiKeyService().CreatePersonalAccessToken(context.Background(), tt.token, tt.create)
+			require.ErrorIs(t, err, tt.expectedError)
+			if tt.expectedError == nil {
+				require.Equal(t, "pat_abcdefghijkl_secret", created.Secret)
+			}
+		})
+	}
+}
+
+func TestAPIKeyService_RevokeAPIKey(t *testing.T) {
+	userId := uuid.New()
+	keyId := uuid.New()
+	token := &domain.Token{
+		TokenType: domain.AccessToken,
+		UserId:    userId,
+		UserRole:  domain.Client,
+	}
+
+	tests := []struct {
+		name          string
+		token         *domain.Token
+		expectedError error
+		mockSetup     func(m *apiKeyMocks)
+	}{
+		{
+			name:          "success own key",
+			token:         token,
+			expectedError: nil,
+			mockSetup: func(m *apiKeyMocks) {
+				m.apiKeyRepository.
+					EXPECT().
+					GetAPIKeyById(gomock.AssignableToTypeOf(context.Background()), keyId).
+					Return(&domain.APIKey{Id: keyId, UserId: userId}, nil)
+				m.apiKeyRepository.
+					EXPECT().
+					RevokeAPIKey(gomock.AssignableToTypeOf(context.Background()), keyId, gomock.AssignableToTypeOf(&domain.AuditEvent{})).
+					Return(nil)
+			},
+		}, {
+			name:          "error key of another user",
+			token:         token,
+			expectedError: domain.ErrInvalidTokenRole,
+			mockSetup: func(m *apiKeyMocks) {
+				m.apiKeyRepository.
+					EXPECT().
+					GetAPIKeyById(gomock.AssignableToTypeOf(context.Background()), keyId).
+					Return(&domain.APIKey{Id: keyId, UserId: uuid.New()}, nil)
+				m.authorizer.
+					EXPECT().
+					Authorize(gomock.AssignableToTypeOf(context.Background()), token, domain.APIKeysWrite).
+					Return(domain.ErrInvalidTokenRole)
+			},
+		}, {
+			name:          "error key not found",
+			token:         token,
+			expectedError: domain.ErrAPIKeyNotFound,
+			mockSetup: func(m *apiKeyMocks) {
+				m.apiKeyRepository.
+					EXPECT().
+					GetAPIKeyById(gomock.AssignableToTypeOf(context.Background()), keyId).
+					Return(nil, domain.ErrAPIKeyNotFound)
+			},
+		}, {
+			name: "error api key",
+			token: &domain.Token{
+				Id:        keyId,
+				TokenType: domain.AccessToken,
+				UserId:    userId,
+				UserRole:  domain.Client,
+				Scopes:    []domain.Permission{},
+			},
+			expectedError: domain.ErrInvalidTokenType,
+			mockSetup:     func(m *apiKeyMocks) {},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := newAPIKeyMocks(gomock.NewController(t))
+			tt.mockSetup(m)
+
+			err := m.apiKeyService().RevokeAPIKey(context.Background(), tt.token, keyId)
+			require.ErrorIs(t, err, tt.expectedError)
+		})
+	}
+}
@@ -0,0 +1,78 @@
+package service
+
+import (
+	"context"
+	"shop-api-go/internal/core/domain"
+	"shop-api-go/internal/core/port"
+
+	"github.com/google/uuid"
+)
+
+// AuditService implements port.AuditService interface and provides access to audit-related business logic.
+type AuditService struct {
+	auditRepository port.AuditRepository
+	authorizer      port.Authorizer
+}
+
+// NewAuditService creates a new AuditService instance.
+func NewAuditService(auditRepository port.AuditRepository, authorizer port.Authorizer) *AuditService {
+	return &AuditService{
+		auditRepository: auditRepository,
+		authorizer:      authorizer,
+	}
+}
+
+func (s *AuditService) GetAuditEvents(ctx context.Context, token *domain.Token, filter *domain.AuditFilter) ([]domain.AuditEvent, error) {
+	if err := s.authorizer.Authorize(ctx, token, domain.AuditRead); err != nil {
+		return nil, err
+	}
+	if filter.Limit <= 0 {
+		return nil, domain.ErrLimitNotSet
+	}
+	if filter.Page <= 0 {
+		filter.Page = 1
+	}
+
+	return s.auditRepository.GetAuditEvents(ctx, filter)
+}
+
+func (s *AuditService) RecordImpersonatedRequest(
+	ctx context.Context,
+	token *domain.Token,
+	method, path string,
+	status int,
+) error {
+	if !token.IsImpersonated() {
+		return nil
+	}
+
+	event := domain.NewAuditEvent(ctx, token.ActorId, domain.AuditImpersonatedRequest, domain.AuditTargetUser, token.UserId.String()).
+		WithChange("method", nil, method).
+		WithChange("path", nil, path).
+		WithChange("status", nil, status)
+	return s.auditRepository.AddAuditEvent(ctx, event)
+}
+
+// userUpdateAuditEvent creates a domain.AuditUserUpdated event with the changed fields of a user,
+// the password is redacted.
+func userUpdateAuditEvent(ctx context.Context, actorId uuid.UUID, before *domain.User, update *domain.UserUpdate) *domain.AuditEvent {
+	event := domain.NewAuditEvent(ctx, &actorId, domain.AuditUserUpdated, domain.AuditTargetUser, before.Id.String())
+	if update.Username != nil {
+		event.WithChange("username", before.Username, *update.Username)
+	}
+	if update.Email != nil {
+		event.WithChange("email", before.Email, *update.Email)
+	}
+	if update.Password != nil {
+		event.WithRedactedChange("password")
+	}
+	if update.Role != nil {
+		event.WithChange("role", before.Role, *update.Role)
+	}
+	return event
+}
+
+// tokensRevokedAuditEvent creates a domain.AuditTokensRevoked event for the tokens of a user.
+func tokensRevokedAuditEvent(ctx context.Context, actorId, userId uuid.UUID) *domain.AuditEvent {
+	return domain.NewAuditEvent(ctx, &actorId, domain.AuditTokensRevoked, domain.AuditTargetUser, userId.String())
+}
@@ -0,0 +1,186 @@
+package service_test
+
+import (
+	"context"
+	"net/http"
+	"shop-api-go/internal/core/domain"
+	"shop-api-go/internal/core/port/mock"
+	"shop-api-go/internal/core/service"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+// auditMocks contains all mocked dependencies of service.AuditService.
+type auditMocks struct {
+	auditRepository *mock.MockAuditRepository
+	authorizer      *mock.MockAuthorizer
+}
+
+// newAuditMocks creates a new auditMocks instance.
+func newAuditMocks(ctrl *gomock.Controller) *auditMocks {
+	return &auditMocks{
+		auditRepository: mock.NewMockAuditRepository(ctrl),
+		authorizer:      mock.NewMockAuthorizer(ctrl),
+	}
+}
+
+// auditService creates a service.AuditService using the mocks.
+func (m *auditMocks) auditService() *service.AuditService {
+	return service.NewAuditService(m.auditRepository, m.authorizer)
+}
+
+// expectAuthorize expects the permission to be checked and returns err.
+func (m *auditMocks) expectAuthorize(permission domain.Permission, err error) {
+	m.authorizer.
+		EXPECT().
+		Authorize(
+			gomock.AssignableToTypeOf(context.Background()),
+			gomock.AssignableToTypeOf(&domain.Token{}),
+			permission,
+		).
+		Return(err)
+}
+
+func TestAuditService_GetAuditEvents(t *testing.T) {
+	token := &domain.Token{
+		TokenType: domain.AccessToken,
+		UserRole:  domain.Admin,
+	}
+	action := domain.AuditLoginFailed
+	events := []domain.AuditEvent{
+		{Action: domain.AuditLoginFailed, TargetType: domain.AuditTargetUsername, TargetId: "username"},
+	}
+
+	tests := []struct {
+		name           string
+		filter         *domain.AuditFilter
+		expectedError  error
+		expectedResult []domain.AuditEvent
+		mockSetup      func(m *auditMocks)
+	}{
+		{
+			name:           "success defaults page",
+			filter:         domain.NewAuditFilter(nil, &action, nil, nil, nil, nil, 0, 10),
+			expectedError:  nil,
+			expectedResult: events,
+			mockSetup: func(m *auditMocks) {
+				m.expectAuthorize(domain.AuditRead, nil)
+				m.auditRepository.
+					EXPECT().
+					GetAuditEvents(
+						gomock.AssignableToTypeOf(context.Background()),
+						gomock.Eq(domain.NewAuditFilter(nil, &action, nil, nil, nil, nil, 1, 10)),
+					).
+					Return(events, nil)
+			},
+		}, {
+			name:          "error limit not set",
+			filter:        domain.NewAuditFilter(nil, nil, nil, nil, nil, nil, 1, 0),
+			expectedError: domain.ErrLimitNotSet,
+			mockSetup: func(m *auditMocks) {
+				m.expectAuthorize(domain.AuditRead, nil)
+			},
+		}, {
+			name:          "error invalid token role",
+			filter:        domain.NewAuditFilter(nil, nil, nil, nil, nil, nil, 1, 10),
+			expectedError: domain.ErrInvalidTokenRole,
+			mockSetup: func(m *auditMocks) {
+				m.expectAuthorize(domain.AuditRead, domain.ErrInvalidTokenRole)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := newAuditMocks(gomock.NewController(t))
+			tt.mockSetup(m)
+
+			result, err := m.auditService().GetAuditEvents(context.Background(), token, tt.filter)
+			if tt.expectedError == nil {
+				require.NoError(t, err)
+				require.Equal(t, tt.expectedResult, result)
+			} else {
+				require.ErrorIs(t, err, tt.expectedError)
+			}
+		})
+	}
+}
+
+func TestAuditService_RecordImpersonatedRequest(t *testing.T) {
+	actorId := uuid.New()
+	userId := uuid.New()
+
+	tests := []struct {
+		name          string
+		token         *domain.Token
+		expectedError error
+		mockSetup     func(m *auditMocks)
+	}{
+		{
+			name: "success",
+			token: &domain.Token{
+				UserId:    userId,
+				TokenType: domain.AccessToken,
+				UserRole:  domain.Client,
+				ActorId:   &actorId,
+			},
+			expectedError: nil,
+			mockSetup: func(m *auditMocks) {
+				m.auditRepository.
+					EXPECT().
+					AddAuditEvent(
+						gomock.AssignableToTypeOf(context.Background()),
+						gomock.Cond(func(event *domain.AuditEvent) bool {
+							return event.Action == domain.AuditImpersonatedRequest &&
+								*event.ActorId == actorId &&
+								event.TargetId == userId.String() &&
+								event.Changes["status"].After == http.StatusOK
+						}),
+					).
+					Return(nil)
+			},
+		}, {
+			name: "success not impersonated",
+			token: &domain.Token{
+				UserId:    userId,
+				TokenType: domain.AccessToken,
+				UserRole:  domain.Client,
+			},
+			expectedError: nil,
+			mockSetup: func(m *auditMocks) {
+
+			},
+		}, {
+			name: "error internal",
+			token: &domain.Token{
+				UserId:    userId,
+				TokenType: domain.AccessToken,
+				UserRole:  domain.Client,
+				ActorId:   &actorId,
+			},
+			expectedError: domain.ErrInternal,
+			mockSetup: func(m *auditMocks) {
+				m.auditRepository.
+					EXPECT().
+					AddAuditEvent(
+						gomock.AssignableToTypeOf(context.Background()),
+						gomock.AssignableToTypeOf(&domain.AuditEvent{}),
+					).
+					Return(domain.ErrInternal)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := newAuditMocks(gomock.NewController(t))
+			tt.mockSetup(m)
+
+			err := m.auditService().RecordImpersonatedRequest(context.Background(), tt.token, http.MethodGet, "/api/v1/users/me/api-keys", http.StatusOK)
+			require.ErrorIs(t, err, tt.expectedError)
+		})
+	}
+}
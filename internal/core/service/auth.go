@@ -2,75 +2,198 @@ package service
 
 import (
 	"context"
+	"crypto/rand"
 	"errors"
 	"shop-api-go/internal/core/domain"
 	"shop-api-go/internal/core/port"
+	"strings"
+	"time"
 
 	"github.com/google/uuid"
 )
 
+// maxExternalUsernameAttempts is the number of usernames tried when creating a user for an external identity.
+const maxExternalUsernameAttempts = 5
+
 // AuthService implements port.AuthService interface and provides access to admin-related business logic.
 type AuthService struct {
 	tokenGenerator  port.TokenGenerator
 	passwordHasher  port.PasswordHasher
 	tokenRepository port.TokenRepository
 	userRepository  port.UserRepository
+	mfaRepository   port.MFARepository
+	mfaProvider     port.MFAProvider
+
+	loginAttemptRepository port.LoginAttemptRepository
+	loginThrottle          *domain.LoginThrottle
+	credentials            *credentialChecker
+
+	identityProvider   port.IdentityProvider
+	identityRepository port.IdentityRepository
+	roleRepository     port.RoleRepository
+
+	auditRepository port.AuditRepository
+	txManager       port.TxManager
 }
 
 // NewAuthService creates a new AuthService instance.
-func NewAuthService(tokenGenerator port.TokenGenerator, passwordHasher port.PasswordHasher, tokenRepository port.TokenRepository, userRepository port.UserRepository) *AuthService {
+func NewAuthService(
+	tokenGenerator port.TokenGenerator,
+	passwordHasher port.PasswordHasher,
+	tokenRepository port.TokenRepository,
+	userRepository port.UserRepository,
+	mfaRepository port.MFARepository,
+	mfaProvider port.MFAProvider,
+	loginAttemptRepository port.LoginAttemptRepository,
+	loginThrottle *domain.LoginThrottle,
+	identityProvider port.IdentityProvider,
+	identityRepository port.IdentityRepository,
+	roleRepository port.RoleRepository,
+	auditRepository port.AuditRepository,
+	txManager port.TxManager,
+) *AuthService {
 	return &AuthService{
-		tokenGenerator:  tokenGenerator,
-		passwordHasher:  passwordHasher,
-		tokenRepository: tokenRepository,
-		userRepository:  userRepository,
+		tokenGenerator:         tokenGenerator,
+		passwordHasher:         passwordHasher,
+		tokenRepository:        tokenRepository,
+		userRepository:         userRepository,
+		mfaRepository:          mfaRepository,
+		mfaProvider:            mfaProvider,
+		loginAttemptRepository: loginAttemptRepository,
+		loginThrottle:          loginThrottle,
+		credentials:            newCredentialChecker(userRepository, passwordHasher, loginAttemptRepository, loginThrottle, auditRepository),
+		identityProvider:       identityProvider,
+		identityRepository:     identityRepository,
+		roleRepository:         roleRepository,
+		auditRepository:        auditRepository,
+		txManager:              txManager,
 	}
 }
 
-func (s *AuthService) Login(ctx context.Context, user *domain.User) (*domain.TokenGroup, error) {
-	fetchedUser, err := s.userRepository.GetUserByUsername(ctx, user.Username)
-	if errors.Is(err, domain.ErrUserNotFound) {
-		return nil, domain.ErrWrongCredentials
+func (s *AuthService) Login(ctx context.Context, user *domain.User) (*domain.LoginResult, error) {
+	fetchedUser, err := s.credentials.check(ctx, user.Username, user.Password)
+	if err != nil {
+		return nil, err
+	}
+
+	if s.passwordHasher.NeedsRehash(fetchedUser.Password) {
+		s.rehashPassword(ctx, fetchedUser.Id, user.Password)
+	}
+
+	return s.finishLogin(ctx, fetchedUser)
+}
+
+func (s *AuthService) VerifyMFA(ctx context.Context, token *domain.Token, code string) (*domain.TokenGroup, error) {
+	if token.TokenType != domain.MFAToken {
+		return nil, domain.ErrInvalidTokenType
+	}
+
+	mfa, err := s.mfaRepository.GetUserMFA(ctx, token.UserId)
+	if errors.Is(err, domain.ErrMFANotEnrolled) {
+		return nil, domain.ErrInvalidToken
 	} else if err != nil {
 		return nil, err
 	}
+	if !mfa.Enabled {
+		return nil, domain.ErrInvalidToken
+	}
 
-	err = s.passwordHasher.Compare(user.Password, fetchedUser.Password)
-	if err != nil {
+	keys := map[string]domain.LoginThrottlePolicy{
+		domain.MFALoginAttemptsKey(token.UserId.String()): s.loginThrottle.User,
+	}
+	if err = s.credentials.checkLoginAttempts(ctx, keys); err != nil {
 		return nil, err
 	}
 
-	accessToken := domain.Token{
-		Id:        uuid.New(),
-		UserId:    fetchedUser.Id,
-		TokenType: domain.AccessToken,
-		UserRole:  fetchedUser.Role,
+	// The code is only used up if the tokens are issued. The failed attempt is recorded after the rollback.
+	step, ok := s.mfaProvider.ValidateCode(code, mfa.Secret)
+	var tokens *domain.TokenGroup
+	err = s.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		var err error
+		if ok {
+			err = s.mfaRepository.UpdateLastUsedStep(ctx, token.UserId, step)
+		} else {
+			err = s.useRecoveryCode(ctx, token.UserId, code)
+		}
+		if err != nil {
+			return err
+		}
+
+		if err = s.loginAttemptRepository.ResetLoginAttempts(ctx, domain.MFALoginAttemptsKey(token.UserId.String())); err != nil {
+			return err
+		}
+
+		// The user may have been suspended or deleted since the MFA token was issued.
+		if err = s.checkUserActive(ctx, token.UserId); err != nil {
+			return err
+		}
+
+		tokens, err = s.newTokenGroup(ctx, token.UserId, token.UserRole, domain.AuditLoginSucceeded)
+		return err
+	})
+	if errors.Is(err, domain.ErrInvalidMFACode) {
+		event := domain.NewAuditEvent(ctx, nil, domain.AuditMFAFailed, domain.AuditTargetUser, token.UserId.String())
+		return nil, s.credentials.recordFailedLogin(ctx, keys, err, event)
+	} else if err != nil {
+		return nil, err
 	}
+	return tokens, nil
+}
 
-	signedAccessToken, err := s.tokenGenerator.SignToken(&accessToken)
+func (s *AuthService) StartOAuthLogin(ctx context.Context, provider string) (string, error) {
+	authorization, err := s.identityProvider.Authorize(ctx, provider)
 	if err != nil {
-		return nil, err
+		return "", err
 	}
 
-	refreshToken := domain.Token{
-		Id:        uuid.New(),
-		UserId:    fetchedUser.Id,
-		TokenType: domain.RefreshToken,
-		UserRole:  fetchedUser.Role,
+	if err = s.identityRepository.AddOAuthState(ctx, authorization.State); err != nil {
+		return "", err
 	}
-	signedRefreshToken, err := s.tokenGenerator.SignToken(&refreshToken)
+	return authorization.URL, nil
+}
+
+func (s *AuthService) StartOAuthLink(ctx context.Context, token *domain.Token, provider string) (string, error) {
+	// Only the user may link a way to log in as them, not an impersonating admin or an API key.
+	if token.TokenType != domain.AccessToken || token.IsImpersonated() || token.IsAPIKey() {
+		return "", domain.ErrInvalidTokenType
+	}
+
+	authorization, err := s.identityProvider.Authorize(ctx, provider)
+	if err != nil {
+		return "", err
+	}
+
+	authorization.State.UserId = &token.UserId
+	if err = s.identityRepository.AddOAuthState(ctx, authorization.State); err != nil {
+		return "", err
+	}
+	return authorization.URL, nil
+}
+
+func (s *AuthService) FinishOAuthLogin(ctx context.Context, provider, state, code string) (*domain.LoginResult, error) {
+	oauthState, err := s.identityRepository.ConsumeOAuthState(ctx, state)
 	if err != nil {
 		return nil, err
 	}
-	err = s.tokenRepository.AddToken(ctx, &refreshToken)
+	if oauthState.Provider != provider || oauthState.ExpiresAt.Before(time.Now()) {
+		return nil, domain.ErrInvalidOAuthState
+	}
+
+	identity, err := s.identityProvider.Exchange(ctx, oauthState, code)
 	if err != nil {
 		return nil, err
 	}
 
-	return &domain.TokenGroup{
-		AccessToken:  signedAccessToken,
-		RefreshToken: signedRefreshToken,
-	}, nil
+	var user *domain.User
+	if oauthState.UserId != nil {
+		user, err = s.linkExternalUser(ctx, *oauthState.UserId, identity)
+	} else {
+		user, err = s.getExternalUser(ctx, identity)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return s.finishLogin(ctx, user)
 }
 
 func (s *AuthService) RefreshSession(ctx context.Context, token *domain.Token) (*domain.TokenGroup, error) {
@@ -78,41 +201,258 @@ func (s *AuthService) RefreshSession(ctx context.Context, token *domain.Token) (
 		return nil, domain.ErrInvalidTokenType
 	}
 
-	err := s.tokenRepository.DeleteToken(ctx, token.Id)
-	if errors.Is(err, domain.ErrTokenNotFound) {
-		return nil, domain.ErrInvalidToken
-	} else if err != nil {
+	// The refresh token is kept for suspended users, so the session can be refreshed once they are reactivated.
+	if err := s.checkUserActive(ctx, token.UserId); err != nil {
+		return nil, err
+	}
+
+	// The refresh token is only rotated if the new one is stored.
+	var tokens *domain.TokenGroup
+	err := s.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		err := s.tokenRepository.DeleteToken(ctx, token.Id)
+		if errors.Is(err, domain.ErrTokenNotFound) {
+			return domain.ErrInvalidToken
+		} else if err != nil {
+			return err
+		}
+
+		tokens, err = s.newTokenGroup(ctx, token.UserId, token.UserRole, domain.AuditSessionRefreshed)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return tokens, nil
+}
+
+// finishLogin returns an MFA challenge if the user has MFA enabled, otherwise it returns a new token group.
+func (s *AuthService) finishLogin(ctx context.Context, user *domain.User) (*domain.LoginResult, error) {
+	if user.Status == domain.UserSuspended {
+		return nil, domain.ErrUserSuspended
+	}
+
+	mfa, err := s.mfaRepository.GetUserMFA(ctx, user.Id)
+	if err != nil && !errors.Is(err, domain.ErrMFANotEnrolled) {
 		return nil, err
 	}
 
+	if mfa != nil && mfa.Enabled {
+		mfaToken := domain.Token{
+			Id:        uuid.New(),
+			UserId:    user.Id,
+			TokenType: domain.MFAToken,
+			UserRole:  user.Role,
+		}
+		signedMFAToken, signErr := s.tokenGenerator.SignToken(&mfaToken)
+		if signErr != nil {
+			return nil, signErr
+		}
+
+		return domain.NewLoginResult(nil, &signedMFAToken), nil
+	}
+
+	required, err := s.mfaRepository.IsMFARequired(ctx, user.Role)
+	if err != nil {
+		return nil, err
+	}
+	if required {
+		return nil, domain.ErrMFAEnrollmentRequired
+	}
+
+	tokenGroup, err := s.newTokenGroup(ctx, user.Id, user.Role, domain.AuditLoginSucceeded)
+	if err != nil {
+		return nil, err
+	}
+	return domain.NewLoginResult(tokenGroup, nil), nil
+}
+
+// getExternalUser returns the user linked to an external identity.
+//
+// Note: An unlinked identity is linked to the user with the same verified email if the user may be linked
+// automatically, if there is no such user a new client user is created.
+func (s *AuthService) getExternalUser(ctx context.Context, external *domain.ExternalIdentity) (*domain.User, error) {
+	identity, err := s.identityRepository.GetUserIdentity(ctx, external.Provider, external.Subject)
+	if err == nil {
+		return s.userRepository.GetUserById(ctx, identity.UserId)
+	} else if !errors.Is(err, domain.ErrIdentityNotFound) {
+		return nil, err
+	}
+
+	if external.Email == "" || !external.EmailVerified {
+		return nil, domain.ErrExternalEmailNotVerified
+	}
+
+	user, err := s.userRepository.GetUserByEmail(ctx, external.Email)
+	if err == nil {
+		if err = s.checkAutoLink(ctx, user); err != nil {
+			return nil, err
+		}
+		identity = domain.NewUserIdentity(uuid.New(), user.Id, external.Provider, external.Subject, external.Email, time.Time{})
+		if err = s.identityRepository.AddUserIdentity(ctx, identity); err != nil {
+			return nil, err
+		}
+		return user, nil
+	} else if !errors.Is(err, domain.ErrUserNotFound) {
+		return nil, err
+	}
+
+	// External users log in only through their provider, so the password is random and never revealed.
+	hashedPassword, err := s.passwordHasher.Hash(rand.Text())
+	if err != nil {
+		return nil, err
+	}
+
+	for attempt := range maxExternalUsernameAttempts {
+		user = domain.NewUser(uuid.New(), externalUsername(external, attempt), external.Email, hashedPassword, domain.Client, time.Time{}, time.Time{})
+		identity = domain.NewUserIdentity(uuid.New(), user.Id, external.Provider, external.Subject, external.Email, time.Time{})
+
+		err = s.identityRepository.AddUserWithIdentity(ctx, user, identity, []*domain.Event{domain.NewUserEvent(domain.EventUserRegistered, user)})
+		if errors.Is(err, domain.ErrUsernameAlreadyInUse) {
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+		return user, nil
+	}
+	return nil, domain.ErrUsernameAlreadyInUse
+}
+
+// checkAutoLink returns domain.ErrOAuthLinkRequired if an identity must not be linked to the user by their email alone.
+// Otherwise a compromised account of the identity provider would take over users with permissions or with MFA enabled,
+// their identities are only linked while they are logged in.
+func (s *AuthService) checkAutoLink(ctx context.Context, user *domain.User) error {
+	role, err := s.roleRepository.GetRole(ctx, user.Role)
+	if err != nil {
+		return err
+	}
+	if len(role.Permissions) > 0 {
+		return domain.ErrOAuthLinkRequired
+	}
+
+	mfa, err := s.mfaRepository.GetUserMFA(ctx, user.Id)
+	if err != nil && !errors.Is(err, domain.ErrMFANotEnrolled) {
+		return err
+	}
+	if mfa != nil && mfa.Enabled {
+		return domain.ErrOAuthLinkRequired
+	}
+	return nil
+}
+
+// linkExternalUser links an external identity to the user who started the link and returns the user.
+func (s *AuthService) linkExternalUser(ctx context.Context, userId uuid.UUID, external *domain.ExternalIdentity) (*domain.User, error) {
+	identity, err := s.identityRepository.GetUserIdentity(ctx, external.Provider, external.Subject)
+	if err == nil && identity.UserId != userId {
+		return nil, domain.ErrIdentityAlreadyLinked
+	} else if errors.Is(err, domain.ErrIdentityNotFound) {
+		identity = domain.NewUserIdentity(uuid.New(), userId, external.Provider, external.Subject, external.Email, time.Time{})
+		err = s.identityRepository.AddUserIdentity(ctx, identity)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := s.userRepository.GetUserById(ctx, userId)
+	if errors.Is(err, domain.ErrUserNotFound) {
+		return nil, domain.ErrInvalidOAuthState
+	}
+	return user, err
+}
+
+// checkUserActive returns domain.ErrUserSuspended if the user is suspended
+// and domain.ErrInvalidToken if the user was deleted.
+func (s *AuthService) checkUserActive(ctx context.Context, userId uuid.UUID) error {
+	user, err := s.userRepository.GetUserById(ctx, userId)
+	if errors.Is(err, domain.ErrUserNotFound) {
+		return domain.ErrInvalidToken
+	} else if err != nil {
+		return err
+	}
+
+	if user.Status == domain.UserSuspended {
+		return domain.ErrUserSuspended
+	}
+	return nil
+}
+
+// rehashPassword replaces an outdated password hash with one using the current hashing policy.
+//
+// Note: Failures are already logged by the adapters and must not prevent the login.
+func (s *AuthService) rehashPassword(ctx context.Context, userId uuid.UUID, password string) {
+	hash, err := s.passwordHasher.Hash(password)
+	if err != nil {
+		return
+	}
+	_ = s.userRepository.UpdatePasswordHash(ctx, userId, hash)
+}
+
+// newTokenGroup signs a new pair of access and refresh tokens and stores the refresh token
+// together with an audit event of the action.
+func (s *AuthService) newTokenGroup(
+	ctx context.Context,
+	userId uuid.UUID,
+	userRole domain.UserRole,
+	action domain.AuditAction,
+) (*domain.TokenGroup, error) {
 	accessToken := domain.Token{
 		Id:        uuid.New(),
-		UserId:    token.UserId,
+		UserId:    userId,
 		TokenType: domain.AccessToken,
-		UserRole:  token.UserRole,
+		UserRole:  userRole,
 	}
 	signedAccessToken, err := s.tokenGenerator.SignToken(&accessToken)
 	if err != nil {
 		return nil, err
 	}
 
-	token = &domain.Token{
+	refreshToken := domain.Token{
 		Id:        uuid.New(),
-		UserId:    token.UserId,
+		UserId:    userId,
 		TokenType: domain.RefreshToken,
-		UserRole:  token.UserRole,
+		UserRole:  userRole,
 	}
-	signedRefreshToken, err := s.tokenGenerator.SignToken(token)
+	signedRefreshToken, err := s.tokenGenerator.SignToken(&refreshToken)
 	if err != nil {
 		return nil, err
 	}
 
-	err = s.tokenRepository.AddToken(ctx, token)
+	event := domain.NewAuditEvent(ctx, &userId, action, domain.AuditTargetUser, userId.String())
+	err = s.tokenRepository.AddToken(ctx, &refreshToken, event)
 	if err != nil {
 		return nil, err
 	}
+
 	return &domain.TokenGroup{
 		AccessToken:  signedAccessToken,
 		RefreshToken: signedRefreshToken,
 	}, nil
 }
+
+// useRecoveryCode finds an unused recovery code of the user matching the code and marks it as used.
+func (s *AuthService) useRecoveryCode(ctx context.Context, userId uuid.UUID, code string) error {
+	return s.mfaRepository.UseRecoveryCode(ctx, userId, s.mfaProvider.HashRecoveryCode(code))
+}
+
+// externalUsername derives a username from an external identity,
+// a random suffix is added to short usernames and to retries.
+func externalUsername(external *domain.ExternalIdentity, attempt int) string {
+	base := external.PreferredUsername
+	if base == "" {
+		base, _, _ = strings.Cut(external.Email, "@")
+	}
+
+	username := strings.Map(func(r rune) rune {
+		if r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '.' || r == '_' || r == '-' {
+			return r
+		}
+		return -1
+	}, base)
+	if len(username) > 200 {
+		username = username[:200]
+	}
+
+	if attempt > 0 || len(username) < 8 {
+		username += "-" + strings.ToLower(rand.Text()[:8])
+	}
+	return username
+}
@@ -6,43 +6,204 @@ import (
 	"shop-api-go/internal/core/port/mock"
 	"shop-api-go/internal/core/service"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
 )
 
+// authMocks contains all mocked dependencies of service.AuthService.
+type authMocks struct {
+	tokenGenerator  *mock.MockTokenGenerator
+	passwordHasher  *mock.MockPasswordHasher
+	tokenRepository *mock.MockTokenRepository
+	userRepository  *mock.MockUserRepository
+	mfaRepository   *mock.MockMFARepository
+	mfaProvider     *mock.MockMFAProvider
+
+	loginAttemptRepository *mock.MockLoginAttemptRepository
+
+	identityProvider   *mock.MockIdentityProvider
+	identityRepository *mock.MockIdentityRepository
+	roleRepository     *mock.MockRoleRepository
+
+	auditRepository *mock.MockAuditRepository
+	txManager       *mock.MockTxManager
+}
+
+// newAuthMocks creates a new authMocks instance.
+func newAuthMocks(ctrl *gomock.Controller) *authMocks {
+	return &authMocks{
+		tokenGenerator:  mock.NewMockTokenGenerator(ctrl),
+		passwordHasher:  mock.NewMockPasswordHasher(ctrl),
+		tokenRepository: mock.NewMockTokenRepository(ctrl),
+		userRepository:  mock.NewMockUserRepository(ctrl),
+		mfaRepository:   mock.NewMockMFARepository(ctrl),
+		mfaProvider:     mock.NewMockMFAProvider(ctrl),
+
+		loginAttemptRepository: mock.NewMockLoginAttemptRepository(ctrl),
+
+		identityProvider:   mock.NewMockIdentityProvider(ctrl),
+		identityRepository: mock.NewMockIdentityRepository(ctrl),
+		roleRepository:     mock.NewMockRoleRepository(ctrl),
+
+		auditRepository: mock.NewMockAuditRepository(ctrl),
+		txManager:       mock.NewMockTxManager(ctrl),
+	}
+}
+
+// loginThrottle is the login throttle of the services checking passwords,
+// every failure blocks the user for a second.
+var loginThrottle = &domain.LoginThrottle{
+	User: domain.LoginThrottlePolicy{
+		MaxFailures:     5,
+		LockoutDuration: 15 * time.Minute,
+		BaseBackoff:     time.Second,
+		MaxBackoff:      time.Minute,
+	},
+	Window: time.Hour,
+}
+
+// authService creates a service.AuthService using the mocks.
+func (m *authMocks) authService() *service.AuthService {
+	return service.NewAuthService(
+		m.tokenGenerator,
+		m.passwordHasher,
+		m.tokenRepository,
+		m.userRepository,
+		m.mfaRepository,
+		m.mfaProvider,
+		m.loginAttemptRepository,
+		loginThrottle,
+		m.identityProvider,
+		m.identityRepository,
+		m.roleRepository,
+		m.auditRepository,
+		m.txManager,
+	)
+}
+
+// expectLoginAllowed sets up the mocks for checking the login attempts of an unblocked key.
+func (m *authMocks) expectLoginAllowed() {
+	expectLoginAllowed(m.loginAttemptRepository)
+}
+
+// expectUserStatus sets up the mock for fetching the user of a token with a specific status,
+// in or outside of a unit of work.
+func (m *authMocks) expectUserStatus(status domain.UserStatus) *gomock.Call {
+	return m.userRepository.
+		EXPECT().
+		GetUserById(
+			gomock.Any(),
+			gomock.AssignableToTypeOf(uuid.UUID{}),
+		).
+		Return(&domain.User{Status: status}, nil)
+}
+
+// expectFailedLogin sets up the mocks for recording a failed login of a key and its audit event.
+func (m *authMocks) expectFailedLogin(action domain.AuditAction) {
+	expectFailedLogin(m.loginAttemptRepository, m.auditRepository, action)
+}
+
+// expectLoginAllowed sets up the mock for checking the login attempts of an unblocked key.
+func expectLoginAllowed(loginAttemptRepository *mock.MockLoginAttemptRepository) {
+	loginAttemptRepository.
+		EXPECT().
+		GetLoginAttempts(
+			gomock.AssignableToTypeOf(context.Background()),
+			gomock.AssignableToTypeOf(""),
+		).
+		Return(&domain.LoginAttempts{}, nil)
+}
+
+// expectLoginBlocked sets up the mock for checking the login attempts of a locked out key.
+func expectLoginBlocked(loginAttemptRepository *mock.MockLoginAttemptRepository) {
+	loginAttemptRepository.
+		EXPECT().
+		GetLoginAttempts(
+			gomock.AssignableToTypeOf(context.Background()),
+			gomock.AssignableToTypeOf(""),
+		).
+		Return(&domain.LoginAttempts{
+			Failures:     5,
+			BlockedUntil: time.Now().Add(time.Minute),
+		}, nil)
+}
+
+// expectLoginReset sets up the mock for resetting the login attempts of a user after a successful login.
+func expectLoginReset(loginAttemptRepository *mock.MockLoginAttemptRepository) *gomock.Call {
+	return loginAttemptRepository.
+		EXPECT().
+		ResetLoginAttempts(
+			gomock.AssignableToTypeOf(context.Background()),
+			gomock.AssignableToTypeOf(""),
+		).
+		Return(nil)
+}
+
+// expectFailedLogin sets up the mocks for recording a failed login of a key and its audit event.
+func expectFailedLogin(
+	loginAttemptRepository *mock.MockLoginAttemptRepository,
+	auditRepository *mock.MockAuditRepository,
+	action domain.AuditAction,
+) {
+	gomock.InOrder(
+		loginAttemptRepository.
+			EXPECT().
+			RecordFailedLogin(
+				gomock.AssignableToTypeOf(context.Background()),
+				gomock.AssignableToTypeOf(""),
+				time.Hour,
+			).
+			Return(&domain.LoginAttempts{Failures: 1}, nil),
+		loginAttemptRepository.
+			EXPECT().
+			BlockLogin(
+				gomock.AssignableToTypeOf(context.Background()),
+				gomock.AssignableToTypeOf(""),
+				gomock.AssignableToTypeOf(time.Time{}),
+			).
+			Return(nil),
+		auditRepository.
+			EXPECT().
+			AddAuditEvent(
+				gomock.AssignableToTypeOf(context.Background()),
+				gomock.Cond(func(event *domain.AuditEvent) bool {
+					return event.Action == action
+				}),
+			).
+			Return(nil),
+	)
+}
+
 func TestAuthService_Login(t *testing.T) {
+	mfaToken := "mfaToken"
+
 	tests := []struct {
-		name               string
-		user               *domain.User
-		expectedError      error
-		expectedTokenGroup *domain.TokenGroup
-		mockSetup          func(
-			mockTokenGenerator *mock.MockTokenGenerator,
-			mockPasswordHasher *mock.MockPasswordHasher,
-			mockTokenRepository *mock.MockTokenRepository,
-			mockUserRepository *mock.MockUserRepository,
-		)
+		name           string
+		user           *domain.User
+		expectedError  error
+		expectedResult *domain.LoginResult
+		mockSetup      func(m *authMocks)
 	}{
 		{
 			name: "success",
 			user: &domain.User{
 				Password: "password",
 			},
-			expectedTokenGroup: &domain.TokenGroup{
-				AccessToken:  "token",
-				RefreshToken: "token",
+			expectedResult: &domain.LoginResult{
+				TokenGroup: &domain.TokenGroup{
+					AccessToken:  "token",
+					RefreshToken: "token",
+				},
 			},
 			expectedError: nil,
-			mockSetup: func(
-				mockTokenGenerator *mock.MockTokenGenerator,
-				mockPasswordHasher *mock.MockPasswordHasher,
-				mockTokenRepository *mock.MockTokenRepository,
-				mockUserRepository *mock.MockUserRepository) {
+			mockSetup: func(m *authMocks) {
+				m.expectLoginAllowed()
 
 				gomock.InOrder(
-					mockUserRepository.
+					m.userRepository.
 						EXPECT().
 						GetUserByUsername(
 							gomock.AssignableToTypeOf(context.Background()),
@@ -51,53 +212,298 @@ func TestAuthService_Login(t *testing.T) {
 						Return(&domain.User{
 							Password: "hashedPassword",
 						}, nil),
-					mockPasswordHasher.EXPECT().
+					m.passwordHasher.EXPECT().
 						Compare("password", "hashedPassword").
 						Return(nil),
-					mockTokenGenerator.
+					m.loginAttemptRepository.
+						EXPECT().
+						ResetLoginAttempts(
+							gomock.AssignableToTypeOf(context.Background()),
+							gomock.AssignableToTypeOf(""),
+						).
+						Return(nil),
+					m.passwordHasher.EXPECT().
+						NeedsRehash("hashedPassword").
+						Return(false),
+					m.mfaRepository.
+						EXPECT().
+						GetUserMFA(
+							gomock.AssignableToTypeOf(context.Background()),
+							gomock.AssignableToTypeOf(uuid.UUID{}),
+						).
+						Return(nil, domain.ErrMFANotEnrolled),
+					m.mfaRepository.
+						EXPECT().
+						IsMFARequired(
+							gomock.AssignableToTypeOf(context.Background()),
+							gomock.AssignableToTypeOf(domain.Client),
+						).
+						Return(false, nil),
+					m.tokenGenerator.
 						EXPECT().
 						SignToken(gomock.AssignableToTypeOf(&domain.Token{})).
 						Return("token", nil).
 						Times(2),
-					mockTokenRepository.
+					m.tokenRepository.
 						EXPECT().
 						AddToken(
 							gomock.AssignableToTypeOf(context.Background()),
 							gomock.AssignableToTypeOf(&domain.Token{}),
+							gomock.AssignableToTypeOf(&domain.AuditEvent{}),
 						).
 						Return(nil),
 				)
 			},
 		}, {
-			name:               "user not found",
-			user:               &domain.User{},
-			expectedTokenGroup: nil,
-			expectedError:      domain.ErrWrongCredentials,
-			mockSetup: func(
-				mockTokenGenerator *mock.MockTokenGenerator,
-				mockPasswordHasher *mock.MockPasswordHasher,
-				mockTokenRepository *mock.MockTokenRepository,
-				mockUserRepository *mock.MockUserRepository) {
-				mockUserRepository.
+			name: "error user suspended",
+			user: &domain.User{
+				Password: "password",
+			},
+			expectedResult: nil,
+			expectedError:  domain.ErrUserSuspended,
+			mockSetup: func(m *authMocks) {
+				m.expectLoginAllowed()
+
+				gomock.InOrder(
+					m.userRepository.
+						EXPECT().
+						GetUserByUsername(
+							gomock.AssignableToTypeOf(context.Background()),
+							gomock.AssignableToTypeOf(""),
+						).
+						Return(&domain.User{
+							Password: "hashedPassword",
+							Status:   domain.UserSuspended,
+						}, nil),
+					m.passwordHasher.EXPECT().
+						Compare("password", "hashedPassword").
+						Return(nil),
+					m.loginAttemptRepository.
+						EXPECT().
+						ResetLoginAttempts(
+							gomock.AssignableToTypeOf(context.Background()),
+							gomock.AssignableToTypeOf(""),
+						).
+						Return(nil),
+					m.passwordHasher.EXPECT().
+						NeedsRehash("hashedPassword").
+						Return(false),
+				)
+			},
+		}, {
+			name: "success rehashes outdated password",
+			user: &domain.User{
+				Password: "password",
+			},
+			expectedResult: &domain.LoginResult{
+				TokenGroup: &domain.TokenGroup{
+					AccessToken:  "token",
+					RefreshToken: "token",
+				},
+			},
+			expectedError: nil,
+			mockSetup: func(m *authMocks) {
+				m.expectLoginAllowed()
+
+				userId := uuid.New()
+				gomock.InOrder(
+					m.userRepository.
+						EXPECT().
+						GetUserByUsername(
+							gomock.AssignableToTypeOf(context.Background()),
+							gomock.AssignableToTypeOf(""),
+						).
+						Return(&domain.User{
+							Id:       userId,
+							Password: "outdatedHash",
+						}, nil),
+					m.passwordHasher.EXPECT().
+						Compare("password", "outdatedHash").
+						Return(nil),
+					m.loginAttemptRepository.
+						EXPECT().
+						ResetLoginAttempts(
+							gomock.AssignableToTypeOf(context.Background()),
+							gomock.AssignableToTypeOf(""),
+						).
+						Return(nil),
+					m.passwordHasher.EXPECT().
+						NeedsRehash("outdatedHash").
+						Return(true),
+					m.passwordHasher.EXPECT().
+						Hash("password").
+						Return("currentHash", nil),
+					m.userRepository.
+						EXPECT().
+						UpdatePasswordHash(
+							gomock.AssignableToTypeOf(context.Background()),
+							userId,
+							"currentHash",
+						).
+						Return(nil),
+					m.mfaRepository.
+						EXPECT().
+						GetUserMFA(
+							gomock.AssignableToTypeOf(context.Background()),
+							userId,
+						).
+						Return(nil, domain.ErrMFANotEnrolled),
+					m.mfaRepository.
+						EXPECT().
+						IsMFARequired(
+							gomock.AssignableToTypeOf(context.Background()),
+							gomock.AssignableToTypeOf(domain.Client),
+						).
+						Return(false, nil),
+					m.tokenGenerator.
+						EXPECT().
+						SignToken(gomock.AssignableToTypeOf(&domain.Token{})).
+						Return("token", nil).
+						Times(2),
+					m.tokenRepository.
+						EXPECT().
+						AddToken(
+							gomock.AssignableToTypeOf(context.Background()),
+							gomock.AssignableToTypeOf(&domain.Token{}),
+							gomock.AssignableToTypeOf(&domain.AuditEvent{}),
+						).
+						Return(nil),
+				)
+			},
+		}, {
+			name: "success mfa enabled",
+			user: &domain.User{
+				Password: "password",
+			},
+			expectedResult: &domain.LoginResult{
+				MFAToken: &mfaToken,
+			},
+			expectedError: nil,
+			mockSetup: func(m *authMocks) {
+				m.expectLoginAllowed()
+				gomock.InOrder(
+					m.userRepository.
+						EXPECT().
+						GetUserByUsername(
+							gomock.AssignableToTypeOf(context.Background()),
+							gomock.AssignableToTypeOf(""),
+						).
+						Return(&domain.User{
+							Password: "hashedPassword",
+						}, nil),
+					m.passwordHasher.EXPECT().
+						Compare("password", "hashedPassword").
+						Return(nil),
+					m.loginAttemptRepository.
+						EXPECT().
+						ResetLoginAttempts(
+							gomock.AssignableToTypeOf(context.Background()),
+							gomock.AssignableToTypeOf(""),
+						).
+						Return(nil),
+					m.passwordHasher.EXPECT().
+						NeedsRehash("hashedPassword").
+						Return(false),
+					m.mfaRepository.
+						EXPECT().
+						GetUserMFA(
+							gomock.AssignableToTypeOf(context.Background()),
+							gomock.AssignableToTypeOf(uuid.UUID{}),
+						).
+						Return(&domain.UserMFA{Enabled: true}, nil),
+					m.tokenGenerator.
+						EXPECT().
+						SignToken(gomock.Cond(func(token *domain.Token) bool {
+							return token.TokenType == domain.MFAToken
+						})).
+						Return(mfaToken, nil),
+				)
+			},
+		}, {
+			name: "error mfa enrollment required",
+			user: &domain.User{
+				Password: "password",
+			},
+			expectedResult: nil,
+			expectedError:  domain.ErrMFAEnrollmentRequired,
+			mockSetup: func(m *authMocks) {
+				m.expectLoginAllowed()
+				gomock.InOrder(
+					m.userRepository.
+						EXPECT().
+						GetUserByUsername(
+							gomock.AssignableToTypeOf(context.Background()),
+							gomock.AssignableToTypeOf(""),
+						).
+						Return(&domain.User{
+							Password: "hashedPassword",
+							Role:     domain.Admin,
+						}, nil),
+					m.passwordHasher.EXPECT().
+						Compare("password", "hashedPassword").
+						Return(nil),
+					m.loginAttemptRepository.
+						EXPECT().
+						ResetLoginAttempts(
+							gomock.AssignableToTypeOf(context.Background()),
+							gomock.AssignableToTypeOf(""),
+						).
+						Return(nil),
+					m.passwordHasher.EXPECT().
+						NeedsRehash("hashedPassword").
+						Return(false),
+					m.mfaRepository.
+						EXPECT().
+						GetUserMFA(
+							gomock.AssignableToTypeOf(context.Background()),
+							gomock.AssignableToTypeOf(uuid.UUID{}),
+						).
+						Return(&domain.UserMFA{Enabled: false}, nil),
+					m.mfaRepository.
+						EXPECT().
+						IsMFARequired(
+							gomock.AssignableToTypeOf(context.Background()),
+							domain.Admin,
+						).
+						Return(true, nil),
+				)
+			},
+		}, {
+			name:           "error too many login attempts",
+			user:           &domain.User{},
+			expectedResult: nil,
+			expectedError:  domain.ErrTooManyLoginAttempts,
+			mockSetup: func(m *authMocks) {
+				expectLoginBlocked(m.loginAttemptRepository)
+			},
+		}, {
+			name:           "user not found",
+			user:           &domain.User{},
+			expectedResult: nil,
+			expectedError:  domain.ErrWrongCredentials,
+			mockSetup: func(m *authMocks) {
+				m.expectLoginAllowed()
+				m.userRepository.
 					EXPECT().
 					GetUserByUsername(
 						gomock.AssignableToTypeOf(context.Background()),
 						gomock.AssignableToTypeOf(""),
 					).
 					Return(nil, domain.ErrUserNotFound)
+				m.passwordHasher.
+					EXPECT().
+					Hash(gomock.AssignableToTypeOf("")).
+					Return("hashedPassword", nil)
+				m.expectFailedLogin(domain.AuditLoginFailed)
 			},
 		}, {
-			name:               "error fetching user",
-			user:               &domain.User{},
-			expectedTokenGroup: nil,
-			expectedError:      domain.ErrInternal,
-			mockSetup: func(
-				mockTokenGenerator *mock.MockTokenGenerator,
-				mockPasswordHasher *mock.MockPasswordHasher,
-				mockTokenRepository *mock.MockTokenRepository,
-				mockUserRepository *mock.MockUserRepository,
-			) {
-				mockUserRepository.
+			name:           "error fetching user",
+			user:           &domain.User{},
+			expectedResult: nil,
+			expectedError:  domain.ErrInternal,
+			mockSetup: func(m *authMocks) {
+				m.expectLoginAllowed()
+				m.userRepository.
 					EXPECT().
 					GetUserByUsername(
 						gomock.AssignableToTypeOf(context.Background()),
@@ -109,15 +515,12 @@ func TestAuthService_Login(t *testing.T) {
 			user: &domain.User{
 				Password: "password",
 			},
-			expectedTokenGroup: nil,
-			expectedError:      domain.ErrWrongCredentials,
-			mockSetup: func(
-				mockTokenGenerator *mock.MockTokenGenerator,
-				mockPasswordHasher *mock.MockPasswordHasher,
-				mockTokenRepository *mock.MockTokenRepository,
-				mockUserRepository *mock.MockUserRepository) {
+			expectedResult: nil,
+			expectedError:  domain.ErrWrongCredentials,
+			mockSetup: func(m *authMocks) {
+				m.expectLoginAllowed()
 				gomock.InOrder(
-					mockUserRepository.
+					m.userRepository.
 						EXPECT().
 						GetUserByUsername(
 							gomock.AssignableToTypeOf(context.Background()),
@@ -125,25 +528,23 @@ func TestAuthService_Login(t *testing.T) {
 						).Return(&domain.User{
 						Password: "hashedPassword",
 					}, nil),
-					mockPasswordHasher.EXPECT().
+					m.passwordHasher.EXPECT().
 						Compare("password", "hashedPassword").
 						Return(domain.ErrWrongCredentials),
 				)
+				m.expectFailedLogin(domain.AuditLoginFailed)
 			},
 		}, {
 			name: "error signing access token",
 			user: &domain.User{
 				Password: "password",
 			},
-			expectedTokenGroup: nil,
-			expectedError:      domain.ErrInternal,
-			mockSetup: func(
-				mockTokenGenerator *mock.MockTokenGenerator,
-				mockPasswordHasher *mock.MockPasswordHasher,
-				mockTokenRepository *mock.MockTokenRepository,
-				mockUserRepository *mock.MockUserRepository) {
+			expectedResult: nil,
+			expectedError:  domain.ErrInternal,
+			mockSetup: func(m *authMocks) {
+				m.expectLoginAllowed()
 				gomock.InOrder(
-					mockUserRepository.
+					m.userRepository.
 						EXPECT().
 						GetUserByUsername(
 							gomock.AssignableToTypeOf(context.Background()),
@@ -152,10 +553,34 @@ func TestAuthService_Login(t *testing.T) {
 						Return(&domain.User{
 							Password: "hashedPassword",
 						}, nil),
-					mockPasswordHasher.EXPECT().
+					m.passwordHasher.EXPECT().
 						Compare("password", "hashedPassword").
 						Return(nil),
-					mockTokenGenerator.
+					m.loginAttemptRepository.
+						EXPECT().
+						ResetLoginAttempts(
+							gomock.AssignableToTypeOf(context.Background()),
+							gomock.AssignableToTypeOf(""),
+						).
+						Return(nil),
+					m.passwordHasher.EXPECT().
+						NeedsRehash("hashedPassword").
+						Return(false),
+					m.mfaRepository.
+						EXPECT().
+						GetUserMFA(
+							gomock.AssignableToTypeOf(context.Background()),
+							gomock.AssignableToTypeOf(uuid.UUID{}),
+						).
+						Return(nil, domain.ErrMFANotEnrolled),
+					m.mfaRepository.
+						EXPECT().
+						IsMFARequired(
+							gomock.AssignableToTypeOf(context.Background()),
+							gomock.AssignableToTypeOf(domain.Client),
+						).
+						Return(false, nil),
+					m.tokenGenerator.
 						EXPECT().
 						SignToken(gomock.AssignableToTypeOf(&domain.Token{})).
 						Return("", domain.ErrInternal),
@@ -166,15 +591,12 @@ func TestAuthService_Login(t *testing.T) {
 			user: &domain.User{
 				Password: "password",
 			},
-			expectedTokenGroup: nil,
-			expectedError:      domain.ErrInternal,
-			mockSetup: func(
-				mockTokenGenerator *mock.MockTokenGenerator,
-				mockPasswordHasher *mock.MockPasswordHasher,
-				mockTokenRepository *mock.MockTokenRepository,
-				mockUserRepository *mock.MockUserRepository) {
+			expectedResult: nil,
+			expectedError:  domain.ErrInternal,
+			mockSetup: func(m *authMocks) {
+				m.expectLoginAllowed()
 				gomock.InOrder(
-					mockUserRepository.
+					m.userRepository.
 						EXPECT().
 						GetUserByUsername(
 							gomock.AssignableToTypeOf(context.Background()),
@@ -183,14 +605,38 @@ func TestAuthService_Login(t *testing.T) {
 						Return(&domain.User{
 							Password: "hashedPassword",
 						}, nil),
-					mockPasswordHasher.EXPECT().
+					m.passwordHasher.EXPECT().
 						Compare("password", "hashedPassword").
 						Return(nil),
-					mockTokenGenerator.
+					m.loginAttemptRepository.
+						EXPECT().
+						ResetLoginAttempts(
+							gomock.AssignableToTypeOf(context.Background()),
+							gomock.AssignableToTypeOf(""),
+						).
+						Return(nil),
+					m.passwordHasher.EXPECT().
+						NeedsRehash("hashedPassword").
+						Return(false),
+					m.mfaRepository.
+						EXPECT().
+						GetUserMFA(
+							gomock.AssignableToTypeOf(context.Background()),
+							gomock.AssignableToTypeOf(uuid.UUID{}),
+						).
+						Return(nil, domain.ErrMFANotEnrolled),
+					m.mfaRepository.
+						EXPECT().
+						IsMFARequired(
+							gomock.AssignableToTypeOf(context.Background()),
+							gomock.AssignableToTypeOf(domain.Client),
+						).
+						Return(false, nil),
+					m.tokenGenerator.
 						EXPECT().
 						SignToken(gomock.AssignableToTypeOf(&domain.Token{})).
 						Return("token", nil),
-					mockTokenGenerator.
+					m.tokenGenerator.
 						EXPECT().
 						SignToken(gomock.AssignableToTypeOf(&domain.Token{})).
 						Return("", domain.ErrInternal),
@@ -201,15 +647,12 @@ func TestAuthService_Login(t *testing.T) {
 			user: &domain.User{
 				Password: "password",
 			},
-			expectedTokenGroup: nil,
-			expectedError:      domain.ErrInternal,
-			mockSetup: func(
-				mockTokenGenerator *mock.MockTokenGenerator,
-				mockPasswordHasher *mock.MockPasswordHasher,
-				mockTokenRepository *mock.MockTokenRepository,
-				mockUserRepository *mock.MockUserRepository) {
+			expectedResult: nil,
+			expectedError:  domain.ErrInternal,
+			mockSetup: func(m *authMocks) {
+				m.expectLoginAllowed()
 				gomock.InOrder(
-					mockUserRepository.
+					m.userRepository.
 						EXPECT().
 						GetUserByUsername(
 							gomock.AssignableToTypeOf(context.Background()),
@@ -218,23 +661,48 @@ func TestAuthService_Login(t *testing.T) {
 						Return(&domain.User{
 							Password: "hashedPassword",
 						}, nil),
-					mockPasswordHasher.EXPECT().
+					m.passwordHasher.EXPECT().
 						Compare("password", "hashedPassword").
 						Return(nil),
-					mockTokenGenerator.
+					m.loginAttemptRepository.
+						EXPECT().
+						ResetLoginAttempts(
+							gomock.AssignableToTypeOf(context.Background()),
+							gomock.AssignableToTypeOf(""),
+						).
+						Return(nil),
+					m.passwordHasher.EXPECT().
+						NeedsRehash("hashedPassword").
+						Return(false),
+					m.mfaRepository.
+						EXPECT().
+						GetUserMFA(
+							gomock.AssignableToTypeOf(context.Background()),
+							gomock.AssignableToTypeOf(uuid.UUID{}),
+						).
+						Return(nil, domain.ErrMFANotEnrolled),
+					m.mfaRepository.
+						EXPECT().
+						IsMFARequired(
+							gomock.AssignableToTypeOf(context.Background()),
+							gomock.AssignableToTypeOf(domain.Client),
+						).
+						Return(false, nil),
+					m.tokenGenerator.
 						EXPECT().
 						SignToken(gomock.AssignableToTypeOf(&domain.Token{})).
 						Return("token", nil),
-					mockTokenGenerator.
+					m.tokenGenerator.
 						EXPECT().
 						SignToken(gomock.AssignableToTypeOf(&domain.Token{})).
 						Return("token", nil),
 
-					mockTokenRepository.
+					m.tokenRepository.
 						EXPECT().
 						AddToken(
 							gomock.AssignableToTypeOf(context.Background()),
 							gomock.AssignableToTypeOf(&domain.Token{}),
+							gomock.AssignableToTypeOf(&domain.AuditEvent{}),
 						).
 						Return(domain.ErrInternal),
 				)
@@ -244,16 +712,10 @@ func TestAuthService_Login(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			ctrl := gomock.NewController(t)
-			mockTokenGenerator := mock.NewMockTokenGenerator(ctrl)
-			mockPasswordHasher := mock.NewMockPasswordHasher(ctrl)
-			mockUserRepository := mock.NewMockUserRepository(ctrl)
-			mockTokenRepository := mock.NewMockTokenRepository(ctrl)
-			tt.mockSetup(mockTokenGenerator, mockPasswordHasher, mockTokenRepository, mockUserRepository)
+			m := newAuthMocks(gomock.NewController(t))
+			tt.mockSetup(m)
 
-			tokenGroup, err := service.
-				NewAuthService(mockTokenGenerator, mockPasswordHasher, mockTokenRepository, mockUserRepository).
-				Login(context.Background(), tt.user)
+			result, err := m.authService().Login(context.Background(), tt.user)
 
 			if tt.expectedError != nil {
 				require.ErrorIs(t, err, tt.expectedError)
@@ -261,7 +723,7 @@ func TestAuthService_Login(t *testing.T) {
 				require.NoError(t, err)
 			}
 
-			require.Equal(t, tt.expectedTokenGroup, tokenGroup)
+			require.Equal(t, tt.expectedResult, result)
 		})
 	}
 }
@@ -272,12 +734,7 @@ func TestAuthService_RefreshSession(t *testing.T) {
 		token              *domain.Token
 		expectedTokenGroup *domain.TokenGroup
 		expectedError      error
-		mockSetup          func(
-			mockTokenGenerator *mock.MockTokenGenerator,
-			mockPasswordHasher *mock.MockPasswordHasher,
-			mockTokenRepository *mock.MockTokenRepository,
-			mockUserRepository *mock.MockUserRepository,
-		)
+		mockSetup          func(m *authMocks)
 	}{
 		{
 			name: "success",
@@ -289,26 +746,26 @@ func TestAuthService_RefreshSession(t *testing.T) {
 				RefreshToken: "token",
 			},
 			expectedError: nil,
-			mockSetup: func(
-				mockTokenGenerator *mock.MockTokenGenerator,
-				mockPasswordHasher *mock.MockPasswordHasher,
-				mockTokenRepository *mock.MockTokenRepository,
-				mockUserRepository *mock.MockUserRepository) {
-				mockTokenRepository.
+			mockSetup: func(m *authMocks) {
+				m.expectUserStatus(domain.UserActive)
+				expectWithinTx(m.txManager)
+				m.tokenRepository.
 					EXPECT().
 					DeleteToken(
-						gomock.AssignableToTypeOf(context.Background()),
+						inTx(),
 						gomock.AssignableToTypeOf(uuid.UUID{}),
 					).Return(nil)
-				mockTokenGenerator.
+				m.tokenGenerator.
 					EXPECT().
 					SignToken(gomock.AssignableToTypeOf(&domain.Token{})).
 					Return("token", nil).Times(2)
-				mockTokenRepository.
+				m.tokenRepository.
 					EXPECT().
 					AddToken(
-						gomock.AssignableToTypeOf(context.Background()),
-						gomock.AssignableToTypeOf(&domain.Token{})).
+						inTx(),
+						gomock.AssignableToTypeOf(&domain.Token{}),
+						gomock.AssignableToTypeOf(&domain.AuditEvent{}),
+					).
 					Return(nil)
 			},
 		}, {
@@ -318,12 +775,7 @@ func TestAuthService_RefreshSession(t *testing.T) {
 			},
 			expectedTokenGroup: nil,
 			expectedError:      domain.ErrInvalidTokenType,
-			mockSetup: func(
-				mockTokenGenerator *mock.MockTokenGenerator,
-				mockPasswordHasher *mock.MockPasswordHasher,
-				mockTokenRepository *mock.MockTokenRepository,
-				mockUserRepository *mock.MockUserRepository,
-			) {
+			mockSetup: func(m *authMocks) {
 
 			},
 		}, {
@@ -333,15 +785,13 @@ func TestAuthService_RefreshSession(t *testing.T) {
 			},
 			expectedTokenGroup: nil,
 			expectedError:      domain.ErrInternal,
-			mockSetup: func(
-				mockTokenGenerator *mock.MockTokenGenerator,
-				mockPasswordHasher *mock.MockPasswordHasher,
-				mockTokenRepository *mock.MockTokenRepository,
-				mockUserRepository *mock.MockUserRepository) {
-				mockTokenRepository.
+			mockSetup: func(m *authMocks) {
+				m.expectUserStatus(domain.UserActive)
+				expectWithinTx(m.txManager)
+				m.tokenRepository.
 					EXPECT().
 					DeleteToken(
-						gomock.AssignableToTypeOf(context.Background()),
+						inTx(),
 						gomock.AssignableToTypeOf(uuid.UUID{}),
 					).
 					Return(domain.ErrInternal)
@@ -353,19 +803,17 @@ func TestAuthService_RefreshSession(t *testing.T) {
 			},
 			expectedTokenGroup: nil,
 			expectedError:      domain.ErrInternal,
-			mockSetup: func(
-				mockTokenGenerator *mock.MockTokenGenerator,
-				mockPasswordHasher *mock.MockPasswordHasher,
-				mockTokenRepository *mock.MockTokenRepository,
-				mockUserRepository *mock.MockUserRepository) {
+			mockSetup: func(m *authMocks) {
+				m.expectUserStatus(domain.UserActive)
 				gomock.InOrder(
-					mockTokenRepository.
+					expectWithinTx(m.txManager),
+					m.tokenRepository.
 						EXPECT().
 						DeleteToken(
-							gomock.AssignableToTypeOf(context.Background()),
+							inTx(),
 							gomock.AssignableToTypeOf(uuid.UUID{})).
 						Return(nil),
-					mockTokenGenerator.
+					m.tokenGenerator.
 						EXPECT().
 						SignToken(gomock.AssignableToTypeOf(&domain.Token{})).
 						Return("", domain.ErrInternal),
@@ -378,23 +826,21 @@ func TestAuthService_RefreshSession(t *testing.T) {
 			},
 			expectedTokenGroup: nil,
 			expectedError:      domain.ErrInternal,
-			mockSetup: func(
-				mockTokenGenerator *mock.MockTokenGenerator,
-				mockPasswordHasher *mock.MockPasswordHasher,
-				mockTokenRepository *mock.MockTokenRepository,
-				mockUserRepository *mock.MockUserRepository) {
+			mockSetup: func(m *authMocks) {
+				m.expectUserStatus(domain.UserActive)
 				gomock.InOrder(
-					mockTokenRepository.
+					expectWithinTx(m.txManager),
+					m.tokenRepository.
 						EXPECT().
 						DeleteToken(
-							gomock.AssignableToTypeOf(context.Background()),
+							inTx(),
 							gomock.AssignableToTypeOf(uuid.UUID{})).
 						Return(nil),
-					mockTokenGenerator.
+					m.tokenGenerator.
 						EXPECT().
 						SignToken(gomock.AssignableToTypeOf(&domain.Token{})).
 						Return("token", nil),
-					mockTokenGenerator.
+					m.tokenGenerator.
 						EXPECT().
 						SignToken(gomock.AssignableToTypeOf(&domain.Token{})).
 						Return("", domain.ErrInternal),
@@ -407,46 +853,63 @@ func TestAuthService_RefreshSession(t *testing.T) {
 			},
 			expectedTokenGroup: nil,
 			expectedError:      domain.ErrInternal,
-			mockSetup: func(
-				mockTokenGenerator *mock.MockTokenGenerator,
-				mockPasswordHasher *mock.MockPasswordHasher,
-				mockTokenRepository *mock.MockTokenRepository,
-				mockUserRepository *mock.MockUserRepository) {
-				mockTokenRepository.
+			mockSetup: func(m *authMocks) {
+				m.expectUserStatus(domain.UserActive)
+				expectWithinTx(m.txManager)
+				m.tokenRepository.
 					EXPECT().
 					DeleteToken(
-						gomock.AssignableToTypeOf(context.Background()),
+						inTx(),
 						gomock.AssignableToTypeOf(uuid.UUID{}),
 					).Return(nil)
-				mockTokenGenerator.
+				m.tokenGenerator.
 					EXPECT().
 					SignToken(gomock.AssignableToTypeOf(&domain.Token{})).
 					Return("token", nil).Times(2)
-				mockTokenRepository.
+				m.tokenRepository.
 					EXPECT().
 					AddToken(
-						gomock.AssignableToTypeOf(context.Background()),
-						gomock.AssignableToTypeOf(&domain.Token{})).
+						inTx(),
+						gomock.AssignableToTypeOf(&domain.Token{}),
+						gomock.AssignableToTypeOf(&domain.AuditEvent{}),
+					).
 					Return(domain.ErrInternal)
 			},
+		}, {
+			name: "error user suspended",
+			token: &domain.Token{
+				TokenType: domain.RefreshToken,
+			},
+			expectedTokenGroup: nil,
+			expectedError:      domain.ErrUserSuspended,
+			mockSetup: func(m *authMocks) {
+				m.expectUserStatus(domain.UserSuspended)
+			},
+		}, {
+			name: "error user deleted",
+			token: &domain.Token{
+				TokenType: domain.RefreshToken,
+			},
+			expectedTokenGroup: nil,
+			expectedError:      domain.ErrInvalidToken,
+			mockSetup: func(m *authMocks) {
+				m.userRepository.
+					EXPECT().
+					GetUserById(
+						gomock.AssignableToTypeOf(context.Background()),
+						gomock.AssignableToTypeOf(uuid.UUID{}),
+					).
+					Return(nil, domain.ErrUserNotFound)
+			},
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			ctrl := gomock.NewController(t)
-			mockTokenGenerator := mock.NewMockTokenGenerator(ctrl)
-			mockPasswordHasher := mock.NewMockPasswordHasher(ctrl)
-			mockUserRepository := mock.NewMockUserRepository(ctrl)
-			mockTokenRepository := mock.NewMockTokenRepository(ctrl)
-			tt.mockSetup(mockTokenGenerator, mockPasswordHasher, mockTokenRepository, mockUserRepository)
-
-			tokenGroup, err := service.NewAuthService(
-				mockTokenGenerator,
-				mockPasswordHasher,
-				mockTokenRepository,
-				mockUserRepository,
-			).
+			m := newAuthMocks(gomock.NewController(t))
+			tt.mockSetup(m)
+
+			tokenGroup, err := m.authService().
 				RefreshSession(context.Background(), tt.token)
 
 			if tt.expectedError != nil {
@@ -459,3 +922,789 @@ func TestAuthService_RefreshSession(t *testing.T) {
 		})
 	}
 }
+
+func TestAuthService_VerifyMFA(t *testing.T) {
+	tests := []struct {
+		name               string
+		token              *domain.Token
+		code               string
+		expectedTokenGroup *domain.TokenGroup
+		expectedError      error
+		mockSetup          func(m *authMocks)
+	}{
+		{
+			name: "success totp code",
+			token: &domain.Token{
+				TokenType: domain.MFAToken,
+			},
+			code: "123456",
+			expectedTokenGroup: &domain.TokenGroup{
+				AccessToken:  "token",
+				RefreshToken: "token",
+			},
+			mockSetup: func(m *authMocks) {
+				m.expectLoginAllowed()
+				gomock.InOrder(
+					m.mfaRepository.
+						EXPECT().
+						GetUserMFA(
+							gomock.AssignableToTypeOf(context.Background()),
+							gomock.AssignableToTypeOf(uuid.UUID{}),
+						).
+						Return(&domain.UserMFA{Secret: "secret", Enabled: true}, nil),
+					m.mfaProvider.
+						EXPECT().
+						ValidateCode("123456", "secret").
+						Return(int64(42), true),
+					expectWithinTx(m.txManager),
+					m.mfaRepository.
+						EXPECT().
+						UpdateLastUsedStep(
+							inTx(),
+							gomock.AssignableToTypeOf(uuid.UUID{}),
+							int64(42),
+						).
+						Return(nil),
+					m.loginAttemptRepository.
+						EXPECT().
+						ResetLoginAttempts(
+							inTx(),
+							gomock.AssignableToTypeOf(""),
+						).
+						Return(nil),
+					m.expectUserStatus(domain.UserActive),
+					m.tokenGenerator.
+						EXPECT().
+						SignToken(gomock.AssignableToTypeOf(&domain.Token{})).
+						Return("token", nil).
+						Times(2),
+					m.tokenRepository.
+						EXPECT().
+						AddToken(
+							inTx(),
+							gomock.AssignableToTypeOf(&domain.Token{}),
+							gomock.AssignableToTypeOf(&domain.AuditEvent{}),
+						).
+						Return(nil),
+				)
+			},
+		}, {
+			name: "success recovery code",
+			token: &domain.Token{
+				TokenType: domain.MFAToken,
+			},
+			code: "ABCDE-FGHIJ",
+			expectedTokenGroup: &domain.TokenGroup{
+				AccessToken:  "token",
+				RefreshToken: "token",
+			},
+			mockSetup: func(m *authMocks) {
+				m.expectLoginAllowed()
+				gomock.InOrder(
+					m.mfaRepository.
+						EXPECT().
+						GetUserMFA(
+							gomock.AssignableToTypeOf(context.Background()),
+							gomock.AssignableToTypeOf(uuid.UUID{}),
+						).
+						Return(&domain.UserMFA{Secret: "secret", Enabled: true}, nil),
+					m.mfaProvider.
+						EXPECT().
+						ValidateCode("ABCDE-FGHIJ", "secret").
+						Return(int64(0), false),
+					expectWithinTx(m.txManager),
+					m.mfaProvider.
+						EXPECT().
+						HashRecoveryCode("ABCDE-FGHIJ").
+						Return("hashedCode"),
+					m.mfaRepository.
+						EXPECT().
+						UseRecoveryCode(
+							inTx(),
+							gomock.AssignableToTypeOf(uuid.UUID{}),
+							"hashedCode",
+						).
+						Return(nil),
+					m.loginAttemptRepository.
+						EXPECT().
+						ResetLoginAttempts(
+							inTx(),
+							gomock.AssignableToTypeOf(""),
+						).
+						Return(nil),
+					m.expectUserStatus(domain.UserActive),
+					m.tokenGenerator.
+						EXPECT().
+						SignToken(gomock.AssignableToTypeOf(&domain.Token{})).
+						Return("token", nil).
+						Times(2),
+					m.tokenRepository.
+						EXPECT().
+						AddToken(
+							inTx(),
+							gomock.AssignableToTypeOf(&domain.Token{}),
+							gomock.AssignableToTypeOf(&domain.AuditEvent{}),
+						).
+						Return(nil),
+				)
+			},
+		}, {
+			name: "error invalid token type",
+			token: &domain.Token{
+				TokenType: domain.AccessToken,
+			},
+			expectedError: domain.ErrInvalidTokenType,
+			mockSetup: func(m *authMocks) {
+
+			},
+		}, {
+			name: "error mfa not enabled",
+			token: &domain.Token{
+				TokenType: domain.MFAToken,
+			},
+			expectedError: domain.ErrInvalidToken,
+			mockSetup: func(m *authMocks) {
+				m.mfaRepository.
+					EXPECT().
+					GetUserMFA(
+						gomock.AssignableToTypeOf(context.Background()),
+						gomock.AssignableToTypeOf(uuid.UUID{}),
+					).
+					Return(nil, domain.ErrMFANotEnrolled)
+			},
+		}, {
+			name: "error invalid code",
+			token: &domain.Token{
+				TokenType: domain.MFAToken,
+			},
+			code:          "000000",
+			expectedError: domain.ErrInvalidMFACode,
+			mockSetup: func(m *authMocks) {
+				m.expectLoginAllowed()
+				gomock.InOrder(
+					m.mfaRepository.
+						EXPECT().
+						GetUserMFA(
+							gomock.AssignableToTypeOf(context.Background()),
+							gomock.AssignableToTypeOf(uuid.UUID{}),
+						).
+						Return(&domain.UserMFA{Secret: "secret", Enabled: true}, nil),
+					m.mfaProvider.
+						EXPECT().
+						ValidateCode("000000", "secret").
+						Return(int64(0), false),
+					expectWithinTx(m.txManager),
+					m.mfaProvider.
+						EXPECT().
+						HashRecoveryCode("000000").
+						Return("hashedCode"),
+					m.mfaRepository.
+						EXPECT().
+						UseRecoveryCode(
+							inTx(),
+							gomock.AssignableToTypeOf(uuid.UUID{}),
+							"hashedCode",
+						).
+						Return(domain.ErrInvalidMFACode),
+				)
+				m.expectFailedLogin(domain.AuditMFAFailed)
+			},
+		}, {
+			name: "error replayed code",
+			token: &domain.Token{
+				TokenType: domain.MFAToken,
+			},
+			code:          "123456",
+			expectedError: domain.ErrInvalidMFACode,
+			mockSetup: func(m *authMocks) {
+				m.expectLoginAllowed()
+				gomock.InOrder(
+					m.mfaRepository.
+						EXPECT().
+						GetUserMFA(
+							gomock.AssignableToTypeOf(context.Background()),
+							gomock.AssignableToTypeOf(uuid.UUID{}),
+						).
+						Return(&domain.UserMFA{Secret: "secret", Enabled: true}, nil),
+					m.mfaProvider.
+						EXPECT().
+						ValidateCode("123456", "secret").
+						Return(int64(42), true),
+					expectWithinTx(m.txManager),
+					m.mfaRepository.
+						EXPECT().
+						UpdateLastUsedStep(
+							inTx(),
+							gomock.AssignableToTypeOf(uuid.UUID{}),
+							int64(42),
+						).
+						Return(domain.ErrInvalidMFACode),
+				)
+				m.expectFailedLogin(domain.AuditMFAFailed)
+			},
+		}, {
+			name: "error suspended user rolls back used code",
+			token: &domain.Token{
+				TokenType: domain.MFAToken,
+			},
+			code:          "123456",
+			expectedError: domain.ErrUserSuspended,
+			mockSetup: func(m *authMocks) {
+				m.expectLoginAllowed()
+				gomock.InOrder(
+					m.mfaRepository.
+						EXPECT().
+						GetUserMFA(
+							gomock.AssignableToTypeOf(context.Background()),
+							gomock.AssignableToTypeOf(uuid.UUID{}),
+						).
+						Return(&domain.UserMFA{Secret: "secret", Enabled: true}, nil),
+					m.mfaProvider.
+						EXPECT().
+						ValidateCode("123456", "secret").
+						Return(int64(42), true),
+					expectWithinTx(m.txManager),
+					m.mfaRepository.
+						EXPECT().
+						UpdateLastUsedStep(
+							inTx(),
+							gomock.AssignableToTypeOf(uuid.UUID{}),
+							int64(42),
+						).
+						Return(nil),
+					m.loginAttemptRepository.
+						EXPECT().
+						ResetLoginAttempts(
+							inTx(),
+							gomock.AssignableToTypeOf(""),
+						).
+						Return(nil),
+					m.expectUserStatus(domain.UserSuspended),
+				)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := newAuthMocks(gomock.NewController(t))
+			tt.mockSetup(m)
+
+			tokenGroup, err := m.authService().VerifyMFA(context.Background(), tt.token, tt.code)
+
+			if tt.expectedError != nil {
+				require.ErrorIs(t, err, tt.expectedError)
+			} else {
+				require.NoError(t, err)
+			}
+
+			require.Equal(t, tt.expectedTokenGroup, tokenGroup)
+		})
+	}
+}
+
+func TestAuthService_StartOAuthLogin(t *testing.T) {
+	state := &domain.OAuthState{State: "state", Provider: "google"}
+
+	tests := []struct {
+		name          string
+		provider      string
+		expectedURL   string
+		expectedError error
+		mockSetup     func(m *authMocks)
+	}{
+		{
+			name:          "success",
+			provider:      "google",
+			expectedURL:   "https://accounts.google.com/auth",
+			expectedError: nil,
+			mockSetup: func(m *authMocks) {
+				gomock.InOrder(
+					m.identityProvider.
+						EXPECT().
+						Authorize(gomock.AssignableToTypeOf(context.Background()), "google").
+						Return(&domain.OAuthAuthorization{State: state, URL: "https://accounts.google.com/auth"}, nil),
+					m.identityRepository.
+						EXPECT().
+						AddOAuthState(gomock.AssignableToTypeOf(context.Background()), state).
+						Return(nil),
+				)
+			},
+		}, {
+			name:          "unknown provider",
+			provider:      "unknown",
+			expectedURL:   "",
+			expectedError: domain.ErrIdentityProviderNotFound,
+			mockSetup: func(m *authMocks) {
+				m.identityProvider.
+					EXPECT().
+					Authorize(gomock.AssignableToTypeOf(context.Background()), "unknown").
+					Return(nil, domain.ErrIdentityProviderNotFound)
+			},
+		}, {
+			name:          "error adding state",
+			provider:      "google",
+			expectedURL:   "",
+			expectedError: domain.ErrInternal,
+			mockSetup: func(m *authMocks) {
+				gomock.InOrder(
+					m.identityProvider.
+						EXPECT().
+						Authorize(gomock.AssignableToTypeOf(context.Background()), "google").
+						Return(&domain.OAuthAuthorization{State: state, URL: "https://accounts.google.com/auth"}, nil),
+					m.identityRepository.
+						EXPECT().
+						AddOAuthState(gomock.AssignableToTypeOf(context.Background()), state).
+						Return(domain.ErrInternal),
+				)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := newAuthMocks(gomock.NewController(t))
+			tt.mockSetup(m)
+
+			url, err := m.authService().StartOAuthLogin(context.Background(), tt.provider)
+
+			if tt.expectedError != nil {
+				require.ErrorIs(t, err, tt.expectedError)
+			} else {
+				require.NoError(t, err)
+			}
+
+			require.Equal(t, tt.expectedURL, url)
+		})
+	}
+}
+
+func TestAuthService_StartOAuthLink(t *testing.T) {
+	userId := uuid.New()
+
+	tests := []struct {
+		name          string
+		token         *domain.Token
+		expectedURL   string
+		expectedError error
+		mockSetup     func(m *authMocks)
+	}{
+		{
+			name:          "success",
+			token:         &domain.Token{UserId: userId, TokenType: domain.AccessToken},
+			expectedURL:   "https://accounts.google.com/auth",
+			expectedError: nil,
+			mockSetup: func(m *authMocks) {
+				state := &domain.OAuthState{State: "state", Provider: "google"}
+				gomock.InOrder(
+					m.identityProvider.
+						EXPECT().
+						Authorize(gomock.AssignableToTypeOf(context.Background()), "google").
+						Return(&domain.OAuthAuthorization{State: state, URL: "https://accounts.google.com/auth"}, nil),
+					m.identityRepository.
+						EXPECT().
+						AddOAuthState(
+							gomock.AssignableToTypeOf(context.Background()),
+							gomock.Cond(func(state *domain.OAuthState) bool {
+								return state.UserId != nil && *state.UserId == userId
+							}),
+						).
+						Return(nil),
+				)
+			},
+		}, {
+			name:          "refresh token",
+			token:         &domain.Token{UserId: userId, TokenType: domain.RefreshToken},
+			expectedURL:   "",
+			expectedError: domain.ErrInvalidTokenType,
+			mockSetup:     func(m *authMocks) {},
+		}, {
+			name:          "impersonation token",
+			token:         &domain.Token{UserId: userId, TokenType: domain.AccessToken, ActorId: &userId},
+			expectedURL:   "",
+			expectedError: domain.ErrInvalidTokenType,
+			mockSetup:     func(m *authMocks) {},
+		}, {
+			name:          "api key",
+			token:         &domain.Token{UserId: userId, TokenType: domain.AccessToken, Scopes: []domain.Permission{}},
+			expectedURL:   "",
+			expectedError: domain.ErrInvalidTokenType,
+			mockSetup:     func(m *authMocks) {},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := newAuthMocks(gomock.NewController(t))
+			tt.mockSetup(m)
+
+			url, err := m.authService().StartOAuthLink(context.Background(), tt.token, "google")
+
+			if tt.expectedError != nil {
+				require.ErrorIs(t, err, tt.expectedError)
+			} else {
+				require.NoError(t, err)
+			}
+
+			require.Equal(t, tt.expectedURL, url)
+		})
+	}
+}
+
+func TestAuthService_FinishOAuthLogin(t *testing.T) {
+	userId := uuid.New()
+	mfaToken := "mfaToken"
+	state := &domain.OAuthState{State: "state", Provider: "google", ExpiresAt: time.Now().Add(time.Minute)}
+	external := &domain.ExternalIdentity{
+		Provider:          "google",
+		Subject:           "subject",
+		Email:             "user@example.com",
+		EmailVerified:     true,
+		PreferredUsername: "external.user",
+	}
+	tokenGroup := &domain.LoginResult{
+		TokenGroup: &domain.TokenGroup{
+			AccessToken:  "token",
+			RefreshToken: "token",
+		},
+	}
+
+	// expectState sets up the mocks for consuming the state and exchanging the code.
+	expectState := func(m *authMocks, external *domain.ExternalIdentity) {
+		m.identityRepository.
+			EXPECT().
+			ConsumeOAuthState(gomock.AssignableToTypeOf(context.Background()), "state").
+			Return(state, nil)
+		m.identityProvider.
+			EXPECT().
+			Exchange(gomock.AssignableToTypeOf(context.Background()), state, "code").
+			Return(external, nil)
+	}
+
+	// expectLinkState sets up the mocks for consuming the state of a link started by the user and exchanging the code.
+	expectLinkState := func(m *authMocks) {
+		linkState := *state
+		linkState.UserId = &userId
+		m.identityRepository.
+			EXPECT().
+			ConsumeOAuthState(gomock.AssignableToTypeOf(context.Background()), "state").
+			Return(&linkState, nil)
+		m.identityProvider.
+			EXPECT().
+			Exchange(gomock.AssignableToTypeOf(context.Background()), &linkState, "code").
+			Return(external, nil)
+	}
+
+	// expectTokenGroup sets up the mocks for finishing the login of a user without MFA.
+	expectTokenGroup := func(m *authMocks) {
+		m.mfaRepository.
+			EXPECT().
+			GetUserMFA(gomock.AssignableToTypeOf(context.Background()), gomock.AssignableToTypeOf(uuid.UUID{})).
+			Return(nil, domain.ErrMFANotEnrolled)
+		m.mfaRepository.
+			EXPECT().
+			IsMFARequired(gomock.AssignableToTypeOf(context.Background()), gomock.AssignableToTypeOf(domain.Client)).
+			Return(false, nil)
+		m.tokenGenerator.
+			EXPECT().
+			SignToken(gomock.AssignableToTypeOf(&domain.Token{})).
+			Return("token", nil).Times(2)
+		m.tokenRepository.
+			EXPECT().
+			AddToken(gomock.AssignableToTypeOf(context.Background()), gomock.AssignableToTypeOf(&domain.Token{}), gomock.AssignableToTypeOf(&domain.AuditEvent{})).
+			Return(nil)
+	}
+
+	tests := []struct {
+		name           string
+		provider       string
+		expectedResult *domain.LoginResult
+		expectedError  error
+		mockSetup      func(m *authMocks)
+	}{
+		{
+			name:           "success linked identity",
+			provider:       "google",
+			expectedResult: tokenGroup,
+			expectedError:  nil,
+			mockSetup: func(m *authMocks) {
+				expectState(m, external)
+				m.identityRepository.
+					EXPECT().
+					GetUserIdentity(gomock.AssignableToTypeOf(context.Background()), "google", "subject").
+					Return(&domain.UserIdentity{UserId: userId}, nil)
+				m.userRepository.
+					EXPECT().
+					GetUserById(gomock.AssignableToTypeOf(context.Background()), userId).
+					Return(&domain.User{Id: userId, Role: domain.Client}, nil)
+				expectTokenGroup(m)
+			},
+		}, {
+			name:           "success link by verified email",
+			provider:       "google",
+			expectedResult: tokenGroup,
+			expectedError:  nil,
+			mockSetup: func(m *authMocks) {
+				expectState(m, external)
+				m.identityRepository.
+					EXPECT().
+					GetUserIdentity(gomock.AssignableToTypeOf(context.Background()), "google", "subject").
+					Return(nil, domain.ErrIdentityNotFound)
+				m.userRepository.
+					EXPECT().
+					GetUserByEmail(gomock.AssignableToTypeOf(context.Background()), "user@example.com").
+					Return(&domain.User{Id: userId, Role: domain.Client}, nil)
+				m.roleRepository.
+					EXPECT().
+					GetRole(gomock.AssignableToTypeOf(context.Background()), domain.Client).
+					Return(&domain.Role{Name: domain.Client}, nil)
+				m.mfaRepository.
+					EXPECT().
+					GetUserMFA(gomock.AssignableToTypeOf(context.Background()), userId).
+					Return(nil, domain.ErrMFANotEnrolled)
+				m.identityRepository.
+					EXPECT().
+					AddUserIdentity(
+						gomock.AssignableToTypeOf(context.Background()),
+						gomock.Cond(func(identity *domain.UserIdentity) bool {
+							return identity.UserId == userId && identity.Provider == "google" && identity.Subject == "subject"
+						}),
+					).
+					Return(nil)
+				expectTokenGroup(m)
+			},
+		}, {
+			name:           "no link by verified email of a privileged user",
+			provider:       "google",
+			expectedResult: nil,
+			expectedError:  domain.ErrOAuthLinkRequired,
+			mockSetup: func(m *authMocks) {
+				expectState(m, external)
+				m.identityRepository.
+					EXPECT().
+					GetUserIdentity(gomock.AssignableToTypeOf(context.Background()), "google", "subject").
+					Return(nil, domain.ErrIdentityNotFound)
+				m.userRepository.
+					EXPECT().
+					GetUserByEmail(gomock.AssignableToTypeOf(context.Background()), "user@example.com").
+					Return(&domain.User{Id: userId, Role: domain.Warehouse}, nil)
+				m.roleRepository.
+					EXPECT().
+					GetRole(gomock.AssignableToTypeOf(context.Background()), domain.Warehouse).
+					Return(&domain.Role{Name: domain.Warehouse, Permissions: []domain.Permission{domain.ProductsWrite}}, nil)
+			},
+		}, {
+			name:           "no link by verified email of a user with mfa",
+			provider:       "google",
+			expectedResult: nil,
+			expectedError:  domain.ErrOAuthLinkRequired,
+			mockSetup: func(m *authMocks) {
+				expectState(m, external)
+				m.identityRepository.
+					EXPECT().
+					GetUserIdentity(gomock.AssignableToTypeOf(context.Background()), "google", "subject").
+					Return(nil, domain.ErrIdentityNotFound)
+				m.userRepository.
+					EXPECT().
+					GetUserByEmail(gomock.AssignableToTypeOf(context.Background()), "user@example.com").
+					Return(&domain.User{Id: userId, Role: domain.Client}, nil)
+				m.roleRepository.
+					EXPECT().
+					GetRole(gomock.AssignableToTypeOf(context.Background()), domain.Client).
+					Return(&domain.Role{Name: domain.Client}, nil)
+				m.mfaRepository.
+					EXPECT().
+					GetUserMFA(gomock.AssignableToTypeOf(context.Background()), userId).
+					Return(&domain.UserMFA{UserId: userId, Enabled: true}, nil)
+			},
+		}, {
+			name:           "success link of logged in user",
+			provider:       "google",
+			expectedResult: tokenGroup,
+			expectedError:  nil,
+			mockSetup: func(m *authMocks) {
+				expectLinkState(m)
+				m.identityRepository.
+					EXPECT().
+					GetUserIdentity(gomock.AssignableToTypeOf(context.Background()), "google", "subject").
+					Return(nil, domain.ErrIdentityNotFound)
+				m.identityRepository.
+					EXPECT().
+					AddUserIdentity(
+						gomock.AssignableToTypeOf(context.Background()),
+						gomock.Cond(func(identity *domain.UserIdentity) bool {
+							return identity.UserId == userId && identity.Provider == "google" && identity.Subject == "subject"
+						}),
+					).
+					Return(nil)
+				m.userRepository.
+					EXPECT().
+					GetUserById(gomock.AssignableToTypeOf(context.Background()), userId).
+					Return(&domain.User{Id: userId, Role: domain.Client}, nil)
+				expectTokenGroup(m)
+			},
+		}, {
+			name:           "link of identity linked to another user",
+			provider:       "google",
+			expectedResult: nil,
+			expectedError:  domain.ErrIdentityAlreadyLinked,
+			mockSetup: func(m *authMocks) {
+				expectLinkState(m)
+				m.identityRepository.
+					EXPECT().
+					GetUserIdentity(gomock.AssignableToTypeOf(context.Background()), "google", "subject").
+					Return(&domain.UserIdentity{UserId: uuid.New()}, nil)
+			},
+		}, {
+			name:           "success new client user",
+			provider:       "google",
+			expectedResult: tokenGroup,
+			expectedError:  nil,
+			mockSetup: func(m *authMocks) {
+				expectState(m, external)
+				m.identityRepository.
+					EXPECT().
+					GetUserIdentity(gomock.AssignableToTypeOf(context.Background()), "google", "subject").
+					Return(nil, domain.ErrIdentityNotFound)
+				m.userRepository.
+					EXPECT().
+					GetUserByEmail(gomock.AssignableToTypeOf(context.Background()), "user@example.com").
+					Return(nil, domain.ErrUserNotFound)
+				m.passwordHasher.
+					EXPECT().
+					Hash(gomock.AssignableToTypeOf("")).
+					Return("hash", nil)
+				gomock.InOrder(
+					m.identityRepository.
+						EXPECT().
+						AddUserWithIdentity(
+							gomock.AssignableToTypeOf(context.Background()),
+							gomock.Cond(func(user *domain.User) bool {
+								return user.Username == "external.user" && user.Role == domain.Client
+							}),
+							gomock.AssignableToTypeOf(&domain.UserIdentity{}),
+							gomock.AssignableToTypeOf([]*domain.Event{}),
+						).
+						Return(domain.ErrUsernameAlreadyInUse),
+					m.identityRepository.
+						EXPECT().
+						AddUserWithIdentity(
+							gomock.AssignableToTypeOf(context.Background()),
+							gomock.Cond(func(user *domain.User) bool {
+								return user.Username != "external.user" && user.Email == "user@example.com"
+							}),
+							gomock.AssignableToTypeOf(&domain.UserIdentity{}),
+							gomock.Cond(func(outbox []*domain.Event) bool {
+								return len(outbox) == 1 && outbox[0].Type == domain.EventUserRegistered
+							}),
+						).
+						Return(nil),
+				)
+				expectTokenGroup(m)
+			},
+		}, {
+			name:           "invalid state",
+			provider:       "google",
+			expectedResult: nil,
+			expectedError:  domain.ErrInvalidOAuthState,
+			mockSetup: func(m *authMocks) {
+				m.identityRepository.
+					EXPECT().
+					ConsumeOAuthState(gomock.AssignableToTypeOf(context.Background()), "state").
+					Return(nil, domain.ErrInvalidOAuthState)
+			},
+		}, {
+			name:           "state of another provider",
+			provider:       "github",
+			expectedResult: nil,
+			expectedError:  domain.ErrInvalidOAuthState,
+			mockSetup: func(m *authMocks) {
+				m.identityRepository.
+					EXPECT().
+					ConsumeOAuthState(gomock.AssignableToTypeOf(context.Background()), "state").
+					Return(state, nil)
+			},
+		}, {
+			name:           "expired state",
+			provider:       "google",
+			expectedResult: nil,
+			expectedError:  domain.ErrInvalidOAuthState,
+			mockSetup: func(m *authMocks) {
+				m.identityRepository.
+					EXPECT().
+					ConsumeOAuthState(gomock.AssignableToTypeOf(context.Background()), "state").
+					Return(&domain.OAuthState{State: "state", Provider: "google", ExpiresAt: time.Now().Add(-time.Minute)}, nil)
+			},
+		}, {
+			name:           "exchange failed",
+			provider:       "google",
+			expectedResult: nil,
+			expectedError:  domain.ErrExternalAuthFailed,
+			mockSetup: func(m *authMocks) {
+				m.identityRepository.
+					EXPECT().
+					ConsumeOAuthState(gomock.AssignableToTypeOf(context.Background()), "state").
+					Return(state, nil)
+				m.identityProvider.
+					EXPECT().
+					Exchange(gomock.AssignableToTypeOf(context.Background()), state, "code").
+					Return(nil, domain.ErrExternalAuthFailed)
+			},
+		}, {
+			name:           "unverified email",
+			provider:       "google",
+			expectedResult: nil,
+			expectedError:  domain.ErrExternalEmailNotVerified,
+			mockSetup: func(m *authMocks) {
+				unverified := *external
+				unverified.EmailVerified = false
+				expectState(m, &unverified)
+				m.identityRepository.
+					EXPECT().
+					GetUserIdentity(gomock.AssignableToTypeOf(context.Background()), "google", "subject").
+					Return(nil, domain.ErrIdentityNotFound)
+			},
+		}, {
+			name:           "mfa challenge",
+			provider:       "google",
+			expectedResult: &domain.LoginResult{MFAToken: &mfaToken},
+			expectedError:  nil,
+			mockSetup: func(m *authMocks) {
+				expectState(m, external)
+				m.identityRepository.
+					EXPECT().
+					GetUserIdentity(gomock.AssignableToTypeOf(context.Background()), "google", "subject").
+					Return(&domain.UserIdentity{UserId: userId}, nil)
+				m.userRepository.
+					EXPECT().
+					GetUserById(gomock.AssignableToTypeOf(context.Background()), userId).
+					Return(&domain.User{Id: userId, Role: domain.Admin}, nil)
+				m.mfaRepository.
+					EXPECT().
+					GetUserMFA(gomock.AssignableToTypeOf(context.Background()), userId).
+					Return(&domain.UserMFA{UserId: userId, Enabled: true}, nil)
+				m.tokenGenerator.
+					EXPECT().
+					SignToken(gomock.Cond(func(token *domain.Token) bool { return token.TokenType == domain.MFAToken })).
+					Return("mfaToken", nil)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := newAuthMocks(gomock.NewController(t))
+			tt.mockSetup(m)
+
+			result, err := m.authService().FinishOAuthLogin(context.Background(), tt.provider, "state", "code")
+
+			if tt.expectedError != nil {
+				require.ErrorIs(t, err, tt.expectedError)
+			} else {
+				require.NoError(t, err)
+			}
+
+			require.Equal(t, tt.expectedResult, result)
+		})
+	}
+}
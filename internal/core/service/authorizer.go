@@ -0,0 +1,41 @@
+package service
+
+import (
+	"context"
+	"shop-api-go/internal/core/domain"
+	"shop-api-go/internal/core/port"
+)
+
+// Authorizer implements port.Authorizer interface and checks the permissions
+// granted to the role of a token.
+type Authorizer struct {
+	roleRepository port.RoleRepository
+}
+
+// NewAuthorizer creates a new Authorizer instance.
+func NewAuthorizer(roleRepository port.RoleRepository) *Authorizer {
+	return &Authorizer{
+		roleRepository: roleRepository,
+	}
+}
+
+func (a *Authorizer) Authorize(ctx context.Context, token *domain.Token, permission domain.Permission) error {
+	if token.TokenType != domain.AccessToken {
+		return domain.ErrInvalidTokenType
+	}
+	if !token.HasScope(permission) {
+		return domain.ErrInsufficientScope
+	}
+	if token.IsImpersonated() && !permission.AllowsImpersonation() {
+		return domain.ErrImpersonationForbidden
+	}
+
+	granted, err := a.roleRepository.HasPermission(ctx, token.UserRole, permission)
+	if err != nil {
+		return err
+	}
+	if !granted {
+		return domain.ErrInvalidTokenRole
+	}
+	return nil
+}
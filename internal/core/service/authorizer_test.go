@@ -0,0 +1,160 @@
+package service_test
+
+import (
+	"context"
+	"shop-api-go/internal/core/domain"
+	"shop-api-go/internal/core/port/mock"
+	"shop-api-go/internal/core/service"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestAuthorizer_Authorize(t *testing.T) {
+	tests := []struct {
+		name          string
+		token         *domain.Token
+		expectedError error
+		mockSetup     func(m *mock.MockRoleRepository)
+	}{
+		{
+			name: "success",
+			token: &domain.Token{
+				TokenType: domain.AccessToken,
+				UserRole:  domain.Warehouse,
+			},
+			expectedError: nil,
+			mockSetup: func(m *mock.MockRoleRepository) {
+				m.EXPECT().
+					HasPermission(
+						gomock.AssignableToTypeOf(context.Background()),
+						domain.Warehouse,
+						domain.ProductsWrite,
+					).
+					Return(true, nil)
+			},
+		}, {
+			name: "error invalid token type",
+			token: &domain.Token{
+				TokenType: domain.RefreshToken,
+				UserRole:  domain.Admin,
+			},
+			expectedError: domain.ErrInvalidTokenType,
+			mockSetup: func(m *mock.MockRoleRepository) {
+
+			},
+		}, {
+			name: "error permission not granted",
+			token: &domain.Token{
+				TokenType: domain.AccessToken,
+				UserRole:  domain.Client,
+			},
+			expectedError: domain.ErrInvalidTokenRole,
+			mockSetup: func(m *mock.MockRoleRepository) {
+				m.EXPECT().
+					HasPermission(
+						gomock.AssignableToTypeOf(context.Background()),
+						domain.Client,
+						domain.ProductsWrite,
+					).
+					Return(false, nil)
+			},
+		}, {
+			name: "error insufficient scope",
+			token: &domain.Token{
+				TokenType: domain.AccessToken,
+				UserRole:  domain.Admin,
+				Scopes:    []domain.Permission{domain.OrdersRead},
+			},
+			expectedError: domain.ErrInsufficientScope,
+			mockSetup: func(m *mock.MockRoleRepository) {
+
+			},
+		}, {
+			name: "error internal",
+			token: &domain.Token{
+				TokenType: domain.AccessToken,
+				UserRole:  domain.Admin,
+			},
+			expectedError: domain.ErrInternal,
+			mockSetup: func(m *mock.MockRoleRepository) {
+				m.EXPECT().
+					HasPermission(
+						gomock.AssignableToTypeOf(context.Background()),
+						domain.Admin,
+						domain.ProductsWrite,
+					).
+					Return(false, domain.ErrInternal)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			roleRepository := mock.NewMockRoleRepository(gomock.NewController(t))
+			tt.mockSetup(roleRepository)
+
+			err := service.NewAuthorizer(roleRepository).
+				Authorize(context.Background(), tt.token, domain.ProductsWrite)
+			require.ErrorIs(t, err, tt.expectedError)
+		})
+	}
+}
+
+func TestAuthorizer_Authorize_Impersonation(t *testing.T) {
+	actorId := uuid.New()
+	token := &domain.Token{
+		TokenType: domain.AccessToken,
+		UserRole:  domain.Admin,
+		ActorId:   &actorId,
+	}
+
+	tests := []struct {
+		name          string
+		permission    domain.Permission
+		expectedError error
+		mockSetup     func(m *mock.MockRoleRepository)
+	}{
+		{
+			name:          "success",
+			permission:    domain.UsersRead,
+			expectedError: nil,
+			mockSetup: func(m *mock.MockRoleRepository) {
+				m.EXPECT().
+					HasPermission(
+						gomock.AssignableToTypeOf(context.Background()),
+						domain.Admin,
+						domain.UsersRead,
+					).
+					Return(true, nil)
+			},
+		}, {
+			name:          "error impersonation forbidden",
+			permission:    domain.UsersWrite,
+			expectedError: domain.ErrImpersonationForbidden,
+			mockSetup: func(m *mock.MockRoleRepository) {
+
+			},
+		}, {
+			name:          "error impersonation forbidden nested impersonation",
+			permission:    domain.UsersImpersonate,
+			expectedError: domain.ErrImpersonationForbidden,
+			mockSetup: func(m *mock.MockRoleRepository) {
+
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			roleRepository := mock.NewMockRoleRepository(gomock.NewController(t))
+			tt.mockSetup(roleRepository)
+
+			err := service.NewAuthorizer(roleRepository).
+				Authorize(context.Background(), token, tt.permission)
+			require.ErrorIs(t, err, tt.expectedError)
+		})
+	}
+}
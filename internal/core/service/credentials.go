@@ -0,0 +1,122 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"shop-api-go/internal/core/domain"
+	"shop-api-go/internal/core/port"
+	"time"
+)
+
+// credentialChecker checks the username and password of a user, throttled by the failed attempts
+// of the user and the client IP. Every service authenticating a user by password shares it,
+// so the lockout applies to all of them alike.
+type credentialChecker struct {
+	userRepository         port.UserRepository
+	passwordHasher         port.PasswordHasher
+	loginAttemptRepository port.LoginAttemptRepository
+	loginThrottle          *domain.LoginThrottle
+	auditRepository        port.AuditRepository
+}
+
+// newCredentialChecker creates a new credentialChecker instance.
+func newCredentialChecker(
+	userRepository port.UserRepository,
+	passwordHasher port.PasswordHasher,
+	loginAttemptRepository port.LoginAttemptRepository,
+	loginThrottle *domain.LoginThrottle,
+	auditRepository port.AuditRepository,
+) *credentialChecker {
+	return &credentialChecker{
+		userRepository:         userRepository,
+		passwordHasher:         passwordHasher,
+		loginAttemptRepository: loginAttemptRepository,
+		loginThrottle:          loginThrottle,
+		auditRepository:        auditRepository,
+	}
+}
+
+// check fetches the user by username and compares the password. A wrong username or password is recorded
+// as a failed login of the user and the client IP, and domain.ErrTooManyLoginAttempts is returned while
+// either is blocked. The failures of the user are reset on success.
+func (c *credentialChecker) check(ctx context.Context, username, password string) (*domain.User, error) {
+	keys := map[string]domain.LoginThrottlePolicy{
+		domain.UserLoginAttemptsKey(username): c.loginThrottle.User,
+	}
+	if ip := domain.RequestMetadataFromContext(ctx).ClientIP; ip != "" {
+		keys[domain.IPLoginAttemptsKey(ip)] = c.loginThrottle.IP
+	}
+
+	if err := c.checkLoginAttempts(ctx, keys); err != nil {
+		return nil, err
+	}
+
+	user, err := c.userRepository.GetUserByUsername(ctx, username)
+	if errors.Is(err, domain.ErrUserNotFound) {
+		// Hash the password anyway so the response time does not reveal whether the user exists.
+		_, _ = c.passwordHasher.Hash(password)
+		event := domain.NewAuditEvent(ctx, nil, domain.AuditLoginFailed, domain.AuditTargetUsername, username)
+		return nil, c.recordFailedLogin(ctx, keys, domain.ErrWrongCredentials, event)
+	} else if err != nil {
+		return nil, err
+	}
+
+	err = c.passwordHasher.Compare(password, user.Password)
+	if errors.Is(err, domain.ErrWrongCredentials) {
+		event := domain.NewAuditEvent(ctx, nil, domain.AuditLoginFailed, domain.AuditTargetUser, user.Id.String())
+		return nil, c.recordFailedLogin(ctx, keys, err, event)
+	} else if err != nil {
+		return nil, err
+	}
+
+	if err = c.loginAttemptRepository.ResetLoginAttempts(ctx, domain.UserLoginAttemptsKey(username)); err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+// checkLoginAttempts returns domain.ErrTooManyLoginAttempts if any of the keys is blocked.
+func (c *credentialChecker) checkLoginAttempts(ctx context.Context, keys map[string]domain.LoginThrottlePolicy) error {
+	now := time.Now()
+	for key := range keys {
+		attempts, err := c.loginAttemptRepository.GetLoginAttempts(ctx, key)
+		if err != nil {
+			return err
+		}
+		if attempts.BlockedUntil.After(now) {
+			return domain.ErrTooManyLoginAttempts
+		}
+	}
+	return nil
+}
+
+// recordFailedLogin records a failure for every key, blocks the keys according to their policy,
+// writes the audit event and returns the cause.
+func (c *credentialChecker) recordFailedLogin(
+	ctx context.Context,
+	keys map[string]domain.LoginThrottlePolicy,
+	cause error,
+	event *domain.AuditEvent,
+) error {
+	now := time.Now()
+	for key, policy := range keys {
+		attempts, err := c.loginAttemptRepository.RecordFailedLogin(ctx, key, c.loginThrottle.Window)
+		if err != nil {
+			return err
+		}
+
+		blockedUntil := policy.BlockedUntil(attempts.Failures, now)
+		if blockedUntil.After(now) {
+			if err = c.loginAttemptRepository.BlockLogin(ctx, key, blockedUntil); err != nil {
+				return err
+			}
+			event.WithChange("blockedUntil:"+key, nil, blockedUntil)
+		}
+	}
+
+	// Login attempts may be kept in memory, so the event cannot share a transaction with them.
+	if err := c.auditRepository.AddAuditEvent(ctx, event); err != nil {
+		return err
+	}
+	return cause
+}
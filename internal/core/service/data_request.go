@@ -0,0 +1,145 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"shop-api-go/internal/core/domain"
+	"shop-api-go/internal/core/port"
+
+	"github.com/google/uuid"
+)
+
+// DataRequestService implements port.DataRequestService interface and provides access to
+// data subject request-related business logic.
+type DataRequestService struct {
+	dataRequestRepository port.DataRequestRepository
+	userDataArchiver      port.UserDataArchiver
+	authorizer            port.Authorizer
+	auditRepository       port.AuditRepository
+}
+
+// NewDataRequestService creates a new DataRequestService instance.
+func NewDataRequestService(
+	dataRequestRepository port.DataRequestRepository,
+	userDataArchiver port.UserDataArchiver,
+	authorizer port.Authorizer,
+	auditRepository port.AuditRepository,
+) *DataRequestService {
+	return &DataRequestService{
+		dataRequestRepository: dataRequestRepository,
+		userDataArchiver:      userDataArchiver,
+		authorizer:            authorizer,
+		auditRepository:       auditRepository,
+	}
+}
+
+func (s *DataRequestService) RequestDataExport(ctx context.Context, token *domain.Token, userId uuid.UUID) (*domain.DataRequest, error) {
+	return s.addDataRequest(ctx, token, userId, domain.DataExport, domain.AuditDataExportRequested)
+}
+
+func (s *DataRequestService) RequestErasure(ctx context.Context, token *domain.Token, userId uuid.UUID) (*domain.DataRequest, error) {
+	return s.addDataRequest(ctx, token, userId, domain.DataErasure, domain.AuditErasureRequested)
+}
+
+func (s *DataRequestService) GetDataRequest(ctx context.Context, token *domain.Token, id uuid.UUID) (*domain.DataRequest, error) {
+	if err := s.authorizer.Authorize(ctx, token, domain.UsersPrivacy); err != nil {
+		return nil, err
+	}
+
+	return s.dataRequestRepository.GetDataRequestById(ctx, id)
+}
+
+func (s *DataRequestService) GetDataExportArchive(
+	ctx context.Context,
+	token *domain.Token,
+	id uuid.UUID,
+) (*domain.DataRequest, []byte, error) {
+	if err := s.authorizer.Authorize(ctx, token, domain.UsersPrivacy); err != nil {
+		return nil, nil, err
+	}
+
+	request, err := s.dataRequestRepository.GetDataRequestById(ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+	if request.Kind != domain.DataExport || request.Status != domain.DataRequestCompleted {
+		return nil, nil, domain.ErrDataExportNotReady
+	}
+
+	archive, err := s.dataRequestRepository.GetDataExportArchive(ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// The archive is only read, so the event cannot share a transaction with a change.
+	event := domain.NewAuditEvent(ctx, &token.UserId, domain.AuditDataExportDownloaded, domain.AuditTargetUser, request.UserId.String()).
+		WithChange("request", nil, request.Id)
+	if err = s.auditRepository.AddAuditEvent(ctx, event); err != nil {
+		return nil, nil, err
+	}
+	return request, archive, nil
+}
+
+func (s *DataRequestService) ProcessDataRequests(ctx context.Context) error {
+	for {
+		request, err := s.dataRequestRepository.ClaimDataRequest(ctx)
+		if errors.Is(err, domain.ErrDataRequestNotFound) {
+			return nil
+		} else if err != nil {
+			return err
+		}
+
+		if err = s.processDataRequest(ctx, request); err != nil {
+			if err = s.dataRequestRepository.FailDataRequest(ctx, request.Id, err.Error()); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// addDataRequest checks the permission and stores a pending request of the kind,
+// admins cannot erase their own data.
+func (s *DataRequestService) addDataRequest(
+	ctx context.Context,
+	token *domain.Token,
+	userId uuid.UUID,
+	kind domain.DataRequestKind,
+	action domain.AuditAction,
+) (*domain.DataRequest, error) {
+	if err := s.authorizer.Authorize(ctx, token, domain.UsersPrivacy); err != nil {
+		return nil, err
+	}
+	if kind == domain.DataErasure && userId == token.UserId {
+		return nil, domain.ErrSelfLockout
+	}
+
+	request := domain.NewDataRequest(userId, token.UserId, kind)
+	event := domain.NewAuditEvent(ctx, &token.UserId, action, domain.AuditTargetUser, userId.String()).
+		WithChange("request", nil, request.Id)
+	if err := s.dataRequestRepository.AddDataRequest(ctx, request, event); err != nil {
+		return nil, err
+	}
+	return request, nil
+}
+
+// processDataRequest compiles the archive of an export or erases the data of the user of an erasure.
+func (s *DataRequestService) processDataRequest(ctx context.Context, request *domain.DataRequest) error {
+	switch request.Kind {
+	case domain.DataExport:
+		data, err := s.dataRequestRepository.GetUserData(ctx, request.UserId)
+		if err != nil {
+			return err
+		}
+		archive, err := s.userDataArchiver.Archive(data)
+		if err != nil {
+			return err
+		}
+		return s.dataRequestRepository.CompleteDataExport(ctx, request.Id, archive)
+	case domain.DataErasure:
+		event := domain.NewAuditEvent(ctx, &request.RequestedBy, domain.AuditUserErased, domain.AuditTargetUser, request.UserId.String()).
+			WithChange("request", nil, request.Id)
+		return s.dataRequestRepository.EraseUser(ctx, request, event)
+	default:
+		return domain.ErrInternal
+	}
+}
@@ -0,0 +1,360 @@
+package service_test
+
+import (
+	"context"
+	"shop-api-go/internal/core/domain"
+	"shop-api-go/internal/core/port/mock"
+	"shop-api-go/internal/core/service"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+// dataRequestMocks contains all mocked dependencies of service.DataRequestService.
+type dataRequestMocks struct {
+	dataRequestRepository *mock.MockDataRequestRepository
+	userDataArchiver      *mock.MockUserDataArchiver
+	authorizer            *mock.MockAuthorizer
+	auditRepository       *mock.MockAuditRepository
+}
+
+// newDataRequestMocks creates a new dataRequestMocks instance.
+func newDataRequestMocks(ctrl *gomock.Controller) *dataRequestMocks {
+	return &dataRequestMocks{
+		dataRequestRepository: mock.NewMockDataRequestRepository(ctrl),
+		userDataArchiver:      mock.NewMockUserDataArchiver(ctrl),
+		authorizer:            mock.NewMockAuthorizer(ctrl),
+		auditRepository:       mock.NewMockAuditRepository(ctrl),
+	}
+}
+
+// dataRequestService creates a service.DataRequestService using the mocks.
+func (m *dataRequestMocks) dataRequestService() *service.DataRequestService {
+	return service.NewDataRequestService(m.dataRequestRepository, m.userDataArchiver, m.authorizer, m.auditRepository)
+}
+
+// expectAuthorize expects the permission to be checked and returns err.
+func (m *dataRequestMocks) expectAuthorize(permission domain.Permission, err error) {
+	m.authorizer.
+		EXPECT().
+		Authorize(
+			gomock.AssignableToTypeOf(context.Background()),
+			gomock.AssignableToTypeOf(&domain.Token{}),
+			permission,
+		).
+		Return(err)
+}
+
+func TestDataRequestService_RequestErasure(t *testing.T) {
+	adminId := uuid.New()
+	userId := uuid.New()
+	token := &domain.Token{
+		UserId:    adminId,
+		TokenType: domain.AccessToken,
+		UserRole:  domain.Admin,
+	}
+
+	tests := []struct {
+		name          string
+		userId        uuid.UUID
+		expectedError error
+		mockSetup     func(m *dataRequestMocks)
+	}{
+		{
+			name:          "success",
+			userId:        userId,
+			expectedError: nil,
+			mockSetup: func(m *dataRequestMocks) {
+				m.expectAuthorize(domain.UsersPrivacy, nil)
+				m.dataRequestRepository.
+					EXPECT().
+					AddDataRequest(
+						gomock.AssignableToTypeOf(context.Background()),
+						gomock.Cond(func(request *domain.DataRequest) bool {
+							return request.UserId == userId &&
+								request.RequestedBy == adminId &&
+								request.Kind == domain.DataErasure &&
+								request.Status == domain.DataRequestPending
+						}),
+						gomock.Cond(func(event *domain.AuditEvent) bool {
+							return event.Action == domain.AuditErasureRequested && event.TargetId == userId.String()
+						}),
+					).
+					Return(nil)
+			},
+		}, {
+			name:          "error user erased",
+			userId:        userId,
+			expectedError: domain.ErrUserErased,
+			mockSetup: func(m *dataRequestMocks) {
+				m.expectAuthorize(domain.UsersPrivacy, nil)
+				m.dataRequestRepository.
+					EXPECT().
+					AddDataRequest(
+						gomock.AssignableToTypeOf(context.Background()),
+						gomock.AssignableToTypeOf(&domain.DataRequest{}),
+						gomock.AssignableToTypeOf(&domain.AuditEvent{}),
+					).
+					Return(domain.ErrUserErased)
+			},
+		}, {
+			name:          "error self lockout",
+			userId:        adminId,
+			expectedError: domain.ErrSelfLockout,
+			mockSetup: func(m *dataRequestMocks) {
+				m.expectAuthorize(domain.UsersPrivacy, nil)
+			},
+		}, {
+			name:          "error invalid token role",
+			userId:        userId,
+			expectedError: domain.ErrInvalidTokenRole,
+			mockSetup: func(m *dataRequestMocks) {
+				m.expectAuthorize(domain.UsersPrivacy, domain.ErrInvalidTokenRole)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := newDataRequestMocks(gomock.NewController(t))
+			tt.mockSetup(m)
+
+			request, err := m.dataRequestService().RequestErasure(context.Background(), token, tt.userId)
+			if tt.expectedError == nil {
+				require.NoError(t, err)
+				require.Equal(t, tt.userId, request.UserId)
+			} else {
+				require.ErrorIs(t, err, tt.expectedError)
+			}
+		})
+	}
+}
+
+func TestDataRequestService_GetDataExportArchive(t *testing.T) {
+	userId := uuid.New()
+	id := uuid.New()
+	completedAt := time.Now()
+	token := &domain.Token{
+		UserId:    uuid.New(),
+		TokenType: domain.AccessToken,
+		UserRole:  domain.Admin,
+	}
+
+	tests := []struct {
+		name          string
+		expectedError error
+		mockSetup     func(m *dataRequestMocks)
+	}{
+		{
+			name:          "success",
+			expectedError: nil,
+			mockSetup: func(m *dataRequestMocks) {
+				m.expectAuthorize(domain.UsersPrivacy, nil)
+				gomock.InOrder(
+					m.dataRequestRepository.
+						EXPECT().
+						GetDataRequestById(gomock.AssignableToTypeOf(context.Background()), id).
+						Return(&domain.DataRequest{
+							Id:          id,
+							UserId:      userId,
+							Kind:        domain.DataExport,
+							Status:      domain.DataRequestCompleted,
+							CompletedAt: &completedAt,
+						}, nil),
+					m.dataRequestRepository.
+						EXPECT().
+						GetDataExportArchive(gomock.AssignableToTypeOf(context.Background()), id).
+						Return([]byte("archive"), nil),
+					m.auditRepository.
+						EXPECT().
+						AddAuditEvent(
+							gomock.AssignableToTypeOf(context.Background()),
+							gomock.Cond(func(event *domain.AuditEvent) bool {
+								return event.Action == domain.AuditDataExportDownloaded && event.TargetId == userId.String()
+							}),
+						).
+						Return(nil),
+				)
+			},
+		}, {
+			name:          "error not ready",
+			expectedError: domain.ErrDataExportNotReady,
+			mockSetup: func(m *dataRequestMocks) {
+				m.expectAuthorize(domain.UsersPrivacy, nil)
+				m.dataRequestRepository.
+					EXPECT().
+					GetDataRequestById(gomock.AssignableToTypeOf(context.Background()), id).
+					Return(&domain.DataRequest{
+						Id:     id,
+						UserId: userId,
+						Kind:   domain.DataExport,
+						Status: domain.DataRequestRunning,
+					}, nil)
+			},
+		}, {
+			name:          "error not an export",
+			expectedError: domain.ErrDataExportNotReady,
+			mockSetup: func(m *dataRequestMocks) {
+				m.expectAuthorize(domain.UsersPrivacy, nil)
+				m.dataRequestRepository.
+					EXPECT().
+					GetDataRequestById(gomock.AssignableToTypeOf(context.Background()), id).
+					Return(&domain.DataRequest{
+						Id:     id,
+						UserId: userId,
+						Kind:   domain.DataErasure,
+						Status: domain.DataRequestCompleted,
+					}, nil)
+			},
+		}, {
+			name:          "error expired",
+			expectedError: domain.ErrDataExportExpired,
+			mockSetup: func(m *dataRequestMocks) {
+				m.expectAuthorize(domain.UsersPrivacy, nil)
+				m.dataRequestRepository.
+					EXPECT().
+					GetDataRequestById(gomock.AssignableToTypeOf(context.Background()), id).
+					Return(&domain.DataRequest{
+						Id:          id,
+						UserId:      userId,
+						Kind:        domain.DataExport,
+						Status:      domain.DataRequestCompleted,
+						CompletedAt: &completedAt,
+					}, nil)
+				m.dataRequestRepository.
+					EXPECT().
+					GetDataExportArchive(gomock.AssignableToTypeOf(context.Background()), id).
+					Return(nil, domain.ErrDataExportExpired)
+			},
+		}, {
+			name:          "error request not found",
+			expectedError: domain.ErrDataRequestNotFound,
+			mockSetup: func(m *dataRequestMocks) {
+				m.expectAuthorize(domain.UsersPrivacy, nil)
+				m.dataRequestRepository.
+					EXPECT().
+					GetDataRequestById(gomock.AssignableToTypeOf(context.Background()), id).
+					Return(nil, domain.ErrDataRequestNotFound)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := newDataRequestMocks(gomock.NewController(t))
+			tt.mockSetup(m)
+
+			request, archive, err := m.dataRequestService().GetDataExportArchive(context.Background(), token, id)
+			if tt.expectedError == nil {
+				require.NoError(t, err)
+				require.Equal(t, userId, request.UserId)
+				require.Equal(t, []byte("archive"), archive)
+			} else {
+				require.ErrorIs(t, err, tt.expectedError)
+			}
+		})
+	}
+}
+
+func TestDataRequestService_ProcessDataRequests(t *testing.T) {
+	adminId := uuid.New()
+	export := &domain.DataRequest{Id: uuid.New(), UserId: uuid.New(), Kind: domain.DataExport, RequestedBy: adminId}
+	erasure := &domain.DataRequest{Id: uuid.New(), UserId: uuid.New(), Kind: domain.DataErasure, RequestedBy: adminId}
+	data := &domain.UserData{User: domain.User{Id: export.UserId}}
+
+	tests := []struct {
+		name          string
+		expectedError error
+		mockSetup     func(m *dataRequestMocks)
+	}{
+		{
+			name:          "success export and erasure",
+			expectedError: nil,
+			mockSetup: func(m *dataRequestMocks) {
+				gomock.InOrder(
+					m.dataRequestRepository.
+						EXPECT().
+						ClaimDataRequest(gomock.AssignableToTypeOf(context.Background())).
+						Return(export, nil),
+					m.dataRequestRepository.
+						EXPECT().
+						GetUserData(gomock.AssignableToTypeOf(context.Background()), export.UserId).
+						Return(data, nil),
+					m.userDataArchiver.
+						EXPECT().
+						Archive(data).
+						Return([]byte("archive"), nil),
+					m.dataRequestRepository.
+						EXPECT().
+						CompleteDataExport(gomock.AssignableToTypeOf(context.Background()), export.Id, []byte("archive")).
+						Return(nil),
+					m.dataRequestRepository.
+						EXPECT().
+						ClaimDataRequest(gomock.AssignableToTypeOf(context.Background())).
+						Return(erasure, nil),
+					m.dataRequestRepository.
+						EXPECT().
+						EraseUser(
+							gomock.AssignableToTypeOf(context.Background()),
+							erasure,
+							gomock.Cond(func(event *domain.AuditEvent) bool {
+								return event.Action == domain.AuditUserErased &&
+									*event.ActorId == adminId &&
+									event.TargetId == erasure.UserId.String()
+							}),
+						).
+						Return(nil),
+					m.dataRequestRepository.
+						EXPECT().
+						ClaimDataRequest(gomock.AssignableToTypeOf(context.Background())).
+						Return(nil, domain.ErrDataRequestNotFound),
+				)
+			},
+		}, {
+			name:          "success failed request is marked failed",
+			expectedError: nil,
+			mockSetup: func(m *dataRequestMocks) {
+				gomock.InOrder(
+					m.dataRequestRepository.
+						EXPECT().
+						ClaimDataRequest(gomock.AssignableToTypeOf(context.Background())).
+						Return(export, nil),
+					m.dataRequestRepository.
+						EXPECT().
+						GetUserData(gomock.AssignableToTypeOf(context.Background()), export.UserId).
+						Return(nil, domain.ErrUserNotFound),
+					m.dataRequestRepository.
+						EXPECT().
+						FailDataRequest(gomock.AssignableToTypeOf(context.Background()), export.Id, domain.ErrUserNotFound.Error()).
+						Return(nil),
+					m.dataRequestRepository.
+						EXPECT().
+						ClaimDataRequest(gomock.AssignableToTypeOf(context.Background())).
+						Return(nil, domain.ErrDataRequestNotFound),
+				)
+			},
+		}, {
+			name:          "error internal",
+			expectedError: domain.ErrInternal,
+			mockSetup: func(m *dataRequestMocks) {
+				m.dataRequestRepository.
+					EXPECT().
+					ClaimDataRequest(gomock.AssignableToTypeOf(context.Background())).
+					Return(nil, domain.ErrInternal)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := newDataRequestMocks(gomock.NewController(t))
+			tt.mockSetup(m)
+
+			err := m.dataRequestService().ProcessDataRequests(context.Background())
+			require.ErrorIs(t, err, tt.expectedError)
+		})
+	}
+}
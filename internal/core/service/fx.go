@@ -26,4 +26,64 @@ var Module = fx.Module(
 			fx.As(new(port.AdminService)),
 		),
 	),
+	fx.Provide(
+		fx.Annotate(
+			NewMFAService,
+			fx.As(new(port.MFAService)),
+		),
+	),
+	fx.Provide(
+		fx.Annotate(
+			NewAuthorizer,
+			fx.As(new(port.Authorizer)),
+		),
+	),
+	fx.Provide(
+		fx.Annotate(
+			NewRoleService,
+			fx.As(new(port.RoleService)),
+		),
+	),
+	fx.Provide(
+		fx.Annotate(
+			NewAPIKeyService,
+			fx.As(new(port.APIKeyService)),
+		),
+	),
+	fx.Provide(
+		fx.Annotate(
+			NewAuditService,
+			fx.As(new(port.AuditService)),
+		),
+	),
+	fx.Provide(
+		fx.Annotate(
+			NewDataRequestService,
+			fx.As(new(port.DataRequestService)),
+		),
+	),
+	fx.Provide(
+		fx.Annotate(
+			NewProductService,
+			fx.As(new(port.ProductService)),
+		),
+	),
+	fx.Provide(
+		fx.Annotate(
+			NewOutboxService,
+			fx.As(new(port.OutboxService)),
+		),
+	),
+	fx.Provide(
+		fx.Annotate(
+			NewWebhookService,
+			fx.As(new(port.WebhookService)),
+		),
+	),
+	fx.Provide(
+		fx.Annotate(
+			NewRuntimeSettingsService,
+			fx.As(new(port.RuntimeSettingsService)),
+		),
+	),
 )
@@ -0,0 +1,150 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"shop-api-go/internal/core/domain"
+	"shop-api-go/internal/core/port"
+
+	"github.com/google/uuid"
+)
+
+// recoveryCodesCount is the number of recovery codes generated on MFA enrolment.
+const recoveryCodesCount = 10
+
+// MFAService implements port.MFAService interface and provides access to MFA-related business logic.
+type MFAService struct {
+	userRepository port.UserRepository
+	mfaRepository  port.MFARepository
+	passwordHasher port.PasswordHasher
+	mfaProvider    port.MFAProvider
+	txManager      port.TxManager
+	credentials    *credentialChecker
+}
+
+// NewMFAService creates a new MFAService instance.
+func NewMFAService(
+	userRepository port.UserRepository,
+	mfaRepository port.MFARepository,
+	passwordHasher port.PasswordHasher,
+	mfaProvider port.MFAProvider,
+	txManager port.TxManager,
+	loginAttemptRepository port.LoginAttemptRepository,
+	loginThrottle *domain.LoginThrottle,
+	auditRepository port.AuditRepository,
+) *MFAService {
+	return &MFAService{
+		userRepository: userRepository,
+		mfaRepository:  mfaRepository,
+		passwordHasher: passwordHasher,
+		mfaProvider:    mfaProvider,
+		txManager:      txManager,
+		credentials:    newCredentialChecker(userRepository, passwordHasher, loginAttemptRepository, loginThrottle, auditRepository),
+	}
+}
+
+func (s *MFAService) Enroll(ctx context.Context, credentials *domain.MFACredentials) (*domain.MFAEnrollment, error) {
+	user, err := s.authenticate(ctx, credentials)
+	if err != nil {
+		return nil, err
+	}
+
+	mfa, err := s.mfaRepository.GetUserMFA(ctx, user.Id)
+	if err != nil && !errors.Is(err, domain.ErrMFANotEnrolled) {
+		return nil, err
+	}
+	if mfa != nil && mfa.Enabled {
+		return nil, domain.ErrMFAAlreadyEnabled
+	}
+
+	secret, err := s.mfaProvider.GenerateSecret()
+	if err != nil {
+		return nil, err
+	}
+
+	if err = s.mfaRepository.SaveUserMFA(ctx, domain.NewUserMFA(user.Id, secret, false, 0)); err != nil {
+		return nil, err
+	}
+
+	return domain.NewMFAEnrollment(secret, s.mfaProvider.ProvisioningURI(user.Username, secret)), nil
+}
+
+func (s *MFAService) ConfirmEnrollment(ctx context.Context, credentials *domain.MFACredentials) ([]string, error) {
+	user, err := s.authenticate(ctx, credentials)
+	if err != nil {
+		return nil, err
+	}
+
+	mfa, err := s.mfaRepository.GetUserMFA(ctx, user.Id)
+	if err != nil {
+		return nil, err
+	}
+	if mfa.Enabled {
+		return nil, domain.ErrMFAAlreadyEnabled
+	}
+
+	step, ok := s.mfaProvider.ValidateCode(credentials.Code, mfa.Secret)
+	if !ok {
+		return nil, domain.ErrInvalidMFACode
+	}
+
+	codes, err := s.mfaProvider.GenerateRecoveryCodes(recoveryCodesCount)
+	if err != nil {
+		return nil, err
+	}
+
+	recoveryCodes := make([]domain.RecoveryCode, 0, len(codes))
+	for _, code := range codes {
+		recoveryCodes = append(recoveryCodes, *domain.NewRecoveryCode(uuid.New(), user.Id, s.mfaProvider.HashRecoveryCode(code)))
+	}
+
+	event := domain.NewAuditEvent(ctx, &user.Id, domain.AuditMFAEnabled, domain.AuditTargetUser, user.Id.String()).
+		WithChange("mfaEnabled", false, true)
+	if err = s.mfaRepository.EnableUserMFA(ctx, user.Id, step, recoveryCodes, event); err != nil {
+		return nil, err
+	}
+
+	return codes, nil
+}
+
+func (s *MFAService) Disable(ctx context.Context, credentials *domain.MFACredentials) error {
+	user, err := s.authenticate(ctx, credentials)
+	if err != nil {
+		return err
+	}
+
+	mfa, err := s.mfaRepository.GetUserMFA(ctx, user.Id)
+	if err != nil {
+		return err
+	}
+	if !mfa.Enabled {
+		return domain.ErrMFANotEnrolled
+	}
+
+	step, ok := s.mfaProvider.ValidateCode(credentials.Code, mfa.Secret)
+	if !ok {
+		return domain.ErrInvalidMFACode
+	}
+
+	return s.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		if err := s.mfaRepository.UpdateLastUsedStep(ctx, user.Id, step); err != nil {
+			return err
+		}
+
+		event := domain.NewAuditEvent(ctx, &user.Id, domain.AuditMFADisabled, domain.AuditTargetUser, user.Id.String()).
+			WithChange("mfaEnabled", true, false)
+		return s.mfaRepository.DeleteUserMFA(ctx, user.Id, event)
+	})
+}
+
+// authenticate fetches the user by username and validates his password, throttled like a login, and status.
+func (s *MFAService) authenticate(ctx context.Context, credentials *domain.MFACredentials) (*domain.User, error) {
+	user, err := s.credentials.check(ctx, credentials.Username, credentials.Password)
+	if err != nil {
+		return nil, err
+	}
+	if user.Status == domain.UserSuspended {
+		return nil, domain.ErrUserSuspended
+	}
+	return user, nil
+}
@@ -0,0 +1,392 @@
+package service_test
+
+import (
+	"context"
+	"shop-api-go/internal/core/domain"
+	"shop-api-go/internal/core/port/mock"
+	"shop-api-go/internal/core/service"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+// mfaMocks contains all mocked dependencies of service.MFAService.
+type mfaMocks struct {
+	userRepository *mock.MockUserRepository
+	mfaRepository  *mock.MockMFARepository
+	passwordHasher *mock.MockPasswordHasher
+	mfaProvider    *mock.MockMFAProvider
+	txManager      *mock.MockTxManager
+
+	loginAttemptRepository *mock.MockLoginAttemptRepository
+	auditRepository        *mock.MockAuditRepository
+}
+
+// newMFAMocks creates a new mfaMocks instance.
+func newMFAMocks(ctrl *gomock.Controller) *mfaMocks {
+	return &mfaMocks{
+		userRepository: mock.NewMockUserRepository(ctrl),
+		mfaRepository:  mock.NewMockMFARepository(ctrl),
+		passwordHasher: mock.NewMockPasswordHasher(ctrl),
+		mfaProvider:    mock.NewMockMFAProvider(ctrl),
+		txManager:      mock.NewMockTxManager(ctrl),
+
+		loginAttemptRepository: mock.NewMockLoginAttemptRepository(ctrl),
+		auditRepository:        mock.NewMockAuditRepository(ctrl),
+	}
+}
+
+// mfaService creates a service.MFAService using the mocks.
+func (m *mfaMocks) mfaService() *service.MFAService {
+	return service.NewMFAService(
+		m.userRepository,
+		m.mfaRepository,
+		m.passwordHasher,
+		m.mfaProvider,
+		m.txManager,
+		m.loginAttemptRepository,
+		loginThrottle,
+		m.auditRepository,
+	)
+}
+
+// expectAuthenticated sets up the mocks for successful credentials validation.
+func (m *mfaMocks) expectAuthenticated() []any {
+	expectLoginAllowed(m.loginAttemptRepository)
+	return []any{
+		m.userRepository.
+			EXPECT().
+			GetUserByUsername(
+				gomock.AssignableToTypeOf(context.Background()),
+				gomock.Eq("username"),
+			).
+			Return(&domain.User{
+				Username: "username",
+				Password: "hashedPassword",
+			}, nil),
+		m.passwordHasher.
+			EXPECT().
+			Compare("password", "hashedPassword").
+			Return(nil),
+		expectLoginReset(m.loginAttemptRepository),
+	}
+}
+
+func TestMFAService_Enroll(t *testing.T) {
+	tests := []struct {
+		name               string
+		credentials        *domain.MFACredentials
+		expectedEnrollment *domain.MFAEnrollment
+		expectedError      error
+		mockSetup          func(m *mfaMocks)
+	}{
+		{
+			name:        "success",
+			credentials: domain.NewMFACredentials("username", "password", ""),
+			expectedEnrollment: &domain.MFAEnrollment{
+				Secret:          "secret",
+				ProvisioningURI: "otpauth://totp/shop:username?secret=secret",
+			},
+			mockSetup: func(m *mfaMocks) {
+				gomock.InOrder(append(
+					m.expectAuthenticated(),
+					m.mfaRepository.
+						EXPECT().
+						GetUserMFA(
+							gomock.AssignableToTypeOf(context.Background()),
+							gomock.AssignableToTypeOf(uuid.UUID{}),
+						).
+						Return(nil, domain.ErrMFANotEnrolled),
+					m.mfaProvider.
+						EXPECT().
+						GenerateSecret().
+						Return("secret", nil),
+					m.mfaRepository.
+						EXPECT().
+						SaveUserMFA(
+							gomock.AssignableToTypeOf(context.Background()),
+							gomock.Eq(&domain.UserMFA{Secret: "secret"}),
+						).
+						Return(nil),
+					m.mfaProvider.
+						EXPECT().
+						ProvisioningURI("username", "secret").
+						Return("otpauth://totp/shop:username?secret=secret"),
+				)...)
+			},
+		}, {
+			name:          "error wrong credentials",
+			credentials:   domain.NewMFACredentials("username", "password", ""),
+			expectedError: domain.ErrWrongCredentials,
+			mockSetup: func(m *mfaMocks) {
+				expectLoginAllowed(m.loginAttemptRepository)
+				m.userRepository.
+					EXPECT().
+					GetUserByUsername(
+						gomock.AssignableToTypeOf(context.Background()),
+						gomock.Eq("username"),
+					).
+					Return(nil, domain.ErrUserNotFound)
+				m.passwordHasher.
+					EXPECT().
+					Hash("password").
+					Return("hashedPassword", nil)
+				expectFailedLogin(m.loginAttemptRepository, m.auditRepository, domain.AuditLoginFailed)
+			},
+		}, {
+			name:          "error too many login attempts",
+			credentials:   domain.NewMFACredentials("username", "password", ""),
+			expectedError: domain.ErrTooManyLoginAttempts,
+			mockSetup: func(m *mfaMocks) {
+				expectLoginBlocked(m.loginAttemptRepository)
+			},
+		}, {
+			name:          "error already enabled",
+			credentials:   domain.NewMFACredentials("username", "password", ""),
+			expectedError: domain.ErrMFAAlreadyEnabled,
+			mockSetup: func(m *mfaMocks) {
+				gomock.InOrder(append(
+					m.expectAuthenticated(),
+					m.mfaRepository.
+						EXPECT().
+						GetUserMFA(
+							gomock.AssignableToTypeOf(context.Background()),
+							gomock.AssignableToTypeOf(uuid.UUID{}),
+						).
+						Return(&domain.UserMFA{Enabled: true}, nil),
+				)...)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := newMFAMocks(gomock.NewController(t))
+			tt.mockSetup(m)
+
+			enrollment, err := m.mfaService().Enroll(context.Background(), tt.credentials)
+			if tt.expectedError != nil {
+				require.ErrorIs(t, err, tt.expectedError)
+			} else {
+				require.NoError(t, err)
+			}
+
+			require.Equal(t, tt.expectedEnrollment, enrollment)
+		})
+	}
+}
+
+func TestMFAService_ConfirmEnrollment(t *testing.T) {
+	tests := []struct {
+		name          string
+		credentials   *domain.MFACredentials
+		expectedCodes []string
+		expectedError error
+		mockSetup     func(m *mfaMocks)
+	}{
+		{
+			name:          "success",
+			credentials:   domain.NewMFACredentials("username", "password", "123456"),
+			expectedCodes: []string{"code1", "code2"},
+			mockSetup: func(m *mfaMocks) {
+				gomock.InOrder(append(
+					m.expectAuthenticated(),
+					m.mfaRepository.
+						EXPECT().
+						GetUserMFA(
+							gomock.AssignableToTypeOf(context.Background()),
+							gomock.AssignableToTypeOf(uuid.UUID{}),
+						).
+						Return(&domain.UserMFA{Secret: "secret"}, nil),
+					m.mfaProvider.
+						EXPECT().
+						ValidateCode("123456", "secret").
+						Return(int64(42), true),
+					m.mfaProvider.
+						EXPECT().
+						GenerateRecoveryCodes(gomock.Any()).
+						Return([]string{"code1", "code2"}, nil),
+					m.mfaProvider.
+						EXPECT().
+						HashRecoveryCode(gomock.AssignableToTypeOf("")).
+						Return("hashedCode").
+						Times(2),
+					m.mfaRepository.
+						EXPECT().
+						EnableUserMFA(
+							gomock.AssignableToTypeOf(context.Background()),
+							gomock.AssignableToTypeOf(uuid.UUID{}),
+							int64(42),
+							gomock.Len(2),
+							gomock.AssignableToTypeOf(&domain.AuditEvent{}),
+						).
+						Return(nil),
+				)...)
+			},
+		}, {
+			name:          "error not enrolled",
+			credentials:   domain.NewMFACredentials("username", "password", "123456"),
+			expectedError: domain.ErrMFANotEnrolled,
+			mockSetup: func(m *mfaMocks) {
+				gomock.InOrder(append(
+					m.expectAuthenticated(),
+					m.mfaRepository.
+						EXPECT().
+						GetUserMFA(
+							gomock.AssignableToTypeOf(context.Background()),
+							gomock.AssignableToTypeOf(uuid.UUID{}),
+						).
+						Return(nil, domain.ErrMFANotEnrolled),
+				)...)
+			},
+		}, {
+			name:          "error invalid code",
+			credentials:   domain.NewMFACredentials("username", "password", "000000"),
+			expectedError: domain.ErrInvalidMFACode,
+			mockSetup: func(m *mfaMocks) {
+				gomock.InOrder(append(
+					m.expectAuthenticated(),
+					m.mfaRepository.
+						EXPECT().
+						GetUserMFA(
+							gomock.AssignableToTypeOf(context.Background()),
+							gomock.AssignableToTypeOf(uuid.UUID{}),
+						).
+						Return(&domain.UserMFA{Secret: "secret"}, nil),
+					m.mfaProvider.
+						EXPECT().
+						ValidateCode("000000", "secret").
+						Return(int64(0), false),
+				)...)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := newMFAMocks(gomock.NewController(t))
+			tt.mockSetup(m)
+
+			codes, err := m.mfaService().ConfirmEnrollment(context.Background(), tt.credentials)
+			if tt.expectedError != nil {
+				require.ErrorIs(t, err, tt.expectedError)
+			} else {
+				require.NoError(t, err)
+			}
+
+			require.Equal(t, tt.expectedCodes, codes)
+		})
+	}
+}
+
+func TestMFAService_Disable(t *testing.T) {
+	tests := []struct {
+		name          string
+		credentials   *domain.MFACredentials
+		expectedError error
+		mockSetup     func(m *mfaMocks)
+	}{
+		{
+			name:        "success",
+			credentials: domain.NewMFACredentials("username", "password", "123456"),
+			mockSetup: func(m *mfaMocks) {
+				gomock.InOrder(append(
+					m.expectAuthenticated(),
+					m.mfaRepository.
+						EXPECT().
+						GetUserMFA(
+							gomock.AssignableToTypeOf(context.Background()),
+							gomock.AssignableToTypeOf(uuid.UUID{}),
+						).
+						Return(&domain.UserMFA{Secret: "secret", Enabled: true}, nil),
+					m.mfaProvider.
+						EXPECT().
+						ValidateCode("123456", "secret").
+						Return(int64(42), true),
+					expectWithinTx(m.txManager),
+					m.mfaRepository.
+						EXPECT().
+						UpdateLastUsedStep(
+							inTx(),
+							gomock.AssignableToTypeOf(uuid.UUID{}),
+							int64(42),
+						).
+						Return(nil),
+					m.mfaRepository.
+						EXPECT().
+						DeleteUserMFA(
+							inTx(),
+							gomock.AssignableToTypeOf(uuid.UUID{}),
+							gomock.AssignableToTypeOf(&domain.AuditEvent{}),
+						).
+						Return(nil),
+				)...)
+			},
+		}, {
+			name:          "error deleting mfa rolls back used code",
+			credentials:   domain.NewMFACredentials("username", "password", "123456"),
+			expectedError: domain.ErrInternal,
+			mockSetup: func(m *mfaMocks) {
+				gomock.InOrder(append(
+					m.expectAuthenticated(),
+					m.mfaRepository.
+						EXPECT().
+						GetUserMFA(
+							gomock.AssignableToTypeOf(context.Background()),
+							gomock.AssignableToTypeOf(uuid.UUID{}),
+						).
+						Return(&domain.UserMFA{Secret: "secret", Enabled: true}, nil),
+					m.mfaProvider.
+						EXPECT().
+						ValidateCode("123456", "secret").
+						Return(int64(42), true),
+					expectWithinTx(m.txManager),
+					m.mfaRepository.
+						EXPECT().
+						UpdateLastUsedStep(
+							inTx(),
+							gomock.AssignableToTypeOf(uuid.UUID{}),
+							int64(42),
+						).
+						Return(nil),
+					m.mfaRepository.
+						EXPECT().
+						DeleteUserMFA(
+							inTx(),
+							gomock.AssignableToTypeOf(uuid.UUID{}),
+							gomock.AssignableToTypeOf(&domain.AuditEvent{}),
+						).
+						Return(domain.ErrInternal),
+				)...)
+			},
+		}, {
+			name:          "error not enabled",
+			credentials:   domain.NewMFACredentials("username", "password", "123456"),
+			expectedError: domain.ErrMFANotEnrolled,
+			mockSetup: func(m *mfaMocks) {
+				gomock.InOrder(append(
+					m.expectAuthenticated(),
+					m.mfaRepository.
+						EXPECT().
+						GetUserMFA(
+							gomock.AssignableToTypeOf(context.Background()),
+							gomock.AssignableToTypeOf(uuid.UUID{}),
+						).
+						Return(&domain.UserMFA{Secret: "secret"}, nil),
+				)...)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := newMFAMocks(gomock.NewController(t))
+			tt.mockSetup(m)
+
+			err := m.mfaService().Disable(context.Background(), tt.credentials)
+			require.ErrorIs(t, err, tt.expectedError)
+		})
+	}
+}
@@ -0,0 +1,58 @@
+package service
+
+import (
+	"context"
+	"shop-api-go/internal/core/domain"
+	"shop-api-go/internal/core/port"
+	"time"
+)
+
+// OutboxService implements port.OutboxService interface and relays the events of the outbox.
+type OutboxService struct {
+	outboxRepository port.OutboxRepository
+	eventPublisher   port.EventPublisher
+	policy           *domain.OutboxPolicy
+}
+
+// NewOutboxService creates a new OutboxService instance.
+func NewOutboxService(
+	outboxRepository port.OutboxRepository,
+	eventPublisher port.EventPublisher,
+	policy *domain.OutboxPolicy,
+) *OutboxService {
+	return &OutboxService{
+		outboxRepository: outboxRepository,
+		eventPublisher:   eventPublisher,
+		policy:           policy,
+	}
+}
+
+func (s *OutboxService) RelayEvents(ctx context.Context) error {
+	for {
+		events, err := s.outboxRepository.ClaimEvents(ctx, s.policy.BatchSize)
+		if err != nil {
+			return err
+		}
+		if len(events) == 0 {
+			return nil
+		}
+
+		for _, event := range events {
+			if err = s.eventPublisher.Publish(ctx, &event); err != nil {
+				if err = s.outboxRepository.MarkEventFailed(
+					ctx,
+					event.Id,
+					s.policy.NextAttemptAt(event.Attempts, time.Now()),
+					err.Error(),
+				); err != nil {
+					return err
+				}
+				continue
+			}
+
+			if err = s.outboxRepository.MarkEventPublished(ctx, event.Id); err != nil {
+				return err
+			}
+		}
+	}
+}
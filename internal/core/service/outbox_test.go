@@ -0,0 +1,138 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"shop-api-go/internal/adapter/publisher"
+	"shop-api-go/internal/core/domain"
+	"shop-api-go/internal/core/port/mock"
+	"shop-api-go/internal/core/service"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestOutboxService_RelayEvents(t *testing.T) {
+	policy := &domain.OutboxPolicy{BatchSize: 10, BaseBackoff: time.Second, MaxBackoff: time.Minute}
+	first := domain.NewEvent(domain.EventUserRegistered, domain.EventAggregateUser, uuid.New(), nil)
+	second := domain.NewEvent(domain.EventUserRegistered, domain.EventAggregateUser, uuid.New(), nil)
+	first.Attempts, second.Attempts = 1, 3
+	errPublish := errors.New("broker unavailable")
+
+	tests := []struct {
+		name              string
+		publishErr        error
+		expectedError     error
+		expectedPublished []domain.Event
+		mockSetup         func(repository *mock.MockOutboxRepository)
+	}{
+		{
+			name:              "success",
+			expectedError:     nil,
+			expectedPublished: []domain.Event{*first, *second},
+			mockSetup: func(repository *mock.MockOutboxRepository) {
+				gomock.InOrder(
+					repository.
+						EXPECT().
+						ClaimEvents(gomock.AssignableToTypeOf(context.Background()), 10).
+						Return([]domain.Event{*first, *second}, nil),
+					repository.
+						EXPECT().
+						MarkEventPublished(gomock.AssignableToTypeOf(context.Background()), first.Id).
+						Return(nil),
+					repository.
+						EXPECT().
+						MarkEventPublished(gomock.AssignableToTypeOf(context.Background()), second.Id).
+						Return(nil),
+					repository.
+						EXPECT().
+						ClaimEvents(gomock.AssignableToTypeOf(context.Background()), 10).
+						Return([]domain.Event{}, nil),
+				)
+			},
+		}, {
+			name:              "success failed events are retried with backoff",
+			publishErr:        errPublish,
+			expectedError:     nil,
+			expectedPublished: []domain.Event{},
+			mockSetup: func(repository *mock.MockOutboxRepository) {
+				gomock.InOrder(
+					repository.
+						EXPECT().
+						ClaimEvents(gomock.AssignableToTypeOf(context.Background()), 10).
+						Return([]domain.Event{*first, *second}, nil),
+					repository.
+						EXPECT().
+						MarkEventFailed(
+							gomock.AssignableToTypeOf(context.Background()),
+							first.Id,
+							gomock.Cond(func(nextAttemptAt time.Time) bool {
+								return time.Until(nextAttemptAt).Round(time.Second) == time.Second
+							}),
+							errPublish.Error(),
+						).
+						Return(nil),
+					repository.
+						EXPECT().
+						MarkEventFailed(
+							gomock.AssignableToTypeOf(context.Background()),
+							second.Id,
+							gomock.Cond(func(nextAttemptAt time.Time) bool {
+								return time.Until(nextAttemptAt).Round(time.Second) == 4*time.Second
+							}),
+							errPublish.Error(),
+						).
+						Return(nil),
+					repository.
+						EXPECT().
+						ClaimEvents(gomock.AssignableToTypeOf(context.Background()), 10).
+						Return([]domain.Event{}, nil),
+				)
+			},
+		}, {
+			name:              "error claiming events",
+			expectedError:     domain.ErrInternal,
+			expectedPublished: []domain.Event{},
+			mockSetup: func(repository *mock.MockOutboxRepository) {
+				repository.
+					EXPECT().
+					ClaimEvents(gomock.AssignableToTypeOf(context.Background()), 10).
+					Return(nil, domain.ErrInternal)
+			},
+		}, {
+			name:              "error marking event published",
+			expectedError:     domain.ErrInternal,
+			expectedPublished: []domain.Event{*first},
+			mockSetup: func(repository *mock.MockOutboxRepository) {
+				gomock.InOrder(
+					repository.
+						EXPECT().
+						ClaimEvents(gomock.AssignableToTypeOf(context.Background()), 10).
+						Return([]domain.Event{*first, *second}, nil),
+					repository.
+						EXPECT().
+						MarkEventPublished(gomock.AssignableToTypeOf(context.Background()), first.Id).
+						Return(domain.ErrInternal),
+				)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			repository := mock.NewMockOutboxRepository(ctrl)
+			eventPublisher := publisher.NewMemoryPublisher()
+			eventPublisher.FailWith(tt.publishErr)
+			tt.mockSetup(repository)
+
+			outboxService := service.NewOutboxService(repository, eventPublisher, policy)
+			err := outboxService.RelayEvents(context.Background())
+			require.Equal(t, tt.expectedError, err)
+			require.Equal(t, tt.expectedPublished, eventPublisher.Events())
+		})
+	}
+}
@@ -0,0 +1,63 @@
+package service
+
+import (
+	"context"
+	"shop-api-go/internal/core/domain"
+	"shop-api-go/internal/core/port"
+
+	"github.com/google/uuid"
+)
+
+// ProductService implements port.ProductService interface and provides access to the product catalog business logic.
+type ProductService struct {
+	productRepository port.ProductRepository
+}
+
+// NewProductService creates a new ProductService instance.
+func NewProductService(productRepository port.ProductRepository) *ProductService {
+	return &ProductService{
+		productRepository: productRepository,
+	}
+}
+
+func (s *ProductService) GetProducts(ctx context.Context, filter *domain.ProductFilter) ([]domain.Product, error) {
+	if filter.Limit <= 0 {
+		return nil, domain.ErrLimitNotSet
+	}
+	if filter.Limit > domain.MaxProductsLimit {
+		return nil, domain.ErrInvalidQuery
+	}
+	if filter.Page <= 0 {
+		filter.Page = 1
+	}
+
+	return s.productRepository.GetProducts(ctx, filter)
+}
+
+func (s *ProductService) GetProduct(ctx context.Context, id uuid.UUID) (*domain.Product, error) {
+	return s.productRepository.GetProductById(ctx, id)
+}
+
+func (s *ProductService) GetCategorySections(ctx context.Context) ([]domain.CategorySection, error) {
+	return s.productRepository.GetCategorySections(ctx)
+}
+
+func (s *ProductService) GetCategorySectionsByIds(ctx context.Context, ids []uuid.UUID) (map[uuid.UUID]domain.CategorySection, error) {
+	return s.productRepository.GetCategorySectionsByIds(ctx, ids)
+}
+
+func (s *ProductService) GetCategoriesByIds(ctx context.Context, ids []uuid.UUID) (map[uuid.UUID]domain.Category, error) {
+	return s.productRepository.GetCategoriesByIds(ctx, ids)
+}
+
+func (s *ProductService) GetSectionCategories(ctx context.Context, sectionIds []uuid.UUID) (map[uuid.UUID][]domain.Category, error) {
+	return s.productRepository.GetCategoriesBySectionIds(ctx, sectionIds)
+}
+
+func (s *ProductService) GetCategorySubcategories(ctx context.Context, categoryIds []uuid.UUID) (map[uuid.UUID][]domain.Subcategory, error) {
+	return s.productRepository.GetSubcategoriesByCategoryIds(ctx, categoryIds)
+}
+
+func (s *ProductService) GetProductSubcategories(ctx context.Context, productIds []uuid.UUID) (map[uuid.UUID][]domain.Subcategory, error) {
+	return s.productRepository.GetSubcategoriesByProductIds(ctx, productIds)
+}
@@ -0,0 +1,82 @@
+package service_test
+
+import (
+	"context"
+	"shop-api-go/internal/core/domain"
+	"shop-api-go/internal/core/port/mock"
+	"shop-api-go/internal/core/service"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestProductService_GetProducts(t *testing.T) {
+	subcategoryId := uuid.New()
+	products := []domain.Product{
+		{Id: uuid.New(), Name: "Running shoes"},
+	}
+
+	tests := []struct {
+		name           string
+		filter         *domain.ProductFilter
+		expectedError  error
+		expectedResult []domain.Product
+		mockSetup      func(productRepository *mock.MockProductRepository)
+	}{
+		{
+			name:           "success defaults page",
+			filter:         domain.NewProductFilter(&subcategoryId, 0, 20),
+			expectedError:  nil,
+			expectedResult: products,
+			mockSetup: func(productRepository *mock.MockProductRepository) {
+				productRepository.
+					EXPECT().
+					GetProducts(
+						gomock.AssignableToTypeOf(context.Background()),
+						gomock.Eq(domain.NewProductFilter(&subcategoryId, 1, 20)),
+					).
+					Return(products, nil)
+			},
+		}, {
+			name:          "error limit not set",
+			filter:        domain.NewProductFilter(nil, 1, 0),
+			expectedError: domain.ErrLimitNotSet,
+			mockSetup:     func(productRepository *mock.MockProductRepository) {},
+		}, {
+			name:          "error limit too large",
+			filter:        domain.NewProductFilter(nil, 1, domain.MaxProductsLimit+1),
+			expectedError: domain.ErrInvalidQuery,
+			mockSetup:     func(productRepository *mock.MockProductRepository) {},
+		}, {
+			name:          "error fetching products",
+			filter:        domain.NewProductFilter(nil, 2, 20),
+			expectedError: domain.ErrInternal,
+			mockSetup: func(productRepository *mock.MockProductRepository) {
+				productRepository.
+					EXPECT().
+					GetProducts(
+						gomock.AssignableToTypeOf(context.Background()),
+						gomock.Eq(domain.NewProductFilter(nil, 2, 20)),
+					).
+					Return(nil, domain.ErrInternal)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			productRepository := mock.NewMockProductRepository(gomock.NewController(t))
+			tt.mockSetup(productRepository)
+
+			result, err := service.NewProductService(productRepository).GetProducts(context.Background(), tt.filter)
+			if tt.expectedError == nil {
+				require.NoError(t, err)
+				require.Equal(t, tt.expectedResult, result)
+			} else {
+				require.ErrorIs(t, err, tt.expectedError)
+			}
+		})
+	}
+}
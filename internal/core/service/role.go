@@ -0,0 +1,131 @@
+package service
+
+import (
+	"context"
+	"shop-api-go/internal/core/domain"
+	"shop-api-go/internal/core/port"
+	"slices"
+)
+
+// RoleService implements port.RoleService interface and provides access to role-related business logic.
+type RoleService struct {
+	roleRepository port.RoleRepository
+	authorizer     port.Authorizer
+}
+
+// NewRoleService creates a new RoleService instance.
+func NewRoleService(roleRepository port.RoleRepository, authorizer port.Authorizer) *RoleService {
+	return &RoleService{
+		roleRepository: roleRepository,
+		authorizer:     authorizer,
+	}
+}
+
+func (s *RoleService) GetRoles(ctx context.Context, token *domain.Token) ([]domain.Role, error) {
+	if err := s.authorizer.Authorize(ctx, token, domain.RolesRead); err != nil {
+		return nil, err
+	}
+
+	return s.roleRepository.GetRoles(ctx)
+}
+
+func (s *RoleService) GetPermissions(ctx context.Context, token *domain.Token) ([]domain.PermissionInfo, error) {
+	if err := s.authorizer.Authorize(ctx, token, domain.RolesRead); err != nil {
+		return nil, err
+	}
+
+	return s.roleRepository.GetPermissions(ctx)
+}
+
+func (s *RoleService) CreateRole(ctx context.Context, token *domain.Token, role *domain.Role) error {
+	if err := s.authorizer.Authorize(ctx, token, domain.RolesWrite); err != nil {
+		return err
+	}
+
+	if err := s.checkPermissionsGrantable(ctx, token, role.Permissions); err != nil {
+		return err
+	}
+
+	role.BuiltIn = false
+	event := domain.NewAuditEvent(ctx, &token.UserId, domain.AuditRoleCreated, domain.AuditTargetRole, string(role.Name)).
+		WithChange("description", nil, role.Description).
+		WithChange("permissions", nil, role.Permissions)
+	return s.roleRepository.AddRole(ctx, role, event)
+}
+
+func (s *RoleService) UpdateRole(ctx context.Context, token *domain.Token, update *domain.RoleUpdate) error {
+	if err := s.authorizer.Authorize(ctx, token, domain.RolesWrite); err != nil {
+		return err
+	}
+	if update.Description == nil && update.Permissions == nil {
+		return domain.ErrNoFieldsToUpdate
+	}
+
+	// Prevent admins from revoking their own ability to manage roles.
+	if update.Name == token.UserRole && update.Permissions != nil {
+		role := domain.Role{Permissions: update.Permissions}
+		if !role.HasPermission(domain.RolesWrite) {
+			return domain.ErrRoleLockout
+		}
+	}
+
+	role, err := s.roleRepository.GetRole(ctx, update.Name)
+	if err != nil {
+		return err
+	}
+	if role.BuiltIn {
+		return domain.ErrBuiltInRole
+	}
+
+	// The actor may neither edit a role with permissions they lack nor grant such permissions to it.
+	if err = s.checkPermissionsGrantable(ctx, token, slices.Concat(role.Permissions, update.Permissions)); err != nil {
+		return err
+	}
+
+	event := domain.NewAuditEvent(ctx, &token.UserId, domain.AuditRoleUpdated, domain.AuditTargetRole, string(update.Name))
+	if update.Description != nil {
+		event.WithChange("description", role.Description, *update.Description)
+	}
+	if update.Permissions != nil {
+		event.WithChange("permissions", role.Permissions, update.Permissions)
+	}
+	return s.roleRepository.UpdateRole(ctx, update, event)
+}
+
+func (s *RoleService) DeleteRole(ctx context.Context, token *domain.Token, name domain.UserRole) error {
+	if err := s.authorizer.Authorize(ctx, token, domain.RolesWrite); err != nil {
+		return err
+	}
+
+	role, err := s.roleRepository.GetRole(ctx, name)
+	if err != nil {
+		return err
+	}
+	if role.BuiltIn {
+		return domain.ErrBuiltInRole
+	}
+	if err = s.checkPermissionsGrantable(ctx, token, role.Permissions); err != nil {
+		return err
+	}
+
+	event := domain.NewAuditEvent(ctx, &token.UserId, domain.AuditRoleDeleted, domain.AuditTargetRole, string(name)).
+		WithChange("description", role.Description, nil).
+		WithChange("permissions", role.Permissions, nil)
+	return s.roleRepository.DeleteRole(ctx, name, event)
+}
+
+// checkPermissionsGrantable returns domain.ErrRoleNotAssignable if a permission is not granted to the role
+// of the token or not in its scopes, so the actor cannot manage roles with more permissions than they have.
+func (s *RoleService) checkPermissionsGrantable(ctx context.Context, token *domain.Token, permissions []domain.Permission) error {
+	actor, err := s.roleRepository.GetRole(ctx, token.UserRole)
+	if err != nil {
+		return err
+	}
+
+	for _, p := range permissions {
+		if !actor.HasPermission(p) || !token.HasScope(p) {
+			return domain.ErrRoleNotAssignable
+		}
+	}
+	return nil
+}
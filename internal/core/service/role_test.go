@@ -0,0 +1,386 @@
+package service_test
+
+import (
+	"context"
+	"shop-api-go/internal/core/domain"
+	"shop-api-go/internal/core/port/mock"
+	"shop-api-go/internal/core/service"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+// roleMocks contains all mocked dependencies of service.RoleService.
+type roleMocks struct {
+	roleRepository *mock.MockRoleRepository
+	authorizer     *mock.MockAuthorizer
+}
+
+// newRoleMocks creates a new roleMocks instance.
+func newRoleMocks(ctrl *gomock.Controller) *roleMocks {
+	return &roleMocks{
+		roleRepository: mock.NewMockRoleRepository(ctrl),
+		authorizer:     mock.NewMockAuthorizer(ctrl),
+	}
+}
+
+// roleService creates a service.RoleService using the mocks.
+func (m *roleMocks) roleService() *service.RoleService {
+	return service.NewRoleService(m.roleRepository, m.authorizer)
+}
+
+// expectAuthorize expects the permission to be checked and returns err.
+func (m *roleMocks) expectAuthorize(permission domain.Permission, err error) {
+	m.authorizer.
+		EXPECT().
+		Authorize(
+			gomock.AssignableToTypeOf(context.Background()),
+			gomock.AssignableToTypeOf(&domain.Token{}),
+			permission,
+		).
+		Return(err)
+}
+
+// expectActorRole expects the role of the token to be fetched with its permissions.
+func (m *roleMocks) expectActorRole(name domain.UserRole, permissions ...domain.Permission) *gomock.Call {
+	return m.roleRepository.
+		EXPECT().
+		GetRole(gomock.AssignableToTypeOf(context.Background()), name).
+		Return(domain.NewRole(name, "", false, permissions), nil)
+}
+
+func TestRoleService_CreateRole(t *testing.T) {
+	admin := &domain.Token{
+		TokenType: domain.AccessToken,
+		UserRole:  domain.Admin,
+	}
+	support := domain.UserRole("support")
+
+	tests := []struct {
+		name          string
+		token         *domain.Token
+		role          *domain.Role
+		expectedError error
+		mockSetup     func(m *roleMocks)
+	}{
+		{
+			name:          "success",
+			token:         admin,
+			role:          domain.NewRole(support, "Support staff", true, []domain.Permission{domain.UsersRead}),
+			expectedError: nil,
+			mockSetup: func(m *roleMocks) {
+				m.expectAuthorize(domain.RolesWrite, nil)
+				gomock.InOrder(
+					m.expectActorRole(domain.Admin, domain.UsersRead, domain.RolesWrite),
+					m.roleRepository.
+						EXPECT().
+						AddRole(
+							gomock.AssignableToTypeOf(context.Background()),
+							gomock.Eq(domain.NewRole(support, "Support staff", false, []domain.Permission{domain.UsersRead})),
+							gomock.Cond(func(event *domain.AuditEvent) bool {
+								return event.Action == domain.AuditRoleCreated
+							}),
+						).
+						Return(nil),
+				)
+			},
+		}, {
+			name:          "error permission not granted to the actor",
+			token:         &domain.Token{TokenType: domain.AccessToken, UserRole: support},
+			role:          domain.NewRole("superuser", "", false, []domain.Permission{domain.UsersRead, domain.UsersWrite}),
+			expectedError: domain.ErrRoleNotAssignable,
+			mockSetup: func(m *roleMocks) {
+				m.expectAuthorize(domain.RolesWrite, nil)
+				m.expectActorRole(support, domain.UsersRead, domain.RolesWrite)
+			},
+		}, {
+			name: "error permission not in the api key scopes",
+			token: &domain.Token{
+				TokenType: domain.AccessToken,
+				UserRole:  domain.Admin,
+				Scopes:    []domain.Permission{domain.RolesWrite},
+			},
+			role:          domain.NewRole("superuser", "", false, []domain.Permission{domain.UsersWrite}),
+			expectedError: domain.ErrRoleNotAssignable,
+			mockSetup: func(m *roleMocks) {
+				m.expectAuthorize(domain.RolesWrite, nil)
+				m.expectActorRole(domain.Admin, domain.UsersWrite, domain.RolesWrite)
+			},
+		}, {
+			name:          "error invalid token role",
+			token:         admin,
+			role:          domain.NewRole(support, "", false, nil),
+			expectedError: domain.ErrInvalidTokenRole,
+			mockSetup: func(m *roleMocks) {
+				m.expectAuthorize(domain.RolesWrite, domain.ErrInvalidTokenRole)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := newRoleMocks(gomock.NewController(t))
+			tt.mockSetup(m)
+
+			err := m.roleService().CreateRole(context.Background(), tt.token, tt.role)
+			require.ErrorIs(t, err, tt.expectedError)
+		})
+	}
+}
+
+func TestRoleService_UpdateRole(t *testing.T) {
+	admin := &domain.Token{
+		TokenType: domain.AccessToken,
+		UserRole:  domain.Admin,
+	}
+	support := domain.UserRole("support")
+	supportToken := &domain.Token{
+		TokenType: domain.AccessToken,
+		UserRole:  support,
+	}
+	description := "Warehouse staff"
+
+	tests := []struct {
+		name          string
+		token         *domain.Token
+		update        *domain.RoleUpdate
+		expectedError error
+		mockSetup     func(m *roleMocks)
+	}{
+		{
+			name:          "success",
+			token:         admin,
+			update:        domain.NewRoleUpdate(support, nil, []domain.Permission{domain.ProductsWrite}),
+			expectedError: nil,
+			mockSetup: func(m *roleMocks) {
+				m.expectAuthorize(domain.RolesWrite, nil)
+				m.roleRepository.
+					EXPECT().
+					GetRole(gomock.AssignableToTypeOf(context.Background()), support).
+					Return(domain.NewRole(support, "", false, []domain.Permission{domain.OrdersRead}), nil)
+				m.expectActorRole(domain.Admin, domain.OrdersRead, domain.ProductsWrite, domain.RolesWrite)
+				m.roleRepository.
+					EXPECT().
+					UpdateRole(
+						gomock.AssignableToTypeOf(context.Background()),
+						gomock.Eq(domain.NewRoleUpdate(support, nil, []domain.Permission{domain.ProductsWrite})),
+						gomock.Cond(func(event *domain.AuditEvent) bool {
+							change, ok := event.Changes["permissions"]
+							return event.Action == domain.AuditRoleUpdated &&
+								ok &&
+								slices.Equal(change.Before.([]domain.Permission), []domain.Permission{domain.OrdersRead})
+						}),
+					).
+					Return(nil)
+			},
+		}, {
+			name:          "success own role description",
+			token:         supportToken,
+			update:        domain.NewRoleUpdate(support, &description, nil),
+			expectedError: nil,
+			mockSetup: func(m *roleMocks) {
+				m.expectAuthorize(domain.RolesWrite, nil)
+				m.expectActorRole(support, domain.RolesWrite).
+					Times(2)
+				m.roleRepository.
+					EXPECT().
+					UpdateRole(
+						gomock.AssignableToTypeOf(context.Background()),
+						gomock.Eq(domain.NewRoleUpdate(support, &description, nil)),
+						gomock.AssignableToTypeOf(&domain.AuditEvent{}),
+					).
+					Return(nil)
+			},
+		}, {
+			name:          "error built-in role",
+			token:         admin,
+			update:        domain.NewRoleUpdate(domain.Warehouse, &description, nil),
+			expectedError: domain.ErrBuiltInRole,
+			mockSetup: func(m *roleMocks) {
+				m.expectAuthorize(domain.RolesWrite, nil)
+				m.roleRepository.
+					EXPECT().
+					GetRole(gomock.AssignableToTypeOf(context.Background()), domain.Warehouse).
+					Return(domain.NewRole(domain.Warehouse, "", true, []domain.Permission{domain.OrdersRead}), nil)
+			},
+		}, {
+			name:          "error role with permissions the actor lacks",
+			token:         supportToken,
+			update:        domain.NewRoleUpdate("manager", &description, nil),
+			expectedError: domain.ErrRoleNotAssignable,
+			mockSetup: func(m *roleMocks) {
+				m.expectAuthorize(domain.RolesWrite, nil)
+				gomock.InOrder(
+					m.roleRepository.
+						EXPECT().
+						GetRole(gomock.AssignableToTypeOf(context.Background()), domain.UserRole("manager")).
+						Return(domain.NewRole("manager", "", false, []domain.Permission{domain.UsersWrite}), nil),
+					m.expectActorRole(support, domain.UsersRead, domain.RolesWrite),
+				)
+			},
+		}, {
+			name:          "error granting permissions the actor lacks",
+			token:         supportToken,
+			update:        domain.NewRoleUpdate(support, nil, []domain.Permission{domain.RolesWrite, domain.UsersWrite}),
+			expectedError: domain.ErrRoleNotAssignable,
+			mockSetup: func(m *roleMocks) {
+				m.expectAuthorize(domain.RolesWrite, nil)
+				m.expectActorRole(support, domain.RolesWrite).
+					Times(2)
+			},
+		}, {
+			name: "error granting permissions outside the api key scopes",
+			token: &domain.Token{
+				TokenType: domain.AccessToken,
+				UserRole:  domain.Admin,
+				Scopes:    []domain.Permission{domain.RolesWrite},
+			},
+			update:        domain.NewRoleUpdate(support, nil, []domain.Permission{domain.UsersWrite}),
+			expectedError: domain.ErrRoleNotAssignable,
+			mockSetup: func(m *roleMocks) {
+				m.expectAuthorize(domain.RolesWrite, nil)
+				m.roleRepository.
+					EXPECT().
+					GetRole(gomock.AssignableToTypeOf(context.Background()), support).
+					Return(domain.NewRole(support, "", false, nil), nil)
+				m.expectActorRole(domain.Admin, domain.UsersWrite, domain.RolesWrite)
+			},
+		}, {
+			name:          "error invalid token role",
+			token:         admin,
+			update:        domain.NewRoleUpdate(support, &description, nil),
+			expectedError: domain.ErrInvalidTokenRole,
+			mockSetup: func(m *roleMocks) {
+				m.expectAuthorize(domain.RolesWrite, domain.ErrInvalidTokenRole)
+			},
+		}, {
+			name:          "error no fields to update",
+			token:         admin,
+			update:        domain.NewRoleUpdate(support, nil, nil),
+			expectedError: domain.ErrNoFieldsToUpdate,
+			mockSetup: func(m *roleMocks) {
+				m.expectAuthorize(domain.RolesWrite, nil)
+			},
+		}, {
+			name:          "error role not found",
+			token:         admin,
+			update:        domain.NewRoleUpdate(support, &description, nil),
+			expectedError: domain.ErrRoleNotFound,
+			mockSetup: func(m *roleMocks) {
+				m.expectAuthorize(domain.RolesWrite, nil)
+				m.roleRepository.
+					EXPECT().
+					GetRole(gomock.AssignableToTypeOf(context.Background()), support).
+					Return(nil, domain.ErrRoleNotFound)
+			},
+		}, {
+			name:          "error role lockout",
+			token:         supportToken,
+			update:        domain.NewRoleUpdate(support, nil, []domain.Permission{domain.UsersRead}),
+			expectedError: domain.ErrRoleLockout,
+			mockSetup: func(m *roleMocks) {
+				m.expectAuthorize(domain.RolesWrite, nil)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := newRoleMocks(gomock.NewController(t))
+			tt.mockSetup(m)
+
+			err := m.roleService().UpdateRole(context.Background(), tt.token, tt.update)
+			require.ErrorIs(t, err, tt.expectedError)
+		})
+	}
+}
+
+func TestRoleService_DeleteRole(t *testing.T) {
+	token := &domain.Token{
+		TokenType: domain.AccessToken,
+		UserRole:  domain.Admin,
+	}
+	custom := domain.UserRole("support")
+
+	tests := []struct {
+		name          string
+		role          domain.UserRole
+		expectedError error
+		mockSetup     func(m *roleMocks)
+	}{
+		{
+			name:          "success",
+			role:          custom,
+			expectedError: nil,
+			mockSetup: func(m *roleMocks) {
+				m.expectAuthorize(domain.RolesWrite, nil)
+				gomock.InOrder(
+					m.roleRepository.
+						EXPECT().
+						GetRole(gomock.AssignableToTypeOf(context.Background()), custom).
+						Return(domain.NewRole(custom, "", false, []domain.Permission{domain.UsersRead}), nil),
+					m.expectActorRole(domain.Admin, domain.UsersRead, domain.RolesWrite),
+					m.roleRepository.
+						EXPECT().
+						DeleteRole(gomock.AssignableToTypeOf(context.Background()), custom, gomock.AssignableToTypeOf(&domain.AuditEvent{})).
+						Return(nil),
+				)
+			},
+		}, {
+			name:          "error built-in role",
+			role:          domain.Warehouse,
+			expectedError: domain.ErrBuiltInRole,
+			mockSetup: func(m *roleMocks) {
+				m.expectAuthorize(domain.RolesWrite, nil)
+				m.roleRepository.
+					EXPECT().
+					GetRole(gomock.AssignableToTypeOf(context.Background()), domain.Warehouse).
+					Return(domain.NewRole(domain.Warehouse, "", true, nil), nil)
+			},
+		}, {
+			name:          "error role with permissions the actor lacks",
+			role:          custom,
+			expectedError: domain.ErrRoleNotAssignable,
+			mockSetup: func(m *roleMocks) {
+				m.expectAuthorize(domain.RolesWrite, nil)
+				gomock.InOrder(
+					m.roleRepository.
+						EXPECT().
+						GetRole(gomock.AssignableToTypeOf(context.Background()), custom).
+						Return(domain.NewRole(custom, "", false, []domain.Permission{domain.AuditRead}), nil),
+					m.expectActorRole(domain.Admin, domain.RolesWrite),
+				)
+			},
+		}, {
+			name:          "error role not found",
+			role:          custom,
+			expectedError: domain.ErrRoleNotFound,
+			mockSetup: func(m *roleMocks) {
+				m.expectAuthorize(domain.RolesWrite, nil)
+				m.roleRepository.
+					EXPECT().
+					GetRole(gomock.AssignableToTypeOf(context.Background()), custom).
+					Return(nil, domain.ErrRoleNotFound)
+			},
+		}, {
+			name:          "error invalid token type",
+			role:          custom,
+			expectedError: domain.ErrInvalidTokenType,
+			mockSetup: func(m *roleMocks) {
+				m.expectAuthorize(domain.RolesWrite, domain.ErrInvalidTokenType)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := newRoleMocks(gomock.NewController(t))
+			tt.mockSetup(m)
+
+			err := m.roleService().DeleteRole(context.Background(), token, tt.role)
+			require.ErrorIs(t, err, tt.expectedError)
+		})
+	}
+}
@@ -0,0 +1,202 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"reflect"
+	"shop-api-go/internal/core/domain"
+	"shop-api-go/internal/core/port"
+	"slices"
+	"sync"
+	"sync/atomic"
+)
+
+// runtimeSettingsTargetId is the audit target id of the runtime settings, there is only one set of them.
+const runtimeSettingsTargetId = "runtime"
+
+// RuntimeSettingsService implements port.RuntimeSettingsService interface and provides access to runtime settings business logic.
+//
+// Note: The current settings are read without locking, changes are serialized and
+// replace the settings as a whole before the watchers are notified.
+type RuntimeSettingsService struct {
+	baseline             *domain.RuntimeSettings
+	runtimeSettingsStore port.RuntimeSettingsStore
+	auditRepository      port.AuditRepository
+	authorizer           port.Authorizer
+	roleRepository       port.RoleRepository
+	txManager            port.TxManager
+
+	current  atomic.Pointer[domain.RuntimeSettings]
+	mu       sync.Mutex
+	watchers []port.RuntimeSettingsWatcher
+	// loaded is the last update loaded from the store, reloads are skipped while it does not change.
+	loaded *domain.RuntimeSettingsUpdate
+}
+
+// NewRuntimeSettingsService creates a new RuntimeSettingsService instance, the baseline
+// settings are the configured ones the stored settings are applied on top of.
+func NewRuntimeSettingsService(
+	baseline *domain.RuntimeSettings,
+	runtimeSettingsStore port.RuntimeSettingsStore,
+	auditRepository port.AuditRepository,
+	authorizer port.Authorizer,
+	roleRepository port.RoleRepository,
+	txManager port.TxManager,
+) *RuntimeSettingsService {
+	s := &RuntimeSettingsService{
+		baseline:             baseline,
+		runtimeSettingsStore: runtimeSettingsStore,
+		auditRepository:      auditRepository,
+		authorizer:           authorizer,
+		roleRepository:       roleRepository,
+		txManager:            txManager,
+	}
+	s.current.Store(baseline)
+	return s
+}
+
+func (s *RuntimeSettingsService) Current() *domain.RuntimeSettings {
+	return s.current.Load()
+}
+
+func (s *RuntimeSettingsService) Watch(watcher port.RuntimeSettingsWatcher) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.watchers = append(s.watchers, watcher)
+	watcher.ApplySettings(s.current.Load())
+}
+
+func (s *RuntimeSettingsService) GetSettings(ctx context.Context, token *domain.Token) (*domain.RuntimeSettings, error) {
+	if err := s.authorizer.Authorize(ctx, token, domain.SettingsRead); err != nil {
+		return nil, err
+	}
+	return s.current.Load(), nil
+}
+
+func (s *RuntimeSettingsService) UpdateSettings(
+	ctx context.Context,
+	token *domain.Token,
+	update *domain.RuntimeSettingsUpdate,
+) (*domain.RuntimeSettings, error) {
+	if err := s.authorizer.Authorize(ctx, token, domain.SettingsWrite); err != nil {
+		return nil, err
+	}
+	if update.IsEmpty() {
+		return nil, domain.ErrNoFieldsToUpdate
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	current := s.current.Load()
+	next, err := current.Apply(update)
+	if err != nil {
+		return nil, err
+	}
+	if err = s.checkRateLimitRoles(ctx, update); err != nil {
+		return nil, err
+	}
+	changes := current.Changes(next)
+	if len(changes) == 0 {
+		return current, nil
+	}
+
+	// The settings are saved last, so a failing store rolls back the audit event
+	// and a postgres store is written in the same transaction.
+	event := domain.NewAuditEvent(ctx, &token.UserId, domain.AuditSettingsUpdated, domain.AuditTargetSettings, runtimeSettingsTargetId)
+	err = s.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		if err := s.record(ctx, event, changes); err != nil {
+			return err
+		}
+		return s.runtimeSettingsStore.SaveSettings(ctx, next)
+	})
+	if err != nil {
+		return nil, err
+	}
+	s.apply(next)
+	return next, nil
+}
+
+func (s *RuntimeSettingsService) ReloadSettings(ctx context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	update, err := s.runtimeSettingsStore.LoadSettings(ctx)
+	if err != nil {
+		return err
+	}
+	if reflect.DeepEqual(update, s.loaded) {
+		return nil
+	}
+
+	next, err := s.baseline.Apply(update)
+	if err != nil {
+		// The invalid update is remembered, so it is reported once instead of on every reload.
+		s.loaded = update
+		return err
+	}
+	current := s.current.Load()
+	changes := current.Changes(next)
+	if len(changes) > 0 {
+		event := domain.NewAuditEvent(ctx, nil, domain.AuditSettingsReloaded, domain.AuditTargetSettings, runtimeSettingsTargetId)
+		if err = s.record(ctx, event, changes); err != nil {
+			return err
+		}
+		s.apply(next)
+	}
+	s.loaded = update
+	return nil
+}
+
+// checkRateLimitRoles reports the roles of the rate limit policies of the update that do not exist.
+//
+// Note: Stored settings are not checked on reload, so deleting a role never fails the startup.
+func (s *RuntimeSettingsService) checkRateLimitRoles(ctx context.Context, update *domain.RuntimeSettingsUpdate) error {
+	hasRoles := false
+	for _, policy := range update.RateLimits {
+		hasRoles = hasRoles || len(policy.Roles) > 0
+	}
+	if !hasRoles {
+		return nil
+	}
+
+	roles, err := s.roleRepository.GetRoles(ctx)
+	if err != nil {
+		return err
+	}
+	known := make(map[domain.UserRole]bool, len(roles))
+	for _, role := range roles {
+		known[role.Name] = true
+	}
+
+	var violations []string
+	for _, group := range slices.Sorted(maps.Keys(update.RateLimits)) {
+		for _, role := range slices.Sorted(maps.Keys(update.RateLimits[group].Roles)) {
+			if !known[role] {
+				violations = append(violations, fmt.Sprintf("unknown role in rate limit of %s: %s", group, role))
+			}
+		}
+	}
+	if len(violations) > 0 {
+		return domain.NewRuntimeSettingsError(violations)
+	}
+	return nil
+}
+
+// record appends the audit event with the changes of the settings to the audit log.
+func (s *RuntimeSettingsService) record(ctx context.Context, event *domain.AuditEvent, changes map[string]domain.AuditChange) error {
+	for field, change := range changes {
+		event.WithChange(field, change.Before, change.After)
+	}
+	return s.auditRepository.AddAuditEvent(ctx, event)
+}
+
+// apply replaces the current settings and notifies the watchers, the caller must hold the lock.
+func (s *RuntimeSettingsService) apply(settings *domain.RuntimeSettings) {
+	s.current.Store(settings)
+	for _, watcher := range s.watchers {
+		watcher.ApplySettings(settings)
+	}
+}
@@ -0,0 +1,341 @@
+package service_test
+
+import (
+	"context"
+	"shop-api-go/internal/core/domain"
+	"shop-api-go/internal/core/port/mock"
+	"shop-api-go/internal/core/service"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+// runtimeSettingsMocks contains all mocked dependencies of service.RuntimeSettingsService.
+type runtimeSettingsMocks struct {
+	runtimeSettingsStore *mock.MockRuntimeSettingsStore
+	auditRepository      *mock.MockAuditRepository
+	authorizer           *mock.MockAuthorizer
+	roleRepository       *mock.MockRoleRepository
+	txManager            *mock.MockTxManager
+	watcher              *mock.MockRuntimeSettingsWatcher
+}
+
+// newRuntimeSettingsMocks creates a new runtimeSettingsMocks instance.
+func newRuntimeSettingsMocks(ctrl *gomock.Controller) *runtimeSettingsMocks {
+	return &runtimeSettingsMocks{
+		runtimeSettingsStore: mock.NewMockRuntimeSettingsStore(ctrl),
+		auditRepository:      mock.NewMockAuditRepository(ctrl),
+		authorizer:           mock.NewMockAuthorizer(ctrl),
+		roleRepository:       mock.NewMockRoleRepository(ctrl),
+		txManager:            mock.NewMockTxManager(ctrl),
+		watcher:              mock.NewMockRuntimeSettingsWatcher(ctrl),
+	}
+}
+
+// runtimeSettingsService creates a service.RuntimeSettingsService using the mocks with the watcher registered.
+func (m *runtimeSettingsMocks) runtimeSettingsService() *service.RuntimeSettingsService {
+	s := service.NewRuntimeSettingsService(
+		baselineSettings(),
+		m.runtimeSettingsStore,
+		m.auditRepository,
+		m.authorizer,
+		m.roleRepository,
+		m.txManager,
+	)
+	m.watcher.EXPECT().ApplySettings(gomock.Eq(baselineSettings()))
+	s.Watch(m.watcher)
+	return s
+}
+
+// expectAuthorize expects the permission to be checked and returns err.
+func (m *runtimeSettingsMocks) expectAuthorize(permission domain.Permission, err error) {
+	m.authorizer.
+		EXPECT().
+		Authorize(
+			gomock.AssignableToTypeOf(context.Background()),
+			gomock.AssignableToTypeOf(&domain.Token{}),
+			permission,
+		).
+		Return(err)
+}
+
+// baselineSettings returns the configured settings used by the tests.
+func baselineSettings() *domain.RuntimeSettings {
+	return &domain.RuntimeSettings{
+		LogLevel:         domain.LogInfo,
+		RateLimitEnabled: true,
+		RateLimits: map[string]domain.RateLimitPolicy{
+			"users": {Default: domain.NewRateLimit(20, time.Minute)},
+		},
+		AccessTokenExpireTime:        30 * time.Minute,
+		RefreshTokenExpireTime:       24 * time.Hour,
+		MFATokenExpireTime:           5 * time.Minute,
+		ImpersonationTokenExpireTime: 15 * time.Minute,
+	}
+}
+
+func TestRuntimeSettingsService_UpdateSettings(t *testing.T) {
+	token := &domain.Token{UserId: uuid.New(), TokenType: domain.AccessToken, UserRole: domain.Admin}
+	debug := domain.LogDebug
+	unknown := domain.LogLevel("verbose")
+	negative := -time.Minute
+	twoHours := 2 * time.Hour
+	enabled := true
+
+	tests := []struct {
+		name          string
+		update        *domain.RuntimeSettingsUpdate
+		expectedError error
+		mockSetup     func(m *runtimeSettingsMocks)
+	}{
+		{
+			name: "success",
+			update: &domain.RuntimeSettingsUpdate{
+				LogLevel:   &debug,
+				RateLimits: map[string]domain.RateLimitPolicy{"users": {Default: domain.NewRateLimit(5, time.Second)}},
+				Features:   map[domain.Feature]bool{domain.FeatureRegistration: false},
+			},
+			expectedError: nil,
+			mockSetup: func(m *runtimeSettingsMocks) {
+				m.expectAuthorize(domain.SettingsWrite, nil)
+				expectWithinTx(m.txManager)
+				m.auditRepository.
+					EXPECT().
+					AddAuditEvent(
+						inTx(),
+						gomock.Cond(func(event *domain.AuditEvent) bool {
+							return event.Action == domain.AuditSettingsUpdated &&
+								*event.ActorId == token.UserId &&
+								len(event.Changes) == 3 &&
+								event.Changes["logLevel"] == domain.AuditChange{Before: "info", After: "debug"} &&
+								event.Changes["rateLimits.users"] == domain.AuditChange{Before: "20/1m0s", After: "5/1s"} &&
+								event.Changes["features.registration"] == domain.AuditChange{Before: true, After: false}
+						}),
+					).
+					Return(nil)
+				m.runtimeSettingsStore.
+					EXPECT().
+					SaveSettings(
+						inTx(),
+						gomock.Cond(func(settings *domain.RuntimeSettings) bool {
+							return settings.LogLevel == domain.LogDebug
+						}),
+					).
+					Return(nil)
+				m.watcher.
+					EXPECT().
+					ApplySettings(gomock.Cond(func(settings *domain.RuntimeSettings) bool {
+						return settings.LogLevel == domain.LogDebug &&
+							!settings.FeatureEnabled(domain.FeatureRegistration)
+					}))
+			},
+		}, {
+			name: "success role limits",
+			update: &domain.RuntimeSettingsUpdate{
+				RateLimits: map[string]domain.RateLimitPolicy{"users": {
+					Default: domain.NewRateLimit(20, time.Minute),
+					Roles:   map[domain.UserRole]domain.RateLimit{domain.Admin: domain.NewRateLimit(100, time.Minute)},
+				}},
+			},
+			expectedError: nil,
+			mockSetup: func(m *runtimeSettingsMocks) {
+				m.expectAuthorize(domain.SettingsWrite, nil)
+				m.roleRepository.
+					EXPECT().
+					GetRoles(gomock.AssignableToTypeOf(context.Background())).
+					Return([]domain.Role{{Name: domain.Admin}, {Name: domain.Client}}, nil)
+				expectWithinTx(m.txManager)
+				m.auditRepository.EXPECT().AddAuditEvent(inTx(), gomock.AssignableToTypeOf(&domain.AuditEvent{})).Return(nil)
+				m.runtimeSettingsStore.EXPECT().SaveSettings(inTx(), gomock.AssignableToTypeOf(&domain.RuntimeSettings{})).Return(nil)
+				m.watcher.EXPECT().ApplySettings(gomock.AssignableToTypeOf(&domain.RuntimeSettings{}))
+			},
+		}, {
+			name:          "unchanged settings are not audited",
+			update:        &domain.RuntimeSettingsUpdate{RateLimitEnabled: &enabled},
+			expectedError: nil,
+			mockSetup: func(m *runtimeSettingsMocks) {
+				m.expectAuthorize(domain.SettingsWrite, nil)
+			},
+		}, {
+			name:          "error no fields to update",
+			update:        &domain.RuntimeSettingsUpdate{},
+			expectedError: domain.ErrNoFieldsToUpdate,
+			mockSetup: func(m *runtimeSettingsMocks) {
+				m.expectAuthorize(domain.SettingsWrite, nil)
+			},
+		}, {
+			name: "error invalid settings",
+			update: &domain.RuntimeSettingsUpdate{
+				LogLevel:              &unknown,
+				AccessTokenExpireTime: &negative,
+				RateLimits:            map[string]domain.RateLimitPolicy{"orders": {Default: domain.NewRateLimit(5, time.Second)}},
+				Features:              map[domain.Feature]bool{"checkout": false},
+			},
+			expectedError: domain.NewRuntimeSettingsError([]string{
+				"unknown log level: verbose",
+				"unknown rate limit group: orders",
+				"access token expire time must be > 0 and <= 24h0m0s: -1m0s",
+				"unknown feature: checkout",
+			}),
+			mockSetup: func(m *runtimeSettingsMocks) {
+				m.expectAuthorize(domain.SettingsWrite, nil)
+			},
+		}, {
+			name:          "error expire time above the upper bound",
+			update:        &domain.RuntimeSettingsUpdate{ImpersonationTokenExpireTime: &twoHours},
+			expectedError: domain.NewRuntimeSettingsError([]string{"impersonation token expire time must be > 0 and <= 1h0m0s: 2h0m0s"}),
+			mockSetup: func(m *runtimeSettingsMocks) {
+				m.expectAuthorize(domain.SettingsWrite, nil)
+			},
+		}, {
+			name: "error unknown rate limit role",
+			update: &domain.RuntimeSettingsUpdate{
+				RateLimits: map[string]domain.RateLimitPolicy{"users": {
+					Default: domain.NewRateLimit(5, time.Second),
+					Roles: map[domain.UserRole]domain.RateLimit{
+						domain.Admin: domain.NewRateLimit(50, time.Second),
+						"admni":      domain.NewRateLimit(50, time.Second),
+					},
+				}},
+			},
+			expectedError: domain.NewRuntimeSettingsError([]string{"unknown role in rate limit of users: admni"}),
+			mockSetup: func(m *runtimeSettingsMocks) {
+				m.expectAuthorize(domain.SettingsWrite, nil)
+				m.roleRepository.
+					EXPECT().
+					GetRoles(gomock.AssignableToTypeOf(context.Background())).
+					Return([]domain.Role{{Name: domain.Admin}, {Name: domain.Client}}, nil)
+			},
+		}, {
+			name:          "error audit failed",
+			update:        &domain.RuntimeSettingsUpdate{LogLevel: &debug},
+			expectedError: domain.ErrInternal,
+			mockSetup: func(m *runtimeSettingsMocks) {
+				m.expectAuthorize(domain.SettingsWrite, nil)
+				expectWithinTx(m.txManager)
+				m.auditRepository.
+					EXPECT().
+					AddAuditEvent(inTx(), gomock.AssignableToTypeOf(&domain.AuditEvent{})).
+					Return(domain.ErrInternal)
+			},
+		}, {
+			name:          "error save failed",
+			update:        &domain.RuntimeSettingsUpdate{LogLevel: &debug},
+			expectedError: domain.ErrInternal,
+			mockSetup: func(m *runtimeSettingsMocks) {
+				m.expectAuthorize(domain.SettingsWrite, nil)
+				expectWithinTx(m.txManager)
+				gomock.InOrder(
+					m.auditRepository.
+						EXPECT().
+						AddAuditEvent(inTx(), gomock.AssignableToTypeOf(&domain.AuditEvent{})).
+						Return(nil),
+					m.runtimeSettingsStore.
+						EXPECT().
+						SaveSettings(inTx(), gomock.AssignableToTypeOf(&domain.RuntimeSettings{})).
+						Return(domain.ErrInternal),
+				)
+			},
+		}, {
+			name:          "error forbidden",
+			update:        &domain.RuntimeSettingsUpdate{LogLevel: &debug},
+			expectedError: domain.ErrInvalidTokenRole,
+			mockSetup: func(m *runtimeSettingsMocks) {
+				m.expectAuthorize(domain.SettingsWrite, domain.ErrInvalidTokenRole)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			m := newRuntimeSettingsMocks(ctrl)
+			s := m.runtimeSettingsService()
+			tt.mockSetup(m)
+
+			settings, err := s.UpdateSettings(context.Background(), token, tt.update)
+			require.Equal(t, tt.expectedError, err)
+			if tt.expectedError == nil {
+				require.Equal(t, settings, s.Current())
+			} else {
+				require.Equal(t, baselineSettings(), s.Current())
+			}
+		})
+	}
+}
+
+func TestRuntimeSettingsService_ReloadSettings(t *testing.T) {
+	debug := domain.LogDebug
+	expireTime := time.Hour
+
+	t.Run("applies changed settings once", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		m := newRuntimeSettingsMocks(ctrl)
+		s := m.runtimeSettingsService()
+		update := &domain.RuntimeSettingsUpdate{LogLevel: &debug, RefreshTokenExpireTime: &expireTime}
+
+		m.runtimeSettingsStore.
+			EXPECT().
+			LoadSettings(gomock.Any()).
+			DoAndReturn(func(context.Context) (*domain.RuntimeSettingsUpdate, error) {
+				copied := *update
+				return &copied, nil
+			}).
+			Times(2)
+		m.auditRepository.
+			EXPECT().
+			AddAuditEvent(
+				gomock.Any(),
+				gomock.Cond(func(event *domain.AuditEvent) bool {
+					return event.Action == domain.AuditSettingsReloaded &&
+						event.ActorId == nil &&
+						event.Changes["refreshTokenExpireTime"] == domain.AuditChange{Before: "24h0m0s", After: "1h0m0s"}
+				}),
+			).
+			Return(nil)
+		m.watcher.EXPECT().ApplySettings(gomock.Any())
+
+		require.NoError(t, s.ReloadSettings(context.Background()))
+		require.NoError(t, s.ReloadSettings(context.Background()))
+		require.Equal(t, domain.LogDebug, s.Current().LogLevel)
+		require.Equal(t, time.Hour, s.Current().RefreshTokenExpireTime)
+	})
+
+	t.Run("removed settings fall back to the baseline", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		m := newRuntimeSettingsMocks(ctrl)
+		s := m.runtimeSettingsService()
+
+		gomock.InOrder(
+			m.runtimeSettingsStore.EXPECT().LoadSettings(gomock.Any()).Return(&domain.RuntimeSettingsUpdate{LogLevel: &debug}, nil),
+			m.runtimeSettingsStore.EXPECT().LoadSettings(gomock.Any()).Return(nil, nil),
+		)
+		m.auditRepository.EXPECT().AddAuditEvent(gomock.Any(), gomock.Any()).Return(nil).Times(2)
+		m.watcher.EXPECT().ApplySettings(gomock.Any()).Times(2)
+
+		require.NoError(t, s.ReloadSettings(context.Background()))
+		require.NoError(t, s.ReloadSettings(context.Background()))
+		require.Equal(t, baselineSettings(), s.Current())
+	})
+
+	t.Run("invalid settings keep the current ones", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		m := newRuntimeSettingsMocks(ctrl)
+		s := m.runtimeSettingsService()
+		unknown := domain.LogLevel("verbose")
+
+		m.runtimeSettingsStore.
+			EXPECT().
+			LoadSettings(gomock.Any()).
+			Return(&domain.RuntimeSettingsUpdate{LogLevel: &unknown}, nil).
+			Times(2)
+
+		require.ErrorIs(t, s.ReloadSettings(context.Background()), domain.ErrInvalidRuntimeSettings)
+		// The same invalid settings are only reported once.
+		require.NoError(t, s.ReloadSettings(context.Background()))
+		require.Equal(t, baselineSettings(), s.Current())
+	})
+}
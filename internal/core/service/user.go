@@ -2,7 +2,6 @@ package service
 
 import (
 	"context"
-	"errors"
 	"shop-api-go/internal/core/domain"
 	"shop-api-go/internal/core/port"
 
@@ -13,30 +12,51 @@ import (
 type UserService struct {
 	userRepository  port.UserRepository
 	passwordHasher  port.PasswordHasher
+	passwordPolicy  port.PasswordPolicy
 	tokenRepository port.TokenRepository
+	txManager       port.TxManager
+	credentials     *credentialChecker
 }
 
 // NewUserService creates a new UserService instance.
 func NewUserService(
 	userRepository port.UserRepository,
 	passwordHasher port.PasswordHasher,
+	passwordPolicy port.PasswordPolicy,
 	tokenRepository port.TokenRepository,
+	txManager port.TxManager,
+	loginAttemptRepository port.LoginAttemptRepository,
+	loginThrottle *domain.LoginThrottle,
+	auditRepository port.AuditRepository,
 ) *UserService {
 	return &UserService{
 		userRepository:  userRepository,
 		passwordHasher:  passwordHasher,
+		passwordPolicy:  passwordPolicy,
 		tokenRepository: tokenRepository,
+		txManager:       txManager,
+		credentials:     newCredentialChecker(userRepository, passwordHasher, loginAttemptRepository, loginThrottle, auditRepository),
 	}
 }
 
 func (s *UserService) Register(ctx context.Context, user *domain.User) error {
+	if err := s.passwordPolicy.Validate(user.Password, user.Username, user.Email); err != nil {
+		return err
+	}
+
 	user.Id = uuid.New()
+	user.Role = domain.Client
+	user.Status = domain.UserActive
 	hash, err := s.passwordHasher.Hash(user.Password)
 	if err != nil {
 		return err
 	}
 	user.Password = hash
-	return s.userRepository.AddUser(ctx, user)
+
+	event := domain.NewAuditEvent(ctx, &user.Id, domain.AuditUserRegistered, domain.AuditTargetUser, user.Id.String()).
+		WithChange("username", nil, user.Username).
+		WithChange("email", nil, user.Email)
+	return s.userRepository.AddUser(ctx, user, event, []*domain.Event{domain.NewUserEvent(domain.EventUserRegistered, user)})
 }
 
 func (s *UserService) UpdateAccount(ctx context.Context, update *domain.UpdateAccount) error {
@@ -44,7 +64,7 @@ func (s *UserService) UpdateAccount(ctx context.Context, update *domain.UpdateAc
 	if update.NewUsername != nil {
 		hasFieldToUpdate = true
 	}
-	if update.NewPassword != nil {
+	if update.NewEmail != nil {
 		hasFieldToUpdate = true
 	}
 	if update.NewPassword != nil {
@@ -54,29 +74,62 @@ func (s *UserService) UpdateAccount(ctx context.Context, update *domain.UpdateAc
 		return domain.ErrNoFieldsToUpdate
 	}
 
-	fetchedUser, err := s.userRepository.GetUserByUsername(ctx, update.Username)
-	if errors.Is(err, domain.ErrUserNotFound) {
-		return domain.ErrWrongCredentials
-	} else if err != nil {
+	fetchedUser, err := s.credentials.check(ctx, update.Username, update.Password)
+	if err != nil {
 		return err
 	}
-
-	if err = s.passwordHasher.Compare(update.Password, fetchedUser.Password); err != nil {
-		return err
+	if fetchedUser.Status == domain.UserSuspended {
+		return domain.ErrUserSuspended
 	}
 
-	if err = s.userRepository.UpdateUser(ctx, &domain.UserUpdate{
+	userUpdate := &domain.UserUpdate{
 		Id:       fetchedUser.Id,
 		Username: update.NewUsername,
 		Email:    update.NewEmail,
-		Password: update.NewPassword,
-	}); err != nil {
-		return err
 	}
+	if update.NewPassword != nil {
+		username, email := fetchedUser.Username, fetchedUser.Email
+		if update.NewUsername != nil {
+			username = *update.NewUsername
+		}
+		if update.NewEmail != nil {
+			email = *update.NewEmail
+		}
+		if err = s.passwordPolicy.Validate(*update.NewPassword, username, email); err != nil {
+			return err
+		}
 
-	if err = s.tokenRepository.DeleteAllTokensByUserId(ctx, fetchedUser.Id); err != nil {
-		return err
+		hash, err := s.passwordHasher.Hash(*update.NewPassword)
+		if err != nil {
+			return err
+		}
+		userUpdate.Password = &hash
+	}
+
+	// Sessions issued with the old credentials must not outlive the update.
+	return s.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		err := s.userRepository.UpdateUser(
+			ctx,
+			userUpdate,
+			userUpdateAuditEvent(ctx, fetchedUser.Id, fetchedUser, userUpdate),
+			nil,
+		)
+		if err != nil {
+			return err
+		}
+
+		return s.tokenRepository.DeleteAllTokensByUserId(
+			ctx,
+			fetchedUser.Id,
+			tokensRevokedAuditEvent(ctx, fetchedUser.Id, fetchedUser.Id),
+		)
+	})
+}
+
+func (s *UserService) GetAccount(ctx context.Context, token *domain.Token) (*domain.User, error) {
+	if token.TokenType != domain.AccessToken {
+		return nil, domain.ErrInvalidTokenType
 	}
 
-	return nil
+	return s.userRepository.GetUserById(ctx, token.UserId)
 }
@@ -7,91 +7,170 @@ import (
 	"shop-api-go/internal/core/port/mock"
 	"shop-api-go/internal/core/service"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
 )
 
-func TestUserService_Register(t *testing.T) {
-	ctrl := gomock.NewController(t)
+// userMocks contains all mocked dependencies of service.UserService.
+type userMocks struct {
+	userRepository  *mock.MockUserRepository
+	passwordHasher  *mock.MockPasswordHasher
+	passwordPolicy  *mock.MockPasswordPolicy
+	tokenRepository *mock.MockTokenRepository
+	txManager       *mock.MockTxManager
+
+	loginAttemptRepository *mock.MockLoginAttemptRepository
+	auditRepository        *mock.MockAuditRepository
+}
+
+// newUserMocks creates a new userMocks instance.
+func newUserMocks(ctrl *gomock.Controller) *userMocks {
+	return &userMocks{
+		userRepository:  mock.NewMockUserRepository(ctrl),
+		passwordHasher:  mock.NewMockPasswordHasher(ctrl),
+		passwordPolicy:  mock.NewMockPasswordPolicy(ctrl),
+		tokenRepository: mock.NewMockTokenRepository(ctrl),
+		txManager:       mock.NewMockTxManager(ctrl),
+
+		loginAttemptRepository: mock.NewMockLoginAttemptRepository(ctrl),
+		auditRepository:        mock.NewMockAuditRepository(ctrl),
+	}
+}
+
+// userService creates a service.UserService using the mocks.
+func (m *userMocks) userService() *service.UserService {
+	return service.NewUserService(
+		m.userRepository,
+		m.passwordHasher,
+		m.passwordPolicy,
+		m.tokenRepository,
+		m.txManager,
+		m.loginAttemptRepository,
+		loginThrottle,
+		m.auditRepository,
+	)
+}
+
+// txKey marks the ctx passed to the function run by expectWithinTx.
+type txKey struct{}
+
+// expectWithinTx expects a unit of work and runs its function with a ctx matched by inTx.
+// The error of the function is returned, like a transaction that is rolled back on error.
+func expectWithinTx(txManager *mock.MockTxManager) *gomock.Call {
+	return txManager.
+		EXPECT().
+		WithinTx(gomock.AssignableToTypeOf(context.Background()), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error {
+			return fn(context.WithValue(ctx, txKey{}, true))
+		})
+}
+
+// inTx matches a ctx passed to the function run by expectWithinTx.
+func inTx() gomock.Matcher {
+	return gomock.Cond(func(ctx context.Context) bool {
+		return ctx.Value(txKey{}) != nil
+	})
+}
 
+func TestUserService_Register(t *testing.T) {
 	tests := []struct {
 		name          string
 		user          *domain.User
 		expectedError error
-		mockSetup     func(
-			userRepository *mock.MockUserRepository,
-			passwordHasher *mock.MockPasswordHasher,
-			tokenRepository *mock.MockTokenRepository,
-		)
+		mockSetup     func(m *userMocks)
 	}{
 		{
 			name: "success",
 			user: &domain.User{
+				Username: "username",
+				Email:    "user@email.com",
 				Password: "password",
 			},
 			expectedError: nil,
-			mockSetup: func(
-				userRepository *mock.MockUserRepository,
-				passwordHasher *mock.MockPasswordHasher,
-				tokenRepository *mock.MockTokenRepository,
-			) {
+			mockSetup: func(m *userMocks) {
+				m.passwordPolicy.EXPECT().
+					Validate("password", "username", "user@email.com").
+					Return(nil)
 				gomock.InOrder(
-					passwordHasher.EXPECT().
+					m.passwordHasher.EXPECT().
 						Hash("password").
 						Return("hashedPassword", nil),
 
-					userRepository.EXPECT().
+					m.userRepository.EXPECT().
 						AddUser(
 							gomock.AssignableToTypeOf(context.Background()),
 							gomock.AssignableToTypeOf(&domain.User{}),
+							gomock.AssignableToTypeOf(&domain.AuditEvent{}),
+							gomock.AssignableToTypeOf([]*domain.Event{}),
 						).
-						DoAndReturn(func(ctx context.Context, user *domain.User) error {
-							if user.Password != "hashedPassword" {
+						DoAndReturn(func(ctx context.Context, user *domain.User, event *domain.AuditEvent, outbox []*domain.Event) error {
+							if user.Password != "hashedPassword" || user.Role != domain.Client || event.Action != domain.AuditUserRegistered {
 								return errors.New("wrong password")
 							}
+							if len(outbox) != 1 || outbox[0].Type != domain.EventUserRegistered || outbox[0].AggregateId != user.Id {
+								return errors.New("wrong outbox events")
+							}
 							return nil
 						}),
 				)
 			},
+		}, {
+			name: "error weak password",
+			user: &domain.User{
+				Username: "username",
+				Email:    "user@email.com",
+				Password: "username_1A",
+			},
+			expectedError: domain.ErrWeakPassword,
+			mockSetup: func(m *userMocks) {
+				m.passwordPolicy.EXPECT().
+					Validate("username_1A", "username", "user@email.com").
+					Return(domain.NewPasswordPolicyError([]domain.PasswordViolation{domain.PasswordContainsUsername}))
+			},
 		}, {
 			name: "error hashing password",
 			user: &domain.User{
+				Username: "username",
+				Email:    "user@email.com",
 				Password: "password",
 			},
 			expectedError: domain.ErrInternal,
-			mockSetup: func(
-				userRepository *mock.MockUserRepository,
-				passwordHasher *mock.MockPasswordHasher,
-				tokenRepository *mock.MockTokenRepository,
-			) {
-				passwordHasher.EXPECT().
+			mockSetup: func(m *userMocks) {
+				m.passwordPolicy.EXPECT().
+					Validate("password", "username", "user@email.com").
+					Return(nil)
+				m.passwordHasher.EXPECT().
 					Hash("password").
 					Return("", domain.ErrInternal)
 			},
 		}, {
 			name: "error adding user",
 			user: &domain.User{
+				Username: "username",
+				Email:    "user@email.com",
 				Password: "password",
 			},
 			expectedError: domain.ErrInternal,
-			mockSetup: func(
-				userRepository *mock.MockUserRepository,
-				passwordHasher *mock.MockPasswordHasher,
-				tokenRepository *mock.MockTokenRepository,
-			) {
+			mockSetup: func(m *userMocks) {
+				m.passwordPolicy.EXPECT().
+					Validate("password", "username", "user@email.com").
+					Return(nil)
 				gomock.InOrder(
-					passwordHasher.EXPECT().
+					m.passwordHasher.EXPECT().
 						Hash("password").
 						Return("hashedPassword", nil),
-					userRepository.EXPECT().
+					m.userRepository.EXPECT().
 						AddUser(
 							gomock.AssignableToTypeOf(context.Background()),
 							gomock.AssignableToTypeOf(&domain.User{}),
+							gomock.AssignableToTypeOf(&domain.AuditEvent{}),
+							gomock.AssignableToTypeOf([]*domain.Event{}),
 						).
-						DoAndReturn(func(ctx context.Context, user *domain.User) error {
-							if user.Password != "hashedPassword" {
+						DoAndReturn(func(ctx context.Context, user *domain.User, event *domain.AuditEvent, outbox []*domain.Event) error {
+							if user.Password != "hashedPassword" || user.Role != domain.Client || event.Action != domain.AuditUserRegistered {
 								return errors.New("wrong password")
 							}
 							return domain.ErrInternal
@@ -104,13 +183,10 @@ func TestUserService_Register(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			mockUserRepository := mock.NewMockUserRepository(ctrl)
-			mockPasswordHasher := mock.NewMockPasswordHasher(ctrl)
-			mockTokenRepository := mock.NewMockTokenRepository(ctrl)
-			tt.mockSetup(mockUserRepository, mockPasswordHasher, mockTokenRepository)
+			m := newUserMocks(gomock.NewController(t))
+			tt.mockSetup(m)
 
-			err := service.NewUserService(mockUserRepository, mockPasswordHasher, mockTokenRepository).
-				Register(context.Background(), tt.user)
+			err := m.userService().Register(context.Background(), tt.user)
 			require.ErrorIs(t, err, tt.expectedError)
 		})
 	}
@@ -118,16 +194,13 @@ func TestUserService_Register(t *testing.T) {
 
 func TestUserService_UpdateAccount(t *testing.T) {
 	newUsername := "newUsername"
+	newPassword := "newPassword"
 
 	tests := []struct {
 		name          string
 		update        *domain.UpdateAccount
 		expectedError error
-		mockSetup     func(
-			mockUserRepository *mock.MockUserRepository,
-			mockPasswordHasher *mock.MockPasswordHasher,
-			mockTokenRepository *mock.MockTokenRepository,
-		)
+		mockSetup     func(m *userMocks)
 	}{
 		{
 			name: "success",
@@ -137,13 +210,10 @@ func TestUserService_UpdateAccount(t *testing.T) {
 				NewUsername: &newUsername,
 			},
 			expectedError: nil,
-			mockSetup: func(
-				mockUserRepository *mock.MockUserRepository,
-				mockPasswordHasher *mock.MockPasswordHasher,
-				mockTokenRepository *mock.MockTokenRepository,
-			) {
+			mockSetup: func(m *userMocks) {
+				expectLoginAllowed(m.loginAttemptRepository)
 				gomock.InOrder(
-					mockUserRepository.
+					m.userRepository.
 						EXPECT().
 						GetUserByUsername(
 							gomock.AssignableToTypeOf(context.Background()),
@@ -153,24 +223,91 @@ func TestUserService_UpdateAccount(t *testing.T) {
 							Username: "username",
 							Password: "hashedPassword",
 						}, nil),
-					mockPasswordHasher.
+					m.passwordHasher.
 						EXPECT().
 						Compare("password", "hashedPassword").
 						Return(nil),
-					mockUserRepository.
+					expectLoginReset(m.loginAttemptRepository),
+					expectWithinTx(m.txManager),
+					m.userRepository.
 						EXPECT().
 						UpdateUser(
-							gomock.AssignableToTypeOf(context.Background()),
+							inTx(),
 							gomock.Eq(&domain.UserUpdate{
 								Username: &newUsername,
 							}),
+							gomock.AssignableToTypeOf(&domain.AuditEvent{}),
+							gomock.Nil(),
 						).
 						Return(nil),
-					mockTokenRepository.
+					m.tokenRepository.
 						EXPECT().
 						DeleteAllTokensByUserId(
+							inTx(),
+							gomock.Eq(uuid.UUID{}),
+							gomock.AssignableToTypeOf(&domain.AuditEvent{}),
+						).
+						Return(nil),
+				)
+			},
+		}, {
+			name: "success hashes new password",
+			update: &domain.UpdateAccount{
+				Username:    "username",
+				Password:    "password",
+				NewPassword: &newPassword,
+			},
+			expectedError: nil,
+			mockSetup: func(m *userMocks) {
+				expectLoginAllowed(m.loginAttemptRepository)
+				gomock.InOrder(
+					m.userRepository.
+						EXPECT().
+						GetUserByUsername(
 							gomock.AssignableToTypeOf(context.Background()),
+							gomock.Eq("username"),
+						).
+						Return(&domain.User{
+							Username: "username",
+							Email:    "user@email.com",
+							Password: "hashedPassword",
+						}, nil),
+					m.passwordHasher.
+						EXPECT().
+						Compare("password", "hashedPassword").
+						Return(nil),
+					expectLoginReset(m.loginAttemptRepository),
+					m.passwordPolicy.
+						EXPECT().
+						Validate(newPassword, "username", "user@email.com").
+						Return(nil),
+					m.passwordHasher.
+						EXPECT().
+						Hash(newPassword).
+						Return("newHashedPassword", nil),
+					expectWithinTx(m.txManager),
+					m.userRepository.
+						EXPECT().
+						UpdateUser(
+							inTx(),
+							gomock.Cond(func(update *domain.UserUpdate) bool {
+								return update.Password != nil && *update.Password == "newHashedPassword"
+							}),
+							gomock.Cond(func(event *domain.AuditEvent) bool {
+								return event.Changes["password"] == domain.AuditChange{
+									Before: domain.AuditRedacted,
+									After:  domain.AuditRedacted,
+								}
+							}),
+							gomock.Nil(),
+						).
+						Return(nil),
+					m.tokenRepository.
+						EXPECT().
+						DeleteAllTokensByUserId(
+							inTx(),
 							gomock.Eq(uuid.UUID{}),
+							gomock.AssignableToTypeOf(&domain.AuditEvent{}),
 						).
 						Return(nil),
 				)
@@ -179,11 +316,7 @@ func TestUserService_UpdateAccount(t *testing.T) {
 			name:          "error no fields to update",
 			update:        &domain.UpdateAccount{},
 			expectedError: domain.ErrNoFieldsToUpdate,
-			mockSetup: func(
-				mockUserRepository *mock.MockUserRepository,
-				mockPasswordHasher *mock.MockPasswordHasher,
-				mockTokenRepository *mock.MockTokenRepository,
-			) {
+			mockSetup: func(m *userMocks) {
 
 			},
 		}, {
@@ -194,13 +327,10 @@ func TestUserService_UpdateAccount(t *testing.T) {
 				NewUsername: &newUsername,
 			},
 			expectedError: domain.ErrInternal,
-			mockSetup: func(
-				mockUserRepository *mock.MockUserRepository,
-				mockPasswordHasher *mock.MockPasswordHasher,
-				mockTokenRepository *mock.MockTokenRepository,
-			) {
+			mockSetup: func(m *userMocks) {
+				expectLoginAllowed(m.loginAttemptRepository)
 				gomock.InOrder(
-					mockUserRepository.
+					m.userRepository.
 						EXPECT().
 						GetUserByUsername(
 							gomock.AssignableToTypeOf(context.Background()),
@@ -217,18 +347,20 @@ func TestUserService_UpdateAccount(t *testing.T) {
 				NewUsername: &newUsername,
 			},
 			expectedError: domain.ErrWrongCredentials,
-			mockSetup: func(
-				mockUserRepository *mock.MockUserRepository,
-				mockPasswordHasher *mock.MockPasswordHasher,
-				mockTokenRepository *mock.MockTokenRepository,
-			) {
-				mockUserRepository.
+			mockSetup: func(m *userMocks) {
+				expectLoginAllowed(m.loginAttemptRepository)
+				m.userRepository.
 					EXPECT().
 					GetUserByUsername(
 						gomock.AssignableToTypeOf(context.Background()),
 						gomock.Eq("wrongUsername"),
 					).
 					Return(nil, domain.ErrUserNotFound)
+				m.passwordHasher.
+					EXPECT().
+					Hash("password").
+					Return("hashedPassword", nil)
+				expectFailedLogin(m.loginAttemptRepository, m.auditRepository, domain.AuditLoginFailed)
 			},
 		}, {
 			name: "error wrong password",
@@ -238,10 +370,10 @@ func TestUserService_UpdateAccount(t *testing.T) {
 				NewUsername: &newUsername,
 			},
 			expectedError: domain.ErrWrongCredentials,
-			mockSetup: func(mockUserRepository *mock.MockUserRepository, mockPasswordHasher *mock.MockPasswordHasher, mockTokenRepository *mock.MockTokenRepository) {
-
+			mockSetup: func(m *userMocks) {
+				expectLoginAllowed(m.loginAttemptRepository)
 				gomock.InOrder(
-					mockUserRepository.
+					m.userRepository.
 						EXPECT().
 						GetUserByUsername(
 							gomock.AssignableToTypeOf(context.Background()),
@@ -251,11 +383,23 @@ func TestUserService_UpdateAccount(t *testing.T) {
 							Username: "username",
 							Password: "hashedPassword",
 						}, nil),
-					mockPasswordHasher.
+					m.passwordHasher.
 						EXPECT().
 						Compare("wrongPassword", "hashedPassword").
 						Return(domain.ErrWrongCredentials),
 				)
+				expectFailedLogin(m.loginAttemptRepository, m.auditRepository, domain.AuditLoginFailed)
+			},
+		}, {
+			name: "error too many login attempts",
+			update: &domain.UpdateAccount{
+				Username:    "username",
+				Password:    "password",
+				NewUsername: &newUsername,
+			},
+			expectedError: domain.ErrTooManyLoginAttempts,
+			mockSetup: func(m *userMocks) {
+				expectLoginBlocked(m.loginAttemptRepository)
 			},
 		}, {
 			name: "error updating user",
@@ -265,12 +409,10 @@ func TestUserService_UpdateAccount(t *testing.T) {
 				NewUsername: &newUsername,
 			},
 			expectedError: domain.ErrInternal,
-			mockSetup: func(
-				mockUserRepository *mock.MockUserRepository,
-				mockPasswordHasher *mock.MockPasswordHasher,
-				mockTokenRepository *mock.MockTokenRepository) {
+			mockSetup: func(m *userMocks) {
+				expectLoginAllowed(m.loginAttemptRepository)
 				gomock.InOrder(
-					mockUserRepository.
+					m.userRepository.
 						EXPECT().
 						GetUserByUsername(
 							gomock.AssignableToTypeOf(context.Background()),
@@ -280,17 +422,21 @@ func TestUserService_UpdateAccount(t *testing.T) {
 							Username: "username",
 							Password: "hashedPassword",
 						}, nil),
-					mockPasswordHasher.
+					m.passwordHasher.
 						EXPECT().
 						Compare("password", "hashedPassword").
 						Return(nil),
-					mockUserRepository.
+					expectLoginReset(m.loginAttemptRepository),
+					expectWithinTx(m.txManager),
+					m.userRepository.
 						EXPECT().
 						UpdateUser(
-							gomock.AssignableToTypeOf(context.Background()),
+							inTx(),
 							gomock.Eq(&domain.UserUpdate{
 								Username: &newUsername,
 							}),
+							gomock.AssignableToTypeOf(&domain.AuditEvent{}),
+							gomock.Nil(),
 						).
 						Return(domain.ErrInternal),
 				)
@@ -303,13 +449,10 @@ func TestUserService_UpdateAccount(t *testing.T) {
 				NewUsername: &newUsername,
 			},
 			expectedError: domain.ErrInternal,
-			mockSetup: func(
-				mockUserRepository *mock.MockUserRepository,
-				mockPasswordHasher *mock.MockPasswordHasher,
-				mockTokenRepository *mock.MockTokenRepository,
-			) {
+			mockSetup: func(m *userMocks) {
+				expectLoginAllowed(m.loginAttemptRepository)
 				gomock.InOrder(
-					mockUserRepository.
+					m.userRepository.
 						EXPECT().
 						GetUserByUsername(
 							gomock.AssignableToTypeOf(context.Background()),
@@ -319,24 +462,29 @@ func TestUserService_UpdateAccount(t *testing.T) {
 							Username: "username",
 							Password: "hashedPassword",
 						}, nil),
-					mockPasswordHasher.
+					m.passwordHasher.
 						EXPECT().
 						Compare("password", "hashedPassword").
 						Return(nil),
-					mockUserRepository.
+					expectLoginReset(m.loginAttemptRepository),
+					expectWithinTx(m.txManager),
+					m.userRepository.
 						EXPECT().
 						UpdateUser(
-							gomock.AssignableToTypeOf(context.Background()),
+							inTx(),
 							gomock.Eq(&domain.UserUpdate{
 								Username: &newUsername,
 							}),
+							gomock.AssignableToTypeOf(&domain.AuditEvent{}),
+							gomock.Nil(),
 						).
 						Return(nil),
-					mockTokenRepository.
+					m.tokenRepository.
 						EXPECT().
 						DeleteAllTokensByUserId(
-							gomock.AssignableToTypeOf(context.Background()),
+							inTx(),
 							gomock.Eq(uuid.UUID{}),
+							gomock.AssignableToTypeOf(&domain.AuditEvent{}),
 						).
 						Return(domain.ErrInternal),
 				)
@@ -346,15 +494,77 @@ func TestUserService_UpdateAccount(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			ctrl := gomock.NewController(t)
-			mockUserRepository := mock.NewMockUserRepository(ctrl)
-			mockPasswordHasher := mock.NewMockPasswordHasher(ctrl)
-			mockTokenRepository := mock.NewMockTokenRepository(ctrl)
-			tt.mockSetup(mockUserRepository, mockPasswordHasher, mockTokenRepository)
+			m := newUserMocks(gomock.NewController(t))
+			tt.mockSetup(m)
+
+			err := m.userService().UpdateAccount(context.Background(), tt.update)
+			require.ErrorIs(t, err, tt.expectedError)
+		})
+	}
+}
+
+func TestUserService_GetAccount(t *testing.T) {
+	userId := uuid.New()
+	user := &domain.User{
+		Id:       userId,
+		Username: "username",
+		Email:    "user@example.com",
+		Role:     domain.Client,
+		Status:   domain.UserActive,
+	}
+
+	tests := []struct {
+		name           string
+		token          *domain.Token
+		expectedError  error
+		expectedResult *domain.User
+		mockSetup      func(m *userMocks)
+	}{
+		{
+			name:           "success",
+			token:          domain.NewToken(uuid.New(), userId, domain.Client, domain.AccessToken, time.Now()),
+			expectedError:  nil,
+			expectedResult: user,
+			mockSetup: func(m *userMocks) {
+				m.userRepository.
+					EXPECT().
+					GetUserById(
+						gomock.AssignableToTypeOf(context.Background()),
+						gomock.Eq(userId),
+					).
+					Return(user, nil)
+			},
+		}, {
+			name:          "error invalid token type",
+			token:         domain.NewToken(uuid.New(), userId, domain.Client, domain.RefreshToken, time.Now()),
+			expectedError: domain.ErrInvalidTokenType,
+			mockSetup:     func(m *userMocks) {},
+		}, {
+			name:          "error user not found",
+			token:         domain.NewToken(uuid.New(), userId, domain.Client, domain.AccessToken, time.Now()),
+			expectedError: domain.ErrUserNotFound,
+			mockSetup: func(m *userMocks) {
+				m.userRepository.
+					EXPECT().
+					GetUserById(
+						gomock.AssignableToTypeOf(context.Background()),
+						gomock.Eq(userId),
+					).
+					Return(nil, domain.ErrUserNotFound)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := newUserMocks(gomock.NewController(t))
+			tt.mockSetup(m)
 
-			if err := service.
-				NewUserService(mockUserRepository, mockPasswordHasher, mockTokenRepository).
-				UpdateAccount(context.Background(), tt.update); err != nil {
+			result, err := m.userService().GetAccount(context.Background(), tt.token)
+			if tt.expectedError == nil {
+				require.NoError(t, err)
+				require.Equal(t, tt.expectedResult, result)
+			} else {
 				require.ErrorIs(t, err, tt.expectedError)
 			}
 		})
@@ -0,0 +1,262 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"shop-api-go/internal/core/domain"
+	"shop-api-go/internal/core/port"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// WebhookService implements port.WebhookService interface and provides access to webhook-related business logic.
+type WebhookService struct {
+	webhookRepository port.WebhookRepository
+	webhookSender     port.WebhookSender
+	authorizer        port.Authorizer
+	policy            *domain.WebhookPolicy
+}
+
+// NewWebhookService creates a new WebhookService instance.
+func NewWebhookService(
+	webhookRepository port.WebhookRepository,
+	webhookSender port.WebhookSender,
+	authorizer port.Authorizer,
+	policy *domain.WebhookPolicy,
+) *WebhookService {
+	return &WebhookService{
+		webhookRepository: webhookRepository,
+		webhookSender:     webhookSender,
+		authorizer:        authorizer,
+		policy:            policy,
+	}
+}
+
+func (s *WebhookService) GetEndpoints(ctx context.Context, token *domain.Token) ([]domain.WebhookEndpoint, error) {
+	if err := s.authorizer.Authorize(ctx, token, domain.WebhooksRead); err != nil {
+		return nil, err
+	}
+
+	endpoints, err := s.webhookRepository.GetEndpoints(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for i := range endpoints {
+		endpoints[i].Secret = ""
+	}
+	return endpoints, nil
+}
+
+func (s *WebhookService) GetEndpoint(ctx context.Context, token *domain.Token, id uuid.UUID) (*domain.WebhookEndpoint, error) {
+	if err := s.authorizer.Authorize(ctx, token, domain.WebhooksRead); err != nil {
+		return nil, err
+	}
+
+	endpoint, err := s.webhookRepository.GetEndpointById(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	endpoint.Secret = ""
+	return endpoint, nil
+}
+
+func (s *WebhookService) CreateEndpoint(
+	ctx context.Context,
+	token *domain.Token,
+	endpoint *domain.WebhookEndpoint,
+) (*domain.WebhookEndpoint, error) {
+	if err := s.authorizer.Authorize(ctx, token, domain.WebhooksWrite); err != nil {
+		return nil, err
+	}
+	if err := s.policy.CheckUrl(endpoint.Url); err != nil {
+		return nil, err
+	}
+
+	event := domain.NewAuditEvent(ctx, &token.UserId, domain.AuditWebhookCreated, domain.AuditTargetWebhook, endpoint.Id.String()).
+		WithChange("url", nil, endpoint.Url).
+		WithChange("description", nil, endpoint.Description).
+		WithChange("eventTypes", nil, endpoint.EventTypes).
+		WithChange("enabled", nil, endpoint.Enabled).
+		WithRedactedChange("secret")
+	if err := s.webhookRepository.AddEndpoint(ctx, endpoint, event); err != nil {
+		return nil, err
+	}
+	return endpoint, nil
+}
+
+func (s *WebhookService) UpdateEndpoint(
+	ctx context.Context,
+	token *domain.Token,
+	update *domain.WebhookEndpointUpdate,
+) (*domain.WebhookEndpoint, error) {
+	if err := s.authorizer.Authorize(ctx, token, domain.WebhooksWrite); err != nil {
+		return nil, err
+	}
+	if update.Url == nil && update.Description == nil && update.EventTypes == nil && update.Enabled == nil {
+		return nil, domain.ErrNoFieldsToUpdate
+	}
+	if update.Url != nil {
+		if err := s.policy.CheckUrl(*update.Url); err != nil {
+			return nil, err
+		}
+	}
+
+	endpoint, err := s.webhookRepository.GetEndpointById(ctx, update.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	event := domain.NewAuditEvent(ctx, &token.UserId, domain.AuditWebhookUpdated, domain.AuditTargetWebhook, endpoint.Id.String())
+	if update.Url != nil {
+		event.WithChange("url", endpoint.Url, *update.Url)
+		endpoint.Url = *update.Url
+	}
+	if update.Description != nil {
+		event.WithChange("description", endpoint.Description, *update.Description)
+		endpoint.Description = *update.Description
+	}
+	if update.EventTypes != nil {
+		event.WithChange("eventTypes", endpoint.EventTypes, update.EventTypes)
+		endpoint.EventTypes = update.EventTypes
+	}
+	if update.Enabled != nil {
+		event.WithChange("enabled", endpoint.Enabled, *update.Enabled)
+		endpoint.Enabled = *update.Enabled
+	}
+	endpoint.UpdatedAt = time.Now()
+
+	if err = s.webhookRepository.UpdateEndpoint(ctx, endpoint, event); err != nil {
+		return nil, err
+	}
+	endpoint.Secret = ""
+	return endpoint, nil
+}
+
+func (s *WebhookService) RotateEndpointSecret(ctx context.Context, token *domain.Token, id uuid.UUID) (*domain.WebhookEndpoint, error) {
+	if err := s.authorizer.Authorize(ctx, token, domain.WebhooksWrite); err != nil {
+		return nil, err
+	}
+
+	endpoint, err := s.webhookRepository.GetEndpointById(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	endpoint.Secret = domain.NewWebhookSecret()
+	endpoint.UpdatedAt = time.Now()
+
+	event := domain.NewAuditEvent(ctx, &token.UserId, domain.AuditWebhookSecretRotated, domain.AuditTargetWebhook, id.String()).
+		WithRedactedChange("secret")
+	if err = s.webhookRepository.UpdateEndpoint(ctx, endpoint, event); err != nil {
+		return nil, err
+	}
+	return endpoint, nil
+}
+
+func (s *WebhookService) DeleteEndpoint(ctx context.Context, token *domain.Token, id uuid.UUID) error {
+	if err := s.authorizer.Authorize(ctx, token, domain.WebhooksWrite); err != nil {
+		return err
+	}
+
+	endpoint, err := s.webhookRepository.GetEndpointById(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	event := domain.NewAuditEvent(ctx, &token.UserId, domain.AuditWebhookDeleted, domain.AuditTargetWebhook, id.String()).
+		WithChange("url", endpoint.Url, nil).
+		WithChange("eventTypes", endpoint.EventTypes, nil)
+	return s.webhookRepository.DeleteEndpoint(ctx, id, event)
+}
+
+func (s *WebhookService) GetDeliveries(
+	ctx context.Context,
+	token *domain.Token,
+	filter *domain.WebhookDeliveryFilter,
+) ([]domain.WebhookDelivery, error) {
+	if err := s.authorizer.Authorize(ctx, token, domain.WebhooksRead); err != nil {
+		return nil, err
+	}
+	if filter.Limit <= 0 {
+		return nil, domain.ErrLimitNotSet
+	}
+	if filter.Page <= 0 {
+		filter.Page = 1
+	}
+
+	// Distinguish an unknown endpoint from one without deliveries.
+	if _, err := s.webhookRepository.GetEndpointById(ctx, filter.EndpointId); err != nil {
+		return nil, err
+	}
+	return s.webhookRepository.GetDeliveries(ctx, filter)
+}
+
+func (s *WebhookService) GetDelivery(
+	ctx context.Context,
+	token *domain.Token,
+	id uuid.UUID,
+) (*domain.WebhookDelivery, []domain.WebhookAttempt, error) {
+	if err := s.authorizer.Authorize(ctx, token, domain.WebhooksRead); err != nil {
+		return nil, nil, err
+	}
+
+	delivery, err := s.webhookRepository.GetDeliveryById(ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+	attempts, err := s.webhookRepository.GetDeliveryAttempts(ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+	return delivery, attempts, nil
+}
+
+func (s *WebhookService) ReplayDelivery(ctx context.Context, token *domain.Token, id uuid.UUID) error {
+	if err := s.authorizer.Authorize(ctx, token, domain.WebhooksWrite); err != nil {
+		return err
+	}
+
+	delivery, err := s.webhookRepository.GetDeliveryById(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	event := domain.NewAuditEvent(ctx, &token.UserId, domain.AuditWebhookReplayed, domain.AuditTargetWebhook, delivery.EndpointId.String()).
+		WithChange("delivery", nil, delivery.Id).
+		WithChange("status", delivery.Status, domain.WebhookDeliveryPending)
+	return s.webhookRepository.ReplayDelivery(ctx, id, event)
+}
+
+func (s *WebhookService) Publish(ctx context.Context, event *domain.Event) error {
+	endpoints, err := s.webhookRepository.GetSubscribedEndpoints(ctx, event.Type)
+	if err != nil {
+		return err
+	}
+	if len(endpoints) == 0 {
+		return nil
+	}
+
+	deliveries := make([]*domain.WebhookDelivery, 0, len(endpoints))
+	for _, endpoint := range endpoints {
+		deliveries = append(deliveries, domain.NewWebhookDelivery(endpoint.Id, event))
+	}
+	return s.webhookRepository.AddDeliveries(ctx, deliveries)
+}
+
+func (s *WebhookService) ClaimDeliveries(ctx context.Context) ([]domain.WebhookDelivery, error) {
+	return s.webhookRepository.ClaimDeliveries(ctx, s.policy.BatchSize)
+}
+
+func (s *WebhookService) Deliver(ctx context.Context, delivery *domain.WebhookDelivery) error {
+	endpoint, err := s.webhookRepository.GetEndpointById(ctx, delivery.EndpointId)
+	// The endpoint was deleted with its deliveries after the delivery was claimed.
+	if errors.Is(err, domain.ErrWebhookEndpointNotFound) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	attempt := s.webhookSender.Send(ctx, endpoint, delivery)
+	s.policy.Record(delivery, attempt)
+	return s.webhookRepository.RecordDeliveryAttempt(ctx, delivery, attempt)
+}
@@ -0,0 +1,477 @@
+package service_test
+
+import (
+	"context"
+	"shop-api-go/internal/core/domain"
+	"shop-api-go/internal/core/port/mock"
+	"shop-api-go/internal/core/service"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+// webhookMocks contains all mocked dependencies of service.WebhookService.
+type webhookMocks struct {
+	webhookRepository *mock.MockWebhookRepository
+	webhookSender     *mock.MockWebhookSender
+	authorizer        *mock.MockAuthorizer
+}
+
+// newWebhookMocks creates a new webhookMocks instance.
+func newWebhookMocks(ctrl *gomock.Controller) *webhookMocks {
+	return &webhookMocks{
+		webhookRepository: mock.NewMockWebhookRepository(ctrl),
+		webhookSender:     mock.NewMockWebhookSender(ctrl),
+		authorizer:        mock.NewMockAuthorizer(ctrl),
+	}
+}
+
+// webhookService creates a service.WebhookService using the mocks.
+func (m *webhookMocks) webhookService() *service.WebhookService {
+	return service.NewWebhookService(
+		m.webhookRepository,
+		m.webhookSender,
+		m.authorizer,
+		&domain.WebhookPolicy{BatchSize: 10, MaxAttempts: 3, BaseBackoff: time.Minute, MaxBackoff: time.Hour, RequireHTTPS: true},
+	)
+}
+
+// expectAuthorize expects the permission to be checked and returns err.
+func (m *webhookMocks) expectAuthorize(permission domain.Permission, err error) {
+	m.authorizer.
+		EXPECT().
+		Authorize(
+			gomock.AssignableToTypeOf(context.Background()),
+			gomock.AssignableToTypeOf(&domain.Token{}),
+			permission,
+		).
+		Return(err)
+}
+
+func TestWebhookService_CreateEndpoint(t *testing.T) {
+	token := &domain.Token{UserId: uuid.New(), TokenType: domain.AccessToken, UserRole: domain.Admin}
+
+	tests := []struct {
+		name          string
+		url           string
+		expectedError error
+		mockSetup     func(m *webhookMocks)
+	}{
+		{
+			name:          "success",
+			url:           "https://partner.example.com/hooks",
+			expectedError: nil,
+			mockSetup: func(m *webhookMocks) {
+				m.expectAuthorize(domain.WebhooksWrite, nil)
+				m.webhookRepository.
+					EXPECT().
+					AddEndpoint(
+						gomock.AssignableToTypeOf(context.Background()),
+						gomock.AssignableToTypeOf(&domain.WebhookEndpoint{}),
+						gomock.Cond(func(event *domain.AuditEvent) bool {
+							return event.Action == domain.AuditWebhookCreated &&
+								event.Changes["secret"].After == domain.AuditRedacted
+						}),
+					).
+					Return(nil)
+			},
+		}, {
+			name:          "error invalid token role",
+			url:           "https://partner.example.com/hooks",
+			expectedError: domain.ErrInvalidTokenRole,
+			mockSetup: func(m *webhookMocks) {
+				m.expectAuthorize(domain.WebhooksWrite, domain.ErrInvalidTokenRole)
+			},
+		}, {
+			name:          "error http url",
+			url:           "http://partner.example.com/hooks",
+			expectedError: domain.ErrInvalidWebhookUrl,
+			mockSetup: func(m *webhookMocks) {
+				m.expectAuthorize(domain.WebhooksWrite, nil)
+			},
+		}, {
+			name:          "error private address",
+			url:           "https://169.254.169.254/latest/meta-data",
+			expectedError: domain.ErrInvalidWebhookUrl,
+			mockSetup: func(m *webhookMocks) {
+				m.expectAuthorize(domain.WebhooksWrite, nil)
+			},
+		}, {
+			name:          "error internal",
+			url:           "https://partner.example.com/hooks",
+			expectedError: domain.ErrInternal,
+			mockSetup: func(m *webhookMocks) {
+				m.expectAuthorize(domain.WebhooksWrite, nil)
+				m.webhookRepository.
+					EXPECT().
+					AddEndpoint(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(domain.ErrInternal)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			m := newWebhookMocks(ctrl)
+			tt.mockSetup(m)
+
+			endpoint := domain.NewWebhookEndpoint(tt.url, "Partner", nil)
+			created, err := m.webhookService().CreateEndpoint(context.Background(), token, endpoint)
+			require.Equal(t, tt.expectedError, err)
+			if tt.expectedError == nil {
+				require.True(t, strings.HasPrefix(created.Secret, "whsec_"))
+			}
+		})
+	}
+}
+
+func TestWebhookService_UpdateEndpoint(t *testing.T) {
+	token := &domain.Token{UserId: uuid.New(), TokenType: domain.AccessToken, UserRole: domain.Admin}
+	id := uuid.New()
+	enabled := false
+	loopbackUrl := "https://127.0.0.1:8080/hooks"
+	newEndpoint := func() *domain.WebhookEndpoint {
+		return &domain.WebhookEndpoint{
+			Id:         id,
+			Url:        "https://partner.example.com/hooks",
+			EventTypes: []domain.EventType{domain.EventUserCreated},
+			Secret:     "whsec_secret",
+			Enabled:    true,
+		}
+	}
+
+	tests := []struct {
+		name          string
+		update        *domain.WebhookEndpointUpdate
+		expectedError error
+		mockSetup     func(m *webhookMocks)
+	}{
+		{
+			name:          "success",
+			update:        domain.NewWebhookEndpointUpdate(id, nil, nil, []domain.EventType{domain.EventUserRegistered}, &enabled),
+			expectedError: nil,
+			mockSetup: func(m *webhookMocks) {
+				m.expectAuthorize(domain.WebhooksWrite, nil)
+				m.webhookRepository.
+					EXPECT().
+					GetEndpointById(gomock.AssignableToTypeOf(context.Background()), id).
+					Return(newEndpoint(), nil)
+				m.webhookRepository.
+					EXPECT().
+					UpdateEndpoint(
+						gomock.AssignableToTypeOf(context.Background()),
+						gomock.Cond(func(endpoint *domain.WebhookEndpoint) bool {
+							return !endpoint.Enabled &&
+								endpoint.Secret == "whsec_secret" &&
+								endpoint.EventTypes[0] == domain.EventUserRegistered
+						}),
+						gomock.Cond(func(event *domain.AuditEvent) bool {
+							_, urlChanged := event.Changes["url"]
+							return event.Action == domain.AuditWebhookUpdated &&
+								event.Changes["enabled"] == domain.AuditChange{Before: true, After: false} &&
+								!urlChanged
+						}),
+					).
+					Return(nil)
+			},
+		}, {
+			name:          "error no fields to update",
+			update:        domain.NewWebhookEndpointUpdate(id, nil, nil, nil, nil),
+			expectedError: domain.ErrNoFieldsToUpdate,
+			mockSetup: func(m *webhookMocks) {
+				m.expectAuthorize(domain.WebhooksWrite, nil)
+			},
+		}, {
+			name:          "error loopback address",
+			update:        domain.NewWebhookEndpointUpdate(id, &loopbackUrl, nil, nil, nil),
+			expectedError: domain.ErrInvalidWebhookUrl,
+			mockSetup: func(m *webhookMocks) {
+				m.expectAuthorize(domain.WebhooksWrite, nil)
+			},
+		}, {
+			name:          "error endpoint not found",
+			update:        domain.NewWebhookEndpointUpdate(id, nil, nil, nil, &enabled),
+			expectedError: domain.ErrWebhookEndpointNotFound,
+			mockSetup: func(m *webhookMocks) {
+				m.expectAuthorize(domain.WebhooksWrite, nil)
+				m.webhookRepository.
+					EXPECT().
+					GetEndpointById(gomock.AssignableToTypeOf(context.Background()), id).
+					Return(nil, domain.ErrWebhookEndpointNotFound)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			m := newWebhookMocks(ctrl)
+			tt.mockSetup(m)
+
+			endpoint, err := m.webhookService().UpdateEndpoint(context.Background(), token, tt.update)
+			require.Equal(t, tt.expectedError, err)
+			if tt.expectedError == nil {
+				require.Empty(t, endpoint.Secret)
+			}
+		})
+	}
+}
+
+func TestWebhookService_Publish(t *testing.T) {
+	event := domain.NewEvent(domain.EventUserRegistered, domain.EventAggregateUser, uuid.New(), nil)
+	first, second := uuid.New(), uuid.New()
+
+	tests := []struct {
+		name          string
+		expectedError error
+		mockSetup     func(m *webhookMocks)
+	}{
+		{
+			name:          "success",
+			expectedError: nil,
+			mockSetup: func(m *webhookMocks) {
+				m.webhookRepository.
+					EXPECT().
+					GetSubscribedEndpoints(gomock.AssignableToTypeOf(context.Background()), domain.EventUserRegistered).
+					Return([]domain.WebhookEndpoint{{Id: first}, {Id: second}}, nil)
+				m.webhookRepository.
+					EXPECT().
+					AddDeliveries(
+						gomock.AssignableToTypeOf(context.Background()),
+						gomock.Cond(func(deliveries []*domain.WebhookDelivery) bool {
+							return len(deliveries) == 2 &&
+								deliveries[0].EndpointId == first &&
+								deliveries[1].EndpointId == second &&
+								deliveries[0].Event.Id == event.Id &&
+								deliveries[0].Status == domain.WebhookDeliveryPending
+						}),
+					).
+					Return(nil)
+			},
+		}, {
+			name:          "success no subscribed endpoint",
+			expectedError: nil,
+			mockSetup: func(m *webhookMocks) {
+				m.webhookRepository.
+					EXPECT().
+					GetSubscribedEndpoints(gomock.AssignableToTypeOf(context.Background()), domain.EventUserRegistered).
+					Return([]domain.WebhookEndpoint{}, nil)
+			},
+		}, {
+			name:          "error internal",
+			expectedError: domain.ErrInternal,
+			mockSetup: func(m *webhookMocks) {
+				m.webhookRepository.
+					EXPECT().
+					GetSubscribedEndpoints(gomock.AssignableToTypeOf(context.Background()), domain.EventUserRegistered).
+					Return(nil, domain.ErrInternal)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			m := newWebhookMocks(ctrl)
+			tt.mockSetup(m)
+
+			err := m.webhookService().Publish(context.Background(), event)
+			require.Equal(t, tt.expectedError, err)
+		})
+	}
+}
+
+func TestWebhookService_Deliver(t *testing.T) {
+	endpoint := &domain.WebhookEndpoint{Id: uuid.New(), Url: "https://partner.example.com/hooks", Enabled: true}
+	now := time.Now()
+
+	tests := []struct {
+		name           string
+		attempts       int
+		expectedStatus domain.WebhookDeliveryStatus
+		expectedError  error
+		mockSetup      func(m *webhookMocks, delivery *domain.WebhookDelivery)
+	}{
+		{
+			name:           "success",
+			attempts:       0,
+			expectedStatus: domain.WebhookDeliverySucceeded,
+			expectedError:  nil,
+			mockSetup: func(m *webhookMocks, delivery *domain.WebhookDelivery) {
+				attempt := domain.NewWebhookAttempt(delivery.Id, 200, "", time.Millisecond, now)
+				gomock.InOrder(
+					m.webhookRepository.
+						EXPECT().
+						GetEndpointById(gomock.AssignableToTypeOf(context.Background()), endpoint.Id).
+						Return(endpoint, nil),
+					m.webhookSender.
+						EXPECT().
+						Send(gomock.AssignableToTypeOf(context.Background()), endpoint, delivery).
+						Return(attempt),
+					m.webhookRepository.
+						EXPECT().
+						RecordDeliveryAttempt(gomock.AssignableToTypeOf(context.Background()), delivery, attempt).
+						Return(nil),
+				)
+			},
+		}, {
+			name:           "failure retried",
+			attempts:       1,
+			expectedStatus: domain.WebhookDeliveryPending,
+			expectedError:  nil,
+			mockSetup: func(m *webhookMocks, delivery *domain.WebhookDelivery) {
+				attempt := domain.NewWebhookAttempt(delivery.Id, 500, "", time.Millisecond, now)
+				m.webhookRepository.
+					EXPECT().
+					GetEndpointById(gomock.AssignableToTypeOf(context.Background()), endpoint.Id).
+					Return(endpoint, nil)
+				m.webhookSender.
+					EXPECT().
+					Send(gomock.AssignableToTypeOf(context.Background()), endpoint, delivery).
+					Return(attempt)
+				m.webhookRepository.
+					EXPECT().
+					RecordDeliveryAttempt(
+						gomock.AssignableToTypeOf(context.Background()),
+						gomock.Cond(func(d *domain.WebhookDelivery) bool {
+							return d.Attempts == 2 && d.NextAttemptAt.Equal(now.Add(2*time.Minute))
+						}),
+						attempt,
+					).
+					Return(nil)
+			},
+		}, {
+			name:           "failure dead after max attempts",
+			attempts:       2,
+			expectedStatus: domain.WebhookDeliveryDead,
+			expectedError:  nil,
+			mockSetup: func(m *webhookMocks, delivery *domain.WebhookDelivery) {
+				attempt := domain.NewWebhookAttempt(delivery.Id, 0, "connection refused", time.Millisecond, now)
+				m.webhookRepository.
+					EXPECT().
+					GetEndpointById(gomock.AssignableToTypeOf(context.Background()), endpoint.Id).
+					Return(endpoint, nil)
+				m.webhookSender.
+					EXPECT().
+					Send(gomock.AssignableToTypeOf(context.Background()), endpoint, delivery).
+					Return(attempt)
+				m.webhookRepository.
+					EXPECT().
+					RecordDeliveryAttempt(gomock.AssignableToTypeOf(context.Background()), delivery, attempt).
+					Return(nil)
+			},
+		}, {
+			name:           "success endpoint deleted",
+			attempts:       0,
+			expectedStatus: domain.WebhookDeliveryPending,
+			expectedError:  nil,
+			mockSetup: func(m *webhookMocks, delivery *domain.WebhookDelivery) {
+				m.webhookRepository.
+					EXPECT().
+					GetEndpointById(gomock.AssignableToTypeOf(context.Background()), endpoint.Id).
+					Return(nil, domain.ErrWebhookEndpointNotFound)
+			},
+		}, {
+			name:           "error recording attempt",
+			attempts:       0,
+			expectedStatus: domain.WebhookDeliverySucceeded,
+			expectedError:  domain.ErrInternal,
+			mockSetup: func(m *webhookMocks, delivery *domain.WebhookDelivery) {
+				m.webhookRepository.
+					EXPECT().
+					GetEndpointById(gomock.AssignableToTypeOf(context.Background()), endpoint.Id).
+					Return(endpoint, nil)
+				m.webhookSender.
+					EXPECT().
+					Send(gomock.AssignableToTypeOf(context.Background()), endpoint, delivery).
+					Return(domain.NewWebhookAttempt(delivery.Id, 204, "", time.Millisecond, now))
+				m.webhookRepository.
+					EXPECT().
+					RecordDeliveryAttempt(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(domain.ErrInternal)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			m := newWebhookMocks(ctrl)
+			event := domain.NewEvent(domain.EventUserCreated, domain.EventAggregateUser, uuid.New(), nil)
+			delivery := domain.NewWebhookDelivery(endpoint.Id, event)
+			delivery.Attempts = tt.attempts
+			tt.mockSetup(m, delivery)
+
+			err := m.webhookService().Deliver(context.Background(), delivery)
+			require.Equal(t, tt.expectedError, err)
+			require.Equal(t, tt.expectedStatus, delivery.Status)
+		})
+	}
+}
+
+func TestWebhookService_ReplayDelivery(t *testing.T) {
+	token := &domain.Token{UserId: uuid.New(), TokenType: domain.AccessToken, UserRole: domain.Admin}
+	delivery := &domain.WebhookDelivery{Id: uuid.New(), EndpointId: uuid.New(), Status: domain.WebhookDeliveryDead}
+
+	tests := []struct {
+		name          string
+		expectedError error
+		mockSetup     func(m *webhookMocks)
+	}{
+		{
+			name:          "success",
+			expectedError: nil,
+			mockSetup: func(m *webhookMocks) {
+				m.expectAuthorize(domain.WebhooksWrite, nil)
+				m.webhookRepository.
+					EXPECT().
+					GetDeliveryById(gomock.AssignableToTypeOf(context.Background()), delivery.Id).
+					Return(delivery, nil)
+				m.webhookRepository.
+					EXPECT().
+					ReplayDelivery(
+						gomock.AssignableToTypeOf(context.Background()),
+						delivery.Id,
+						gomock.Cond(func(event *domain.AuditEvent) bool {
+							return event.Action == domain.AuditWebhookReplayed &&
+								event.TargetId == delivery.EndpointId.String() &&
+								event.Changes["status"].Before == domain.WebhookDeliveryDead
+						}),
+					).
+					Return(nil)
+			},
+		}, {
+			name:          "error invalid token role",
+			expectedError: domain.ErrInvalidTokenRole,
+			mockSetup: func(m *webhookMocks) {
+				m.expectAuthorize(domain.WebhooksWrite, domain.ErrInvalidTokenRole)
+			},
+		}, {
+			name:          "error delivery not found",
+			expectedError: domain.ErrWebhookDeliveryNotFound,
+			mockSetup: func(m *webhookMocks) {
+				m.expectAuthorize(domain.WebhooksWrite, nil)
+				m.webhookRepository.
+					EXPECT().
+					GetDeliveryById(gomock.AssignableToTypeOf(context.Background()), delivery.Id).
+					Return(nil, domain.ErrWebhookDeliveryNotFound)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			m := newWebhookMocks(ctrl)
+			tt.mockSetup(m)
+
+			err := m.webhookService().ReplayDelivery(context.Background(), token, delivery.Id)
+			require.Equal(t, tt.expectedError, err)
+		})
+	}
+}
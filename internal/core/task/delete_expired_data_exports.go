@@ -0,0 +1,26 @@
+package task
+
+import (
+	"context"
+	"shop-api-go/internal/core/port"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+func StartDeleteExpiredDataExportsTask(ctx context.Context, dataRequestRepository port.DataRequestRepository, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	go func() {
+		for {
+			select {
+			case <-ticker.C:
+				zap.L().Info("deleting expired data exports")
+				_ = dataRequestRepository.DeleteExpiredDataExports()
+			case <-ctx.Done():
+				zap.L().Info("stoping expired data exports clean up task")
+				ticker.Stop()
+				return
+			}
+		}
+	}()
+}
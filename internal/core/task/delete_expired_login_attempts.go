@@ -0,0 +1,31 @@
+package task
+
+import (
+	"context"
+	"shop-api-go/internal/core/port"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+func StartDeleteExpiredLoginAttemptsTask(
+	ctx context.Context,
+	loginAttemptRepository port.LoginAttemptRepository,
+	interval time.Duration,
+	window time.Duration,
+) {
+	ticker := time.NewTicker(interval)
+	go func() {
+		for {
+			select {
+			case <-ticker.C:
+				zap.L().Info("deleting expired login attempts")
+				_ = loginAttemptRepository.DeleteExpiredLoginAttempts(window)
+			case <-ctx.Done():
+				zap.L().Info("stoping expired login attempts clean up task")
+				ticker.Stop()
+				return
+			}
+		}
+	}()
+}
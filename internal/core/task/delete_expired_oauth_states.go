@@ -0,0 +1,30 @@
+package task
+
+import (
+	"context"
+	"shop-api-go/internal/core/port"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+func StartDeleteExpiredOAuthStatesTask(
+	ctx context.Context,
+	identityRepository port.IdentityRepository,
+	interval time.Duration,
+) {
+	ticker := time.NewTicker(interval)
+	go func() {
+		for {
+			select {
+			case <-ticker.C:
+				zap.L().Info("deleting expired oauth states")
+				_ = identityRepository.DeleteExpiredOAuthStates()
+			case <-ctx.Done():
+				zap.L().Info("stoping expired oauth states clean up task")
+				ticker.Stop()
+				return
+			}
+		}
+	}()
+}
@@ -0,0 +1,31 @@
+package task
+
+import (
+	"context"
+	"shop-api-go/internal/core/port"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+func StartDeleteIdleRateLimitBucketsTask(
+	ctx context.Context,
+	rateLimitStore port.RateLimitStore,
+	interval time.Duration,
+	idle time.Duration,
+) {
+	ticker := time.NewTicker(interval)
+	go func() {
+		for {
+			select {
+			case <-ticker.C:
+				zap.L().Info("deleting idle rate limit buckets")
+				_ = rateLimitStore.DeleteIdleBuckets(idle)
+			case <-ctx.Done():
+				zap.L().Info("stoping idle rate limit buckets clean up task")
+				ticker.Stop()
+				return
+			}
+		}
+	}()
+}
@@ -0,0 +1,31 @@
+package task
+
+import (
+	"context"
+	"shop-api-go/internal/core/port"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+func StartDeletePublishedOutboxEventsTask(
+	ctx context.Context,
+	outboxRepository port.OutboxRepository,
+	interval time.Duration,
+	retention time.Duration,
+) {
+	ticker := time.NewTicker(interval)
+	go func() {
+		for {
+			select {
+			case <-ticker.C:
+				zap.L().Info("deleting published outbox events")
+				_ = outboxRepository.DeletePublishedEvents(retention)
+			case <-ctx.Done():
+				zap.L().Info("stoping published outbox events clean up task")
+				ticker.Stop()
+				return
+			}
+		}
+	}()
+}
@@ -0,0 +1,108 @@
+package task
+
+import (
+	"context"
+	"shop-api-go/internal/core/domain"
+	"shop-api-go/internal/core/port"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// WebhookWorkerPool claims the due webhook deliveries every poll interval and sends them with a fixed
+// number of workers.
+type WebhookWorkerPool struct {
+	webhookService port.WebhookService
+	policy         *domain.WebhookPolicy
+	cancel         context.CancelFunc
+	abort          context.CancelFunc
+	done           chan struct{}
+}
+
+// NewWebhookWorkerPool creates a new WebhookWorkerPool instance.
+func NewWebhookWorkerPool(webhookService port.WebhookService, policy *domain.WebhookPolicy) *WebhookWorkerPool {
+	return &WebhookWorkerPool{
+		webhookService: webhookService,
+		policy:         policy,
+	}
+}
+
+// Start starts the workers and the polling of due deliveries.
+func (p *WebhookWorkerPool) Start() {
+	claimCtx, cancel := context.WithCancel(context.Background())
+	deliverCtx, abort := context.WithCancel(context.Background())
+	p.cancel, p.abort = cancel, abort
+	p.done = make(chan struct{})
+
+	deliveries := make(chan domain.WebhookDelivery)
+	var wg sync.WaitGroup
+	for range p.policy.Workers {
+		wg.Go(func() {
+			for delivery := range deliveries {
+				if err := p.webhookService.Deliver(deliverCtx, &delivery); err != nil {
+					zap.L().Error("delivering webhook failed", zap.String("id", delivery.Id.String()), zap.Error(err))
+				}
+			}
+		})
+	}
+
+	ticker := time.NewTicker(p.policy.PollInterval)
+	go func() {
+		defer func() {
+			close(deliveries)
+			wg.Wait()
+			close(p.done)
+		}()
+
+		for {
+			select {
+			case <-ticker.C:
+				p.dispatch(claimCtx, deliveries)
+			case <-claimCtx.Done():
+				zap.L().Info("stoping webhook delivery workers")
+				ticker.Stop()
+				return
+			}
+		}
+	}()
+}
+
+// Stop stops claiming deliveries and waits until the workers sent the deliveries they started,
+// the deliveries still running when ctx is done are aborted.
+func (p *WebhookWorkerPool) Stop(ctx context.Context) error {
+	p.cancel()
+	defer p.abort()
+
+	select {
+	case <-p.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// dispatch claims due deliveries and hands them to the workers until none is left or ctx is done.
+//
+// Note: Deliveries claimed but not handed over when ctx is done are claimed again
+// after domain.WebhookDeliveryTimeout.
+func (p *WebhookWorkerPool) dispatch(ctx context.Context, deliveries chan<- domain.WebhookDelivery) {
+	for {
+		claimed, err := p.webhookService.ClaimDeliveries(ctx)
+		if err != nil {
+			zap.L().Error("claiming webhook deliveries failed", zap.Error(err))
+			return
+		}
+		if len(claimed) == 0 {
+			return
+		}
+
+		for _, delivery := range claimed {
+			select {
+			case deliveries <- delivery:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}
+}
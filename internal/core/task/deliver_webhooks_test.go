@@ -0,0 +1,119 @@
+package task
+
+import (
+	"context"
+	"shop-api-go/internal/core/domain"
+	"shop-api-go/internal/core/port/mock"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestWebhookWorkerPool(t *testing.T) {
+	policy := &domain.WebhookPolicy{Workers: 2, PollInterval: 10 * time.Millisecond, BatchSize: 10}
+	deliveries := []domain.WebhookDelivery{{Id: uuid.New()}, {Id: uuid.New()}, {Id: uuid.New()}}
+
+	t.Run("delivers claimed deliveries and stops", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		service := mock.NewMockWebhookService(ctrl)
+		var delivered atomic.Int32
+
+		service.
+			EXPECT().
+			ClaimDeliveries(gomock.Any()).
+			Return(deliveries, nil)
+		service.
+			EXPECT().
+			ClaimDeliveries(gomock.Any()).
+			Return([]domain.WebhookDelivery{}, nil).
+			AnyTimes()
+		service.
+			EXPECT().
+			Deliver(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(context.Context, *domain.WebhookDelivery) error {
+				delivered.Add(1)
+				return nil
+			}).
+			Times(len(deliveries))
+
+		pool := NewWebhookWorkerPool(service, policy)
+		pool.Start()
+		require.Eventually(t, func() bool { return delivered.Load() == int32(len(deliveries)) }, time.Second, time.Millisecond)
+		require.NoError(t, pool.Stop(context.Background()))
+	})
+
+	t.Run("stop waits for running deliveries", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		service := mock.NewMockWebhookService(ctrl)
+		started := make(chan struct{})
+		release := make(chan struct{})
+		var finished atomic.Bool
+
+		service.
+			EXPECT().
+			ClaimDeliveries(gomock.Any()).
+			Return(deliveries[:1], nil)
+		service.
+			EXPECT().
+			ClaimDeliveries(gomock.Any()).
+			Return([]domain.WebhookDelivery{}, nil).
+			AnyTimes()
+		service.
+			EXPECT().
+			Deliver(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(context.Context, *domain.WebhookDelivery) error {
+				close(started)
+				<-release
+				finished.Store(true)
+				return nil
+			})
+
+		pool := NewWebhookWorkerPool(service, policy)
+		pool.Start()
+		<-started
+		go func() {
+			time.Sleep(20 * time.Millisecond)
+			close(release)
+		}()
+		require.NoError(t, pool.Stop(context.Background()))
+		require.True(t, finished.Load())
+	})
+
+	t.Run("stop aborts running deliveries when ctx is done", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		service := mock.NewMockWebhookService(ctrl)
+		started := make(chan struct{})
+		aborted := make(chan struct{})
+
+		service.
+			EXPECT().
+			ClaimDeliveries(gomock.Any()).
+			Return(deliveries[:1], nil)
+		service.
+			EXPECT().
+			ClaimDeliveries(gomock.Any()).
+			Return([]domain.WebhookDelivery{}, nil).
+			AnyTimes()
+		service.
+			EXPECT().
+			Deliver(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, _ *domain.WebhookDelivery) error {
+				close(started)
+				<-ctx.Done()
+				close(aborted)
+				return ctx.Err()
+			})
+
+		pool := NewWebhookWorkerPool(service, policy)
+		pool.Start()
+		<-started
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+		require.ErrorIs(t, pool.Stop(ctx), context.DeadlineExceeded)
+		<-aborted
+	})
+}
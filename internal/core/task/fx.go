@@ -2,7 +2,11 @@ package task
 
 import (
 	"context"
+	"os"
+	"os/signal"
+	"shop-api-go/internal/core/domain"
 	"shop-api-go/internal/core/port"
+	"syscall"
 	"time"
 
 	"go.uber.org/fx"
@@ -21,4 +25,113 @@ var Module = fx.Module(
 			},
 		})
 	}),
+	fx.Invoke(func(lc fx.Lifecycle, repository port.LoginAttemptRepository, throttle *domain.LoginThrottle) {
+		bgCtx, cancel := context.WithCancel(context.Background())
+		StartDeleteExpiredLoginAttemptsTask(bgCtx, repository, time.Hour, throttle.Window)
+
+		lc.Append(fx.Hook{
+			OnStop: func(context.Context) error {
+				cancel()
+				return nil
+			},
+		})
+	}),
+	fx.Invoke(func(lc fx.Lifecycle, store port.RateLimitStore) {
+		bgCtx, cancel := context.WithCancel(context.Background())
+		StartDeleteIdleRateLimitBucketsTask(bgCtx, store, time.Hour, 24*time.Hour)
+
+		lc.Append(fx.Hook{
+			OnStop: func(context.Context) error {
+				cancel()
+				return nil
+			},
+		})
+	}),
+	fx.Invoke(func(lc fx.Lifecycle, repository port.IdentityRepository) {
+		bgCtx, cancel := context.WithCancel(context.Background())
+		StartDeleteExpiredOAuthStatesTask(bgCtx, repository, time.Hour)
+
+		lc.Append(fx.Hook{
+			OnStop: func(context.Context) error {
+				cancel()
+				return nil
+			},
+		})
+	}),
+	fx.Invoke(func(lc fx.Lifecycle, service port.DataRequestService) {
+		bgCtx, cancel := context.WithCancel(context.Background())
+		StartProcessDataRequestsTask(bgCtx, service, time.Minute)
+
+		lc.Append(fx.Hook{
+			OnStop: func(context.Context) error {
+				cancel()
+				return nil
+			},
+		})
+	}),
+	fx.Invoke(func(lc fx.Lifecycle, repository port.DataRequestRepository) {
+		bgCtx, cancel := context.WithCancel(context.Background())
+		StartDeleteExpiredDataExportsTask(bgCtx, repository, time.Hour)
+
+		lc.Append(fx.Hook{
+			OnStop: func(context.Context) error {
+				cancel()
+				return nil
+			},
+		})
+	}),
+	fx.Invoke(func(lc fx.Lifecycle, service port.OutboxService, policy *domain.OutboxPolicy) {
+		bgCtx, cancel := context.WithCancel(context.Background())
+		StartRelayOutboxEventsTask(bgCtx, service, policy.RelayInterval)
+
+		lc.Append(fx.Hook{
+			OnStop: func(context.Context) error {
+				cancel()
+				return nil
+			},
+		})
+	}),
+	fx.Invoke(func(lc fx.Lifecycle, repository port.OutboxRepository, policy *domain.OutboxPolicy) {
+		bgCtx, cancel := context.WithCancel(context.Background())
+		StartDeletePublishedOutboxEventsTask(bgCtx, repository, time.Hour, policy.Retention)
+
+		lc.Append(fx.Hook{
+			OnStop: func(context.Context) error {
+				cancel()
+				return nil
+			},
+		})
+	}),
+	fx.Invoke(func(lc fx.Lifecycle, service port.WebhookService, policy *domain.WebhookPolicy) {
+		pool := NewWebhookWorkerPool(service, policy)
+
+		lc.Append(fx.Hook{
+			OnStart: func(context.Context) error {
+				pool.Start()
+				return nil
+			},
+			OnStop: pool.Stop,
+		})
+	}),
+	fx.Invoke(func(lc fx.Lifecycle, service port.RuntimeSettingsService, policy *domain.RuntimeSettingsPolicy) {
+		bgCtx, cancel := context.WithCancel(context.Background())
+		signals := make(chan os.Signal, 1)
+
+		lc.Append(fx.Hook{
+			OnStart: func(ctx context.Context) error {
+				// The stored settings are applied before requests are served, invalid ones stop the startup.
+				if err := service.ReloadSettings(ctx); err != nil {
+					return err
+				}
+				signal.Notify(signals, syscall.SIGHUP)
+				StartReloadRuntimeSettingsTask(bgCtx, service, signals, policy.PollInterval)
+				return nil
+			},
+			OnStop: func(context.Context) error {
+				signal.Stop(signals)
+				cancel()
+				return nil
+			},
+		})
+	}),
 )
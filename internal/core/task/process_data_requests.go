@@ -0,0 +1,27 @@
+package task
+
+import (
+	"context"
+	"shop-api-go/internal/core/port"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+func StartProcessDataRequestsTask(ctx context.Context, dataRequestService port.DataRequestService, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	go func() {
+		for {
+			select {
+			case <-ticker.C:
+				if err := dataRequestService.ProcessDataRequests(ctx); err != nil {
+					zap.L().Error("processing data requests failed", zap.Error(err))
+				}
+			case <-ctx.Done():
+				zap.L().Info("stoping data request processing task")
+				ticker.Stop()
+				return
+			}
+		}
+	}()
+}
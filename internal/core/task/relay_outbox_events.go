@@ -0,0 +1,27 @@
+package task
+
+import (
+	"context"
+	"shop-api-go/internal/core/port"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+func StartRelayOutboxEventsTask(ctx context.Context, outboxService port.OutboxService, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	go func() {
+		for {
+			select {
+			case <-ticker.C:
+				if err := outboxService.RelayEvents(ctx); err != nil {
+					zap.L().Error("relaying outbox events failed", zap.Error(err))
+				}
+			case <-ctx.Done():
+				zap.L().Info("stoping outbox relay task")
+				ticker.Stop()
+				return
+			}
+		}
+	}()
+}
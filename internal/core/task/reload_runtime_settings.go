@@ -0,0 +1,49 @@
+package task
+
+import (
+	"context"
+	"os"
+	"shop-api-go/internal/core/port"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// StartReloadRuntimeSettingsTask reloads the stored runtime settings every interval and whenever a signal is received,
+// e.g. SIGHUP. A failed reload keeps the current settings, the same error is only logged once.
+func StartReloadRuntimeSettingsTask(
+	ctx context.Context,
+	runtimeSettingsService port.RuntimeSettingsService,
+	signals <-chan os.Signal,
+	interval time.Duration,
+) {
+	ticker := time.NewTicker(interval)
+	go func() {
+		var lastErr string
+		reload := func() {
+			err := runtimeSettingsService.ReloadSettings(ctx)
+			if err != nil && err.Error() != lastErr {
+				zap.L().Error("reloading runtime settings failed, keeping the current settings", zap.Error(err))
+			}
+			lastErr = ""
+			if err != nil {
+				lastErr = err.Error()
+			}
+		}
+
+		for {
+			select {
+			case <-ticker.C:
+				reload()
+			case signal := <-signals:
+				zap.L().Info("reloading runtime settings", zap.String("signal", signal.String()))
+				lastErr = ""
+				reload()
+			case <-ctx.Done():
+				zap.L().Info("stoping runtime settings reload task")
+				ticker.Stop()
+				return
+			}
+		}
+	}()
+}
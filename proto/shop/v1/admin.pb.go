@@ -0,0 +1,802 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.10
+// 	protoc        v5.29.3
+// source: shop/v1/admin.proto
+
+package shopv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type UserFilter struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            *string                `protobuf:"bytes,1,opt,name=id,proto3,oneof" json:"id,omitempty"`
+	Username      *string                `protobuf:"bytes,2,opt,name=username,proto3,oneof" json:"username,omitempty"`
+	Email         *string                `protobuf:"bytes,3,opt,name=email,proto3,oneof" json:"email,omitempty"`
+	Role          *string                `protobuf:"bytes,4,opt,name=role,proto3,oneof" json:"role,omitempty"`
+	Status        *string                `protobuf:"bytes,5,opt,name=status,proto3,oneof" json:"status,omitempty"`
+	CreatedFrom   *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=created_from,json=createdFrom,proto3" json:"created_from,omitempty"`
+	CreatedTo     *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=created_to,json=createdTo,proto3" json:"created_to,omitempty"`
+	UpdatedFrom   *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=updated_from,json=updatedFrom,proto3" json:"updated_from,omitempty"`
+	UpdatedTo     *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=updated_to,json=updatedTo,proto3" json:"updated_to,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UserFilter) Reset() {
+	*x = UserFilter{}
+	mi := &file_shop_v1_admin_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UserFilter) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UserFilter) ProtoMessage() {}
+
+func (x *UserFilter) ProtoReflect() protoreflect.Message {
+	mi := &file_shop_v1_admin_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UserFilter.ProtoReflect.Descriptor instead.
+func (*UserFilter) Descriptor() ([]byte, []int) {
+	return file_shop_v1_admin_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *UserFilter) GetId() string {
+	if x != nil && x.Id != nil {
+		return *x.Id
+	}
+	return ""
+}
+
+func (x *UserFilter) GetUsername() string {
+	if x != nil && x.Username != nil {
+		return *x.Username
+	}
+	return ""
+}
+
+func (x *UserFilter) GetEmail() string {
+	if x != nil && x.Email != nil {
+		return *x.Email
+	}
+	return ""
+}
+
+func (x *UserFilter) GetRole() string {
+	if x != nil && x.Role != nil {
+		return *x.Role
+	}
+	return ""
+}
+
+func (x *UserFilter) GetStatus() string {
+	if x != nil && x.Status != nil {
+		return *x.Status
+	}
+	return ""
+}
+
+func (x *UserFilter) GetCreatedFrom() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedFrom
+	}
+	return nil
+}
+
+func (x *UserFilter) GetCreatedTo() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedTo
+	}
+	return nil
+}
+
+func (x *UserFilter) GetUpdatedFrom() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedFrom
+	}
+	return nil
+}
+
+func (x *UserFilter) GetUpdatedTo() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedTo
+	}
+	return nil
+}
+
+type GetUsersRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Filter *UserFilter            `protobuf:"bytes,1,opt,name=filter,proto3" json:"filter,omitempty"`
+	// One of "id", "username", "email", "role", "status", "created_at" or "updated_at", defaults to "created_at".
+	Sort string `protobuf:"bytes,2,opt,name=sort,proto3" json:"sort,omitempty"`
+	// One of "asc" or "desc", defaults to "asc".
+	Order string `protobuf:"bytes,3,opt,name=order,proto3" json:"order,omitempty"`
+	Page  *int32 `protobuf:"varint,4,opt,name=page,proto3,oneof" json:"page,omitempty"`
+	// Cursor of the previous page, takes precedence over page.
+	Cursor        *string `protobuf:"bytes,5,opt,name=cursor,proto3,oneof" json:"cursor,omitempty"`
+	Limit         *int32  `protobuf:"varint,6,opt,name=limit,proto3,oneof" json:"limit,omitempty"`
+	Total         bool    `protobuf:"varint,7,opt,name=total,proto3" json:"total,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetUsersRequest) Reset() {
+	*x = GetUsersRequest{}
+	mi := &file_shop_v1_admin_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetUsersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetUsersRequest) ProtoMessage() {}
+
+func (x *GetUsersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_shop_v1_admin_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetUsersRequest.ProtoReflect.Descriptor instead.
+func (*GetUsersRequest) Descriptor() ([]byte, []int) {
+	return file_shop_v1_admin_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *GetUsersRequest) GetFilter() *UserFilter {
+	if x != nil {
+		return x.Filter
+	}
+	return nil
+}
+
+func (x *GetUsersRequest) GetSort() string {
+	if x != nil {
+		return x.Sort
+	}
+	return ""
+}
+
+func (x *GetUsersRequest) GetOrder() string {
+	if x != nil {
+		return x.Order
+	}
+	return ""
+}
+
+func (x *GetUsersRequest) GetPage() int32 {
+	if x != nil && x.Page != nil {
+		return *x.Page
+	}
+	return 0
+}
+
+func (x *GetUsersRequest) GetCursor() string {
+	if x != nil && x.Cursor != nil {
+		return *x.Cursor
+	}
+	return ""
+}
+
+func (x *GetUsersRequest) GetLimit() int32 {
+	if x != nil && x.Limit != nil {
+		return *x.Limit
+	}
+	return 0
+}
+
+func (x *GetUsersRequest) GetTotal() bool {
+	if x != nil {
+		return x.Total
+	}
+	return false
+}
+
+type GetUsersResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Users []*User                `protobuf:"bytes,1,rep,name=users,proto3" json:"users,omitempty"`
+	// Cursor of the next page, unset when there are no more users.
+	Cursor *string `protobuf:"bytes,2,opt,name=cursor,proto3,oneof" json:"cursor,omitempty"`
+	// Number of users matching the filter, only set if requested.
+	Total         *int64 `protobuf:"varint,3,opt,name=total,proto3,oneof" json:"total,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetUsersResponse) Reset() {
+	*x = GetUsersResponse{}
+	mi := &file_shop_v1_admin_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetUsersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetUsersResponse) ProtoMessage() {}
+
+func (x *GetUsersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_shop_v1_admin_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetUsersResponse.ProtoReflect.Descriptor instead.
+func (*GetUsersResponse) Descriptor() ([]byte, []int) {
+	return file_shop_v1_admin_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *GetUsersResponse) GetUsers() []*User {
+	if x != nil {
+		return x.Users
+	}
+	return nil
+}
+
+func (x *GetUsersResponse) GetCursor() string {
+	if x != nil && x.Cursor != nil {
+		return *x.Cursor
+	}
+	return ""
+}
+
+func (x *GetUsersResponse) GetTotal() int64 {
+	if x != nil && x.Total != nil {
+		return *x.Total
+	}
+	return 0
+}
+
+type CreateUserRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Email         string                 `protobuf:"bytes,1,opt,name=email,proto3" json:"email,omitempty"`
+	Username      string                 `protobuf:"bytes,2,opt,name=username,proto3" json:"username,omitempty"`
+	Password      string                 `protobuf:"bytes,3,opt,name=password,proto3" json:"password,omitempty"`
+	Role          string                 `protobuf:"bytes,4,opt,name=role,proto3" json:"role,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateUserRequest) Reset() {
+	*x = CreateUserRequest{}
+	mi := &file_shop_v1_admin_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateUserRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateUserRequest) ProtoMessage() {}
+
+func (x *CreateUserRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_shop_v1_admin_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateUserRequest.ProtoReflect.Descriptor instead.
+func (*CreateUserRequest) Descriptor() ([]byte, []int) {
+	return file_shop_v1_admin_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *CreateUserRequest) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *CreateUserRequest) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *CreateUserRequest) GetPassword() string {
+	if x != nil {
+		return x.Password
+	}
+	return ""
+}
+
+func (x *CreateUserRequest) GetRole() string {
+	if x != nil {
+		return x.Role
+	}
+	return ""
+}
+
+type UpdateUserRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Username      *string                `protobuf:"bytes,2,opt,name=username,proto3,oneof" json:"username,omitempty"`
+	Email         *string                `protobuf:"bytes,3,opt,name=email,proto3,oneof" json:"email,omitempty"`
+	Password      *string                `protobuf:"bytes,4,opt,name=password,proto3,oneof" json:"password,omitempty"`
+	Role          *string                `protobuf:"bytes,5,opt,name=role,proto3,oneof" json:"role,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateUserRequest) Reset() {
+	*x = UpdateUserRequest{}
+	mi := &file_shop_v1_admin_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateUserRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateUserRequest) ProtoMessage() {}
+
+func (x *UpdateUserRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_shop_v1_admin_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateUserRequest.ProtoReflect.Descriptor instead.
+func (*UpdateUserRequest) Descriptor() ([]byte, []int) {
+	return file_shop_v1_admin_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *UpdateUserRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *UpdateUserRequest) GetUsername() string {
+	if x != nil && x.Username != nil {
+		return *x.Username
+	}
+	return ""
+}
+
+func (x *UpdateUserRequest) GetEmail() string {
+	if x != nil && x.Email != nil {
+		return *x.Email
+	}
+	return ""
+}
+
+func (x *UpdateUserRequest) GetPassword() string {
+	if x != nil && x.Password != nil {
+		return *x.Password
+	}
+	return ""
+}
+
+func (x *UpdateUserRequest) GetRole() string {
+	if x != nil && x.Role != nil {
+		return *x.Role
+	}
+	return ""
+}
+
+type UserIdRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UserIdRequest) Reset() {
+	*x = UserIdRequest{}
+	mi := &file_shop_v1_admin_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UserIdRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UserIdRequest) ProtoMessage() {}
+
+func (x *UserIdRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_shop_v1_admin_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UserIdRequest.ProtoReflect.Descriptor instead.
+func (*UserIdRequest) Descriptor() ([]byte, []int) {
+	return file_shop_v1_admin_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *UserIdRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type ImpersonationToken struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AccessToken   string                 `protobuf:"bytes,1,opt,name=access_token,json=accessToken,proto3" json:"access_token,omitempty"`
+	ExpiresAt     *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImpersonationToken) Reset() {
+	*x = ImpersonationToken{}
+	mi := &file_shop_v1_admin_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImpersonationToken) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImpersonationToken) ProtoMessage() {}
+
+func (x *ImpersonationToken) ProtoReflect() protoreflect.Message {
+	mi := &file_shop_v1_admin_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImpersonationToken.ProtoReflect.Descriptor instead.
+func (*ImpersonationToken) Descriptor() ([]byte, []int) {
+	return file_shop_v1_admin_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ImpersonationToken) GetAccessToken() string {
+	if x != nil {
+		return x.AccessToken
+	}
+	return ""
+}
+
+func (x *ImpersonationToken) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+type RoleMFARequirement struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Role          string                 `protobuf:"bytes,1,opt,name=role,proto3" json:"role,omitempty"`
+	Required      bool                   `protobuf:"varint,2,opt,name=required,proto3" json:"required,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RoleMFARequirement) Reset() {
+	*x = RoleMFARequirement{}
+	mi := &file_shop_v1_admin_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RoleMFARequirement) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RoleMFARequirement) ProtoMessage() {}
+
+func (x *RoleMFARequirement) ProtoReflect() protoreflect.Message {
+	mi := &file_shop_v1_admin_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RoleMFARequirement.ProtoReflect.Descriptor instead.
+func (*RoleMFARequirement) Descriptor() ([]byte, []int) {
+	return file_shop_v1_admin_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *RoleMFARequirement) GetRole() string {
+	if x != nil {
+		return x.Role
+	}
+	return ""
+}
+
+func (x *RoleMFARequirement) GetRequired() bool {
+	if x != nil {
+		return x.Required
+	}
+	return false
+}
+
+type GetMFARequirementsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Requirements  []*RoleMFARequirement  `protobuf:"bytes,1,rep,name=requirements,proto3" json:"requirements,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetMFARequirementsResponse) Reset() {
+	*x = GetMFARequirementsResponse{}
+	mi := &file_shop_v1_admin_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetMFARequirementsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetMFARequirementsResponse) ProtoMessage() {}
+
+func (x *GetMFARequirementsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_shop_v1_admin_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetMFARequirementsResponse.ProtoReflect.Descriptor instead.
+func (*GetMFARequirementsResponse) Descriptor() ([]byte, []int) {
+	return file_shop_v1_admin_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *GetMFARequirementsResponse) GetRequirements() []*RoleMFARequirement {
+	if x != nil {
+		return x.Requirements
+	}
+	return nil
+}
+
+var File_shop_v1_admin_proto protoreflect.FileDescriptor
+
+const file_shop_v1_admin_proto_rawDesc = "" +
+	"\n" +
+	"\x13shop/v1/admin.proto\x12\ashop.v1\x1a\x1bgoogle/protobuf/empty.proto\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x12shop/v1/user.proto\"\xb9\x03\n" +
+	"\n" +
+	"UserFilter\x12\x13\n" +
+	"\x02id\x18\x01 \x01(\tH\x00R\x02id\x88\x01\x01\x12\x1f\n" +
+	"\busername\x18\x02 \x01(\tH\x01R\busername\x88\x01\x01\x12\x19\n" +
+	"\x05email\x18\x03 \x01(\tH\x02R\x05email\x88\x01\x01\x12\x17\n" +
+	"\x04role\x18\x04 \x01(\tH\x03R\x04role\x88\x01\x01\x12\x1b\n" +
+	"\x06status\x18\x05 \x01(\tH\x04R\x06status\x88\x01\x01\x12=\n" +
+	"\fcreated_from\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\vcreatedFrom\x129\n" +
+	"\n" +
+	"created_to\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedTo\x12=\n" +
+	"\fupdated_from\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\vupdatedFrom\x129\n" +
+	"\n" +
+	"updated_to\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedToB\x05\n" +
+	"\x03_idB\v\n" +
+	"\t_usernameB\b\n" +
+	"\x06_emailB\a\n" +
+	"\x05_roleB\t\n" +
+	"\a_status\"\xed\x01\n" +
+	"\x0fGetUsersRequest\x12+\n" +
+	"\x06filter\x18\x01 \x01(\v2\x13.shop.v1.UserFilterR\x06filter\x12\x12\n" +
+	"\x04sort\x18\x02 \x01(\tR\x04sort\x12\x14\n" +
+	"\x05order\x18\x03 \x01(\tR\x05order\x12\x17\n" +
+	"\x04page\x18\x04 \x01(\x05H\x00R\x04page\x88\x01\x01\x12\x1b\n" +
+	"\x06cursor\x18\x05 \x01(\tH\x01R\x06cursor\x88\x01\x01\x12\x19\n" +
+	"\x05limit\x18\x06 \x01(\x05H\x02R\x05limit\x88\x01\x01\x12\x14\n" +
+	"\x05total\x18\a \x01(\bR\x05totalB\a\n" +
+	"\x05_pageB\t\n" +
+	"\a_cursorB\b\n" +
+	"\x06_limit\"\x84\x01\n" +
+	"\x10GetUsersResponse\x12#\n" +
+	"\x05users\x18\x01 \x03(\v2\r.shop.v1.UserR\x05users\x12\x1b\n" +
+	"\x06cursor\x18\x02 \x01(\tH\x00R\x06cursor\x88\x01\x01\x12\x19\n" +
+	"\x05total\x18\x03 \x01(\x03H\x01R\x05total\x88\x01\x01B\t\n" +
+	"\a_cursorB\b\n" +
+	"\x06_total\"u\n" +
+	"\x11CreateUserRequest\x12\x14\n" +
+	"\x05email\x18\x01 \x01(\tR\x05email\x12\x1a\n" +
+	"\busername\x18\x02 \x01(\tR\busername\x12\x1a\n" +
+	"\bpassword\x18\x03 \x01(\tR\bpassword\x12\x12\n" +
+	"\x04role\x18\x04 \x01(\tR\x04role\"\xc6\x01\n" +
+	"\x11UpdateUserRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1f\n" +
+	"\busername\x18\x02 \x01(\tH\x00R\busername\x88\x01\x01\x12\x19\n" +
+	"\x05email\x18\x03 \x01(\tH\x01R\x05email\x88\x01\x01\x12\x1f\n" +
+	"\bpassword\x18\x04 \x01(\tH\x02R\bpassword\x88\x01\x01\x12\x17\n" +
+	"\x04role\x18\x05 \x01(\tH\x03R\x04role\x88\x01\x01B\v\n" +
+	"\t_usernameB\b\n" +
+	"\x06_emailB\v\n" +
+	"\t_passwordB\a\n" +
+	"\x05_role\"\x1f\n" +
+	"\rUserIdRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"r\n" +
+	"\x12ImpersonationToken\x12!\n" +
+	"\faccess_token\x18\x01 \x01(\tR\vaccessToken\x129\n" +
+	"\n" +
+	"expires_at\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\"D\n" +
+	"\x12RoleMFARequirement\x12\x12\n" +
+	"\x04role\x18\x01 \x01(\tR\x04role\x12\x1a\n" +
+	"\brequired\x18\x02 \x01(\bR\brequired\"]\n" +
+	"\x1aGetMFARequirementsResponse\x12?\n" +
+	"\frequirements\x18\x01 \x03(\v2\x1b.shop.v1.RoleMFARequirementR\frequirements2\xf4\x05\n" +
+	"\fAdminService\x12?\n" +
+	"\bGetUsers\x12\x18.shop.v1.GetUsersRequest\x1a\x19.shop.v1.GetUsersResponse\x12@\n" +
+	"\n" +
+	"CreateUser\x12\x1a.shop.v1.CreateUserRequest\x1a\x16.google.protobuf.Empty\x12@\n" +
+	"\n" +
+	"UpdateUser\x12\x1a.shop.v1.UpdateUserRequest\x1a\x16.google.protobuf.Empty\x12=\n" +
+	"\vSuspendUser\x12\x16.shop.v1.UserIdRequest\x1a\x16.google.protobuf.Empty\x12@\n" +
+	"\x0eReactivateUser\x12\x16.shop.v1.UserIdRequest\x1a\x16.google.protobuf.Empty\x12<\n" +
+	"\n" +
+	"DeleteUser\x12\x16.shop.v1.UserIdRequest\x1a\x16.google.protobuf.Empty\x12=\n" +
+	"\vRestoreUser\x12\x16.shop.v1.UserIdRequest\x1a\x16.google.protobuf.Empty\x12<\n" +
+	"\n" +
+	"UnlockUser\x12\x16.shop.v1.UserIdRequest\x1a\x16.google.protobuf.Empty\x12F\n" +
+	"\x0fImpersonateUser\x12\x16.shop.v1.UserIdRequest\x1a\x1b.shop.v1.ImpersonationToken\x12Q\n" +
+	"\x12GetMFARequirements\x12\x16.google.protobuf.Empty\x1a#.shop.v1.GetMFARequirementsResponse\x12H\n" +
+	"\x11SetMFARequirement\x12\x1b.shop.v1.RoleMFARequirement\x1a\x16.google.protobuf.EmptyB\"Z shop-api-go/proto/shop/v1;shopv1b\x06proto3"
+
+var (
+	file_shop_v1_admin_proto_rawDescOnce sync.Once
+	file_shop_v1_admin_proto_rawDescData []byte
+)
+
+func file_shop_v1_admin_proto_rawDescGZIP() []byte {
+	file_shop_v1_admin_proto_rawDescOnce.Do(func() {
+		file_shop_v1_admin_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_shop_v1_admin_proto_rawDesc), len(file_shop_v1_admin_proto_rawDesc)))
+	})
+	return file_shop_v1_admin_proto_rawDescData
+}
+
+var file_shop_v1_admin_proto_msgTypes = make([]protoimpl.MessageInfo, 9)
+var file_shop_v1_admin_proto_goTypes = []any{
+	(*UserFilter)(nil),                 // 0: shop.v1.UserFilter
+	(*GetUsersRequest)(nil),            // 1: shop.v1.GetUsersRequest
+	(*GetUsersResponse)(nil),           // 2: shop.v1.GetUsersResponse
+	(*CreateUserRequest)(nil),          // 3: shop.v1.CreateUserRequest
+	(*UpdateUserRequest)(nil),          // 4: shop.v1.UpdateUserRequest
+	(*UserIdRequest)(nil),              // 5: shop.v1.UserIdRequest
+	(*ImpersonationToken)(nil),         // 6: shop.v1.ImpersonationToken
+	(*RoleMFARequirement)(nil),         // 7: shop.v1.RoleMFARequirement
+	(*GetMFARequirementsResponse)(nil), // 8: shop.v1.GetMFARequirementsResponse
+	(*timestamppb.Timestamp)(nil),      // 9: google.protobuf.Timestamp
+	(*User)(nil),                       // 10: shop.v1.User
+	(*emptypb.Empty)(nil),              // 11: google.protobuf.Empty
+}
+var file_shop_v1_admin_proto_depIdxs = []int32{
+	9,  // 0: shop.v1.UserFilter.created_from:type_name -> google.protobuf.Timestamp
+	9,  // 1: shop.v1.UserFilter.created_to:type_name -> google.protobuf.Timestamp
+	9,  // 2: shop.v1.UserFilter.updated_from:type_name -> google.protobuf.Timestamp
+	9,  // 3: shop.v1.UserFilter.updated_to:type_name -> google.protobuf.Timestamp
+	0,  // 4: shop.v1.GetUsersRequest.filter:type_name -> shop.v1.UserFilter
+	10, // 5: shop.v1.GetUsersResponse.users:type_name -> shop.v1.User
+	9,  // 6: shop.v1.ImpersonationToken.expires_at:type_name -> google.protobuf.Timestamp
+	7,  // 7: shop.v1.GetMFARequirementsResponse.requirements:type_name -> shop.v1.RoleMFARequirement
+	1,  // 8: shop.v1.AdminService.GetUsers:input_type -> shop.v1.GetUsersRequest
+	3,  // 9: shop.v1.AdminService.CreateUser:input_type -> shop.v1.CreateUserRequest
+	4,  // 10: shop.v1.AdminService.UpdateUser:input_type -> shop.v1.UpdateUserRequest
+	5,  // 11: shop.v1.AdminService.SuspendUser:input_type -> shop.v1.UserIdRequest
+	5,  // 12: shop.v1.AdminService.ReactivateUser:input_type -> shop.v1.UserIdRequest
+	5,  // 13: shop.v1.AdminService.DeleteUser:input_type -> shop.v1.UserIdRequest
+	5,  // 14: shop.v1.AdminService.RestoreUser:input_type -> shop.v1.UserIdRequest
+	5,  // 15: shop.v1.AdminService.UnlockUser:input_type -> shop.v1.UserIdRequest
+	5,  // 16: shop.v1.AdminService.ImpersonateUser:input_type -> shop.v1.UserIdRequest
+	11, // 17: shop.v1.AdminService.GetMFARequirements:input_type -> google.protobuf.Empty
+	7,  // 18: shop.v1.AdminService.SetMFARequirement:input_type -> shop.v1.RoleMFARequirement
+	2,  // 19: shop.v1.AdminService.GetUsers:output_type -> shop.v1.GetUsersResponse
+	11, // 20: shop.v1.AdminService.CreateUser:output_type -> google.protobuf.Empty
+	11, // 21: shop.v1.AdminService.UpdateUser:output_type -> google.protobuf.Empty
+	11, // 22: shop.v1.AdminService.SuspendUser:output_type -> google.protobuf.Empty
+	11, // 23: shop.v1.AdminService.ReactivateUser:output_type -> google.protobuf.Empty
+	11, // 24: shop.v1.AdminService.DeleteUser:output_type -> google.protobuf.Empty
+	11, // 25: shop.v1.AdminService.RestoreUser:output_type -> google.protobuf.Empty
+	11, // 26: shop.v1.AdminService.UnlockUser:output_type -> google.protobuf.Empty
+	6,  // 27: shop.v1.AdminService.ImpersonateUser:output_type -> shop.v1.ImpersonationToken
+	8,  // 28: shop.v1.AdminService.GetMFARequirements:output_type -> shop.v1.GetMFARequirementsResponse
+	11, // 29: shop.v1.AdminService.SetMFARequirement:output_type -> google.protobuf.Empty
+	19, // [19:30] is the sub-list for method output_type
+	8,  // [8:19] is the sub-list for method input_type
+	8,  // [8:8] is the sub-list for extension type_name
+	8,  // [8:8] is the sub-list for extension extendee
+	0,  // [0:8] is the sub-list for field type_name
+}
+
+func init() { file_shop_v1_admin_proto_init() }
+func file_shop_v1_admin_proto_init() {
+	if File_shop_v1_admin_proto != nil {
+		return
+	}
+	file_shop_v1_user_proto_init()
+	file_shop_v1_admin_proto_msgTypes[0].OneofWrappers = []any{}
+	file_shop_v1_admin_proto_msgTypes[1].OneofWrappers = []any{}
+	file_shop_v1_admin_proto_msgTypes[2].OneofWrappers = []any{}
+	file_shop_v1_admin_proto_msgTypes[4].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_shop_v1_admin_proto_rawDesc), len(file_shop_v1_admin_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   9,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_shop_v1_admin_proto_goTypes,
+		DependencyIndexes: file_shop_v1_admin_proto_depIdxs,
+		MessageInfos:      file_shop_v1_admin_proto_msgTypes,
+	}.Build()
+	File_shop_v1_admin_proto = out.File
+	file_shop_v1_admin_proto_goTypes = nil
+	file_shop_v1_admin_proto_depIdxs = nil
+}
@@ -0,0 +1,375 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.10
+// 	protoc        v5.29.3
+// source: shop/v1/auth.proto
+
+package shopv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type LoginRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Username      string                 `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
+	Password      string                 `protobuf:"bytes,2,opt,name=password,proto3" json:"password,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LoginRequest) Reset() {
+	*x = LoginRequest{}
+	mi := &file_shop_v1_auth_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LoginRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LoginRequest) ProtoMessage() {}
+
+func (x *LoginRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_shop_v1_auth_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LoginRequest.ProtoReflect.Descriptor instead.
+func (*LoginRequest) Descriptor() ([]byte, []int) {
+	return file_shop_v1_auth_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *LoginRequest) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *LoginRequest) GetPassword() string {
+	if x != nil {
+		return x.Password
+	}
+	return ""
+}
+
+type LoginResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Result:
+	//
+	//	*LoginResponse_Tokens
+	//	*LoginResponse_MfaToken
+	Result        isLoginResponse_Result `protobuf_oneof:"result"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LoginResponse) Reset() {
+	*x = LoginResponse{}
+	mi := &file_shop_v1_auth_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LoginResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LoginResponse) ProtoMessage() {}
+
+func (x *LoginResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_shop_v1_auth_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LoginResponse.ProtoReflect.Descriptor instead.
+func (*LoginResponse) Descriptor() ([]byte, []int) {
+	return file_shop_v1_auth_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *LoginResponse) GetResult() isLoginResponse_Result {
+	if x != nil {
+		return x.Result
+	}
+	return nil
+}
+
+func (x *LoginResponse) GetTokens() *Tokens {
+	if x != nil {
+		if x, ok := x.Result.(*LoginResponse_Tokens); ok {
+			return x.Tokens
+		}
+	}
+	return nil
+}
+
+func (x *LoginResponse) GetMfaToken() string {
+	if x != nil {
+		if x, ok := x.Result.(*LoginResponse_MfaToken); ok {
+			return x.MfaToken
+		}
+	}
+	return ""
+}
+
+type isLoginResponse_Result interface {
+	isLoginResponse_Result()
+}
+
+type LoginResponse_Tokens struct {
+	Tokens *Tokens `protobuf:"bytes,1,opt,name=tokens,proto3,oneof"`
+}
+
+type LoginResponse_MfaToken struct {
+	// Token to send to VerifyMFA.
+	MfaToken string `protobuf:"bytes,2,opt,name=mfa_token,json=mfaToken,proto3,oneof"`
+}
+
+func (*LoginResponse_Tokens) isLoginResponse_Result() {}
+
+func (*LoginResponse_MfaToken) isLoginResponse_Result() {}
+
+type Tokens struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AccessToken   string                 `protobuf:"bytes,1,opt,name=access_token,json=accessToken,proto3" json:"access_token,omitempty"`
+	RefreshToken  string                 `protobuf:"bytes,2,opt,name=refresh_token,json=refreshToken,proto3" json:"refresh_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Tokens) Reset() {
+	*x = Tokens{}
+	mi := &file_shop_v1_auth_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Tokens) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Tokens) ProtoMessage() {}
+
+func (x *Tokens) ProtoReflect() protoreflect.Message {
+	mi := &file_shop_v1_auth_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Tokens.ProtoReflect.Descriptor instead.
+func (*Tokens) Descriptor() ([]byte, []int) {
+	return file_shop_v1_auth_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Tokens) GetAccessToken() string {
+	if x != nil {
+		return x.AccessToken
+	}
+	return ""
+}
+
+func (x *Tokens) GetRefreshToken() string {
+	if x != nil {
+		return x.RefreshToken
+	}
+	return ""
+}
+
+type VerifyMFARequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Code          string                 `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VerifyMFARequest) Reset() {
+	*x = VerifyMFARequest{}
+	mi := &file_shop_v1_auth_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VerifyMFARequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerifyMFARequest) ProtoMessage() {}
+
+func (x *VerifyMFARequest) ProtoReflect() protoreflect.Message {
+	mi := &file_shop_v1_auth_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VerifyMFARequest.ProtoReflect.Descriptor instead.
+func (*VerifyMFARequest) Descriptor() ([]byte, []int) {
+	return file_shop_v1_auth_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *VerifyMFARequest) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+type RefreshSessionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RefreshSessionRequest) Reset() {
+	*x = RefreshSessionRequest{}
+	mi := &file_shop_v1_auth_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RefreshSessionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RefreshSessionRequest) ProtoMessage() {}
+
+func (x *RefreshSessionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_shop_v1_auth_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RefreshSessionRequest.ProtoReflect.Descriptor instead.
+func (*RefreshSessionRequest) Descriptor() ([]byte, []int) {
+	return file_shop_v1_auth_proto_rawDescGZIP(), []int{4}
+}
+
+var File_shop_v1_auth_proto protoreflect.FileDescriptor
+
+const file_shop_v1_auth_proto_rawDesc = "" +
+	"\n" +
+	"\x12shop/v1/auth.proto\x12\ashop.v1\"F\n" +
+	"\fLoginRequest\x12\x1a\n" +
+	"\busername\x18\x01 \x01(\tR\busername\x12\x1a\n" +
+	"\bpassword\x18\x02 \x01(\tR\bpassword\"c\n" +
+	"\rLoginResponse\x12)\n" +
+	"\x06tokens\x18\x01 \x01(\v2\x0f.shop.v1.TokensH\x00R\x06tokens\x12\x1d\n" +
+	"\tmfa_token\x18\x02 \x01(\tH\x00R\bmfaTokenB\b\n" +
+	"\x06result\"P\n" +
+	"\x06Tokens\x12!\n" +
+	"\faccess_token\x18\x01 \x01(\tR\vaccessToken\x12#\n" +
+	"\rrefresh_token\x18\x02 \x01(\tR\frefreshToken\"&\n" +
+	"\x10VerifyMFARequest\x12\x12\n" +
+	"\x04code\x18\x01 \x01(\tR\x04code\"\x17\n" +
+	"\x15RefreshSessionRequest2\xc1\x01\n" +
+	"\vAuthService\x126\n" +
+	"\x05Login\x12\x15.shop.v1.LoginRequest\x1a\x16.shop.v1.LoginResponse\x127\n" +
+	"\tVerifyMFA\x12\x19.shop.v1.VerifyMFARequest\x1a\x0f.shop.v1.Tokens\x12A\n" +
+	"\x0eRefreshSession\x12\x1e.shop.v1.RefreshSessionRequest\x1a\x0f.shop.v1.TokensB\"Z shop-api-go/proto/shop/v1;shopv1b\x06proto3"
+
+var (
+	file_shop_v1_auth_proto_rawDescOnce sync.Once
+	file_shop_v1_auth_proto_rawDescData []byte
+)
+
+func file_shop_v1_auth_proto_rawDescGZIP() []byte {
+	file_shop_v1_auth_proto_rawDescOnce.Do(func() {
+		file_shop_v1_auth_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_shop_v1_auth_proto_rawDesc), len(file_shop_v1_auth_proto_rawDesc)))
+	})
+	return file_shop_v1_auth_proto_rawDescData
+}
+
+var file_shop_v1_auth_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
+var file_shop_v1_auth_proto_goTypes = []any{
+	(*LoginRequest)(nil),          // 0: shop.v1.LoginRequest
+	(*LoginResponse)(nil),         // 1: shop.v1.LoginResponse
+	(*Tokens)(nil),                // 2: shop.v1.Tokens
+	(*VerifyMFARequest)(nil),      // 3: shop.v1.VerifyMFARequest
+	(*RefreshSessionRequest)(nil), // 4: shop.v1.RefreshSessionRequest
+}
+var file_shop_v1_auth_proto_depIdxs = []int32{
+	2, // 0: shop.v1.LoginResponse.tokens:type_name -> shop.v1.Tokens
+	0, // 1: shop.v1.AuthService.Login:input_type -> shop.v1.LoginRequest
+	3, // 2: shop.v1.AuthService.VerifyMFA:input_type -> shop.v1.VerifyMFARequest
+	4, // 3: shop.v1.AuthService.RefreshSession:input_type -> shop.v1.RefreshSessionRequest
+	1, // 4: shop.v1.AuthService.Login:output_type -> shop.v1.LoginResponse
+	2, // 5: shop.v1.AuthService.VerifyMFA:output_type -> shop.v1.Tokens
+	2, // 6: shop.v1.AuthService.RefreshSession:output_type -> shop.v1.Tokens
+	4, // [4:7] is the sub-list for method output_type
+	1, // [1:4] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
+}
+
+func init() { file_shop_v1_auth_proto_init() }
+func file_shop_v1_auth_proto_init() {
+	if File_shop_v1_auth_proto != nil {
+		return
+	}
+	file_shop_v1_auth_proto_msgTypes[1].OneofWrappers = []any{
+		(*LoginResponse_Tokens)(nil),
+		(*LoginResponse_MfaToken)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_shop_v1_auth_proto_rawDesc), len(file_shop_v1_auth_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   5,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_shop_v1_auth_proto_goTypes,
+		DependencyIndexes: file_shop_v1_auth_proto_depIdxs,
+		MessageInfos:      file_shop_v1_auth_proto_msgTypes,
+	}.Build()
+	File_shop_v1_auth_proto = out.File
+	file_shop_v1_auth_proto_goTypes = nil
+	file_shop_v1_auth_proto_depIdxs = nil
+}
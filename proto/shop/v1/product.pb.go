@@ -0,0 +1,638 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.10
+// 	protoc        v5.29.3
+// source: shop/v1/product.proto
+
+package shopv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Product struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Id          string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name        string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Description string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	// Decimal price, e.g. "89.99".
+	Price string `protobuf:"bytes,4,opt,name=price,proto3" json:"price,omitempty"`
+	// Decimal rating from 0 to 5.
+	Rating        string                 `protobuf:"bytes,5,opt,name=rating,proto3" json:"rating,omitempty"`
+	Count         int32                  `protobuf:"varint,6,opt,name=count,proto3" json:"count,omitempty"`
+	ImageUrl      string                 `protobuf:"bytes,7,opt,name=image_url,json=imageUrl,proto3" json:"image_url,omitempty"`
+	Subcategories []*Subcategory         `protobuf:"bytes,8,rep,name=subcategories,proto3" json:"subcategories,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Product) Reset() {
+	*x = Product{}
+	mi := &file_shop_v1_product_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Product) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Product) ProtoMessage() {}
+
+func (x *Product) ProtoReflect() protoreflect.Message {
+	mi := &file_shop_v1_product_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Product.ProtoReflect.Descriptor instead.
+func (*Product) Descriptor() ([]byte, []int) {
+	return file_shop_v1_product_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Product) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Product) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Product) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *Product) GetPrice() string {
+	if x != nil {
+		return x.Price
+	}
+	return ""
+}
+
+func (x *Product) GetRating() string {
+	if x != nil {
+		return x.Rating
+	}
+	return ""
+}
+
+func (x *Product) GetCount() int32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *Product) GetImageUrl() string {
+	if x != nil {
+		return x.ImageUrl
+	}
+	return ""
+}
+
+func (x *Product) GetSubcategories() []*Subcategory {
+	if x != nil {
+		return x.Subcategories
+	}
+	return nil
+}
+
+func (x *Product) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Product) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+type CategorySection struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Categories    []*Category            `protobuf:"bytes,3,rep,name=categories,proto3" json:"categories,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CategorySection) Reset() {
+	*x = CategorySection{}
+	mi := &file_shop_v1_product_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CategorySection) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CategorySection) ProtoMessage() {}
+
+func (x *CategorySection) ProtoReflect() protoreflect.Message {
+	mi := &file_shop_v1_product_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CategorySection.ProtoReflect.Descriptor instead.
+func (*CategorySection) Descriptor() ([]byte, []int) {
+	return file_shop_v1_product_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *CategorySection) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *CategorySection) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CategorySection) GetCategories() []*Category {
+	if x != nil {
+		return x.Categories
+	}
+	return nil
+}
+
+type Category struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Subcategories []*Subcategory         `protobuf:"bytes,3,rep,name=subcategories,proto3" json:"subcategories,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Category) Reset() {
+	*x = Category{}
+	mi := &file_shop_v1_product_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Category) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Category) ProtoMessage() {}
+
+func (x *Category) ProtoReflect() protoreflect.Message {
+	mi := &file_shop_v1_product_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Category.ProtoReflect.Descriptor instead.
+func (*Category) Descriptor() ([]byte, []int) {
+	return file_shop_v1_product_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Category) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Category) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Category) GetSubcategories() []*Subcategory {
+	if x != nil {
+		return x.Subcategories
+	}
+	return nil
+}
+
+type Subcategory struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	CategoryId    *string                `protobuf:"bytes,3,opt,name=category_id,json=categoryId,proto3,oneof" json:"category_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Subcategory) Reset() {
+	*x = Subcategory{}
+	mi := &file_shop_v1_product_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Subcategory) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Subcategory) ProtoMessage() {}
+
+func (x *Subcategory) ProtoReflect() protoreflect.Message {
+	mi := &file_shop_v1_product_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Subcategory.ProtoReflect.Descriptor instead.
+func (*Subcategory) Descriptor() ([]byte, []int) {
+	return file_shop_v1_product_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *Subcategory) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Subcategory) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Subcategory) GetCategoryId() string {
+	if x != nil && x.CategoryId != nil {
+		return *x.CategoryId
+	}
+	return ""
+}
+
+type GetProductsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SubcategoryId *string                `protobuf:"bytes,1,opt,name=subcategory_id,json=subcategoryId,proto3,oneof" json:"subcategory_id,omitempty"`
+	Page          int32                  `protobuf:"varint,2,opt,name=page,proto3" json:"page,omitempty"`
+	Limit         int32                  `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetProductsRequest) Reset() {
+	*x = GetProductsRequest{}
+	mi := &file_shop_v1_product_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetProductsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetProductsRequest) ProtoMessage() {}
+
+func (x *GetProductsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_shop_v1_product_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetProductsRequest.ProtoReflect.Descriptor instead.
+func (*GetProductsRequest) Descriptor() ([]byte, []int) {
+	return file_shop_v1_product_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *GetProductsRequest) GetSubcategoryId() string {
+	if x != nil && x.SubcategoryId != nil {
+		return *x.SubcategoryId
+	}
+	return ""
+}
+
+func (x *GetProductsRequest) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *GetProductsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type GetProductsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Products      []*Product             `protobuf:"bytes,1,rep,name=products,proto3" json:"products,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetProductsResponse) Reset() {
+	*x = GetProductsResponse{}
+	mi := &file_shop_v1_product_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetProductsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetProductsResponse) ProtoMessage() {}
+
+func (x *GetProductsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_shop_v1_product_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetProductsResponse.ProtoReflect.Descriptor instead.
+func (*GetProductsResponse) Descriptor() ([]byte, []int) {
+	return file_shop_v1_product_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *GetProductsResponse) GetProducts() []*Product {
+	if x != nil {
+		return x.Products
+	}
+	return nil
+}
+
+type GetProductRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetProductRequest) Reset() {
+	*x = GetProductRequest{}
+	mi := &file_shop_v1_product_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetProductRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetProductRequest) ProtoMessage() {}
+
+func (x *GetProductRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_shop_v1_product_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetProductRequest.ProtoReflect.Descriptor instead.
+func (*GetProductRequest) Descriptor() ([]byte, []int) {
+	return file_shop_v1_product_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *GetProductRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type GetCategorySectionsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Sections      []*CategorySection     `protobuf:"bytes,1,rep,name=sections,proto3" json:"sections,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetCategorySectionsResponse) Reset() {
+	*x = GetCategorySectionsResponse{}
+	mi := &file_shop_v1_product_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCategorySectionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCategorySectionsResponse) ProtoMessage() {}
+
+func (x *GetCategorySectionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_shop_v1_product_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCategorySectionsResponse.ProtoReflect.Descriptor instead.
+func (*GetCategorySectionsResponse) Descriptor() ([]byte, []int) {
+	return file_shop_v1_product_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *GetCategorySectionsResponse) GetSections() []*CategorySection {
+	if x != nil {
+		return x.Sections
+	}
+	return nil
+}
+
+var File_shop_v1_product_proto protoreflect.FileDescriptor
+
+const file_shop_v1_product_proto_rawDesc = "" +
+	"\n" +
+	"\x15shop/v1/product.proto\x12\ashop.v1\x1a\x1bgoogle/protobuf/empty.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\xe2\x02\n" +
+	"\aProduct\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12 \n" +
+	"\vdescription\x18\x03 \x01(\tR\vdescription\x12\x14\n" +
+	"\x05price\x18\x04 \x01(\tR\x05price\x12\x16\n" +
+	"\x06rating\x18\x05 \x01(\tR\x06rating\x12\x14\n" +
+	"\x05count\x18\x06 \x01(\x05R\x05count\x12\x1b\n" +
+	"\timage_url\x18\a \x01(\tR\bimageUrl\x12:\n" +
+	"\rsubcategories\x18\b \x03(\v2\x14.shop.v1.SubcategoryR\rsubcategories\x129\n" +
+	"\n" +
+	"created_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"h\n" +
+	"\x0fCategorySection\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x121\n" +
+	"\n" +
+	"categories\x18\x03 \x03(\v2\x11.shop.v1.CategoryR\n" +
+	"categories\"j\n" +
+	"\bCategory\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12:\n" +
+	"\rsubcategories\x18\x03 \x03(\v2\x14.shop.v1.SubcategoryR\rsubcategories\"g\n" +
+	"\vSubcategory\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12$\n" +
+	"\vcategory_id\x18\x03 \x01(\tH\x00R\n" +
+	"categoryId\x88\x01\x01B\x0e\n" +
+	"\f_category_id\"}\n" +
+	"\x12GetProductsRequest\x12*\n" +
+	"\x0esubcategory_id\x18\x01 \x01(\tH\x00R\rsubcategoryId\x88\x01\x01\x12\x12\n" +
+	"\x04page\x18\x02 \x01(\x05R\x04page\x12\x14\n" +
+	"\x05limit\x18\x03 \x01(\x05R\x05limitB\x11\n" +
+	"\x0f_subcategory_id\"C\n" +
+	"\x13GetProductsResponse\x12,\n" +
+	"\bproducts\x18\x01 \x03(\v2\x10.shop.v1.ProductR\bproducts\"#\n" +
+	"\x11GetProductRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"S\n" +
+	"\x1bGetCategorySectionsResponse\x124\n" +
+	"\bsections\x18\x01 \x03(\v2\x18.shop.v1.CategorySectionR\bsections2\xeb\x01\n" +
+	"\x0eProductService\x12H\n" +
+	"\vGetProducts\x12\x1b.shop.v1.GetProductsRequest\x1a\x1c.shop.v1.GetProductsResponse\x12:\n" +
+	"\n" +
+	"GetProduct\x12\x1a.shop.v1.GetProductRequest\x1a\x10.shop.v1.Product\x12S\n" +
+	"\x13GetCategorySections\x12\x16.google.protobuf.Empty\x1a$.shop.v1.GetCategorySectionsResponseB\"Z shop-api-go/proto/shop/v1;shopv1b\x06proto3"
+
+var (
+	file_shop_v1_product_proto_rawDescOnce sync.Once
+	file_shop_v1_product_proto_rawDescData []byte
+)
+
+func file_shop_v1_product_proto_rawDescGZIP() []byte {
+	file_shop_v1_product_proto_rawDescOnce.Do(func() {
+		file_shop_v1_product_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_shop_v1_product_proto_rawDesc), len(file_shop_v1_product_proto_rawDesc)))
+	})
+	return file_shop_v1_product_proto_rawDescData
+}
+
+var file_shop_v1_product_proto_msgTypes = make([]protoimpl.MessageInfo, 8)
+var file_shop_v1_product_proto_goTypes = []any{
+	(*Product)(nil),                     // 0: shop.v1.Product
+	(*CategorySection)(nil),             // 1: shop.v1.CategorySection
+	(*Category)(nil),                    // 2: shop.v1.Category
+	(*Subcategory)(nil),                 // 3: shop.v1.Subcategory
+	(*GetProductsRequest)(nil),          // 4: shop.v1.GetProductsRequest
+	(*GetProductsResponse)(nil),         // 5: shop.v1.GetProductsResponse
+	(*GetProductRequest)(nil),           // 6: shop.v1.GetProductRequest
+	(*GetCategorySectionsResponse)(nil), // 7: shop.v1.GetCategorySectionsResponse
+	(*timestamppb.Timestamp)(nil),       // 8: google.protobuf.Timestamp
+	(*emptypb.Empty)(nil),               // 9: google.protobuf.Empty
+}
+var file_shop_v1_product_proto_depIdxs = []int32{
+	3,  // 0: shop.v1.Product.subcategories:type_name -> shop.v1.Subcategory
+	8,  // 1: shop.v1.Product.created_at:type_name -> google.protobuf.Timestamp
+	8,  // 2: shop.v1.Product.updated_at:type_name -> google.protobuf.Timestamp
+	2,  // 3: shop.v1.CategorySection.categories:type_name -> shop.v1.Category
+	3,  // 4: shop.v1.Category.subcategories:type_name -> shop.v1.Subcategory
+	0,  // 5: shop.v1.GetProductsResponse.products:type_name -> shop.v1.Product
+	1,  // 6: shop.v1.GetCategorySectionsResponse.sections:type_name -> shop.v1.CategorySection
+	4,  // 7: shop.v1.ProductService.GetProducts:input_type -> shop.v1.GetProductsRequest
+	6,  // 8: shop.v1.ProductService.GetProduct:input_type -> shop.v1.GetProductRequest
+	9,  // 9: shop.v1.ProductService.GetCategorySections:input_type -> google.protobuf.Empty
+	5,  // 10: shop.v1.ProductService.GetProducts:output_type -> shop.v1.GetProductsResponse
+	0,  // 11: shop.v1.ProductService.GetProduct:output_type -> shop.v1.Product
+	7,  // 12: shop.v1.ProductService.GetCategorySections:output_type -> shop.v1.GetCategorySectionsResponse
+	10, // [10:13] is the sub-list for method output_type
+	7,  // [7:10] is the sub-list for method input_type
+	7,  // [7:7] is the sub-list for extension type_name
+	7,  // [7:7] is the sub-list for extension extendee
+	0,  // [0:7] is the sub-list for field type_name
+}
+
+func init() { file_shop_v1_product_proto_init() }
+func file_shop_v1_product_proto_init() {
+	if File_shop_v1_product_proto != nil {
+		return
+	}
+	file_shop_v1_product_proto_msgTypes[3].OneofWrappers = []any{}
+	file_shop_v1_product_proto_msgTypes[4].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_shop_v1_product_proto_rawDesc), len(file_shop_v1_product_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   8,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_shop_v1_product_proto_goTypes,
+		DependencyIndexes: file_shop_v1_product_proto_depIdxs,
+		MessageInfos:      file_shop_v1_product_proto_msgTypes,
+	}.Build()
+	File_shop_v1_product_proto = out.File
+	file_shop_v1_product_proto_goTypes = nil
+	file_shop_v1_product_proto_depIdxs = nil
+}